@@ -0,0 +1,275 @@
+// Package aof는 Redis AOF(Append Only File) 영속성을 제공합니다.
+//
+// AOF는 데이터를 변경한 명령어를 클라이언트가 보낸 것과 같은 RESP 배열 형식으로
+// 파일 끝에 계속 덧붙인 로그입니다. 서버를 다시 시작할 때 파일의 명령어를
+// 처음부터 다시 실행하면 종료 직전의 데이터셋이 복원됩니다.
+//
+//	*3\r\n$3\r\nSET\r\n$3\r\nkey\r\n$5\r\nvalue\r\n
+//	*2\r\n$4\r\nLPOP\r\n$4\r\nlist\r\n
+//
+// 디스크 동기화(fsync) 정책 (appendfsync):
+//   - always: 명령어를 기록할 때마다 동기화 (가장 안전하고 가장 느림)
+//   - everysec: 1초마다 백그라운드에서 동기화 (최대 1초의 데이터 손실)
+//   - no: 동기화를 운영체제에 맡김
+//
+// aof-use-rdb-preamble이 켜져 있으면 재작성(BGREWRITEAOF)한 파일은 데이터셋을 담은
+// RDB 스냅샷으로 시작하고, 그 뒤에 명령어가 이어집니다. Replay는 두 형식을 모두 읽습니다.
+package aof
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/rdb"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// 디스크 동기화 정책 (appendfsync 설정값)
+const (
+	FsyncAlways   = "always"
+	FsyncEverySec = "everysec"
+	FsyncNo       = "no"
+)
+
+// rdbMagic은 RDB 프리앰블로 시작하는 AOF의 첫 바이트들입니다 (RDB 파일의 매직 문자열).
+const rdbMagic = "REDIS"
+
+// ErrTruncated는 AOF가 명령어 중간에서 끝난 경우의 에러입니다.
+// 기록 도중 서버가 종료되면 마지막 명령어가 잘릴 수 있으며,
+// Replay는 그 앞까지의 명령어를 모두 실행한 뒤 이 에러와 일치하는 *TruncatedError를 반환합니다.
+var ErrTruncated = errors.New("aof: unexpected end of file")
+
+// TruncatedError는 잘린 명령어의 위치를 담은 ErrTruncated입니다.
+// 이어서 기록하기 전에 파일을 Offset 길이로 잘라내야 다음 재실행이 실패하지 않습니다.
+type TruncatedError struct {
+	Offset int64 // 마지막으로 완전한 명령어가 끝나는 위치 (바이트)
+}
+
+// Error는 error 인터페이스를 구현합니다.
+func (e *TruncatedError) Error() string {
+	return fmt.Sprintf("%v (last complete command ends at offset %d)", ErrTruncated, e.Offset)
+}
+
+// Is는 errors.Is(err, ErrTruncated)가 true가 되도록 합니다.
+func (e *TruncatedError) Is(target error) bool {
+	return target == ErrTruncated
+}
+
+// Writer는 AOF에 명령어를 덧붙이는 기록기입니다.
+// 여러 고루틴에서 동시에 Append를 호출해도 안전합니다.
+type Writer struct {
+	mu     sync.Mutex
+	path   string
+	file   *os.File
+	policy string
+	dirty  bool // 마지막 동기화 이후 기록한 내용이 있는지 여부 (everysec)
+
+	rdbPreamble bool // 재작성할 때 데이터셋을 RDB 형식으로 기록할지 여부 (aof-use-rdb-preamble)
+
+	rewriting  bool   // 재작성(Rewrite)이 진행 중인지 여부
+	rewriteBuf []byte // 재작성 중에 기록된 명령어 (새 파일 끝에 덧붙임)
+
+	stop chan struct{} // everysec 동기화 고루틴 종료 신호
+	done chan struct{} // everysec 동기화 고루틴이 끝나면 닫힘
+}
+
+// Open은 AOF 파일을 덧붙이기 모드로 엽니다 (없으면 생성).
+//
+// 매개변수:
+//   - path: AOF 파일 경로
+//   - policy: 디스크 동기화 정책 (FsyncAlways, FsyncEverySec, FsyncNo)
+//
+// 에러 케이스:
+//   - 알 수 없는 동기화 정책
+//   - 파일을 열 수 없는 경우
+func Open(path, policy string) (*Writer, error) {
+	switch policy {
+	case FsyncAlways, FsyncEverySec, FsyncNo:
+	default:
+		return nil, fmt.Errorf("aof: invalid appendfsync policy %q", policy)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Writer{path: path, file: file, policy: policy}
+	if policy == FsyncEverySec {
+		w.stop = make(chan struct{})
+		w.done = make(chan struct{})
+		go w.syncEverySecond()
+	}
+	return w, nil
+}
+
+// Append는 명령어들을 RESP 배열로 인코딩해 한 번의 쓰기로 파일 끝에 덧붙입니다.
+// 트랜잭션(MULTI ... EXEC)처럼 함께 기록되어야 하는 명령어들은 한 번에 전달합니다.
+//
+// 매개변수:
+//   - commands: 명령어 이름을 포함한 인자 목록들 (예: ["SET", "key", "value"])
+func (w *Writer) Append(commands ...[]string) error {
+	buf := make([]byte, 0, 64)
+	for _, args := range commands {
+		buf = protocol.AppendCommand(buf, args)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := w.file.Write(buf); err != nil {
+		return err
+	}
+	if w.rewriting {
+		w.rewriteBuf = append(w.rewriteBuf, buf...)
+	}
+	switch w.policy {
+	case FsyncAlways:
+		return w.file.Sync()
+	case FsyncEverySec:
+		w.dirty = true
+	}
+	return nil
+}
+
+// syncEverySecond는 everysec 정책에서 1초마다 기록한 내용을 디스크에 동기화합니다.
+func (w *Writer) syncEverySecond() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			if w.dirty {
+				w.file.Sync()
+				w.dirty = false
+			}
+			w.mu.Unlock()
+		}
+	}
+}
+
+// Close는 남은 내용을 디스크에 동기화하고 파일을 닫습니다.
+func (w *Writer) Close() error {
+	if w.stop != nil {
+		close(w.stop)
+		<-w.done
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.file.Sync(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
+
+// SetRDBPreamble은 재작성할 때 데이터셋을 RDB 형식으로 기록할지 설정합니다 (aof-use-rdb-preamble).
+// 켜면 재작성한 파일은 RDB 스냅샷 뒤에 재작성 중의 명령어가 이어지는 형태가 되어,
+// 명령어로 기록할 때보다 파일이 작고 불러오기가 빠릅니다.
+func (w *Writer) SetRDBPreamble(enabled bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rdbPreamble = enabled
+}
+
+// Replay는 AOF의 명령어들을 순서대로 읽어 exec로 실행합니다.
+// 파일이 RDB 매직("REDIS")으로 시작하면 RDB 프리앰블을 먼저 s에 불러온 뒤 이어지는 명령어를 실행합니다.
+//
+// 매개변수:
+//   - r: AOF 내용
+//   - s: RDB 프리앰블의 키를 넣을 저장소
+//   - exec: 명령어 하나를 실행하는 함수 (args[0]은 명령어 이름)
+//
+// 에러 케이스:
+//   - 명령어 중간에서 파일이 끝난 경우: *TruncatedError (그 앞까지는 실행됨)
+//   - RDB 프리앰블이 잘못되었거나 잘린 경우
+//   - RESP 배열이 아닌 내용이 있는 경우
+//   - exec가 에러를 반환한 경우
+func Replay(r io.Reader, s *store.Store, exec func(args []string) error) error {
+	counter := &countingReader{r: r}
+	reader := bufio.NewReader(counter)
+	parser := protocol.NewParser(reader)
+
+	consumed := func() int64 { return counter.n - int64(reader.Buffered()) }
+
+	if prefix, _ := reader.Peek(len(rdbMagic)); string(prefix) == rdbMagic {
+		if err := rdb.Load(reader, s); err != nil {
+			return fmt.Errorf("aof: loading RDB preamble: %w", err)
+		}
+	}
+
+	for n := 1; ; n++ {
+		start := consumed()
+		value, err := parser.Parse()
+		if err == io.EOF && consumed() == start {
+			// 명령어 경계에서 끝난 경우
+			return nil
+		}
+		if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+			return &TruncatedError{Offset: start}
+		}
+		if err != nil {
+			return fmt.Errorf("aof: bad format at command %d: %w", n, err)
+		}
+
+		arr, ok := value.([]interface{})
+		if !ok || len(arr) == 0 {
+			return fmt.Errorf("aof: bad format at command %d: expected a non-empty array", n)
+		}
+		args := make([]string, len(arr))
+		for i, elem := range arr {
+			s, ok := elem.(string)
+			if !ok {
+				return fmt.Errorf("aof: bad format at command %d: expected bulk strings", n)
+			}
+			args[i] = s
+		}
+
+		if err := exec(args); err != nil {
+			return fmt.Errorf("aof: command %d (%s): %w", n, args[0], err)
+		}
+	}
+}
+
+// countingReader는 지금까지 읽은 바이트 수를 세는 io.Reader입니다.
+// 파일 끝이 명령어 경계인지 명령어 중간인지 구분하는 데 사용합니다.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read는 io.Reader 인터페이스를 구현합니다.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// ReplayFile은 AOF 파일의 명령어들을 실행합니다 (RDB 프리앰블이 있으면 s에 먼저 불러옴).
+// 파일이 없으면 빈 데이터셋으로 시작하도록 아무것도 하지 않고 nil을 반환합니다.
+func ReplayFile(path string, s *store.Store, exec func(args []string) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	return Replay(f, s, exec)
+}
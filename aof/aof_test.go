@@ -0,0 +1,249 @@
+package aof
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestAppendAndReplay는 기록한 명령어를 같은 순서로 다시 읽는지 테스트합니다.
+func TestAppendAndReplay(t *testing.T) {
+	for _, policy := range []string{FsyncAlways, FsyncEverySec, FsyncNo} {
+		path := filepath.Join(t.TempDir(), "appendonly.aof")
+		w, err := Open(path, policy)
+		if err != nil {
+			t.Fatalf("%s: Open failed: %v", policy, err)
+		}
+
+		written := [][]string{
+			{"SET", "key", "value"},
+			{"MULTI"},
+			{"RPUSH", "list", "a", ""},
+			{"EXEC"},
+			{"SET", "bin", "line1\r\nline2\x00\xff"},
+		}
+		if err := w.Append(written[0]); err != nil {
+			t.Fatalf("%s: Append failed: %v", policy, err)
+		}
+		if err := w.Append(written[1:4]...); err != nil {
+			t.Fatalf("%s: Append failed: %v", policy, err)
+		}
+		w.Append(written[4])
+		if err := w.Close(); err != nil {
+			t.Fatalf("%s: Close failed: %v", policy, err)
+		}
+
+		var replayed [][]string
+		err = ReplayFile(path, store.NewStore(), func(args []string) error {
+			replayed = append(replayed, args)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: ReplayFile failed: %v", policy, err)
+		}
+		if !reflect.DeepEqual(replayed, written) {
+			t.Errorf("%s: Expected %q, got %q", policy, written, replayed)
+		}
+	}
+
+	if _, err := Open(filepath.Join(t.TempDir(), "x.aof"), "sometimes"); err == nil {
+		t.Error("Expected error for invalid fsync policy")
+	}
+}
+
+// TestReplayErrors는 잘린 파일과 잘못된 형식의 파일 처리를 테스트합니다.
+func TestReplayErrors(t *testing.T) {
+	complete := "*2\r\n$3\r\nGET\r\n$1\r\na\r\n"
+	noop := func(args []string) error { return nil }
+
+	// 테스트 케이스 1: 명령어 중간에서 끝나면 TruncatedError와 마지막 완전한 명령어의 위치
+	for _, tail := range []string{"*", "*2\r\n", "*2\r\n$3\r\nGE", "*2\r\n$3\r\nGET\r\n$1"} {
+		count := 0
+		err := Replay(strings.NewReader(complete+tail), store.NewStore(), func(args []string) error {
+			count++
+			return nil
+		})
+		var truncated *TruncatedError
+		if !errors.As(err, &truncated) || !errors.Is(err, ErrTruncated) {
+			t.Errorf("tail %q: expected TruncatedError, got %v", tail, err)
+			continue
+		}
+		if truncated.Offset != int64(len(complete)) || count != 1 {
+			t.Errorf("tail %q: expected offset %d after 1 command, got %d after %d", tail, len(complete), truncated.Offset, count)
+		}
+	}
+
+	// 테스트 케이스 2: 배열이 아닌 내용
+	if err := Replay(strings.NewReader("+OK\r\n"), store.NewStore(), noop); err == nil || errors.Is(err, ErrTruncated) {
+		t.Errorf("Expected format error, got %v", err)
+	}
+
+	// 테스트 케이스 3: exec의 에러는 그대로 전달
+	failure := errors.New("boom")
+	err := Replay(strings.NewReader(complete), store.NewStore(), func(args []string) error { return failure })
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected exec error, got %v", err)
+	}
+
+	// 테스트 케이스 4: 파일이 없으면 nil
+	if err := ReplayFile(filepath.Join(t.TempDir(), "missing.aof"), store.NewStore(), noop); err != nil {
+		t.Errorf("Expected nil for missing file, got %v", err)
+	}
+}
+
+// TestOpenAppends는 기존 AOF에 이어서 기록하는지 테스트합니다.
+func TestOpenAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "appendonly.aof")
+	for i := 0; i < 2; i++ {
+		w, err := Open(path, FsyncNo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Append([]string{"PING"})
+		w.Close()
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "*1\r\n$4\r\nPING\r\n*1\r\n$4\r\nPING\r\n" {
+		t.Errorf("Unexpected file content %q", data)
+	}
+}
+
+// TestRewrite는 재작성한 AOF가 데이터셋과 재작성 중의 명령어를 모두 담는지 테스트합니다.
+func TestRewrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "appendonly.aof")
+	w, err := Open(path, FsyncNo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// 재작성으로 사라질 이전 기록
+	w.Append([]string{"SET", "key", "old"}, []string{"SET", "key", "value"})
+
+	list := make([]string, 100)
+	for i := range list {
+		list[i] = strconv.Itoa(i)
+	}
+	expireAt := time.UnixMilli(time.Now().Add(time.Hour).UnixMilli())
+	entries := []store.Entry{
+		{Key: "key", Value: []byte("value")},
+		{Key: "list", Value: list},
+		{Key: "temp", Value: []byte("soon"), ExpireAt: expireAt},
+		{Key: "zset", Value: []store.ScoredMember{{Member: "a", Score: 1.5}, {Member: "b", Score: math.Inf(1)}}},
+	}
+
+	done, err := w.Rewrite(func() []store.Entry { return entries })
+	if err != nil {
+		t.Fatalf("Rewrite failed: %v", err)
+	}
+	// 재작성 중에 들어온 명령어
+	w.Append([]string{"SET", "during", "rewrite"})
+	if err := <-done; err != nil {
+		t.Fatalf("Rewrite failed: %v", err)
+	}
+	// 교체된 파일에 이어서 기록
+	w.Append([]string{"SET", "after", "rewrite"})
+
+	var replayed [][]string
+	ReplayFile(path, store.NewStore(), func(args []string) error {
+		replayed = append(replayed, args)
+		return nil
+	})
+
+	expected := [][]string{
+		{"SET", "key", "value"},
+		append([]string{"RPUSH", "list"}, list[:64]...),
+		append([]string{"RPUSH", "list"}, list[64:]...),
+		{"SET", "temp", "soon", "PXAT", strconv.FormatInt(expireAt.UnixMilli(), 10)},
+		{"ZADD", "zset", "1.5", "a", "+Inf", "b"},
+		{"SET", "during", "rewrite"},
+		{"SET", "after", "rewrite"},
+	}
+	if !reflect.DeepEqual(replayed, expected) {
+		t.Errorf("Expected %q, got %q", expected, replayed)
+	}
+
+	// 테스트 케이스 2: 진행 중에는 거부
+	w.mu.Lock()
+	w.rewriting = true
+	w.mu.Unlock()
+	if _, err := w.Rewrite(nil); !errors.Is(err, ErrRewriteInProgress) {
+		t.Errorf("Expected ErrRewriteInProgress, got %v", err)
+	}
+	w.mu.Lock()
+	w.rewriting = false
+	w.mu.Unlock()
+}
+
+// TestRewriteRDBPreamble은 RDB 프리앰블로 재작성한 AOF를 다시 불러올 수 있는지 테스트합니다.
+func TestRewriteRDBPreamble(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "appendonly.aof")
+	w, err := Open(path, FsyncNo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	w.SetRDBPreamble(true)
+
+	src := store.NewStore()
+	src.SET("key", []byte("value"), nil)
+	src.RPUSH("list", "a", "b")
+	src.ZADD("zset", 1.5, "one")
+
+	done, err := w.Rewrite(src.Snapshot)
+	if err != nil {
+		t.Fatalf("Rewrite failed: %v", err)
+	}
+	w.Append([]string{"SET", "during", "rewrite"})
+	if err := <-done; err != nil {
+		t.Fatalf("Rewrite failed: %v", err)
+	}
+	w.Append([]string{"RPUSH", "list", "c"})
+
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "REDIS") {
+		t.Fatalf("Expected RDB preamble, got %q", data)
+	}
+
+	// 테스트 케이스 1: 프리앰블은 저장소로, 이어지는 명령어는 exec로
+	dst := store.NewStore()
+	var replayed [][]string
+	err = ReplayFile(path, dst, func(args []string) error {
+		replayed = append(replayed, args)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReplayFile failed: %v", err)
+	}
+	if !reflect.DeepEqual(dst.Snapshot(), src.Snapshot()) {
+		t.Errorf("Expected preamble to restore %v, got %v", src.Snapshot(), dst.Snapshot())
+	}
+	expected := [][]string{{"SET", "during", "rewrite"}, {"RPUSH", "list", "c"}}
+	if !reflect.DeepEqual(replayed, expected) {
+		t.Errorf("Expected %q, got %q", expected, replayed)
+	}
+
+	// 테스트 케이스 2: 손상된 프리앰블은 거부
+	corrupt := bytes.Replace(data, []byte("value"), []byte("vxlue"), 1)
+	if err := Replay(bytes.NewReader(corrupt), store.NewStore(), func([]string) error { return nil }); err == nil {
+		t.Error("Expected error for corrupt RDB preamble")
+	}
+
+	// 테스트 케이스 3: 명령어 부분이 잘린 경우의 위치는 파일 기준
+	truncated := data[:len(data)-3]
+	err = Replay(bytes.NewReader(truncated), store.NewStore(), func([]string) error { return nil })
+	var te *TruncatedError
+	if !errors.As(err, &te) || te.Offset != int64(len(data)-len("*3\r\n$5\r\nRPUSH\r\n$4\r\nlist\r\n$1\r\nc\r\n")) {
+		t.Errorf("Expected truncation at the last command, got %v", err)
+	}
+}
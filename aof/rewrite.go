@@ -0,0 +1,154 @@
+package aof
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/rdb"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// itemsPerCommand는 재작성할 때 명령어 하나에 담는 최대 원소 개수입니다
+// (Redis의 AOF_REWRITE_ITEMS_PER_CMD와 동일). 큰 리스트는 여러 명령어로 나눠 기록합니다.
+const itemsPerCommand = 64
+
+// ErrRewriteInProgress는 재작성이 이미 진행 중일 때 Rewrite를 호출한 경우의 에러입니다.
+var ErrRewriteInProgress = errors.New("aof: rewrite already in progress")
+
+// Rewrite는 키 목록을 최소한의 명령어(또는 RDB 프리앰블)로 기록한 새 AOF를 백그라운드에서 만들고,
+// 완성되면 기존 파일과 원자적으로 교체합니다 (BGREWRITEAOF).
+//
+// 호출한 순간부터 교체될 때까지 Append로 들어온 명령어는 기존 파일에 계속 기록되는 동시에
+// 따로 모아 두었다가 새 파일 끝에 덧붙이므로, 교체 후에도 명령어가 빠지지 않습니다.
+// 따라서 dataset은 Rewrite를 호출하기 직전의 데이터셋을 반환해야 합니다.
+//
+// 매개변수:
+//   - dataset: 재작성할 데이터셋을 반환하는 함수 (store.SnapshotView.Entries, 백그라운드 고루틴에서 호출됨)
+//
+// 반환값:
+//   - <-chan error: 재작성이 끝나면 결과(성공 시 nil)를 한 번 전달하는 채널
+//   - error: 재작성이 이미 진행 중인 경우 ErrRewriteInProgress
+func (w *Writer) Rewrite(dataset func() []store.Entry) (<-chan error, error) {
+	w.mu.Lock()
+	if w.rewriting {
+		w.mu.Unlock()
+		return nil, ErrRewriteInProgress
+	}
+	w.rewriting = true
+	w.rewriteBuf = nil
+	w.mu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		err := w.rewrite(dataset())
+
+		w.mu.Lock()
+		w.rewriting = false
+		w.rewriteBuf = nil
+		w.mu.Unlock()
+
+		done <- err
+	}()
+	return done, nil
+}
+
+// Rewriting은 재작성이 진행 중인지 확인합니다.
+func (w *Writer) Rewriting() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rewriting
+}
+
+// rewrite는 임시 파일에 데이터셋과 모아 둔 명령어를 기록한 뒤 기존 파일과 교체합니다.
+func (w *Writer) rewrite(entries []store.Entry) error {
+	tmp, err := os.CreateTemp(filepath.Dir(w.path), "temp-rewriteaof-*.aof")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // 교체한 뒤에는 아무 일도 하지 않음
+
+	w.mu.Lock()
+	preamble := w.rdbPreamble
+	w.mu.Unlock()
+
+	write := WriteCommands
+	if preamble {
+		write = rdb.Write
+	}
+	if err := write(tmp, entries); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	// 데이터셋을 기록하는 동안 들어온 명령어를 덧붙이고, 교체가 끝날 때까지 Append를 막음
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := tmp.Write(w.rewriteBuf); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := os.Rename(tmp.Name(), w.path); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	// 이후의 명령어는 새 파일 끝에 기록 (파일 위치가 이미 끝에 있음)
+	w.file.Close()
+	w.file = tmp
+	w.dirty = false
+	return nil
+}
+
+// WriteCommands는 키 목록을 다시 실행하면 같은 데이터셋이 되는 명령어들로 기록합니다.
+//   - 문자열: SET key value (만료 시각이 있으면 PXAT 포함)
+//   - 리스트: RPUSH key element ... (itemsPerCommand개씩 나눔)
+//   - 정렬된 집합: ZADD key score member ... (itemsPerCommand개씩 나눔)
+func WriteCommands(out io.Writer, entries []store.Entry) error {
+	bw := bufio.NewWriter(out)
+	var buf []byte
+
+	for _, entry := range entries {
+		buf = buf[:0]
+		switch v := entry.Value.(type) {
+		case []byte:
+			args := []string{"SET", entry.Key, string(v)}
+			if !entry.ExpireAt.IsZero() {
+				args = append(args, "PXAT", strconv.FormatInt(entry.ExpireAt.UnixMilli(), 10))
+			}
+			buf = protocol.AppendCommand(buf, args)
+		case []string:
+			for start := 0; start < len(v); start += itemsPerCommand {
+				end := min(start+itemsPerCommand, len(v))
+				args := append([]string{"RPUSH", entry.Key}, v[start:end]...)
+				buf = protocol.AppendCommand(buf, args)
+			}
+		case []store.ScoredMember:
+			for start := 0; start < len(v); start += itemsPerCommand {
+				end := min(start+itemsPerCommand, len(v))
+				args := []string{"ZADD", entry.Key}
+				for _, m := range v[start:end] {
+					args = append(args, strconv.FormatFloat(m.Score, 'g', 17, 64), m.Member)
+				}
+				buf = protocol.AppendCommand(buf, args)
+			}
+		default:
+			return fmt.Errorf("aof: unsupported value type %T for key %q", entry.Value, entry.Key)
+		}
+
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
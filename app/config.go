@@ -0,0 +1,322 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/aof"
+	"github.com/codecrafters-io/redis-starter-go/handler"
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/server"
+)
+
+// loadConfig는 설정 파일, 환경 변수, 명령줄 인자를 파싱해 서버 설정을 만듭니다.
+// 첫 번째 인자가 "-"로 시작하지 않으면 redis.conf 형식의 설정 파일 경로입니다
+// (redis-server /etc/redis.conf --port 7000).
+//
+// 같은 설정을 여러 곳에서 지정하면 명령줄 > 환경 변수 > 설정 파일 > 기본값(server.DefaultConfig) 순으로 우선합니다.
+// 환경 변수 이름은 설정 이름 앞에 REDIS_를 붙이고 대문자와 _로 바꾼 것입니다 (envName).
+//
+// 매개변수:
+//   - args: 프로그램 이름을 제외한 명령줄 인자 (os.Args[1:])
+//   - lookupEnv: 환경 변수를 읽는 함수 (os.LookupEnv)
+//   - output: 사용법, 파싱 에러, 설정 파일의 경고를 출력할 곳
+//
+// 에러 케이스:
+//   - 설정 파일을 읽을 수 없거나 형식이 잘못된 경우
+//   - 알 수 없는 설정이나 잘못된 값이 있는 경우 (-h, --help이면 flag.ErrHelp)
+func loadConfig(args []string, lookupEnv func(string) (string, bool), output io.Writer) (server.Config, error) {
+	cfg := server.DefaultConfig()
+	var directives []handler.ConfigDirective
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return cfg, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("read config file: %w", err)
+		}
+		if directives, err = handler.ParseConfigFile(string(data)); err != nil {
+			return cfg, fmt.Errorf("config file %s: %w", args[0], err)
+		}
+		// CONFIG REWRITE가 현재 설정을 이 파일에 기록
+		cfg.ConfigFile = path
+		args = args[1:]
+	}
+
+	fs := flag.NewFlagSet("redis-server", flag.ContinueOnError)
+	fs.SetOutput(output)
+
+	// --bind "127.0.0.1 ::1"처럼 나열한 주소들의 --port에서 연결을 받음 ("-"를 붙인 주소는 사용할 수 없어도 무시)
+	bind := fs.String("bind", strings.Join(cfg.Bind, " "), "space-separated addresses to listen on; * is every IPv4 address, ::* every IPv6 address, and a leading - makes an address optional")
+	port := fs.Int("port", cfg.Port, "port to listen on")
+	// --replicaof "host port"이면 해당 마스터의 레플리카로 시작
+	replicaof := fs.String("replicaof", "", "start as a replica of the given master (\"<host> <port>\")")
+	// --dir와 --dbfilename이 가리키는 RDB 파일을 시작 시 불러옴
+	dir := fs.String("dir", cfg.Dir, "directory where the RDB file is stored")
+	dbfilename := fs.String("dbfilename", cfg.DBFilename, "name of the RDB file")
+	// --save "3600 1 300 100"처럼 지정하면 조건을 만족할 때 자동으로 BGSAVE
+	save := fs.String("save", "", "automatic BGSAVE rules as <seconds> <changes> pairs (empty disables)")
+	// --appendonly yes이면 RDB 대신 AOF로 데이터셋을 복원하고, 이후의 쓰기 명령어를 AOF에 기록
+	appendonly := fs.String("appendonly", "no", "enable append-only file persistence (yes/no)")
+	appendfilename := fs.String("appendfilename", cfg.AppendFilename, "name of the append-only file")
+	appendfsync := fs.String("appendfsync", aof.FsyncEverySec, "AOF fsync policy (always/everysec/no)")
+	// --aof-use-rdb-preamble yes이면 BGREWRITEAOF가 데이터셋을 RDB 형식으로 기록
+	aofUseRDBPreamble := fs.String("aof-use-rdb-preamble", "yes", "write the dataset as an RDB preamble when rewriting the AOF (yes/no)")
+	// --replica-read-only yes이면 레플리카가 마스터가 보낸 것 외의 쓰기 명령어를 거부
+	replicaReadOnly := fs.String("replica-read-only", "yes", "reject write commands from clients when running as a replica (yes/no)")
+	// --repl-diskless-sync yes이면 전체 동기화의 RDB를 임시 파일 없이 레플리카에 바로 보냄
+	replDisklessSync := fs.String("repl-diskless-sync", "yes", "send the RDB to replicas without a temporary file during full sync (yes/no)")
+	// --cluster-enabled yes이면 클러스터 모드로 동작 (CLUSTER 명령어, 해시 슬롯)
+	clusterEnabled := fs.String("cluster-enabled", "no", "run as a cluster node (yes/no)")
+	// --requirepass가 있으면 연결은 AUTH로 이 비밀번호를 보낸 뒤에 명령어를 실행할 수 있음
+	requirepass := fs.String("requirepass", "", "password clients must send with AUTH before running commands (empty disables)")
+	// --latency-monitor-threshold 이상 걸린 작업을 LATENCY로 조회할 수 있게 기록 (밀리초, 0이면 끔)
+	latencyMonitorThreshold := fs.Int("latency-monitor-threshold", 0, "record operations slower than this many milliseconds for LATENCY (0 disables)")
+	// --proto-max-bulk-len보다 긴 인자는 읽기 전에 프로토콜 에러로 거부 (바이트, 기본 512MB)
+	protoMaxBulkLen := fs.Int64("proto-max-bulk-len", protocol.DefaultMaxBulkLen, "maximum size in bytes of a single bulk string argument in a request")
+	// --maxclients보다 많은 클라이언트가 동시에 연결하면 에러 응답을 보내고 연결을 닫음
+	maxclients := fs.Int("maxclients", handler.DefaultMaxClients, "maximum number of simultaneously connected clients")
+	// --maxmemory "100mb"처럼 데이터셋이 사용할 메모리의 한도를 지정 (0이면 제한 없음)
+	maxmemory := fs.String("maxmemory", "0", "memory limit for the dataset, e.g. 100mb or 1gb (0 disables)")
+	// --maxmemory-policy allkeys-lru이면 한도를 넘을 때 오래 사용하지 않은 키부터 지움 (noeviction이면 쓰기 명령어를 거부)
+	maxmemoryPolicy := fs.String("maxmemory-policy", cfg.MaxMemoryPolicy, "what to do when maxmemory is reached: noeviction, allkeys-lru, volatile-lru, allkeys-lfu, volatile-lfu, volatile-ttl, allkeys-random or volatile-random")
+	maxmemorySamples := fs.Int("maxmemory-samples", cfg.MaxMemorySamples, "number of keys sampled when choosing a key to evict")
+	// LFU 정책의 빈도 카운터: --lfu-log-factor가 클수록 천천히 늘고, --lfu-decay-time분 동안 사용하지 않을 때마다 1 줄어듦
+	lfuLogFactor := fs.Int("lfu-log-factor", cfg.LFULogFactor, "how slowly the LFU access counter grows (0 counts every access)")
+	lfuDecayTime := fs.Int("lfu-decay-time", int(cfg.LFUDecayTime/time.Minute), "minutes of inactivity after which the LFU access counter is decremented (0 disables decay)")
+	// --timeout초 넘게 명령어를 보내지 않은 연결은 끊음 (0이면 끊지 않음, 대기 중이거나 구독 중인 연결 제외)
+	timeout := fs.Int("timeout", 0, "close the connection after a client is idle for this many seconds (0 disables)")
+	// --tcp-keepalive초 주기로 받은 연결에 TCP keepalive를 보냄 (0이면 끔)
+	tcpKeepAlive := fs.Int("tcp-keepalive", int(handler.DefaultTCPKeepAlive/time.Second), "TCP keepalive period in seconds for accepted connections (0 disables)")
+	// --proxy-protocol yes이면 로드 밸런서가 연결 앞에 보내는 PROXY 헤더에서 원래 클라이언트 주소를 읽음
+	proxyProtocol := fs.String("proxy-protocol", "no", "expect a HAProxy PROXY v1/v2 header on every connection and report the client address it carries (yes/no)")
+	// --loglevel 이상의 로그만 --logfile에 기록 (debug, verbose, notice, warning, nothing, 파일이 비어 있으면 표준 출력)
+	loglevel := fs.String("loglevel", cfg.LogLevel, "log verbosity: debug, verbose, notice, warning or nothing")
+	logfile := fs.String("logfile", cfg.LogFile, "append the log to this file instead of standard output")
+	// --pprof-port를 지정하면 127.0.0.1의 이 포트에서 /debug/pprof/의 프로파일을 제공 (0이면 끔)
+	pprofPort := fs.Int("pprof-port", cfg.PprofPort, "serve net/http/pprof profiles on this localhost-only port (0 disables)")
+	// --shutdown-timeout 동안 SIGTERM/SIGINT를 받은 뒤 실행 중인 명령어(대기 중인 BLPOP 등)가 끝나기를 기다림 (초)
+	shutdownTimeout := fs.Int("shutdown-timeout", int(cfg.ShutdownTimeout/time.Second), "seconds to wait for in-flight and blocked commands when shutting down")
+	// --rename-command "CONFIG MYCONFIG"처럼 명령어 이름을 바꾸거나, `--rename-command 'FLUSHALL ""'`처럼 끔 (여러 번 지정 가능)
+	var renameCommands stringList
+	fs.Var(&renameCommands, "rename-command", "rename a command as \"<command> <new-name>\"; an empty new name disables it (repeatable)")
+
+	fs.Usage = func() {
+		fmt.Fprintln(output, "Usage: redis-server [/path/to/redis.conf] [--option value ...]")
+		fmt.Fprintln(output, "Options can also be set with REDIS_<OPTION> environment variables (e.g. REDIS_PORT, REDIS_PASSWORD).")
+		fmt.Fprintln(output, "Precedence: command line > environment > config file > defaults.")
+		fs.PrintDefaults()
+	}
+
+	// 설정 파일, 환경 변수 순으로 값을 넣은 뒤 명령줄 인자를 파싱하므로, 나중에 넣은 값이 덮어씀
+	if err := applyConfigFile(fs, directives, output); err != nil {
+		return cfg, fmt.Errorf("config file %s: %w", cfg.ConfigFile, err)
+	}
+	if err := applyEnv(fs, lookupEnv); err != nil {
+		return cfg, err
+	}
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	if fs.NArg() > 0 {
+		return cfg, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	saveRules, err := handler.ParseSaveRules(*save)
+	if err != nil {
+		return cfg, fmt.Errorf("invalid --save: %w", err)
+	}
+	maxMemory, err := handler.ParseMemory(*maxmemory)
+	if err != nil {
+		return cfg, fmt.Errorf("invalid --maxmemory: %w", err)
+	}
+	maxMemoryPolicy, err := handler.ParseMaxMemoryPolicy(*maxmemoryPolicy)
+	if err != nil {
+		return cfg, fmt.Errorf("invalid --maxmemory-policy: %w", err)
+	}
+	logLevel, err := handler.ParseLogLevel(*loglevel)
+	if err != nil {
+		return cfg, fmt.Errorf("invalid --loglevel: %w", err)
+	}
+	yesNoOptions := []struct {
+		name  string
+		value string
+		dest  *bool
+	}{
+		{"appendonly", *appendonly, &cfg.AppendOnly},
+		{"aof-use-rdb-preamble", *aofUseRDBPreamble, &cfg.AOFUseRDBPreamble},
+		{"replica-read-only", *replicaReadOnly, &cfg.ReplicaReadOnly},
+		{"repl-diskless-sync", *replDisklessSync, &cfg.ReplDisklessSync},
+		{"cluster-enabled", *clusterEnabled, &cfg.ClusterEnabled},
+		{"proxy-protocol", *proxyProtocol, &cfg.ProxyProtocol},
+	}
+	for _, option := range yesNoOptions {
+		if *option.dest, err = parseYesNo(option.value); err != nil {
+			return cfg, fmt.Errorf("invalid --%s: %w", option.name, err)
+		}
+	}
+	for _, spec := range renameCommands {
+		name, newName, err := handler.ParseRenameCommand(spec)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid --rename-command: %w", err)
+		}
+		cfg.RenameCommands = append(cfg.RenameCommands, [2]string{name, newName})
+	}
+
+	cfg.Bind = strings.Fields(*bind)
+	cfg.Port = *port
+	cfg.Dir = *dir
+	cfg.DBFilename = *dbfilename
+	if len(saveRules) > 0 {
+		cfg.SaveRules = saveRules
+	}
+	cfg.AppendFilename = *appendfilename
+	cfg.AppendFsync = *appendfsync
+	cfg.ReplicaOf = *replicaof
+	cfg.RequirePass = *requirepass
+	cfg.LatencyMonitorThreshold = *latencyMonitorThreshold
+	cfg.ProtoMaxBulkLen = *protoMaxBulkLen
+	cfg.MaxClients = *maxclients
+	cfg.MaxMemory = maxMemory
+	cfg.MaxMemoryPolicy = maxMemoryPolicy
+	cfg.MaxMemorySamples = *maxmemorySamples
+	cfg.LFULogFactor = *lfuLogFactor
+	cfg.LFUDecayTime = time.Duration(*lfuDecayTime) * time.Minute
+	cfg.IdleTimeout = time.Duration(*timeout) * time.Second
+	cfg.TCPKeepAlive = time.Duration(*tcpKeepAlive) * time.Second
+	cfg.ShutdownTimeout = time.Duration(*shutdownTimeout) * time.Second
+	cfg.LogLevel = logLevel
+	cfg.LogFile = *logfile
+	cfg.PprofPort = *pprofPort
+	return cfg, nil
+}
+
+// parseYesNo는 yes/no 설정 값을 불리언으로 변환합니다 (대소문자 구분 없음).
+//
+// 에러 케이스:
+//   - yes나 no가 아닌 값 (true, 1, 빈 문자열 등)
+func parseYesNo(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes":
+		return true, nil
+	case "no":
+		return false, nil
+	}
+	return false, fmt.Errorf("argument must be 'yes' or 'no', got %q", value)
+}
+
+// applyConfigFile은 설정 파일의 지시어들을 같은 이름의 명령줄 설정에 넣습니다.
+//
+// 규칙:
+//   - save는 여러 줄의 조건을 모두 합치며, save ""는 그때까지의 조건을 지움
+//   - rename-command는 줄마다 추가하고, 나머지는 마지막 줄의 값을 사용
+//   - replicaof와 bind는 여러 값을 공백으로 이어 하나의 값으로 사용
+//   - 지원하지 않는 지시어는 경고를 출력하고 무시
+//
+// 에러 케이스:
+//   - 값의 개수나 형식이 잘못된 경우 (줄 번호 포함)
+func applyConfigFile(fs *flag.FlagSet, directives []handler.ConfigDirective, output io.Writer) error {
+	var saveArgs []string
+	for _, d := range directives {
+		if fs.Lookup(d.Name) == nil {
+			fmt.Fprintf(output, "Warning: ignoring unsupported directive '%s' at line %d\n", d.Name, d.Line)
+			continue
+		}
+
+		var value string
+		switch d.Name {
+		case "save":
+			if len(d.Args) == 1 && d.Args[0] == "" {
+				saveArgs = nil
+			} else {
+				saveArgs = append(saveArgs, d.Args...)
+			}
+			value = strings.Join(saveArgs, " ")
+		case "rename-command":
+			quoted := make([]string, len(d.Args))
+			for i, arg := range d.Args {
+				quoted[i] = strconv.Quote(arg)
+			}
+			value = strings.Join(quoted, " ")
+		case "replicaof", "bind":
+			value = strings.Join(d.Args, " ")
+		default:
+			if len(d.Args) != 1 {
+				return fmt.Errorf("line %d: wrong number of arguments for '%s'", d.Line, d.Name)
+			}
+			value = d.Args[0]
+		}
+		if err := fs.Set(d.Name, value); err != nil {
+			return fmt.Errorf("line %d: invalid value for '%s': %v", d.Line, d.Name, err)
+		}
+	}
+	return nil
+}
+
+// envAliases는 설정 이름과 다른 이름으로도 읽는 환경 변수들입니다 (컨테이너 이미지에서 흔히 쓰는 이름).
+var envAliases = map[string]string{
+	"REDIS_PASSWORD": "requirepass",
+}
+
+// envName은 설정에 해당하는 환경 변수 이름을 반환합니다 (maxmemory → REDIS_MAXMEMORY,
+// repl-diskless-sync → REDIS_REPL_DISKLESS_SYNC).
+func envName(name string) string {
+	return "REDIS_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// applyEnv는 환경 변수로 지정한 설정들을 같은 이름의 명령줄 설정에 넣습니다.
+// 별칭(envAliases)을 먼저 넣으므로, 설정 이름의 환경 변수(REDIS_REQUIREPASS)가 있으면 그 값이 우선합니다.
+// 빈 값도 설정으로 취급합니다 (REDIS_SAVE=""는 자동 저장을 끔).
+//
+// 에러 케이스:
+//   - 값이 잘못된 경우 (환경 변수 이름 포함)
+func applyEnv(fs *flag.FlagSet, lookupEnv func(string) (string, bool)) error {
+	aliases := make([]string, 0, len(envAliases))
+	for env := range envAliases {
+		aliases = append(aliases, env)
+	}
+	sort.Strings(aliases)
+	for _, env := range aliases {
+		if value, ok := lookupEnv(env); ok {
+			if err := fs.Set(envAliases[env], value); err != nil {
+				return fmt.Errorf("invalid %s: %v", env, err)
+			}
+		}
+	}
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		env := envName(f.Name)
+		value, ok := lookupEnv(env)
+		if !ok || err != nil {
+			return
+		}
+		if setErr := fs.Set(f.Name, value); setErr != nil {
+			err = fmt.Errorf("invalid %s: %v", env, setErr)
+		}
+	})
+	return err
+}
+
+// stringList는 여러 번 지정할 수 있는 문자열 명령줄 설정입니다 (flag.Value).
+type stringList []string
+
+// String은 flag.Value를 구현합니다.
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+// Set은 flag.Value를 구현합니다. 지정할 때마다 값을 추가합니다.
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/handler"
+	"github.com/codecrafters-io/redis-starter-go/server"
+)
+
+// noEnv는 환경 변수가 하나도 없는 것처럼 동작하는 lookupEnv입니다.
+func noEnv(string) (string, bool) { return "", false }
+
+// mapEnv는 env의 값을 환경 변수로 돌려주는 lookupEnv를 반환합니다.
+func mapEnv(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+// TestLoadConfig는 명령줄 인자를 서버 설정으로 옮기는 것을 테스트합니다.
+func TestLoadConfig(t *testing.T) {
+	// 테스트 케이스 1: 인자가 없으면 기본 설정
+	t.Run("Defaults", func(t *testing.T) {
+		cfg, err := loadConfig(nil, noEnv, io.Discard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := server.DefaultConfig(); !reflect.DeepEqual(cfg, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, cfg)
+		}
+	})
+
+	// 테스트 케이스 2: 지정한 설정이 Config에 반영됨
+	t.Run("Flags", func(t *testing.T) {
+		cfg, err := loadConfig([]string{
+			"--bind", "127.0.0.1", "--port", "7000",
+			"--dir", "/data", "--appendonly", "yes", "--requirepass", "secret",
+			"--maxmemory", "100mb", "--maxmemory-policy", "allkeys-LRU", "--save", "60 10", "--timeout", "30",
+			"--lfu-log-factor", "0", "--lfu-decay-time", "5", "--loglevel", "WARNING", "--logfile", "/data/redis.log",
+			"--pprof-port", "6060",
+			"--rename-command", "CONFIG MYCONFIG", "--rename-command", `FLUSHALL ""`,
+		}, noEnv, io.Discard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(cfg.Bind, []string{"127.0.0.1"}) || cfg.Port != 7000 {
+			t.Errorf("Expected 127.0.0.1 port 7000, got %v port %d", cfg.Bind, cfg.Port)
+		}
+		if cfg.Dir != "/data" || !cfg.AppendOnly || cfg.RequirePass != "secret" {
+			t.Errorf("Unexpected config: %+v", cfg)
+		}
+		if cfg.MaxMemory != 100<<20 {
+			t.Errorf("Expected maxmemory of 100mb, got %d", cfg.MaxMemory)
+		}
+		if cfg.MaxMemoryPolicy != handler.MaxMemoryPolicyAllKeysLRU {
+			t.Errorf("Expected allkeys-lru, got %q", cfg.MaxMemoryPolicy)
+		}
+		if cfg.LFULogFactor != 0 || cfg.LFUDecayTime != 5*time.Minute {
+			t.Errorf("Expected lfu-log-factor 0 and lfu-decay-time 5m, got %d and %v", cfg.LFULogFactor, cfg.LFUDecayTime)
+		}
+		if cfg.LogLevel != handler.LogLevelWarning || cfg.LogFile != "/data/redis.log" {
+			t.Errorf("Expected loglevel warning and logfile /data/redis.log, got %q and %q", cfg.LogLevel, cfg.LogFile)
+		}
+		if cfg.PprofPort != 6060 {
+			t.Errorf("Expected pprof port 6060, got %d", cfg.PprofPort)
+		}
+		if !reflect.DeepEqual(cfg.SaveRules, []handler.SaveRule{{Seconds: 60, Changes: 10}}) {
+			t.Errorf("Unexpected save rules: %v", cfg.SaveRules)
+		}
+		if cfg.IdleTimeout != 30*time.Second {
+			t.Errorf("Expected 30s timeout, got %v", cfg.IdleTimeout)
+		}
+		if expected := [][2]string{{"CONFIG", "MYCONFIG"}, {"FLUSHALL", ""}}; !reflect.DeepEqual(cfg.RenameCommands, expected) {
+			t.Errorf("Expected %v, got %v", expected, cfg.RenameCommands)
+		}
+	})
+
+	// 테스트 케이스 3: 공백으로 구분한 여러 주소 (IPv6 포함)
+	t.Run("MultipleBind", func(t *testing.T) {
+		cfg, err := loadConfig([]string{"--bind", "127.0.0.1 -::1"}, noEnv, io.Discard)
+		if expected := []string{"127.0.0.1", "-::1"}; err != nil || !reflect.DeepEqual(cfg.Bind, expected) {
+			t.Errorf("Expected %v, got %v, %v", expected, cfg.Bind, err)
+		}
+	})
+
+	// 테스트 케이스 4: 첫 번째 인자의 설정 파일을 불러오고, 명령줄 설정이 우선
+	t.Run("ConfigFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "redis.conf")
+		content := "# 테스트 설정\nport 7000\nrequirepass filepass\nsave 3600 1\nsave 300 100\n" +
+			"bind 127.0.0.1 ::1\nmaxmemory 1gb\nrename-command FLUSHALL \"\"\nloglevel verbose\ndatabases 16\n"
+		os.WriteFile(path, []byte(content), 0644)
+
+		var output strings.Builder
+		cfg, err := loadConfig([]string{path, "--port", "7001", "--rename-command", "CONFIG MYCONFIG"}, noEnv, &output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Port != 7001 {
+			t.Errorf("Expected the command line port to win, got %d", cfg.Port)
+		}
+		if expected := []string{"127.0.0.1", "::1"}; !reflect.DeepEqual(cfg.Bind, expected) {
+			t.Errorf("Expected %v, got %v", expected, cfg.Bind)
+		}
+		if cfg.RequirePass != "filepass" || cfg.MaxMemory != 1<<30 || cfg.ConfigFile != path {
+			t.Errorf("Unexpected config: %+v", cfg)
+		}
+		if expected := []handler.SaveRule{{Seconds: 3600, Changes: 1}, {Seconds: 300, Changes: 100}}; !reflect.DeepEqual(cfg.SaveRules, expected) {
+			t.Errorf("Expected %v, got %v", expected, cfg.SaveRules)
+		}
+		if expected := [][2]string{{"FLUSHALL", ""}, {"CONFIG", "MYCONFIG"}}; !reflect.DeepEqual(cfg.RenameCommands, expected) {
+			t.Errorf("Expected %v, got %v", expected, cfg.RenameCommands)
+		}
+		if cfg.LogLevel != handler.LogLevelVerbose {
+			t.Errorf("Expected loglevel verbose, got %q", cfg.LogLevel)
+		}
+		if !strings.Contains(output.String(), "'databases' at line 10") {
+			t.Errorf("Expected a warning for the unsupported directive, got %q", output.String())
+		}
+	})
+
+	// 테스트 케이스 5: save ""는 앞의 조건을 지움
+	t.Run("ConfigFileSaveReset", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "redis.conf")
+		os.WriteFile(path, []byte("save 3600 1\nsave \"\"\n"), 0644)
+		cfg, err := loadConfig([]string{path}, noEnv, io.Discard)
+		if err != nil || len(cfg.SaveRules) != 0 {
+			t.Errorf("Expected no save rules, got %v, %v", cfg.SaveRules, err)
+		}
+	})
+
+	// 테스트 케이스 6: 환경 변수는 설정 파일보다 우선하고, 명령줄보다는 나중
+	t.Run("Environment", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "redis.conf")
+		os.WriteFile(path, []byte("port 7000\nmaxclients 10\nrequirepass filepass\nappendonly no\n"), 0644)
+		env := mapEnv(map[string]string{
+			"REDIS_PORT":               "7001",
+			"REDIS_MAXCLIENTS":         "20",
+			"REDIS_PASSWORD":           "envpass",
+			"REDIS_APPENDONLY":         "yes",
+			"REDIS_REPL_DISKLESS_SYNC": "no",
+		})
+
+		cfg, err := loadConfig([]string{path, "--maxclients", "30"}, env, io.Discard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Port != 7001 {
+			t.Errorf("Expected the environment port over the file, got %d", cfg.Port)
+		}
+		if cfg.MaxClients != 30 {
+			t.Errorf("Expected the command line maxclients over the environment, got %d", cfg.MaxClients)
+		}
+		if cfg.RequirePass != "envpass" || !cfg.AppendOnly || cfg.ReplDisklessSync {
+			t.Errorf("Unexpected config: %+v", cfg)
+		}
+
+		// 설정 이름의 환경 변수가 별칭보다 우선
+		env = mapEnv(map[string]string{"REDIS_PASSWORD": "alias", "REDIS_REQUIREPASS": "exact"})
+		if cfg, err := loadConfig(nil, env, io.Discard); err != nil || cfg.RequirePass != "exact" {
+			t.Errorf("Expected exact, got %q, %v", cfg.RequirePass, err)
+		}
+
+		env = mapEnv(map[string]string{"REDIS_MAXCLIENTS": "many"})
+		if _, err := loadConfig(nil, env, io.Discard); err == nil || !strings.Contains(err.Error(), "REDIS_MAXCLIENTS") {
+			t.Errorf("Expected an error naming the variable, got %v", err)
+		}
+	})
+
+	// 테스트 케이스 7: 잘못된 값은 에러
+	t.Run("Invalid", func(t *testing.T) {
+		dir := t.TempDir()
+		badValue := filepath.Join(dir, "bad-value.conf")
+		os.WriteFile(badValue, []byte("port 7000\nmaxclients many\n"), 0644)
+		badArgs := filepath.Join(dir, "bad-args.conf")
+		os.WriteFile(badArgs, []byte("port 7000 7001\n"), 0644)
+
+		for _, args := range [][]string{
+			{"--port", "abc"},
+			{"--maxmemory", "lots"},
+			{"--maxmemory-policy", "lru"},
+			{"--loglevel", "loud"},
+			{"--save", "60"},
+			{"--rename-command", "CONFIG"},
+			{"--appendonly", "true"},
+			{"--cluster-enabled", "1"},
+			{"--proxy-protocol", ""},
+			{"--nosuchflag"},
+			{"--port", "7000", "extra"},
+			{filepath.Join(dir, "missing.conf")},
+			{badValue},
+			{badArgs},
+		} {
+			if _, err := loadConfig(args, noEnv, io.Discard); err == nil {
+				t.Errorf("Expected an error for %v", args)
+			}
+		}
+	})
+
+	// 테스트 케이스 8: yes/no 설정은 대소문자를 구분하지 않고, 다른 값이면 설정 이름을 포함한 에러
+	t.Run("YesNo", func(t *testing.T) {
+		cfg, err := loadConfig([]string{"--appendonly", "YES", "--replica-read-only", "No", "--cluster-enabled", "yes"}, noEnv, io.Discard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cfg.AppendOnly || cfg.ReplicaReadOnly || !cfg.ClusterEnabled {
+			t.Errorf("Unexpected config: %+v", cfg)
+		}
+		for _, name := range []string{"appendonly", "aof-use-rdb-preamble", "replica-read-only", "repl-diskless-sync", "cluster-enabled", "proxy-protocol"} {
+			if _, err := loadConfig([]string{"--" + name, "on"}, noEnv, io.Discard); err == nil || !strings.Contains(err.Error(), name) {
+				t.Errorf("Expected an error naming %s, got %v", name, err)
+			}
+		}
+	})
+}
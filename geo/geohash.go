@@ -0,0 +1,155 @@
+// Package geo는 Redis 호환 geohash 인코딩과 거리 계산을 구현합니다.
+//
+// Redis는 좌표를 52비트 geohash 정수로 인코딩하여 Sorted Set의 점수(score)로 저장합니다.
+// 경도와 위도를 각각 26비트로 양자화한 뒤 비트를 교차(interleave)시키므로,
+// 가까운 위치는 대체로 가까운 점수를 갖게 됩니다.
+//
+// 위도 범위는 Web Mercator(EPSG:3857)의 한계인 ±85.05112878도로 제한됩니다.
+package geo
+
+import (
+	"math"
+)
+
+const (
+	// LatMin, LatMax는 인코딩 가능한 위도의 범위입니다.
+	LatMin = -85.05112878
+	LatMax = 85.05112878
+	// LonMin, LonMax는 인코딩 가능한 경도의 범위입니다.
+	LonMin = -180.0
+	LonMax = 180.0
+
+	// step은 좌표 하나당 사용하는 비트 수입니다 (2 * 26 = 52비트).
+	step = 26
+
+	// earthRadiusMeters는 Redis가 거리 계산에 사용하는 지구 반지름(미터)입니다.
+	earthRadiusMeters = 6372797.560856
+
+	// base32Alphabet은 표준 geohash 문자열에 사용하는 문자 집합입니다.
+	base32Alphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+)
+
+// ValidCoordinates는 경도/위도가 인코딩 가능한 범위 안에 있는지 확인합니다.
+func ValidCoordinates(lon, lat float64) bool {
+	return lon >= LonMin && lon <= LonMax && lat >= LatMin && lat <= LatMax
+}
+
+// Encode는 경도/위도를 52비트 geohash 정수로 인코딩합니다.
+// 결과는 float64로 손실 없이 표현 가능하므로 Sorted Set 점수로 사용할 수 있습니다.
+func Encode(lon, lat float64) uint64 {
+	return encodeRange(lon, lat, LonMin, LonMax, LatMin, LatMax)
+}
+
+// Decode는 52비트 geohash 정수를 해당 셀 중심의 경도/위도로 디코딩합니다.
+func Decode(hash uint64) (lon, lat float64) {
+	separated := deinterleave64(hash)
+	latCell := float64(uint32(separated))
+	lonCell := float64(uint32(separated >> 32))
+	cells := float64(uint64(1) << step)
+
+	latMin := LatMin + (latCell/cells)*(LatMax-LatMin)
+	latMax := LatMin + ((latCell+1)/cells)*(LatMax-LatMin)
+	lonMin := LonMin + (lonCell/cells)*(LonMax-LonMin)
+	lonMax := LonMin + ((lonCell+1)/cells)*(LonMax-LonMin)
+
+	lon = math.Max(LonMin, math.Min(LonMax, (lonMin+lonMax)/2))
+	lat = math.Max(LatMin, math.Min(LatMax, (latMin+latMax)/2))
+	return lon, lat
+}
+
+// HashString은 좌표를 11자리 표준 geohash 문자열로 변환합니다 (GEOHASH 명령어용).
+// Redis 내부 인코딩과 달리 표준 geohash는 위도 범위로 ±90도를 사용합니다.
+func HashString(lon, lat float64) string {
+	hash := encodeRange(lon, lat, LonMin, LonMax, -90, 90)
+
+	buf := make([]byte, 11)
+	for i := 0; i < 11; i++ {
+		// 52비트로는 10자리(50비트)까지만 온전히 표현되므로 마지막 자리는 0으로 채움
+		index := 0
+		if i < 10 {
+			index = int(hash>>(52-uint((i+1)*5))) & 0x1f
+		}
+		buf[i] = base32Alphabet[index]
+	}
+	return string(buf)
+}
+
+// Distance는 두 좌표 사이의 거리를 미터 단위로 계산합니다 (haversine 공식).
+func Distance(lon1, lat1, lon2, lat2 float64) float64 {
+	lat1r := degToRad(lat1)
+	lat2r := degToRad(lat2)
+	u := math.Sin((lat2r - lat1r) / 2)
+	v := math.Sin((degToRad(lon2) - degToRad(lon1)) / 2)
+	return 2.0 * earthRadiusMeters * math.Asin(math.Sqrt(u*u+math.Cos(lat1r)*math.Cos(lat2r)*v*v))
+}
+
+// DistanceInBox는 점이 중심 좌표 기준 width x height(미터) 사각형 안에 있는지 확인합니다.
+// 사각형 안에 있으면 중심과의 거리(미터)와 true를 반환합니다 (GEOSEARCH BYBOX용).
+//
+// 판정 방식 (Redis와 동일):
+//   - 위도 방향: 자오선을 따라 잰 거리가 height/2 이하
+//   - 경도 방향: 점의 위도에서 잰 동서 방향 거리가 width/2 이하
+func DistanceInBox(width, height, centerLon, centerLat, lon, lat float64) (float64, bool) {
+	latDistance := earthRadiusMeters * math.Abs(degToRad(lat)-degToRad(centerLat))
+	if latDistance > height/2 {
+		return 0, false
+	}
+
+	lonDistance := Distance(lon, lat, centerLon, lat)
+	if lonDistance > width/2 {
+		return 0, false
+	}
+
+	return Distance(centerLon, centerLat, lon, lat), true
+}
+
+// encodeRange는 주어진 범위를 기준으로 좌표를 양자화하고 비트를 교차시킵니다.
+// 위도 비트가 짝수 위치, 경도 비트가 홀수 위치에 놓입니다.
+func encodeRange(lon, lat, lonMin, lonMax, latMin, latMax float64) uint64 {
+	cells := float64(uint64(1) << step)
+	latOffset := (lat - latMin) / (latMax - latMin) * cells
+	lonOffset := (lon - lonMin) / (lonMax - lonMin) * cells
+	return interleave64(uint32(latOffset), uint32(lonOffset))
+}
+
+// interleave64는 x의 비트를 짝수 위치에, y의 비트를 홀수 위치에 배치합니다.
+// 참고: https://graphics.stanford.edu/~seander/bithacks.html#InterleaveBMN
+func interleave64(xlo, ylo uint32) uint64 {
+	x := spreadBits(uint64(xlo))
+	y := spreadBits(uint64(ylo))
+	return x | (y << 1)
+}
+
+// deinterleave64는 interleave64의 역연산입니다.
+// 짝수 위치 비트를 하위 32비트에, 홀수 위치 비트를 상위 32비트에 모읍니다.
+func deinterleave64(interleaved uint64) uint64 {
+	x := compactBits(interleaved)
+	y := compactBits(interleaved >> 1)
+	return x | (y << 32)
+}
+
+// spreadBits는 32비트 값의 각 비트 사이에 0 비트를 끼워 넣습니다.
+func spreadBits(v uint64) uint64 {
+	v = (v | (v << 16)) & 0x0000FFFF0000FFFF
+	v = (v | (v << 8)) & 0x00FF00FF00FF00FF
+	v = (v | (v << 4)) & 0x0F0F0F0F0F0F0F0F
+	v = (v | (v << 2)) & 0x3333333333333333
+	v = (v | (v << 1)) & 0x5555555555555555
+	return v
+}
+
+// compactBits는 짝수 위치의 비트만 모아 32비트 값으로 만듭니다.
+func compactBits(v uint64) uint64 {
+	v &= 0x5555555555555555
+	v = (v | (v >> 1)) & 0x3333333333333333
+	v = (v | (v >> 2)) & 0x0F0F0F0F0F0F0F0F
+	v = (v | (v >> 4)) & 0x00FF00FF00FF00FF
+	v = (v | (v >> 8)) & 0x0000FFFF0000FFFF
+	v = (v | (v >> 16)) & 0x00000000FFFFFFFF
+	return v
+}
+
+// degToRad는 도(degree)를 라디안으로 변환합니다.
+func degToRad(deg float64) float64 {
+	return deg * math.Pi / 180.0
+}
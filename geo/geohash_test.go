@@ -0,0 +1,88 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// TestEncodeDecode는 인코딩/디코딩 왕복 시 좌표 오차가 작은지 테스트합니다.
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		lon, lat float64
+	}{
+		{"Palermo", 13.361389, 38.115556},
+		{"Catania", 15.087269, 37.502669},
+		{"원점", 0, 0},
+		{"경계값", -180, -85.05112878},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lon, lat := Decode(Encode(tt.lon, tt.lat))
+			if math.Abs(lon-tt.lon) > 1e-5 || math.Abs(lat-tt.lat) > 1e-5 {
+				t.Errorf("Expected (%f, %f), got (%f, %f)", tt.lon, tt.lat, lon, lat)
+			}
+		})
+	}
+}
+
+// TestEncodeMatchesRedis는 Redis가 저장하는 점수와 동일한 값이 나오는지 테스트합니다.
+func TestEncodeMatchesRedis(t *testing.T) {
+	// GEOADD Sicily 13.361389 38.115556 "Palermo" 후 ZSCORE Sicily Palermo
+	if score := Encode(13.361389, 38.115556); score != 3479099956230698 {
+		t.Errorf("Expected 3479099956230698, got %d", score)
+	}
+}
+
+// TestHashString은 표준 geohash 문자열을 테스트합니다.
+func TestHashString(t *testing.T) {
+	lon, lat := Decode(Encode(13.361389, 38.115556))
+	if hash := HashString(lon, lat); hash != "sqc8b49rny0" {
+		t.Errorf("Expected sqc8b49rny0, got %s", hash)
+	}
+
+	lon, lat = Decode(Encode(15.087269, 37.502669))
+	if hash := HashString(lon, lat); hash != "sqdtr74hyu0" {
+		t.Errorf("Expected sqdtr74hyu0, got %s", hash)
+	}
+}
+
+// TestDistance는 haversine 거리 계산을 테스트합니다.
+func TestDistance(t *testing.T) {
+	// Redis 문서 예시: GEODIST Sicily Palermo Catania → 166274.1516
+	lon1, lat1 := Decode(Encode(13.361389, 38.115556))
+	lon2, lat2 := Decode(Encode(15.087269, 37.502669))
+
+	dist := Distance(lon1, lat1, lon2, lat2)
+	if math.Abs(dist-166274.1516) > 0.001 {
+		t.Errorf("Expected 166274.1516, got %.4f", dist)
+	}
+}
+
+// TestValidCoordinates는 좌표 범위 검증을 테스트합니다.
+func TestValidCoordinates(t *testing.T) {
+	if !ValidCoordinates(13.361389, 38.115556) {
+		t.Error("Palermo should be valid")
+	}
+	if ValidCoordinates(181, 0) {
+		t.Error("Longitude 181 should be invalid")
+	}
+	if ValidCoordinates(0, 86) {
+		t.Error("Latitude 86 should be invalid")
+	}
+}
+
+// TestDistanceInBox는 사각형 범위 판정을 테스트합니다.
+func TestDistanceInBox(t *testing.T) {
+	// 중심에서 북쪽으로 약 111km (위도 1도) 떨어진 점
+	if _, ok := DistanceInBox(10000, 250000, 0, 0, 0, 1); !ok {
+		t.Error("Point within height/2 should be inside the box")
+	}
+	if _, ok := DistanceInBox(10000, 250000, 0, 0, 1, 0); ok {
+		t.Error("Point beyond width/2 should be outside the box")
+	}
+	if _, ok := DistanceInBox(300000, 100000, 0, 0, 0, 1); ok {
+		t.Error("Point beyond height/2 should be outside the box")
+	}
+}
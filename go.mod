@@ -1,3 +1,5 @@
 module github.com/codecrafters-io/redis-starter-go
 
 go 1.24.0
+
+require github.com/yuin/gopher-lua v1.1.2
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"time"
+)
+
+// activeExpireInterval은 만료된 키를 찾아 지우는 주기입니다 (Redis의 serverCron, hz 10과 동일).
+const activeExpireInterval = 100 * time.Millisecond
+
+// activeExpireSample은 한 번에 확인하는 만료 시간이 있는 키의 수입니다
+// (Redis의 ACTIVE_EXPIRE_CYCLE_KEYS_PER_LOOP와 동일).
+const activeExpireSample = 20
+
+// activeExpireBudget은 한 주기에서 만료된 키를 지우는 데 쓰는 최대 시간입니다 (주기의 25%).
+const activeExpireBudget = 25 * time.Millisecond
+
+// StartActiveExpire는 읽히지 않는 만료된 키도 주기적으로 지우는 고루틴을 시작합니다.
+// 만료된 키는 읽을 때도 지워지므로(lazy expire) 명령어의 결과는 같지만,
+// 다시 읽히지 않는 키의 메모리는 이 고루틴이 있어야 회수됩니다.
+// 여러 번 호출해도 고루틴은 하나만 시작하며, DEBUG SET-ACTIVE-EXPIRE 0으로 멈출 수 있습니다.
+func (r *CommandRegistry) StartActiveExpire() {
+	r.activeExpireOnce.Do(func() { go r.activeExpireCron() })
+}
+
+// activeExpireCron은 서버가 종료될 때까지 activeExpireInterval마다 만료된 키를 지웁니다.
+func (r *CommandRegistry) activeExpireCron() {
+	ticker := time.NewTicker(activeExpireInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.activeExpireOff.Load() {
+				r.activeExpireCycle()
+			}
+		}
+	}
+}
+
+// activeExpireCycle은 만료 시간이 있는 키를 표본으로 확인해 만료된 키를 지우고, 지운 키의 수를 반환합니다.
+// 표본의 25% 넘게 만료되었으면 남은 키도 많이 만료되었다고 보고 시간 예산 안에서 반복합니다.
+// 지운 키는 store.OnKeyExpired 알림으로 레플리카와 AOF에 DEL로 전달됩니다.
+func (r *CommandRegistry) activeExpireCycle() int {
+	r.execMu.Lock()
+	defer r.execMu.Unlock()
+
+	start := time.Now()
+	deadline := start.Add(activeExpireBudget)
+	total := 0
+	for {
+		sampled, expired := r.store.ActiveExpire(activeExpireSample)
+		total += expired
+		if expired*4 <= sampled || time.Now().After(deadline) {
+			r.latency.addSample(latencyEventExpireCycle, time.Since(start))
+			return total
+		}
+	}
+}
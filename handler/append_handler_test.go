@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestAppendHandler는 APPEND 명령어 핸들러를 테스트합니다.
+func TestAppendHandler(t *testing.T) {
+	handler := &AppendHandler{}
+	dataStore := store.NewStore()
+
+	// 테스트 케이스 1: 없는 키는 value로 새로 만듦
+	result, err := handler.Execute([]string{"key", "Hello"}, dataStore)
+	if err != nil || result != protocol.Integer(5) {
+		t.Fatalf("Expected 5, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 2: 기존 값 끝에 덧붙임 (\r\n, NUL 포함)
+	result, err = handler.Execute([]string{"key", " World\r\n\x00"}, dataStore)
+	if err != nil || result != protocol.Integer(14) {
+		t.Fatalf("Expected 14, got %v, %v", result, err)
+	}
+	if value := dataStore.GET("key"); string(value) != "Hello World\r\n\x00" {
+		t.Errorf("Expected %q, got %q", "Hello World\r\n\x00", value)
+	}
+
+	// 테스트 케이스 3: 빈 문자열을 덧붙여도 키가 만들어짐
+	result, err = handler.Execute([]string{"empty", ""}, dataStore)
+	if err != nil || result != protocol.Integer(0) {
+		t.Fatalf("Expected 0, got %v, %v", result, err)
+	}
+	if value := dataStore.GET("empty"); value == nil || len(value) != 0 {
+		t.Errorf("Expected an empty string, got %q", value)
+	}
+
+	// 테스트 케이스 4: String이 아닌 키 (에러 케이스)
+	dataStore.RPUSH("list", "a")
+	if _, err := handler.Execute([]string{"list", "b"}, dataStore); err == nil || err.Error() != (&WrongTypeError{}).Error() {
+		t.Errorf("Expected WRONGTYPE, got %v", err)
+	}
+
+	// 테스트 케이스 5: 인자 개수가 잘못됨 (에러 케이스)
+	if _, err := handler.Execute([]string{"key"}, dataStore); err == nil {
+		t.Error("Expected error for missing value")
+	}
+}
+
+// TestAppendKeepsTTL은 APPEND가 키의 만료 시각을 바꾸지 않는지 테스트합니다.
+func TestAppendKeepsTTL(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.Execute("SET", []string{"key", "a", "PX", "60000"})
+	before, _ := registry.store.Lookup("key")
+
+	registry.Execute("APPEND", []string{"key", "b"})
+	after, ok := registry.store.Lookup("key")
+	if !ok || !after.ExpireAt.Equal(before.ExpireAt) {
+		t.Errorf("Expected expire time %v to be kept, got %v", before.ExpireAt, after.ExpireAt)
+	}
+
+	// 만료된 키에 덧붙이면 새 키처럼 만료 시각 없이 만들어짐
+	registry.Execute("SET", []string{"short", "old", "PX", "10"})
+	time.Sleep(20 * time.Millisecond)
+	if result, _ := registry.Execute("APPEND", []string{"short", "new"}); result != protocol.Integer(3) {
+		t.Errorf("Expected 3, got %v", result)
+	}
+	if entry, _ := registry.store.Lookup("short"); !entry.ExpireAt.IsZero() {
+		t.Errorf("Expected no expire time, got %v", entry.ExpireAt)
+	}
+}
+
+// TestAppendReaders는 APPEND가 이미 읽어 간 값과 스냅샷을 바꾸지 않는지 테스트합니다.
+func TestAppendReaders(t *testing.T) {
+	s := store.NewStore()
+	s.SET("key", []byte("abc"), nil)
+
+	// 여러 번 덧붙여 남는 용량이 생긴 뒤에 읽음
+	for i := 0; i < 10; i++ {
+		s.APPEND("key", []byte("x"))
+	}
+	read := s.GET("key")
+	snapshot := s.BeginSnapshot()
+
+	s.APPEND("key", []byte(strings.Repeat("y", 5)))
+	if string(read) != "abcxxxxxxxxxx" {
+		t.Errorf("Expected earlier GET result to be unchanged, got %q", read)
+	}
+	for _, entry := range snapshot.Entries() {
+		if value := entry.Value.([]byte); string(value) != "abcxxxxxxxxxx" {
+			t.Errorf("Expected snapshot value to be unchanged, got %q", value)
+		}
+	}
+
+	// 읽어 간 값에 덧붙여도 저장소의 값은 바뀌지 않음
+	_ = append(read, 'z')
+	if value := s.GET("key"); string(value) != "abcxxxxxxxxxxyyyyy" {
+		t.Errorf("Expected %q, got %q", "abcxxxxxxxxxxyyyyy", value)
+	}
+}
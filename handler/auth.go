@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// noAuthCommands는 인증하지 않은 연결에서도 실행할 수 있는 명령어 목록입니다 (Redis의 no-auth 플래그).
+var noAuthCommands = map[string]bool{
+	"AUTH":  true,
+	"HELLO": true,
+	"QUIT":  true,
+	"RESET": true,
+}
+
+// defaultUser는 requirepass로 인증하는 유일한 사용자 이름입니다.
+const defaultUser = "default"
+
+// SetRequirePass는 연결이 명령어를 실행하기 전에 AUTH로 보내야 하는 비밀번호를 설정합니다 (빈 문자열이면 인증 없음).
+// 연결은 생성될 때 비밀번호가 없으면 인증된 상태로 시작하므로, 설정을 바꿔도 이미 있는 연결에는 영향이 없습니다.
+func (r *CommandRegistry) SetRequirePass(password string) {
+	r.authMu.Lock()
+	defer r.authMu.Unlock()
+	r.requirePass = password
+}
+
+// requirePassword는 설정된 비밀번호를 반환합니다 (없으면 빈 문자열).
+func (r *CommandRegistry) requirePassword() string {
+	r.authMu.Lock()
+	defer r.authMu.Unlock()
+	return r.requirePass
+}
+
+// checkPassword는 사용자 이름과 비밀번호가 맞는지 확인합니다.
+// 비밀번호가 설정되지 않았으면 default 사용자는 어떤 비밀번호로도 인증됩니다 (Redis의 nopass).
+// 비밀번호는 일치하는 앞부분의 길이가 응답 시간으로 드러나지 않도록 비교합니다.
+func (r *CommandRegistry) checkPassword(username, password string) error {
+	if username != defaultUser {
+		return &WrongPassError{}
+	}
+	required := r.requirePassword()
+	if required != "" && subtle.ConstantTimeCompare([]byte(password), []byte(required)) != 1 {
+		return &WrongPassError{}
+	}
+	return nil
+}
+
+// AuthHandler는 AUTH 명령어를 처리하는 핸들러입니다.
+//
+// Redis AUTH 명령어 사양:
+//   - AUTH password: default 사용자로 인증 (requirepass와 비교)
+//   - AUTH username password: username 사용자로 인증 (default 사용자만 있음)
+//   - 성공하면 OK, 틀리면 WRONGPASS 에러
+//   - requirepass가 없을 때 AUTH password는 설정 오류로 보고 에러
+//
+// requirepass가 설정되어 있으면 인증하기 전에는 AUTH, HELLO, QUIT, RESET 외의 명령어가 NOAUTH 에러로 거부됩니다.
+//
+// 예시:
+//
+//	클라이언트: AUTH secret
+//	서버: +OK\r\n
+type AuthHandler struct {
+	registry *CommandRegistry
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. 비밀번호만 확인합니다.
+func (h *AuthHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.ExecuteWithClient(nil, args, store)
+}
+
+// ExecuteWithClient는 AUTH 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.SimpleString: 인증에 성공하면 OK
+//   - error: 인자 개수가 잘못된 경우, 비밀번호 없이 AUTH password를 호출한 경우, 인증에 실패한 경우
+func (h *AuthHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	var username, password string
+	switch len(args) {
+	case 1:
+		if h.registry.requirePassword() == "" {
+			return nil, &InvalidArgumentError{Message: "AUTH <password> called without any password configured for the default user. Are you sure your configuration is correct?"}
+		}
+		username, password = defaultUser, args[0]
+	case 2:
+		username, password = args[0], args[1]
+	default:
+		return nil, &WrongNumberOfArgumentsError{Command: "auth"}
+	}
+
+	if err := h.registry.checkPassword(username, password); err != nil {
+		return nil, err
+	}
+	if client != nil {
+		client.authenticated = true
+	}
+	return okReply, nil
+}
+
+// authRequired는 인증하지 않은 연결이 cmdUpper를 실행할 수 없는지 확인합니다.
+// HELLO는 AUTH 옵션이 있을 때만 인증 없이 실행할 수 있습니다.
+func authRequired(client *Client, cmdUpper string, args []string) error {
+	if client.authenticated {
+		return nil
+	}
+	if cmdUpper == "HELLO" && !helloHasAuth(args) {
+		return &NoAuthError{Message: "HELLO must be called with the client already authenticated, otherwise the HELLO <proto> AUTH <user> <pass> option can be used to authenticate the client and select the RESP protocol version at the same time"}
+	}
+	if !noAuthCommands[cmdUpper] {
+		return &NoAuthError{}
+	}
+	return nil
+}
+
+// helloHasAuth는 HELLO의 인자에 AUTH 옵션이 있는지 확인합니다.
+func helloHasAuth(args []string) bool {
+	for i := 1; i+2 < len(args); i++ {
+		if strings.EqualFold(args[i], "AUTH") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestAuthRequirePass는 requirepass가 있으면 인증 전의 명령어가 거부되고 AUTH로 인증되는지 테스트합니다.
+func TestAuthRequirePass(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+
+	// 테스트 케이스 1: 비밀번호가 없으면 AUTH password는 설정 오류
+	before, _ := newTestClient(registry)
+	if _, err := registry.ExecuteForClient(before, "AUTH", []string{"secret"}); err == nil || !strings.Contains(err.Error(), "without any password configured") {
+		t.Errorf("Expected no password configured error, got %v", err)
+	}
+	if result, err := registry.ExecuteForClient(before, "AUTH", []string{"default", "anything"}); err != nil || !isStatus(result, "OK") {
+		t.Errorf("AUTH default without requirepass: expected OK, got %v, %v", result, err)
+	}
+
+	registry.SetRequirePass("secret")
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 2: 비밀번호 설정 전에 생성된 연결은 계속 인증된 상태
+	if result, err := registry.ExecuteForClient(before, "PING", nil); err != nil || !isStatus(result, "PONG") {
+		t.Errorf("Expected existing connection to stay authenticated, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 3: 인증 전에는 NOAUTH, 연결 명령어는 실행 가능
+	if _, err := registry.ExecuteForClient(client, "GET", []string{"k"}); err == nil || !strings.HasPrefix(err.Error(), "-NOAUTH Authentication required.") {
+		t.Errorf("Expected NOAUTH, got %v", err)
+	}
+	if _, err := registry.ExecuteForClient(client, "HELLO", []string{"3"}); err == nil || !strings.Contains(err.Error(), "HELLO must be called with the client already authenticated") {
+		t.Errorf("Expected NOAUTH for HELLO without AUTH, got %v", err)
+	}
+	if _, err := registry.ExecuteForClient(client, "NOSUCH", nil); err == nil || !strings.Contains(err.Error(), "unknown command") {
+		t.Errorf("Expected unknown command before NOAUTH, got %v", err)
+	}
+
+	// 테스트 케이스 4: 틀린 비밀번호와 사용자
+	for _, args := range [][]string{{"wrong"}, {"default", "wrong"}, {"admin", "secret"}} {
+		if _, err := registry.ExecuteForClient(client, "AUTH", args); err == nil || !strings.HasPrefix(err.Error(), "-WRONGPASS") {
+			t.Errorf("AUTH %v: expected WRONGPASS, got %v", args, err)
+		}
+	}
+
+	// 테스트 케이스 5: AUTH 성공 후 명령어 실행, RESET으로 인증 취소
+	if result, err := registry.ExecuteForClient(client, "AUTH", []string{"secret"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("AUTH secret: expected OK, got %v, %v", result, err)
+	}
+	if result, err := registry.ExecuteForClient(client, "PING", nil); err != nil || !isStatus(result, "PONG") {
+		t.Errorf("Expected PING after AUTH, got %v, %v", result, err)
+	}
+	registry.ExecuteForClient(client, "RESET", nil)
+	if _, err := registry.ExecuteForClient(client, "PING", nil); err == nil || !strings.Contains(err.Error(), "NOAUTH") {
+		t.Errorf("Expected NOAUTH after RESET, got %v", err)
+	}
+
+	// 테스트 케이스 6: HELLO AUTH로 인증과 프로토콜 전환을 함께
+	if _, err := registry.ExecuteForClient(client, "HELLO", []string{"3", "AUTH", "default", "secret"}); err != nil {
+		t.Fatalf("HELLO 3 AUTH: %v", err)
+	}
+	if result, err := registry.ExecuteForClient(client, "PING", nil); err != nil || !isStatus(result, "PONG") || client.Protocol() != 3 {
+		t.Errorf("Expected authenticated RESP3 connection, got %v, %v (resp %d)", result, err, client.Protocol())
+	}
+}
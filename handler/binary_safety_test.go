@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/rdb"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// binaryValue는 \r\n, NUL, UTF-8이 아닌 바이트를 포함한 값입니다.
+const binaryValue = "a\r\nb\x00c\xff\xfe$3\r\n"
+
+// roundTrip은 요청을 RESP로 인코딩해 파싱하고, 실행 결과를 RESP로 작성해 다시 파싱합니다.
+// 클라이언트가 연결로 보내고 받는 것과 같은 경로로 값을 전달합니다.
+func roundTrip(t *testing.T, registry *CommandRegistry, client *Client, args ...string) interface{} {
+	t.Helper()
+
+	request := protocol.EncodeCommand(args...)
+	parsed, err := protocol.NewParser(bufio.NewReader(bytes.NewReader(request))).ParseRequest()
+	if err != nil {
+		t.Fatalf("parsing request %q: %v", args, err)
+	}
+	elements := parsed.([]interface{})
+	received := make([]string, len(elements))
+	for i, element := range elements {
+		received[i] = element.(string)
+	}
+
+	result, err := registry.ExecuteForClient(client, received[0], received[1:])
+	var reply bytes.Buffer
+	if err != nil {
+		protocol.NewWriter(&reply).WriteReply(protocol.NewError(err))
+	} else {
+		protocol.NewWriter(&reply).WriteReply(result)
+	}
+	value, err := protocol.Unmarshal(reply.Bytes())
+	if err != nil {
+		t.Fatalf("parsing reply to %q: %v", args, err)
+	}
+	return value
+}
+
+// TestBinarySafeValues는 임의의 바이트를 포함한 키와 값이 바뀌지 않고 저장, 조회, 저장 파일 복원되는지 테스트합니다.
+func TestBinarySafeValues(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetRDBFile(t.TempDir(), "binary.rdb")
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: SET/GET
+	roundTrip(t, registry, client, "SET", binaryValue, binaryValue)
+	if got := roundTrip(t, registry, client, "GET", binaryValue); got != binaryValue {
+		t.Errorf("GET: expected %q, got %q", binaryValue, got)
+	}
+	roundTrip(t, registry, client, "APPEND", "appended", binaryValue)
+	roundTrip(t, registry, client, "APPEND", "appended", "\x00\r\n")
+	if got := roundTrip(t, registry, client, "GET", "appended"); got != binaryValue+"\x00\r\n" {
+		t.Errorf("APPEND: expected %q, got %q", binaryValue+"\x00\r\n", got)
+	}
+
+	// 테스트 케이스 2: 리스트 연산
+	roundTrip(t, registry, client, "RPUSH", "list", binaryValue, "\x00")
+	roundTrip(t, registry, client, "LPUSH", "list", "\r\n")
+	got := roundTrip(t, registry, client, "LRANGE", "list", "0", "-1").([]interface{})
+	if len(got) != 3 || got[0] != "\r\n" || got[1] != binaryValue || got[2] != "\x00" {
+		t.Errorf("LRANGE: expected [\\r\\n %q \\x00], got %q", binaryValue, got)
+	}
+	if popped := roundTrip(t, registry, client, "LPOP", "list"); popped != "\r\n" {
+		t.Errorf("LPOP: expected %q, got %q", "\r\n", popped)
+	}
+
+	// 테스트 케이스 3: RDB 저장과 복원
+	if _, err := registry.ExecuteForClient(client, "SAVE", []string{}); err != nil {
+		t.Fatalf("SAVE failed: %v", err)
+	}
+	loaded := store.NewStore()
+	if err := rdb.LoadFile(filepath.Join(registry.persistence.dir, "binary.rdb"), loaded); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if value := loaded.GET(binaryValue); value == nil || string(value) != binaryValue {
+		t.Errorf("RDB: expected %q, got %v", binaryValue, value)
+	}
+	if list := loaded.LRANGE("list", 0, -1); len(list) != 2 || list[0] != binaryValue || list[1] != "\x00" {
+		t.Errorf("RDB list: expected [%q \\x00], got %q", binaryValue, list)
+	}
+}
@@ -0,0 +1,420 @@
+// Package handler는 Redis의 비트 연산 명령어들을 구현합니다.
+// 비트 명령어들은 String 값을 비트 배열로 취급하며, 각 바이트의 최상위 비트(MSB)가 0번 비트입니다.
+package handler
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// BitCountHandler는 BITCOUNT 명령어를 처리하는 핸들러입니다.
+//
+// Redis BITCOUNT 명령어 사양:
+//   - BITCOUNT key → 전체 문자열의 1 비트 개수
+//   - BITCOUNT key start end [BYTE|BIT] → 지정된 범위의 1 비트 개수
+//
+// 범위 모드:
+//   - BYTE (기본값): start, end가 바이트 인덱스
+//   - BIT: start, end가 비트 인덱스
+//
+// 예시:
+//
+//	SET mykey "foobar"
+//	BITCOUNT mykey → :26\r\n
+//	BITCOUNT mykey 1 1 → :6\r\n
+//	BITCOUNT mykey 5 30 BIT → :17\r\n
+//
+// 시간 복잡도: O(N)
+type BitCountHandler struct{}
+
+// Execute는 BITCOUNT 명령어를 실행합니다.
+//
+// 매개변수:
+//   - args[0]: 키 이름
+//   - args[1], args[2]: 시작/끝 인덱스 (선택적, 음수 인덱스 지원)
+//   - args[3]: "BYTE" 또는 "BIT" (선택적)
+//
+// 반환값:
+//   - protocol.Integer: 1로 설정된 비트 개수
+//   - error: 인자가 잘못되었거나 String이 아닌 키인 경우
+func (h *BitCountHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "bitcount"}
+	}
+
+	// start만 있고 end가 없는 경우, 또는 인자가 너무 많은 경우는 문법 오류
+	if len(args) == 2 || len(args) > 4 {
+		return nil, &InvalidArgumentError{Message: "syntax error"}
+	}
+
+	var start, end int
+	isBit := false
+	hasRange := len(args) >= 3
+
+	if hasRange {
+		var err error
+		if start, err = parseBitIndex(args[1]); err != nil {
+			return nil, err
+		}
+		if end, err = parseBitIndex(args[2]); err != nil {
+			return nil, err
+		}
+		if len(args) == 4 {
+			if isBit, err = parseBitRangeUnit(args[3]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := checkStringKeyType(store, args[0]); err != nil {
+		return nil, err
+	}
+
+	// 키가 없으면 빈 문자열로 취급 → 0
+	value := store.GET(args[0])
+	if value == nil {
+		return protocol.Integer(0), nil
+	}
+	data := value
+
+	if !hasRange {
+		return protocol.Integer(popcount(data)), nil
+	}
+
+	// 범위 모드에 따른 전체 길이 (바이트 또는 비트)
+	totalLen := len(data)
+	if isBit {
+		totalLen = len(data) * 8
+	}
+
+	start, end, ok := normalizeBitRange(start, end, totalLen)
+	if !ok {
+		return protocol.Integer(0), nil
+	}
+
+	if !isBit {
+		return protocol.Integer(popcount(data[start : end+1])), nil
+	}
+
+	// BIT 모드: 시작/끝 바이트를 포함해 센 뒤 범위 밖의 비트를 빼줍니다
+	firstByte, lastByte := start/8, end/8
+	count := popcount(data[firstByte : lastByte+1])
+
+	// 첫 바이트에서 start 이전의 상위 비트들 제외
+	if headBits := start % 8; headBits > 0 {
+		count -= bits.OnesCount8(data[firstByte] >> (8 - headBits))
+	}
+	// 마지막 바이트에서 end 이후의 하위 비트들 제외
+	if tailBits := 7 - end%8; tailBits > 0 {
+		count -= bits.OnesCount8(data[lastByte] & (1<<tailBits - 1))
+	}
+
+	return protocol.Integer(count), nil
+}
+
+// BitPosHandler는 BITPOS 명령어를 처리하는 핸들러입니다.
+//
+// Redis BITPOS 명령어 사양:
+//   - BITPOS key bit [start [end [BYTE|BIT]]]
+//   - bit(0 또는 1)이 처음 나타나는 비트 위치를 반환
+//
+// 특별한 동작:
+//   - 키가 없으면 bit=1일 때 -1, bit=0일 때 0 반환
+//   - bit=0을 찾는데 end가 지정되지 않았고 모든 비트가 1이면,
+//     문자열 오른쪽이 0으로 채워져 있다고 보고 문자열 길이(비트) 반환
+//   - end가 지정된 경우 범위 안에서 찾지 못하면 -1 반환
+//
+// 예시:
+//
+//	SET mykey "\xff\xf0\x00"
+//	BITPOS mykey 0 → :12\r\n
+//	BITPOS mykey 1 2 → :-1\r\n
+//
+// 시간 복잡도: O(N)
+type BitPosHandler struct{}
+
+// Execute는 BITPOS 명령어를 실행합니다.
+//
+// 매개변수:
+//   - args[0]: 키 이름
+//   - args[1]: 찾을 비트 값 (0 또는 1)
+//   - args[2], args[3]: 시작/끝 인덱스 (선택적)
+//   - args[4]: "BYTE" 또는 "BIT" (선택적)
+//
+// 반환값:
+//   - protocol.Integer: 비트 위치, 찾지 못하면 -1
+//   - error: 인자가 잘못되었거나 String이 아닌 키인 경우
+func (h *BitPosHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 2 {
+		return nil, &WrongNumberOfArgumentsError{Command: "bitpos"}
+	}
+	if len(args) > 5 {
+		return nil, &InvalidArgumentError{Message: "syntax error"}
+	}
+
+	bit, err := strconv.Atoi(args[1])
+	if err != nil || (bit != 0 && bit != 1) {
+		return nil, &InvalidArgumentError{Message: "The bit argument must be 1 or 0."}
+	}
+
+	var start, end int
+	isBit := false
+	endGiven := len(args) >= 4
+
+	if len(args) >= 3 {
+		if start, err = parseBitIndex(args[2]); err != nil {
+			return nil, err
+		}
+	}
+	if endGiven {
+		if end, err = parseBitIndex(args[3]); err != nil {
+			return nil, err
+		}
+	}
+	if len(args) == 5 {
+		if isBit, err = parseBitRangeUnit(args[4]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkStringKeyType(store, args[0]); err != nil {
+		return nil, err
+	}
+
+	value := store.GET(args[0])
+	if value == nil {
+		// 존재하지 않는 키는 0으로 채워진 무한한 문자열로 취급
+		if bit == 1 {
+			return protocol.Integer(-1), nil
+		}
+		return protocol.Integer(0), nil
+	}
+	data := value
+
+	totalLen := len(data)
+	if isBit {
+		totalLen = len(data) * 8
+	}
+	if !endGiven {
+		end = totalLen - 1
+	}
+
+	start, end, ok := normalizeBitRange(start, end, totalLen)
+	if !ok {
+		return protocol.Integer(-1), nil
+	}
+
+	// 검색할 비트 범위 계산 (양 끝 포함)
+	startBit, endBit := start, end
+	if !isBit {
+		startBit, endBit = start*8, end*8+7
+	}
+
+	if pos := findBit(data, bit, startBit, endBit); pos >= 0 {
+		return protocol.Integer(pos), nil
+	}
+
+	// 0을 찾는데 범위가 명시되지 않았다면 문자열 뒤쪽을 0으로 간주
+	if bit == 0 && !endGiven {
+		return protocol.Integer(len(data) * 8), nil
+	}
+	return protocol.Integer(-1), nil
+}
+
+// popcount는 바이트 슬라이스에서 1로 설정된 비트 개수를 셉니다.
+// 8바이트 단위로 묶어 64비트 popcount를 사용하고, 남은 바이트만 개별 처리합니다.
+func popcount(data []byte) int {
+	count := 0
+	for len(data) >= 8 {
+		count += bits.OnesCount64(binary.LittleEndian.Uint64(data))
+		data = data[8:]
+	}
+	for _, b := range data {
+		count += bits.OnesCount8(b)
+	}
+	return count
+}
+
+// findBit는 [startBit, endBit] 범위에서 bit 값이 처음 나타나는 위치를 찾습니다.
+// 찾는 비트가 없는 바이트(0x00 또는 0xff)는 통째로 건너뜁니다.
+// 찾지 못하면 -1을 반환합니다.
+func findBit(data []byte, bit, startBit, endBit int) int {
+	// 찾는 비트가 하나도 없는 바이트 값
+	skipByte := byte(0x00)
+	if bit == 0 {
+		skipByte = 0xff
+	}
+
+	for pos := startBit; pos <= endBit; {
+		b := data[pos/8]
+
+		// 바이트 경계에서 시작하고 바이트 전체가 범위에 포함되면 한 번에 건너뛰기
+		if pos%8 == 0 && pos+7 <= endBit && b == skipByte {
+			pos += 8
+			continue
+		}
+
+		if int(b>>(7-pos%8))&1 == bit {
+			return pos
+		}
+		pos++
+	}
+	return -1
+}
+
+// normalizeBitRange는 Redis 방식으로 음수 인덱스를 변환하고 범위를 조정합니다.
+// 유효한 범위가 없으면 ok=false를 반환합니다.
+func normalizeBitRange(start, end, totalLen int) (int, int, bool) {
+	if start < 0 {
+		start = totalLen + start
+	}
+	if end < 0 {
+		end = totalLen + end
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end < 0 {
+		end = 0
+	}
+	if end >= totalLen {
+		end = totalLen - 1
+	}
+	if start > end {
+		return 0, 0, false
+	}
+	return start, end, true
+}
+
+// parseBitIndex는 비트 명령어의 start/end 인덱스를 파싱합니다.
+func parseBitIndex(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, &InvalidArgumentError{Message: "value is not an integer or out of range"}
+	}
+	return n, nil
+}
+
+// parseBitRangeUnit는 BYTE/BIT 범위 단위를 파싱합니다.
+// BIT이면 true, BYTE이면 false를 반환합니다.
+func parseBitRangeUnit(s string) (bool, error) {
+	switch strings.ToUpper(s) {
+	case "BYTE":
+		return false, nil
+	case "BIT":
+		return true, nil
+	default:
+		return false, &InvalidArgumentError{Message: "syntax error"}
+	}
+}
+
+// BitOpHandler는 BITOP 명령어를 처리하는 핸들러입니다.
+//
+// Redis BITOP 명령어 사양:
+//   - BITOP AND|OR|XOR destkey srckey [srckey ...]
+//   - BITOP NOT destkey srckey (소스 키는 정확히 하나)
+//
+// 동작 방식:
+//   - 길이가 다른 문자열들은 가장 긴 문자열 길이에 맞춰 0으로 채워짐
+//   - 존재하지 않는 소스 키는 빈 문자열(모두 0)로 취급
+//   - 결과가 빈 문자열이면 destkey는 삭제됨
+//
+// 예시:
+//
+//	SET key1 "foobar"
+//	SET key2 "abcdef"
+//	BITOP AND dest key1 key2 → :6\r\n
+//	GET dest → "`bc`ab"
+//
+// 시간 복잡도: O(N) (N은 가장 긴 문자열의 길이)
+type BitOpHandler struct{}
+
+// Execute는 BITOP 명령어를 실행합니다.
+//
+// 매개변수:
+//   - args[0]: 연산 종류 (AND, OR, XOR, NOT)
+//   - args[1]: 결과를 저장할 키
+//   - args[2:]: 소스 키들
+//
+// 반환값:
+//   - protocol.Integer: 결과 문자열의 길이
+//   - error: 인자가 잘못되었거나 String이 아닌 소스 키가 있는 경우
+func (h *BitOpHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 3 {
+		return nil, &WrongNumberOfArgumentsError{Command: "bitop"}
+	}
+
+	op := strings.ToUpper(args[0])
+	destKey := args[1]
+	srcKeys := args[2:]
+
+	switch op {
+	case "AND", "OR", "XOR":
+	case "NOT":
+		if len(srcKeys) != 1 {
+			return nil, &InvalidArgumentError{Message: "BITOP NOT must be called with a single source key."}
+		}
+	default:
+		return nil, &InvalidArgumentError{Message: "syntax error"}
+	}
+
+	// 소스 키 중 하나라도 String이 아니면 결과를 쓰지 않고 에러
+	for _, key := range srcKeys {
+		if err := checkStringKeyType(store, key); err != nil {
+			return nil, err
+		}
+	}
+
+	// 소스 값들을 읽고 결과 길이(가장 긴 문자열) 계산
+	sources := make([][]byte, len(srcKeys))
+	maxLen := 0
+	for i, key := range srcKeys {
+		sources[i] = store.GET(key)
+		if len(sources[i]) > maxLen {
+			maxLen = len(sources[i])
+		}
+	}
+
+	// 결과가 빈 문자열이면 destkey 삭제
+	if maxLen == 0 {
+		store.DEL(destKey)
+		return protocol.Integer(0), nil
+	}
+
+	result := make([]byte, maxLen)
+	if op == "NOT" {
+		for i, b := range sources[0] {
+			result[i] = ^b
+		}
+	} else {
+		copy(result, sources[0])
+		for _, src := range sources[1:] {
+			for i := range result {
+				// 짧은 문자열의 범위 밖은 0으로 취급
+				var b byte
+				if i < len(src) {
+					b = src[i]
+				}
+				switch op {
+				case "AND":
+					result[i] &= b
+				case "OR":
+					result[i] |= b
+				case "XOR":
+					result[i] ^= b
+				}
+			}
+		}
+	}
+
+	// 기존 값의 타입과 관계없이 덮어쓰기
+	store.DEL(destKey)
+	store.SET(destKey, result, nil)
+
+	return protocol.Integer(maxLen), nil
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestBitCountHandler는 BITCOUNT 명령어 핸들러를 테스트합니다.
+func TestBitCountHandler(t *testing.T) {
+	handler := &BitCountHandler{}
+	dataStore := store.NewStore()
+	dataStore.SET("mykey", []byte("foobar"), nil)
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"전체 문자열", []string{"mykey"}, 26},
+		{"단일 바이트 범위", []string{"mykey", "0", "0"}, 4},
+		{"두 번째 바이트", []string{"mykey", "1", "1"}, 6},
+		{"음수 인덱스", []string{"mykey", "-2", "-1"}, 7},
+		{"BYTE 모드 명시", []string{"mykey", "1", "1", "byte"}, 6},
+		{"BIT 모드", []string{"mykey", "5", "30", "BIT"}, 17},
+		{"BIT 모드 단일 바이트 내부", []string{"mykey", "1", "3", "BIT"}, 2},
+		{"범위 초과", []string{"mykey", "10", "20"}, 0},
+		{"start > end", []string{"mykey", "3", "1"}, 0},
+		{"존재하지 않는 키", []string{"nokey"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.Execute(tt.args, dataStore)
+			if err != nil {
+				t.Fatalf("BITCOUNT failed: %v", err)
+			}
+			if result != protocol.Integer(tt.expected) {
+				t.Errorf("Expected %d, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// 에러 케이스: String이 아닌 키 → WRONGTYPE
+	dataStore.RPUSH("list", "a")
+	if _, err := handler.Execute([]string{"list"}, dataStore); err == nil || err.Error() != (&WrongTypeError{}).Error() {
+		t.Errorf("Expected WRONGTYPE error, got %v", err)
+	}
+
+	// 에러 케이스: 인자 부족
+	if _, err := handler.Execute([]string{}, dataStore); err == nil {
+		t.Error("Expected error for no args")
+	}
+
+	// 에러 케이스: start만 지정
+	if _, err := handler.Execute([]string{"mykey", "1"}, dataStore); err == nil {
+		t.Error("Expected syntax error when end is missing")
+	}
+
+	// 에러 케이스: 정수가 아닌 인덱스
+	if _, err := handler.Execute([]string{"mykey", "a", "1"}, dataStore); err == nil {
+		t.Error("Expected error for non-integer index")
+	}
+
+	// 에러 케이스: 잘못된 범위 단위
+	if _, err := handler.Execute([]string{"mykey", "0", "1", "WORD"}, dataStore); err == nil {
+		t.Error("Expected syntax error for unknown unit")
+	}
+}
+
+// TestBitPosHandler는 BITPOS 명령어 핸들러를 테스트합니다.
+func TestBitPosHandler(t *testing.T) {
+	handler := &BitPosHandler{}
+	dataStore := store.NewStore()
+	dataStore.SET("mykey", []byte("\xff\xf0\x00"), nil)
+	dataStore.SET("allones", []byte("\xff\xff\xff"), nil)
+	dataStore.SET("zeros", []byte("\x00\x00\x00"), nil)
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"첫 번째 0 비트", []string{"mykey", "0"}, 12},
+		{"첫 번째 1 비트", []string{"mykey", "1"}, 0},
+		{"시작 바이트 지정", []string{"mykey", "1", "2"}, -1},
+		{"바이트 범위 지정", []string{"mykey", "1", "1", "-1"}, 8},
+		{"BIT 모드", []string{"mykey", "1", "7", "15", "BIT"}, 7},
+		{"BIT 모드 0 비트", []string{"mykey", "0", "2", "15", "bit"}, 12},
+		{"모두 1일 때 0 검색 (end 없음)", []string{"allones", "0"}, 24},
+		{"모두 1일 때 0 검색 (end 지정)", []string{"allones", "0", "0", "-1"}, -1},
+		{"모두 0일 때 1 검색", []string{"zeros", "1"}, -1},
+		{"존재하지 않는 키에서 1 검색", []string{"nokey", "1"}, -1},
+		{"존재하지 않는 키에서 0 검색", []string{"nokey", "0"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.Execute(tt.args, dataStore)
+			if err != nil {
+				t.Fatalf("BITPOS failed: %v", err)
+			}
+			if result != protocol.Integer(tt.expected) {
+				t.Errorf("Expected %d, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// 에러 케이스: String이 아닌 키 → WRONGTYPE
+	dataStore.RPUSH("list", "a")
+	if _, err := handler.Execute([]string{"list", "1"}, dataStore); err == nil || err.Error() != (&WrongTypeError{}).Error() {
+		t.Errorf("Expected WRONGTYPE error, got %v", err)
+	}
+
+	// 에러 케이스: bit 값이 0/1이 아님
+	if _, err := handler.Execute([]string{"mykey", "2"}, dataStore); err == nil {
+		t.Error("Expected error for invalid bit argument")
+	}
+
+	// 에러 케이스: 인자 부족
+	if _, err := handler.Execute([]string{"mykey"}, dataStore); err == nil {
+		t.Error("Expected error for insufficient args")
+	}
+}
+
+// TestBitOpHandler는 BITOP 명령어 핸들러를 테스트합니다.
+func TestBitOpHandler(t *testing.T) {
+	handler := &BitOpHandler{}
+	dataStore := store.NewStore()
+	dataStore.SET("key1", []byte("foobar"), nil)
+	dataStore.SET("key2", []byte("abcdef"), nil)
+	dataStore.SET("short", []byte("\xff"), nil)
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectedLen int
+		expected    string
+	}{
+		{"AND", []string{"AND", "dest", "key1", "key2"}, 6, "`bc`ab"},
+		{"OR", []string{"or", "dest", "key1", "key2"}, 6, "goofev"},
+		{"XOR", []string{"XOR", "dest", "key1", "key2"}, 6, "\x07\x0d\x0c\x06\x04\x14"},
+		{"NOT", []string{"NOT", "dest", "short"}, 1, "\x00"},
+		{"길이가 다른 키 (0으로 채움)", []string{"AND", "dest", "short", "key1"}, 6, "f\x00\x00\x00\x00\x00"},
+		{"존재하지 않는 소스 키", []string{"OR", "dest", "short", "nokey"}, 1, "\xff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.Execute(tt.args, dataStore)
+			if err != nil {
+				t.Fatalf("BITOP failed: %v", err)
+			}
+			if result != protocol.Integer(tt.expectedLen) {
+				t.Errorf("Expected length %d, got %v", tt.expectedLen, result)
+			}
+
+			// 실제 저장된 값 검증
+			value := dataStore.GET("dest")
+			if value == nil || string(value) != tt.expected {
+				t.Errorf("Expected dest %q, got %q", tt.expected, value)
+			}
+		})
+	}
+
+	// 테스트 케이스: 결과가 빈 문자열이면 destkey 삭제
+	result, err := handler.Execute([]string{"AND", "dest", "nokey1", "nokey2"}, dataStore)
+	if err != nil {
+		t.Fatalf("BITOP with empty sources failed: %v", err)
+	}
+	if result != protocol.Integer(0) {
+		t.Errorf("Expected length 0, got %v", result)
+	}
+	if value := dataStore.GET("dest"); value != nil {
+		t.Errorf("Expected dest to be deleted, got %q", value)
+	}
+
+	// 에러 케이스: NOT에 여러 소스 키
+	if _, err := handler.Execute([]string{"NOT", "dest", "key1", "key2"}, dataStore); err == nil {
+		t.Error("Expected error for NOT with multiple sources")
+	}
+
+	// 에러 케이스: 알 수 없는 연산
+	if _, err := handler.Execute([]string{"NAND", "dest", "key1"}, dataStore); err == nil {
+		t.Error("Expected syntax error for unknown operation")
+	}
+
+	// 에러 케이스: String이 아닌 소스 키 → WRONGTYPE, destkey는 그대로
+	dataStore.RPUSH("list", "a")
+	for _, args := range [][]string{{"AND", "dest", "key1", "list"}, {"NOT", "dest", "list"}} {
+		_, err := handler.Execute(args, dataStore)
+		if _, ok := err.(*WrongTypeError); !ok {
+			t.Errorf("BITOP %v: expected WrongTypeError, got %v", args, err)
+		}
+	}
+	if value := dataStore.GET("dest"); value != nil {
+		t.Errorf("Expected dest to stay deleted, got %q", value)
+	}
+
+	// 에러 케이스: 인자 부족
+	if _, err := handler.Execute([]string{"AND", "dest"}, dataStore); err == nil {
+		t.Error("Expected error for insufficient args")
+	}
+}
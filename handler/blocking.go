@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"bufio"
+	"sync/atomic"
+	"time"
+)
+
+// SetRequestReader는 연결 고루틴이 요청을 읽는 버퍼를 지정합니다.
+// 지정하면 BLPOP 같은 대기 명령어가 대기하는 동안 연결이 끊어지는지 지켜보고, 끊어지면 대기를 취소합니다
+// (끊어진 클라이언트가 다른 클라이언트가 넣은 값을 가져가지 않도록).
+//
+// SetConn으로 지정한 연결이 읽기 마감 시간(SetReadDeadline)을 지원해야 합니다.
+func (c *Client) SetRequestReader(reader *bufio.Reader) {
+	c.reader = reader
+}
+
+// readDeadliner는 읽기 마감 시간을 지정할 수 있는 연결입니다 (net.Conn).
+type readDeadliner interface {
+	SetReadDeadline(t time.Time) error
+}
+
+// watchDisconnect는 클라이언트가 대기하는 동안 연결이 끊어지는지 지켜봅니다.
+//
+// 반환한 채널은 연결이 끊어지면 닫힙니다. 대기가 끝나면 연결 고루틴이 다시 요청을 읽기 전에 stop을 호출해야 합니다.
+// 파이프라이닝된 다음 요청은 읽지 않고 버퍼에 남겨 두며, 버퍼가 가득 차면 더 지켜보지 않습니다.
+// 요청 버퍼가 없는 연결(가상의 연결, 스크립트 안)이면 닫히지 않는 nil 채널을 반환합니다.
+func (c *Client) watchDisconnect() (disconnected <-chan struct{}, stop func()) {
+	if c == nil || c.reader == nil {
+		return nil, func() {}
+	}
+	c.infoMu.Lock()
+	conn, ok := c.conn.(readDeadliner)
+	c.infoMu.Unlock()
+	if !ok {
+		return nil, func() {}
+	}
+
+	reader := c.reader
+	closed := make(chan struct{})
+	finished := make(chan struct{})
+	var stopping atomic.Bool
+	go func() {
+		defer close(finished)
+		for reader.Buffered() < reader.Size() {
+			if _, err := reader.Peek(reader.Buffered() + 1); err != nil {
+				// stop이 읽기 마감 시간으로 Peek을 깨운 경우는 연결 끊김이 아님
+				if !stopping.Load() {
+					close(closed)
+				}
+				return
+			}
+		}
+	}()
+
+	stop = func() {
+		stopping.Store(true)
+		conn.SetReadDeadline(time.Now())
+		<-finished
+		conn.SetReadDeadline(time.Time{})
+	}
+	return closed, stop
+}
@@ -0,0 +1,303 @@
+package handler
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// newPipeClient는 net.Pipe의 서버 쪽 연결을 쓰는 클라이언트를 만들고, 클라이언트 쪽 연결을 반환합니다.
+// 연결 고루틴처럼 요청 버퍼를 지정해 대기 중 연결 끊김을 확인할 수 있습니다.
+func newPipeClient(t *testing.T, registry *CommandRegistry) (*Client, *bufio.Reader, net.Conn) {
+	t.Helper()
+	serverConn, clientConn := net.Pipe()
+	t.Cleanup(func() {
+		serverConn.Close()
+		clientConn.Close()
+	})
+
+	client := registry.NewClient(protocol.NewWriter(serverConn))
+	client.SetConn(serverConn)
+	reader := bufio.NewReader(serverConn)
+	client.SetRequestReader(reader)
+	return client, reader, clientConn
+}
+
+// TestBlockingDisconnect는 대기 중인 클라이언트의 연결이 끊어지면 대기가 취소되는지 테스트합니다.
+func TestBlockingDisconnect(t *testing.T) {
+	// 테스트 케이스 1: 연결이 끊어진 BLPOP 클라이언트는 이후에 추가된 값을 가져가지 않음
+	t.Run("DisconnectedClientDoesNotConsume", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		client, _, clientConn := newPipeClient(t, registry)
+
+		done := make(chan protocol.Reply, 1)
+		go func() {
+			result, _ := registry.ExecuteForClient(client, "BLPOP", []string{"queue", "0"})
+			done <- result
+		}()
+		waitFor(t, "BLPOP to block", func() bool {
+			return registry.store.Blocking().Blocked("queue", store.BlockOnList) == 1
+		})
+
+		clientConn.Close()
+		select {
+		case result := <-done:
+			if result != (protocol.NullArray{}) {
+				t.Errorf("Expected null array after disconnect, got %v", result)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("BLPOP was not cancelled after disconnect")
+		}
+
+		registry.store.RPUSH("queue", "value")
+		if got := registry.store.LLEN("queue"); got != 1 {
+			t.Errorf("Expected the pushed value to remain, got length %d", got)
+		}
+	})
+
+	// 테스트 케이스 2: 대기 중에 받은 다음 요청은 버퍼에 남아 대기가 끝난 뒤 읽힘
+	t.Run("PipelinedRequestPreserved", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		client, reader, clientConn := newPipeClient(t, registry)
+
+		done := make(chan protocol.Reply, 1)
+		go func() {
+			result, _ := registry.ExecuteForClient(client, "BLPOP", []string{"queue", "0"})
+			done <- result
+		}()
+		waitFor(t, "BLPOP to block", func() bool {
+			return registry.store.Blocking().Blocked("queue", store.BlockOnList) == 1
+		})
+
+		if _, err := clientConn.Write([]byte("*1\r\n$4\r\nPING\r\n")); err != nil {
+			t.Fatal(err)
+		}
+		registry.store.RPUSH("queue", "value")
+
+		select {
+		case result := <-done:
+			expected := protocol.BulkStrings{"queue", "value"}
+			got, ok := result.(protocol.BulkStrings)
+			if !ok || len(got) != 2 || got[0] != expected[0] || got[1] != expected[1] {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("BLPOP did not return after RPUSH")
+		}
+
+		value, err := protocol.NewParser(reader).ParseRequest()
+		if err != nil {
+			t.Fatalf("Failed to read pipelined request: %v", err)
+		}
+		if arr, ok := value.([]interface{}); !ok || len(arr) != 1 || arr[0] != "PING" {
+			t.Errorf("Expected [PING], got %v", value)
+		}
+	})
+
+	// 테스트 케이스 3: 시간 초과로 끝난 대기자는 큐에서 제거됨
+	t.Run("TimedOutWaiterRemoved", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		client, _, _ := newPipeClient(t, registry)
+
+		result, err := registry.ExecuteForClient(client, "BLPOP", []string{"queue", "0.05"})
+		if err != nil || result != (protocol.NullArray{}) {
+			t.Fatalf("Expected null array on timeout, got %v (err %v)", result, err)
+		}
+		if n := registry.store.Blocking().Blocked("queue", store.BlockOnList); n != 0 {
+			t.Errorf("Expected no blocked clients after timeout, got %d", n)
+		}
+	})
+}
+
+// TestBlockingFairness는 추가된 값이 가장 오래 기다린 클라이언트부터 전달되는지 테스트합니다.
+func TestBlockingFairness(t *testing.T) {
+	// 테스트 케이스 1: 같은 키를 기다리는 클라이언트들은 대기한 순서대로 값을 받음
+	t.Run("FIFOOnSameKey", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		const waiters = 5
+		results := make([]chan protocol.Reply, waiters)
+		for i := 0; i < waiters; i++ {
+			results[i] = make(chan protocol.Reply, 1)
+			client, _ := newTestClient(registry)
+			go func(ch chan protocol.Reply) {
+				result, _ := registry.ExecuteForClient(client, "BLPOP", []string{"queue", "0"})
+				ch <- result
+			}(results[i])
+			waitFor(t, "BLPOP to block", func() bool {
+				return registry.store.Blocking().Blocked("queue", store.BlockOnList) == i+1
+			})
+		}
+
+		registry.store.RPUSH("queue", "v0", "v1", "v2", "v3", "v4")
+		for i, ch := range results {
+			select {
+			case result := <-ch:
+				got, ok := result.(protocol.BulkStrings)
+				if !ok || got[1] != "v"+strconv.Itoa(i) {
+					t.Errorf("Waiter %d: expected v%d, got %v", i, i, result)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatalf("Waiter %d did not receive a value", i)
+			}
+		}
+		if got := registry.store.LLEN("queue"); got != 0 {
+			t.Errorf("Expected all values delivered, %d left", got)
+		}
+	})
+
+	// 테스트 케이스 2: 여러 키를 기다리는 클라이언트는 값이 추가된 키에서 받음
+	t.Run("ServedFromSignalledKey", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		client, _ := newTestClient(registry)
+		done := make(chan protocol.Reply, 1)
+		go func() {
+			result, _ := registry.ExecuteForClient(client, "BLPOP", []string{"first", "second", "0"})
+			done <- result
+		}()
+		waitFor(t, "BLPOP to block", func() bool {
+			return registry.store.Blocking().Blocked("second", store.BlockOnList) == 1
+		})
+
+		registry.store.RPUSH("second", "value")
+		result := <-done
+		if got, ok := result.(protocol.BulkStrings); !ok || got[0] != "second" || got[1] != "value" {
+			t.Errorf("Expected [second value], got %v", result)
+		}
+		if n := registry.store.Blocking().Blocked("first", store.BlockOnList); n != 0 {
+			t.Errorf("Expected the served client to leave every queue, %d left on first", n)
+		}
+	})
+
+	// 테스트 케이스 3: 대기 중인 클라이언트가 있으면 추가 직후의 LPOP은 값을 가로채지 못함
+	t.Run("PushedValueReserved", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		client, _ := newTestClient(registry)
+		done := make(chan protocol.Reply, 1)
+		go func() {
+			result, _ := registry.ExecuteForClient(client, "BLPOP", []string{"queue", "0"})
+			done <- result
+		}()
+		waitFor(t, "BLPOP to block", func() bool {
+			return registry.store.Blocking().Blocked("queue", store.BlockOnList) == 1
+		})
+
+		registry.store.RPUSH("queue", "value")
+		if stolen := registry.store.LPOP("queue", nil); stolen != nil {
+			t.Errorf("LPOP took a value reserved for the blocked client: %v", *stolen.(*string))
+		}
+		if got, ok := (<-done).(protocol.BulkStrings); !ok || got[1] != "value" {
+			t.Errorf("Expected the blocked client to receive value, got %v", got)
+		}
+	})
+}
+
+// TestBlockingStress는 여러 생산자와 소비자가 동시에 실행될 때 모든 값이 정확히 한 번 전달되는지 테스트합니다.
+func TestBlockingStress(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	const producers = 8
+	const perProducer = 200
+	const consumers = 8
+	const poppers = 4
+	total := producers * perProducer
+
+	var mu sync.Mutex
+	seen := make(map[string]int)
+	record := func(value string) {
+		mu.Lock()
+		seen[value]++
+		mu.Unlock()
+	}
+	received := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(seen)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+
+	// 대기하는 소비자: 짧은 시간 초과로 반복 (시간 초과된 대기자가 값을 가져가지 않아야 함)
+	for i := 0; i < consumers; i++ {
+		client, _ := newTestClient(registry)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				result, err := registry.ExecuteForClient(client, "BLPOP", []string{"q1", "q2", "0.01"})
+				if err != nil {
+					t.Errorf("BLPOP failed: %v", err)
+					return
+				}
+				if pair, ok := result.(protocol.BulkStrings); ok {
+					record(pair[1])
+				}
+			}
+		}()
+	}
+
+	// 대기하지 않고 꺼내는 클라이언트
+	for i := 0; i < poppers; i++ {
+		client, _ := newTestClient(registry)
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				result, _ := registry.ExecuteForClient(client, "LPOP", []string{key})
+				if value, ok := result.(protocol.BulkString); ok {
+					record(string(value))
+				}
+			}
+		}([]string{"q1", "q2"}[i%2])
+	}
+
+	var producerWG sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		client, _ := newTestClient(registry)
+		producerWG.Add(1)
+		go func(p int) {
+			defer producerWG.Done()
+			for i := 0; i < perProducer; i++ {
+				cmd := "RPUSH"
+				if i%2 == 1 {
+					cmd = "LPUSH"
+				}
+				key := []string{"q1", "q2"}[(p+i)%2]
+				registry.ExecuteForClient(client, cmd, []string{key, strconv.Itoa(p) + ":" + strconv.Itoa(i)})
+			}
+		}(p)
+	}
+	producerWG.Wait()
+
+	waitFor(t, "all values to be consumed", func() bool {
+		return received() == total
+	})
+	close(stop)
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) != total {
+		t.Errorf("Expected %d distinct values delivered, got %d", total, len(seen))
+	}
+	for value, n := range seen {
+		if n != 1 {
+			t.Errorf("Value %s delivered %d times", value, n)
+		}
+	}
+}
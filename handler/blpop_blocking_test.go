@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/codecrafters-io/redis-starter-go/protocol"
 	"github.com/codecrafters-io/redis-starter-go/store"
 )
 
@@ -18,7 +19,7 @@ func TestBLPopBlocking(t *testing.T) {
 	// 테스트 케이스 1: 짧은 타임아웃 후 값 추가
 	t.Run("ShortTimeoutWithValueAdded", func(t *testing.T) {
 		var wg sync.WaitGroup
-		var result interface{}
+		var result protocol.Reply
 		var err error
 
 		// 고루틴에서 BLPOP 실행 (1초 타임아웃)
@@ -39,7 +40,7 @@ func TestBLPopBlocking(t *testing.T) {
 			t.Fatalf("BLPOP should not fail: %v", err)
 		}
 
-		resultArray, ok := result.([]string)
+		resultArray, ok := result.(protocol.BulkStrings)
 		if !ok {
 			t.Fatalf("Expected []string result, got %T", result)
 		}
@@ -59,7 +60,7 @@ func TestBLPopBlocking(t *testing.T) {
 			t.Fatalf("BLPOP should not fail on timeout: %v", err)
 		}
 
-		if _, ok := result.(*NullArray); !ok {
+		if _, ok := result.(protocol.NullArray); !ok {
 			t.Errorf("Expected NullArray result on timeout, got %v", result)
 		}
 
@@ -72,7 +73,7 @@ func TestBLPopBlocking(t *testing.T) {
 	// 테스트 케이스 3: 여러 클라이언트가 같은 키를 대기
 	t.Run("MultipleWaitersOnSameKey", func(t *testing.T) {
 		var wg sync.WaitGroup
-		results := make([]interface{}, 3)
+		results := make([]protocol.Reply, 3)
 		errors := make([]error, 3)
 
 		// 3개의 고루틴이 같은 키를 대기
@@ -100,9 +101,9 @@ func TestBLPopBlocking(t *testing.T) {
 				t.Fatalf("BLPOP %d should not fail: %v", i, errors[i])
 			}
 
-			if resultArray, ok := results[i].([]string); ok && len(resultArray) == 2 && resultArray[0] == "multi_wait" && resultArray[1] == "shared_value" {
+			if resultArray, ok := results[i].(protocol.BulkStrings); ok && len(resultArray) == 2 && resultArray[0] == "multi_wait" && resultArray[1] == "shared_value" {
 				successCount++
-			} else if _, ok := results[i].(*NullArray); ok {
+			} else if _, ok := results[i].(protocol.NullArray); ok {
 				timeoutCount++
 			} else {
 				t.Errorf("Expected [multi_wait, shared_value] or NullArray, got %v", results[i])
@@ -121,7 +122,7 @@ func TestBLPopBlocking(t *testing.T) {
 	// 테스트 케이스 4: 여러 키를 모니터링하다가 하나에 값 추가
 	t.Run("MultipleKeysOneGetsValue", func(t *testing.T) {
 		var wg sync.WaitGroup
-		var result interface{}
+		var result protocol.Reply
 		var err error
 
 		// key1, key2, key3을 모니터링
@@ -141,7 +142,7 @@ func TestBLPopBlocking(t *testing.T) {
 			t.Fatalf("BLPOP should not fail: %v", err)
 		}
 
-		resultArray, ok := result.([]string)
+		resultArray, ok := result.(protocol.BulkStrings)
 		if !ok {
 			t.Fatalf("Expected []string result, got %T", result)
 		}
@@ -154,7 +155,7 @@ func TestBLPopBlocking(t *testing.T) {
 	// 테스트 케이스 5: 순서 우선순위 테스트 (blocking 환경에서)
 	t.Run("KeyPriorityInBlocking", func(t *testing.T) {
 		var wg sync.WaitGroup
-		var result interface{}
+		var result protocol.Reply
 		var err error
 
 		// priority_low, priority_high 순서로 모니터링
@@ -175,7 +176,7 @@ func TestBLPopBlocking(t *testing.T) {
 			t.Fatalf("BLPOP should not fail: %v", err)
 		}
 
-		resultArray, ok := result.([]string)
+		resultArray, ok := result.(protocol.BulkStrings)
 		if !ok {
 			t.Fatalf("Expected []string result, got %T", result)
 		}
@@ -189,7 +190,7 @@ func TestBLPopBlocking(t *testing.T) {
 	// 테스트 케이스 6: LPUSH로 값 추가 시 알림
 	t.Run("LPUSHTriggersWaiters", func(t *testing.T) {
 		var wg sync.WaitGroup
-		var result interface{}
+		var result protocol.Reply
 		var err error
 
 		wg.Add(1)
@@ -208,7 +209,7 @@ func TestBLPopBlocking(t *testing.T) {
 			t.Fatalf("BLPOP should not fail: %v", err)
 		}
 
-		resultArray, ok := result.([]string)
+		resultArray, ok := result.(protocol.BulkStrings)
 		if !ok {
 			t.Fatalf("Expected []string result, got %T", result)
 		}
@@ -222,7 +223,7 @@ func TestBLPopBlocking(t *testing.T) {
 	t.Run("ConsecutiveBlockingRequests", func(t *testing.T) {
 		// 첫 번째 요청
 		var wg1 sync.WaitGroup
-		var result1 interface{}
+		var result1 protocol.Reply
 		var err1 error
 
 		wg1.Add(1)
@@ -239,7 +240,7 @@ func TestBLPopBlocking(t *testing.T) {
 			t.Fatalf("First BLPOP should not fail: %v", err1)
 		}
 
-		resultArray1, ok := result1.([]string)
+		resultArray1, ok := result1.(protocol.BulkStrings)
 		if !ok {
 			t.Fatalf("Expected []string result, got %T", result1)
 		}
@@ -250,7 +251,7 @@ func TestBLPopBlocking(t *testing.T) {
 
 		// 두 번째 요청 (바로 이어서)
 		var wg2 sync.WaitGroup
-		var result2 interface{}
+		var result2 protocol.Reply
 		var err2 error
 
 		wg2.Add(1)
@@ -267,7 +268,7 @@ func TestBLPopBlocking(t *testing.T) {
 			t.Fatalf("Second BLPOP should not fail: %v", err2)
 		}
 
-		resultArray2, ok := result2.([]string)
+		resultArray2, ok := result2.(protocol.BulkStrings)
 		if !ok {
 			t.Fatalf("Expected []string result, got %T", result2)
 		}
@@ -294,7 +295,7 @@ func TestBLPopInfiniteWait(t *testing.T) {
 		t.Fatalf("BLPOP should not fail: %v", err)
 	}
 
-	resultArray, ok := result.([]string)
+	resultArray, ok := result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -311,7 +312,7 @@ func TestBLPopInfiniteWait(t *testing.T) {
 	// 테스트 케이스 2: timeout=0, 무한 대기 후 값 추가
 	t.Run("InfiniteWaitWithValueAdded", func(t *testing.T) {
 		var wg sync.WaitGroup
-		var result interface{}
+		var result protocol.Reply
 		var err error
 
 		// 고루틴에서 BLPOP 실행 (무한 대기)
@@ -332,7 +333,7 @@ func TestBLPopInfiniteWait(t *testing.T) {
 			t.Fatalf("BLPOP should not fail: %v", err)
 		}
 
-		resultArray, ok := result.([]string)
+		resultArray, ok := result.(protocol.BulkStrings)
 		if !ok {
 			t.Fatalf("Expected []string result, got %T", result)
 		}
@@ -352,7 +353,7 @@ func TestBLPopInfiniteWait(t *testing.T) {
 			t.Fatalf("BLPOP with float timeout should not fail: %v", err)
 		}
 
-		if _, ok := result.(*NullArray); !ok {
+		if _, ok := result.(protocol.NullArray); !ok {
 			t.Errorf("Expected NullArray result on timeout, got %v", result)
 		}
 
@@ -365,7 +366,7 @@ func TestBLPopInfiniteWait(t *testing.T) {
 	// 테스트 케이스: 소수점 timeout 중 값 추가
 	t.Run("FloatTimeoutWithValueAdded", func(t *testing.T) {
 		var wg sync.WaitGroup
-		var result interface{}
+		var result protocol.Reply
 		var err error
 
 		// 고루틴에서 BLPOP 실행 (0.5초 타임아웃)
@@ -386,7 +387,7 @@ func TestBLPopInfiniteWait(t *testing.T) {
 			t.Fatalf("BLPOP should not fail: %v", err)
 		}
 
-		resultArray, ok := result.([]string)
+		resultArray, ok := result.(protocol.BulkStrings)
 		if !ok {
 			t.Fatalf("Expected []string result, got %T", result)
 		}
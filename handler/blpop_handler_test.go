@@ -3,6 +3,7 @@ package handler
 import (
 	"testing"
 
+	"github.com/codecrafters-io/redis-starter-go/protocol"
 	"github.com/codecrafters-io/redis-starter-go/store"
 )
 
@@ -23,7 +24,7 @@ func TestBLPopHandler(t *testing.T) {
 	}
 	
 	// 결과는 [key, value] 배열이어야 함
-	resultArray, ok := result.([]string)
+	resultArray, ok := result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -49,7 +50,7 @@ func TestBLPopHandler(t *testing.T) {
 		t.Fatalf("BLPOP on non-existent key should not fail: %v", err)
 	}
 	
-	if _, ok := result.(*NullArray); !ok {
+	if _, ok := result.(protocol.NullArray); !ok {
 		t.Errorf("Expected NullArray for non-existent key, got %v", result)
 	}
 
@@ -62,7 +63,7 @@ func TestBLPopHandler(t *testing.T) {
 		t.Fatalf("BLPOP on empty list should not fail: %v", err)
 	}
 	
-	if _, ok := result.(*NullArray); !ok {
+	if _, ok := result.(protocol.NullArray); !ok {
 		t.Errorf("Expected NullArray for empty list, got %v", result)
 	}
 
@@ -77,7 +78,7 @@ func TestBLPopHandler(t *testing.T) {
 		t.Fatalf("BLPOP with multiple keys failed: %v", err)
 	}
 	
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -93,7 +94,7 @@ func TestBLPopHandler(t *testing.T) {
 		t.Fatalf("BLPOP with multiple keys (second has value) failed: %v", err)
 	}
 	
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -108,7 +109,7 @@ func TestBLPopHandler(t *testing.T) {
 		t.Fatalf("BLPOP with all empty keys should not fail: %v", err)
 	}
 	
-	if _, ok := result.(*NullArray); !ok {
+	if _, ok := result.(protocol.NullArray); !ok {
 		t.Errorf("Expected NullArray when all keys are empty, got %v", result)
 	}
 
@@ -123,7 +124,7 @@ func TestBLPopHandler(t *testing.T) {
 		t.Fatalf("BLPOP priority test failed: %v", err)
 	}
 	
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -143,7 +144,7 @@ func TestBLPopHandler(t *testing.T) {
 		t.Fatalf("BLPOP with timeout=1 failed: %v", err)
 	}
 	
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -158,7 +159,7 @@ func TestBLPopHandler(t *testing.T) {
 		t.Fatalf("BLPOP with timeout=0 should not fail: %v", err)
 	}
 	
-	if _, ok := result.(*NullArray); !ok {
+	if _, ok := result.(protocol.NullArray); !ok {
 		t.Errorf("Expected NullArray for timeout on empty key, got %v", result)
 	}
 
@@ -218,7 +219,7 @@ func TestBLPopHandler(t *testing.T) {
 		t.Fatalf("Work queue scenario failed: %v", err)
 	}
 	
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -246,7 +247,7 @@ func TestBLPopHandler(t *testing.T) {
 		t.Fatalf("Bulk keys test failed: %v", err)
 	}
 	
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -263,7 +264,7 @@ func TestBLPopHandler(t *testing.T) {
 		t.Fatalf("BLPOP with empty string value failed: %v", err)
 	}
 	
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -278,7 +279,7 @@ func TestBLPopHandler(t *testing.T) {
 		t.Fatalf("BLPOP second value failed: %v", err)
 	}
 	
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -0,0 +1,409 @@
+package handler
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/pubsub"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// Client는 하나의 클라이언트 연결에 대한 상태를 보관합니다.
+//
+// 대부분의 명령어는 연결 상태가 필요 없지만,
+// SUBSCRIBE처럼 연결마다 상태를 가지는 명령어는 Client를 통해 접근합니다.
+//
+// 동시성:
+//   - 명령어 응답은 연결을 처리하는 고루틴에서 작성됨
+//   - 발행 메시지는 PUBLISH를 실행한 다른 고루틴에서 작성됨
+//   - 두 경로 모두 mu를 잡고 쓰므로 RESP 메시지가 섞이지 않음
+type Client struct {
+	// ID는 서버 내에서 유일한 클라이언트 식별자입니다.
+	ID int64
+
+	// addr과 laddr은 연결의 원격 주소와 로컬 주소(ip:port)이고, conn은 서버가 연결을 끊을 때 사용합니다.
+	// 가상의 연결이면 빈 문자열과 nil입니다.
+	// info는 CLIENT LIST가 보고하는 연결 정보입니다.
+	// 모두 다른 연결의 고루틴(CLIENT LIST, CLIENT KILL)이 읽으므로 infoMu로 보호합니다.
+	infoMu sync.Mutex
+	addr   string
+	laddr  string
+	conn   io.Closer
+	info   clientInfo
+
+	// mu는 writer에 대한 동시 쓰기를 막습니다.
+	mu     sync.Mutex
+	writer *protocol.Writer
+
+	// reader는 연결 고루틴이 요청을 읽는 버퍼입니다 (SetRequestReader).
+	// 대기 명령어가 대기하는 동안 연결이 끊어지는지 확인하는 데 사용하며, 연결 고루틴에서만 접근합니다.
+	reader *bufio.Reader
+
+	// channels, patterns, shardChannels는 이 연결이 구독 중인 채널/패턴/샤드 채널 목록입니다.
+	// 연결 고루틴에서만 접근하므로 별도의 잠금이 필요 없습니다.
+	channels      map[string]struct{}
+	patterns      map[string]struct{}
+	shardChannels map[string]struct{}
+
+	// closing은 현재 응답을 보낸 뒤 연결을 종료해야 하는지 여부입니다 (QUIT).
+	closing bool
+
+	// inMulti는 MULTI 이후 EXEC/DISCARD 전인지 여부이고,
+	// queued는 그동안 대기열에 쌓인 명령어들입니다.
+	// multiDirty는 대기열에 넣는 중 오류가 있었는지 여부입니다 (EXEC가 EXECABORT로 실패).
+	inMulti    bool
+	queued     []queuedCommand
+	multiDirty bool
+
+	// replListeningPort는 REPLCONF listening-port로 받은 레플리카의 포트이고,
+	// replica는 PSYNC 이후 이 연결이 레플리카가 되었을 때의 복제 상태입니다.
+	// 레플리카 연결에는 명령어 응답을 보내지 않고 복제 스트림만 보냅니다.
+	replListeningPort int
+	replCapaEOF       bool // REPLCONF capa eof (EOF 표식 형식의 RDB 페이로드 지원)
+	replica           *replicaConn
+
+	// master는 레플리카가 마스터의 복제 스트림을 실행하는 연결인지 여부입니다.
+	// 읽기 전용 레플리카에서도 이 연결의 쓰기 명령어는 실행됩니다.
+	master bool
+
+	// asking은 ASKING 바로 다음 명령어인지 여부입니다.
+	// 클러스터 모드에서 그 명령어 하나는 가져오는 중인(IMPORTING) 슬롯의 키도 실행합니다.
+	asking bool
+
+	// noEvict와 noTouch는 CLIENT NO-EVICT, CLIENT NO-TOUCH로 켠 연결 플래그입니다.
+	// noEvict이면 클라이언트 메모리가 한도를 넘어도 이 연결을 내보내지 않고 (백업 도구 등),
+	// noTouch이면 이 연결의 읽기가 키의 접근 정보(LRU/LFU)를 갱신하지 않습니다 (SCAN 도구 등).
+	noEvict bool
+	noTouch bool
+
+	// authenticated는 연결이 인증되었는지 여부입니다.
+	// requirepass가 없을 때 생성된 연결은 인증된 상태로 시작하고, 아니면 AUTH나 HELLO AUTH로 인증합니다.
+	authenticated bool
+}
+
+// clientInfo는 CLIENT LIST가 보고하는 연결 정보입니다.
+//
+// 구독 수나 트랜잭션 상태는 연결 고루틴만 접근하는 필드이므로,
+// 연결 고루틴이 명령어를 실행할 때마다(commandStarted, commandFinished) 복사해 둡니다.
+type clientInfo struct {
+	name            string    // CLIENT SETNAME으로 지정한 이름 (name)
+	created         time.Time // 연결된 시각 (age)
+	lastInteraction time.Time // 마지막 명령어를 받은 시각 (idle)
+	lastCommand     string    // 마지막으로 실행한 명령어, 소문자 (cmd)
+	executing       bool      // 명령어를 실행 중인지 여부 (대기 명령어 포함, 종료 시 DrainClients가 기다림)
+
+	kind            string // 연결 종류: normal, master, replica, pubsub (CLIENT LIST TYPE)
+	flags           string // 연결 상태 플래그 (flags)
+	sub, psub, ssub int    // 구독 중인 채널, 패턴, 샤드 채널 수
+	multi           int    // MULTI 중 대기열의 명령어 수, MULTI 중이 아니면 -1
+}
+
+// newClient는 writer로 응답을 보내는 새로운 Client를 생성합니다.
+func newClient(id int64, writer *protocol.Writer) *Client {
+	now := time.Now()
+	return &Client{
+		ID:            id,
+		writer:        writer,
+		channels:      make(map[string]struct{}),
+		patterns:      make(map[string]struct{}),
+		shardChannels: make(map[string]struct{}),
+		info: clientInfo{
+			created:         now,
+			lastInteraction: now,
+			lastCommand:     "NULL",
+			kind:            "normal",
+			flags:           "N",
+			multi:           -1,
+		},
+	}
+}
+
+// SetAddr는 연결의 원격 주소(ip:port)를 설정합니다.
+func (c *Client) SetAddr(addr string) {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	c.addr = addr
+}
+
+// SetConn은 클라이언트의 네트워크 연결을 설정합니다.
+// 원격 주소를 기록하고, 서버가 연결을 끊어야 할 때(disconnect) 사용합니다.
+func (c *Client) SetConn(conn net.Conn) {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	c.addr = conn.RemoteAddr().String()
+	c.laddr = conn.LocalAddr().String()
+	c.conn = conn
+}
+
+// Name은 CLIENT SETNAME으로 지정한 연결 이름을 반환합니다 (없으면 빈 문자열).
+func (c *Client) Name() string {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	return c.info.name
+}
+
+// setName은 연결 이름을 설정합니다 (CLIENT SETNAME).
+func (c *Client) setName(name string) {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	c.info.name = name
+}
+
+// commandStarted는 명령어를 받은 시각과 명령어 이름을 기록합니다 (CLIENT LIST의 idle, cmd).
+func (c *Client) commandStarted(cmdUpper string) {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	c.info.lastInteraction = time.Now()
+	c.info.lastCommand = strings.ToLower(cmdUpper)
+	c.info.executing = true
+}
+
+// commandFinished는 명령어 실행 후의 연결 상태를 다른 연결이 읽을 수 있도록 복사해 둡니다.
+// 연결 고루틴에서만 호출합니다.
+func (c *Client) commandFinished() {
+	kind, flags := "normal", ""
+	switch {
+	case c.master:
+		kind, flags = "master", "M"
+	case c.replica != nil:
+		kind, flags = "replica", "S"
+	case c.InSubscribeMode():
+		kind, flags = "pubsub", "P"
+	}
+	multi := -1
+	if c.inMulti {
+		flags += "x"
+		multi = len(c.queued)
+	}
+	if c.noEvict {
+		flags += "e"
+	}
+	if c.noTouch {
+		flags += "T"
+	}
+	if flags == "" {
+		flags = "N"
+	}
+
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	c.info.kind, c.info.flags, c.info.multi = kind, flags, multi
+	c.info.executing = false
+	c.info.sub, c.info.psub, c.info.ssub = len(c.channels), len(c.patterns), len(c.shardChannels)
+}
+
+// addresses는 연결의 원격 주소와 로컬 주소를 반환합니다.
+func (c *Client) addresses() (addr, laddr string) {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	return c.addr, c.laddr
+}
+
+// snapshot은 연결 정보의 복사본을 반환합니다.
+func (c *Client) snapshot() clientInfo {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	return c.info
+}
+
+// infoLine은 CLIENT LIST, CLIENT INFO가 보고하는 연결 정보 한 줄을 만듭니다.
+//
+// 형식 (Redis와 같은 필드 이름):
+//
+//	id=3 addr=127.0.0.1:50412 laddr=127.0.0.1:6379 name= age=12 idle=0 flags=N db=0 sub=0 psub=0 ssub=0 multi=-1 cmd=client user=default redir=-1 resp=2
+//
+// 매개변수:
+//   - now: age, idle을 계산할 기준 시각
+//   - redir: CLIENT TRACKING REDIRECT 대상 ID (추적하지 않으면 -1)
+func (c *Client) infoLine(now time.Time, redir int64) string {
+	c.infoMu.Lock()
+	info, addr, laddr := c.info, c.addr, c.laddr
+	c.infoMu.Unlock()
+
+	fields := []string{
+		"id=" + strconv.FormatInt(c.ID, 10),
+		"addr=" + addr,
+		"laddr=" + laddr,
+		"name=" + info.name,
+		"age=" + strconv.Itoa(int(now.Sub(info.created).Seconds())),
+		"idle=" + strconv.Itoa(int(now.Sub(info.lastInteraction).Seconds())),
+		"flags=" + info.flags,
+		"db=0",
+		"sub=" + strconv.Itoa(info.sub),
+		"psub=" + strconv.Itoa(info.psub),
+		"ssub=" + strconv.Itoa(info.ssub),
+		"multi=" + strconv.Itoa(info.multi),
+		"cmd=" + info.lastCommand,
+		"user=default",
+		"redir=" + strconv.FormatInt(redir, 10),
+		"resp=" + strconv.Itoa(c.Protocol()),
+	}
+	return strings.Join(fields, " ")
+}
+
+// disconnect는 연결을 끊습니다. 연결 고루틴의 읽기가 실패하면서 CloseClient로 정리됩니다.
+// 가상의 연결이면 아무 일도 하지 않습니다.
+func (c *Client) disconnect() {
+	c.infoMu.Lock()
+	conn := c.conn
+	c.infoMu.Unlock()
+	if conn != nil {
+		conn.Close()
+	}
+}
+
+// hasConn은 서버가 끊을 수 있는 네트워크 연결이 있는지 확인합니다 (가상의 연결이면 false).
+func (c *Client) hasConn() bool {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	return c.conn != nil
+}
+
+// WithWriter는 쓰기 잠금을 잡은 상태로 fn을 실행하고, 작성한 내용을 바로 보냅니다.
+// 명령어 응답을 작성할 때 사용하여 발행 메시지와 섞이지 않도록 합니다.
+func (c *Client) WithWriter(fn func(w *protocol.Writer)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fn(c.writer)
+	c.writer.Flush()
+}
+
+// WithBufferedWriter는 WithWriter와 같지만 작성한 내용을 보내지 않고 버퍼에 남겨 둡니다.
+// 파이프라이닝된 명령어들의 응답을 모았다가 Flush로 한 번에 보낼 때 사용합니다.
+func (c *Client) WithBufferedWriter(fn func(w *protocol.Writer)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fn(c.writer)
+}
+
+// Flush는 버퍼에 남아 있는 응답을 연결로 보냅니다.
+// 더 읽을 요청이 없거나 명령어가 대기하기 전에 호출하여 응답이 늦어지지 않도록 합니다.
+func (c *Client) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.writer.Flush()
+}
+
+// Protocol은 연결에서 사용 중인 RESP 버전(2 또는 3)을 반환합니다.
+func (c *Client) Protocol() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.writer.Protocol()
+}
+
+// SetProtocol은 연결의 RESP 버전을 변경합니다.
+// 발행 메시지를 작성 중인 다른 고루틴과 겹치지 않도록 쓰기 잠금 안에서 변경합니다.
+func (c *Client) SetProtocol(version int) {
+	c.WithWriter(func(w *protocol.Writer) {
+		w.SetProtocol(version)
+	})
+}
+
+// Deliver는 pubsub.Subscriber 인터페이스를 구현합니다.
+//
+// 전송 형식 (RESP3에서는 Push, RESP2에서는 Array):
+//   - 채널 구독: ["message", <채널>, <메시지>]
+//   - 패턴 구독: ["pmessage", <패턴>, <채널>, <메시지>]
+//   - 샤드 채널 구독: ["smessage", <채널>, <메시지>]
+func (c *Client) Deliver(msg pubsub.Message) {
+	switch {
+	case msg.Shard:
+		c.push("smessage", msg.Channel, msg.Payload)
+	case msg.Pattern != "":
+		c.push("pmessage", msg.Pattern, msg.Channel, msg.Payload)
+	default:
+		c.push("message", msg.Channel, msg.Payload)
+	}
+}
+
+// push는 쓰기 잠금을 잡고 문자열 요소들로 이루어진 Push 메시지를 작성합니다.
+func (c *Client) push(elements ...string) {
+	c.WithWriter(func(w *protocol.Writer) {
+		w.WritePushHeader(len(elements))
+		for _, element := range elements {
+			w.WriteBulkString(&element)
+		}
+	})
+}
+
+// SubscriptionCount는 구독 중인 채널과 패턴 수의 합을 반환합니다.
+// SUBSCRIBE/UNSUBSCRIBE 응답의 마지막 요소로 사용됩니다.
+func (c *Client) SubscriptionCount() int {
+	return len(c.channels) + len(c.patterns)
+}
+
+// ShardSubscriptionCount는 구독 중인 샤드 채널 수를 반환합니다.
+// SSUBSCRIBE/SUNSUBSCRIBE 응답의 마지막 요소로 사용됩니다.
+func (c *Client) ShardSubscriptionCount() int {
+	return len(c.shardChannels)
+}
+
+// InSubscribeMode는 연결이 구독 모드인지 확인합니다.
+// 채널, 패턴, 샤드 채널을 하나 이상 구독 중이면 구독 모드이며,
+// RESP2 연결은 이 상태에서 구독 관련 명령어와 PING/QUIT/RESET만 실행할 수 있습니다.
+// RESP3 연결은 발행 메시지가 Push로 구분되므로 제한이 없습니다.
+func (c *Client) InSubscribeMode() bool {
+	return c.SubscriptionCount()+c.ShardSubscriptionCount() > 0
+}
+
+// IsReplica는 PSYNC로 레플리카가 된 연결인지 확인합니다.
+// 레플리카 연결에는 명령어 응답을 보내지 않습니다 (복제 스트림과 섞이지 않도록).
+func (c *Client) IsReplica() bool {
+	return c.replica != nil
+}
+
+// ShouldClose는 현재 응답을 보낸 뒤 연결을 종료해야 하는지 확인합니다.
+func (c *Client) ShouldClose() bool {
+	return c.closing
+}
+
+// flagTransaction은 MULTI 중에 명령어를 대기열에 넣지 못한 경우 트랜잭션을 실패로 표시합니다.
+// MULTI 중이 아니면 아무 일도 하지 않습니다.
+func (c *Client) flagTransaction() {
+	if c.inMulti {
+		c.multiDirty = true
+	}
+}
+
+// resetTransaction은 진행 중인 트랜잭션 상태를 모두 지웁니다.
+func (c *Client) resetTransaction() {
+	c.inMulti = false
+	c.queued = nil
+	c.multiDirty = false
+}
+
+// subscribeModeCommands는 구독 모드에서 실행할 수 있는 명령어 목록입니다.
+var subscribeModeCommands = map[string]bool{
+	"SUBSCRIBE":    true,
+	"UNSUBSCRIBE":  true,
+	"PSUBSCRIBE":   true,
+	"PUNSUBSCRIBE": true,
+	"SSUBSCRIBE":   true,
+	"SUNSUBSCRIBE": true,
+	"PING":         true,
+	"QUIT":         true,
+	"RESET":        true,
+}
+
+// ClientCommandHandler는 연결 상태가 필요한 명령어 핸들러가 구현하는 인터페이스입니다.
+//
+// CommandRegistry.ExecuteForClient는 핸들러가 이 인터페이스를 구현하면
+// Execute 대신 ExecuteWithClient를 호출합니다.
+type ClientCommandHandler interface {
+	CommandHandler
+
+	// ExecuteWithClient는 명령어를 실행한 연결의 Client와 함께 명령어를 실행합니다.
+	ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error)
+}
+
+// 자주 쓰는 상태 응답입니다.
+var (
+	okReply     = protocol.SimpleString("OK")
+	queuedReply = protocol.SimpleString("QUEUED")
+)
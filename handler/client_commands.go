@@ -0,0 +1,420 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// ClientHandler는 CLIENT 명령어를 처리하는 핸들러입니다.
+//
+// Redis CLIENT 명령어 사양 (지원하는 서브커맨드):
+//   - CLIENT ID: 현재 연결의 클라이언트 ID
+//   - CLIENT LIST [TYPE normal|master|replica|pubsub] [ID id [id ...]]: 연결들의 정보 (한 줄에 하나)
+//   - CLIENT INFO: 현재 연결의 정보 (CLIENT LIST와 같은 형식)
+//   - CLIENT SETNAME name: 연결 이름 설정 (빈 문자열이면 이름 제거, 공백이나 특수 문자 불가)
+//   - CLIENT GETNAME: 연결 이름 (없으면 nil)
+//   - CLIENT NO-EVICT ON|OFF: 클라이언트 메모리 한도를 넘어도 이 연결을 내보내지 않음 (CLIENT LIST 플래그 e)
+//   - CLIENT NO-TOUCH ON|OFF: 이 연결의 읽기가 키의 접근 정보(LRU/LFU)를 갱신하지 않음 (CLIENT LIST 플래그 T)
+//   - CLIENT KILL ip:port: 해당 주소의 연결을 끊음 (예전 형식, 없으면 에러)
+//   - CLIENT KILL <filter> <value> [<filter> <value> ...]: 모든 필터와 일치하는 연결들을 끊고 개수를 반환
+//     (ADDR ip:port, LADDR ip:port, ID id, TYPE type, USER username, SKIPME yes|no, MAXAGE seconds)
+//   - CLIENT TRACKING <ON|OFF> [REDIRECT id] [BCAST] [PREFIX prefix ...]: 클라이언트 측 캐싱
+//   - CLIENT GETREDIR: 무효화 메시지 REDIRECT 대상 (-1: 추적 안 함, 0: 자기 자신)
+//   - CLIENT HELP: 서브커맨드들의 사용법
+//
+// 예시:
+//
+//	클라이언트: CLIENT TRACKING ON BCAST PREFIX user:
+//	서버: +OK\r\n
+type ClientHandler struct {
+	registry *CommandRegistry
+	tracking *trackingTable
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. CLIENT는 연결 상태가 필요합니다.
+func (h *ClientHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "CLIENT requires a client connection"}
+}
+
+// ExecuteWithClient는 CLIENT 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.Integer: ID, GETREDIR의 결과, 새 형식 KILL로 끊은 연결 수
+//   - protocol.BulkString: LIST, INFO의 결과, GETNAME의 결과
+//   - protocol.SimpleString: TRACKING, SETNAME, NO-EVICT, NO-TOUCH 성공 시 OK
+//   - nil: 이름이 없는 연결의 GETNAME
+//   - error: 서브커맨드가 없거나 알 수 없는 경우, 옵션이 잘못된 경우
+func (h *ClientHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return h.subcommands().dispatch(client, args, store)
+}
+
+// subcommands는 CLIENT의 서브커맨드 목록입니다.
+func (h *ClientHandler) subcommands() *subcommandTable {
+	return &subcommandTable{command: "CLIENT", subs: []subcommand{
+		{"ID", 2, []string{"ID", "Return the ID of the current connection."}, h.id},
+		{"LIST", -2, []string{"LIST [options ...]", "Return information about client connections. Options:",
+			"* TYPE (NORMAL|MASTER|REPLICA|PUBSUB)", "  Return clients of specified type.",
+			"* ID id [id ...]", "  Return clients of specified IDs only."}, h.list},
+		{"INFO", 2, []string{"INFO", "Return information about the current client connection."}, h.info},
+		{"SETNAME", 3, []string{"SETNAME <name>", "Assign the name <name> to the current connection."}, h.setName},
+		{"GETNAME", 2, []string{"GETNAME", "Return the name of the current connection."}, h.getName},
+		{"KILL", -3, []string{"KILL <ip:port>", "Kill connection made from <ip:port>.",
+			"KILL <option> <value> [<option> <value> [...]]", "Kill connections. Options are:",
+			"* ADDR (<ip:port>|<unixsocket>:0)", "  Kill connections made from the specified address",
+			"* LADDR (<ip:port>|<unixsocket>:0)", "  Kill connections made to specified local address",
+			"* TYPE (NORMAL|MASTER|REPLICA|PUBSUB)", "  Kill connections by type.",
+			"* USER <username>", "  Kill connections authenticated by <username>.",
+			"* SKIPME (YES|NO)", "  Skip killing current connection (default: yes).",
+			"* ID <client-id>", "  Kill connections by client id.",
+			"* MAXAGE <maxage>", "  Kill connections older than the specified age."}, h.kill},
+		{"NO-EVICT", 3, []string{"NO-EVICT (ON|OFF)", "Protect current client connection from eviction."}, h.noEvict},
+		{"NO-TOUCH", 3, []string{"NO-TOUCH (ON|OFF)", "Will not touch LRU/LFU stats when this mode is on."}, h.noTouch},
+		{"TRACKING", -3, []string{"TRACKING (ON|OFF) [REDIRECT <id>] [BCAST] [PREFIX <prefix> [...]]",
+			"Control server assisted client side caching."}, h.setTracking},
+		{"GETREDIR", 2, []string{"GETREDIR", "Return the client ID we are redirecting to when tracking is enabled."}, h.getRedir},
+	}}
+}
+
+// id는 CLIENT ID를 실행합니다.
+func (h *ClientHandler) id(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return protocol.Integer(client.ID), nil
+}
+
+// list는 CLIENT LIST를 실행합니다.
+func (h *ClientHandler) list(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return h.executeList(args[1:])
+}
+
+// info는 CLIENT INFO를 실행합니다.
+func (h *ClientHandler) info(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return protocol.BulkString(h.infoLine(client, time.Now()) + "\n"), nil
+}
+
+// setName은 CLIENT SETNAME을 실행합니다.
+func (h *ClientHandler) setName(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if !validClientName(args[1]) {
+		return nil, &InvalidArgumentError{Message: "Client names cannot contain spaces, newlines or special characters."}
+	}
+	client.setName(args[1])
+	return okReply, nil
+}
+
+// getName은 CLIENT GETNAME을 실행합니다. 이름이 없으면 nil입니다.
+func (h *ClientHandler) getName(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if name := client.Name(); name != "" {
+		return protocol.BulkString(name), nil
+	}
+	return nil, nil
+}
+
+// kill은 CLIENT KILL을 실행합니다.
+func (h *ClientHandler) kill(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return h.executeKill(client, args[1:])
+}
+
+// noEvict는 CLIENT NO-EVICT를 실행합니다.
+func (h *ClientHandler) noEvict(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	on, err := parseOnOff(args[1])
+	if err != nil {
+		return nil, err
+	}
+	client.noEvict = on
+	return okReply, nil
+}
+
+// noTouch는 CLIENT NO-TOUCH를 실행합니다.
+func (h *ClientHandler) noTouch(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	on, err := parseOnOff(args[1])
+	if err != nil {
+		return nil, err
+	}
+	client.noTouch = on
+	return okReply, nil
+}
+
+// setTracking은 CLIENT TRACKING을 실행합니다.
+func (h *ClientHandler) setTracking(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return h.executeTracking(client, args[1:])
+}
+
+// getRedir는 CLIENT GETREDIR를 실행합니다. 추적하지 않으면 -1입니다.
+func (h *ClientHandler) getRedir(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	opts, enabled := h.tracking.options(client)
+	if !enabled {
+		return protocol.Integer(-1), nil
+	}
+	return protocol.Integer(opts.redirect), nil
+}
+
+// parseOnOff는 ON 또는 OFF 인자를 해석합니다 (대소문자 구분 없음).
+func parseOnOff(arg string) (bool, error) {
+	switch strings.ToUpper(arg) {
+	case "ON":
+		return true, nil
+	case "OFF":
+		return false, nil
+	}
+	return false, &InvalidArgumentError{Message: "syntax error"}
+}
+
+// executeList는 CLIENT LIST 서브커맨드를 실행합니다.
+// 연결들을 ID 순으로 한 줄씩 보고합니다.
+//
+// 옵션:
+//   - TYPE type: normal, master, replica(slave), pubsub 중 해당 종류의 연결만
+//   - ID id [id ...]: 지정한 ID의 연결만
+func (h *ClientHandler) executeList(args []string) (protocol.Reply, error) {
+	var kind string
+	var ids map[int64]bool
+	for i := 0; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "TYPE":
+			if i+1 >= len(args) {
+				return nil, &InvalidArgumentError{Message: "syntax error"}
+			}
+			i++
+			var ok bool
+			if kind, ok = parseClientType(args[i]); !ok {
+				return nil, &InvalidArgumentError{Message: "Unknown client type '" + args[i] + "'"}
+			}
+		case "ID":
+			if i+1 >= len(args) {
+				return nil, &InvalidArgumentError{Message: "syntax error"}
+			}
+			ids = make(map[int64]bool)
+			for i++; i < len(args); i++ {
+				id, err := strconv.ParseInt(args[i], 10, 64)
+				if err != nil || id <= 0 {
+					return nil, &InvalidArgumentError{Message: "Invalid client ID"}
+				}
+				ids[id] = true
+			}
+		default:
+			return nil, &InvalidArgumentError{Message: "syntax error"}
+		}
+	}
+
+	now := time.Now()
+	var sb strings.Builder
+	for _, c := range h.registry.connectedClients() {
+		if kind != "" && c.snapshot().kind != kind {
+			continue
+		}
+		if ids != nil && !ids[c.ID] {
+			continue
+		}
+		sb.WriteString(h.infoLine(c, now) + "\n")
+	}
+	return protocol.BulkString(sb.String()), nil
+}
+
+// clientFilter는 CLIENT KILL이 끊을 연결의 조건입니다. 지정하지 않은 조건은 검사하지 않습니다.
+type clientFilter struct {
+	addr   string        // 원격 주소 (ADDR)
+	laddr  string        // 로컬 주소 (LADDR)
+	id     int64         // 클라이언트 ID (ID)
+	kind   string        // 연결 종류 (TYPE)
+	maxAge time.Duration // 이보다 오래된 연결만 (MAXAGE)
+	skipMe bool          // 명령어를 보낸 연결은 제외 (SKIPME, 기본값 yes)
+}
+
+// matches는 연결이 모든 조건과 일치하는지 확인합니다.
+func (f *clientFilter) matches(c, self *Client, now time.Time) bool {
+	addr, laddr := c.addresses()
+	info := c.snapshot()
+	switch {
+	case f.skipMe && c == self,
+		f.addr != "" && addr != f.addr,
+		f.laddr != "" && laddr != f.laddr,
+		f.id != 0 && c.ID != f.id,
+		f.kind != "" && info.kind != f.kind,
+		f.maxAge > 0 && now.Sub(info.created) < f.maxAge:
+		return false
+	}
+	return true
+}
+
+// executeKill은 CLIENT KILL 서브커맨드를 실행합니다.
+//
+// 다른 연결은 네트워크 연결을 닫아 끊고(연결 고루틴의 읽기가 실패하면서 정리됨),
+// 명령어를 보낸 연결 자신은 응답을 보낸 뒤 끊습니다.
+// 가상의 연결(스크립트, AOF 복원 등)은 끊을 수 없지만 개수에는 포함됩니다.
+func (h *ClientHandler) executeKill(client *Client, args []string) (protocol.Reply, error) {
+	if len(args) == 0 {
+		return nil, &WrongNumberOfArgumentsError{Command: "client|kill"}
+	}
+
+	// 예전 형식: CLIENT KILL ip:port
+	if len(args) == 1 {
+		filter := &clientFilter{addr: args[0]}
+		if h.killClients(client, filter) == 0 {
+			return nil, &InvalidArgumentError{Message: "No such client"}
+		}
+		return okReply, nil
+	}
+
+	filter := &clientFilter{skipMe: true}
+	if len(args)%2 != 0 {
+		return nil, &InvalidArgumentError{Message: "syntax error"}
+	}
+	for i := 0; i < len(args); i += 2 {
+		value := args[i+1]
+		switch strings.ToUpper(args[i]) {
+		case "ADDR":
+			filter.addr = value
+		case "LADDR":
+			filter.laddr = value
+		case "ID":
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || id <= 0 {
+				return nil, &InvalidArgumentError{Message: "client-id should be greater than 0"}
+			}
+			filter.id = id
+		case "TYPE":
+			kind, ok := parseClientType(value)
+			if !ok {
+				return nil, &InvalidArgumentError{Message: "Unknown client type '" + value + "'"}
+			}
+			filter.kind = kind
+		case "USER":
+			// ACL이 없으므로 모든 연결은 default 사용자
+			if value != "default" {
+				return nil, &InvalidArgumentError{Message: "No such user '" + value + "'"}
+			}
+		case "SKIPME":
+			switch strings.ToLower(value) {
+			case "yes":
+				filter.skipMe = true
+			case "no":
+				filter.skipMe = false
+			default:
+				return nil, &InvalidArgumentError{Message: "syntax error"}
+			}
+		case "MAXAGE":
+			seconds, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || seconds <= 0 {
+				return nil, &InvalidArgumentError{Message: "value is not an integer or out of range"}
+			}
+			filter.maxAge = time.Duration(seconds) * time.Second
+		default:
+			return nil, &InvalidArgumentError{Message: "syntax error"}
+		}
+	}
+	return protocol.Integer(h.killClients(client, filter)), nil
+}
+
+// killClients는 조건과 일치하는 연결들을 끊고 그 개수를 반환합니다.
+func (h *ClientHandler) killClients(self *Client, filter *clientFilter) int {
+	now := time.Now()
+	killed := 0
+	for _, c := range h.registry.connectedClients() {
+		if !filter.matches(c, self, now) {
+			continue
+		}
+		if c == self {
+			self.closing = true
+		} else {
+			c.disconnect()
+		}
+		killed++
+	}
+	return killed
+}
+
+// infoLine은 연결 하나의 CLIENT LIST 줄을 만듭니다 (줄바꿈 제외).
+func (h *ClientHandler) infoLine(client *Client, now time.Time) string {
+	redir := int64(-1)
+	if opts, enabled := h.tracking.options(client); enabled {
+		redir = opts.redirect
+	}
+	return client.infoLine(now, redir)
+}
+
+// parseClientType은 CLIENT LIST, CLIENT KILL의 TYPE 값을 연결 종류로 변환합니다.
+// slave는 replica와 같습니다.
+func parseClientType(value string) (string, bool) {
+	switch kind := strings.ToLower(value); kind {
+	case "normal", "master", "replica", "pubsub":
+		return kind, true
+	case "slave":
+		return "replica", true
+	}
+	return "", false
+}
+
+// validClientName은 CLIENT SETNAME의 이름이 공백과 특수 문자 없이 출력 가능한 ASCII 문자로만 이루어졌는지 확인합니다.
+func validClientName(name string) bool {
+	for i := 0; i < len(name); i++ {
+		if name[i] < '!' || name[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// executeTracking은 CLIENT TRACKING 서브커맨드를 실행합니다.
+//
+// 옵션:
+//   - REDIRECT id: 무효화 메시지를 다른 연결로 보냄 (RESP2에서는 대상이 __redis__:invalidate 구독 필요)
+//   - BCAST: 읽은 키와 관계없이 모든 키(또는 PREFIX와 매칭되는 키) 변경을 알림
+//   - PREFIX prefix: BCAST 모드에서 알림을 받을 키 접두사 (여러 번 지정 가능)
+func (h *ClientHandler) executeTracking(client *Client, args []string) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "client|tracking"}
+	}
+
+	switch strings.ToUpper(args[0]) {
+	case "OFF":
+		if len(args) != 1 {
+			return nil, &InvalidArgumentError{Message: "syntax error"}
+		}
+		h.tracking.disable(client)
+		return okReply, nil
+	case "ON":
+	default:
+		return nil, &InvalidArgumentError{Message: "syntax error"}
+	}
+
+	opts := trackingOptions{}
+	for i := 1; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "REDIRECT":
+			if i+1 >= len(args) {
+				return nil, &InvalidArgumentError{Message: "syntax error"}
+			}
+			i++
+			id, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return nil, &InvalidArgumentError{Message: "value is not an integer or out of range"}
+			}
+			if h.tracking.lookup(id) == nil {
+				return nil, &InvalidArgumentError{Message: "The client ID you want redirect to does not exist"}
+			}
+			opts.redirect = id
+
+		case "BCAST":
+			opts.bcast = true
+
+		case "PREFIX":
+			if i+1 >= len(args) {
+				return nil, &InvalidArgumentError{Message: "syntax error"}
+			}
+			i++
+			opts.prefixes = append(opts.prefixes, args[i])
+
+		default:
+			return nil, &InvalidArgumentError{Message: "syntax error"}
+		}
+	}
+
+	if len(opts.prefixes) > 0 && !opts.bcast {
+		return nil, &InvalidArgumentError{Message: "PREFIX option requires BCAST mode to be enabled"}
+	}
+
+	h.tracking.enable(client, opts)
+	return okReply, nil
+}
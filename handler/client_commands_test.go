@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"errors"
+	"net"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestClientIDHandler는 CLIENT ID 명령어를 테스트합니다.
+func TestClientIDHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	first, _ := newTestClient(registry)
+	second, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: 연결마다 서로 다른 ID
+	firstID, err := registry.ExecuteForClient(first, "CLIENT", []string{"ID"})
+	if err != nil {
+		t.Fatalf("CLIENT ID failed: %v", err)
+	}
+	secondID, _ := registry.ExecuteForClient(second, "CLIENT", []string{"id"})
+	if firstID == secondID {
+		t.Errorf("Expected different IDs, got %v and %v", firstID, secondID)
+	}
+
+	// 테스트 케이스 2: 알 수 없는 서브커맨드 (에러 케이스)
+	if _, err := registry.ExecuteForClient(first, "CLIENT", []string{"UNKNOWN"}); err == nil {
+		t.Error("Expected error for unknown subcommand")
+	}
+}
+
+// TestClientTrackingHandler는 CLIENT TRACKING과 무효화 메시지 전달을 테스트합니다.
+func TestClientTrackingHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, buf := newTestClient(registry)
+	client.SetProtocol(3)
+
+	// 테스트 케이스 1: 추적을 켠 뒤 읽은 키가 변경되면 무효화 메시지 전달
+	if _, err := registry.ExecuteForClient(client, "CLIENT", []string{"TRACKING", "ON"}); err != nil {
+		t.Fatalf("CLIENT TRACKING ON failed: %v", err)
+	}
+	registry.ExecuteForClient(client, "GET", []string{"user:1"})
+	registry.Execute("SET", []string{"user:1", "alice"})
+
+	want := ">2\r\n$10\r\ninvalidate\r\n*1\r\n$6\r\nuser:1\r\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	// 테스트 케이스 2: 한 번 알린 키는 다시 읽기 전까지 알리지 않음
+	buf.Reset()
+	registry.Execute("SET", []string{"user:1", "bob"})
+	if buf.Len() != 0 {
+		t.Errorf("Expected no message, got %q", buf.String())
+	}
+
+	// 테스트 케이스 3: 읽지 않은 키는 알리지 않음
+	registry.Execute("SET", []string{"user:2", "carol"})
+	if buf.Len() != 0 {
+		t.Errorf("Expected no message for unread key, got %q", buf.String())
+	}
+
+	// 테스트 케이스 4: 추적을 끄면 알림 없음
+	registry.ExecuteForClient(client, "GET", []string{"user:1"})
+	registry.ExecuteForClient(client, "CLIENT", []string{"TRACKING", "OFF"})
+	registry.Execute("SET", []string{"user:1", "dave"})
+	if buf.Len() != 0 {
+		t.Errorf("Expected no message after OFF, got %q", buf.String())
+	}
+
+	// 테스트 케이스 5: BCAST 없이 PREFIX (에러 케이스)
+	if _, err := registry.ExecuteForClient(client, "CLIENT", []string{"TRACKING", "ON", "PREFIX", "user:"}); err == nil {
+		t.Error("Expected error for PREFIX without BCAST")
+	}
+}
+
+// TestClientTrackingBroadcast는 BCAST 모드의 접두사 기반 알림을 테스트합니다.
+func TestClientTrackingBroadcast(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, buf := newTestClient(registry)
+	client.SetProtocol(3)
+
+	registry.ExecuteForClient(client, "CLIENT", []string{"TRACKING", "ON", "BCAST", "PREFIX", "user:"})
+
+	// 테스트 케이스 1: 읽지 않아도 접두사와 매칭되는 키 변경은 매번 알림
+	registry.Execute("SET", []string{"user:1", "alice"})
+	registry.Execute("RPUSH", []string{"user:list", "a"})
+	want := ">2\r\n$10\r\ninvalidate\r\n*1\r\n$6\r\nuser:1\r\n" +
+		">2\r\n$10\r\ninvalidate\r\n*1\r\n$9\r\nuser:list\r\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	// 테스트 케이스 2: 접두사와 매칭되지 않는 키는 알리지 않음
+	buf.Reset()
+	registry.Execute("SET", []string{"order:1", "x"})
+	if buf.Len() != 0 {
+		t.Errorf("Expected no message, got %q", buf.String())
+	}
+}
+
+// TestClientTrackingRedirect는 REDIRECT 대상 연결로 무효화 메시지가 전달되는지 테스트합니다.
+func TestClientTrackingRedirect(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, clientBuf := newTestClient(registry)
+	target, targetBuf := newTestClient(registry)
+
+	id, _ := registry.ExecuteForClient(target, "CLIENT", []string{"ID"})
+
+	// RESP2 대상은 무효화 채널을 구독해야 메시지를 받음
+	registry.ExecuteForClient(target, "SUBSCRIBE", []string{"__redis__:invalidate"})
+	targetBuf.Reset()
+
+	_, err := registry.ExecuteForClient(client, "CLIENT", []string{"TRACKING", "ON", "REDIRECT", strconv.Itoa(int(id.(protocol.Integer)))})
+	if err != nil {
+		t.Fatalf("CLIENT TRACKING ON REDIRECT failed: %v", err)
+	}
+
+	// 테스트 케이스 1: REDIRECT 설정 확인
+	redir, _ := registry.ExecuteForClient(client, "CLIENT", []string{"GETREDIR"})
+	if redir != id {
+		t.Errorf("Expected GETREDIR %v, got %v", id, redir)
+	}
+
+	// 테스트 케이스 2: 무효화 메시지는 대상 연결에 Pub/Sub 메시지 형식으로 전달
+	registry.ExecuteForClient(client, "GET", []string{"k"})
+	registry.Execute("SET", []string{"k", "v"})
+	want := "*3\r\n$7\r\nmessage\r\n$20\r\n__redis__:invalidate\r\n*1\r\n$1\r\nk\r\n"
+	if targetBuf.String() != want {
+		t.Errorf("Expected %q, got %q", want, targetBuf.String())
+	}
+	if clientBuf.Len() != 0 {
+		t.Errorf("Expected no message on tracking client, got %q", clientBuf.String())
+	}
+
+	// 테스트 케이스 3: 존재하지 않는 클라이언트로 REDIRECT (에러 케이스)
+	if _, err := registry.ExecuteForClient(client, "CLIENT", []string{"TRACKING", "ON", "REDIRECT", "9999"}); err == nil {
+		t.Error("Expected error for unknown redirect client")
+	}
+}
+
+// TestClientList는 CLIENT LIST, INFO, SETNAME, GETNAME을 테스트합니다.
+func TestClientList(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	first, _ := newTestClient(registry)
+	first.SetAddr("10.0.0.1:5000")
+	second, _ := newTestClient(registry)
+	second.SetProtocol(3)
+
+	// 테스트 케이스 1: SETNAME, GETNAME
+	if result, _ := registry.ExecuteForClient(first, "CLIENT", []string{"GETNAME"}); result != nil {
+		t.Errorf("Expected nil name, got %v", result)
+	}
+	if result, err := registry.ExecuteForClient(first, "CLIENT", []string{"SETNAME", "worker-1"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	if result, _ := registry.ExecuteForClient(first, "CLIENT", []string{"GETNAME"}); result != protocol.BulkString("worker-1") {
+		t.Errorf("Expected worker-1, got %v", result)
+	}
+	for _, name := range []string{"has space", "new\nline"} {
+		if _, err := registry.ExecuteForClient(first, "CLIENT", []string{"SETNAME", name}); err == nil {
+			t.Errorf("Expected error for name %q", name)
+		}
+	}
+
+	// 테스트 케이스 2: CLIENT INFO는 현재 연결의 정보
+	info, _ := registry.ExecuteForClient(first, "CLIENT", []string{"INFO"})
+	expected := regexp.MustCompile(`^id=` + strconv.FormatInt(first.ID, 10) + ` addr=10\.0\.0\.1:5000 laddr= name=worker-1 age=0 idle=0 flags=N db=0 sub=0 psub=0 ssub=0 multi=-1 cmd=client user=default redir=-1 resp=2\n$`)
+	if !expected.MatchString(string(info.(protocol.BulkString))) {
+		t.Errorf("Unexpected CLIENT INFO: %q", info)
+	}
+
+	// 테스트 케이스 3: 다른 연결의 상태는 마지막으로 실행한 명령어 기준
+	registry.ExecuteForClient(second, "SUBSCRIBE", []string{"news"})
+	registry.ExecuteForClient(first, "MULTI", []string{})
+	registry.ExecuteForClient(first, "SET", []string{"a", "1"})
+	list, _ := registry.ExecuteForClient(second, "CLIENT", []string{"LIST"})
+	lines := strings.Split(strings.TrimSuffix(string(list.(protocol.BulkString)), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", list)
+	}
+	for _, want := range []string{"flags=x ", "multi=1 ", "cmd=set "} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("Expected %q in %q", want, lines[0])
+		}
+	}
+	for _, want := range []string{"flags=P ", "sub=1 ", "cmd=client ", "resp=3"} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("Expected %q in %q", want, lines[1])
+		}
+	}
+	registry.ExecuteForClient(first, "DISCARD", []string{})
+
+	// 테스트 케이스 4: TYPE, ID 필터
+	filters := []struct {
+		args []string
+		ids  []int64
+	}{
+		{[]string{"TYPE", "pubsub"}, []int64{second.ID}},
+		{[]string{"TYPE", "normal"}, []int64{first.ID}},
+		{[]string{"TYPE", "replica"}, nil},
+		{[]string{"ID", strconv.FormatInt(second.ID, 10), "999"}, []int64{second.ID}},
+	}
+	for _, tt := range filters {
+		result, err := registry.ExecuteForClient(first, "CLIENT", append([]string{"LIST"}, tt.args...))
+		if err != nil {
+			t.Errorf("CLIENT LIST %v: unexpected error %v", tt.args, err)
+			continue
+		}
+		var ids []int64
+		for _, m := range regexp.MustCompile(`(?m)^id=(\d+) `).FindAllStringSubmatch(string(result.(protocol.BulkString)), -1) {
+			id, _ := strconv.ParseInt(m[1], 10, 64)
+			ids = append(ids, id)
+		}
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Errorf("CLIENT LIST %v: expected IDs %v, got %v", tt.args, tt.ids, ids)
+		}
+	}
+	for _, args := range [][]string{{"TYPE", "bogus"}, {"ID", "abc"}, {"NOSUCH"}} {
+		if _, err := registry.ExecuteForClient(first, "CLIENT", append([]string{"LIST"}, args...)); err == nil {
+			t.Errorf("Expected error for CLIENT LIST %v", args)
+		}
+	}
+
+	// 테스트 케이스 5: 연결이 끊어지면 목록에서 제외
+	registry.CloseClient(second)
+	if list, _ := registry.ExecuteForClient(first, "CLIENT", []string{"LIST"}); strings.Count(string(list.(protocol.BulkString)), "\n") != 1 {
+		t.Errorf("Expected 1 line after close, got %q", list)
+	}
+}
+
+// TestClientKill은 CLIENT KILL이 조건과 일치하는 연결을 끊는지 테스트합니다.
+func TestClientKill(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	port := serveRegistry(t, registry)
+	client, _ := newTestClient(registry)
+
+	dial := func() net.Conn {
+		conn, err := net.Dial("tcp", "127.0.0.1:"+strconv.Itoa(port))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	first, second := dial(), dial()
+	waitFor(t, "connections to register", func() bool {
+		return len(registry.connectedClients()) == 3
+	})
+	closed := func(conn net.Conn) bool {
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		_, err := conn.Read(make([]byte, 1))
+		return err != nil && !errors.Is(err, os.ErrDeadlineExceeded)
+	}
+
+	// 테스트 케이스 1: 예전 형식은 주소로 끊고, 없는 주소는 에러
+	if _, err := registry.ExecuteForClient(client, "CLIENT", []string{"KILL", "10.0.0.1:1"}); err == nil || !strings.Contains(err.Error(), "No such client") {
+		t.Errorf("Expected No such client, got %v", err)
+	}
+	if result, err := registry.ExecuteForClient(client, "CLIENT", []string{"KILL", first.LocalAddr().String()}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	if !closed(first) {
+		t.Error("Expected first connection to be closed")
+	}
+	waitFor(t, "killed connection to be removed", func() bool {
+		return len(registry.connectedClients()) == 2
+	})
+
+	// 테스트 케이스 2: 일치하는 연결이 없으면 0, MAXAGE는 오래된 연결만
+	if result, _ := registry.ExecuteForClient(client, "CLIENT", []string{"KILL", "TYPE", "pubsub"}); result != protocol.Integer(0) {
+		t.Errorf("Expected 0, got %v", result)
+	}
+	if result, _ := registry.ExecuteForClient(client, "CLIENT", []string{"KILL", "TYPE", "normal", "MAXAGE", "3600"}); result != protocol.Integer(0) {
+		t.Errorf("Expected 0 for MAXAGE, got %v", result)
+	}
+
+	// 테스트 케이스 3: 새 형식은 기본적으로 자기 자신을 제외
+	if result, err := registry.ExecuteForClient(client, "CLIENT", []string{"KILL", "TYPE", "normal", "USER", "default"}); err != nil || result != protocol.Integer(1) {
+		t.Errorf("Expected 1, got %v, %v", result, err)
+	}
+	if !closed(second) {
+		t.Error("Expected second connection to be closed")
+	}
+	if client.ShouldClose() {
+		t.Error("Expected the calling client to be skipped")
+	}
+
+	// 테스트 케이스 4: SKIPME no이면 응답을 보낸 뒤 자기 자신도 끊음
+	id := strconv.FormatInt(client.ID, 10)
+	if result, _ := registry.ExecuteForClient(client, "CLIENT", []string{"KILL", "ID", id, "SKIPME", "no"}); result != protocol.Integer(1) || !client.ShouldClose() {
+		t.Errorf("Expected the calling client to be closed, got %v", result)
+	}
+
+	// 테스트 케이스 5: 잘못된 필터
+	for _, args := range [][]string{{"ID", "0"}, {"TYPE", "bogus"}, {"USER", "alice"}, {"SKIPME", "maybe"}, {"MAXAGE", "x"}, {"BOGUS", "1"}, {"ID", "1", "TYPE"}} {
+		if _, err := registry.ExecuteForClient(client, "CLIENT", append([]string{"KILL"}, args...)); err == nil {
+			t.Errorf("Expected error for CLIENT KILL %v", args)
+		}
+	}
+}
+
+// TestClientNoEvictNoTouch는 CLIENT NO-EVICT, NO-TOUCH 플래그와 RESET을 테스트합니다.
+func TestClientNoEvictNoTouch(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	for _, sub := range []string{"NO-EVICT", "no-touch"} {
+		if result, err := registry.ExecuteForClient(client, "CLIENT", []string{sub, "on"}); err != nil || !isStatus(result, "OK") {
+			t.Errorf("CLIENT %s ON: expected OK, got %v, %v", sub, result, err)
+		}
+		if _, err := registry.ExecuteForClient(client, "CLIENT", []string{sub, "maybe"}); err == nil {
+			t.Errorf("CLIENT %s: expected error for invalid value", sub)
+		}
+	}
+	if !client.noEvict || !client.noTouch {
+		t.Fatal("Expected both flags to be set")
+	}
+	if info, _ := registry.ExecuteForClient(client, "CLIENT", []string{"INFO"}); !strings.Contains(string(info.(protocol.BulkString)), " flags=eT ") {
+		t.Errorf("Expected flags eT, got %q", info)
+	}
+
+	// CLIENT NO-TOUCH OFF, RESET은 플래그를 끔
+	registry.ExecuteForClient(client, "CLIENT", []string{"NO-TOUCH", "OFF"})
+	if client.noTouch {
+		t.Error("Expected NO-TOUCH to be off")
+	}
+	registry.ExecuteForClient(client, "RESET", []string{})
+	if client.noEvict {
+		t.Error("Expected RESET to turn off NO-EVICT")
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"time"
+)
+
+// clientsCronInterval은 오래 쉬고 있는 연결을 찾아 끊는 주기입니다 (Redis의 clientsCron, hz 10과 동일).
+const clientsCronInterval = 100 * time.Millisecond
+
+// DefaultTCPKeepAlive는 tcp-keepalive의 기본값입니다 (Redis와 동일한 300초).
+const DefaultTCPKeepAlive = 300 * time.Second
+
+// SetClientTimeout은 명령어를 보내지 않은 채 timeout보다 오래 쉬고 있는 연결을 끊도록 설정합니다 (timeout).
+// 0이면 끊지 않습니다. 처음으로 0보다 큰 값을 설정하면 주기적으로 연결을 확인하는 고루틴이 시작됩니다.
+//
+// 대기 중인 명령어(BLPOP 등)를 실행 중인 연결, 구독 중인 연결, 마스터와 레플리카 연결은 끊지 않습니다.
+func (r *CommandRegistry) SetClientTimeout(timeout time.Duration) {
+	r.clientTimeout.Store(int64(timeout))
+	if timeout > 0 {
+		r.clientsCronOnce.Do(func() { go r.clientsCron() })
+	}
+}
+
+// SetTCPKeepAlive는 새 연결에 설정할 TCP keepalive 주기를 설정합니다 (tcp-keepalive).
+// 0이면 keepalive를 끕니다. 서버는 연결을 받을 때 TCPKeepAlive로 이 값을 읽어 적용합니다.
+func (r *CommandRegistry) SetTCPKeepAlive(period time.Duration) {
+	r.tcpKeepAlive.Store(int64(period))
+}
+
+// TCPKeepAlive는 새 연결에 설정할 TCP keepalive 주기를 반환합니다 (0이면 끔).
+func (r *CommandRegistry) TCPKeepAlive() time.Duration {
+	return time.Duration(r.tcpKeepAlive.Load())
+}
+
+// clientsCron은 서버가 종료될 때까지 clientsCronInterval마다 오래 쉬고 있는 연결을 끊습니다.
+func (r *CommandRegistry) clientsCron() {
+	ticker := time.NewTicker(clientsCronInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.closeIdleClients(now)
+		}
+	}
+}
+
+// closeIdleClients는 마지막 명령어를 받은 지 timeout보다 오래된 일반 연결들을 끊고, 끊은 연결 수를 반환합니다.
+// 연결 고루틴의 읽기가 실패하면서 CloseClient로 정리됩니다.
+func (r *CommandRegistry) closeIdleClients(now time.Time) int {
+	timeout := time.Duration(r.clientTimeout.Load())
+	if timeout <= 0 {
+		return 0
+	}
+
+	closed := 0
+	for _, client := range r.connectedClients() {
+		if !client.hasConn() {
+			continue
+		}
+		info := client.snapshot()
+		// 대기 중인 연결과 구독, 복제 연결은 명령어를 보내지 않는 것이 정상
+		if info.executing || info.kind != "normal" {
+			continue
+		}
+		if now.Sub(info.lastInteraction) > timeout {
+			client.disconnect()
+			closed++
+		}
+	}
+	return closed
+}
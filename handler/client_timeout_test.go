@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestCloseIdleClients는 timeout보다 오래 쉬고 있는 연결만 끊는지 테스트합니다.
+func TestCloseIdleClients(t *testing.T) {
+	// 테스트 케이스 1: 오래 쉰 일반 연결은 끊고, 대기 중이거나 구독 중인 연결은 유지
+	t.Run("SkipsBlockedAndSubscribed", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		registry.clientTimeout.Store(int64(time.Second))
+
+		_, _, idleConn := newPipeClient(t, registry)
+
+		subscriber, _, subscriberConn := newPipeClient(t, registry)
+		go io.Copy(io.Discard, subscriberConn)
+		if _, err := registry.ExecuteForClient(subscriber, "SUBSCRIBE", []string{"channel"}); err != nil {
+			t.Fatalf("SUBSCRIBE failed: %v", err)
+		}
+
+		blocked, _, _ := newPipeClient(t, registry)
+		go registry.ExecuteForClient(blocked, "BLPOP", []string{"queue", "0"})
+		waitFor(t, "BLPOP to block", func() bool {
+			return registry.store.Blocking().Blocked("queue", store.BlockOnList) == 1
+		})
+
+		if closed := registry.closeIdleClients(time.Now().Add(time.Minute)); closed != 1 {
+			t.Errorf("Expected 1 idle client to be closed, got %d", closed)
+		}
+		if _, err := idleConn.Read(make([]byte, 1)); err == nil {
+			t.Error("Expected the idle connection to be closed")
+		}
+	})
+
+	// 테스트 케이스 2: timeout이 0이거나 아직 지나지 않았으면 끊지 않음
+	t.Run("WithinTimeout", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		newPipeClient(t, registry)
+
+		if closed := registry.closeIdleClients(time.Now().Add(time.Hour)); closed != 0 {
+			t.Errorf("Expected no clients to be closed without a timeout, got %d", closed)
+		}
+		registry.clientTimeout.Store(int64(time.Minute))
+		if closed := registry.closeIdleClients(time.Now()); closed != 0 {
+			t.Errorf("Expected no clients to be closed within the timeout, got %d", closed)
+		}
+	})
+}
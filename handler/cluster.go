@@ -0,0 +1,734 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// clusterSlots는 클러스터의 해시 슬롯 개수입니다.
+const clusterSlots = 16384
+
+// clusterNode는 클러스터에 속한 노드 하나입니다.
+type clusterNode struct {
+	id   string // 40자리 16진수 노드 ID
+	host string // 클라이언트가 연결할 주소
+	port int    // 클라이언트가 연결할 포트
+}
+
+// addr는 MOVED 응답에 쓰는 노드 주소(host:port)를 반환합니다.
+func (n *clusterNode) addr() string {
+	return net.JoinHostPort(n.host, strconv.Itoa(n.port))
+}
+
+// cluster는 클러스터 모드 설정과 상태입니다.
+//
+// 클러스터 모드(--cluster-enabled)에서는 시작할 때 노드 ID를 새로 만들고,
+// 해시 슬롯마다 담당 노드를 기록합니다. 모든 슬롯에 담당 노드가 있어야 cluster_state가 ok입니다.
+//
+// 노드 사이의 가십은 없으므로 다른 노드는 CLUSTER MEET으로, 슬롯 배정은 CLUSTER ADDSLOTS와
+// CLUSTER SETSLOT으로 노드마다 직접 알려 주어야 합니다.
+//
+// 슬롯 옮기기 (MIGRATE로 키를 옮기는 동안):
+//  1. 가져올 노드: CLUSTER SETSLOT <slot> IMPORTING <원래 노드>
+//  2. 원래 노드: CLUSTER SETSLOT <slot> MIGRATING <가져올 노드>
+//  3. 원래 노드: CLUSTER GETKEYSINSLOT으로 키를 찾아 MIGRATE로 옮김
+//     (원래 노드에 없는 키는 -ASK로 가져올 노드에 보내고, 가져올 노드는 ASKING 다음 명령어만 실행)
+//  4. 모든 노드: CLUSTER SETSLOT <slot> NODE <가져올 노드>
+type cluster struct {
+	mu sync.Mutex
+
+	enabled bool
+
+	// myself는 이 서버의 노드이며, nodes는 노드 ID로 찾는 알려진 노드들입니다 (myself 포함).
+	myself *clusterNode
+	nodes  map[string]*clusterNode
+
+	// slots는 해시 슬롯별 담당 노드이며, 담당 노드가 없는 슬롯은 nil입니다.
+	slots [clusterSlots]*clusterNode
+
+	// migrating은 이 노드가 담당하면서 다른 노드로 옮기는 중인 슬롯의 대상 노드이고,
+	// importing은 다른 노드에서 가져오는 중인 슬롯의 원래 노드입니다 (CLUSTER SETSLOT).
+	migrating [clusterSlots]*clusterNode
+	importing [clusterSlots]*clusterNode
+
+	currentEpoch int64
+}
+
+// newCluster는 새 노드 ID를 가진 비활성 cluster를 생성합니다.
+func newCluster() *cluster {
+	myself := &clusterNode{id: newReplID(), host: "127.0.0.1", port: 6379}
+	return &cluster{
+		myself: myself,
+		nodes:  map[string]*clusterNode{myself.id: myself},
+	}
+}
+
+// SetClusterEnabled는 클러스터 모드를 켜거나 끕니다 (--cluster-enabled).
+// 기본값은 false이며, 꺼져 있으면 CLUSTER 명령어를 거부합니다.
+func (r *CommandRegistry) SetClusterEnabled(enabled bool) {
+	r.cluster.mu.Lock()
+	defer r.cluster.mu.Unlock()
+	r.cluster.enabled = enabled
+}
+
+// assignedSlotsLocked는 담당 노드가 있는 슬롯 수를 반환합니다. c.mu를 잡고 호출합니다.
+func (c *cluster) assignedSlotsLocked() int {
+	n := 0
+	for _, node := range c.slots {
+		if node != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// sizeLocked는 슬롯을 하나 이상 담당하는 노드 수를 반환합니다 (cluster_size). c.mu를 잡고 호출합니다.
+func (c *cluster) sizeLocked() int {
+	owners := make(map[*clusterNode]bool)
+	for _, node := range c.slots {
+		if node != nil {
+			owners[node] = true
+		}
+	}
+	return len(owners)
+}
+
+// keyHashSlot은 키의 해시 슬롯을 계산합니다 (CRC16 mod 16384).
+//
+// 키에 해시 태그가 있으면 태그만 해시하므로 같은 태그의 키들은 같은 슬롯에 놓입니다.
+//   - 해시 태그: 첫 번째 '{'와 그 뒤의 첫 번째 '}' 사이의 문자열 ({user:1}:name → user:1)
+//   - '{' 뒤에 '}'가 없거나 사이가 비어 있으면({}) 키 전체를 해시
+func keyHashSlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key)) % clusterSlots
+}
+
+// crc16은 Redis 클러스터가 사용하는 CRC16 (XMODEM: 다항식 0x1021, 초기값 0)을 계산합니다.
+func crc16(data string) uint16 {
+	var crc uint16
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// commandKeys는 명령어 인자 중 키들을 반환합니다. 키를 다루지 않는 명령어는 nil입니다.
+// 고정된 키 위치는 commandTable에서 가져오고, 키 위치가 다른 인자에 따라 정해지는
+// 명령어(EVAL, EVALSHA, FCALL, FCALL_RO, MIGRATE)는 여기서 따로 구합니다.
+func commandKeys(cmdUpper string, args []string) []string {
+	switch cmdUpper {
+	case "EVAL", "EVALSHA", "FCALL", "FCALL_RO":
+		if len(args) < 2 {
+			return nil
+		}
+		keys, _, err := parseScriptKeys(args[1], args[2:])
+		if err != nil {
+			return nil
+		}
+		return keys
+	case "MIGRATE":
+		return migrateKeys(args)
+	}
+	if meta, ok := commandTable[cmdUpper]; ok && meta.hasKeys() {
+		return meta.keys.keys(args)
+	}
+	return nil
+}
+
+// clusterRedirect는 클러스터 모드에서 명령어를 이 노드에서 실행할 수 있는지 확인합니다.
+//   - 키들이 서로 다른 슬롯에 있으면 CROSSSLOT
+//   - 슬롯을 다른 노드가 담당하면 MOVED (가져오는 중인 슬롯이고 ASKING 다음이면 실행)
+//   - 담당 노드가 없으면 CLUSTERDOWN
+//   - 옮기는 중인 슬롯에서 키가 모두 없으면 ASK, 일부만 없으면 TRYAGAIN
+//
+// 마스터가 보낸 명령어와 연결 없이 실행되는 스크립트의 명령어(client가 nil)는 확인하지 않습니다. ASKING은 이 확인을 거친 다음 명령어 하나에만 적용됩니다.
+func (r *CommandRegistry) clusterRedirect(client *Client, cmdUpper string, args []string) error {
+	if client == nil || client.master {
+		return nil
+	}
+	asking := client.asking
+	if cmdUpper != "ASKING" {
+		client.asking = false
+	}
+
+	c := r.cluster
+	c.mu.Lock()
+	if !c.enabled {
+		c.mu.Unlock()
+		return nil
+	}
+	keys := commandKeys(cmdUpper, args)
+	if len(keys) == 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	slot := keyHashSlot(keys[0])
+	for _, key := range keys[1:] {
+		if keyHashSlot(key) != slot {
+			c.mu.Unlock()
+			return &CrossSlotError{}
+		}
+	}
+
+	node, target := c.slots[slot], c.migrating[slot]
+	importing := c.importing[slot] != nil
+	c.mu.Unlock()
+
+	switch {
+	case importing && asking:
+		return nil
+	case node == nil:
+		return &ClusterDownError{}
+	case node != c.myself:
+		return &MovedError{Slot: slot, Addr: node.addr()}
+	case target == nil:
+		return nil
+	}
+
+	// 옮기는 중이면 이미 옮겨진 키는 가져오는 노드에 있음
+	// (클러스터 잠금을 놓고 확인해 CLUSTER 명령어와 잠금 순서가 엇갈리지 않게 함)
+	r.execMu.RLock()
+	present := 0
+	for _, key := range keys {
+		if r.store.TYPE(key) != "none" {
+			present++
+		}
+	}
+	r.execMu.RUnlock()
+	switch present {
+	case len(keys):
+		return nil
+	case 0:
+		return &AskError{Slot: slot, Addr: target.addr()}
+	default:
+		return &TryAgainError{}
+	}
+}
+
+// keysInSlot은 해시 슬롯에 속한 키들을 이름 순으로 반환합니다.
+// 슬롯별 색인이 없으므로 키 공간 전체를 훑습니다.
+//
+// 시간 복잡도: O(N log N) (N은 키의 개수)
+func keysInSlot(s *store.Store, slot int) []string {
+	var keys []string
+	for _, key := range s.Keys() {
+		if keyHashSlot(key) == slot {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// meet은 host:port의 노드에 연결해 노드 ID를 알아내고 알려진 노드로 추가합니다.
+// 상대 노드는 이 노드를 알게 되지 않으므로 필요하면 상대 노드에서도 MEET해야 합니다.
+func (c *cluster) meet(host string, port int) error {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), replConnectTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(replConnectTimeout))
+
+	if err := protocol.NewWriter(conn).WriteArray([]string{"CLUSTER", "MYID"}); err != nil {
+		return err
+	}
+	reply, err := protocol.NewParser(bufio.NewReader(conn)).Parse()
+	if err != nil {
+		return err
+	}
+	if replyErr, ok := reply.(*protocol.ErrorReply); ok {
+		return replyErr
+	}
+	id, ok := reply.(string)
+	if !ok || !isNodeID(id) {
+		return fmt.Errorf("unexpected CLUSTER MYID reply: %v", reply)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if id == c.myself.id {
+		return nil
+	}
+	node := c.nodes[id]
+	if node == nil {
+		node = &clusterNode{id: id}
+		c.nodes[id] = node
+	}
+	node.host, node.port = host, port
+	return nil
+}
+
+// isNodeID는 문자열이 40자리 16진수 노드 ID인지 확인합니다.
+func isNodeID(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// parseSlot은 슬롯 번호 인자를 파싱합니다.
+func parseSlot(arg string) (int, error) {
+	slot, err := strconv.Atoi(arg)
+	if err != nil || slot < 0 || slot >= clusterSlots {
+		return 0, &InvalidArgumentError{Message: "Invalid or out of range slot"}
+	}
+	return slot, nil
+}
+
+// ClusterHandler는 CLUSTER 명령어를 처리하는 핸들러입니다.
+//
+// Redis CLUSTER 명령어 사양 (지원하는 서브커맨드):
+//   - CLUSTER INFO: 클러스터 상태와 슬롯 배정 현황 ("필드:값" 줄로 이루어진 텍스트)
+//   - CLUSTER MYID: 이 노드의 ID
+//   - CLUSTER KEYSLOT key: 키의 해시 슬롯 (해시 태그 적용)
+//   - CLUSTER SLOTS: 연속된 슬롯 범위마다 [시작, 끝, [host, port, node-id]] 배열
+//   - CLUSTER SHARDS: 샤드마다 slots(범위 목록)와 nodes(노드 속성 목록)를 담은 배열
+//   - CLUSTER NODES: 노드마다 한 줄씩 "<id> <ip:port@cport> <flags> <master> <ping-sent> <pong-recv> <epoch> <link-state> <slot> ..." 텍스트
+//   - CLUSTER MEET ip port: ip:port의 노드를 알려진 노드로 추가
+//   - CLUSTER ADDSLOTS slot [slot ...]: 담당 노드가 없는 슬롯들을 이 노드에 배정
+//   - CLUSTER DELSLOTS slot [slot ...]: 슬롯들의 배정을 해제
+//   - CLUSTER SETSLOT slot NODE node-id: 슬롯을 지정한 노드에 배정 (옮기는 중인 상태 해제)
+//   - CLUSTER SETSLOT slot IMPORTING|MIGRATING node-id: 슬롯을 가져오는/옮기는 중으로 표시
+//   - CLUSTER SETSLOT slot STABLE: 가져오는/옮기는 중인 상태 해제
+//   - CLUSTER COUNTKEYSINSLOT slot: 슬롯에 속한 키 개수
+//   - CLUSTER GETKEYSINSLOT slot count: 슬롯에 속한 키를 최대 count개
+//   - CLUSTER HELP: 서브커맨드들의 사용법
+//   - 클러스터 모드가 아니면 모든 서브커맨드가 에러
+//
+// 예시:
+//
+//	클라이언트: CLUSTER MYID
+//	서버: $40\r\n07c37dfeb235213a872192d90877d0cd55635b91\r\n
+type ClusterHandler struct {
+	cluster *cluster
+}
+
+// Execute는 CLUSTER 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.BulkString: INFO, NODES의 텍스트, MYID의 노드 ID
+//   - protocol.SimpleString: 그 외 성공 시 OK
+//   - protocol.Integer: KEYSLOT의 슬롯, COUNTKEYSINSLOT의 키 개수
+//   - protocol.BulkStrings: GETKEYSINSLOT의 키 목록
+//   - protocol.Array: SLOTS, SHARDS의 토폴로지
+//   - error: 클러스터 모드가 아닌 경우, 서브커맨드가 없거나 알 수 없는 경우, 인자가 잘못된 경우
+func (h *ClusterHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "cluster"}
+	}
+
+	c := h.cluster
+	c.mu.Lock()
+	enabled := c.enabled
+	c.mu.Unlock()
+	if !enabled {
+		return nil, &InvalidArgumentError{Message: "This instance has cluster support disabled"}
+	}
+
+	// MEET은 상대 노드와 통신하므로 잠금 없이 실행
+	if !strings.EqualFold(args[0], "MEET") {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	return h.subcommands().dispatch(nil, args, store)
+}
+
+// subcommands는 CLUSTER의 서브커맨드 목록입니다. MEET 외에는 c.mu를 잡고 실행됩니다.
+func (h *ClusterHandler) subcommands() *subcommandTable {
+	return &subcommandTable{command: "CLUSTER", subs: []subcommand{
+		{"ADDSLOTS", -3, []string{"ADDSLOTS <slot> [<slot> ...]", "Assign slots to current node."}, h.addSlots},
+		{"COUNTKEYSINSLOT", 3, []string{"COUNTKEYSINSLOT <slot>", "Return the number of keys in <slot>."}, h.countKeysInSlot},
+		{"DELSLOTS", -3, []string{"DELSLOTS <slot> [<slot> ...]", "Delete slots information from current node."}, h.delSlots},
+		{"GETKEYSINSLOT", 4, []string{"GETKEYSINSLOT <slot> <count>", "Return key names stored by current node in a slot."}, h.getKeysInSlot},
+		{"INFO", 2, []string{"INFO", "Return information about the cluster."}, h.info},
+		{"KEYSLOT", 3, []string{"KEYSLOT <key>", "Return the hash slot for <key>."}, h.keySlot},
+		{"MEET", -4, []string{"MEET <ip> <port> [<bus-port>]", "Connect nodes into a working cluster."}, h.meet},
+		{"MYID", 2, []string{"MYID", "Return the node id."}, h.myID},
+		{"NODES", 2, []string{"NODES", "Return cluster configuration seen by node. Output format:",
+			"    <id> <ip:port@bus-port[,hostname]> <flags> <master> <pings> <pongs> <epoch> <link> <slot> ..."}, h.nodes},
+		{"SETSLOT", -4, []string{"SETSLOT <slot> (IMPORTING <node-id>|MIGRATING <node-id>|STABLE|NODE <node-id>)",
+			"Set slot state."}, h.setSlot},
+		{"SHARDS", 2, []string{"SHARDS", "Return information about slot range mappings and the nodes associated with them."}, h.shards},
+		{"SLOTS", 2, []string{"SLOTS", "Return information about slots range mappings. Each range is made of:",
+			"    start, end, master and replicas IP addresses, ports and ids"}, h.slots},
+	}}
+}
+
+// meet은 CLUSTER MEET을 실행합니다. 상대 노드와 통신하므로 c.mu를 잡지 않고 호출됩니다.
+func (h *ClusterHandler) meet(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) > 4 {
+		return nil, &WrongNumberOfArgumentsError{Command: "cluster|meet"}
+	}
+	port, err := strconv.Atoi(args[2])
+	if err == nil {
+		err = h.cluster.meet(args[1], port)
+	}
+	if err != nil {
+		return nil, &InvalidArgumentError{Message: "Invalid node address specified: " + args[1] + ":" + args[2]}
+	}
+	return okReply, nil
+}
+
+// info는 CLUSTER INFO를 실행합니다.
+func (h *ClusterHandler) info(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return protocol.BulkString(h.cluster.infoLocked()), nil
+}
+
+// myID는 CLUSTER MYID를 실행합니다.
+func (h *ClusterHandler) myID(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return protocol.BulkString(h.cluster.myself.id), nil
+}
+
+// keySlot은 CLUSTER KEYSLOT을 실행합니다.
+func (h *ClusterHandler) keySlot(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return protocol.Integer(keyHashSlot(args[1])), nil
+}
+
+// slots는 CLUSTER SLOTS를 실행합니다.
+func (h *ClusterHandler) slots(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return h.cluster.slotsReplyLocked(), nil
+}
+
+// shards는 CLUSTER SHARDS를 실행합니다.
+func (h *ClusterHandler) shards(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return h.cluster.shardsReplyLocked(), nil
+}
+
+// nodes는 CLUSTER NODES를 실행합니다.
+func (h *ClusterHandler) nodes(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return protocol.BulkString(h.cluster.nodesReplyLocked()), nil
+}
+
+// addSlots는 CLUSTER ADDSLOTS를 실행합니다.
+func (h *ClusterHandler) addSlots(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return h.cluster.assignSlotsLocked(args[1:], true)
+}
+
+// delSlots는 CLUSTER DELSLOTS를 실행합니다.
+func (h *ClusterHandler) delSlots(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return h.cluster.assignSlotsLocked(args[1:], false)
+}
+
+// assignSlotsLocked는 슬롯들을 이 노드에 배정하거나(add) 배정을 해제합니다. c.mu를 잡고 호출합니다.
+// 하나라도 배정할 수 없으면 아무 슬롯도 바꾸지 않습니다.
+func (c *cluster) assignSlotsLocked(args []string, add bool) (protocol.Reply, error) {
+	slots := make([]int, 0, len(args))
+	seen := make(map[int]bool, len(args))
+	for _, arg := range args {
+		slot, err := parseSlot(arg)
+		if err != nil {
+			return nil, err
+		}
+		if seen[slot] {
+			return nil, &InvalidArgumentError{Message: "Slot " + strconv.Itoa(slot) + " specified multiple times"}
+		}
+		seen[slot] = true
+		slots = append(slots, slot)
+	}
+
+	for _, slot := range slots {
+		if add && c.slots[slot] != nil {
+			return nil, &InvalidArgumentError{Message: "Slot " + strconv.Itoa(slot) + " is already busy"}
+		}
+		if !add && c.slots[slot] == nil {
+			return nil, &InvalidArgumentError{Message: "Slot " + strconv.Itoa(slot) + " is already unassigned"}
+		}
+	}
+	var owner *clusterNode
+	if add {
+		owner = c.myself
+	}
+	for _, slot := range slots {
+		c.slots[slot] = owner
+	}
+	return okReply, nil
+}
+
+// setSlot은 CLUSTER SETSLOT을 실행합니다.
+func (h *ClusterHandler) setSlot(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	c := h.cluster
+	slot, err := parseSlot(args[1])
+	if err != nil {
+		return nil, err
+	}
+	action := strings.ToUpper(args[2])
+	if action == "STABLE" && len(args) == 3 {
+		c.migrating[slot], c.importing[slot] = nil, nil
+		return okReply, nil
+	}
+	if action != "NODE" && action != "IMPORTING" && action != "MIGRATING" || len(args) != 4 {
+		return nil, &InvalidArgumentError{Message: "Invalid CLUSTER SETSLOT action or number of arguments. Try CLUSTER HELP"}
+	}
+	node := c.nodes[args[3]]
+	if node == nil {
+		return nil, &InvalidArgumentError{Message: "Unknown node " + args[3]}
+	}
+	return c.setSlotLocked(store, slot, action, node)
+}
+
+// countKeysInSlot은 CLUSTER COUNTKEYSINSLOT을 실행합니다.
+func (h *ClusterHandler) countKeysInSlot(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	slot, err := parseSlot(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return protocol.Integer(len(keysInSlot(store, slot))), nil
+}
+
+// getKeysInSlot은 CLUSTER GETKEYSINSLOT을 실행합니다.
+func (h *ClusterHandler) getKeysInSlot(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	slot, err := parseSlot(args[1])
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(args[2])
+	if err != nil || count < 0 {
+		return nil, &InvalidArgumentError{Message: "Invalid number of keys"}
+	}
+	keys := keysInSlot(store, slot)
+	if len(keys) > count {
+		keys = keys[:count]
+	}
+	return protocol.BulkStrings(keys), nil
+}
+
+// setSlotLocked는 CLUSTER SETSLOT의 NODE, IMPORTING, MIGRATING을 실행합니다. c.mu를 잡고 호출합니다.
+func (c *cluster) setSlotLocked(s *store.Store, slot int, action string, node *clusterNode) (protocol.Reply, error) {
+	owned := c.slots[slot] == c.myself
+	switch action {
+	case "IMPORTING":
+		if owned {
+			return nil, &InvalidArgumentError{Message: "I'm already the owner of hash slot " + strconv.Itoa(slot)}
+		}
+		if node == c.myself {
+			return nil, &InvalidArgumentError{Message: "I can't import a slot from myself"}
+		}
+		c.importing[slot] = node
+
+	case "MIGRATING":
+		if !owned {
+			return nil, &InvalidArgumentError{Message: "I'm not the owner of hash slot " + strconv.Itoa(slot)}
+		}
+		if node == c.myself {
+			return nil, &InvalidArgumentError{Message: "I can't migrate a slot to myself"}
+		}
+		c.migrating[slot] = node
+
+	case "NODE":
+		// 옮기기를 마치지 않은 키가 남아 있으면 넘기지 않음
+		if owned && node != c.myself && len(keysInSlot(s, slot)) > 0 {
+			return nil, &InvalidArgumentError{Message: "Can't assign hashslot " + strconv.Itoa(slot) + " to a different node while I still hold keys for this hash slot."}
+		}
+		c.slots[slot] = node
+		c.migrating[slot], c.importing[slot] = nil, nil
+	}
+	return okReply, nil
+}
+
+// AskingHandler는 ASKING 명령어를 처리하는 핸들러입니다.
+//
+// Redis ASKING 명령어 사양:
+//   - ASKING
+//   - -ASK 응답을 받은 클라이언트가 가져오는 중인 노드에 보냄
+//   - 바로 다음 명령어 하나는 이 노드가 가져오는 중인(IMPORTING) 슬롯의 키도 실행
+//
+// 예시:
+//
+//	클라이언트: ASKING
+//	서버: +OK\r\n
+type AskingHandler struct {
+	cluster *cluster
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. ASKING은 연결 상태가 필요합니다.
+func (h *AskingHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "ASKING requires a client connection"}
+}
+
+// ExecuteWithClient는 ASKING 명령어를 실행합니다.
+func (h *AskingHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	h.cluster.mu.Lock()
+	enabled := h.cluster.enabled
+	h.cluster.mu.Unlock()
+	if !enabled {
+		return nil, &InvalidArgumentError{Message: "This instance has cluster support disabled"}
+	}
+	client.asking = true
+	return okReply, nil
+}
+
+// slotRange는 같은 노드가 담당하는 연속된 슬롯들입니다.
+type slotRange struct {
+	start, end int
+	node       *clusterNode
+}
+
+// slotRangesLocked는 배정된 슬롯들을 슬롯 순서대로 연속된 범위로 묶어 반환합니다. c.mu를 잡고 호출합니다.
+func (c *cluster) slotRangesLocked() []slotRange {
+	var ranges []slotRange
+	for slot, node := range c.slots {
+		if node == nil {
+			continue
+		}
+		if n := len(ranges); n > 0 && ranges[n-1].node == node && ranges[n-1].end == slot-1 {
+			ranges[n-1].end = slot
+			continue
+		}
+		ranges = append(ranges, slotRange{start: slot, end: slot, node: node})
+	}
+	return ranges
+}
+
+// sortedNodesLocked는 알려진 노드들을 이 노드를 먼저, 나머지는 노드 ID 순서로 반환합니다. c.mu를 잡고 호출합니다.
+func (c *cluster) sortedNodesLocked() []*clusterNode {
+	nodes := make([]*clusterNode, 0, len(c.nodes))
+	for _, node := range c.nodes {
+		if node != c.myself {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].id < nodes[j].id })
+	return append([]*clusterNode{c.myself}, nodes...)
+}
+
+// slotsReplyLocked는 CLUSTER SLOTS의 응답을 만듭니다. c.mu를 잡고 호출합니다.
+//
+// 응답 형식 (범위마다):
+//
+//	[시작 슬롯, 끝 슬롯, [host, port, node-id]]
+func (c *cluster) slotsReplyLocked() protocol.Array {
+	ranges := c.slotRangesLocked()
+	result := make(protocol.Array, 0, len(ranges))
+	for _, r := range ranges {
+		result = append(result, protocol.Array{
+			protocol.Integer(r.start),
+			protocol.Integer(r.end),
+			protocol.Array{protocol.BulkString(r.node.host), protocol.Integer(r.node.port), protocol.BulkString(r.node.id)},
+		})
+	}
+	return result
+}
+
+// shardsReplyLocked는 CLUSTER SHARDS의 응답을 만듭니다. c.mu를 잡고 호출합니다.
+// 레플리카가 없으므로 노드마다 샤드 하나이며, 속성 맵은 RESP2처럼 키와 값을 번갈아 담은 배열입니다.
+func (c *cluster) shardsReplyLocked() protocol.Array {
+	ranges := c.slotRangesLocked()
+	nodes := c.sortedNodesLocked()
+	result := make(protocol.Array, 0, len(nodes))
+	for _, node := range nodes {
+		slots := protocol.Array{}
+		for _, r := range ranges {
+			if r.node == node {
+				slots = append(slots, protocol.Integer(r.start), protocol.Integer(r.end))
+			}
+		}
+		result = append(result, protocol.Array{
+			protocol.BulkString("slots"), slots,
+			protocol.BulkString("nodes"), protocol.Array{protocol.Array{
+				protocol.BulkString("id"), protocol.BulkString(node.id),
+				protocol.BulkString("port"), protocol.Integer(node.port),
+				protocol.BulkString("ip"), protocol.BulkString(node.host),
+				protocol.BulkString("endpoint"), protocol.BulkString(node.host),
+				protocol.BulkString("role"), protocol.BulkString("master"),
+				protocol.BulkString("replication-offset"), protocol.Integer(0),
+				protocol.BulkString("health"), protocol.BulkString("online"),
+			}},
+		})
+	}
+	return result
+}
+
+// nodesReplyLocked는 CLUSTER NODES의 응답 텍스트를 만듭니다. c.mu를 잡고 호출합니다.
+// 클러스터 버스 포트(@cport)는 Redis처럼 포트 + 10000으로 표시하고,
+// 이 노드의 줄에는 옮기는 중인 슬롯([slot->-대상 ID])과 가져오는 중인 슬롯([slot-<-원래 ID])을 덧붙입니다.
+func (c *cluster) nodesReplyLocked() string {
+	ranges := c.slotRangesLocked()
+	var sb strings.Builder
+	for _, node := range c.sortedNodesLocked() {
+		flags := "master"
+		if node == c.myself {
+			flags = "myself,master"
+		}
+		fmt.Fprintf(&sb, "%s %s@%d %s - 0 0 %d connected", node.id, node.addr(), node.port+10000, flags, c.currentEpoch)
+		for _, r := range ranges {
+			if r.node != node {
+				continue
+			}
+			if r.start == r.end {
+				fmt.Fprintf(&sb, " %d", r.start)
+			} else {
+				fmt.Fprintf(&sb, " %d-%d", r.start, r.end)
+			}
+		}
+		if node == c.myself {
+			for slot := range c.slots {
+				if target := c.migrating[slot]; target != nil {
+					fmt.Fprintf(&sb, " [%d->-%s]", slot, target.id)
+				}
+				if source := c.importing[slot]; source != nil {
+					fmt.Fprintf(&sb, " [%d-<-%s]", slot, source.id)
+				}
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// infoLocked는 CLUSTER INFO의 응답 텍스트를 만듭니다. c.mu를 잡고 호출합니다.
+func (c *cluster) infoLocked() string {
+	assigned := c.assignedSlotsLocked()
+	state := "fail"
+	if assigned == clusterSlots {
+		state = "ok"
+	}
+
+	fields := [][2]string{
+		{"cluster_state", state},
+		{"cluster_slots_assigned", strconv.Itoa(assigned)},
+		{"cluster_slots_ok", strconv.Itoa(assigned)},
+		{"cluster_slots_pfail", "0"},
+		{"cluster_slots_fail", "0"},
+		{"cluster_known_nodes", strconv.Itoa(len(c.nodes))},
+		{"cluster_size", strconv.Itoa(c.sizeLocked())},
+		{"cluster_current_epoch", strconv.FormatInt(c.currentEpoch, 10)},
+		{"cluster_my_epoch", strconv.FormatInt(c.currentEpoch, 10)},
+	}
+
+	var sb strings.Builder
+	for _, field := range fields {
+		sb.WriteString(field[0] + ":" + field[1] + "\r\n")
+	}
+	return sb.String()
+}
+
+// clusterInfo는 INFO cluster 섹션의 필드들을 반환합니다.
+func clusterInfo(r *CommandRegistry) [][2]string {
+	c := r.cluster
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return [][2]string{{"cluster_enabled", boolInfo(c.enabled)}}
+}
@@ -0,0 +1,376 @@
+package handler
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestCluster는 클러스터 모드 설정과 CLUSTER INFO, MYID를 테스트합니다.
+func TestCluster(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: 클러스터 모드가 아니면 거부
+	if _, err := registry.ExecuteForClient(client, "CLUSTER", []string{"INFO"}); err == nil || !strings.Contains(err.Error(), "cluster support disabled") {
+		t.Errorf("Expected cluster support disabled error, got %v", err)
+	}
+	if result, _ := registry.ExecuteForClient(client, "INFO", []string{"cluster"}); !strings.Contains(string(result.(protocol.BulkString)), "cluster_enabled:0") {
+		t.Errorf("Expected cluster_enabled:0, got %q", result)
+	}
+
+	registry.SetClusterEnabled(true)
+
+	// 테스트 케이스 2: 노드 ID는 40자리 16진수이고 바뀌지 않음
+	id, err := registry.ExecuteForClient(client, "CLUSTER", []string{"MYID"})
+	if err != nil || !regexp.MustCompile(`^[0-9a-f]{40}$`).MatchString(string(id.(protocol.BulkString))) {
+		t.Fatalf("Expected 40-char node ID, got %v, %v", id, err)
+	}
+	if again, _ := registry.ExecuteForClient(client, "CLUSTER", []string{"myid"}); again != id {
+		t.Errorf("Expected stable node ID %v, got %v", id, again)
+	}
+	other := NewCommandRegistry(store.NewStore())
+	other.SetClusterEnabled(true)
+	if otherID, _ := other.Execute("CLUSTER", []string{"MYID"}); otherID == nil || otherID == id {
+		t.Error("Expected a different node ID for another node")
+	}
+
+	// 테스트 케이스 3: 슬롯이 배정되지 않은 클러스터 상태
+	result, err := registry.ExecuteForClient(client, "CLUSTER", []string{"INFO"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, field := range []string{"cluster_state:fail\r\n", "cluster_slots_assigned:0\r\n", "cluster_known_nodes:1\r\n", "cluster_size:0\r\n"} {
+		if !strings.Contains(string(result.(protocol.BulkString)), field) {
+			t.Errorf("Expected %q in CLUSTER INFO, got %q", field, result)
+		}
+	}
+	if result, _ := registry.ExecuteForClient(client, "INFO", []string{"cluster"}); !strings.Contains(string(result.(protocol.BulkString)), "cluster_enabled:1") {
+		t.Errorf("Expected cluster_enabled:1, got %q", result)
+	}
+
+	// 테스트 케이스 4: 잘못된 서브커맨드와 인자 개수
+	if _, err := registry.ExecuteForClient(client, "CLUSTER", []string{"NOSUCH"}); err == nil || !strings.Contains(err.Error(), "unknown subcommand") {
+		t.Errorf("Expected unknown subcommand error, got %v", err)
+	}
+	if _, err := registry.ExecuteForClient(client, "CLUSTER", []string{"MYID", "extra"}); err == nil {
+		t.Error("Expected wrong number of arguments error")
+	}
+}
+
+// TestKeyHashSlot은 Redis 클러스터와 같은 해시 슬롯을 계산하는지 테스트합니다.
+func TestKeyHashSlot(t *testing.T) {
+	if crc := crc16("123456789"); crc != 0x31c3 {
+		t.Errorf("Expected CRC16 0x31c3, got %#x", crc)
+	}
+	tests := map[string]int{"foo": 12182, "bar": 5061, "hello": 866, "": 0}
+	for key, want := range tests {
+		if slot := keyHashSlot(key); slot != want {
+			t.Errorf("keyHashSlot(%q): expected %d, got %d", key, want, slot)
+		}
+	}
+}
+
+// TestKeyHashSlotHashTag는 해시 태그 규칙을 테스트합니다.
+func TestKeyHashSlotHashTag(t *testing.T) {
+	tests := []struct {
+		key  string
+		hash string // 실제로 해시되는 부분
+	}{
+		{"{user1000}.following", "user1000"},
+		{"{user1000}.followers", "user1000"},
+		{"foo{bar}{zap}", "bar"},     // 첫 번째 태그만 사용
+		{"foo{}{bar}", "foo{}{bar}"}, // 첫 번째 태그가 비어 있으면 키 전체
+		{"foo{{bar}}zap", "{bar"},    // 첫 번째 '{' 뒤의 첫 번째 '}'까지
+		{"foo{bar", "foo{bar"},       // 닫는 괄호 없음
+		{"foo}bar{", "foo}bar{"},     // '{' 뒤에 '}' 없음
+		{"{}", "{}"},                 // 빈 태그
+		{"a{b}c", "b"},               // 중간의 태그
+		{"{\xff}", "\xff"},           // 태그 안의 임의 바이트
+	}
+	for _, tt := range tests {
+		if slot, want := keyHashSlot(tt.key), int(crc16(tt.hash))%clusterSlots; slot != want {
+			t.Errorf("keyHashSlot(%q): expected slot of %q (%d), got %d", tt.key, tt.hash, want, slot)
+		}
+	}
+
+	// 같은 태그의 키들은 여러 키 명령어에서 CROSSSLOT이 아님
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetClusterEnabled(true)
+	client, _ := newTestClient(registry)
+	slot := strconv.Itoa(keyHashSlot("{user}"))
+	registry.ExecuteForClient(client, "CLUSTER", []string{"ADDSLOTS", slot})
+	if result, err := registry.ExecuteForClient(client, "CLUSTER", []string{"KEYSLOT", "{user}:a"}); err != nil || strconv.Itoa(int(result.(protocol.Integer))) != slot {
+		t.Errorf("Expected slot %s, got %v, %v", slot, result, err)
+	}
+	if _, err := registry.ExecuteForClient(client, "PFMERGE", []string{"{user}:all", "{user}:a", "{user}:b"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := registry.ExecuteForClient(client, "PFMERGE", []string{"{user}:all", "{other}:a"}); err == nil || !strings.HasPrefix(err.Error(), "-CROSSSLOT") {
+		t.Errorf("Expected CROSSSLOT, got %v", err)
+	}
+}
+
+// TestClusterSlots는 슬롯 배정과 MOVED, CROSSSLOT, CLUSTERDOWN 응답을 테스트합니다.
+func TestClusterSlots(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetClusterEnabled(true)
+	client, _ := newTestClient(registry)
+
+	other := NewCommandRegistry(store.NewStore())
+	other.SetClusterEnabled(true)
+	otherPort := serveRegistry(t, other)
+	otherID, _ := other.Execute("CLUSTER", []string{"MYID"})
+
+	// 테스트 케이스 1: 배정되지 않은 슬롯의 키는 CLUSTERDOWN, 키가 없는 명령어는 실행
+	if _, err := registry.ExecuteForClient(client, "SET", []string{"foo", "1"}); err == nil || !strings.HasPrefix(err.Error(), "-CLUSTERDOWN") {
+		t.Errorf("Expected CLUSTERDOWN, got %v", err)
+	}
+	if result, err := registry.ExecuteForClient(client, "PING", []string{}); err != nil || !isStatus(result, "PONG") {
+		t.Errorf("Expected PONG, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 2: ADDSLOTS로 배정한 슬롯의 키는 실행
+	if result, err := registry.ExecuteForClient(client, "CLUSTER", []string{"ADDSLOTS", "12182", "5061"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	if _, err := registry.ExecuteForClient(client, "SET", []string{"foo", "1"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for _, args := range [][]string{{"ADDSLOTS", "12182"}, {"ADDSLOTS", "1", "1"}, {"ADDSLOTS", "16384"}, {"DELSLOTS", "2"}} {
+		if _, err := registry.ExecuteForClient(client, "CLUSTER", args); err == nil {
+			t.Errorf("Expected error for CLUSTER %v", args)
+		}
+	}
+	if result, _ := registry.ExecuteForClient(client, "CLUSTER", []string{"INFO"}); !strings.Contains(string(result.(protocol.BulkString)), "cluster_slots_assigned:2\r\n") {
+		t.Errorf("Expected 2 assigned slots, got %q", result)
+	}
+
+	// 테스트 케이스 3: 다른 슬롯의 키를 함께 다루면 CROSSSLOT
+	if _, err := registry.ExecuteForClient(client, "PFCOUNT", []string{"foo", "bar"}); err == nil || !strings.HasPrefix(err.Error(), "-CROSSSLOT") {
+		t.Errorf("Expected CROSSSLOT, got %v", err)
+	}
+	if _, err := registry.ExecuteForClient(client, "EVAL", []string{"return 1", "2", "foo", "bar"}); err == nil || !strings.HasPrefix(err.Error(), "-CROSSSLOT") {
+		t.Errorf("Expected CROSSSLOT for EVAL, got %v", err)
+	}
+
+	// 테스트 케이스 4: MEET한 노드에 SETSLOT으로 넘긴 슬롯은 MOVED
+	if _, err := registry.ExecuteForClient(client, "CLUSTER", []string{"SETSLOT", "5061", "NODE", "0000000000000000000000000000000000000000"}); err == nil || !strings.Contains(err.Error(), "Unknown node") {
+		t.Errorf("Expected unknown node error, got %v", err)
+	}
+	if result, err := registry.ExecuteForClient(client, "CLUSTER", []string{"MEET", "127.0.0.1", strconv.Itoa(otherPort)}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	if result, err := registry.ExecuteForClient(client, "CLUSTER", []string{"SETSLOT", "5061", "NODE", string(otherID.(protocol.BulkString))}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	want := "-MOVED 5061 127.0.0.1:" + strconv.Itoa(otherPort)
+	if _, err := registry.ExecuteForClient(client, "GET", []string{"bar"}); err == nil || err.Error() != want {
+		t.Errorf("Expected %q, got %v", want, err)
+	}
+	if _, err := registry.ExecuteForClient(client, "EVAL", []string{"return redis.call('GET', 'bar')", "0"}); err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("Expected %q from a script, got %v", want, err)
+	}
+	if result, _ := registry.ExecuteForClient(client, "CLUSTER", []string{"INFO"}); !strings.Contains(string(result.(protocol.BulkString)), "cluster_known_nodes:2\r\n") || !strings.Contains(string(result.(protocol.BulkString)), "cluster_size:2\r\n") {
+		t.Errorf("Expected 2 known nodes in a cluster of size 2, got %q", result)
+	}
+	if _, err := registry.ExecuteForClient(client, "CLUSTER", []string{"MEET", "127.0.0.1", "1"}); err == nil {
+		t.Error("Expected error when meeting an unreachable node")
+	}
+
+	// 테스트 케이스 5: MULTI 중의 MOVED는 트랜잭션을 실패로 표시
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	registry.ExecuteForClient(client, "GET", []string{"bar"})
+	if _, err := registry.ExecuteForClient(client, "EXEC", []string{}); err == nil || !strings.HasPrefix(err.Error(), "-EXECABORT") {
+		t.Errorf("Expected EXECABORT, got %v", err)
+	}
+}
+
+// TestClusterTopology는 CLUSTER KEYSLOT, SLOTS, SHARDS, NODES 응답을 테스트합니다.
+func TestClusterTopology(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetClusterEnabled(true)
+	registry.SetListeningPort(7000)
+	client, _ := newTestClient(registry)
+	myID, _ := registry.ExecuteForClient(client, "CLUSTER", []string{"MYID"})
+
+	other := NewCommandRegistry(store.NewStore())
+	other.SetClusterEnabled(true)
+	otherPort := serveRegistry(t, other)
+	otherID, _ := other.Execute("CLUSTER", []string{"MYID"})
+
+	// 테스트 케이스 1: KEYSLOT
+	if result, err := registry.ExecuteForClient(client, "CLUSTER", []string{"KEYSLOT", "foo"}); err != nil || result != protocol.Integer(12182) {
+		t.Errorf("Expected 12182, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 2: 슬롯이 없으면 빈 SLOTS, 이 노드만 있는 NODES
+	if result, _ := registry.ExecuteForClient(client, "CLUSTER", []string{"SLOTS"}); len(result.(protocol.Array)) != 0 {
+		t.Errorf("Expected no slot ranges, got %v", result)
+	}
+	want := myID.(protocol.BulkString) + " 127.0.0.1:7000@17000 myself,master - 0 0 0 connected\n"
+	if result, _ := registry.ExecuteForClient(client, "CLUSTER", []string{"NODES"}); result != want {
+		t.Errorf("Expected %q, got %q", want, result)
+	}
+
+	// 테스트 케이스 3: 연속된 슬롯은 범위로 묶임
+	registry.ExecuteForClient(client, "CLUSTER", []string{"ADDSLOTS", "0", "1", "2", "5", "10", "11"})
+	registry.ExecuteForClient(client, "CLUSTER", []string{"MEET", "127.0.0.1", strconv.Itoa(otherPort)})
+	registry.ExecuteForClient(client, "CLUSTER", []string{"SETSLOT", "11", "NODE", string(otherID.(protocol.BulkString))})
+
+	result, _ := registry.ExecuteForClient(client, "CLUSTER", []string{"SLOTS"})
+	me := protocol.Array{protocol.BulkString("127.0.0.1"), protocol.Integer(7000), myID}
+	them := protocol.Array{protocol.BulkString("127.0.0.1"), protocol.Integer(otherPort), otherID}
+	wantSlots := protocol.Array{
+		protocol.Array{protocol.Integer(0), protocol.Integer(2), me},
+		protocol.Array{protocol.Integer(5), protocol.Integer(5), me},
+		protocol.Array{protocol.Integer(10), protocol.Integer(10), me},
+		protocol.Array{protocol.Integer(11), protocol.Integer(11), them},
+	}
+	if !reflect.DeepEqual(result, wantSlots) {
+		t.Errorf("Expected %v, got %v", wantSlots, result)
+	}
+
+	result, _ = registry.ExecuteForClient(client, "CLUSTER", []string{"NODES"})
+	lines := strings.Split(strings.TrimSuffix(string(result.(protocol.BulkString)), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 nodes, got %q", result)
+	}
+	if want := string(myID.(protocol.BulkString)) + " 127.0.0.1:7000@17000 myself,master - 0 0 0 connected 0-2 5 10"; lines[0] != want {
+		t.Errorf("Expected %q, got %q", want, lines[0])
+	}
+	if want := string(otherID.(protocol.BulkString)) + " 127.0.0.1:" + strconv.Itoa(otherPort) + "@" + strconv.Itoa(otherPort+10000) + " master - 0 0 0 connected 11"; lines[1] != want {
+		t.Errorf("Expected %q, got %q", want, lines[1])
+	}
+
+	// 테스트 케이스 4: 노드마다 샤드 하나
+	result, _ = registry.ExecuteForClient(client, "CLUSTER", []string{"SHARDS"})
+	shards := result.(protocol.Array)
+	if len(shards) != 2 {
+		t.Fatalf("Expected 2 shards, got %v", result)
+	}
+	shard := shards[0].(protocol.Array)
+	if !reflect.DeepEqual(shard[1], protocol.Array{protocol.Integer(0), protocol.Integer(2), protocol.Integer(5), protocol.Integer(5), protocol.Integer(10), protocol.Integer(10)}) {
+		t.Errorf("Expected slot ranges of this node, got %v", shard[1])
+	}
+	node := shard[3].(protocol.Array)[0].(protocol.Array)
+	if node[0] != protocol.BulkString("id") || node[1] != myID || node[3] != protocol.Integer(7000) {
+		t.Errorf("Expected attributes of this node, got %v", node)
+	}
+}
+
+// TestClusterMigration은 IMPORTING/MIGRATING 슬롯 상태와 -ASK 응답, MIGRATE로 슬롯을 옮기는 과정을 테스트합니다.
+func TestClusterMigration(t *testing.T) {
+	source := NewCommandRegistry(store.NewStore())
+	source.SetClusterEnabled(true)
+	sourceClient, _ := newTestClient(source)
+	sourcePort := serveRegistry(t, source)
+	sourceID, _ := source.Execute("CLUSTER", []string{"MYID"})
+
+	target := NewCommandRegistry(store.NewStore())
+	target.SetClusterEnabled(true)
+	targetClient, _ := newTestClient(target)
+	targetPort := serveRegistry(t, target)
+	targetID, _ := target.Execute("CLUSTER", []string{"MYID"})
+
+	slot := strconv.Itoa(keyHashSlot("{m}"))
+	source.ExecuteForClient(sourceClient, "CLUSTER", []string{"ADDSLOTS", slot})
+	source.ExecuteForClient(sourceClient, "CLUSTER", []string{"MEET", "127.0.0.1", strconv.Itoa(targetPort)})
+	target.ExecuteForClient(targetClient, "CLUSTER", []string{"MEET", "127.0.0.1", strconv.Itoa(sourcePort)})
+	target.ExecuteForClient(targetClient, "CLUSTER", []string{"SETSLOT", slot, "NODE", string(sourceID.(protocol.BulkString))})
+	for _, key := range []string{"{m}a", "{m}b", "{m}c"} {
+		source.ExecuteForClient(sourceClient, "SET", []string{key, key})
+	}
+
+	// 테스트 케이스 1: COUNTKEYSINSLOT, GETKEYSINSLOT
+	if result, err := source.ExecuteForClient(sourceClient, "CLUSTER", []string{"COUNTKEYSINSLOT", slot}); err != nil || result != protocol.Integer(3) {
+		t.Errorf("Expected 3, got %v, %v", result, err)
+	}
+	if result, _ := source.ExecuteForClient(sourceClient, "CLUSTER", []string{"GETKEYSINSLOT", slot, "2"}); !reflect.DeepEqual(result, protocol.BulkStrings{"{m}a", "{m}b"}) {
+		t.Errorf("Expected [{m}a {m}b], got %v", result)
+	}
+
+	// 테스트 케이스 2: 잘못된 SETSLOT 상태 변경
+	for _, tt := range []struct {
+		registry *CommandRegistry
+		args     []string
+	}{
+		{source, []string{"SETSLOT", slot, "IMPORTING", string(targetID.(protocol.BulkString))}},
+		{source, []string{"SETSLOT", slot, "MIGRATING", string(sourceID.(protocol.BulkString))}},
+		{target, []string{"SETSLOT", slot, "MIGRATING", string(sourceID.(protocol.BulkString))}},
+		{target, []string{"SETSLOT", slot, "IMPORTING", string(targetID.(protocol.BulkString))}},
+	} {
+		if _, err := tt.registry.Execute("CLUSTER", tt.args); err == nil {
+			t.Errorf("Expected error for CLUSTER %v", tt.args)
+		}
+	}
+
+	// 테스트 케이스 3: 옮기기 시작
+	if result, err := target.ExecuteForClient(targetClient, "CLUSTER", []string{"SETSLOT", slot, "IMPORTING", string(sourceID.(protocol.BulkString))}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	if result, err := source.ExecuteForClient(sourceClient, "CLUSTER", []string{"SETSLOT", slot, "MIGRATING", string(targetID.(protocol.BulkString))}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	if result, _ := source.ExecuteForClient(sourceClient, "CLUSTER", []string{"NODES"}); !strings.Contains(string(result.(protocol.BulkString)), "["+slot+"->-"+string(targetID.(protocol.BulkString))+"]") {
+		t.Errorf("Expected migrating marker, got %q", result)
+	}
+	if result, _ := target.ExecuteForClient(targetClient, "CLUSTER", []string{"NODES"}); !strings.Contains(string(result.(protocol.BulkString)), "["+slot+"-<-"+string(sourceID.(protocol.BulkString))+"]") {
+		t.Errorf("Expected importing marker, got %q", result)
+	}
+
+	// 테스트 케이스 4: 옮긴 키는 원래 노드에서 -ASK, 일부만 남은 여러 키 명령어는 TRYAGAIN
+	if result, err := source.ExecuteForClient(sourceClient, "MIGRATE", []string{"127.0.0.1", strconv.Itoa(targetPort), "", "0", "1000", "KEYS", "{m}a", "{m}b"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	askErr := "-ASK " + slot + " 127.0.0.1:" + strconv.Itoa(targetPort)
+	if _, err := source.ExecuteForClient(sourceClient, "GET", []string{"{m}a"}); err == nil || err.Error() != askErr {
+		t.Errorf("Expected %q, got %v", askErr, err)
+	}
+	if result, err := source.ExecuteForClient(sourceClient, "GET", []string{"{m}c"}); err != nil || !isBulk(result, "{m}c") {
+		t.Errorf("Expected {m}c, got %v, %v", result, err)
+	}
+	if _, err := source.ExecuteForClient(sourceClient, "PFCOUNT", []string{"{m}a", "{m}c"}); err == nil || !strings.HasPrefix(err.Error(), "-TRYAGAIN") {
+		t.Errorf("Expected TRYAGAIN, got %v", err)
+	}
+
+	// 테스트 케이스 5: 가져오는 노드는 ASKING 바로 다음 명령어 하나만 실행
+	if _, err := target.ExecuteForClient(targetClient, "GET", []string{"{m}a"}); err == nil || !strings.HasPrefix(err.Error(), "-MOVED") {
+		t.Errorf("Expected MOVED without ASKING, got %v", err)
+	}
+	if result, err := target.ExecuteForClient(targetClient, "ASKING", []string{}); err != nil || !isStatus(result, "OK") {
+		t.Errorf("Expected OK, got %v, %v", result, err)
+	}
+	if result, err := target.ExecuteForClient(targetClient, "GET", []string{"{m}a"}); err != nil || !isBulk(result, "{m}a") {
+		t.Errorf("Expected {m}a, got %v, %v", result, err)
+	}
+	if _, err := target.ExecuteForClient(targetClient, "GET", []string{"{m}b"}); err == nil || !strings.HasPrefix(err.Error(), "-MOVED") {
+		t.Errorf("Expected MOVED after the asking flag is consumed, got %v", err)
+	}
+
+	// 테스트 케이스 6: 키가 남아 있으면 슬롯을 넘길 수 없고, 모두 옮긴 뒤 양쪽에서 SETSLOT NODE로 마무리
+	if _, err := source.ExecuteForClient(sourceClient, "CLUSTER", []string{"SETSLOT", slot, "NODE", string(targetID.(protocol.BulkString))}); err == nil || !strings.Contains(err.Error(), "still hold keys") {
+		t.Errorf("Expected error while keys remain, got %v", err)
+	}
+	source.ExecuteForClient(sourceClient, "MIGRATE", []string{"127.0.0.1", strconv.Itoa(targetPort), "{m}c", "0", "1000"})
+	for _, registry := range []*CommandRegistry{target, source} {
+		if result, err := registry.Execute("CLUSTER", []string{"SETSLOT", slot, "NODE", string(targetID.(protocol.BulkString))}); err != nil || !isStatus(result, "OK") {
+			t.Fatalf("Expected OK, got %v, %v", result, err)
+		}
+	}
+	if result, err := target.ExecuteForClient(targetClient, "GET", []string{"{m}c"}); err != nil || !isBulk(result, "{m}c") {
+		t.Errorf("Expected {m}c, got %v, %v", result, err)
+	}
+	movedErr := "-MOVED " + slot + " 127.0.0.1:" + strconv.Itoa(targetPort)
+	if _, err := source.ExecuteForClient(sourceClient, "GET", []string{"{m}a"}); err == nil || err.Error() != movedErr {
+		t.Errorf("Expected %q, got %v", movedErr, err)
+	}
+	if result, _ := source.ExecuteForClient(sourceClient, "CLUSTER", []string{"NODES"}); strings.Contains(string(result.(protocol.BulkString)), "->-") {
+		t.Errorf("Expected migrating marker to be cleared, got %q", result)
+	}
+}
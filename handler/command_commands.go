@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// CommandCommandHandler는 COMMAND 명령어를 처리하는 핸들러입니다.
+//
+// Redis COMMAND 명령어 사양:
+//   - COMMAND: 등록된 모든 명령어의 정보
+//   - COMMAND COUNT: 등록된 명령어 개수
+//   - COMMAND INFO [command ...]: 지정한 명령어들의 정보 (알 수 없는 명령어는 nil, 생략하면 모든 명령어)
+//   - COMMAND DOCS [command ...]: 명령어 이름과 문서 맵 (요약, 추가된 버전, 그룹)
+//   - COMMAND GETKEYS command [arg ...]: 명령어 줄에서 키 인자들을 골라 반환
+//   - COMMAND HELP: 서브커맨드들의 사용법
+//
+// 명령어 정보는 레지스트리가 실행 시 사용하는 메타데이터(commands.json으로 만든 commandTable,
+// RegisterCommand로 등록한 명령어는 CommandSpec)에서 만듭니다:
+//   - arity: 인자 개수 규칙
+//   - 키 위치: 1부터 시작, 음수이면 끝에서부터 (키 위치가 다른 인자에 따라 정해지면 0, 0, 0)
+//   - 플래그: write, readonly, admin, noscript, blocking, movablekeys, fast
+//   - 서브커맨드: CONFIG처럼 서브커맨드로 이루어진 명령어의 서브커맨드별 이름("config|get")과 arity
+//
+// 예시:
+//
+//	클라이언트: COMMAND INFO get
+//	서버: *1\r\n*10\r\n$3\r\nget\r\n:2\r\n*1\r\n+readonly\r\n:1\r\n:1\r\n:1\r\n*0\r\n*0\r\n*0\r\n*0\r\n
+type CommandCommandHandler struct {
+	registry *CommandRegistry
+}
+
+// Execute는 COMMAND 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.Array: 명령어 정보 목록
+//   - protocol.Map: DOCS 결과
+//   - protocol.Integer: COUNT 결과
+//   - protocol.BulkStrings: GETKEYS 결과
+//   - error: 알 수 없는 서브커맨드, GETKEYS의 명령어가 없거나 인자 개수가 틀리거나 키가 없는 경우
+func (h *CommandCommandHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) == 0 {
+		return h.registry.commandInfos(h.registry.sortedCommands()), nil
+	}
+
+	return h.subcommands().dispatch(nil, args, store)
+}
+
+// subcommands는 COMMAND의 서브커맨드 목록입니다.
+func (h *CommandCommandHandler) subcommands() *subcommandTable {
+	return &subcommandTable{command: "COMMAND", subs: []subcommand{
+		{"COUNT", 2, []string{"COUNT", "Return the total number of commands in this Redis server."}, h.count},
+		{"DOCS", -2, []string{"DOCS [<command-name> ...]",
+			"Return documentation details about multiple Redis commands.",
+			"If no command names are given, documentation details for all",
+			"commands are returned."}, h.docs},
+		{"GETKEYS", -3, []string{"GETKEYS <full-command>", "Return the keys from a full Redis command."}, h.getKeys},
+		{"INFO", -2, []string{"INFO [<command-name> ...]",
+			"Return details about multiple Redis commands.",
+			"If no command names are given, documentation details for all",
+			"commands are returned."}, h.info},
+	}}
+}
+
+// count는 COMMAND COUNT를 실행합니다.
+func (h *CommandCommandHandler) count(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return protocol.Integer(len(h.registry.handlers)), nil
+}
+
+// info는 COMMAND INFO를 실행합니다. 명령어를 생략하면 모든 명령어의 정보입니다.
+func (h *CommandCommandHandler) info(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) == 1 {
+		return h.registry.commandInfos(h.registry.sortedCommands()), nil
+	}
+	return h.registry.commandInfos(args[1:]), nil
+}
+
+// docs는 COMMAND DOCS를 실행합니다. 등록된 명령어만 commands.json의 요약, 버전, 그룹과 함께 나열합니다.
+func (h *CommandCommandHandler) docs(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	names := args[1:]
+	if len(names) == 0 {
+		names = h.registry.sortedCommands()
+	}
+	result := protocol.Map{}
+	for _, name := range names {
+		if h.registry.HasCommand(name) {
+			result = append(result, protocol.BulkString(strings.ToLower(name)), h.registry.commandDocs(strings.ToUpper(name)))
+		}
+	}
+	return result, nil
+}
+
+// commandDocs는 명령어 하나의 COMMAND DOCS 응답입니다 ({summary, since, group}).
+// 설명이 없는 명령어(Register, RegisterCommand로 등록)는 빈 맵입니다.
+func (r *CommandRegistry) commandDocs(cmdUpper string) protocol.Map {
+	doc := protocol.Map{}
+	meta, _ := r.lookupCommandMeta(cmdUpper)
+	if meta.summary != "" {
+		doc = append(doc, protocol.BulkString("summary"), protocol.BulkString(meta.summary))
+	}
+	if meta.since != "" {
+		doc = append(doc, protocol.BulkString("since"), protocol.BulkString(meta.since))
+	}
+	if meta.group != "" {
+		doc = append(doc, protocol.BulkString("group"), protocol.BulkString(meta.group))
+	}
+	return doc
+}
+
+// getKeys는 COMMAND GETKEYS를 실행합니다.
+func (h *CommandCommandHandler) getKeys(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	keys, err := h.registry.getKeys(strings.ToUpper(args[1]), args[2:])
+	if err != nil {
+		return nil, err
+	}
+	return protocol.BulkStrings(keys), nil
+}
+
+// sortedCommands는 등록된 명령어 이름들을 이름 순으로 반환합니다.
+func (r *CommandRegistry) sortedCommands() []string {
+	names := r.GetRegisteredCommands()
+	sort.Strings(names)
+	return names
+}
+
+// commandInfos는 명령어들의 COMMAND INFO 응답을 만듭니다. 등록되지 않은 명령어는 nil입니다.
+func (r *CommandRegistry) commandInfos(names []string) protocol.Array {
+	result := make(protocol.Array, len(names))
+	for i, name := range names {
+		if info := r.commandInfo(strings.ToUpper(name)); info != nil {
+			result[i] = info
+		}
+	}
+	return result
+}
+
+// commandInfo는 명령어 하나의 정보를 Redis 7의 COMMAND INFO 형식으로 만듭니다.
+// [이름, arity, {플래그...}, 첫 키, 마지막 키, 키 간격, [ACL 범주], [팁], [키 명세], [서브커맨드]]
+// 플래그는 RESP3에서 Set으로 작성됩니다.
+// 서브커맨드로 이루어진 명령어(CONFIG 등)는 서브커맨드마다 같은 형식의 정보를 보고합니다.
+// ACL 범주, 팁, 키 명세는 빈 배열입니다.
+// 등록되지 않은 명령어는 nil입니다.
+func (r *CommandRegistry) commandInfo(cmdUpper string) protocol.Array {
+	if !r.HasCommand(cmdUpper) {
+		return nil
+	}
+
+	// Register로 등록해 메타데이터가 없는 명령어는 arity 0, 플래그와 키 위치 없음
+	meta, _ := r.lookupCommandMeta(cmdUpper)
+
+	var first, last, step int
+	if meta.hasKeys() {
+		first, last, step = meta.keys.first+1, meta.keys.last, meta.keys.step
+		if last >= 0 {
+			last++
+		}
+	}
+
+	flags := protocol.Set{}
+	for _, flag := range meta.flagNames() {
+		flags = append(flags, protocol.SimpleString(flag))
+	}
+
+	subcommands := protocol.Array{}
+	if container, ok := r.handlers[cmdUpper].(containerCommandHandler); ok {
+		subcommands = container.subcommands().infos(flags)
+	}
+
+	return protocol.Array{
+		protocol.BulkString(strings.ToLower(cmdUpper)), protocol.Integer(meta.arity), flags,
+		protocol.Integer(first), protocol.Integer(last), protocol.Integer(step),
+		protocol.Array{}, protocol.Array{}, protocol.Array{}, subcommands,
+	}
+}
+
+// getKeys는 명령어 줄에서 키 인자들을 골라 반환합니다 (COMMAND GETKEYS).
+func (r *CommandRegistry) getKeys(cmdUpper string, args []string) ([]string, error) {
+	if !r.HasCommand(cmdUpper) {
+		return nil, &InvalidArgumentError{Message: "Invalid command specified"}
+	}
+	if !r.checkArity(cmdUpper, len(args)) {
+		return nil, &InvalidArgumentError{Message: "Invalid number of arguments specified for command"}
+	}
+	keys := commandKeys(cmdUpper, args)
+	if len(keys) == 0 {
+		return nil, &InvalidArgumentError{Message: "The command has no key arguments"}
+	}
+	return keys, nil
+}
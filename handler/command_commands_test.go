@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestCommandInfo는 COMMAND, COMMAND COUNT, COMMAND INFO가 명령어 정보를 보고하는지 테스트합니다.
+func TestCommandInfo(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.RegisterCommand(CommandSpec{
+		Name:  "HELLOWORLD",
+		Arity: 1,
+		Flags: []string{FlagReadOnly, FlagFast},
+		Func: func(ctx *CommandContext, args []string) (protocol.Reply, error) {
+			return protocol.BulkString("hello"), nil
+		},
+	})
+
+	// 테스트 케이스 1: COUNT와 전체 목록의 개수가 일치
+	count, _ := registry.Execute("COMMAND", []string{"COUNT"})
+	all, _ := registry.Execute("COMMAND", []string{})
+	if count != protocol.Integer(len(registry.handlers)) || count != protocol.Integer(len(all.(protocol.Array))) {
+		t.Errorf("Expected %d commands, got COUNT %v and %d infos", len(registry.handlers), count, len(all.(protocol.Array)))
+	}
+
+	// 테스트 케이스 2: 명령어별 arity, 플래그, 키 위치
+	status := func(flags ...string) protocol.Set {
+		result := protocol.Set{}
+		for _, flag := range flags {
+			result = append(result, protocol.SimpleString(flag))
+		}
+		return result
+	}
+	info := func(name string, arity int, flags protocol.Set, first, last, step int) protocol.Array {
+		empty := protocol.Array{}
+		return protocol.Array{
+			protocol.BulkString(name), protocol.Integer(arity), flags,
+			protocol.Integer(first), protocol.Integer(last), protocol.Integer(step),
+			empty, empty, empty, empty,
+		}
+	}
+	tests := []struct {
+		name     string
+		expected protocol.Array
+	}{
+		{"get", info("get", 2, status("readonly"), 1, 1, 1)},
+		{"SET", info("set", -3, status("write", "denyoom"), 1, 1, 1)},
+		{"blpop", info("blpop", -3, status("write", "blocking"), 1, -2, 1)},
+		{"bitop", info("bitop", -4, status("write", "denyoom"), 2, -1, 1)},
+		{"eval", info("eval", -3, status("noscript", "movablekeys"), 0, 0, 0)},
+		{"ping", info("ping", -1, status(), 0, 0, 0)},
+		{"save", info("save", 1, status("admin", "noscript"), 0, 0, 0)},
+		{"helloworld", info("helloworld", 1, status("readonly", "fast"), 0, 0, 0)},
+	}
+	for _, tt := range tests {
+		result, err := registry.Execute("COMMAND", []string{"INFO", tt.name})
+		if expected := (protocol.Array{tt.expected}); err != nil || !reflect.DeepEqual(result, expected) {
+			t.Errorf("COMMAND INFO %s: expected %v, got %v, %v", tt.name, expected, result, err)
+		}
+	}
+
+	// 테스트 케이스 3: 알 수 없는 명령어는 nil
+	if result, _ := registry.Execute("COMMAND", []string{"INFO", "nosuch", "get"}); result.(protocol.Array)[0] != nil || result.(protocol.Array)[1] == nil {
+		t.Errorf("Expected [nil, info], got %v", result)
+	}
+
+	// 테스트 케이스 4: DOCS는 등록된 명령어만 commands.json의 문서와 함께 나열
+	expectedDocs := protocol.Map{protocol.BulkString("get"), protocol.Map{
+		protocol.BulkString("summary"), protocol.BulkString("Returns the string value of a key."),
+		protocol.BulkString("since"), protocol.BulkString("1.0.0"),
+		protocol.BulkString("group"), protocol.BulkString("string"),
+	}}
+	if result, _ := registry.Execute("COMMAND", []string{"DOCS", "GET", "nosuch"}); !reflect.DeepEqual(result, expectedDocs) {
+		t.Errorf("Expected {get: {summary, since, group}}, got %v", result)
+	}
+}
+
+// TestCommandGetKeys는 COMMAND GETKEYS가 명령어 줄에서 키를 골라내는지 테스트합니다.
+func TestCommandGetKeys(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{"SET", "a", "1", "PX", "100"}, []string{"a"}},
+		{[]string{"BLPOP", "a", "b", "0"}, []string{"a", "b"}},
+		{[]string{"pfmerge", "dest", "s1", "s2"}, []string{"dest", "s1", "s2"}},
+		{[]string{"EVAL", "return 1", "2", "k1", "k2", "arg"}, []string{"k1", "k2"}},
+		{[]string{"MIGRATE", "host", "6379", "", "0", "1000", "KEYS", "k1", "k2"}, []string{"k1", "k2"}},
+	}
+	for _, tt := range tests {
+		if result, err := registry.Execute("COMMAND", append([]string{"GETKEYS"}, tt.args...)); err != nil || !reflect.DeepEqual(result, protocol.BulkStrings(tt.expected)) {
+			t.Errorf("GETKEYS %v: expected %v, got %v, %v", tt.args, tt.expected, result, err)
+		}
+	}
+
+	errorTests := []struct {
+		args    []string
+		message string
+	}{
+		{[]string{"NOSUCH", "a"}, "Invalid command specified"},
+		{[]string{"GET"}, "Invalid number of arguments"},
+		{[]string{"PING"}, "no key arguments"},
+		{[]string{"EVAL", "return 1", "0"}, "no key arguments"},
+	}
+	for _, tt := range errorTests {
+		if _, err := registry.Execute("COMMAND", append([]string{"GETKEYS"}, tt.args...)); err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("GETKEYS %v: expected %q error, got %v", tt.args, tt.message, err)
+		}
+	}
+}
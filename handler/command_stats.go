@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+)
+
+// latencyInfoPercentiles는 INFO latencystats가 보고하는 백분위수입니다
+// (Redis의 latency-tracking-info-percentiles 기본값과 동일).
+var latencyInfoPercentiles = []float64{50, 99, 99.9}
+
+// 명령어 실행 시간 히스토그램의 구간 (마이크로초)
+//   - histogramLinear 미만의 값은 1마이크로초마다 한 구간
+//   - 그 이상은 2의 거듭제곱마다 histogramSubBuckets개의 구간으로 나눔 (오차 12.5% 이하)
+const (
+	histogramLinear     = 16
+	histogramSubBuckets = 8
+	histogramBuckets    = histogramLinear + (64-4)*histogramSubBuckets
+)
+
+// commandStat은 명령어 하나의 누적 통계입니다 (INFO commandstats, latencystats).
+// 모든 필드는 원자적으로 읽고 쓰므로 명령어를 실행하는 여러 고루틴이 잠금 없이 갱신합니다.
+type commandStat struct {
+	calls    atomic.Int64 // 핸들러를 호출한 횟수 (calls)
+	nanos    atomic.Int64 // 실행 시간의 합, 나노초 (usec)
+	rejected atomic.Int64 // 실행하기 전에 거부한 횟수, 인자 개수, 권한, OOM 등 (rejected_calls)
+	failed   atomic.Int64 // 실행했지만 에러를 반환한 횟수 (failed_calls)
+
+	// histogram은 실행 시간의 분포입니다 (histogramBucket의 구간마다 횟수).
+	histogram [histogramBuckets]atomic.Int64
+}
+
+// commandStats는 명령어별 통계입니다. 키는 대문자로 정규화한 원래 명령어 이름입니다 (rename-command 이전).
+// 한 번이라도 실행하거나 거부한 명령어만 있습니다.
+type commandStats struct {
+	stats sync.Map // string → *commandStat
+}
+
+// get은 명령어의 통계를 반환합니다. 없으면 새로 만듭니다.
+func (s *commandStats) get(cmdUpper string) *commandStat {
+	if stat, ok := s.stats.Load(cmdUpper); ok {
+		return stat.(*commandStat)
+	}
+	stat, _ := s.stats.LoadOrStore(cmdUpper, &commandStat{})
+	return stat.(*commandStat)
+}
+
+// reset은 모든 명령어의 통계를 지웁니다 (CONFIG RESETSTAT).
+func (s *commandStats) reset() {
+	s.stats.Clear()
+}
+
+// sorted는 통계가 있는 명령어들을 이름 순으로 반환합니다.
+func (s *commandStats) sorted() []string {
+	var names []string
+	s.stats.Range(func(key, value any) bool {
+		names = append(names, key.(string))
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+// record는 핸들러를 호출한 명령어 한 번을 기록합니다.
+// timed가 false이면 실행 시간을 통계에 넣지 않습니다 (대기하는 명령어의 대기 시간은 실행 시간이 아님).
+func (s *commandStat) record(duration time.Duration, err error, timed bool) {
+	s.calls.Add(1)
+	if err != nil {
+		s.failed.Add(1)
+	}
+	if timed {
+		s.nanos.Add(int64(duration))
+		s.histogram[histogramBucket(max(duration.Microseconds(), 1))].Add(1)
+	}
+}
+
+// histogramBucket은 usec마이크로초가 들어가는 히스토그램 구간입니다.
+func histogramBucket(usec int64) int {
+	if usec < histogramLinear {
+		return int(usec)
+	}
+	shift := bits.Len64(uint64(usec)) - 4
+	sub := int(usec>>shift) - histogramSubBuckets
+	return histogramLinear + (shift-1)*histogramSubBuckets + sub
+}
+
+// histogramUpper는 히스토그램 구간 i에 들어가는 가장 큰 값입니다 (마이크로초).
+func histogramUpper(i int) int64 {
+	if i < histogramLinear {
+		return int64(i)
+	}
+	i -= histogramLinear
+	shift := i/histogramSubBuckets + 1
+	sub := int64(i%histogramSubBuckets + histogramSubBuckets)
+	return (sub+1)<<shift - 1
+}
+
+// percentiles는 실행 시간의 백분위수들을 마이크로초로 반환합니다 (구간의 가장 큰 값).
+// 기록이 없으면 nil을 반환합니다.
+func (s *commandStat) percentiles(ps []float64) []int64 {
+	var counts [histogramBuckets]int64
+	var total int64
+	for i := range s.histogram {
+		counts[i] = s.histogram[i].Load()
+		total += counts[i]
+	}
+	if total == 0 {
+		return nil
+	}
+
+	result := make([]int64, len(ps))
+	for j, p := range ps {
+		rank := max(int64(math.Ceil(p/100*float64(total))), 1)
+		var seen int64
+		for i, count := range counts {
+			seen += count
+			if seen >= rank {
+				result[j] = histogramUpper(i)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// commandStatsInfo는 INFO commandstats 섹션의 필드들을 반환합니다.
+//
+//	cmdstat_get:calls=2,usec=15,usec_per_call=7.50,rejected_calls=0,failed_calls=0
+func commandStatsInfo(r *CommandRegistry) [][2]string {
+	var fields [][2]string
+	for _, name := range r.commandStats.sorted() {
+		stat := r.commandStats.get(name)
+		calls, usec := stat.calls.Load(), stat.nanos.Load()/1000
+		perCall := 0.0
+		if calls > 0 {
+			perCall = float64(usec) / float64(calls)
+		}
+		fields = append(fields, [2]string{"cmdstat_" + strings.ToLower(name),
+			"calls=" + strconv.FormatInt(calls, 10) +
+				",usec=" + strconv.FormatInt(usec, 10) +
+				",usec_per_call=" + strconv.FormatFloat(perCall, 'f', 2, 64) +
+				",rejected_calls=" + strconv.FormatInt(stat.rejected.Load(), 10) +
+				",failed_calls=" + strconv.FormatInt(stat.failed.Load(), 10)})
+	}
+	return fields
+}
+
+// latencyStatsInfo는 INFO latencystats 섹션의 필드들을 반환합니다 (실행 시간을 기록한 명령어만).
+//
+//	latency_percentiles_usec_get:p50=3.000,p99=9.000,p99.9=9.000
+func latencyStatsInfo(r *CommandRegistry) [][2]string {
+	var fields [][2]string
+	for _, name := range r.commandStats.sorted() {
+		values := r.commandStats.get(name).percentiles(latencyInfoPercentiles)
+		if values == nil {
+			continue
+		}
+		parts := make([]string, len(values))
+		for i, v := range values {
+			parts[i] = "p" + strconv.FormatFloat(latencyInfoPercentiles[i], 'f', -1, 64) + "=" + strconv.FormatFloat(float64(v), 'f', 3, 64)
+		}
+		fields = append(fields, [2]string{"latency_percentiles_usec_" + strings.ToLower(name), strings.Join(parts, ",")})
+	}
+	return fields
+}
+
+// rejectCommand는 실행하기 전에 거부한 명령어를 기록하고 err를 반환합니다.
+// MULTI 중이면 트랜잭션을 실패로 표시합니다 (EXEC는 EXECABORT).
+func (r *CommandRegistry) rejectCommand(client *Client, cmdUpper string, err error) (protocol.Reply, error) {
+	client.flagTransaction()
+	r.commandStats.get(cmdUpper).rejected.Add(1)
+	return nil, err
+}
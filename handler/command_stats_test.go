@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestCommandStats는 INFO commandstats, latencystats 섹션과 CONFIG RESETSTAT을 테스트합니다.
+func TestCommandStats(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	registry.ExecuteForClient(client, "GET", []string{"a"})
+	registry.ExecuteForClient(client, "get", []string{"a"})
+	registry.ExecuteForClient(client, "GET", []string{"a", "b"})           // 인자 개수가 잘못되어 거부
+	registry.ExecuteForClient(client, "LPOP", []string{"a", "x"})          // 개수가 정수가 아니어서 실패
+	registry.ExecuteForClient(client, "NOSUCHCOMMAND", []string{"a", "b"}) // 알 수 없는 명령어는 통계 없음
+
+	// 테스트 케이스 1: 명령어마다 호출, 거부, 실패 횟수를 이름 순으로 보고
+	info, _ := registry.ExecuteForClient(client, "INFO", []string{"commandstats"})
+	text := string(info.(protocol.BulkString))
+	for _, expected := range []string{
+		"# Commandstats\r\ncmdstat_get:calls=2,",
+		",rejected_calls=1,failed_calls=0\r\n",
+		"cmdstat_lpop:calls=1,",
+		",rejected_calls=0,failed_calls=1\r\n",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in %q", expected, text)
+		}
+	}
+	if strings.Contains(text, "nosuchcommand") {
+		t.Errorf("Expected no stats for an unknown command, got %q", text)
+	}
+	if strings.Index(text, "cmdstat_get") > strings.Index(text, "cmdstat_set") {
+		t.Errorf("Expected commands sorted by name, got %q", text)
+	}
+
+	// 테스트 케이스 2: 실행 시간을 기록한 명령어의 백분위수
+	info, _ = registry.ExecuteForClient(client, "INFO", []string{"latencystats"})
+	if text := string(info.(protocol.BulkString)); !strings.Contains(text, "# Latencystats\r\nlatency_percentiles_usec_get:p50=") ||
+		!strings.Contains(text, ",p99=") || !strings.Contains(text, ",p99.9=") {
+		t.Errorf("Expected GET percentiles, got %q", text)
+	}
+
+	// 테스트 케이스 3: 기본 INFO에는 없고 all에는 있음
+	info, _ = registry.ExecuteForClient(client, "INFO", nil)
+	if strings.Contains(string(info.(protocol.BulkString)), "# Commandstats") {
+		t.Errorf("Expected no commandstats in the default sections, got %q", info)
+	}
+	info, _ = registry.ExecuteForClient(client, "INFO", []string{"all"})
+	if !strings.Contains(string(info.(protocol.BulkString)), "# Commandstats") || !strings.Contains(string(info.(protocol.BulkString)), "# Latencystats") {
+		t.Errorf("Expected commandstats and latencystats in INFO all, got %q", info)
+	}
+
+	// 테스트 케이스 4: CONFIG RESETSTAT으로 지움 (RESETSTAT 자신은 지운 뒤에 기록)
+	registry.ExecuteForClient(client, "CONFIG", []string{"RESETSTAT"})
+	info, _ = registry.ExecuteForClient(client, "INFO", []string{"commandstats"})
+	if text := string(info.(protocol.BulkString)); !strings.HasPrefix(text, "# Commandstats\r\ncmdstat_config:calls=1,") || strings.Count(text, "cmdstat_") != 1 {
+		t.Errorf("Expected only the CONFIG RESETSTAT call, got %q", text)
+	}
+}
+
+// TestHistogramBucket은 실행 시간 히스토그램의 구간이 값을 포함하고 오차가 12.5% 이하인지 테스트합니다.
+func TestHistogramBucket(t *testing.T) {
+	for _, usec := range []int64{1, 15, 16, 17, 31, 32, 100, 1000, 123456, 1 << 40} {
+		i := histogramBucket(usec)
+		upper := histogramUpper(i)
+		if upper < usec || float64(upper-usec) > float64(usec)/8 {
+			t.Errorf("%d: bucket %d has upper bound %d", usec, i, upper)
+		}
+		if i > 0 && histogramUpper(i-1) >= usec {
+			t.Errorf("%d: expected bucket %d to start after %d", usec, i, histogramUpper(i-1))
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+)
+
+// commandFlag는 명령어의 성격을 나타내는 플래그입니다 (Redis 명령어 플래그와 같은 의미).
+// 레지스트리는 플래그로 명령어를 분류해 복제, 스크립트 호출 제한 등을 적용합니다.
+type commandFlag uint16
+
+const (
+	cmdWrite       commandFlag = 1 << iota // 데이터를 변경 (AOF/레플리카에 전달, 읽기 전용 레플리카에서 거부)
+	cmdReadOnly                            // 키를 읽기만 함
+	cmdAdmin                               // 저장, 복제, 설정 등 서버를 관리
+	cmdNoScript                            // 스크립트(redis.call)에서 호출할 수 없음
+	cmdBlocking                            // 클라이언트를 대기시킬 수 있음
+	cmdMovableKeys                         // 키 위치가 다른 인자에 따라 정해짐 (commandKeys에서 따로 처리)
+	cmdFast                                // 실행 시간이 일정하고 짧음
+	cmdDenyOOM                             // 메모리를 늘릴 수 있음 (maxmemory를 넘으면 거부)
+)
+
+// commandFlagNames는 COMMAND INFO가 보고하는 플래그 이름입니다 (보고하는 순서).
+var commandFlagNames = []struct {
+	flag commandFlag
+	name string
+}{
+	{cmdWrite, FlagWrite},
+	{cmdReadOnly, FlagReadOnly},
+	{cmdDenyOOM, FlagDenyOOM},
+	{cmdAdmin, "admin"},
+	{cmdNoScript, FlagNoScript},
+	{cmdBlocking, "blocking"},
+	{cmdMovableKeys, "movablekeys"},
+	{cmdFast, FlagFast},
+}
+
+// commandMeta는 명령어 하나의 메타데이터입니다.
+type commandMeta struct {
+	// arity는 명령어 이름을 포함한 인자 개수 규칙입니다 (Redis의 arity와 동일).
+	//   - 양수 N: 정확히 N개
+	//   - 음수 -N: 최소 N개
+	arity int
+
+	// flags는 명령어의 플래그들입니다.
+	flags commandFlag
+
+	// keys는 고정된 키 인자의 위치입니다 (명령어 이름 제외, 0부터 시작). step이 0이면 키가 없습니다.
+	keys keyRange
+
+	// group, since, summary는 COMMAND DOCS가 보고하는 명령어 그룹, 추가된 Redis 버전, 요약 설명입니다.
+	// RegisterCommand로 등록한 명령어는 비어 있습니다.
+	group   string
+	since   string
+	summary string
+}
+
+// has는 명령어에 플래그가 지정되어 있는지 확인합니다.
+func (m commandMeta) has(flag commandFlag) bool {
+	return m.flags&flag != 0
+}
+
+// hasKeys는 명령어에 고정된 키 위치가 있는지 확인합니다.
+func (m commandMeta) hasKeys() bool {
+	return m.keys.step > 0
+}
+
+// flagNames는 명령어의 플래그 이름들을 COMMAND INFO의 순서로 반환합니다.
+func (m commandMeta) flagNames() []string {
+	var names []string
+	for _, f := range commandFlagNames {
+		if m.has(f.flag) {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// commandsSpec은 내장 명령어들의 정의입니다 (commands.json).
+// 명령어마다 이름, arity, 플래그, 고정된 키 위치, 명령어 그룹, 추가된 Redis 버전, 요약 설명을 담습니다.
+//
+//go:embed commands.json
+var commandsSpec []byte
+
+// commandSpecEntry는 commands.json의 명령어 정의 하나입니다.
+type commandSpecEntry struct {
+	Name  string   `json:"name"`
+	Arity int      `json:"arity"`
+	Flags []string `json:"flags"`
+
+	// Keys는 고정된 키 인자의 위치입니다 (COMMAND INFO와 같이 명령어 이름이 0, 음수이면 끝에서부터).
+	// 키가 없거나 키 위치가 다른 인자에 따라 정해지는 명령어는 생략합니다.
+	Keys *struct {
+		First int `json:"first"`
+		Last  int `json:"last"`
+		Step  int `json:"step"`
+	} `json:"keys"`
+
+	Group   string `json:"group"`
+	Since   string `json:"since"`
+	Summary string `json:"summary"`
+}
+
+// commandTable은 내장 명령어들의 메타데이터입니다 (commandsSpec에서 만듦).
+//
+// NewCommandRegistry는 이 표의 명령어마다 builtinHandlers의 핸들러를 등록하고,
+// 레지스트리는 핸들러를 호출하기 전에 이 표의 arity로 인자 개수를 확인하고,
+// 플래그와 키 위치로 복제, 스크립트 호출 제한, 클러스터 슬롯 확인, COMMAND INFO 등을 처리합니다.
+// 새 내장 명령어를 추가하면 commands.json과 builtinHandlers에 모두 추가해야 합니다.
+//
+// 클러스터 모드에서 키 위치가 있는 명령어의 키는 모두 이 노드가 담당하는 같은 슬롯에 있어야 합니다
+// (여러 키를 다루려면 {tag} 해시 태그로 같은 슬롯에 모음).
+var commandTable = mustParseCommandsSpec(commandsSpec)
+
+// mustParseCommandsSpec은 명령어 정의를 메타데이터 표로 만듭니다.
+// 정의는 바이너리에 포함되므로, 형식이 잘못되었거나 알 수 없는 플래그가 있으면 프로그램 오류로 보고 패닉합니다.
+func mustParseCommandsSpec(data []byte) map[string]commandMeta {
+	var entries []commandSpecEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		panic("handler: invalid commands.json: " + err.Error())
+	}
+
+	table := make(map[string]commandMeta, len(entries))
+	for _, entry := range entries {
+		name := strings.ToUpper(entry.Name)
+		if _, dup := table[name]; dup || entry.Arity == 0 {
+			panic("handler: invalid commands.json entry for " + name)
+		}
+
+		meta := commandMeta{arity: entry.Arity, group: entry.Group, since: entry.Since, summary: entry.Summary}
+		for _, flagName := range entry.Flags {
+			flag, ok := commandFlagByName(flagName)
+			if !ok {
+				panic("handler: unknown flag " + flagName + " for " + name + " in commands.json")
+			}
+			meta.flags |= flag
+		}
+		if k := entry.Keys; k != nil {
+			// COMMAND INFO의 위치(명령어 이름이 0)를 명령어 이름을 뺀 인자의 위치로 바꿈
+			meta.keys = keyRange{first: k.First - 1, last: k.Last, step: k.Step}
+			if k.Last > 0 {
+				meta.keys.last--
+			}
+		}
+		table[name] = meta
+	}
+	return table
+}
+
+// commandFlagByName은 COMMAND INFO의 플래그 이름에 해당하는 플래그를 찾습니다.
+func commandFlagByName(name string) (commandFlag, bool) {
+	for _, f := range commandFlagNames {
+		if f.name == name {
+			return f.flag, true
+		}
+	}
+	return 0, false
+}
+
+// lookupCommandMeta는 명령어의 메타데이터를 반환합니다.
+// RegisterCommand로 등록한 명령어는 CommandSpec에서, 내장 명령어는 commandTable에서 가져옵니다.
+// Register로 등록해 메타데이터가 없는 명령어는 false를 반환합니다.
+//
+// 매개변수:
+//   - cmdUpper: 대문자로 정규화된 명령어 이름
+func (r *CommandRegistry) lookupCommandMeta(cmdUpper string) (commandMeta, bool) {
+	if ext, ok := r.handlers[cmdUpper].(*extensionHandler); ok {
+		return ext.meta, true
+	}
+	meta, ok := commandTable[cmdUpper]
+	return meta, ok
+}
+
+// commandHas는 명령어에 플래그가 지정되어 있는지 확인합니다. 메타데이터가 없는 명령어는 false입니다.
+func (r *CommandRegistry) commandHas(cmdUpper string, flag commandFlag) bool {
+	meta, ok := r.lookupCommandMeta(cmdUpper)
+	return ok && meta.has(flag)
+}
+
+// checkArity는 인자 개수가 명령어의 arity 규칙에 맞는지 확인합니다.
+// 메타데이터가 없는 명령어는 항상 true입니다 (핸들러가 직접 확인).
+//
+// 매개변수:
+//   - cmdUpper: 대문자로 정규화된 명령어 이름
+//   - argc: 인자 개수 (명령어 이름 제외)
+func (r *CommandRegistry) checkArity(cmdUpper string, argc int) bool {
+	meta, ok := r.lookupCommandMeta(cmdUpper)
+	return !ok || arityMatches(meta.arity, argc)
+}
+
+// allowedInScript는 명령어를 스크립트(redis.call)에서 호출할 수 있는지 확인합니다.
+func (r *CommandRegistry) allowedInScript(cmdUpper string) bool {
+	return !r.commandHas(cmdUpper, cmdNoScript)
+}
+
+// validateArity는 핸들러를 호출하기 전에 인자 개수를 확인합니다.
+// 맞지 않으면 wrong number of arguments 에러를 반환합니다.
+func (r *CommandRegistry) validateArity(cmdUpper string, args []string) error {
+	if !r.checkArity(cmdUpper, len(args)) {
+		return &WrongNumberOfArgumentsError{Command: strings.ToLower(cmdUpper)}
+	}
+	return nil
+}
+
+// arityMatches는 인자 개수(명령어 이름 제외)가 arity 규칙에 맞는지 확인합니다.
+func arityMatches(arity, argc int) bool {
+	if arity > 0 {
+		return argc+1 == arity
+	}
+	return argc+1 >= -arity
+}
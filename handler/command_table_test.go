@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestCommandTable은 내장 명령어의 메타데이터와 레지스트리의 인자 개수 확인을 테스트합니다.
+func TestCommandTable(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+
+	// 테스트 케이스 1: 등록된 내장 명령어와 commandTable이 일치
+	for _, name := range registry.GetRegisteredCommands() {
+		if _, ok := commandTable[name]; !ok {
+			t.Errorf("Command %s is registered but missing from commandTable", name)
+		}
+	}
+	for name := range commandTable {
+		if !registry.HasCommand(name) {
+			t.Errorf("Command %s is in commandTable but not registered", name)
+		}
+	}
+	for name := range builtinHandlers(registry) {
+		if _, ok := commandTable[name]; !ok {
+			t.Errorf("Command %s has a handler but is missing from commands.json", name)
+		}
+	}
+
+	// 테스트 케이스 2: commands.json의 키 위치(COMMAND INFO 형식)를 명령어 이름을 뺀 인자의 위치로 변환
+	keyTests := map[string]keyRange{
+		"GET":            {0, 0, 1},
+		"BLPOP":          {0, -2, 1},
+		"BITOP":          {1, -1, 1},
+		"GEOSEARCHSTORE": {0, 1, 1},
+		"EVAL":           {},
+	}
+	for name, expected := range keyTests {
+		if got := commandTable[name].keys; got != expected {
+			t.Errorf("Command %s: expected keys %+v, got %+v", name, expected, got)
+		}
+	}
+
+	// 테스트 케이스 3: write와 readonly는 함께 지정할 수 없음
+	for name, meta := range commandTable {
+		if meta.has(cmdWrite) && meta.has(cmdReadOnly) {
+			t.Errorf("Command %s has both write and readonly flags", name)
+		}
+	}
+
+	// 테스트 케이스 4: 인자 개수가 틀리면 핸들러를 호출하지 않고 에러
+	tests := []struct {
+		cmd  string
+		args []string
+	}{
+		{"GET", []string{}},
+		{"GET", []string{"a", "b"}},
+		{"SET", []string{"a"}},
+		{"LRANGE", []string{"a", "0"}},
+		{"MULTI", []string{"extra"}},
+	}
+	for _, tt := range tests {
+		_, err := registry.Execute(tt.cmd, tt.args)
+		if _, ok := err.(*WrongNumberOfArgumentsError); !ok {
+			t.Errorf("%s %v: expected wrong number of arguments error, got %v", tt.cmd, tt.args, err)
+		}
+	}
+
+	// 테스트 케이스 5: 클라이언트 연결에서도 같은 에러
+	client, _ := newTestClient(registry)
+	if _, err := registry.ExecuteForClient(client, "echo", []string{}); err == nil || err.Error() != "-ERR wrong number of arguments for 'echo' command" {
+		t.Errorf("Expected wrong number of arguments error, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// runParallelClients는 clients개의 연결이 각각 fn을 동시에 실행하게 하고 모두 끝날 때까지 기다립니다.
+// go test -race로 실행하면 저장소와 레지스트리의 데이터 경쟁을 찾아냅니다.
+func runParallelClients(t *testing.T, registry *CommandRegistry, clients int, fn func(id int, client *Client)) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		client, _ := newTestClient(registry)
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			defer registry.CloseClient(client)
+			fn(id, client)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestParallelClients는 여러 연결이 동시에 명령어를 실행해도 저장소가 일관되게 유지되는지 테스트합니다.
+func TestParallelClients(t *testing.T) {
+	const clients = 16
+	const ops = 200
+
+	// 테스트 케이스 1: 같은 리스트에 동시에 RPUSH/LPUSH → 모든 값이 빠짐없이 들어감
+	t.Run("ConcurrentPushes", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		runParallelClients(t, registry, clients, func(id int, client *Client) {
+			for i := 0; i < ops; i++ {
+				cmd := "RPUSH"
+				if i%2 == 1 {
+					cmd = "LPUSH"
+				}
+				if _, err := registry.ExecuteForClient(client, cmd, []string{"list", strconv.Itoa(id)}); err != nil {
+					t.Errorf("%s failed: %v", cmd, err)
+					return
+				}
+			}
+		})
+
+		if got := registry.store.LLEN("list"); got != clients*ops {
+			t.Errorf("Expected %d elements, got %d", clients*ops, got)
+		}
+	})
+
+	// 테스트 케이스 2: 서로 다른 키에 동시에 SET/GET/DEL → 각 연결이 자기 키의 값을 그대로 읽음
+	t.Run("ConcurrentStrings", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		runParallelClients(t, registry, clients, func(id int, client *Client) {
+			key := "key:" + strconv.Itoa(id)
+			for i := 0; i < ops; i++ {
+				value := strconv.Itoa(i)
+				args := []string{key, value}
+				if i%3 == 0 {
+					args = append(args, "PX", "60000")
+				}
+				if _, err := registry.ExecuteForClient(client, "SET", args); err != nil {
+					t.Errorf("SET failed: %v", err)
+					return
+				}
+				result, err := registry.ExecuteForClient(client, "GET", []string{key})
+				if err != nil || !isBulk(result, value) {
+					t.Errorf("GET %s: expected %q, got %v (err %v)", key, value, result, err)
+					return
+				}
+				if i%10 == 9 {
+					registry.ExecuteForClient(client, "DEL", []string{key})
+				}
+			}
+		})
+	})
+
+	// 테스트 케이스 3: 쓰기와 키 공간 전체를 읽는 명령어가 동시에 실행됨
+	t.Run("ConcurrentWritersAndSnapshots", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		runParallelClients(t, registry, clients, func(id int, client *Client) {
+			for i := 0; i < ops/4; i++ {
+				switch id % 4 {
+				case 0:
+					registry.ExecuteForClient(client, "ZADD", []string{"zset", strconv.Itoa(i), "m" + strconv.Itoa(id)})
+				case 1:
+					registry.ExecuteForClient(client, "LPOP", []string{"list"})
+					registry.ExecuteForClient(client, "RPUSH", []string{"list", "v"})
+				case 2:
+					registry.store.Snapshot()
+					registry.store.Keys()
+				case 3:
+					registry.store.ZRANGE("zset", 0, -1)
+					registry.ExecuteForClient(client, "LRANGE", []string{"list", "0", "-1"})
+				}
+			}
+		})
+
+		if got := registry.store.ZCARD("zset"); got != clients/4 {
+			t.Errorf("Expected %d zset members, got %d", clients/4, got)
+		}
+	})
+	// 테스트 케이스 4: 여러 샤드에 걸친 DEL을 서로 다른 키 순서로 동시에 실행 → 교착 상태 없이 끝남
+	t.Run("ConcurrentMultiKeyDeletes", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		keys := make([]string, 8)
+		for i := range keys {
+			keys[i] = "multi:" + strconv.Itoa(i)
+		}
+		reversed := make([]string, len(keys))
+		for i, key := range keys {
+			reversed[len(keys)-1-i] = key
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			runParallelClients(t, registry, clients, func(id int, client *Client) {
+				for i := 0; i < ops; i++ {
+					registry.ExecuteForClient(client, "SET", []string{keys[(id+i)%len(keys)], "v"})
+					if id%2 == 0 {
+						registry.ExecuteForClient(client, "DEL", keys)
+					} else {
+						registry.ExecuteForClient(client, "DEL", reversed)
+					}
+				}
+			})
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatal("Concurrent multi-key DEL deadlocked")
+		}
+	})
+}
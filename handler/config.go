@@ -0,0 +1,502 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/pubsub"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// configParam은 CONFIG GET, SET, REWRITE가 다루는 설정 항목 하나입니다.
+type configParam struct {
+	name string                          // 설정 이름 (redis.conf의 지시어)
+	def  string                          // 기본값 (설정 파일에 없고 값이 기본값이면 REWRITE가 추가하지 않음)
+	get  func(r *CommandRegistry) string // 현재 값
+
+	// set은 CONFIG SET으로 받은 값을 확인하고 적용합니다. 값이 잘못되었으면 아무것도 바꾸지 않고 에러를 반환합니다.
+	// nil이면 실행 중에 바꿀 수 없는 설정입니다.
+	set func(r *CommandRegistry, value string) error
+
+	// multi이면 값이 공백으로 구분된 여러 인자입니다 (save 3600 1 300 100).
+	// optional이면 값이 비어 있을 때 설정 파일에서 줄을 지웁니다 (마스터의 replicaof).
+	multi    bool
+	optional bool
+}
+
+// configParams는 서버가 실행 중에 알고 있는 설정들입니다 (CONFIG GET, REWRITE의 순서와 동일).
+var configParams = []configParam{
+	{name: "port", def: "6379", get: func(r *CommandRegistry) string {
+		r.replication.mu.Lock()
+		defer r.replication.mu.Unlock()
+		return strconv.Itoa(r.replication.listeningPort)
+	}},
+	{name: "dir", def: ".", get: func(r *CommandRegistry) string {
+		r.persistence.mu.Lock()
+		defer r.persistence.mu.Unlock()
+		return r.persistence.dir
+	}},
+	{name: "dbfilename", def: "dump.rdb", get: func(r *CommandRegistry) string {
+		r.persistence.mu.Lock()
+		defer r.persistence.mu.Unlock()
+		return r.persistence.dbfilename
+	}},
+	{name: "save", def: "", multi: true, get: func(r *CommandRegistry) string {
+		r.persistence.mu.Lock()
+		defer r.persistence.mu.Unlock()
+		fields := make([]string, 0, 2*len(r.persistence.saveRules))
+		for _, rule := range r.persistence.saveRules {
+			fields = append(fields, strconv.Itoa(rule.Seconds), strconv.Itoa(rule.Changes))
+		}
+		return strings.Join(fields, " ")
+	}},
+	{name: "appendonly", def: "no", get: func(r *CommandRegistry) string {
+		r.persistence.mu.Lock()
+		defer r.persistence.mu.Unlock()
+		return yesNo(r.persistence.aof != nil)
+	}},
+	{name: "replicaof", def: "", multi: true, optional: true, get: func(r *CommandRegistry) string {
+		r.replication.mu.Lock()
+		defer r.replication.mu.Unlock()
+		if r.replication.masterHost == "" {
+			return ""
+		}
+		return r.replication.masterHost + " " + strconv.Itoa(r.replication.masterPort)
+	}},
+	{name: "replica-read-only", def: "yes", get: func(r *CommandRegistry) string {
+		r.replication.mu.Lock()
+		defer r.replication.mu.Unlock()
+		return yesNo(r.replication.readOnly)
+	}},
+	{name: "repl-diskless-sync", def: "yes", get: func(r *CommandRegistry) string {
+		r.replication.mu.Lock()
+		defer r.replication.mu.Unlock()
+		return yesNo(r.replication.disklessSync)
+	}},
+	{name: "cluster-enabled", def: "no", get: func(r *CommandRegistry) string {
+		r.cluster.mu.Lock()
+		defer r.cluster.mu.Unlock()
+		return yesNo(r.cluster.enabled)
+	}},
+	{name: "requirepass", def: "", get: func(r *CommandRegistry) string {
+		return r.requirePassword()
+	}},
+	{name: "latency-monitor-threshold", def: "0", get: func(r *CommandRegistry) string {
+		return strconv.FormatInt(r.latency.threshold.Load(), 10)
+	}},
+	{name: "proto-max-bulk-len", def: "536870912", get: func(r *CommandRegistry) string {
+		return strconv.FormatInt(r.ProtoMaxBulkLen(), 10)
+	}},
+	{name: "maxclients", def: "10000", get: func(r *CommandRegistry) string {
+		return strconv.FormatInt(r.maxClients.Load(), 10)
+	}},
+	{name: "maxmemory", def: "0", get: func(r *CommandRegistry) string {
+		return strconv.FormatInt(r.MaxMemory(), 10)
+	}, set: func(r *CommandRegistry, value string) error {
+		n, err := ParseMemory(value)
+		if err != nil {
+			return err
+		}
+		r.SetMaxMemory(n)
+		return nil
+	}},
+	{name: "maxmemory-policy", def: "noeviction", get: func(r *CommandRegistry) string {
+		return r.MaxMemoryPolicy()
+	}, set: func(r *CommandRegistry, value string) error {
+		return r.SetMaxMemoryPolicy(value)
+	}},
+	{name: "maxmemory-samples", def: "5", get: func(r *CommandRegistry) string {
+		return strconv.FormatInt(r.maxMemorySamples.Load(), 10)
+	}, set: func(r *CommandRegistry, value string) error {
+		n, err := parseConfigInt(value, 1)
+		if err != nil {
+			return err
+		}
+		r.SetMaxMemorySamples(n)
+		return nil
+	}},
+	{name: "lfu-log-factor", def: "10", get: func(r *CommandRegistry) string {
+		return strconv.Itoa(r.store.LFULogFactor())
+	}, set: func(r *CommandRegistry, value string) error {
+		n, err := parseConfigInt(value, 0)
+		if err != nil {
+			return err
+		}
+		r.SetLFULogFactor(n)
+		return nil
+	}},
+	{name: "lfu-decay-time", def: "1", get: func(r *CommandRegistry) string {
+		return strconv.FormatInt(int64(r.store.LFUDecayTime()/time.Minute), 10)
+	}, set: func(r *CommandRegistry, value string) error {
+		n, err := parseConfigInt(value, 0)
+		if err != nil {
+			return err
+		}
+		r.SetLFUDecayTime(time.Duration(n) * time.Minute)
+		return nil
+	}},
+	{name: "loglevel", def: "notice", get: func(r *CommandRegistry) string {
+		return r.LogLevel()
+	}, set: func(r *CommandRegistry, value string) error {
+		return r.SetLogLevel(value)
+	}},
+	{name: "logfile", def: "", get: func(r *CommandRegistry) string {
+		r.logMu.Lock()
+		defer r.logMu.Unlock()
+		return r.logFile
+	}},
+	{name: "timeout", def: "0", get: func(r *CommandRegistry) string {
+		return strconv.FormatInt(int64(time.Duration(r.clientTimeout.Load())/time.Second), 10)
+	}},
+	{name: "tcp-keepalive", def: "300", get: func(r *CommandRegistry) string {
+		return strconv.FormatInt(int64(r.TCPKeepAlive()/time.Second), 10)
+	}},
+}
+
+// configRewriteMarker는 REWRITE가 설정 파일 끝에 새로 추가하는 설정들 앞에 붙이는 주석입니다.
+const configRewriteMarker = "# Generated by CONFIG REWRITE"
+
+// SetConfigFile은 서버가 불러온 설정 파일 경로를 설정합니다.
+// CONFIG REWRITE는 이 파일에 현재 설정을 기록합니다 (설정하지 않으면 REWRITE는 에러).
+func (r *CommandRegistry) SetConfigFile(path string) {
+	r.configMu.Lock()
+	defer r.configMu.Unlock()
+	r.configFile = path
+}
+
+// SetConfigReloader는 CONFIG RELOAD가 설정 파일을 다시 읽어 적용할 때 호출할 함수를 설정합니다.
+// 설정하지 않으면 CONFIG RELOAD는 에러입니다.
+func (r *CommandRegistry) SetConfigReloader(reload func() error) {
+	r.configMu.Lock()
+	defer r.configMu.Unlock()
+	r.configReload = reload
+}
+
+// SetProtoMaxBulkLen은 요청의 Bulk String 하나의 최대 길이를 설정합니다 (proto-max-bulk-len).
+// 이미 연결된 클라이언트에도 다음 요청부터 적용됩니다.
+func (r *CommandRegistry) SetProtoMaxBulkLen(n int64) {
+	r.protoMaxBulkLen.Store(n)
+}
+
+// DefaultMaxClients는 maxclients의 기본값입니다 (Redis와 동일).
+const DefaultMaxClients = 10000
+
+// SetMaxClients는 동시에 연결할 수 있는 네트워크 연결 수를 설정합니다 (maxclients).
+// 이미 연결된 클라이언트는 끊지 않고, 이후에 연결하는 클라이언트에 적용됩니다.
+func (r *CommandRegistry) SetMaxClients(n int) {
+	r.maxClients.Store(int64(n))
+}
+
+// MaxClients는 동시에 연결할 수 있는 네트워크 연결 수를 반환합니다.
+func (r *CommandRegistry) MaxClients() int {
+	return int(r.maxClients.Load())
+}
+
+// ParseMemory는 "100mb"나 "1gb"처럼 단위가 붙은 메모리 크기를 바이트로 바꿉니다 (redis.conf와 같은 형식).
+// 단위는 대소문자를 구분하지 않으며, k/m/g는 1000의 거듭제곱, kb/mb/gb는 1024의 거듭제곱입니다.
+// 단위가 없으면 바이트입니다.
+//
+// 에러 케이스:
+//   - 숫자가 아니거나 음수인 경우, 알 수 없는 단위인 경우
+func ParseMemory(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		mul    int64
+	}{
+		{"gb", 1 << 30}, {"mb", 1 << 20}, {"kb", 1 << 10},
+		{"g", 1000 * 1000 * 1000}, {"m", 1000 * 1000}, {"k", 1000}, {"b", 1},
+	}
+	lower := strings.ToLower(strings.TrimSpace(s))
+	mul := int64(1)
+	for _, unit := range units {
+		if strings.HasSuffix(lower, unit.suffix) {
+			lower, mul = strings.TrimSuffix(lower, unit.suffix), unit.mul
+			break
+		}
+	}
+	n, err := strconv.ParseInt(lower, 10, 64)
+	if err != nil || n < 0 || n > math.MaxInt64/mul {
+		return 0, fmt.Errorf("invalid memory size %q", s)
+	}
+	return n * mul, nil
+}
+
+// ProtoMaxBulkLen은 요청의 Bulk String 하나의 최대 길이를 반환합니다.
+func (r *CommandRegistry) ProtoMaxBulkLen() int64 {
+	return r.protoMaxBulkLen.Load()
+}
+
+// ConfigHandler는 CONFIG 명령어를 처리하는 핸들러입니다.
+//
+// Redis CONFIG 명령어 사양:
+//   - CONFIG GET parameter [parameter ...]: glob 패턴과 일치하는 설정의 이름과 값을 번갈아 나열
+//   - CONFIG SET parameter value [parameter value ...]: 실행 중에 설정을 바꿈
+//     (maxmemory, maxmemory-policy 등. 하나라도 실패하면 앞에서 바꾼 설정도 되돌림, 설정 파일에는 기록하지 않음)
+//   - CONFIG REWRITE: 현재 설정을 불러온 설정 파일에 기록
+//     (주석과 알 수 없는 지시어는 유지하고, 설정된 지시어는 첫 줄을 현재 값으로 바꾸고 나머지 중복 줄은 지움)
+//   - CONFIG RESETSTAT: INFO stats, commandstats, latencystats 섹션의 통계를 0으로 되돌림
+//   - CONFIG RELOAD: 설정 파일을 다시 읽어 실행 중에 바꿀 수 있는 설정을 적용 (SIGHUP과 동일, 이 서버의 확장)
+//   - CONFIG HELP: 서브커맨드들의 사용법
+//
+// 예시:
+//
+//	클라이언트: CONFIG GET dbfilename
+//	서버: *2\r\n$10\r\ndbfilename\r\n$8\r\ndump.rdb\r\n (RESP3에서는 %1\r\n...)
+type ConfigHandler struct {
+	registry *CommandRegistry
+}
+
+// Execute는 CONFIG 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.Map: GET의 결과 (설정 이름에서 값으로의 맵)
+//   - protocol.SimpleString: SET, REWRITE, RESETSTAT 성공 시 OK
+//   - error: 서브커맨드가 없거나 알 수 없는 경우, SET의 설정을 바꿀 수 없거나 값이 잘못된 경우,
+//     설정 파일이 없거나 기록에 실패한 경우
+func (h *ConfigHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.subcommands().dispatch(nil, args, store)
+}
+
+// subcommands는 CONFIG의 서브커맨드 목록입니다.
+func (h *ConfigHandler) subcommands() *subcommandTable {
+	return &subcommandTable{command: "CONFIG", subs: []subcommand{
+		{"GET", -3, []string{"GET <pattern>", "Return parameters matching the glob-like <pattern> and their values."}, h.get},
+		{"SET", -4, []string{"SET <directive> <value>", "Set the configuration <directive> to <value>."}, h.set},
+		{"REWRITE", 2, []string{"REWRITE", "Rewrite the configuration file."}, h.rewrite},
+		{"RESETSTAT", 2, []string{"RESETSTAT", "Reset statistics reported by the INFO command."}, h.resetStat},
+		{"RELOAD", 2, []string{"RELOAD", "Re-read the configuration file and apply the parameters that can be changed at runtime."}, h.reload},
+	}}
+}
+
+// get은 CONFIG GET을 실행합니다. 패턴 중 하나와 일치하는 설정들을 설정 순서대로 반환합니다.
+func (h *ConfigHandler) get(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	result := protocol.Map{}
+	for _, p := range configParams {
+		for _, pattern := range args[1:] {
+			if pubsub.Match(strings.ToLower(pattern), p.name) {
+				result = append(result, protocol.BulkString(p.name), protocol.BulkString(p.get(h.registry)))
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// set은 CONFIG SET을 실행합니다. 설정들을 순서대로 적용하고, 하나라도 실패하면 앞에서 바꾼 설정을 이전 값으로 되돌립니다.
+func (h *ConfigHandler) set(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args)%2 != 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "config|set"}
+	}
+
+	type change struct {
+		param configParam
+		old   string
+	}
+	var applied []change
+	fail := func(message string) error {
+		for i := len(applied) - 1; i >= 0; i-- {
+			applied[i].param.set(h.registry, applied[i].old)
+		}
+		return &InvalidArgumentError{Message: message}
+	}
+
+	for i := 1; i < len(args); i += 2 {
+		name := strings.ToLower(args[i])
+		p, ok := lookupConfigParam(name)
+		if !ok {
+			return nil, fail("Unknown option or number of arguments for CONFIG SET - '" + args[i] + "'")
+		}
+		if p.set == nil {
+			return nil, fail("CONFIG SET failed (possibly related to argument '" + name + "') - can't set immutable config")
+		}
+		old := p.get(h.registry)
+		if err := p.set(h.registry, args[i+1]); err != nil {
+			return nil, fail("CONFIG SET failed (possibly related to argument '" + name + "') - " + err.Error())
+		}
+		applied = append(applied, change{p, old})
+	}
+	return okReply, nil
+}
+
+// lookupConfigParam은 이름(소문자)으로 설정 항목을 찾습니다.
+func lookupConfigParam(name string) (configParam, bool) {
+	for _, p := range configParams {
+		if p.name == name {
+			return p, true
+		}
+	}
+	return configParam{}, false
+}
+
+// parseConfigInt는 CONFIG SET의 정수 값을 파싱합니다. lowest보다 작으면 에러입니다.
+func parseConfigInt(value string, lowest int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.New("argument couldn't be parsed into an integer")
+	}
+	if n < lowest {
+		return 0, fmt.Errorf("argument must be at least %d", lowest)
+	}
+	return n, nil
+}
+
+// rewrite는 CONFIG REWRITE를 실행합니다.
+func (h *ConfigHandler) rewrite(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if err := h.registry.rewriteConfig(); err != nil {
+		return nil, err
+	}
+	return okReply, nil
+}
+
+// reload는 CONFIG RELOAD를 실행합니다. 서버가 설정한 함수로 설정 파일을 다시 읽어 적용합니다.
+func (h *ConfigHandler) reload(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	h.registry.configMu.Lock()
+	reload := h.registry.configReload
+	h.registry.configMu.Unlock()
+	if reload == nil {
+		return nil, &InvalidArgumentError{Message: "The server does not support reloading its configuration"}
+	}
+	if err := reload(); err != nil {
+		return nil, &InvalidArgumentError{Message: "Reloading config: " + err.Error()}
+	}
+	return okReply, nil
+}
+
+// resetStat은 CONFIG RESETSTAT을 실행합니다.
+func (h *ConfigHandler) resetStat(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	h.registry.stats.reset()
+	h.registry.commandStats.reset()
+	return okReply, nil
+}
+
+// rewriteConfig는 현재 설정을 설정 파일에 기록합니다 (CONFIG REWRITE).
+// 임시 파일에 쓴 뒤 이름을 바꾸므로, 실패해도 기존 설정 파일은 그대로 남습니다.
+func (r *CommandRegistry) rewriteConfig() error {
+	r.configMu.Lock()
+	defer r.configMu.Unlock()
+	if r.configFile == "" {
+		return &InvalidArgumentError{Message: "The server is running without a config file"}
+	}
+
+	data, err := os.ReadFile(r.configFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return &InvalidArgumentError{Message: "Rewriting config file: " + err.Error()}
+	}
+	var lines []string
+	if content := strings.TrimSuffix(string(data), "\n"); content != "" {
+		lines = strings.Split(content, "\n")
+	}
+
+	values := make(map[string]string, len(configParams))
+	for _, p := range configParams {
+		values[p.name] = p.get(r)
+	}
+	content := strings.Join(rewriteConfigLines(lines, values), "\n") + "\n"
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.configFile), "temp-config-*.conf")
+	if err == nil {
+		_, err = tmp.WriteString(content)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(tmp.Name(), r.configFile)
+		}
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}
+	if err != nil {
+		return &InvalidArgumentError{Message: "Rewriting config file: " + err.Error()}
+	}
+	return nil
+}
+
+// rewriteConfigLines는 설정 파일의 줄들에 현재 설정 값(values)을 반영한 줄들을 반환합니다.
+//
+// 규칙 (Redis의 rewriteConfig와 동일):
+//   - 빈 줄, 주석, 알 수 없는 지시어는 그대로 유지
+//   - 설정 항목의 첫 줄은 현재 값으로 바꾸고, 같은 항목의 나머지 줄은 지움
+//   - 파일에 없는 항목은 기본값과 다를 때만 끝에 추가 (이전 REWRITE가 남긴 표시 주석 아래)
+func rewriteConfigLines(lines []string, values map[string]string) []string {
+	params := make(map[string]configParam, len(configParams))
+	for _, p := range configParams {
+		params[p.name] = p
+	}
+
+	result := make([]string, 0, len(lines))
+	written := make(map[string]bool)
+	hasMarker := false
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			hasMarker = hasMarker || strings.TrimSpace(line) == configRewriteMarker
+			result = append(result, line)
+			continue
+		}
+		name := strings.ToLower(fields[0])
+		// 예전 이름(slaveof, slave-read-only)도 같은 항목으로 취급
+		switch name {
+		case "slaveof":
+			name = "replicaof"
+		case "slave-read-only":
+			name = "replica-read-only"
+		}
+		p, known := params[name]
+		if !known {
+			result = append(result, line)
+			continue
+		}
+		if !written[name] {
+			written[name] = true
+			if newLine := configLine(p, values[name]); newLine != "" {
+				result = append(result, newLine)
+			}
+		}
+	}
+
+	for _, p := range configParams {
+		value := values[p.name]
+		if written[p.name] || value == p.def {
+			continue
+		}
+		if !hasMarker {
+			result = append(result, configRewriteMarker)
+			hasMarker = true
+		}
+		if newLine := configLine(p, value); newLine != "" {
+			result = append(result, newLine)
+		}
+	}
+	return result
+}
+
+// configLine은 설정 항목 하나를 redis.conf 형식의 줄로 만듭니다.
+// 비어 있는 값은 ""로, 공백이나 따옴표가 있는 값은 따옴표로 감쌉니다.
+// optional 항목의 값이 비어 있으면 빈 문자열(줄 없음)을 반환합니다.
+func configLine(p configParam, value string) string {
+	switch {
+	case value == "" && p.optional:
+		return ""
+	case value == "":
+		return p.name + ` ""`
+	case !p.multi && strings.ContainsAny(value, " \t\"'\\"):
+		return p.name + " " + strconv.Quote(value)
+	}
+	return p.name + " " + value
+}
+
+// yesNo는 불리언 설정 값을 redis.conf 형식("yes"/"no")으로 변환합니다.
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
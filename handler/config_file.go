@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+)
+
+// ConfigDirective는 설정 파일(redis.conf)의 지시어 한 줄입니다.
+type ConfigDirective struct {
+	Name string   // 지시어 이름 (소문자, slaveof 같은 예전 이름은 현재 이름으로 바꿈)
+	Args []string // 지시어 뒤의 값들 (따옴표를 푼 값)
+	Line int      // 파일에서의 줄 번호 (1부터)
+}
+
+// ParseConfigFile은 redis.conf 형식의 설정 파일 내용을 지시어 목록으로 파싱합니다.
+//
+// 형식 (Redis와 동일):
+//   - 한 줄에 지시어 하나: 이름 뒤에 공백으로 구분된 값들 (save 3600 1 300 100)
+//   - 값은 따옴표로 감쌀 수 있음 (dir "/var/lib/my redis", rename-command FLUSHALL "")
+//   - 빈 줄과 #으로 시작하는 줄은 무시
+//   - 같은 지시어가 여러 줄에 있으면 파일 순서대로 모두 반환 (save는 누적, 나머지는 마지막 값이 적용)
+//
+// 에러 케이스:
+//   - 따옴표가 맞지 않거나 값이 없는 지시어가 있는 경우 (줄 번호 포함)
+func ParseConfigFile(content string) ([]ConfigDirective, error) {
+	var directives []ConfigDirective
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields, err := protocol.SplitArgs(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: wrong number of arguments for '%s'", i+1, fields[0])
+		}
+		name := strings.ToLower(fields[0])
+		// 예전 이름(slaveof, slave-read-only)도 같은 설정으로 취급
+		switch name {
+		case "slaveof":
+			name = "replicaof"
+		case "slave-read-only":
+			name = "replica-read-only"
+		}
+		directives = append(directives, ConfigDirective{Name: name, Args: fields[1:], Line: i + 1})
+	}
+	return directives, nil
+}
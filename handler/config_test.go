@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestConfigGet은 CONFIG GET이 패턴과 일치하는 설정을 반환하는지 테스트합니다.
+func TestConfigGet(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetRDBFile("/data", "my.rdb")
+	registry.SetSaveRules([]SaveRule{{Seconds: 3600, Changes: 1}, {Seconds: 300, Changes: 100}})
+
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{"GET", "dbfilename"}, []string{"dbfilename", "my.rdb"}},
+		{[]string{"GET", "DIR", "save"}, []string{"dir", "/data", "save", "3600 1 300 100"}},
+		{[]string{"GET", "repl*"}, []string{"replicaof", "", "replica-read-only", "yes", "repl-diskless-sync", "yes"}},
+		{[]string{"GET", "nosuch"}, []string{}},
+	}
+	for _, tt := range tests {
+		expected := protocol.Map{}
+		for _, s := range tt.expected {
+			expected = append(expected, protocol.BulkString(s))
+		}
+		if result, err := registry.Execute("CONFIG", tt.args); err != nil || !reflect.DeepEqual(result, expected) {
+			t.Errorf("CONFIG %v: expected %q, got %v, %v", tt.args, tt.expected, result, err)
+		}
+	}
+
+	if _, err := registry.Execute("CONFIG", []string{"NOSUCH"}); err == nil || !strings.Contains(err.Error(), "unknown subcommand") {
+		t.Errorf("Expected unknown subcommand error, got %v", err)
+	}
+}
+
+// TestConfigRewrite는 CONFIG REWRITE가 설정 파일에 현재 설정을 기록하는지 테스트합니다.
+func TestConfigRewrite(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+
+	// 테스트 케이스 1: 설정 파일 없이 시작한 경우
+	if _, err := registry.Execute("CONFIG", []string{"REWRITE"}); err == nil || !strings.Contains(err.Error(), "without a config file") {
+		t.Errorf("Expected error without config file, got %v", err)
+	}
+
+	// 테스트 케이스 2: 주석과 알 수 없는 지시어는 유지, 설정된 지시어는 현재 값으로, 중복 줄은 삭제
+	path := filepath.Join(t.TempDir(), "redis.conf")
+	original := "# 서버 설정\nport 7000\n\ndbfilename old.rdb\nactiverehashing yes\ndbfilename older.rdb\nslaveof 10.0.0.1 6379\n"
+	os.WriteFile(path, []byte(original), 0644)
+	registry.SetConfigFile(path)
+	registry.SetListeningPort(7000)
+	registry.SetRDBFile("/var/lib/my redis", "new.rdb")
+	registry.SetReplDisklessSync(false)
+
+	if result, err := registry.Execute("CONFIG", []string{"REWRITE"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	data, _ := os.ReadFile(path)
+	expected := "# 서버 설정\nport 7000\n\ndbfilename new.rdb\nactiverehashing yes\n" +
+		"# Generated by CONFIG REWRITE\ndir \"/var/lib/my redis\"\nrepl-diskless-sync no\n"
+	if string(data) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, data)
+	}
+
+	// 테스트 케이스 3: 다시 기록해도 표시 주석과 설정이 중복되지 않음
+	registry.SetSaveRules([]SaveRule{{Seconds: 60, Changes: 10}})
+	registry.Execute("CONFIG", []string{"REWRITE"})
+	data, _ = os.ReadFile(path)
+	if expected += "save 60 10\n"; string(data) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, data)
+	}
+}
+
+// TestConfigResetStat은 CONFIG RESETSTAT이 INFO stats의 통계를 초기화하는지 테스트합니다.
+func TestConfigResetStat(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	registry.ExecuteForClient(client, "SET", []string{"b", "1", "PX", "1"})
+	waitFor(t, "key to expire", func() bool {
+		registry.ExecuteForClient(client, "GET", []string{"b"})
+		info, _ := registry.ExecuteForClient(client, "INFO", []string{"stats"})
+		return strings.Contains(string(info.(protocol.BulkString)), "expired_keys:1\r\n")
+	})
+
+	info, _ := registry.ExecuteForClient(client, "INFO", []string{"stats"})
+	if !strings.Contains(string(info.(protocol.BulkString)), "# Stats\r\ntotal_connections_received:1\r\n") {
+		t.Errorf("Expected one connection, got %q", info)
+	}
+
+	if result, err := registry.ExecuteForClient(client, "CONFIG", []string{"RESETSTAT"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	info, _ = registry.ExecuteForClient(client, "INFO", []string{"stats"})
+	expected := "# Stats\r\ntotal_connections_received:0\r\ntotal_commands_processed:1\r\ninstantaneous_ops_per_sec:0\r\n" +
+		"rejected_connections:0\r\nexpired_keys:0\r\nevicted_keys:0\r\nkeyspace_hits:0\r\nkeyspace_misses:0\r\nsync_full:0\r\n"
+	if info != protocol.BulkString(expected) {
+		t.Errorf("Expected %q, got %q", expected, info)
+	}
+}
+
+// TestParseMemory는 단위가 붙은 메모리 크기의 파싱을 테스트합니다.
+func TestParseMemory(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"0", 0, false},
+		{"1024", 1024, false},
+		{"100b", 100, false},
+		{"1k", 1000, false},
+		{"1kb", 1024, false},
+		{"100MB", 100 << 20, false},
+		{"2g", 2000000000, false},
+		{"1gb", 1 << 30, false},
+		{"", 0, true},
+		{"-1mb", 0, true},
+		{"10tb", 0, true},
+		{"99999999999999gb", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMemory(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMemory(%q) = %d, %v", tt.input, got, err)
+		}
+	}
+}
+
+// TestParseConfigFile은 redis.conf 형식의 설정 파일 파싱을 테스트합니다.
+func TestParseConfigFile(t *testing.T) {
+	content := "# 주석\n\nport 7000\n  SAVE 3600 1\nslaveof 10.0.0.1 6379\ndir \"/var/lib/my redis\"\nrename-command FLUSHALL \"\"\n"
+	directives, err := ParseConfigFile(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []ConfigDirective{
+		{Name: "port", Args: []string{"7000"}, Line: 3},
+		{Name: "save", Args: []string{"3600", "1"}, Line: 4},
+		{Name: "replicaof", Args: []string{"10.0.0.1", "6379"}, Line: 5},
+		{Name: "dir", Args: []string{"/var/lib/my redis"}, Line: 6},
+		{Name: "rename-command", Args: []string{"FLUSHALL", ""}, Line: 7},
+	}
+	if !reflect.DeepEqual(directives, expected) {
+		t.Errorf("Expected %v, got %v", expected, directives)
+	}
+
+	for _, invalid := range []string{"port\n", "dir \"unterminated\n"} {
+		if _, err := ParseConfigFile(invalid); err == nil || !strings.Contains(err.Error(), "line 1") {
+			t.Errorf("Expected an error with the line number for %q, got %v", invalid, err)
+		}
+	}
+}
+
+// TestConfigSet은 CONFIG SET이 설정을 바꾸고, 실패하면 앞에서 바꾼 설정도 되돌리는지 테스트합니다.
+func TestConfigSet(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+
+	// 테스트 케이스 1: 여러 설정을 한 번에 바꿈 (이름은 대소문자를 구분하지 않음)
+	if result, err := registry.Execute("CONFIG", []string{"SET", "MAXMEMORY-POLICY", "volatile-ttl", "maxmemory", "10mb"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	if policy := registry.MaxMemoryPolicy(); policy != MaxMemoryPolicyVolatileTTL {
+		t.Errorf("Expected volatile-ttl, got %q", policy)
+	}
+	if limit := registry.MaxMemory(); limit != 10<<20 {
+		t.Errorf("Expected maxmemory of 10mb, got %d", limit)
+	}
+
+	// 테스트 케이스 2: 하나라도 실패하면 에러를 반환하고 앞에서 바꾼 설정을 되돌림
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"SET", "maxmemory-policy", "allkeys-random", "maxmemory-policy", "lru"}, "possibly related to argument 'maxmemory-policy'"},
+		{[]string{"SET", "maxmemory-policy", "allkeys-random", "maxmemory-samples", "0"}, "argument must be at least 1"},
+		{[]string{"SET", "maxmemory-policy", "allkeys-random", "port", "7000"}, "can't set immutable config"},
+		{[]string{"SET", "maxmemory-policy", "allkeys-random", "nosuch", "1"}, "Unknown option or number of arguments for CONFIG SET - 'nosuch'"},
+		{[]string{"SET", "maxmemory-policy", "allkeys-random", "maxmemory"}, "wrong number of arguments for 'config|set' command"},
+	}
+	for _, tt := range tests {
+		if _, err := registry.Execute("CONFIG", tt.args); err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("CONFIG %v: expected error containing %q, got %v", tt.args, tt.expected, err)
+		}
+		if policy := registry.MaxMemoryPolicy(); policy != MaxMemoryPolicyVolatileTTL {
+			t.Errorf("CONFIG %v: expected the policy to stay volatile-ttl, got %q", tt.args, policy)
+		}
+	}
+
+	// 테스트 케이스 3: CONFIG GET이 바뀐 값을 보고
+	result, _ := registry.Execute("CONFIG", []string{"GET", "maxmemory*"})
+	expected := protocol.Map{
+		protocol.BulkString("maxmemory"), protocol.BulkString("10485760"),
+		protocol.BulkString("maxmemory-policy"), protocol.BulkString("volatile-ttl"),
+		protocol.BulkString("maxmemory-samples"), protocol.BulkString("5"),
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// 테스트 케이스 4: loglevel은 실행 중에 바꿀 수 있고, logfile은 바꿀 수 없음
+	if _, err := registry.Execute("CONFIG", []string{"SET", "loglevel", "VERBOSE"}); err != nil {
+		t.Fatal(err)
+	}
+	result, _ = registry.Execute("CONFIG", []string{"GET", "log*"})
+	expected = protocol.Map{protocol.BulkString("loglevel"), protocol.BulkString("verbose"), protocol.BulkString("logfile"), protocol.BulkString("")}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if _, err := registry.Execute("CONFIG", []string{"SET", "loglevel", "loud"}); err == nil {
+		t.Error("Expected an error for an unknown loglevel")
+	}
+	if _, err := registry.Execute("CONFIG", []string{"SET", "logfile", "redis.log"}); err == nil || !strings.Contains(err.Error(), "immutable") {
+		t.Errorf("Expected logfile to be immutable, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/pubsub"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// QuitHandler는 QUIT 명령어를 처리하는 핸들러입니다.
+//
+// Redis QUIT 명령어 사양:
+//   - QUIT → +OK 응답 후 서버가 연결을 종료
+//   - 구독 모드에서도 실행 가능
+//
+// 예시:
+//
+//	클라이언트: QUIT
+//	서버: +OK\r\n (이후 연결 종료)
+type QuitHandler struct{}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. 종료할 연결이 없으므로 OK만 반환합니다.
+func (h *QuitHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return okReply, nil
+}
+
+// ExecuteWithClient는 QUIT 명령어를 실행합니다.
+// 응답을 보낸 뒤 연결이 종료되도록 클라이언트에 표시합니다.
+func (h *QuitHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	client.closing = true
+	return okReply, nil
+}
+
+// ResetHandler는 RESET 명령어를 처리하는 핸들러입니다.
+//
+// Redis RESET 명령어 사양:
+//   - RESET → +RESET 응답
+//   - 연결 상태를 새로 연결한 것처럼 초기화 (구독 모드 해제 등)
+//   - 구독 모드에서도 실행 가능
+//
+// 예시:
+//
+//	클라이언트: RESET
+//	서버: +RESET\r\n
+type ResetHandler struct {
+	registry *CommandRegistry
+	broker   *pubsub.Broker
+	tracking *trackingTable
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. 초기화할 상태가 없으므로 RESET만 반환합니다.
+func (h *ResetHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 0 {
+		return nil, &WrongNumberOfArgumentsError{Command: "reset"}
+	}
+	return protocol.SimpleString("RESET"), nil
+}
+
+// ExecuteWithClient는 RESET 명령어를 실행합니다.
+//
+// 초기화 항목:
+//   - 모든 채널/패턴/샤드 채널 구독 해지 (구독 모드 해제)
+//   - 클라이언트 측 캐싱 키 추적 중단 (CLIENT TRACKING OFF)
+//   - 진행 중인 트랜잭션 취소 (DISCARD)
+//   - CLIENT NO-EVICT, CLIENT NO-TOUCH 끄기
+//   - RESP2로 되돌리기 (HELLO 2)
+//   - 인증 취소 (requirepass가 있으면 다시 AUTH 필요)
+func (h *ResetHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 0 {
+		return nil, &WrongNumberOfArgumentsError{Command: "reset"}
+	}
+
+	h.broker.UnsubscribeAll(client)
+	client.channels = make(map[string]struct{})
+	client.patterns = make(map[string]struct{})
+	client.shardChannels = make(map[string]struct{})
+	h.tracking.disable(client)
+	client.resetTransaction()
+	client.noEvict = false
+	client.noTouch = false
+	client.SetProtocol(2)
+	client.authenticated = h.registry.requirePassword() == ""
+	return protocol.SimpleString("RESET"), nil
+}
+
+// serverVersion은 HELLO가 보고하는 서버 버전입니다 (명령어와 응답 형식이 호환되는 Redis 버전).
+const serverVersion = "7.2.4"
+
+// HelloHandler는 HELLO 명령어를 처리하는 핸들러입니다.
+//
+// Redis HELLO 명령어 사양:
+//   - HELLO [protover [AUTH username password] [SETNAME clientname]]
+//   - protover(2 또는 3)로 연결의 응답 형식을 바꾸고 서버 정보를 맵으로 반환
+//     (RESP3에서는 Map, RESP2에서는 키와 값을 번갈아 나열한 Array)
+//   - protover를 생략하면 응답 형식을 바꾸지 않고 서버 정보만 반환
+//   - AUTH로 인증하고, SETNAME으로 연결 이름을 설정 (CLIENT SETNAME과 동일)
+//   - 지원하지 않는 버전이면 NOPROTO 에러, 이때 연결 상태는 바뀌지 않음
+//
+// 서버 정보: server, version, proto, id (클라이언트 ID), mode (standalone/cluster),
+// role (master/replica), modules (빈 배열)
+//
+// 예시:
+//
+//	클라이언트: HELLO 3
+//	서버: %7\r\n$6\r\nserver\r\n$5\r\nredis\r\n$7\r\nversion\r\n$5\r\n7.2.4\r\n$5\r\nproto\r\n:3\r\n...
+type HelloHandler struct {
+	registry *CommandRegistry
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. 바꿀 연결이 없으므로 RESP2 기준의 서버 정보만 반환합니다.
+func (h *HelloHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.ExecuteWithClient(nil, args, store)
+}
+
+// ExecuteWithClient는 HELLO 명령어를 실행합니다.
+// 모든 인자를 확인한 뒤에 인증, 이름 설정, 응답 형식 변경을 차례로 적용합니다.
+//
+// 반환값:
+//   - protocol.Map: 서버 정보
+//   - error: 지원하지 않는 버전, 잘못된 옵션, 인증 실패, 잘못된 이름
+func (h *HelloHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	proto := 2
+	if client != nil {
+		proto = client.Protocol()
+	}
+	if len(args) > 0 {
+		version, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, &InvalidArgumentError{Message: "Protocol version is not an integer or out of range"}
+		}
+		if version != 2 && version != 3 {
+			return nil, &NoProtoError{}
+		}
+		proto = version
+	}
+
+	var auth []string
+	name, setName := "", false
+	for i := 1; i < len(args); i++ {
+		switch option := strings.ToUpper(args[i]); {
+		case option == "AUTH" && i+2 < len(args):
+			auth = args[i+1 : i+3]
+			i += 2
+		case option == "SETNAME" && i+1 < len(args):
+			name, setName = args[i+1], true
+			i++
+		default:
+			return nil, &InvalidArgumentError{Message: "Syntax error in HELLO option '" + args[i] + "'"}
+		}
+	}
+
+	if auth != nil {
+		if err := h.registry.checkPassword(auth[0], auth[1]); err != nil {
+			return nil, err
+		}
+	}
+	if setName && !validClientName(name) {
+		return nil, &InvalidArgumentError{Message: "Client names cannot contain spaces, newlines or special characters."}
+	}
+
+	id := 0
+	if client != nil {
+		if auth != nil {
+			client.authenticated = true
+		}
+		if setName {
+			client.setName(name)
+		}
+		client.SetProtocol(proto)
+		id = int(client.ID)
+	}
+	return h.registry.serverProperties(proto, id), nil
+}
+
+// serverProperties는 HELLO가 반환하는 서버 정보 맵을 만듭니다.
+func (r *CommandRegistry) serverProperties(proto, clientID int) protocol.Map {
+	r.cluster.mu.Lock()
+	mode := "standalone"
+	if r.cluster.enabled {
+		mode = "cluster"
+	}
+	r.cluster.mu.Unlock()
+
+	r.replication.mu.Lock()
+	role := "master"
+	if r.replication.masterHost != "" {
+		role = "replica"
+	}
+	r.replication.mu.Unlock()
+
+	return protocol.Map{
+		protocol.BulkString("server"), protocol.BulkString("redis"),
+		protocol.BulkString("version"), protocol.BulkString(serverVersion),
+		protocol.BulkString("proto"), protocol.Integer(proto),
+		protocol.BulkString("id"), protocol.Integer(clientID),
+		protocol.BulkString("mode"), protocol.BulkString(mode),
+		protocol.BulkString("role"), protocol.BulkString(role),
+		protocol.BulkString("modules"), protocol.Array{},
+	}
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestQuitHandler는 QUIT 명령어를 테스트합니다.
+func TestQuitHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: QUIT 전에는 연결 유지
+	if client.ShouldClose() {
+		t.Fatal("New client should not be closing")
+	}
+
+	// 테스트 케이스 2: QUIT → OK 응답 후 연결 종료 표시
+	result, err := registry.ExecuteForClient(client, "QUIT", []string{})
+	if err != nil {
+		t.Fatalf("QUIT failed: %v", err)
+	}
+	if !isStatus(result, "OK") {
+		t.Errorf("Expected 'OK', got %v", result)
+	}
+	if !client.ShouldClose() {
+		t.Error("Expected client to be closing after QUIT")
+	}
+
+	// 테스트 케이스 3: 구독 모드에서도 QUIT 가능
+	subscriber, _ := newTestClient(registry)
+	registry.ExecuteForClient(subscriber, "SUBSCRIBE", []string{"news"})
+	if _, err := registry.ExecuteForClient(subscriber, "QUIT", []string{}); err != nil {
+		t.Errorf("QUIT in subscribe mode failed: %v", err)
+	}
+}
+
+// TestResetHandler는 RESET 명령어를 테스트합니다.
+func TestResetHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(client, "SUBSCRIBE", []string{"news"})
+	registry.ExecuteForClient(client, "PSUBSCRIBE", []string{"n*"})
+
+	// 테스트 케이스 1: RESET → 구독 모드 해제
+	result, err := registry.ExecuteForClient(client, "RESET", []string{})
+	if err != nil {
+		t.Fatalf("RESET failed: %v", err)
+	}
+	if !isStatus(result, "RESET") {
+		t.Errorf("Expected 'RESET', got %v", result)
+	}
+	if client.InSubscribeMode() {
+		t.Error("Expected client to leave subscribe mode after RESET")
+	}
+
+	// 테스트 케이스 2: 해지된 구독으로는 메시지가 전달되지 않음
+	result, _ = registry.Execute("PUBLISH", []string{"news", "hello"})
+	if result != protocol.Integer(0) {
+		t.Errorf("Expected 0 receivers after RESET, got %v", result)
+	}
+
+	// 테스트 케이스 3: 인자가 있는 경우 (에러 케이스)
+	if _, err := registry.ExecuteForClient(client, "RESET", []string{"extra"}); err == nil {
+		t.Error("Expected error for RESET with args")
+	}
+}
+
+// TestHelloHandler는 HELLO 명령어의 프로토콜 협상과 옵션을 테스트합니다.
+func TestHelloHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: 버전 없이 호출하면 현재 프로토콜(RESP2)의 서버 정보
+	result, err := registry.ExecuteForClient(client, "HELLO", []string{})
+	if err != nil {
+		t.Fatalf("HELLO failed: %v", err)
+	}
+	expected := protocol.Map{
+		protocol.BulkString("server"), protocol.BulkString("redis"),
+		protocol.BulkString("version"), protocol.BulkString(serverVersion),
+		protocol.BulkString("proto"), protocol.Integer(2),
+		protocol.BulkString("id"), protocol.Integer(client.ID),
+		protocol.BulkString("mode"), protocol.BulkString("standalone"),
+		protocol.BulkString("role"), protocol.BulkString("master"),
+		protocol.BulkString("modules"), protocol.Array{},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// 테스트 케이스 2: HELLO 3 SETNAME → RESP3로 전환하고 이름 설정
+	result, err = registry.ExecuteForClient(client, "HELLO", []string{"3", "AUTH", "default", "secret", "SETNAME", "worker"})
+	if err != nil {
+		t.Fatalf("HELLO 3 failed: %v", err)
+	}
+	if client.Protocol() != 3 || client.Name() != "worker" {
+		t.Errorf("Expected RESP3 and name worker, got %d and %q", client.Protocol(), client.Name())
+	}
+	if reply := result.(protocol.Map); reply[5] != protocol.Integer(3) {
+		t.Errorf("Expected proto 3 in reply, got %v", reply[5])
+	}
+
+	// 테스트 케이스 3: 잘못된 요청은 연결 상태를 바꾸지 않음
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"4"}, "NOPROTO"},
+		{[]string{"x"}, "not an integer"},
+		{[]string{"2", "AUTH", "admin", "secret"}, "WRONGPASS"},
+		{[]string{"2", "SETNAME", "bad name"}, "Client names cannot contain spaces"},
+		{[]string{"2", "NOSUCH"}, "Syntax error in HELLO option 'NOSUCH'"},
+	}
+	for _, tt := range tests {
+		if _, err := registry.ExecuteForClient(client, "HELLO", tt.args); err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("HELLO %v: expected %q error, got %v", tt.args, tt.expected, err)
+		}
+	}
+	if client.Protocol() != 3 || client.Name() != "worker" {
+		t.Errorf("Expected failed HELLO to keep RESP3 and name, got %d and %q", client.Protocol(), client.Name())
+	}
+
+	// 테스트 케이스 4: RESET은 RESP2로 되돌림
+	registry.ExecuteForClient(client, "RESET", []string{})
+	if client.Protocol() != 2 {
+		t.Errorf("Expected RESP2 after RESET, got %d", client.Protocol())
+	}
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/rdb"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// DebugHandler는 DEBUG 명령어를 처리하는 핸들러입니다.
+//
+// Redis DEBUG 명령어 사양 (지원하는 서브커맨드):
+//   - DEBUG SLEEP seconds: 서버를 seconds초(소수 가능) 동안 멈춤 (다른 연결의 명령어도 대기)
+//   - DEBUG OBJECT key: 키의 값에 대한 내부 정보 (인코딩, 직렬화한 길이)
+//   - DEBUG SET-ACTIVE-EXPIRE 0|1: 만료된 키를 주기적으로 지우는 작업을 멈추거나 재개
+//   - DEBUG CHANGE-REPL-ID: 복제 ID를 새로 만듦 (레플리카는 다음 동기화에서 전체 동기화)
+//   - DEBUG HELP: 서브커맨드들의 사용법
+//
+// 통합 테스트가 서버 상태를 조작하는 데 사용하며, 실행 잠금을 배타적으로 잡고 실행됩니다.
+//
+// 예시:
+//
+//	클라이언트: DEBUG OBJECT mykey
+//	서버: +Value at:0x0 refcount:1 encoding:embstr serializedlength:6 lru:9876543 lru_seconds_idle:3\r\n
+type DebugHandler struct {
+	registry *CommandRegistry
+}
+
+// Execute는 DEBUG 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.SimpleString: OBJECT의 결과, SLEEP, SET-ACTIVE-EXPIRE, CHANGE-REPL-ID 성공 시 OK
+//   - error: 서브커맨드가 없거나 알 수 없는 경우, 인자가 잘못되었거나 키가 없는 경우
+func (h *DebugHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.subcommands().dispatch(nil, args, store)
+}
+
+// subcommands는 DEBUG의 서브커맨드 목록입니다.
+func (h *DebugHandler) subcommands() *subcommandTable {
+	return &subcommandTable{command: "DEBUG", subs: []subcommand{
+		{"CHANGE-REPL-ID", 2, []string{"CHANGE-REPL-ID", "Change the replication IDs of the instance.",
+			"Dangerous: should be used only for testing the replication subsystem."}, h.changeReplID},
+		{"OBJECT", 3, []string{"OBJECT <key>", "Show low level info about the <key> and associated value."}, h.object},
+		{"SET-ACTIVE-EXPIRE", 3, []string{"SET-ACTIVE-EXPIRE <0|1>",
+			"Setting it to 0 disables expiring keys in background when they are not accessed (otherwise the",
+			"Redis behavior). Setting it to 1 reenables back the default."}, h.setActiveExpire},
+		{"SLEEP", 3, []string{"SLEEP <seconds>", "Stop the server for <seconds>. Decimals allowed."}, h.sleep},
+	}}
+}
+
+// sleep은 DEBUG SLEEP을 실행합니다.
+func (h *DebugHandler) sleep(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	seconds, err := strconv.ParseFloat(args[1], 64)
+	if err != nil || seconds < 0 {
+		return nil, &InvalidArgumentError{Message: "value is not a valid float"}
+	}
+	time.Sleep(time.Duration(seconds * float64(time.Second)))
+	return okReply, nil
+}
+
+// object는 DEBUG OBJECT를 실행합니다.
+func (h *DebugHandler) object(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	entry, exists := store.Lookup(args[1])
+	if !exists {
+		return nil, &InvalidArgumentError{Message: "no such key"}
+	}
+	payload, err := rdb.Dump(entry.Value)
+	if err != nil {
+		return nil, &InvalidArgumentError{Message: err.Error()}
+	}
+	// DUMP 페이로드에서 타입(1바이트), RDB 버전(2바이트), CRC64(8바이트)를 뺀 값 부분의 길이
+	serializedLength := len(payload) - 11
+	// lru는 Redis처럼 마지막 사용 시각을 초 단위 24비트 시계로 나타낸 값
+	access, _ := store.Access(args[1])
+	lru := access.LastAccess.Unix() & lruClockMax
+	return protocol.SimpleString("Value at:0x0 refcount:1 encoding:" + objectEncoding(entry.Value) +
+		" serializedlength:" + strconv.Itoa(serializedLength) + " lru:" + strconv.FormatInt(lru, 10) +
+		" lru_seconds_idle:" + strconv.FormatInt(int64(access.Idle/time.Second), 10)), nil
+}
+
+// setActiveExpire는 DEBUG SET-ACTIVE-EXPIRE를 실행합니다.
+func (h *DebugHandler) setActiveExpire(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	switch args[1] {
+	case "0":
+		h.registry.activeExpireOff.Store(true)
+	case "1":
+		h.registry.activeExpireOff.Store(false)
+	default:
+		return nil, &InvalidArgumentError{Message: "syntax error"}
+	}
+	return okReply, nil
+}
+
+// changeReplID는 DEBUG CHANGE-REPL-ID를 실행합니다.
+func (h *DebugHandler) changeReplID(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	h.registry.replication.mu.Lock()
+	h.registry.replication.replID = newReplID()
+	h.registry.replication.mu.Unlock()
+	return okReply, nil
+}
+
+// objectEncoding은 Redis가 값을 저장할 때 사용하는 인코딩 이름을 반환합니다 (store.ObjectEncoding).
+// 핸들러의 store 매개변수가 패키지 이름을 가리는 곳에서 사용합니다.
+func objectEncoding(value interface{}) string {
+	return store.ObjectEncoding(value)
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestDebugObject는 DEBUG OBJECT가 값의 인코딩을 보고하는지 테스트합니다.
+func TestDebugObject(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.Execute("SET", []string{"int", "12345"})
+	registry.Execute("SET", []string{"short", "hello"})
+	registry.Execute("SET", []string{"long", strings.Repeat("x", 100)})
+	registry.Execute("RPUSH", []string{"list", "a", "b"})
+	registry.Execute("ZADD", []string{"zset", "1", "a"})
+
+	tests := []struct {
+		key      string
+		encoding string
+	}{
+		{"int", "int"},
+		{"short", "embstr"},
+		{"long", "raw"},
+		{"list", "listpack"},
+		{"zset", "listpack"},
+	}
+	for _, tt := range tests {
+		result, err := registry.Execute("DEBUG", []string{"OBJECT", tt.key})
+		reply, ok := result.(protocol.SimpleString)
+		if err != nil || !ok || !strings.Contains(string(reply), " encoding:"+tt.encoding+" ") {
+			t.Errorf("DEBUG OBJECT %s: expected encoding %s, got %v, %v", tt.key, tt.encoding, result, err)
+		}
+	}
+
+	// 테스트 케이스 1: 없는 키
+	if _, err := registry.Execute("DEBUG", []string{"OBJECT", "nosuch"}); err == nil || !strings.Contains(err.Error(), "no such key") {
+		t.Errorf("Expected no such key error, got %v", err)
+	}
+
+	// 테스트 케이스 2: 알 수 없는 서브커맨드
+	if _, err := registry.Execute("DEBUG", []string{"NOSUCH"}); err == nil || !strings.Contains(err.Error(), "unknown subcommand") {
+		t.Errorf("Expected unknown subcommand error, got %v", err)
+	}
+}
+
+// TestDebugSleep은 DEBUG SLEEP이 다른 클라이언트의 명령어도 멈추게 하는지 테스트합니다.
+func TestDebugSleep(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	sleeper, _ := newTestClient(registry)
+	other, _ := newTestClient(registry)
+
+	done := make(chan struct{})
+	go func() {
+		registry.ExecuteForClient(sleeper, "DEBUG", []string{"SLEEP", "0.2"})
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	if result, err := registry.ExecuteForClient(other, "PING", nil); err != nil || !isStatus(result, "PONG") {
+		t.Fatalf("PING: expected PONG, got %v, %v", result, err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("Expected PING to wait for DEBUG SLEEP, returned after %v", elapsed)
+	}
+	<-done
+
+	if _, err := registry.Execute("DEBUG", []string{"SLEEP", "abc"}); err == nil {
+		t.Error("Expected error for invalid sleep time")
+	}
+}
+
+// TestDebugActiveExpire는 만료된 키가 읽히지 않아도 지워지고, SET-ACTIVE-EXPIRE 0으로 멈추는지 테스트합니다.
+func TestDebugActiveExpire(t *testing.T) {
+	s := store.NewStore()
+	registry := NewCommandRegistry(s)
+	propagated := recordPropagation(registry)
+
+	for _, key := range []string{"a", "b", "c"} {
+		registry.Execute("SET", []string{key, "v", "PX", "10"})
+	}
+	registry.Execute("SET", []string{"keep", "v"})
+	time.Sleep(20 * time.Millisecond)
+
+	// 테스트 케이스 1: 주기 작업이 만료된 키를 지우고 DEL을 전달
+	if expired := registry.activeExpireCycle(); expired != 3 {
+		t.Errorf("Expected 3 expired keys, got %d", expired)
+	}
+	if keys := s.Keys(); len(keys) != 1 || keys[0] != "keep" {
+		t.Errorf("Expected only keep to remain, got %v", keys)
+	}
+	dels := 0
+	for _, batch := range *propagated {
+		for _, cmd := range batch {
+			if strings.EqualFold(cmd[0], "DEL") {
+				dels++
+			}
+		}
+	}
+	if dels != 3 {
+		t.Errorf("Expected 3 propagated DELs, got %v", *propagated)
+	}
+
+	// 테스트 케이스 2: SET-ACTIVE-EXPIRE 0이면 고루틴이 키를 지우지 않음
+	if result, err := registry.Execute("DEBUG", []string{"SET-ACTIVE-EXPIRE", "0"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("DEBUG SET-ACTIVE-EXPIRE 0: expected OK, got %v, %v", result, err)
+	}
+	registry.StartActiveExpire()
+	registry.Execute("SET", []string{"d", "v", "PX", "10"})
+	time.Sleep(3 * activeExpireInterval)
+	if expired := atomic.LoadInt64(&registry.stats.expiredKeys); expired != 3 {
+		t.Errorf("Expected no keys expired while disabled, got %d expired in total", expired)
+	}
+
+	registry.Execute("DEBUG", []string{"SET-ACTIVE-EXPIRE", "1"})
+	waitFor(t, "active expire", func() bool { return atomic.LoadInt64(&registry.stats.expiredKeys) == 4 })
+}
+
+// TestDebugChangeReplID는 DEBUG CHANGE-REPL-ID가 복제 ID를 바꾸는지 테스트합니다.
+func TestDebugChangeReplID(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	before := registry.replication.replID
+	if result, err := registry.Execute("DEBUG", []string{"CHANGE-REPL-ID"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("DEBUG CHANGE-REPL-ID: expected OK, got %v, %v", result, err)
+	}
+	if after := registry.replication.replID; after == before || len(after) != 40 {
+		t.Errorf("Expected new 40-character replication ID, got %q (was %q)", after, before)
+	}
+}
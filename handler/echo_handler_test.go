@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/codecrafters-io/redis-starter-go/protocol"
 	"github.com/codecrafters-io/redis-starter-go/store"
 )
 
@@ -18,7 +19,7 @@ func TestEchoHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("ECHO failed: %v", err)
 	}
-	if result != message {
+	if result != protocol.BulkString(message) {
 		t.Errorf("Expected %q, got %v", message, result)
 	}
 
@@ -39,7 +40,7 @@ func TestEchoHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("ECHO with multiple args failed: %v", err)
 	}
-	if result != "first" {
+	if result != protocol.BulkString("first") {
 		t.Errorf("Expected 'first', got %v", result)
 	}
 }
\ No newline at end of file
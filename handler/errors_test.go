@@ -26,4 +26,18 @@ func TestErrorTypes(t *testing.T) {
 	if invalidArgErr.Error() != expectedMsg {
 		t.Errorf("Expected %q, got %q", expectedMsg, invalidArgErr.Error())
 	}
+
+	// 테스트 케이스 4: WrongTypeError (기본 메시지)
+	wrongTypeErr := &WrongTypeError{}
+	expectedMsg = "-WRONGTYPE Operation against a key holding the wrong kind of value"
+	if wrongTypeErr.Error() != expectedMsg {
+		t.Errorf("Expected %q, got %q", expectedMsg, wrongTypeErr.Error())
+	}
+
+	// 테스트 케이스 5: ExecAbortError
+	execAbortErr := &ExecAbortError{}
+	expectedMsg = "-EXECABORT Transaction discarded because of previous errors."
+	if execAbortErr.Error() != expectedMsg {
+		t.Errorf("Expected %q, got %q", expectedMsg, execAbortErr.Error())
+	}
 }
\ No newline at end of file
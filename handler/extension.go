@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// 확장 명령어의 플래그 (Redis 명령어 플래그와 같은 이름)
+const (
+	FlagWrite    = "write"    // 데이터를 변경하는 명령어
+	FlagReadOnly = "readonly" // 데이터를 읽기만 하는 명령어
+	FlagNoScript = "noscript" // 스크립트(redis.call)에서 호출할 수 없는 명령어
+	FlagFast     = "fast"     // 실행 시간이 일정하고 짧은 명령어
+	FlagDenyOOM  = "denyoom"  // 메모리를 늘릴 수 있어 maxmemory를 넘으면 거부하는 명령어
+)
+
+// extensionFlags는 CommandSpec.Flags에 지정할 수 있는 플래그와 그에 해당하는 명령어 플래그입니다.
+var extensionFlags = map[string]commandFlag{
+	FlagWrite:    cmdWrite,
+	FlagReadOnly: cmdReadOnly,
+	FlagNoScript: cmdNoScript,
+	FlagFast:     cmdFast,
+	FlagDenyOOM:  cmdDenyOOM,
+}
+
+// CommandFunc는 RegisterCommand로 등록하는 명령어의 구현입니다.
+//
+// 반환값은 CommandHandler.Execute와 같이 protocol의 응답 타입입니다
+// (protocol.BulkString, protocol.BulkBytes, protocol.Integer, protocol.Array, nil 등).
+// OK 같은 상태 응답은 protocol.SimpleString("OK")로 반환합니다.
+// 에러 응답은 InvalidArgumentError 같은 이 패키지의 에러 타입을 반환하면 됩니다.
+//
+// 사용 예:
+//
+//	func(ctx *handler.CommandContext, args []string) (protocol.Reply, error) {
+//	    value := ctx.Store.GET(args[0])
+//	    if value == nil {
+//	        return nil, &handler.InvalidArgumentError{Message: "no such key"}
+//	    }
+//	    return protocol.BulkBytes(bytes.ToUpper(value)), nil
+//	}
+type CommandFunc func(ctx *CommandContext, args []string) (protocol.Reply, error)
+
+// CommandContext는 확장 명령어가 실행될 때 전달되는 실행 환경입니다.
+type CommandContext struct {
+	// Store는 서버의 데이터 저장소입니다. 사용할 수 있는 API는 store 패키지 문서를 참고하세요.
+	Store *store.Store
+
+	// Client는 명령어를 보낸 연결입니다.
+	// 연결 없이 실행된 경우(CommandRegistry.Execute, 연결 없이 실행된 스크립트)에는 nil입니다.
+	Client *Client
+}
+
+// CommandSpec은 RegisterCommand로 등록할 명령어의 선언입니다.
+type CommandSpec struct {
+	// Name은 명령어 이름입니다 (대소문자 구분 없음, 공백 불가).
+	Name string
+
+	// Arity는 명령어 이름을 포함한 인자 개수 규칙입니다 (Redis의 arity와 동일).
+	//   - 양수 N: 정확히 N개
+	//   - 음수 -N: 최소 N개
+	//
+	// 인자 개수가 맞지 않으면 Func를 호출하지 않고 wrong number of arguments 에러를 반환하며,
+	// MULTI 중이면 대기열에 넣지 않고 트랜잭션을 실패로 표시합니다.
+	Arity int
+
+	// Flags는 명령어의 성격을 나타내는 플래그입니다 (FlagWrite, FlagReadOnly, FlagNoScript, FlagFast, FlagDenyOOM).
+	Flags []string
+
+	// Func는 명령어의 구현입니다.
+	Func CommandFunc
+}
+
+// extensionHandler는 RegisterCommand로 등록한 명령어를 CommandHandler로 감싸는 어댑터입니다.
+type extensionHandler struct {
+	spec CommandSpec
+	meta commandMeta // spec의 Arity와 Flags로 만든 메타데이터
+}
+
+// Execute는 연결 정보 없이 확장 명령어를 실행합니다.
+func (h *extensionHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.ExecuteWithClient(nil, args, store)
+}
+
+// ExecuteWithClient는 확장 명령어를 실행합니다.
+// 인자 개수는 레지스트리가 핸들러를 호출하기 전에 확인합니다.
+func (h *extensionHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return h.spec.Func(&CommandContext{Store: store, Client: client}, args)
+}
+
+// RegisterCommand는 Go로 구현한 명령어를 등록합니다.
+// 서버를 다른 Go 프로그램에 내장해 사용할 때 명령어를 추가하는 공개 API입니다.
+//
+// Register와 달리 arity와 플래그를 선언하므로, 내장 명령어와 동일하게
+// 인자 개수 검사(MULTI 대기열 포함)와 스크립트 호출 제한(FlagNoScript)이 적용됩니다.
+//
+// 동시성: 레지스트리는 등록된 명령어 목록을 잠금 없이 읽으므로,
+// RegisterCommand는 연결을 받기 전(NewCommandRegistry 직후)에 호출해야 합니다.
+//
+// 에러 케이스:
+//   - 이름이 비어 있거나 공백을 포함하는 경우
+//   - 같은 이름의 명령어가 이미 등록된 경우 (내장 명령어 포함)
+//   - Arity가 0이거나 Func가 nil인 경우
+//   - 알 수 없는 플래그, 또는 FlagWrite와 FlagReadOnly를 함께 지정한 경우
+//
+// 사용 예:
+//
+//	registry := handler.NewCommandRegistry(store.NewStore())
+//	err := registry.RegisterCommand(handler.CommandSpec{
+//	    Name:  "HELLOWORLD",
+//	    Arity: 1,
+//	    Flags: []string{handler.FlagReadOnly, handler.FlagFast},
+//	    Func: func(ctx *handler.CommandContext, args []string) (protocol.Reply, error) {
+//	        return "hello world", nil
+//	    },
+//	})
+func (r *CommandRegistry) RegisterCommand(spec CommandSpec) error {
+	if spec.Name == "" || strings.ContainsAny(spec.Name, " \t\r\n") {
+		return fmt.Errorf("invalid command name %q", spec.Name)
+	}
+	name := strings.ToUpper(spec.Name)
+	if _, exists := r.handlers[name]; exists {
+		return fmt.Errorf("command %s is already registered", name)
+	}
+	if spec.Arity == 0 {
+		return fmt.Errorf("command %s: arity must not be zero", name)
+	}
+	if spec.Func == nil {
+		return fmt.Errorf("command %s: Func must not be nil", name)
+	}
+	meta := commandMeta{arity: spec.Arity}
+	for _, flag := range spec.Flags {
+		f, ok := extensionFlags[flag]
+		if !ok {
+			return fmt.Errorf("command %s: unknown flag %q", name, flag)
+		}
+		meta.flags |= f
+	}
+	if meta.has(cmdWrite) && meta.has(cmdReadOnly) {
+		return fmt.Errorf("command %s: %s and %s are mutually exclusive", name, FlagWrite, FlagReadOnly)
+	}
+
+	spec.Name = name
+	spec.Flags = append([]string(nil), spec.Flags...)
+	r.handlers[name] = &extensionHandler{spec: spec, meta: meta}
+	return nil
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestRegisterCommand는 Go로 구현한 확장 명령어의 등록과 실행을 테스트합니다.
+func TestRegisterCommand(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	err := registry.RegisterCommand(CommandSpec{
+		Name:  "upperget",
+		Arity: 2,
+		Flags: []string{FlagReadOnly, FlagFast},
+		Func: func(ctx *CommandContext, args []string) (protocol.Reply, error) {
+			value := ctx.Store.GET(args[0])
+			if value == nil {
+				return nil, nil
+			}
+			return protocol.BulkBytes(bytes.ToUpper(value)), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterCommand failed: %v", err)
+	}
+	registry.RegisterCommand(CommandSpec{
+		Name:  "WHOAMI",
+		Arity: 1,
+		Flags: []string{FlagNoScript},
+		Func: func(ctx *CommandContext, args []string) (protocol.Reply, error) {
+			if ctx.Client == nil {
+				return protocol.Integer(-1), nil
+			}
+			return protocol.Integer(ctx.Client.ID), nil
+		},
+	})
+
+	// 테스트 케이스 1: 내장 명령어와 같은 방식으로 실행 (대소문자 구분 없음)
+	registry.ExecuteForClient(client, "SET", []string{"key", "value"})
+	result, err := registry.ExecuteForClient(client, "UPPERGET", []string{"key"})
+	if err != nil || !isBulk(result, "VALUE") {
+		t.Errorf("Expected 'VALUE', got %v, %v", result, err)
+	}
+	result, _ = registry.ExecuteForClient(client, "whoami", []string{})
+	if result != protocol.Integer(client.ID) {
+		t.Errorf("Expected client ID %d, got %v", client.ID, result)
+	}
+	result, _ = registry.Execute("WHOAMI", []string{})
+	if result != protocol.Integer(-1) {
+		t.Errorf("Expected -1 without client, got %v", result)
+	}
+
+	// 테스트 케이스 2: 선언한 arity로 인자 개수 검사
+	_, err = registry.ExecuteForClient(client, "UPPERGET", []string{})
+	if err == nil || err.Error() != "-ERR wrong number of arguments for 'upperget' command" {
+		t.Errorf("Expected wrong number of arguments error, got %v", err)
+	}
+
+	// 테스트 케이스 3: MULTI 중 인자 개수가 틀리면 트랜잭션 실패
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	if _, err := registry.ExecuteForClient(client, "UPPERGET", []string{"a", "b"}); err == nil {
+		t.Error("Expected error for queueing with wrong arity")
+	}
+	if _, err := registry.ExecuteForClient(client, "EXEC", []string{}); err == nil {
+		t.Error("Expected EXECABORT")
+	}
+
+	// 테스트 케이스 4: 스크립트에서 호출 (noscript 플래그는 거부)
+	result, err = registry.ExecuteForClient(client, "EVAL", []string{"return redis.call('upperget', 'key')", "0"})
+	if err != nil || !isBulk(result, "VALUE") {
+		t.Errorf("Expected 'VALUE' from script, got %v, %v", result, err)
+	}
+	if _, err := registry.ExecuteForClient(client, "EVAL", []string{"return redis.call('whoami')", "0"}); err == nil {
+		t.Error("Expected error for noscript command called from script")
+	}
+}
+
+// TestRegisterCommandErrors는 잘못된 명령어 선언을 거부하는지 테스트합니다.
+func TestRegisterCommandErrors(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	noop := func(ctx *CommandContext, args []string) (protocol.Reply, error) {
+		return protocol.SimpleString("OK"), nil
+	}
+
+	tests := []CommandSpec{
+		{Name: "", Arity: 1, Func: noop},
+		{Name: "MY CMD", Arity: 1, Func: noop},
+		{Name: "get", Arity: 2, Func: noop},
+		{Name: "ZEROARITY", Arity: 0, Func: noop},
+		{Name: "NOFUNC", Arity: 1},
+		{Name: "BADFLAG", Arity: 1, Flags: []string{"bogus"}, Func: noop},
+		{Name: "CONFLICT", Arity: 1, Flags: []string{FlagWrite, FlagReadOnly}, Func: noop},
+	}
+
+	for _, spec := range tests {
+		if err := registry.RegisterCommand(spec); err == nil {
+			t.Errorf("Expected error for %+v", spec.Name)
+		}
+	}
+	if registry.HasCommand("CONFLICT") {
+		t.Error("Rejected command should not be registered")
+	}
+}
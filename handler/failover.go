@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// failoverCheckInterval은 FAILOVER 대상 레플리카가 따라잡았는지 확인하는 주기입니다.
+const failoverCheckInterval = 10 * time.Millisecond
+
+// FAILOVER 진행 상태 (INFO replication의 master_failover_state)
+const (
+	failoverNone       = "no-failover"          // 진행 중인 FAILOVER 없음
+	failoverWaiting    = "waiting-for-sync"     // 쓰기를 멈추고 대상 레플리카가 따라잡기를 기다리는 중
+	failoverInProgress = "failover-in-progress" // 강등되어 대상 레플리카에 승격을 요청하는 중
+)
+
+// failoverPausedCommands는 쓰기 명령어 외에 FAILOVER 동안 대기하는 명령어들입니다 (쓰기를 포함할 수 있음).
+var failoverPausedCommands = map[string]bool{
+	"EXEC":    true,
+	"EVAL":    true,
+	"EVALSHA": true,
+	"FCALL":   true,
+}
+
+// failover는 진행 중인 FAILOVER 하나의 상태입니다.
+//
+// 진행 순서:
+//  1. waiting-for-sync: 쓰기 명령어를 멈추고, 대상 레플리카의 ACK 오프셋이 복제 오프셋과 같아질 때까지 대기
+//  2. failover-in-progress: 대상 레플리카의 레플리카가 되어 PSYNC FAILOVER로 승격을 요청
+//  3. 대상이 +FULLRESYNC로 응답하면 끝나고 쓰기를 재개 (이후 쓰기는 READONLY로 거부됨)
+//
+// 시간 제한을 넘기거나 ABORT, 또는 대상이 승격을 거부하면 마스터로 남거나 되돌아갑니다.
+type failover struct {
+	host    string        // 승격할 레플리카의 주소
+	port    int           // 승격할 레플리카의 포트 (listening-port)
+	timeout time.Duration // 따라잡기를 기다리는 최대 시간 (0이면 무제한)
+	force   bool          // 시간 제한을 넘겨도 승격을 진행
+
+	state     string        // failoverWaiting 또는 failoverInProgress (replication.mu로 보호)
+	link      *masterLink   // failover-in-progress에서 대상 레플리카와의 연결 (replication.mu로 보호)
+	abort     chan struct{} // ABORT로 기다리기를 중단하면 닫힘
+	abortOnce sync.Once
+	resumed   chan struct{} // FAILOVER가 끝나 쓰기를 재개하면 닫힘
+}
+
+// FailoverHandler는 FAILOVER 명령어를 처리하는 핸들러입니다.
+//
+// Redis FAILOVER 명령어 사양:
+//   - FAILOVER [TO <host> <port> [FORCE]] [ABORT] [TIMEOUT <milliseconds>]
+//   - 마스터가 레플리카 하나에 역할을 넘기고 자신은 그 레플리카의 레플리카가 됨
+//   - TO: 승격할 레플리카 (생략하면 가장 많이 따라잡은 온라인 레플리카)
+//   - TIMEOUT: 레플리카가 따라잡기를 기다리는 최대 시간, 넘기면 FAILOVER를 취소
+//   - FORCE: 시간 제한을 넘겨도 승격을 진행 (TO와 TIMEOUT이 필요)
+//   - ABORT: 진행 중인 FAILOVER를 취소
+//   - 백그라운드에서 진행되므로 바로 OK를 반환, 진행 상태는 INFO의 master_failover_state로 확인
+//
+// 예시:
+//
+//	클라이언트: FAILOVER TO 127.0.0.1 6380 TIMEOUT 5000
+//	서버: +OK\r\n
+type FailoverHandler struct {
+	registry *CommandRegistry
+}
+
+// Execute는 FAILOVER 명령어를 실행합니다.
+func (h *FailoverHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	var host string
+	var port int
+	var timeout time.Duration
+	var force, abort bool
+	for i := 0; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "TO":
+			if i+2 >= len(args) {
+				return nil, &InvalidArgumentError{Message: "syntax error"}
+			}
+			p, err := strconv.Atoi(args[i+2])
+			if err != nil {
+				return nil, &InvalidArgumentError{Message: "value is not an integer or out of range"}
+			}
+			host, port = args[i+1], p
+			i += 2
+		case "TIMEOUT":
+			if i+1 >= len(args) {
+				return nil, &InvalidArgumentError{Message: "syntax error"}
+			}
+			ms, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil {
+				return nil, &InvalidArgumentError{Message: "value is not an integer or out of range"}
+			}
+			if ms <= 0 {
+				return nil, &InvalidArgumentError{Message: "FAILOVER timeout must be greater than 0"}
+			}
+			timeout = time.Duration(ms) * time.Millisecond
+			i++
+		case "FORCE":
+			force = true
+		case "ABORT":
+			abort = true
+		default:
+			return nil, &InvalidArgumentError{Message: "syntax error"}
+		}
+	}
+
+	if abort {
+		if force || timeout > 0 || host != "" {
+			return nil, &InvalidArgumentError{Message: "syntax error"}
+		}
+		if !h.registry.abortFailover(nil) {
+			return nil, &InvalidArgumentError{Message: "No failover in progress."}
+		}
+		return okReply, nil
+	}
+	if force && (timeout == 0 || host == "") {
+		return nil, &InvalidArgumentError{Message: "FAILOVER with force option requires both a timeout and target HOST and IP."}
+	}
+
+	f, err := h.registry.replication.startFailover(host, port, timeout, force)
+	if err != nil {
+		return nil, err
+	}
+	go h.registry.runFailover(f)
+	return okReply, nil
+}
+
+// startFailover는 FAILOVER를 시작할 수 있는지 확인하고 쓰기를 멈춥니다.
+// host가 빈 문자열이면 가장 많이 따라잡은 온라인 레플리카를 대상으로 고릅니다.
+func (p *replication) startFailover(host string, port int, timeout time.Duration, force bool) (*failover, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case p.masterHost != "":
+		return nil, &InvalidArgumentError{Message: "FAILOVER is not valid when server is a replica."}
+	case len(p.replicas) == 0:
+		return nil, &InvalidArgumentError{Message: "FAILOVER requires connected replicas."}
+	case p.failover != nil:
+		return nil, &InvalidArgumentError{Message: "FAILOVER already in progress."}
+	}
+
+	var target *replicaConn
+	var best int64 = -1
+	for _, rep := range p.replicas {
+		rep.mu.Lock()
+		online, ackOffset := rep.online, rep.ackOffset
+		rep.mu.Unlock()
+
+		if host != "" {
+			if rep.ip != host || rep.listeningPort != port {
+				continue
+			}
+			if !online {
+				return nil, &InvalidArgumentError{Message: "FAILOVER target replica is not online."}
+			}
+			target = rep
+			break
+		}
+		if online && ackOffset > best {
+			target, best = rep, ackOffset
+		}
+	}
+	if target == nil {
+		if host != "" {
+			return nil, &InvalidArgumentError{Message: "FAILOVER target HOST and PORT is not a replica."}
+		}
+		return nil, &InvalidArgumentError{Message: "FAILOVER requires connected replicas."}
+	}
+
+	f := &failover{
+		host:    target.ip,
+		port:    target.listeningPort,
+		timeout: timeout,
+		force:   force,
+		state:   failoverWaiting,
+		abort:   make(chan struct{}),
+		resumed: make(chan struct{}),
+	}
+	p.failover = f
+	return f, nil
+}
+
+// runFailover는 대상 레플리카가 따라잡기를 기다린 뒤 강등되어 승격을 요청합니다.
+// 시간 제한을 넘기거나(FORCE 제외) ABORT되면 마스터로 남고 쓰기를 재개합니다.
+func (r *CommandRegistry) runFailover(f *failover) {
+	ticker := time.NewTicker(failoverCheckInterval)
+	defer ticker.Stop()
+	var timeout <-chan time.Time
+	if f.timeout > 0 {
+		timer := time.NewTimer(f.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for waiting := true; waiting && !r.replication.caughtUp(f); {
+		select {
+		case <-f.abort:
+			r.replication.endFailover()
+			return
+		case <-timeout:
+			if !f.force {
+				r.Logger().Warn("Failover timed out waiting for the replica to catch up", "host", f.host, "port", f.port)
+				r.replication.endFailover()
+				return
+			}
+			waiting = false
+		case <-ticker.C:
+			// 멈추기 전에 실행된 쓰기까지 처리했는지 묻기
+			r.replication.sendGetAck()
+		}
+	}
+
+	r.replication.mu.Lock()
+	f.state = failoverInProgress
+	r.replication.mu.Unlock()
+	r.replicaOf(f.host, f.port, true)
+}
+
+// caughtUp은 FAILOVER 대상 레플리카가 복제 스트림을 모두 처리했는지 확인합니다.
+func (p *replication) caughtUp(f *failover) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, rep := range p.replicas {
+		if rep.ip == f.host && rep.listeningPort == f.port {
+			rep.mu.Lock()
+			defer rep.mu.Unlock()
+			return rep.ackOffset == p.offset
+		}
+	}
+	return false
+}
+
+// endFailover는 진행 중인 FAILOVER를 끝내고 멈췄던 쓰기를 재개합니다.
+func (p *replication) endFailover() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failover == nil {
+		return
+	}
+	close(p.failover.resumed)
+	p.failover = nil
+}
+
+// abortFailover는 진행 중인 FAILOVER를 취소합니다.
+// 대상을 기다리는 중이면 기다리기를 멈추고, 이미 강등되었으면 마스터로 되돌아갑니다.
+// link가 nil이 아니면 그 연결로 진행 중인 FAILOVER만 취소합니다 (승격 요청이 실패한 경우).
+//
+// 반환값:
+//   - bool: 취소할 FAILOVER가 있었으면 true
+func (r *CommandRegistry) abortFailover(link *masterLink) bool {
+	p := r.replication
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f := p.failover
+	if f == nil || link != nil && f.link != link {
+		return false
+	}
+	if f.state == failoverWaiting {
+		f.abortOnce.Do(func() { close(f.abort) })
+		return true
+	}
+
+	r.becomeMasterLocked()
+	close(f.resumed)
+	p.failover = nil
+	return true
+}
+
+// writesPaused는 FAILOVER로 쓰기가 멈춰 있으면 재개될 때 닫히는 채널을, 아니면 nil을 반환합니다.
+func (p *replication) writesPaused() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failover == nil {
+		return nil
+	}
+	return p.failover.resumed
+}
+
+// pausesWrites는 FAILOVER 동안 대기해야 하는 명령어인지 확인합니다.
+func (r *CommandRegistry) pausesWrites(cmdUpper string) bool {
+	return r.isWriteCommand(cmdUpper) || failoverPausedCommands[cmdUpper]
+}
+
+// failoverStateLocked는 INFO replication의 master_failover_state 값을 반환합니다. p.mu를 잡고 호출합니다.
+func (p *replication) failoverStateLocked() string {
+	if p.failover == nil {
+		return failoverNone
+	}
+	return p.failover.state
+}
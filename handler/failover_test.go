@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// serveRegistry는 registry로 명령어를 실행하는 TCP 서버를 시작하고 포트를 반환합니다.
+// 복제 핸드셰이크에 필요한 상태 응답과 에러만 작성합니다.
+func serveRegistry(t *testing.T, registry *CommandRegistry) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				client := registry.NewClient(protocol.NewWriter(conn))
+				client.SetConn(conn)
+				defer registry.CloseClient(client)
+
+				parser := protocol.NewParser(bufio.NewReader(conn))
+				for {
+					value, err := parser.Parse()
+					if err != nil {
+						return
+					}
+					args, _ := commandArgs(value)
+					result, err := registry.ExecuteForClient(client, args[0], args[1:])
+					if client.IsReplica() {
+						continue
+					}
+					client.WithWriter(func(w *protocol.Writer) {
+						if err != nil {
+							w.WriteReply(protocol.NewError(err))
+						} else {
+							w.WriteReply(result)
+						}
+					})
+				}
+			}()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+// TestFailover는 FAILOVER로 레플리카가 승격하고 이전 마스터가 그 레플리카가 되는지 테스트합니다.
+func TestFailover(t *testing.T) {
+	master := NewCommandRegistry(store.NewStore())
+	master.SetRDBFile(t.TempDir(), "dump.rdb")
+	masterPort := serveRegistry(t, master)
+	masterClient, _ := newTestClient(master)
+
+	replica := NewCommandRegistry(store.NewStore())
+	replica.SetRDBFile(t.TempDir(), "dump.rdb")
+	replicaPort := serveRegistry(t, replica)
+	replica.SetListeningPort(replicaPort)
+	replicaClient, _ := newTestClient(replica)
+
+	info := func(registry *CommandRegistry, client *Client) string {
+		result, _ := registry.ExecuteForClient(client, "INFO", []string{"replication"})
+		s, _ := result.(protocol.BulkString)
+		return string(s)
+	}
+
+	// 테스트 케이스 1: 레플리카가 없으면 거부
+	if _, err := master.ExecuteForClient(masterClient, "FAILOVER", []string{}); err == nil || !strings.Contains(err.Error(), "requires connected replicas") {
+		t.Errorf("Expected error without replicas, got %v", err)
+	}
+	if _, err := master.ExecuteForClient(masterClient, "FAILOVER", []string{"ABORT"}); err == nil {
+		t.Error("Expected error when aborting without a failover")
+	}
+
+	replica.ReplicaOf("127.0.0.1", masterPort)
+	waitFor(t, "replica online", func() bool {
+		return strings.Contains(info(master, masterClient), "state=online")
+	})
+	master.ExecuteForClient(masterClient, "SET", []string{"a", "1"})
+
+	// 테스트 케이스 2: 잘못된 대상, 레플리카에서의 FAILOVER, FORCE 조건
+	if _, err := master.ExecuteForClient(masterClient, "FAILOVER", []string{"TO", "127.0.0.1", "1"}); err == nil || !strings.Contains(err.Error(), "is not a replica") {
+		t.Errorf("Expected error for unknown target, got %v", err)
+	}
+	if _, err := replica.ExecuteForClient(replicaClient, "FAILOVER", []string{}); err == nil || !strings.Contains(err.Error(), "not valid when server is a replica") {
+		t.Errorf("Expected error on a replica, got %v", err)
+	}
+	if _, err := master.ExecuteForClient(masterClient, "FAILOVER", []string{"FORCE"}); err == nil {
+		t.Error("Expected error for FORCE without TO and TIMEOUT")
+	}
+
+	// 테스트 케이스 3: 레플리카가 따라잡은 뒤 역할 교체
+	args := []string{"TO", "127.0.0.1", strconv.Itoa(replicaPort), "TIMEOUT", "5000"}
+	if result, err := master.ExecuteForClient(masterClient, "FAILOVER", args); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	waitFor(t, "role change", func() bool {
+		return strings.Contains(info(master, masterClient), "master_link_status:up") &&
+			strings.Contains(info(replica, replicaClient), "role:master")
+	})
+	if s := info(master, masterClient); !strings.Contains(s, "master_port:"+strconv.Itoa(replicaPort)) || !strings.Contains(s, "master_failover_state:no-failover") {
+		t.Errorf("Expected old master to replicate from the promoted replica, got %q", s)
+	}
+
+	// 테스트 케이스 4: 이전 마스터는 쓰기를 거부하고, 승격된 레플리카의 쓰기를 받음
+	if _, err := master.ExecuteForClient(masterClient, "SET", []string{"b", "2"}); err == nil || !strings.Contains(err.Error(), "READONLY") {
+		t.Errorf("Expected READONLY on the old master, got %v", err)
+	}
+	if _, err := replica.ExecuteForClient(replicaClient, "SET", []string{"b", "2"}); err != nil {
+		t.Fatalf("Unexpected error on the new master: %v", err)
+	}
+	waitFor(t, "write from the new master", func() bool {
+		result, _ := master.ExecuteForClient(masterClient, "GET", []string{"b"})
+		return isBulk(result, "2")
+	})
+	if result, _ := master.ExecuteForClient(masterClient, "GET", []string{"a"}); !isBulk(result, "1") {
+		t.Errorf("Expected dataset to survive the failover, got %v", result)
+	}
+
+	master.ReplicaOfNoOne()
+}
+
+// TestFailoverAbort는 대상 레플리카를 기다리는 동안 쓰기가 멈추고, ABORT로 재개되는지 테스트합니다.
+func TestFailoverAbort(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetRDBFile(t.TempDir(), "dump.rdb")
+	replica, _ := newTestClient(registry)
+	client, _ := newTestClient(registry)
+	registry.ExecuteForClient(replica, "PSYNC", []string{"?", "-1"})
+	waitFor(t, "full sync", func() bool {
+		replica.replica.mu.Lock()
+		defer replica.replica.mu.Unlock()
+		return replica.replica.online
+	})
+
+	// ACK를 보내지 않는 레플리카는 따라잡지 못함
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	if _, err := registry.ExecuteForClient(client, "FAILOVER", []string{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := registry.ExecuteForClient(client, "FAILOVER", []string{}); err == nil {
+		t.Error("Expected error while a failover is in progress")
+	}
+
+	// 테스트 케이스 1: 쓰기는 대기, 읽기는 실행
+	done := make(chan struct{})
+	go func() {
+		writer, _ := newTestClient(registry)
+		registry.ExecuteForClient(writer, "SET", []string{"a", "2"})
+		close(done)
+	}()
+	if result, _ := registry.ExecuteForClient(client, "GET", []string{"a"}); !isBulk(result, "1") {
+		t.Errorf("Expected '1', got %v", result)
+	}
+	select {
+	case <-done:
+		t.Fatal("Expected write to wait during failover")
+	default:
+	}
+	if s, _ := registry.ExecuteForClient(client, "INFO", []string{"replication"}); !strings.Contains(string(s.(protocol.BulkString)), "master_failover_state:waiting-for-sync") {
+		t.Errorf("Expected waiting-for-sync state, got %q", s)
+	}
+
+	// 테스트 케이스 2: ABORT하면 마스터로 남고 쓰기 재개
+	if result, err := registry.ExecuteForClient(client, "FAILOVER", []string{"ABORT"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	<-done
+	if result, _ := registry.ExecuteForClient(client, "GET", []string{"a"}); !isBulk(result, "2") {
+		t.Errorf("Expected '2', got %v", result)
+	}
+	if s, _ := registry.ExecuteForClient(client, "INFO", []string{"replication"}); !strings.Contains(string(s.(protocol.BulkString)), "role:master") || !strings.Contains(string(s.(protocol.BulkString)), "master_failover_state:no-failover") {
+		t.Errorf("Expected master with no failover, got %q", s)
+	}
+}
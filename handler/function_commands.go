@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// FunctionHandler는 FUNCTION 명령어를 처리하는 핸들러입니다.
+//
+// Redis FUNCTION 명령어 사양 (지원하는 서브커맨드):
+//   - FUNCTION LOAD [REPLACE] code: 라이브러리 코드를 실행해 함수들을 등록하고 라이브러리 이름 반환
+//   - FUNCTION DELETE library: 라이브러리와 그 함수들 삭제
+//   - FUNCTION LIST [WITHCODE] [LIBRARYNAME pattern]: 등록된 라이브러리와 함수 목록
+//   - FUNCTION DUMP: 모든 라이브러리를 하나의 페이로드로 직렬화
+//   - FUNCTION RESTORE payload [FLUSH|APPEND|REPLACE]: DUMP 페이로드로 라이브러리 복원
+//   - FUNCTION FLUSH [ASYNC|SYNC]: 모든 라이브러리 삭제
+//   - FUNCTION KILL: 데이터를 변경하지 않은 채 오래 실행 중인 함수 중단
+//   - FUNCTION HELP: 서브커맨드들의 사용법
+//
+// 라이브러리 코드는 첫 줄에 메타데이터가 있어야 합니다:
+//
+//	#!lua name=mylib
+//	redis.register_function('myfunc', function(keys, args) return args[1] end)
+//
+// 예시:
+//
+//	클라이언트: FUNCTION LOAD "#!lua name=mylib\nredis.register_function(...)"
+//	서버: $5\r\nmylib\r\n
+type FunctionHandler struct {
+	scripts *scriptEngine
+}
+
+// Execute는 FUNCTION 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.BulkString: LOAD의 라이브러리 이름, DUMP의 페이로드
+//   - protocol.SimpleString: 그 외 성공 시 OK
+//   - protocol.Array: LIST의 결과
+//   - error: 서브커맨드가 없거나 알 수 없는 경우, 라이브러리 오류, 중단할 함수가 없는 경우 등
+func (h *FunctionHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.subcommands().dispatch(nil, args, store)
+}
+
+// subcommands는 FUNCTION의 서브커맨드 목록입니다.
+func (h *FunctionHandler) subcommands() *subcommandTable {
+	return &subcommandTable{command: "FUNCTION", subs: []subcommand{
+		{"LOAD", -3, []string{"LOAD [REPLACE] <FUNCTION CODE>",
+			"Create a new library with the given library name and code."}, h.load},
+		{"DELETE", 3, []string{"DELETE <LIBRARY NAME>", "Delete the given library."}, h.delete},
+		{"LIST", -2, []string{"LIST [LIBRARYNAME PATTERN] [WITHCODE]",
+			"Return general information on all the libraries:",
+			"* Library name", "* The engine used to run the Library",
+			"* List of functions", "* Library code (if WITHCODE is given)"}, h.list},
+		{"DUMP", 2, []string{"DUMP", "Return a serialized payload representing the current libraries, can be restored using FUNCTION RESTORE command"}, h.dump},
+		{"RESTORE", -3, []string{"RESTORE <PAYLOAD> [FLUSH|APPEND|REPLACE]",
+			"Restore the libraries represented by the given payload, it is possible to give a restore policy to",
+			"control how to handle existing libraries (default APPEND):",
+			"* FLUSH: delete all existing libraries.",
+			"* APPEND: appends the restored libraries to the existing libraries. On collision, abort.",
+			"* REPLACE: appends the restored libraries to the existing libraries, On collision, replace the old",
+			"  libraries with the new libraries (notice that even on this option there is a chance of failure",
+			"  in case of functions name collision with another library)."}, h.restore},
+		{"FLUSH", -2, []string{"FLUSH [ASYNC|SYNC]", "Delete all the libraries."}, h.flush},
+		{"KILL", 2, []string{"KILL", "Kill the current running function."}, h.kill},
+	}}
+}
+
+// load는 FUNCTION LOAD를 실행하고 라이브러리 이름을 반환합니다.
+func (h *FunctionHandler) load(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	replace := len(args) == 3 && strings.EqualFold(args[1], "REPLACE")
+	if len(args) != 2 && !replace {
+		if len(args) == 3 {
+			return nil, &InvalidArgumentError{Message: "Unknown option given: " + args[1]}
+		}
+		return nil, &WrongNumberOfArgumentsError{Command: "function|load"}
+	}
+	name, err := h.scripts.loadLibrary(args[len(args)-1], replace)
+	if err != nil {
+		return nil, err
+	}
+	return protocol.BulkString(name), nil
+}
+
+// delete는 FUNCTION DELETE를 실행합니다.
+func (h *FunctionHandler) delete(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if err := h.scripts.deleteLibrary(args[1]); err != nil {
+		return nil, err
+	}
+	return okReply, nil
+}
+
+// list는 FUNCTION LIST를 실행합니다.
+func (h *FunctionHandler) list(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return h.executeList(args[1:])
+}
+
+// dump는 FUNCTION DUMP를 실행합니다.
+func (h *FunctionHandler) dump(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return protocol.BulkString(h.scripts.dumpLibraries()), nil
+}
+
+// restore는 FUNCTION RESTORE를 실행합니다.
+func (h *FunctionHandler) restore(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) > 3 {
+		return nil, &WrongNumberOfArgumentsError{Command: "function|restore"}
+	}
+	policy := "APPEND"
+	if len(args) == 3 {
+		policy = strings.ToUpper(args[2])
+		if policy != "APPEND" && policy != "REPLACE" && policy != "FLUSH" {
+			return nil, &InvalidArgumentError{Message: "Wrong restore policy given, value should be either FLUSH, APPEND or REPLACE."}
+		}
+	}
+	if err := h.scripts.restoreLibraries(args[1], policy); err != nil {
+		return nil, err
+	}
+	return okReply, nil
+}
+
+// flush는 FUNCTION FLUSH를 실행합니다.
+func (h *FunctionHandler) flush(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) > 2 {
+		return nil, &WrongNumberOfArgumentsError{Command: "function|flush"}
+	}
+	if len(args) == 2 && !strings.EqualFold(args[1], "ASYNC") && !strings.EqualFold(args[1], "SYNC") {
+		return nil, &InvalidArgumentError{Message: "FUNCTION FLUSH only supports SYNC|ASYNC option"}
+	}
+	h.scripts.flushFunctions()
+	return okReply, nil
+}
+
+// kill은 FUNCTION KILL을 실행합니다.
+func (h *FunctionHandler) kill(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if err := h.scripts.kill(); err != nil {
+		return nil, err
+	}
+	return okReply, nil
+}
+
+// executeList는 FUNCTION LIST 서브커맨드를 실행합니다.
+//
+// 옵션:
+//   - WITHCODE: 라이브러리 코드 포함
+//   - LIBRARYNAME pattern: 이름이 패턴과 일치하는 라이브러리만
+func (h *FunctionHandler) executeList(args []string) (protocol.Reply, error) {
+	withCode := false
+	pattern := ""
+	for i := 0; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "WITHCODE":
+			if withCode {
+				return nil, &InvalidArgumentError{Message: "Unknown argument withcode"}
+			}
+			withCode = true
+		case "LIBRARYNAME":
+			if pattern != "" || i+1 >= len(args) {
+				return nil, &InvalidArgumentError{Message: "library name argument was not given"}
+			}
+			i++
+			pattern = args[i]
+		default:
+			return nil, &InvalidArgumentError{Message: "Unknown argument " + args[i]}
+		}
+	}
+	return h.scripts.listLibraries(pattern, withCode), nil
+}
+
+// FCallHandler는 FCALL과 FCALL_RO 명령어를 처리하는 핸들러입니다.
+//
+// Redis FCALL 명령어 사양:
+//   - FCALL function numkeys [key ...] [arg ...]
+//   - FUNCTION LOAD로 등록한 함수를 실행 (함수는 keys, args 두 테이블을 인자로 받음)
+//   - 실행 중에는 다른 클라이언트의 명령어가 끼어들지 않음 (원자적 실행)
+//   - FCALL_RO는 no-writes 플래그로 등록한 함수만 실행 가능
+//
+// 예시:
+//
+//	클라이언트: FCALL myfunc 1 key value
+//	서버: $5\r\nvalue\r\n
+type FCallHandler struct {
+	scripts  *scriptEngine
+	readOnly bool // true이면 FCALL_RO
+}
+
+// Execute는 연결 정보 없이 FCALL 명령어를 실행합니다.
+func (h *FCallHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.ExecuteWithClient(nil, args, store)
+}
+
+// ExecuteWithClient는 FCALL 명령어를 실행합니다.
+func (h *FCallHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 2 {
+		if h.readOnly {
+			return nil, &WrongNumberOfArgumentsError{Command: "fcall_ro"}
+		}
+		return nil, &WrongNumberOfArgumentsError{Command: "fcall"}
+	}
+	keys, argv, err := parseScriptKeys(args[1], args[2:])
+	if err != nil {
+		return nil, err
+	}
+	return h.scripts.fcall(client, args[0], keys, argv, h.readOnly)
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+const testLibrary = `#!lua name=mylib
+redis.register_function('echo_arg', function(keys, args) return args[1] end)
+redis.register_function{
+	function_name = 'get_key',
+	callback = function(keys, args) return redis.call('GET', keys[1]) end,
+	flags = {'no-writes'},
+	description = 'reads a key',
+}
+redis.register_function('set_key', function(keys, args) return redis.call('SET', keys[1], args[1]) end)
+`
+
+// TestFunctionLoadAndCall은 FUNCTION LOAD와 FCALL/FCALL_RO를 테스트합니다.
+func TestFunctionLoadAndCall(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: LOAD → 라이브러리 이름
+	result, err := registry.ExecuteForClient(client, "FUNCTION", []string{"LOAD", testLibrary})
+	if err != nil {
+		t.Fatalf("FUNCTION LOAD failed: %v", err)
+	}
+	if result != protocol.BulkString("mylib") {
+		t.Errorf("Expected 'mylib', got %v", result)
+	}
+
+	// 테스트 케이스 2: FCALL → 함수는 keys, args를 인자로 받음
+	result, _ = registry.ExecuteForClient(client, "FCALL", []string{"echo_arg", "0", "hello"})
+	if result != protocol.BulkString("hello") {
+		t.Errorf("Expected 'hello', got %v", result)
+	}
+	result, _ = registry.ExecuteForClient(client, "FCALL", []string{"set_key", "1", "k", "v"})
+	if !reflect.DeepEqual(result, protocol.SimpleString("OK")) {
+		t.Errorf("Expected OK status, got %v", result)
+	}
+
+	// 테스트 케이스 3: FCALL_RO는 no-writes 함수만 실행 가능
+	result, err = registry.ExecuteForClient(client, "FCALL_RO", []string{"get_key", "1", "k"})
+	if err != nil || result != protocol.BulkString("v") {
+		t.Errorf("Expected 'v', got %v, %v", result, err)
+	}
+	if _, err := registry.ExecuteForClient(client, "FCALL_RO", []string{"set_key", "1", "k", "v"}); err == nil {
+		t.Error("Expected error for FCALL_RO on a function without no-writes")
+	}
+
+	// 테스트 케이스 4: 같은 이름의 라이브러리는 REPLACE 없이 다시 LOAD 불가
+	if _, err := registry.ExecuteForClient(client, "FUNCTION", []string{"LOAD", testLibrary}); err == nil {
+		t.Error("Expected error for loading an existing library")
+	}
+	replaced := "#!lua name=mylib\nredis.register_function('echo_arg', function(keys, args) return 'replaced' end)"
+	if _, err := registry.ExecuteForClient(client, "FUNCTION", []string{"LOAD", "REPLACE", replaced}); err != nil {
+		t.Fatalf("FUNCTION LOAD REPLACE failed: %v", err)
+	}
+	result, _ = registry.ExecuteForClient(client, "FCALL", []string{"echo_arg", "0"})
+	if result != protocol.BulkString("replaced") {
+		t.Errorf("Expected 'replaced', got %v", result)
+	}
+	if _, err := registry.ExecuteForClient(client, "FCALL", []string{"get_key", "1", "k"}); err == nil {
+		t.Error("Expected functions of the replaced library to be removed")
+	}
+
+	// 테스트 케이스 5: 실행 중 에러에는 함수 이름이 붙음
+	registry.ExecuteForClient(client, "FUNCTION", []string{"LOAD", "#!lua name=failing\nredis.register_function('boom', function() error('bad') end)"})
+	_, err = registry.ExecuteForClient(client, "FCALL", []string{"boom", "0"})
+	if err == nil || !strings.HasPrefix(err.Error(), "-ERR user_function:2: bad script: boom") {
+		t.Errorf("Expected error with function name, got %v", err)
+	}
+
+	// 테스트 케이스 6: 없는 함수 (에러 케이스)
+	if _, err := registry.ExecuteForClient(client, "FCALL", []string{"nosuch", "0"}); err == nil {
+		t.Error("Expected error for unknown function")
+	}
+}
+
+// TestFunctionLoadErrors는 잘못된 라이브러리 코드에 대한 에러를 테스트합니다.
+func TestFunctionLoadErrors(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{"redis.register_function('f', function() end)", "-ERR Missing library metadata"},
+		{"#!python name=lib\n", "-ERR Engine 'python' not found"},
+		{"#!lua\n", "-ERR Library name was not given"},
+		{"#!lua name=lib foo=bar\n", "-ERR Invalid metadata value given: foo=bar"},
+		{"#!lua name=bad-name\n", "-ERR Library names can only contain letters"},
+		{"#!lua name=lib\nlocal x = 1", "-ERR No functions registered"},
+		{"#!lua name=lib\nredis.register_function('f',", "-ERR Error compiling function"},
+		{"#!lua name=lib\nredis.register_function('f', 'notfn')", "-ERR Error registering functions"},
+		{"#!lua name=lib\nredis.register_function{function_name = 'f', callback = function() end, flags = {'bogus'}}", "unknown flag given"},
+		{"#!lua name=lib\nredis.call('SET', 'a', 'b')", "not allowed while loading a library"},
+		{"#!lua name=lib\nwhile true do end", "FUNCTION LOAD timeout"},
+	}
+
+	for _, tt := range tests {
+		_, err := registry.Execute("FUNCTION", []string{"LOAD", tt.code})
+		if err == nil {
+			t.Errorf("%q: expected error", tt.code)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%q: expected error containing %q, got %q", tt.code, tt.expected, err.Error())
+		}
+	}
+
+	// 다른 라이브러리의 함수와 이름이 겹치면 에러
+	registry.Execute("FUNCTION", []string{"LOAD", "#!lua name=first\nredis.register_function('shared', function() end)"})
+	_, err := registry.Execute("FUNCTION", []string{"LOAD", "#!lua name=second\nredis.register_function('shared', function() end)"})
+	if err == nil || !strings.Contains(err.Error(), "Function shared already exists") {
+		t.Errorf("Expected duplicate function error, got %v", err)
+	}
+
+	// EVAL 스크립트에서는 register_function을 사용할 수 없음
+	if _, err := registry.Execute("EVAL", []string{"redis.register_function('f', function() end)", "0"}); err == nil {
+		t.Error("Expected error for register_function from EVAL")
+	}
+}
+
+// TestFunctionManagement는 FUNCTION LIST/DELETE/DUMP/RESTORE/FLUSH를 테스트합니다.
+func TestFunctionManagement(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.Execute("FUNCTION", []string{"LOAD", testLibrary})
+	other := "#!lua name=otherlib\nredis.register_function('other', function() return 1 end)"
+	registry.Execute("FUNCTION", []string{"LOAD", other})
+
+	// 테스트 케이스 1: LIST LIBRARYNAME pattern WITHCODE
+	result, err := registry.Execute("FUNCTION", []string{"LIST", "LIBRARYNAME", "other*", "WITHCODE"})
+	if err != nil {
+		t.Fatalf("FUNCTION LIST failed: %v", err)
+	}
+	expected := protocol.Array{
+		protocol.Array{
+			protocol.BulkString("library_name"), protocol.BulkString("otherlib"),
+			protocol.BulkString("engine"), protocol.BulkString("LUA"),
+			protocol.BulkString("functions"), protocol.Array{
+				protocol.Array{
+					protocol.BulkString("name"), protocol.BulkString("other"),
+					protocol.BulkString("description"), nil,
+					protocol.BulkString("flags"), protocol.BulkStrings{},
+				},
+			},
+			protocol.BulkString("library_code"), protocol.BulkString(other),
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// 테스트 케이스 2: LIST는 이름 순, 함수의 설명과 플래그 포함
+	result, _ = registry.Execute("FUNCTION", []string{"LIST"})
+	libs := result.(protocol.Array)
+	if len(libs) != 2 {
+		t.Fatalf("Expected 2 libraries, got %v", libs)
+	}
+	mylib := libs[0].(protocol.Array)
+	getKey := mylib[5].(protocol.Array)[1]
+	if !reflect.DeepEqual(getKey, protocol.Array{
+		protocol.BulkString("name"), protocol.BulkString("get_key"),
+		protocol.BulkString("description"), protocol.BulkString("reads a key"),
+		protocol.BulkString("flags"), protocol.BulkStrings{"no-writes"},
+	}) {
+		t.Errorf("Unexpected function entry %v", getKey)
+	}
+
+	// 테스트 케이스 3: DUMP → FLUSH → RESTORE
+	payload, _ := registry.Execute("FUNCTION", []string{"DUMP"})
+	if result, _ := registry.Execute("FUNCTION", []string{"FLUSH"}); !isStatus(result, "OK") {
+		t.Errorf("Expected 'OK', got %v", result)
+	}
+	if result, _ := registry.Execute("FUNCTION", []string{"LIST"}); len(result.(protocol.Array)) != 0 {
+		t.Errorf("Expected no libraries after FLUSH, got %v", result)
+	}
+	if _, err := registry.Execute("FUNCTION", []string{"RESTORE", string(payload.(protocol.BulkString))}); err != nil {
+		t.Fatalf("FUNCTION RESTORE failed: %v", err)
+	}
+	if result, _ := registry.Execute("FCALL", []string{"other", "0"}); result != protocol.Integer(1) {
+		t.Errorf("Expected 1 after RESTORE, got %v", result)
+	}
+
+	// 테스트 케이스 4: 이미 있는 라이브러리는 APPEND로 복원 불가, 실패하면 상태 유지
+	if _, err := registry.Execute("FUNCTION", []string{"RESTORE", string(payload.(protocol.BulkString))}); err == nil {
+		t.Error("Expected error for RESTORE APPEND with existing libraries")
+	}
+	if _, err := registry.Execute("FUNCTION", []string{"RESTORE", string(payload.(protocol.BulkString)), "REPLACE"}); err != nil {
+		t.Errorf("FUNCTION RESTORE REPLACE failed: %v", err)
+	}
+	if _, err := registry.Execute("FUNCTION", []string{"RESTORE", "garbage"}); err == nil {
+		t.Error("Expected error for invalid payload")
+	}
+
+	// 테스트 케이스 5: DELETE
+	if result, _ := registry.Execute("FUNCTION", []string{"DELETE", "otherlib"}); !isStatus(result, "OK") {
+		t.Errorf("Expected 'OK', got %v", result)
+	}
+	if _, err := registry.Execute("FCALL", []string{"other", "0"}); err == nil {
+		t.Error("Expected error for function of deleted library")
+	}
+	if _, err := registry.Execute("FUNCTION", []string{"DELETE", "otherlib"}); err == nil {
+		t.Error("Expected error for deleting a missing library")
+	}
+
+	// 테스트 케이스 6: 실행 중인 함수가 없으면 KILL은 NOTBUSY
+	if _, err := registry.Execute("FUNCTION", []string{"KILL"}); err == nil {
+		t.Error("Expected NOTBUSY error for FUNCTION KILL")
+	}
+}
@@ -0,0 +1,428 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/pubsub"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// functionFlags는 redis.register_function에서 지정할 수 있는 함수 플래그입니다.
+var functionFlags = map[string]bool{
+	"no-writes":             true,
+	"allow-oom":             true,
+	"allow-stale":           true,
+	"no-cluster":            true,
+	"allow-cross-slot-keys": true,
+}
+
+// functionLoadTimeout은 FUNCTION LOAD가 라이브러리 코드를 실행할 수 있는 최대 시간입니다.
+// 라이브러리 코드는 함수를 등록하기만 해야 하므로 Redis와 동일하게 짧게 제한합니다.
+const functionLoadTimeout = 500 * time.Millisecond
+
+// functionLibrary는 FUNCTION LOAD로 등록한 라이브러리입니다.
+type functionLibrary struct {
+	name      string
+	code      string         // 메타데이터(#!lua name=...) 줄을 포함한 전체 코드
+	functions []*luaFunction // 등록 순서대로
+}
+
+// luaFunction은 라이브러리 안에서 redis.register_function으로 등록한 함수입니다.
+type luaFunction struct {
+	name        string
+	library     *functionLibrary
+	callback    *lua.LFunction
+	description string
+	flags       []string
+}
+
+// hasFlag는 함수에 플래그가 지정되어 있는지 확인합니다.
+func (f *luaFunction) hasFlag(flag string) bool {
+	for _, fl := range f.flags {
+		if fl == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// resetFunctions는 등록된 모든 라이브러리를 지우고 함수용 lua.LState를 새로 만듭니다.
+// 호출하는 쪽에서 mu를 잡고 있어야 합니다 (생성 시 제외).
+func (e *scriptEngine) resetFunctions() {
+	L := e.newState()
+	redis := L.GetGlobal("redis").(*lua.LTable)
+	redis.RawSetString("register_function", L.NewFunction(e.registerFunction))
+
+	e.functionL = L
+	e.libraries = make(map[string]*functionLibrary)
+	e.functions = make(map[string]*luaFunction)
+}
+
+// registerFunction은 redis.register_function의 구현입니다.
+//
+// 호출 형식:
+//   - redis.register_function(name, callback)
+//   - redis.register_function{function_name = name, callback = fn, flags = {...}, description = "..."}
+func (e *scriptEngine) registerFunction(L *lua.LState) int {
+	if e.loading == nil {
+		L.RaiseError("redis.register_function can only be called on FUNCTION LOAD command")
+	}
+
+	var name, callback, flags, description lua.LValue = lua.LNil, lua.LNil, lua.LNil, lua.LNil
+	switch L.GetTop() {
+	case 1:
+		t, ok := L.Get(1).(*lua.LTable)
+		if !ok {
+			L.RaiseError("calling redis.register_function with a single argument is only applicable to Lua table (representing named arguments)")
+		}
+		var unknown bool
+		t.ForEach(func(key, value lua.LValue) {
+			switch key.String() {
+			case "function_name":
+				name = value
+			case "callback":
+				callback = value
+			case "flags":
+				flags = value
+			case "description":
+				description = value
+			default:
+				unknown = true
+			}
+		})
+		if unknown {
+			L.RaiseError("unknown argument given to redis.register_function")
+		}
+	case 2:
+		name, callback = L.Get(1), L.Get(2)
+	default:
+		L.RaiseError("wrong number of arguments to redis.register_function")
+	}
+
+	fnName, ok := name.(lua.LString)
+	if !ok {
+		L.RaiseError("function_name argument given to redis.register_function must be a string")
+	}
+	fn, ok := callback.(*lua.LFunction)
+	if !ok {
+		L.RaiseError("callback argument given to redis.register_function must be a function")
+	}
+	if !validFunctionName(string(fnName)) {
+		L.RaiseError("Function names can only contain letters, numbers, or underscores(_) and must be at least one character long")
+	}
+	for _, f := range e.loading.functions {
+		if f.name == string(fnName) {
+			L.RaiseError("Function already exists in the library")
+		}
+	}
+
+	f := &luaFunction{name: string(fnName), library: e.loading, callback: fn, flags: []string{}}
+	if description != lua.LNil {
+		desc, ok := description.(lua.LString)
+		if !ok {
+			L.RaiseError("description argument given to redis.register_function must be a string")
+		}
+		f.description = string(desc)
+	}
+	if flags != lua.LNil {
+		t, ok := flags.(*lua.LTable)
+		if !ok {
+			L.RaiseError("flags argument to redis.register_function must be a table representing function flags")
+		}
+		for i := 1; i <= t.Len(); i++ {
+			flag, ok := t.RawGetInt(i).(lua.LString)
+			if !ok || !functionFlags[string(flag)] {
+				L.RaiseError("unknown flag given")
+			}
+			f.flags = append(f.flags, string(flag))
+		}
+	}
+
+	e.loading.functions = append(e.loading.functions, f)
+	return 0
+}
+
+// validFunctionName은 라이브러리/함수 이름이 영문자, 숫자, 밑줄로만 이루어졌는지 확인합니다.
+func validFunctionName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLibraryMetadata는 라이브러리 코드 첫 줄의 메타데이터(#!lua name=<이름>)를 해석합니다.
+//
+// 반환값:
+//   - string: 라이브러리 이름
+//   - string: 실행할 코드 (줄 번호가 유지되도록 메타데이터 줄을 빈 줄로 바꾼 코드)
+//   - error: 메타데이터가 없거나 잘못된 경우
+func parseLibraryMetadata(code string) (string, string, error) {
+	if !strings.HasPrefix(code, "#!") {
+		return "", "", &InvalidArgumentError{Message: "Missing library metadata"}
+	}
+	header, body := code, ""
+	if i := strings.IndexByte(code, '\n'); i >= 0 {
+		header, body = code[:i], code[i:]
+	}
+
+	fields := strings.Fields(header[2:])
+	if len(fields) == 0 || !strings.EqualFold(fields[0], "lua") {
+		engine := ""
+		if len(fields) > 0 {
+			engine = fields[0]
+		}
+		return "", "", &InvalidArgumentError{Message: "Engine '" + engine + "' not found"}
+	}
+
+	name := ""
+	for _, field := range fields[1:] {
+		value, ok := strings.CutPrefix(field, "name=")
+		if !ok {
+			return "", "", &InvalidArgumentError{Message: "Invalid metadata value given: " + field}
+		}
+		name = value
+	}
+	if name == "" {
+		return "", "", &InvalidArgumentError{Message: "Library name was not given"}
+	}
+	if !validFunctionName(name) {
+		return "", "", &InvalidArgumentError{Message: "Library names can only contain letters, numbers, or underscores(_) and must be at least one character long"}
+	}
+	return name, body, nil
+}
+
+// loadLibrary는 라이브러리 코드를 실행해 함수들을 등록하고 라이브러리 이름을 반환합니다 (FUNCTION LOAD).
+//
+// 매개변수:
+//   - replace: true이면 같은 이름의 라이브러리를 교체, false이면 에러
+func (e *scriptEngine) loadLibrary(code string, replace bool) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.loadLibraryLocked(code, replace)
+}
+
+// loadLibraryLocked는 mu를 잡은 상태에서 loadLibrary를 실행합니다.
+func (e *scriptEngine) loadLibraryLocked(code string, replace bool) (string, error) {
+	name, body, err := parseLibraryMetadata(code)
+	if err != nil {
+		return "", err
+	}
+	old := e.libraries[name]
+	if old != nil && !replace {
+		return "", &InvalidArgumentError{Message: "Library '" + name + "' already exists"}
+	}
+
+	fn, err := compile(e.functionL, body, "user_function")
+	if err != nil {
+		return "", &InvalidArgumentError{Message: "Error compiling function: " + err.Error()}
+	}
+
+	lib := &functionLibrary{name: name, code: code}
+	ctx, cancel := context.WithTimeout(context.Background(), functionLoadTimeout)
+	e.functionL.SetContext(ctx)
+	e.loading = lib
+	err = e.functionL.CallByParam(lua.P{Fn: fn, NRet: 0, Protect: true})
+	e.loading = nil
+	e.functionL.RemoveContext()
+	cancel()
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", &InvalidArgumentError{Message: "Error registering functions: FUNCTION LOAD timeout"}
+	}
+	if err != nil {
+		return "", &InvalidArgumentError{Message: "Error registering functions: " + luaErrorMessage(err)}
+	}
+	if len(lib.functions) == 0 {
+		return "", &InvalidArgumentError{Message: "No functions registered"}
+	}
+	for _, f := range lib.functions {
+		if existing, ok := e.functions[f.name]; ok && existing.library != old {
+			return "", &InvalidArgumentError{Message: "Function " + f.name + " already exists"}
+		}
+	}
+
+	if old != nil {
+		e.removeLibrary(old)
+	}
+	e.libraries[name] = lib
+	for _, f := range lib.functions {
+		e.functions[f.name] = f
+	}
+	return name, nil
+}
+
+// removeLibrary는 라이브러리와 그 함수들을 등록 해제합니다.
+func (e *scriptEngine) removeLibrary(lib *functionLibrary) {
+	for _, f := range lib.functions {
+		delete(e.functions, f.name)
+	}
+	delete(e.libraries, lib.name)
+}
+
+// deleteLibrary는 이름으로 라이브러리를 삭제합니다 (FUNCTION DELETE).
+func (e *scriptEngine) deleteLibrary(name string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	lib, ok := e.libraries[name]
+	if !ok {
+		return &InvalidArgumentError{Message: "Library not found"}
+	}
+	e.removeLibrary(lib)
+	return nil
+}
+
+// flushFunctions는 등록된 모든 라이브러리를 삭제합니다 (FUNCTION FLUSH).
+func (e *scriptEngine) flushFunctions() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.resetFunctions()
+}
+
+// fcall은 등록된 함수를 실행합니다 (FCALL, FCALL_RO).
+// 함수는 KEYS와 ARGV를 전역 변수가 아닌 인자 (keys, args)로 받습니다.
+//
+// 매개변수:
+//   - readOnly: true이면 no-writes 플래그가 있는 함수만 실행 가능 (FCALL_RO)
+func (e *scriptEngine) fcall(client *Client, name string, keys, argv []string, readOnly bool) (protocol.Reply, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	f, ok := e.functions[name]
+	if !ok {
+		return nil, &InvalidArgumentError{Message: "Function not found"}
+	}
+	if readOnly && !f.hasFlag("no-writes") {
+		return nil, &InvalidArgumentError{Message: "Can not execute a script with write flag using *_ro command."}
+	}
+
+	return e.invoke(client, name, e.functionL, f.callback, stringArray(e.functionL, keys), stringArray(e.functionL, argv))
+}
+
+// sortedLibraries는 라이브러리들을 이름 순으로 반환합니다. 호출하는 쪽에서 mu를 잡고 있어야 합니다.
+func (e *scriptEngine) sortedLibraries() []*functionLibrary {
+	libs := make([]*functionLibrary, 0, len(e.libraries))
+	for _, lib := range e.libraries {
+		libs = append(libs, lib)
+	}
+	sort.Slice(libs, func(i, j int) bool { return libs[i].name < libs[j].name })
+	return libs
+}
+
+// listLibraries는 FUNCTION LIST의 응답을 만듭니다.
+//
+// 매개변수:
+//   - pattern: 라이브러리 이름 패턴 (빈 문자열이면 전체)
+//   - withCode: true이면 라이브러리 코드도 포함
+func (e *scriptEngine) listLibraries(pattern string, withCode bool) protocol.Array {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := make(protocol.Array, 0, len(e.libraries))
+	for _, lib := range e.sortedLibraries() {
+		if pattern != "" && !pubsub.Match(pattern, lib.name) {
+			continue
+		}
+
+		functions := make(protocol.Array, 0, len(lib.functions))
+		for _, f := range lib.functions {
+			var description protocol.Reply
+			if f.description != "" {
+				description = protocol.BulkString(f.description)
+			}
+			functions = append(functions, protocol.Array{
+				protocol.BulkString("name"), protocol.BulkString(f.name),
+				protocol.BulkString("description"), description,
+				protocol.BulkString("flags"), protocol.BulkStrings(f.flags),
+			})
+		}
+
+		entry := protocol.Array{
+			protocol.BulkString("library_name"), protocol.BulkString(lib.name),
+			protocol.BulkString("engine"), protocol.BulkString("LUA"),
+			protocol.BulkString("functions"), functions,
+		}
+		if withCode {
+			entry = append(entry, protocol.BulkString("library_code"), protocol.BulkString(lib.code))
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// dumpLibraries는 등록된 모든 라이브러리의 코드를 하나의 페이로드로 직렬화합니다 (FUNCTION DUMP).
+//
+// 페이로드 형식: 라이브러리마다 "<코드 길이>\n<코드>"를 이름 순으로 이어 붙인 문자열
+func (e *scriptEngine) dumpLibraries() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var b strings.Builder
+	for _, lib := range e.sortedLibraries() {
+		b.WriteString(strconv.Itoa(len(lib.code)))
+		b.WriteByte('\n')
+		b.WriteString(lib.code)
+	}
+	return b.String()
+}
+
+// restoreLibraries는 FUNCTION DUMP의 페이로드로 라이브러리들을 다시 등록합니다 (FUNCTION RESTORE).
+// 하나라도 실패하면 복원 전 상태로 되돌립니다.
+//
+// 매개변수:
+//   - policy: "APPEND" (이름이 겹치면 에러), "REPLACE" (겹치면 교체), "FLUSH" (기존 라이브러리 모두 삭제 후 복원)
+func (e *scriptEngine) restoreLibraries(payload, policy string) error {
+	var codes []string
+	for rest := payload; rest != ""; {
+		i := strings.IndexByte(rest, '\n')
+		if i < 0 {
+			return &InvalidArgumentError{Message: "payload version or checksum are wrong"}
+		}
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil || n < 0 || n > len(rest)-i-1 {
+			return &InvalidArgumentError{Message: "payload version or checksum are wrong"}
+		}
+		codes = append(codes, rest[i+1:i+1+n])
+		rest = rest[i+1+n:]
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// 실패 시 되돌릴 수 있도록 현재 상태 보관
+	savedL, savedLibraries, savedFunctions := e.functionL, e.libraries, e.functions
+	if policy == "FLUSH" {
+		e.resetFunctions()
+	} else {
+		e.libraries = make(map[string]*functionLibrary, len(savedLibraries))
+		for name, lib := range savedLibraries {
+			e.libraries[name] = lib
+		}
+		e.functions = make(map[string]*luaFunction, len(savedFunctions))
+		for name, f := range savedFunctions {
+			e.functions[name] = f
+		}
+	}
+
+	for _, code := range codes {
+		if _, err := e.loadLibraryLocked(code, policy == "REPLACE"); err != nil {
+			e.functionL, e.libraries, e.functions = savedL, savedLibraries, savedFunctions
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,621 @@
+// Package handler는 Redis의 Geo 명령어들을 구현합니다.
+// Geo 데이터는 Sorted Set에 저장되며, 각 멤버의 점수는 52비트 geohash 정수입니다.
+// 따라서 Geo 키는 Sorted Set 타입이고, 좌표 인코딩 로직은 geo 패키지가 담당합니다.
+package handler
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/geo"
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// GeoAddHandler는 GEOADD 명령어를 처리하는 핸들러입니다.
+//
+// Redis GEOADD 명령어 사양:
+//   - GEOADD key [NX|XX] [CH] longitude latitude member [longitude latitude member ...]
+//   - NX: 새 멤버만 추가 (기존 멤버는 갱신하지 않음)
+//   - XX: 기존 멤버만 갱신 (새 멤버는 추가하지 않음)
+//   - CH: 추가된 개수 대신 변경된(추가+갱신) 개수 반환
+//
+// 예시:
+//
+//	GEOADD Sicily 13.361389 38.115556 "Palermo" 15.087269 37.502669 "Catania" → :2\r\n
+//
+// 시간 복잡도: O(log(N)) (추가하는 멤버 하나당)
+type GeoAddHandler struct{}
+
+// Execute는 GEOADD 명령어를 실행합니다.
+func (h *GeoAddHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 4 {
+		return nil, &WrongNumberOfArgumentsError{Command: "geoadd"}
+	}
+
+	key := args[0]
+	nx, xx, ch := false, false, false
+
+	// 옵션 파싱: 좌표가 시작되기 전까지의 NX/XX/CH
+	i := 1
+	for ; i < len(args); i++ {
+		option := strings.ToUpper(args[i])
+		if option == "NX" {
+			nx = true
+		} else if option == "XX" {
+			xx = true
+		} else if option == "CH" {
+			ch = true
+		} else {
+			break
+		}
+	}
+
+	if nx && xx {
+		return nil, &InvalidArgumentError{Message: "XX and NX options at the same time are not compatible"}
+	}
+
+	triples := args[i:]
+	if len(triples) == 0 || len(triples)%3 != 0 {
+		return nil, &InvalidArgumentError{Message: "syntax error. Try GEOADD key [x1] [y1] [name1] [x2] [y2] [name2] ... "}
+	}
+
+	if err := checkGeoKeyType(store, key); err != nil {
+		return nil, err
+	}
+
+	// 모든 좌표를 먼저 검증 (일부만 추가되는 일이 없도록)
+	scores := make([]float64, 0, len(triples)/3)
+	for j := 0; j < len(triples); j += 3 {
+		lon, lat, err := parseLonLat(triples[j], triples[j+1])
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, float64(geo.Encode(lon, lat)))
+	}
+
+	changed := 0
+	added := 0
+	for j, score := range scores {
+		member := triples[j*3+2]
+		oldScore, exists := store.ZSCORE(key, member)
+
+		if (nx && exists) || (xx && !exists) {
+			continue
+		}
+
+		store.ZADD(key, score, member)
+		if !exists {
+			added++
+			changed++
+		} else if oldScore != score {
+			changed++
+		}
+	}
+
+	if ch {
+		return protocol.Integer(changed), nil
+	}
+	return protocol.Integer(added), nil
+}
+
+// GeoPosHandler는 GEOPOS 명령어를 처리하는 핸들러입니다.
+//
+// Redis GEOPOS 명령어 사양:
+//   - GEOPOS key [member ...]
+//   - 각 멤버의 [경도, 위도] 배열을 반환, 없는 멤버는 null
+//
+// 예시:
+//
+//	GEOPOS Sicily Palermo NonExisting
+//	→ 1) 1) "13.36138933897018433"
+//	      2) "38.11555639549629859"
+//	   2) (nil)
+type GeoPosHandler struct{}
+
+// Execute는 GEOPOS 명령어를 실행합니다.
+func (h *GeoPosHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "geopos"}
+	}
+
+	key := args[0]
+	if err := checkGeoKeyType(store, key); err != nil {
+		return nil, err
+	}
+
+	result := make(protocol.Array, 0, len(args)-1)
+	for _, member := range args[1:] {
+		lon, lat, ok := memberPosition(store, key, member)
+		if !ok {
+			result = append(result, protocol.NullArray{})
+			continue
+		}
+		result = append(result, protocol.BulkStrings{formatCoordinate(lon), formatCoordinate(lat)})
+	}
+
+	return result, nil
+}
+
+// GeoDistHandler는 GEODIST 명령어를 처리하는 핸들러입니다.
+//
+// Redis GEODIST 명령어 사양:
+//   - GEODIST key member1 member2 [M|KM|FT|MI]
+//   - 두 멤버 사이의 거리를 지정된 단위(기본값 미터)로 반환
+//   - 멤버 중 하나라도 없으면 null 반환
+//
+// 예시:
+//
+//	GEODIST Sicily Palermo Catania → "166274.1516"
+//	GEODIST Sicily Palermo Catania km → "166.2742"
+type GeoDistHandler struct{}
+
+// Execute는 GEODIST 명령어를 실행합니다.
+func (h *GeoDistHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 3 {
+		return nil, &WrongNumberOfArgumentsError{Command: "geodist"}
+	}
+	if len(args) > 4 {
+		return nil, &InvalidArgumentError{Message: "syntax error"}
+	}
+
+	unit := 1.0
+	if len(args) == 4 {
+		var err error
+		if unit, err = parseGeoUnit(args[3]); err != nil {
+			return nil, err
+		}
+	}
+
+	key := args[0]
+	if err := checkGeoKeyType(store, key); err != nil {
+		return nil, err
+	}
+
+	lon1, lat1, ok1 := memberPosition(store, key, args[1])
+	lon2, lat2, ok2 := memberPosition(store, key, args[2])
+	if !ok1 || !ok2 {
+		return nil, nil
+	}
+
+	distance := geo.Distance(lon1, lat1, lon2, lat2) / unit
+	return protocol.BulkString(strconv.FormatFloat(distance, 'f', 4, 64)), nil
+}
+
+// GeoHashHandler는 GEOHASH 명령어를 처리하는 핸들러입니다.
+//
+// Redis GEOHASH 명령어 사양:
+//   - GEOHASH key [member ...]
+//   - 각 멤버의 11자리 표준 geohash 문자열을 반환, 없는 멤버는 null
+//
+// 예시:
+//
+//	GEOHASH Sicily Palermo Catania → 1) "sqc8b49rny0" 2) "sqdtr74hyu0"
+type GeoHashHandler struct{}
+
+// Execute는 GEOHASH 명령어를 실행합니다.
+func (h *GeoHashHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "geohash"}
+	}
+
+	key := args[0]
+	if err := checkGeoKeyType(store, key); err != nil {
+		return nil, err
+	}
+
+	result := make(protocol.Array, 0, len(args)-1)
+	for _, member := range args[1:] {
+		lon, lat, ok := memberPosition(store, key, member)
+		if !ok {
+			result = append(result, nil)
+			continue
+		}
+		result = append(result, protocol.BulkString(geo.HashString(lon, lat)))
+	}
+
+	return result, nil
+}
+
+// checkGeoKeyType은 키가 존재한다면 Sorted Set 타입인지 확인합니다.
+func checkGeoKeyType(store *store.Store, key string) error {
+	if keyType := store.TYPE(key); keyType != "none" && keyType != "zset" {
+		return &WrongTypeError{}
+	}
+	return nil
+}
+
+// memberPosition은 Sorted Set 멤버의 점수를 디코딩하여 좌표를 반환합니다.
+func memberPosition(store *store.Store, key, member string) (float64, float64, bool) {
+	score, exists := store.ZSCORE(key, member)
+	if !exists {
+		return 0, 0, false
+	}
+	lon, lat := geo.Decode(uint64(score))
+	return lon, lat, true
+}
+
+// parseLonLat는 경도/위도 문자열을 파싱하고 범위를 검증합니다.
+func parseLonLat(lonStr, latStr string) (float64, float64, error) {
+	lon, err1 := strconv.ParseFloat(lonStr, 64)
+	lat, err2 := strconv.ParseFloat(latStr, 64)
+	if err1 != nil || err2 != nil {
+		return 0, 0, &InvalidArgumentError{Message: "value is not a valid float"}
+	}
+
+	if !geo.ValidCoordinates(lon, lat) {
+		return 0, 0, &InvalidArgumentError{
+			Message: fmt.Sprintf("invalid longitude,latitude pair %f,%f", lon, lat),
+		}
+	}
+	return lon, lat, nil
+}
+
+// parseGeoUnit는 거리 단위를 미터 기준 배율로 변환합니다.
+func parseGeoUnit(unit string) (float64, error) {
+	switch strings.ToLower(unit) {
+	case "m":
+		return 1, nil
+	case "km":
+		return 1000, nil
+	case "ft":
+		return 0.3048, nil
+	case "mi":
+		return 1609.34, nil
+	default:
+		return 0, &InvalidArgumentError{Message: "unsupported unit provided. please use M, KM, FT, MI"}
+	}
+}
+
+// formatCoordinate는 좌표를 Redis와 같은 형식(소수점 17자리, 뒤쪽 0 제거)으로 변환합니다.
+func formatCoordinate(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 17, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// geoSearchQuery는 GEOSEARCH/GEOSEARCHSTORE의 파싱된 검색 조건입니다.
+type geoSearchQuery struct {
+	// 검색 중심
+	fromMember string
+	hasMember  bool
+	centerLon  float64
+	centerLat  float64
+	hasLonLat  bool
+
+	// 검색 영역 (미터 단위)
+	radius    float64
+	width     float64
+	height    float64
+	byRadius  bool
+	byBox     bool
+	unit      float64 // 결과 거리 변환용 단위 배율
+	sortOrder int     // 0: 정렬 안 함, 1: ASC, -1: DESC
+
+	count     int // 0이면 제한 없음
+	any       bool
+	withCoord bool
+	withDist  bool
+	withHash  bool
+	storeDist bool
+}
+
+// geoSearchResult는 검색 조건에 맞는 멤버 하나입니다.
+type geoSearchResult struct {
+	member   string
+	score    float64
+	distance float64 // 미터 단위
+	lon, lat float64
+}
+
+// GeoSearchHandler는 GEOSEARCH 명령어를 처리하는 핸들러입니다.
+//
+// Redis GEOSEARCH 명령어 사양:
+//
+//	GEOSEARCH key <FROMMEMBER member | FROMLONLAT longitude latitude>
+//	  <BYRADIUS radius <M|KM|FT|MI> | BYBOX width height <M|KM|FT|MI>>
+//	  [ASC|DESC] [COUNT count [ANY]] [WITHCOORD] [WITHDIST] [WITHHASH]
+//
+// 응답 형식:
+//   - WITH 옵션이 없으면 멤버 이름 배열
+//   - WITH 옵션이 있으면 [멤버, 거리?, 해시?, [경도, 위도]?] 배열의 배열
+//
+// 예시:
+//
+//	GEOSEARCH Sicily FROMLONLAT 15 37 BYRADIUS 200 km ASC WITHDIST
+//	→ 1) 1) "Catania"
+//	      2) "56.4413"
+//	   2) 1) "Palermo"
+//	      2) "190.4424"
+//
+// 시간 복잡도: O(N) (N은 Sorted Set의 멤버 수)
+type GeoSearchHandler struct{}
+
+// Execute는 GEOSEARCH 명령어를 실행합니다.
+func (h *GeoSearchHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "geosearch"}
+	}
+
+	query, err := parseGeoSearch(args[1:], false)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := runGeoSearch(store, args[0], query)
+	if err != nil {
+		return nil, err
+	}
+
+	// WITH 옵션이 없으면 멤버 이름만 반환
+	if !query.withCoord && !query.withDist && !query.withHash {
+		members := make([]string, len(results))
+		for i, r := range results {
+			members[i] = r.member
+		}
+		return protocol.BulkStrings(members), nil
+	}
+
+	reply := make(protocol.Array, len(results))
+	for i, r := range results {
+		item := protocol.Array{protocol.BulkString(r.member)}
+		if query.withDist {
+			item = append(item, protocol.BulkString(strconv.FormatFloat(r.distance/query.unit, 'f', 4, 64)))
+		}
+		if query.withHash {
+			item = append(item, protocol.Integer(r.score))
+		}
+		if query.withCoord {
+			item = append(item, protocol.BulkStrings{formatCoordinate(r.lon), formatCoordinate(r.lat)})
+		}
+		reply[i] = item
+	}
+	return reply, nil
+}
+
+// GeoSearchStoreHandler는 GEOSEARCHSTORE 명령어를 처리하는 핸들러입니다.
+//
+// Redis GEOSEARCHSTORE 명령어 사양:
+//
+//	GEOSEARCHSTORE destination source <FROMMEMBER ... | FROMLONLAT ...>
+//	  <BYRADIUS ... | BYBOX ...> [ASC|DESC] [COUNT count [ANY]] [STOREDIST]
+//
+// 동작 방식:
+//   - 검색 결과를 destination Sorted Set에 저장 (기존 값은 덮어씀)
+//   - 기본적으로 원래의 geohash 점수를 저장하므로 결과도 Geo 키로 사용 가능
+//   - STOREDIST: 점수 대신 중심과의 거리(지정 단위)를 저장
+//   - 결과가 없으면 destination을 삭제
+//
+// 반환값: 저장된 멤버 수
+type GeoSearchStoreHandler struct{}
+
+// Execute는 GEOSEARCHSTORE 명령어를 실행합니다.
+func (h *GeoSearchStoreHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 2 {
+		return nil, &WrongNumberOfArgumentsError{Command: "geosearchstore"}
+	}
+
+	destKey := args[0]
+	query, err := parseGeoSearch(args[2:], true)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := runGeoSearch(store, args[1], query)
+	if err != nil {
+		return nil, err
+	}
+
+	store.DEL(destKey)
+	for _, r := range results {
+		score := r.score
+		if query.storeDist {
+			score = r.distance / query.unit
+		}
+		store.ZADD(destKey, score, r.member)
+	}
+
+	return protocol.Integer(len(results)), nil
+}
+
+// parseGeoSearch는 GEOSEARCH 계열 명령어의 검색 옵션을 파싱합니다.
+//
+// 매개변수:
+//   - args: 키 이름 이후의 인자들
+//   - isStore: GEOSEARCHSTORE이면 true (WITH 옵션 대신 STOREDIST 허용)
+func parseGeoSearch(args []string, isStore bool) (*geoSearchQuery, error) {
+	syntaxErr := &InvalidArgumentError{Message: "syntax error"}
+	query := &geoSearchQuery{unit: 1}
+
+	for i := 0; i < len(args); i++ {
+		remaining := len(args) - i - 1
+
+		switch option := strings.ToUpper(args[i]); {
+		case option == "FROMMEMBER" && remaining >= 1:
+			if query.hasMember || query.hasLonLat {
+				return nil, syntaxErr
+			}
+			query.fromMember = args[i+1]
+			query.hasMember = true
+			i++
+
+		case option == "FROMLONLAT" && remaining >= 2:
+			if query.hasMember || query.hasLonLat {
+				return nil, syntaxErr
+			}
+			lon, lat, err := parseLonLat(args[i+1], args[i+2])
+			if err != nil {
+				return nil, err
+			}
+			query.centerLon, query.centerLat = lon, lat
+			query.hasLonLat = true
+			i += 2
+
+		case option == "BYRADIUS" && remaining >= 2:
+			if query.byRadius || query.byBox {
+				return nil, syntaxErr
+			}
+			radius, err := parseGeoLength(args[i+1], "radius")
+			if err != nil {
+				return nil, err
+			}
+			if query.unit, err = parseGeoUnit(args[i+2]); err != nil {
+				return nil, err
+			}
+			query.radius = radius * query.unit
+			query.byRadius = true
+			i += 2
+
+		case option == "BYBOX" && remaining >= 3:
+			if query.byRadius || query.byBox {
+				return nil, syntaxErr
+			}
+			width, err := parseGeoLength(args[i+1], "width")
+			if err != nil {
+				return nil, err
+			}
+			height, err := parseGeoLength(args[i+2], "height")
+			if err != nil {
+				return nil, err
+			}
+			if query.unit, err = parseGeoUnit(args[i+3]); err != nil {
+				return nil, err
+			}
+			query.width, query.height = width*query.unit, height*query.unit
+			query.byBox = true
+			i += 3
+
+		case option == "ASC":
+			query.sortOrder = 1
+
+		case option == "DESC":
+			query.sortOrder = -1
+
+		case option == "COUNT" && remaining >= 1:
+			count, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				return nil, &InvalidArgumentError{Message: "value is not an integer or out of range"}
+			}
+			if count <= 0 {
+				return nil, &InvalidArgumentError{Message: "COUNT must be > 0"}
+			}
+			query.count = count
+			i++
+
+		case option == "ANY":
+			query.any = true
+
+		case option == "WITHCOORD" && !isStore:
+			query.withCoord = true
+
+		case option == "WITHDIST" && !isStore:
+			query.withDist = true
+
+		case option == "WITHHASH" && !isStore:
+			query.withHash = true
+
+		case option == "STOREDIST" && isStore:
+			query.storeDist = true
+
+		default:
+			return nil, syntaxErr
+		}
+	}
+
+	if query.hasMember == query.hasLonLat {
+		return nil, &InvalidArgumentError{
+			Message: "exactly one of FROMMEMBER or FROMLONLAT can be specified for GEOSEARCH",
+		}
+	}
+	if query.byRadius == query.byBox {
+		return nil, &InvalidArgumentError{
+			Message: "exactly one of BYRADIUS and BYBOX can be specified for GEOSEARCH",
+		}
+	}
+	if query.any && query.count == 0 {
+		return nil, &InvalidArgumentError{Message: "the ANY argument requires COUNT argument"}
+	}
+
+	return query, nil
+}
+
+// runGeoSearch는 Sorted Set의 모든 멤버를 검사하여 검색 영역 안의 멤버들을 찾습니다.
+//
+// 정렬 규칙 (Redis와 동일):
+//   - ASC/DESC가 지정되면 거리 순으로 정렬
+//   - COUNT만 지정되고 ANY가 없으면 가장 가까운 멤버부터 (ASC)
+//   - COUNT ANY는 먼저 찾은 count개만 반환 (정렬 옵션은 그 결과에만 적용)
+func runGeoSearch(store *store.Store, key string, query *geoSearchQuery) ([]geoSearchResult, error) {
+	if err := checkGeoKeyType(store, key); err != nil {
+		return nil, err
+	}
+
+	if query.hasMember {
+		lon, lat, ok := memberPosition(store, key, query.fromMember)
+		if !ok {
+			return nil, &InvalidArgumentError{Message: "could not decode requested zset member"}
+		}
+		query.centerLon, query.centerLat = lon, lat
+	}
+
+	results := []geoSearchResult{}
+	for _, entry := range store.ZRANGE(key, 0, -1) {
+		lon, lat := geo.Decode(uint64(entry.Score))
+
+		var distance float64
+		inside := false
+		if query.byRadius {
+			distance = geo.Distance(query.centerLon, query.centerLat, lon, lat)
+			inside = distance <= query.radius
+		} else {
+			distance, inside = geo.DistanceInBox(query.width, query.height, query.centerLon, query.centerLat, lon, lat)
+		}
+		if !inside {
+			continue
+		}
+
+		results = append(results, geoSearchResult{
+			member:   entry.Member,
+			score:    entry.Score,
+			distance: distance,
+			lon:      lon,
+			lat:      lat,
+		})
+
+		if query.any && len(results) == query.count {
+			break
+		}
+	}
+
+	sortOrder := query.sortOrder
+	if sortOrder == 0 && query.count > 0 && !query.any {
+		sortOrder = 1
+	}
+	if sortOrder != 0 {
+		sort.SliceStable(results, func(i, j int) bool {
+			if sortOrder > 0 {
+				return results[i].distance < results[j].distance
+			}
+			return results[i].distance > results[j].distance
+		})
+	}
+
+	if query.count > 0 && len(results) > query.count {
+		results = results[:query.count]
+	}
+	return results, nil
+}
+
+// parseGeoLength는 반경/너비/높이 값을 파싱합니다 (음수 불가).
+func parseGeoLength(s, name string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, &InvalidArgumentError{Message: "need numeric " + name}
+	}
+	if v < 0 {
+		return 0, &InvalidArgumentError{Message: name + " cannot be negative"}
+	}
+	return v, nil
+}
@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// setupSicily는 Redis 문서 예시와 같은 Geo 데이터를 준비합니다.
+func setupSicily(t *testing.T) *store.Store {
+	t.Helper()
+	dataStore := store.NewStore()
+	_, err := (&GeoAddHandler{}).Execute([]string{
+		"Sicily", "13.361389", "38.115556", "Palermo", "15.087269", "37.502669", "Catania",
+	}, dataStore)
+	if err != nil {
+		t.Fatalf("GEOADD setup failed: %v", err)
+	}
+	return dataStore
+}
+
+// TestGeoAddHandler는 GEOADD 명령어 핸들러를 테스트합니다.
+func TestGeoAddHandler(t *testing.T) {
+	handler := &GeoAddHandler{}
+	dataStore := store.NewStore()
+
+	// 테스트 케이스 1: 새 멤버 추가
+	result, err := handler.Execute([]string{"Sicily", "13.361389", "38.115556", "Palermo", "15.087269", "37.502669", "Catania"}, dataStore)
+	if err != nil {
+		t.Fatalf("GEOADD failed: %v", err)
+	}
+	if result != protocol.Integer(2) {
+		t.Errorf("Expected 2, got %v", result)
+	}
+
+	// Redis와 동일한 점수로 저장되는지 확인
+	if score, _ := dataStore.ZSCORE("Sicily", "Palermo"); score != 3479099956230698 {
+		t.Errorf("Expected score 3479099956230698, got %f", score)
+	}
+
+	// 테스트 케이스 2: 기존 멤버 위치 갱신 (CH 없음 → 0)
+	result, err = handler.Execute([]string{"Sicily", "13.5", "38.2", "Palermo"}, dataStore)
+	if err != nil {
+		t.Fatalf("GEOADD update failed: %v", err)
+	}
+	if result != protocol.Integer(0) {
+		t.Errorf("Expected 0, got %v", result)
+	}
+
+	// 테스트 케이스 3: CH 옵션 → 변경된 개수
+	result, _ = handler.Execute([]string{"Sicily", "CH", "13.361389", "38.115556", "Palermo"}, dataStore)
+	if result != protocol.Integer(1) {
+		t.Errorf("Expected 1 with CH, got %v", result)
+	}
+
+	// 테스트 케이스 4: NX 옵션 → 기존 멤버 무시
+	result, _ = handler.Execute([]string{"Sicily", "NX", "CH", "0", "0", "Palermo", "12.5", "41.9", "Rome"}, dataStore)
+	if result != protocol.Integer(1) {
+		t.Errorf("Expected 1 with NX CH, got %v", result)
+	}
+
+	// 테스트 케이스 5: XX 옵션 → 새 멤버 무시
+	result, _ = handler.Execute([]string{"Sicily", "XX", "1", "1", "Milan"}, dataStore)
+	if result != protocol.Integer(0) {
+		t.Errorf("Expected 0 with XX, got %v", result)
+	}
+	if dataStore.ZCARD("Sicily") != 3 {
+		t.Errorf("Expected 3 members, got %d", dataStore.ZCARD("Sicily"))
+	}
+
+	// 에러 케이스들
+	errorCases := [][]string{
+		{"Sicily", "200", "38", "Invalid"},      // 경도 범위 초과
+		{"Sicily", "13", "86", "Invalid"},       // 위도 범위 초과
+		{"Sicily", "abc", "38", "Invalid"},      // 숫자가 아닌 좌표
+		{"Sicily", "13", "38", "A", "14"},       // 3의 배수가 아닌 인자
+		{"Sicily", "NX", "XX", "13", "38", "A"}, // NX와 XX 동시 사용
+	}
+	for _, args := range errorCases {
+		if _, err := handler.Execute(args, dataStore); err == nil {
+			t.Errorf("Expected error for args %v", args)
+		}
+	}
+
+	// 에러 케이스: 다른 타입의 키
+	dataStore.SET("plain", []byte("value"), nil)
+	if _, err := handler.Execute([]string{"plain", "13", "38", "A"}, dataStore); err == nil {
+		t.Error("Expected WRONGTYPE error for string key")
+	}
+}
+
+// TestGeoPosHandler는 GEOPOS 명령어 핸들러를 테스트합니다.
+func TestGeoPosHandler(t *testing.T) {
+	handler := &GeoPosHandler{}
+	dataStore := setupSicily(t)
+
+	result, err := handler.Execute([]string{"Sicily", "Palermo", "NonExisting"}, dataStore)
+	if err != nil {
+		t.Fatalf("GEOPOS failed: %v", err)
+	}
+
+	positions, ok := result.(protocol.Array)
+	if !ok || len(positions) != 2 {
+		t.Fatalf("Expected 2 positions, got %v", result)
+	}
+
+	expected := []string{"13.36138933897018433", "38.11555639549629859"}
+	if pos, ok := positions[0].(protocol.BulkStrings); !ok || !equalStringSlices(pos, expected) {
+		t.Errorf("Expected %v, got %v", expected, positions[0])
+	}
+	if _, ok := positions[1].(protocol.NullArray); !ok {
+		t.Errorf("Expected NullArray for missing member, got %v", positions[1])
+	}
+}
+
+// TestGeoDistHandler는 GEODIST 명령어 핸들러를 테스트합니다.
+func TestGeoDistHandler(t *testing.T) {
+	handler := &GeoDistHandler{}
+	dataStore := setupSicily(t)
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected protocol.Reply
+	}{
+		{"기본 단위 (미터)", []string{"Sicily", "Palermo", "Catania"}, protocol.BulkString("166274.1516")},
+		{"킬로미터", []string{"Sicily", "Palermo", "Catania", "km"}, protocol.BulkString("166.2742")},
+		{"마일", []string{"Sicily", "Palermo", "Catania", "MI"}, protocol.BulkString("103.3182")},
+		{"피트", []string{"Sicily", "Palermo", "Catania", "ft"}, protocol.BulkString("545518.8700")},
+		{"없는 멤버", []string{"Sicily", "Palermo", "Nowhere"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.Execute(tt.args, dataStore)
+			if err != nil {
+				t.Fatalf("GEODIST failed: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// 에러 케이스: 지원하지 않는 단위
+	if _, err := handler.Execute([]string{"Sicily", "Palermo", "Catania", "yards"}, dataStore); err == nil {
+		t.Error("Expected error for unsupported unit")
+	}
+}
+
+// TestGeoHashHandler는 GEOHASH 명령어 핸들러를 테스트합니다.
+func TestGeoHashHandler(t *testing.T) {
+	handler := &GeoHashHandler{}
+	dataStore := setupSicily(t)
+
+	result, err := handler.Execute([]string{"Sicily", "Palermo", "Catania", "Nowhere"}, dataStore)
+	if err != nil {
+		t.Fatalf("GEOHASH failed: %v", err)
+	}
+
+	hashes, ok := result.(protocol.Array)
+	if !ok || len(hashes) != 3 {
+		t.Fatalf("Expected 3 hashes, got %v", result)
+	}
+	if hashes[0] != protocol.BulkString("sqc8b49rny0") || hashes[1] != protocol.BulkString("sqdtr74hyu0") || hashes[2] != nil {
+		t.Errorf("Expected [sqc8b49rny0 sqdtr74hyu0 <nil>], got %v", hashes)
+	}
+}
+
+// TestGeoSearchHandler는 GEOSEARCH 명령어 핸들러를 테스트합니다.
+func TestGeoSearchHandler(t *testing.T) {
+	handler := &GeoSearchHandler{}
+	dataStore := setupSicily(t)
+	(&GeoAddHandler{}).Execute([]string{"Sicily", "12.758489", "38.788135", "edge1", "17.241510", "38.788135", "edge2"}, dataStore)
+
+	// 테스트 케이스 1: FROMLONLAT + BYRADIUS + ASC
+	result, err := handler.Execute([]string{"Sicily", "FROMLONLAT", "15", "37", "BYRADIUS", "200", "km", "ASC"}, dataStore)
+	if err != nil {
+		t.Fatalf("GEOSEARCH failed: %v", err)
+	}
+	expected := []string{"Catania", "Palermo"}
+	if members, ok := result.(protocol.BulkStrings); !ok || !equalStringSlices(members, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// 테스트 케이스 2: BYBOX + DESC + WITHDIST (Redis 문서 예시)
+	result, err = handler.Execute([]string{"Sicily", "FROMLONLAT", "15", "37", "BYBOX", "400", "400", "km", "DESC", "WITHDIST"}, dataStore)
+	if err != nil {
+		t.Fatalf("GEOSEARCH BYBOX failed: %v", err)
+	}
+	items, ok := result.(protocol.Array)
+	if !ok || len(items) != 4 {
+		t.Fatalf("Expected 4 items, got %v", result)
+	}
+	first := items[0].(protocol.Array)
+	if first[0] != protocol.BulkString("edge1") || first[1] != protocol.BulkString("279.7405") {
+		t.Errorf("Expected [edge1 279.7405], got %v", first)
+	}
+	last := items[3].(protocol.Array)
+	if last[0] != protocol.BulkString("Catania") || last[1] != protocol.BulkString("56.4413") {
+		t.Errorf("Expected [Catania 56.4413], got %v", last)
+	}
+
+	// 테스트 케이스 3: FROMMEMBER + COUNT + WITHCOORD WITHHASH
+	result, err = handler.Execute([]string{"Sicily", "FROMMEMBER", "Palermo", "BYRADIUS", "500", "km", "COUNT", "1", "WITHHASH", "WITHCOORD"}, dataStore)
+	if err != nil {
+		t.Fatalf("GEOSEARCH FROMMEMBER failed: %v", err)
+	}
+	items = result.(protocol.Array)
+	if len(items) != 1 {
+		t.Fatalf("Expected 1 item, got %v", items)
+	}
+	item := items[0].(protocol.Array)
+	if item[0] != protocol.BulkString("Palermo") || item[1] != protocol.Integer(3479099956230698) {
+		t.Errorf("Expected [Palermo 3479099956230698 ...], got %v", item)
+	}
+	coord := item[2].(protocol.BulkStrings)
+	if !equalStringSlices(coord, []string{"13.36138933897018433", "38.11555639549629859"}) {
+		t.Errorf("Unexpected coordinates %v", coord)
+	}
+
+	// 테스트 케이스 4: 존재하지 않는 키 → 빈 결과
+	result, err = handler.Execute([]string{"nokey", "FROMLONLAT", "15", "37", "BYRADIUS", "10", "km"}, dataStore)
+	if err != nil {
+		t.Fatalf("GEOSEARCH on missing key failed: %v", err)
+	}
+	if members, ok := result.(protocol.BulkStrings); !ok || len(members) != 0 {
+		t.Errorf("Expected empty result, got %v", result)
+	}
+
+	// 에러 케이스들
+	errorCases := [][]string{
+		{"Sicily", "BYRADIUS", "10", "km"},                                         // FROM 없음
+		{"Sicily", "FROMLONLAT", "15", "37"},                                       // BY 없음
+		{"Sicily", "FROMMEMBER", "Nowhere", "BYRADIUS", "10", "km"},                // 없는 멤버
+		{"Sicily", "FROMLONLAT", "15", "37", "BYRADIUS", "10", "km", "ANY"},        // COUNT 없는 ANY
+		{"Sicily", "FROMLONLAT", "15", "37", "BYRADIUS", "10", "km", "COUNT", "0"}, // COUNT 0
+		{"Sicily", "FROMLONLAT", "15", "37", "BYRADIUS", "10", "km", "STOREDIST"},  // GEOSEARCH에서 STOREDIST
+		{"Sicily", "FROMLONLAT", "15", "37", "BYRADIUS", "10", "parsecs"},          // 잘못된 단위
+	}
+	for _, args := range errorCases {
+		if _, err := handler.Execute(args, dataStore); err == nil {
+			t.Errorf("Expected error for args %v", args)
+		}
+	}
+}
+
+// TestGeoSearchStoreHandler는 GEOSEARCHSTORE 명령어 핸들러를 테스트합니다.
+func TestGeoSearchStoreHandler(t *testing.T) {
+	handler := &GeoSearchStoreHandler{}
+	dataStore := setupSicily(t)
+
+	// 테스트 케이스 1: geohash 점수 그대로 저장
+	result, err := handler.Execute([]string{"dest", "Sicily", "FROMLONLAT", "15", "37", "BYRADIUS", "200", "km"}, dataStore)
+	if err != nil {
+		t.Fatalf("GEOSEARCHSTORE failed: %v", err)
+	}
+	if result != protocol.Integer(2) {
+		t.Errorf("Expected 2, got %v", result)
+	}
+	if score, _ := dataStore.ZSCORE("dest", "Palermo"); score != 3479099956230698 {
+		t.Errorf("Expected geohash score, got %f", score)
+	}
+
+	// 테스트 케이스 2: STOREDIST → 거리 저장
+	result, err = handler.Execute([]string{"dist", "Sicily", "FROMLONLAT", "15", "37", "BYRADIUS", "100", "km", "STOREDIST"}, dataStore)
+	if err != nil {
+		t.Fatalf("GEOSEARCHSTORE STOREDIST failed: %v", err)
+	}
+	if result != protocol.Integer(1) {
+		t.Errorf("Expected 1, got %v", result)
+	}
+	if score, _ := dataStore.ZSCORE("dist", "Catania"); score < 56.44 || score > 56.45 {
+		t.Errorf("Expected distance ~56.4413, got %f", score)
+	}
+
+	// 테스트 케이스 3: 결과가 없으면 destination 삭제
+	result, _ = handler.Execute([]string{"dist", "Sicily", "FROMLONLAT", "0", "0", "BYRADIUS", "1", "km"}, dataStore)
+	if result != protocol.Integer(0) {
+		t.Errorf("Expected 0, got %v", result)
+	}
+	if dataStore.TYPE("dist") != "none" {
+		t.Error("Expected destination to be deleted")
+	}
+
+	// 에러 케이스: GEOSEARCHSTORE에서 WITH 옵션
+	if _, err := handler.Execute([]string{"dest", "Sicily", "FROMLONLAT", "15", "37", "BYRADIUS", "10", "km", "WITHDIST"}, dataStore); err == nil {
+		t.Error("Expected syntax error for WITHDIST")
+	}
+}
@@ -12,14 +12,14 @@ func TestGetHandler(t *testing.T) {
 	dataStore := store.NewStore()
 
 	// 테스트 데이터 설정
-	dataStore.SET("existingkey", "existingvalue", nil)
+	dataStore.SET("existingkey", []byte("existingvalue"), nil)
 
 	// 테스트 케이스 1: 존재하는 키 조회
 	result, err := handler.Execute([]string{"existingkey"}, dataStore)
 	if err != nil {
 		t.Fatalf("GET failed: %v", err)
 	}
-	if result != "existingvalue" {
+	if !isBulk(result, "existingvalue") {
 		t.Errorf("Expected 'existingvalue', got %v", result)
 	}
 
@@ -9,8 +9,18 @@
 package handler
 
 import (
+	"context"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/pubsub"
 	"github.com/codecrafters-io/redis-starter-go/store"
 )
 
@@ -19,13 +29,14 @@ import (
 // 인터페이스 설계 원칙:
 //   - 단순함: Execute 메서드 하나만 정의
 //   - 일관성: 모든 핸들러가 같은 시그니처 사용
-//   - 유연성: interface{} 반환으로 다양한 타입 지원
+//   - 명확성: 결과는 protocol의 구체적인 응답 타입으로 반환
 //
-// 반환값 타입:
-//   - string: Simple String이나 Bulk String으로 응답
-//   - int: Integer로 응답
-//   - []string: Array로 응답
-//   - nil: Null Bulk String으로 응답
+// 반환값 타입 (protocol.Reply 참고):
+//   - protocol.SimpleString: 상태 응답 (OK, PONG 등)
+//   - protocol.BulkString: 사용자 데이터
+//   - protocol.Integer: 정수 응답
+//   - protocol.BulkStrings, protocol.Array: 배열 응답
+//   - nil, protocol.Null: 값이 없음
 //   - error: 에러 응답
 type CommandHandler interface {
 	// Execute는 명령어를 실행하고 결과를 반환합니다.
@@ -36,9 +47,9 @@ type CommandHandler interface {
 	//   - store: 데이터 저장소 인스턴스
 	//
 	// 반환값:
-	//   - interface{}: 명령어 실행 결과 (타입에 따라 적절한 RESP 형식으로 변환됨)
+	//   - protocol.Reply: 명령어 실행 결과 (연결의 RESP 버전에 맞는 형식으로 작성됨)
 	//   - error: 실행 중 발생한 에러
-	Execute(args []string, store *store.Store) (interface{}, error)
+	Execute(args []string, store *store.Store) (protocol.Reply, error)
 }
 
 // CommandRegistry는 명령어와 해당 핸들러를 매핑하고 관리하는 구조체입니다.
@@ -56,6 +67,122 @@ type CommandRegistry struct {
 	// store는 모든 핸들러가 공유하는 데이터 저장소입니다.
 	// 각 핸들러 실행 시 전달됩니다.
 	store *store.Store
+
+	// broker는 Pub/Sub 채널 구독을 관리합니다.
+	// SUBSCRIBE/PUBLISH 계열 핸들러가 공유합니다.
+	broker *pubsub.Broker
+
+	// nextClientID는 마지막으로 발급한 클라이언트 ID입니다.
+	nextClientID int64
+
+	// clients는 현재 연결된 클라이언트들입니다 (ID → Client).
+	// CLIENT TRACKING REDIRECT처럼 다른 연결을 ID로 찾을 때 사용합니다.
+	clientsMu sync.RWMutex
+	clients   map[int64]*Client
+
+	// tracking은 클라이언트 측 캐싱을 위한 키 추적 테이블입니다.
+	tracking *trackingTable
+
+	// execMu는 트랜잭션(EXEC)과 스크립트(EVAL)의 원자적 실행을 보장합니다.
+	// 일반 명령어는 공유 잠금을, EXEC/EVAL은 배타 잠금을 잡고 실행하므로
+	// 실행 중에는 다른 클라이언트의 명령어가 끼어들 수 없습니다.
+	execMu sync.RWMutex
+
+	// scripts는 EVAL/EVALSHA와 FCALL이 공유하는 Lua 실행 환경과 스크립트 캐시, 함수 라이브러리입니다.
+	scripts *scriptEngine
+
+	// persistence는 SAVE/BGSAVE/BGREWRITEAOF가 사용하는 RDB, AOF 설정과 상태입니다.
+	persistence *persistence
+
+	// replication은 복제 설정과 상태입니다 (레플리카 모드의 마스터 연결 등).
+	replication *replication
+
+	// cluster는 클러스터 모드 설정과 상태입니다 (노드 ID, 슬롯 배정).
+	cluster *cluster
+
+	// stats는 INFO stats 섹션의 누적 통계이고, commandStats는 명령어별 통계입니다
+	// (INFO commandstats, latencystats, CONFIG RESETSTAT으로 초기화).
+	stats        stats
+	commandStats commandStats
+
+	// started는 레지스트리를 만든 시각이고 (uptime_in_seconds), runID는 실행마다 새로 만드는 서버 ID입니다 (run_id).
+	// statsCollectorOnce는 순간 통계의 표본을 모으는 고루틴을 한 번만 시작합니다 (StartStatsCollector).
+	started            time.Time
+	runID              string
+	statsCollectorOnce sync.Once
+
+	// memory는 MEMORY STATS가 보고하는 시작 시점과 최대 메모리 사용량입니다.
+	memory memoryStats
+
+	// latency는 latency-monitor-threshold 이상 걸린 작업의 기록입니다 (LATENCY).
+	latency *latencyMonitor
+
+	// protoMaxBulkLen은 요청의 Bulk String 하나의 최대 길이입니다 (proto-max-bulk-len).
+	protoMaxBulkLen atomic.Int64
+
+	// maxClients는 동시에 연결할 수 있는 네트워크 연결 수입니다 (maxclients).
+	maxClients atomic.Int64
+
+	// maxMemory는 데이터셋이 사용할 수 있는 메모리의 한도입니다 (maxmemory, 바이트, 0이면 제한 없음).
+	// maxMemoryPolicy는 evictionPolicies의 인덱스이고 (maxmemory-policy),
+	// maxMemorySamples는 지울 키를 고를 때 확인하는 키 수입니다 (maxmemory-samples).
+	// evictMu는 한 번에 한 연결만 키를 지우게 합니다.
+	maxMemory        atomic.Int64
+	maxMemoryPolicy  atomic.Int32
+	maxMemorySamples atomic.Int64
+	evictMu          sync.Mutex
+
+	// clientTimeout은 쉬고 있는 연결을 끊기까지의 시간이고 (timeout, 0이면 끊지 않음),
+	// clientsCronOnce는 연결을 확인하는 고루틴을 한 번만 시작합니다 (SetClientTimeout).
+	// tcpKeepAlive는 새 연결의 TCP keepalive 주기입니다 (tcp-keepalive, 0이면 끔).
+	clientTimeout   atomic.Int64
+	clientsCronOnce sync.Once
+	tcpKeepAlive    atomic.Int64
+
+	// configFile은 서버가 불러온 설정 파일 경로입니다 (CONFIG REWRITE가 기록, 없으면 빈 문자열).
+	configMu   sync.Mutex
+	configFile string
+	// configReload는 CONFIG RELOAD가 설정을 다시 읽어 적용하는 함수입니다 (없으면 nil).
+	configReload func() error
+
+	// requirePass는 연결이 AUTH로 보내야 하는 비밀번호입니다 (없으면 빈 문자열).
+	authMu      sync.Mutex
+	requirePass string
+
+	// activeExpireOnce는 만료된 키를 지우는 고루틴을 한 번만 시작하고 (StartActiveExpire),
+	// activeExpireOff이면 그 고루틴이 아무것도 하지 않습니다 (DEBUG SET-ACTIVE-EXPIRE 0).
+	activeExpireOnce sync.Once
+	activeExpireOff  atomic.Bool
+
+	// lruClockOnce는 저장소의 LRU 시계를 맞추는 고루틴을 한 번만 시작합니다 (StartLRUClock).
+	lruClockOnce sync.Once
+
+	// logger는 서버의 로거이고 (SetLogOutput), logLevel은 기록할 최소 수준입니다 (loglevel).
+	// logFile은 로그를 기록하는 파일 경로입니다 (logfile, 표준 출력이면 빈 문자열).
+	logger   atomic.Pointer[slog.Logger]
+	logLevel slog.LevelVar
+	logMu    sync.Mutex
+	logFile  string
+
+	// propagateHooks는 쓰기 명령어를 전달받는 함수들입니다 (OnPropagate).
+	// propagateDepth와 propagateBatch는 트랜잭션과 스크립트 안의 쓰기 명령어를 모으는 데 사용합니다.
+	propagateHooks []func(commands [][]string)
+	propagateDepth int
+	propagateBatch [][]string
+
+	// renames는 rename-command로 바꾼 명령어 이름입니다 (RenameCommand).
+	// 클라이언트가 보내는 이름에서 원래 이름으로의 대응이며, 끈 명령어의 원래 이름은 빈 문자열에 대응합니다.
+	renames map[string]string
+
+	// beforeHooks와 afterHooks는 명령어 실행 전후에 호출되는 함수들입니다 (OnBeforeCommand, OnAfterCommand).
+	beforeHooks []BeforeCommandFunc
+	afterHooks  []AfterCommandFunc
+
+	// ctx는 Shutdown에서 취소되어 백그라운드 고루틴들(만료된 키 정리, 자동 저장 등)을 멈춥니다.
+	// shuttingDown은 Shutdown이 호출되었는지 여부입니다 (ShuttingDown).
+	ctx          context.Context
+	cancel       context.CancelFunc
+	shuttingDown atomic.Bool
 }
 
 // NewCommandRegistry는 새로운 CommandRegistry 인스턴스를 생성하고
@@ -72,27 +199,153 @@ type CommandRegistry struct {
 // 반환값:
 //   - *CommandRegistry: 설정된 레지스트리 인스턴스
 func NewCommandRegistry(store *store.Store) *CommandRegistry {
+	ctx, cancel := context.WithCancel(context.Background())
 	registry := &CommandRegistry{
-		handlers: make(map[string]CommandHandler),
-		store:    store,
+		handlers:    make(map[string]CommandHandler),
+		store:       store,
+		broker:      pubsub.NewBroker(),
+		clients:     make(map[int64]*Client),
+		persistence: newPersistence(),
+		replication: newReplication(ctx),
+		cluster:     newCluster(),
+		latency:     newLatencyMonitor(),
+		started:     time.Now(),
+		runID:       newReplID(),
+		ctx:         ctx,
+		cancel:      cancel,
 	}
+	registry.protoMaxBulkLen.Store(protocol.DefaultMaxBulkLen)
+	registry.maxClients.Store(DefaultMaxClients)
+	registry.tcpKeepAlive.Store(int64(DefaultTCPKeepAlive))
+	registry.maxMemorySamples.Store(DefaultMaxMemorySamples)
+	registry.SetLogOutput(os.Stdout)
+	registry.tracking = newTrackingTable(registry.broker, registry.lookupClient)
+	registry.scripts = newScriptEngine(registry)
+	store.OnKeyModified(registry.tracking.invalidate)
+	store.OnKeyModified(registry.persistence.keyModified)
+	store.OnKeyExpired(registry.propagateExpired)
+	store.OnKeyExpired(registry.stats.keyExpired)
+	registry.OnPropagate(registry.replication.feed)
+	registry.memory.startup, _ = registry.memory.usedMemory()
 
-	// 기본 명령어 핸들러들 등록
+	// commands.json의 내장 명령어마다 핸들러 등록
 	// 각 핸들러는 해당 명령어의 비즈니스 로직을 캡슐화합니다.
-	registry.Register("PING", &PingHandler{})     // 연결 테스트
-	registry.Register("ECHO", &EchoHandler{})     // 메시지 에코
-	registry.Register("SET", &SetHandler{})       // 키-값 저장
-	registry.Register("GET", &GetHandler{})       // 키로 값 조회
-	registry.Register("RPUSH", &RPushHandler{})   // 리스트 끝에 추가
-	registry.Register("LPUSH", &LPushHandler{})   // 리스트 앞에 추가
-	registry.Register("LRANGE", &LRangeHandler{}) // 리스트 범위 조회
-	registry.Register("LLEN", &LLenHandler{})     // 리스트 길이 조회
-	registry.Register("LPOP", &LPopHandler{})     // 리스트 앞에서 제거
-	registry.Register("BLPOP", &BLPopHandler{})   // Blocking 리스트 앞에서 제거
+	handlers := builtinHandlers(registry)
+	for name := range commandTable {
+		handler, ok := handlers[name]
+		if !ok {
+			panic("handler: no handler for command " + name + " in commands.json")
+		}
+		registry.Register(name, handler)
+	}
 
 	return registry
 }
 
+// builtinHandlers는 내장 명령어의 핸들러들을 만듭니다.
+// 등록할 명령어 목록과 메타데이터는 commands.json(commandTable)이 정하며,
+// 이 함수는 명령어 이름에 해당하는 구현만 제공합니다.
+func builtinHandlers(registry *CommandRegistry) map[string]CommandHandler {
+	return map[string]CommandHandler{
+		// 문자열, 리스트 명령어
+		"PING":   &PingHandler{},   // 연결 테스트
+		"ECHO":   &EchoHandler{},   // 메시지 에코
+		"SET":    &SetHandler{},    // 키-값 저장
+		"GET":    &GetHandler{},    // 키로 값 조회
+		"APPEND": &AppendHandler{}, // 문자열 값 끝에 덧붙이기
+		"RPUSH":  &RPushHandler{},  // 리스트 끝에 추가
+		"LPUSH":  &LPushHandler{},  // 리스트 앞에 추가
+		"LRANGE": &LRangeHandler{}, // 리스트 범위 조회
+		"LLEN":   &LLenHandler{},   // 리스트 길이 조회
+		"LPOP":   &LPopHandler{},   // 리스트 앞에서 제거
+		"BLPOP":  &BLPopHandler{},  // Blocking 리스트 앞에서 제거
+
+		// 연결 관리 명령어
+		"QUIT":   &QuitHandler{},                                                                          // 연결 종료
+		"RESET":  &ResetHandler{registry: registry, broker: registry.broker, tracking: registry.tracking}, // 연결 상태 초기화
+		"AUTH":   &AuthHandler{registry: registry},                                                        // 비밀번호 인증
+		"HELLO":  &HelloHandler{registry: registry},                                                       // RESP 버전 협상과 서버 정보 조회
+		"CLIENT": &ClientHandler{registry: registry, tracking: registry.tracking},                         // 연결 정보 조회 및 설정
+
+		// 트랜잭션 명령어
+		"MULTI":   &MultiHandler{},                  // 트랜잭션 시작
+		"EXEC":    &ExecHandler{registry: registry}, // 대기열 명령어 실행
+		"DISCARD": &DiscardHandler{},                // 트랜잭션 취소
+
+		// 스크립트 명령어
+		"EVAL":     &EvalHandler{scripts: registry.scripts},                  // Lua 스크립트 실행
+		"EVALSHA":  &EvalShaHandler{scripts: registry.scripts},               // 캐시된 스크립트 실행
+		"SCRIPT":   &ScriptHandler{scripts: registry.scripts},                // 스크립트 캐시 관리
+		"FUNCTION": &FunctionHandler{scripts: registry.scripts},              // 함수 라이브러리 관리
+		"FCALL":    &FCallHandler{scripts: registry.scripts},                 // 등록된 함수 실행
+		"FCALL_RO": &FCallHandler{scripts: registry.scripts, readOnly: true}, // 읽기 전용 함수 실행
+
+		// Sorted Set 명령어
+		"ZADD": &ZAddHandler{}, // 멤버 추가 및 점수 갱신
+
+		// 영속성 명령어
+		"SAVE":         &SaveHandler{persistence: registry.persistence},                                    // RDB 파일로 저장
+		"BGSAVE":       &BgSaveHandler{persistence: registry.persistence, latency: registry.latency},       // 백그라운드에서 RDB 파일로 저장
+		"BGREWRITEAOF": &BgRewriteAofHandler{persistence: registry.persistence, latency: registry.latency}, // 백그라운드에서 AOF 재작성
+		"LASTSAVE":     &LastSaveHandler{persistence: registry.persistence},                                // 마지막 저장 시각 조회
+
+		// 서버 관리 명령어
+		"INFO":    &InfoHandler{registry: registry},           // 서버 정보와 통계 조회
+		"CONFIG":  &ConfigHandler{registry: registry},         // 설정 조회, 설정 파일 기록, 통계 초기화
+		"COMMAND": &CommandCommandHandler{registry: registry}, // 명령어 정보 조회
+		"DEBUG":   &DebugHandler{registry: registry},          // 테스트용 서버 조작 (SLEEP, OBJECT 등)
+		"MEMORY":  &MemoryHandler{registry: registry},         // 키와 서버의 메모리 사용량 조회
+		"LATENCY": &LatencyHandler{latency: registry.latency}, // 지연 기록 조회와 분석
+
+		// 키 공간 명령어
+		"DEL":     &DelHandler{},                       // 키 삭제
+		"DUMP":    &DumpHandler{},                      // 값을 RDB 형식으로 직렬화
+		"RESTORE": &RestoreHandler{},                   // DUMP 페이로드로 키 생성
+		"MIGRATE": &MigrateHandler{registry: registry}, // 다른 서버로 키 옮기기
+		"OBJECT":  &ObjectHandler{registry: registry},  // 값의 인코딩과 사용 빈도 조회
+
+		// 복제 명령어
+		"REPLCONF":  &ReplConfHandler{},                    // 레플리카 핸드셰이크 설정
+		"PSYNC":     &PsyncHandler{registry: registry},     // 레플리카 동기화 시작
+		"REPLICAOF": &ReplicaOfHandler{registry: registry}, // 마스터 변경, 승격 (NO ONE)
+		"SLAVEOF":   &ReplicaOfHandler{registry: registry}, // REPLICAOF의 이전 이름
+		"FAILOVER":  &FailoverHandler{registry: registry},  // 레플리카에 역할을 넘기고 강등
+
+		// 클러스터 명령어
+		"CLUSTER": &ClusterHandler{cluster: registry.cluster}, // 클러스터 상태 조회 및 설정
+		"ASKING":  &AskingHandler{cluster: registry.cluster},  // 다음 명령어를 가져오는 중인 슬롯에서 실행
+
+		// 비트 연산 명령어
+		"BITCOUNT": &BitCountHandler{}, // 1 비트 개수 세기
+		"BITPOS":   &BitPosHandler{},   // 첫 번째 0/1 비트 위치 찾기
+		"BITOP":    &BitOpHandler{},    // 여러 키 간의 비트 연산
+
+		// HyperLogLog 명령어
+		"PFADD":   &PFAddHandler{},   // 원소 추가
+		"PFCOUNT": &PFCountHandler{}, // 추정 cardinality 조회
+		"PFMERGE": &PFMergeHandler{}, // 여러 HyperLogLog 병합
+
+		// Geo 명령어 (Sorted Set 기반)
+		"GEOADD":         &GeoAddHandler{},         // 좌표와 멤버 추가
+		"GEOPOS":         &GeoPosHandler{},         // 멤버 좌표 조회
+		"GEODIST":        &GeoDistHandler{},        // 두 멤버 간 거리
+		"GEOHASH":        &GeoHashHandler{},        // 표준 geohash 문자열
+		"GEOSEARCH":      &GeoSearchHandler{},      // 반경/사각형 검색
+		"GEOSEARCHSTORE": &GeoSearchStoreHandler{}, // 검색 결과 저장
+
+		// Pub/Sub 명령어
+		"SUBSCRIBE":    &SubscribeHandler{broker: registry.broker},    // 채널 구독
+		"UNSUBSCRIBE":  &UnsubscribeHandler{broker: registry.broker},  // 채널 구독 해지
+		"PSUBSCRIBE":   &PSubscribeHandler{broker: registry.broker},   // 패턴 구독
+		"PUNSUBSCRIBE": &PUnsubscribeHandler{broker: registry.broker}, // 패턴 구독 해지
+		"PUBLISH":      &PublishHandler{broker: registry.broker},      // 메시지 발행
+		"PUBSUB":       &PubSubHandler{broker: registry.broker},       // 구독 현황 조회
+		"SSUBSCRIBE":   &SSubscribeHandler{broker: registry.broker},   // 샤드 채널 구독
+		"SUNSUBSCRIBE": &SUnsubscribeHandler{broker: registry.broker}, // 샤드 채널 구독 해지
+		"SPUBLISH":     &SPublishHandler{broker: registry.broker},     // 샤드 채널에 메시지 발행
+	}
+}
+
 // Register는 새로운 명령어 핸들러를 등록합니다.
 //
 // 등록 과정:
@@ -117,36 +370,316 @@ func (r *CommandRegistry) Register(cmd string, handler CommandHandler) {
 //
 // 실행 과정:
 //  1. 명령어 이름을 대문자로 정규화
-//  2. 해당 핸들러 검색
-//  3. 핸들러가 존재하면 Execute 호출
-//  4. 핸들러가 없으면 에러 반환
+//  2. 해당 핸들러 검색 (없으면 에러 반환)
+//  3. 명령어 메타데이터의 arity로 인자 개수 확인
+//  4. 핸들러의 Execute 호출
 //
 // 매개변수:
 //   - cmd: 실행할 명령어 이름
 //   - args: 명령어의 인자들
 //
 // 반환값:
-//   - interface{}: 명령어 실행 결과
+//   - protocol.Reply: 명령어 실행 결과
 //   - error: 실행 중 발생한 에러 (알 수 없는 명령어 포함)
 //
 // 에러 케이스:
 //   - 등록되지 않은 명령어
+//   - 인자 개수가 잘못된 명령어
 //   - 핸들러 실행 중 발생한 에러
-func (r *CommandRegistry) Execute(cmd string, args []string) (interface{}, error) {
-	// 명령어 이름 정규화
-	cmdUpper := strings.ToUpper(cmd)
+func (r *CommandRegistry) Execute(cmd string, args []string) (protocol.Reply, error) {
+	// 명령어 이름 정규화 (rename-command로 바꾼 이름이면 원래 이름으로)
+	cmdUpper, callable := r.resolveCommand(strings.ToUpper(cmd))
 
 	// 등록된 핸들러 검색
 	handler, exists := r.handlers[cmdUpper]
-	if !exists {
+	if !exists || !callable {
 		// Redis 표준 에러 형식 반환
 		return nil, &UnknownCommandError{Command: cmd}
 	}
+	if err := r.validateArity(cmdUpper, args); err != nil {
+		return nil, err
+	}
 
 	// 핸들러 실행
 	return handler.Execute(args, r.store)
 }
 
+// NewClient는 새 연결에 대한 Client를 생성합니다.
+// 연결이 종료되면 반드시 CloseClient를 호출해야 합니다.
+//
+// 매개변수:
+//   - writer: 해당 연결로 응답을 보내는 Writer
+func (r *CommandRegistry) NewClient(writer *protocol.Writer) *Client {
+	client := newClient(atomic.AddInt64(&r.nextClientID, 1), writer)
+	client.authenticated = r.requirePassword() == ""
+	atomic.AddInt64(&r.stats.connectionsReceived, 1)
+
+	r.clientsMu.Lock()
+	r.clients[client.ID] = client
+	r.clientsMu.Unlock()
+
+	return client
+}
+
+// CloseClient는 연결 종료 시 클라이언트에 남아 있는 상태를 정리합니다.
+// 구독 중이던 모든 채널과 패턴에서 해지되고, 키 추적도 중단되며, 레플리카였다면 등록이 해제됩니다.
+func (r *CommandRegistry) CloseClient(client *Client) {
+	r.broker.UnsubscribeAll(client)
+	r.tracking.disable(client)
+	r.replication.removeReplica(client)
+
+	r.clientsMu.Lock()
+	delete(r.clients, client.ID)
+	r.clientsMu.Unlock()
+}
+
+// AdmitClient는 네트워크 연결의 Client(SetConn 이후)를 받아들일 수 있는지 확인합니다.
+// 연결 수가 maxclients를 넘으면 거부한 연결 수(rejected_connections)를 세고 에러를 반환합니다.
+// 연결 고루틴은 에러 응답을 보낸 뒤 연결을 닫아야 합니다 (CloseClient는 그대로 호출).
+//
+// 에러 케이스:
+//   - 연결 수가 maxclients를 넘은 경우
+func (r *CommandRegistry) AdmitClient(client *Client) error {
+	if int64(r.networkClientCount()) <= r.maxClients.Load() {
+		return nil
+	}
+	// 거부한 연결은 받은 연결 수에 세지 않음 (Redis와 동일)
+	atomic.AddInt64(&r.stats.connectionsReceived, -1)
+	atomic.AddInt64(&r.stats.rejectedConnections, 1)
+	return &InvalidArgumentError{Message: "max number of clients reached"}
+}
+
+// networkClientCount는 네트워크 연결이 있는 클라이언트 수입니다 (가상의 연결 제외, connected_clients).
+func (r *CommandRegistry) networkClientCount() int {
+	r.clientsMu.RLock()
+	defer r.clientsMu.RUnlock()
+	count := 0
+	for _, client := range r.clients {
+		if client.hasConn() {
+			count++
+		}
+	}
+	return count
+}
+
+// connectedClients는 현재 연결된 클라이언트들을 ID 순으로 반환합니다.
+func (r *CommandRegistry) connectedClients() []*Client {
+	r.clientsMu.RLock()
+	clients := make([]*Client, 0, len(r.clients))
+	for _, client := range r.clients {
+		clients = append(clients, client)
+	}
+	r.clientsMu.RUnlock()
+
+	sort.Slice(clients, func(i, j int) bool { return clients[i].ID < clients[j].ID })
+	return clients
+}
+
+// lookupClient는 ID로 연결된 클라이언트를 찾습니다. 없으면 nil을 반환합니다.
+func (r *CommandRegistry) lookupClient(id int64) *Client {
+	r.clientsMu.RLock()
+	defer r.clientsMu.RUnlock()
+	return r.clients[id]
+}
+
+// ExecuteForClient는 특정 연결에서 받은 명령어를 실행합니다.
+//
+// 핸들러가 ClientCommandHandler를 구현하면 연결 정보와 함께 실행하고,
+// 그렇지 않으면 Execute와 동일하게 동작합니다.
+//
+// 구독 모드인 RESP2 연결에서는 구독 관련 명령어와 PING/QUIT/RESET 외의
+// 명령어를 실행하지 않고 에러를 반환합니다.
+//
+// MULTI 중인 연결의 명령어는 실행하지 않고 대기열에 넣은 뒤 QUEUED 상태 응답을 반환합니다.
+// 알 수 없는 명령어나 인자 개수가 잘못된 명령어는 대기열에 넣지 않고 에러를 반환하며,
+// 이 경우 이후의 EXEC는 EXECABORT로 실패합니다.
+//
+// 매개변수:
+//   - client: 명령어를 보낸 연결
+//   - cmd: 실행할 명령어 이름
+//   - args: 명령어의 인자들
+func (r *CommandRegistry) ExecuteForClient(client *Client, cmd string, args []string) (protocol.Reply, error) {
+	return r.executeForClient(client, cmd, args, false)
+}
+
+// executeForClient는 ExecuteForClient의 구현입니다.
+// locked이면 호출자가 이미 실행 잠금을 배타적으로 잡고 있으므로 잠그지 않습니다 (마스터의 복제 스트림).
+func (r *CommandRegistry) executeForClient(client *Client, cmd string, args []string, locked bool) (protocol.Reply, error) {
+	cmdUpper := strings.ToUpper(cmd)
+	client.commandStarted(cmdUpper)
+	defer client.commandFinished()
+
+	// rename-command로 바꾼 이름이면 원래 이름으로 실행 (마스터의 복제 스트림은 원래 이름으로 옴)
+	callable := true
+	if !client.master {
+		cmdUpper, callable = r.resolveCommand(cmdUpper)
+	}
+
+	handler, exists := r.handlers[cmdUpper]
+	if !exists || !callable {
+		client.flagTransaction()
+		return nil, &UnknownCommandError{Command: cmd}
+	}
+
+	// 인자 개수가 잘못된 명령어는 핸들러를 호출하지 않음 (MULTI 중이면 트랜잭션을 실패로 표시)
+	if err := r.validateArity(cmdUpper, args); err != nil {
+		return r.rejectCommand(client, cmdUpper, err)
+	}
+
+	// requirepass가 설정되어 있으면 인증하기 전에는 AUTH 등 몇 가지 명령어만 실행
+	if err := authRequired(client, cmdUpper, args); err != nil {
+		return r.rejectCommand(client, cmdUpper, err)
+	}
+
+	if client.InSubscribeMode() && client.Protocol() == 2 && !subscribeModeCommands[cmdUpper] {
+		return r.rejectCommand(client, cmdUpper, &InvalidArgumentError{
+			Message: "Can't execute '" + strings.ToLower(cmd) + "': only (P|S)SUBSCRIBE / (P|S)UNSUBSCRIBE / PING / QUIT / RESET are allowed in this context",
+		})
+	}
+
+	// 스크립트가 시간 제한을 넘겨 실행 중이면 SCRIPT KILL, FUNCTION KILL 외의 명령어는 대기하지 않고 거부
+	if r.scripts.busy() && !isScriptKill(cmdUpper, args) {
+		return r.rejectCommand(client, cmdUpper, &BusyError{})
+	}
+
+	// FAILOVER 중에는 대상 레플리카가 따라잡을 수 있도록 쓰기 명령어를 끝날 때까지 대기
+	if !locked && !client.master && !client.inMulti && r.pausesWrites(cmdUpper) {
+		if resumed := r.replication.writesPaused(); resumed != nil {
+			client.Flush()
+			<-resumed
+		}
+	}
+
+	// 읽기 전용 레플리카는 마스터가 보낸 명령어 외의 쓰기 명령어를 거부
+	if r.rejectsWrite(client, cmdUpper) {
+		return r.rejectCommand(client, cmdUpper, &ReadOnlyError{})
+	}
+
+	// 데이터셋이 maxmemory를 넘었으면 메모리를 늘릴 수 있는 명령어를 거부 (읽기와 삭제는 실행)
+	if r.rejectsOOM(client, cmdUpper) {
+		return r.rejectCommand(client, cmdUpper, &OOMError{})
+	}
+
+	// 클러스터 모드에서는 이 노드가 담당하지 않는 슬롯의 키를 다루는 명령어를 거부
+	if err := r.clusterRedirect(client, cmdUpper, args); err != nil {
+		return r.rejectCommand(client, cmdUpper, err)
+	}
+
+	if client.inMulti && !transactionCommands[cmdUpper] {
+		client.queued = append(client.queued, queuedCommand{name: cmdUpper, args: args})
+		return queuedReply, nil
+	}
+
+	// 다른 클라이언트의 명령어와 섞이지 않도록 잠금을 잡고 실행
+	//   - EXEC, EVAL처럼 원자적으로 실행되어야 하는 명령어: 배타 잠금
+	//   - 대기하는 명령어: 잠금 없음 (잠금을 잡은 채 대기하면 안 됨)
+	//   - SCRIPT KILL, FUNCTION KILL: 잠금 없음 (실행 중인 스크립트가 배타 잠금을 잡고 있음)
+	//   - 그 외: 공유 잠금
+	switch _, blocking := handler.(blockingCommandHandler); {
+	case locked:
+	case exclusiveCommands[cmdUpper]:
+		r.execMu.Lock()
+		defer r.execMu.Unlock()
+	case blocking:
+		// 대기하기 전에 앞서 파이프라이닝된 명령어들의 응답을 보냄
+		client.Flush()
+	case isScriptKill(cmdUpper, args):
+	default:
+		r.execMu.RLock()
+		defer r.execMu.RUnlock()
+	}
+
+	return r.dispatch(client, cmdUpper, handler, args, false)
+}
+
+// execTransaction은 대기열의 명령어들을 순서대로 실행합니다.
+// 각 명령어의 응답(실패한 경우 에러)을 순서대로 담은 배열을 반환합니다.
+// EXEC는 배타 잠금을 잡고 실행되므로 다른 클라이언트의 명령어와 섞이지 않습니다.
+func (r *CommandRegistry) execTransaction(client *Client, queued []queuedCommand) protocol.Array {
+	r.beginPropagation()
+	defer r.endPropagation()
+
+	results := make(protocol.Array, 0, len(queued))
+	for _, cmd := range queued {
+		result, err := r.dispatch(client, cmd.name, r.handlers[cmd.name], cmd.args, true)
+		if err != nil {
+			results = append(results, protocol.NewError(err))
+			continue
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// dispatch는 핸들러 종류에 맞는 실행 메서드를 호출합니다.
+// 실행 전후에는 OnBeforeCommand, OnAfterCommand로 등록한 함수들을 호출합니다.
+//
+// 매개변수:
+//   - client: 명령어를 보낸 연결 (연결 없이 실행된 스크립트 안에서는 nil)
+//   - nonBlocking: true이면 대기하는 명령어도 대기 없이 실행 (트랜잭션, 스크립트 안)
+func (r *CommandRegistry) dispatch(client *Client, cmdUpper string, handler CommandHandler, args []string, nonBlocking bool) (protocol.Reply, error) {
+	var result protocol.Reply
+	var err error
+	_, blocking := handler.(blockingCommandHandler)
+	var ctx *CommandContext
+	if r.hasCommandHooks() {
+		ctx = &CommandContext{Store: r.store, Client: client}
+		r.runBeforeHooks(ctx, cmdUpper, args)
+	}
+
+	// 명령어가 다룰 키들을 사용한 것으로 기록 (Redis처럼 키를 찾을 때 기록하므로 이 명령어가 만드는 키는 제외)
+	// CLIENT NO-TOUCH를 켠 연결은 기록하지 않음
+	// 읽기 명령어이면 찾은 키와 없는 키를 keyspace_hits, keyspace_misses로 셈
+	if keys := commandKeys(cmdUpper, args); len(keys) > 0 {
+		readOnly := r.commandHas(cmdUpper, cmdReadOnly)
+		found := 0
+		if client == nil || !client.noTouch {
+			found = r.store.Touch(keys...)
+		} else if readOnly {
+			found = r.store.Exists(keys...)
+		}
+		if readOnly {
+			atomic.AddInt64(&r.stats.keyspaceHits, int64(found))
+			atomic.AddInt64(&r.stats.keyspaceMisses, int64(len(keys)-found))
+		}
+	}
+
+	// 대기할 수 있는 명령어를 실행하는 동안은 blocked_clients로 셈
+	if blocking && !nonBlocking {
+		atomic.AddInt64(&r.stats.blockedClients, 1)
+		defer atomic.AddInt64(&r.stats.blockedClients, -1)
+	}
+
+	start := time.Now()
+	if blockingHandler, ok := handler.(blockingCommandHandler); ok && nonBlocking {
+		result, err = blockingHandler.ExecuteNonBlocking(args, r.store)
+	} else if clientHandler, ok := handler.(ClientCommandHandler); ok && client != nil {
+		result, err = clientHandler.ExecuteWithClient(client, args, r.store)
+	} else {
+		result, err = handler.Execute(args, r.store)
+	}
+	duration := time.Since(start)
+	atomic.AddInt64(&r.stats.commandsProcessed, 1)
+	// 대기하는 명령어는 대기 시간이 지연이 아니므로 대기하지 않고 실행한 경우만 기록
+	timed := !blocking || nonBlocking
+	if timed {
+		r.latency.addSample(latencyEventCommand, duration)
+	}
+	r.commandStats.get(cmdUpper).record(duration, err, timed)
+	if ctx != nil {
+		r.runAfterHooks(ctx, cmdUpper, result, err, duration)
+	}
+
+	if err == nil {
+		// 클라이언트 측 캐싱: 추적 중인 클라이언트가 읽은 키를 기억
+		r.tracking.trackRead(client, cmdUpper, args)
+
+		// 쓰기 명령어를 AOF 등에 전달 (트랜잭션, 스크립트 안에서는 모아서 전달)
+		r.propagate(cmdUpper, args, result, nonBlocking)
+	}
+	return result, err
+}
+
 // HasCommand는 명령어가 등록되어 있는지 확인합니다.
 //
 // 매개변수:
@@ -201,3 +734,246 @@ type UnknownCommandError struct {
 func (e *UnknownCommandError) Error() string {
 	return "-ERR unknown command '" + e.Command + "'"
 }
+
+// ExecAbortError는 대기열에 넣는 중 오류가 있었던 트랜잭션을 EXEC할 때의 에러입니다.
+type ExecAbortError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-EXECABORT Transaction discarded because of previous errors.
+func (e *ExecAbortError) Error() string {
+	return "-EXECABORT Transaction discarded because of previous errors."
+}
+
+// NoScriptError는 EVALSHA로 캐시에 없는 스크립트를 실행하려 한 경우의 에러입니다.
+type NoScriptError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-NOSCRIPT No matching script. Please use EVAL.
+func (e *NoScriptError) Error() string {
+	return "-NOSCRIPT No matching script. Please use EVAL."
+}
+
+// BusyError는 스크립트가 시간 제한을 넘겨 실행 중일 때 다른 명령어를 거부하는 에러입니다.
+type BusyError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-BUSY Redis is busy running a script. You can only call SCRIPT KILL or SHUTDOWN NOSAVE.
+func (e *BusyError) Error() string {
+	return "-BUSY Redis is busy running a script. You can only call SCRIPT KILL or SHUTDOWN NOSAVE."
+}
+
+// NotBusyError는 실행 중인 스크립트가 없을 때 SCRIPT KILL을 호출한 경우의 에러입니다.
+type NotBusyError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-NOTBUSY No scripts in execution right now.
+func (e *NotBusyError) Error() string {
+	return "-NOTBUSY No scripts in execution right now."
+}
+
+// UnkillableError는 이미 데이터를 변경한 스크립트를 SCRIPT KILL로 중단하려 한 경우의 에러입니다.
+// 중단하면 스크립트의 원자성이 깨지므로 Redis와 동일하게 거부합니다.
+type UnkillableError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-UNKILLABLE Sorry the script already executed write commands against the dataset. ...
+func (e *UnkillableError) Error() string {
+	return "-UNKILLABLE Sorry the script already executed write commands against the dataset. You can either wait the script termination or kill the server in a hard way using the SHUTDOWN NOSAVE command."
+}
+
+// ReadOnlyError는 읽기 전용 레플리카에서 일반 클라이언트가 쓰기 명령어를 실행한 경우의 에러입니다.
+type ReadOnlyError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-READONLY You can't write against a read only replica.
+func (e *ReadOnlyError) Error() string {
+	return "-READONLY You can't write against a read only replica."
+}
+
+// OOMError는 데이터셋이 maxmemory를 넘은 상태에서 메모리를 늘릴 수 있는 명령어(denyoom)를 실행한 경우의 에러입니다.
+type OOMError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-OOM command not allowed when used memory > 'maxmemory'.
+func (e *OOMError) Error() string {
+	return "-OOM command not allowed when used memory > 'maxmemory'."
+}
+
+// MovedError는 클러스터 모드에서 키의 슬롯을 다른 노드가 담당하는 경우의 에러입니다.
+// 클라이언트는 addr의 노드로 다시 요청하고 슬롯 배정 정보를 갱신합니다.
+type MovedError struct {
+	Slot int    // 키의 해시 슬롯
+	Addr string // 슬롯을 담당하는 노드의 주소 (host:port)
+}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-MOVED <슬롯> <host>:<port>
+func (e *MovedError) Error() string {
+	return "-MOVED " + strconv.Itoa(e.Slot) + " " + e.Addr
+}
+
+// CrossSlotError는 클러스터 모드에서 여러 키를 다루는 명령어의 키들이 서로 다른 슬롯에 있는 경우의 에러입니다.
+type CrossSlotError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-CROSSSLOT Keys in request don't hash to the same slot
+func (e *CrossSlotError) Error() string {
+	return "-CROSSSLOT Keys in request don't hash to the same slot"
+}
+
+// ClusterDownError는 클러스터 모드에서 키의 슬롯을 담당하는 노드가 없는 경우의 에러입니다.
+type ClusterDownError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-CLUSTERDOWN Hash slot not served
+func (e *ClusterDownError) Error() string {
+	return "-CLUSTERDOWN Hash slot not served"
+}
+
+// AskError는 클러스터 모드에서 옮겨지는 중인 슬롯의 키가 이 노드에 없는 경우의 에러입니다.
+// 클라이언트는 이번 요청만 ASKING을 먼저 보낸 뒤 addr의 노드로 다시 보냅니다 (슬롯 배정 정보는 갱신하지 않음).
+type AskError struct {
+	Slot int    // 키의 해시 슬롯
+	Addr string // 슬롯을 가져오는 중인 노드의 주소 (host:port)
+}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-ASK <슬롯> <host>:<port>
+func (e *AskError) Error() string {
+	return "-ASK " + strconv.Itoa(e.Slot) + " " + e.Addr
+}
+
+// TryAgainError는 옮겨지는 중인 슬롯에서 여러 키 명령어의 키 중 일부만 이 노드에 있는 경우의 에러입니다.
+type TryAgainError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-TRYAGAIN Multiple keys request during rehashing of slot
+func (e *TryAgainError) Error() string {
+	return "-TRYAGAIN Multiple keys request during rehashing of slot"
+}
+
+// IOError는 MIGRATE가 대상 서버와 통신하지 못한 경우의 에러입니다.
+type IOError struct {
+	Message string // 구체적인 에러 메시지
+}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-IOERR <메시지>
+func (e *IOError) Error() string {
+	return "-IOERR " + e.Message
+}
+
+// BusyKeyError는 RESTORE의 대상 키가 이미 있는 경우의 에러입니다.
+type BusyKeyError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-BUSYKEY Target key name already exists.
+func (e *BusyKeyError) Error() string {
+	return "-BUSYKEY Target key name already exists."
+}
+
+// NoProtoError는 HELLO로 지원하지 않는 RESP 버전을 요청한 경우의 에러입니다.
+type NoProtoError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-NOPROTO unsupported protocol version
+func (e *NoProtoError) Error() string {
+	return "-NOPROTO unsupported protocol version"
+}
+
+// NoAuthError는 인증하지 않은 연결이 인증이 필요한 명령어를 실행한 경우의 에러입니다.
+type NoAuthError struct {
+	Message string // 구체적인 에러 메시지 (비어 있으면 기본 메시지 사용)
+}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-NOAUTH Authentication required.
+func (e *NoAuthError) Error() string {
+	if e.Message == "" {
+		return "-NOAUTH Authentication required."
+	}
+	return "-NOAUTH " + e.Message
+}
+
+// WrongPassError는 사용자 이름이나 비밀번호가 틀려 인증에 실패한 경우의 에러입니다.
+type WrongPassError struct{}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-WRONGPASS invalid username-password pair or user is disabled.
+func (e *WrongPassError) Error() string {
+	return "-WRONGPASS invalid username-password pair or user is disabled."
+}
+
+// WrongTypeError는 키에 저장된 값의 타입이 명령어와 맞지 않는 경우의 에러입니다.
+// 일반 에러(-ERR)와 구분되도록 WRONGTYPE 에러 코드를 사용합니다.
+type WrongTypeError struct {
+	Message string // 구체적인 에러 메시지 (비어 있으면 기본 메시지 사용)
+}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-WRONGTYPE <메시지>
+//
+// 예시:
+//
+//	-WRONGTYPE Operation against a key holding the wrong kind of value
+//	-WRONGTYPE Key is not a valid HyperLogLog string value.
+func (e *WrongTypeError) Error() string {
+	if e.Message == "" {
+		return "-WRONGTYPE Operation against a key holding the wrong kind of value"
+	}
+	return "-WRONGTYPE " + e.Message
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+)
+
+// BeforeCommandFunc는 명령어가 실행되기 직전에 호출되는 함수입니다 (OnBeforeCommand).
+//
+// 매개변수:
+//   - ctx: 명령어의 실행 환경 (연결 없이 실행된 경우 Client는 nil)
+//   - cmd: 대문자로 정규화된 명령어 이름
+//   - args: 명령어 인자들 (명령어 이름 제외, 수정하면 안 됨)
+type BeforeCommandFunc func(ctx *CommandContext, cmd string, args []string)
+
+// AfterCommandFunc는 명령어가 실행된 직후에 호출되는 함수입니다 (OnAfterCommand).
+//
+// 매개변수:
+//   - ctx: 명령어의 실행 환경 (연결 없이 실행된 경우 Client는 nil)
+//   - cmd: 대문자로 정규화된 명령어 이름
+//   - result: 핸들러가 반환한 응답 (에러이면 nil일 수 있음)
+//   - err: 핸들러가 반환한 에러
+//   - duration: 핸들러의 실행 시간 (대기하는 명령어는 대기 시간 포함)
+type AfterCommandFunc func(ctx *CommandContext, cmd string, result protocol.Reply, err error, duration time.Duration)
+
+// OnBeforeCommand는 명령어가 실행되기 직전마다 호출될 함수를 등록합니다.
+// MONITOR, 지표 수집처럼 모든 명령어에 적용되는 기능을 핸들러를 고치지 않고 붙일 때 사용합니다.
+//
+// 핸들러가 실제로 호출되는 명령어에만 호출됩니다. 알 수 없는 명령어, 인자 개수가 잘못된 명령어,
+// MULTI 중 대기열에 넣은 명령어처럼 실행 전에 걸러진 명령어에는 호출되지 않고,
+// 트랜잭션과 스크립트 안에서 실행된 명령어에는 각각 호출됩니다.
+//
+// fn은 여러 고루틴에서 동시에 호출될 수 있고, 실행 잠금을 잡은 채 호출될 수 있으므로
+// 레지스트리의 명령어를 실행하면 안 됩니다.
+// RegisterCommand와 마찬가지로 연결을 받기 전에 호출해야 합니다.
+func (r *CommandRegistry) OnBeforeCommand(fn BeforeCommandFunc) {
+	r.beforeHooks = append(r.beforeHooks, fn)
+}
+
+// OnAfterCommand는 명령어가 실행된 직후마다 호출될 함수를 등록합니다.
+// SLOWLOG, 명령어별 통계처럼 실행 결과와 시간이 필요한 기능이 사용합니다.
+//
+// 호출되는 명령어와 동시성 규칙은 OnBeforeCommand와 같습니다.
+// 실행에 실패한 명령어에도 err와 함께 호출됩니다.
+func (r *CommandRegistry) OnAfterCommand(fn AfterCommandFunc) {
+	r.afterHooks = append(r.afterHooks, fn)
+}
+
+// hasCommandHooks는 등록된 실행 전후 함수가 있는지 확인합니다.
+func (r *CommandRegistry) hasCommandHooks() bool {
+	return len(r.beforeHooks) > 0 || len(r.afterHooks) > 0
+}
+
+// runBeforeHooks는 OnBeforeCommand로 등록한 함수들을 등록한 순서대로 호출합니다.
+func (r *CommandRegistry) runBeforeHooks(ctx *CommandContext, cmdUpper string, args []string) {
+	for _, fn := range r.beforeHooks {
+		fn(ctx, cmdUpper, args)
+	}
+}
+
+// runAfterHooks는 OnAfterCommand로 등록한 함수들을 등록한 순서대로 호출합니다.
+func (r *CommandRegistry) runAfterHooks(ctx *CommandContext, cmdUpper string, result protocol.Reply, err error, duration time.Duration) {
+	for _, fn := range r.afterHooks {
+		fn(ctx, cmdUpper, result, err, duration)
+	}
+}
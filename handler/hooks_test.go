@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestCommandHooks는 명령어 실행 전후에 등록한 함수들이 호출되는지 테스트합니다.
+func TestCommandHooks(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	var events []string
+	var lastErr error
+	registry.OnBeforeCommand(func(ctx *CommandContext, cmd string, args []string) {
+		if ctx.Client != client {
+			t.Errorf("Expected hook context with the calling client")
+		}
+		events = append(events, "before "+cmd)
+	})
+	registry.OnAfterCommand(func(ctx *CommandContext, cmd string, result protocol.Reply, err error, duration time.Duration) {
+		if duration < 0 {
+			t.Errorf("Expected non-negative duration, got %v", duration)
+		}
+		lastErr = err
+		events = append(events, "after "+cmd)
+	})
+
+	// 테스트 케이스 1: 실행된 명령어마다 전후로 호출
+	registry.ExecuteForClient(client, "SET", []string{"key", "value"})
+	registry.ExecuteForClient(client, "GET", []string{"key"})
+	expected := []string{"before SET", "after SET", "before GET", "after GET"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %q, got %q", expected, events)
+	}
+
+	// 테스트 케이스 2: 실행 전에 걸러진 명령어에는 호출하지 않음
+	events = nil
+	registry.ExecuteForClient(client, "NOSUCH", []string{})
+	registry.ExecuteForClient(client, "GET", []string{})
+	if len(events) != 0 {
+		t.Errorf("Expected no hook calls for rejected commands, got %q", events)
+	}
+
+	// 테스트 케이스 3: 실패한 명령어에는 에러와 함께 호출
+	registry.ExecuteForClient(client, "PFADD", []string{"key", "x"})
+	if _, ok := lastErr.(*WrongTypeError); !ok {
+		t.Errorf("Expected WRONGTYPE error in after hook, got %v", lastErr)
+	}
+
+	// 테스트 케이스 4: 트랜잭션 안의 명령어에는 각각 호출 (대기열에 넣을 때는 호출하지 않음)
+	events = nil
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	registry.ExecuteForClient(client, "GET", []string{"a"})
+	registry.ExecuteForClient(client, "EXEC", []string{})
+	expected = []string{
+		"before MULTI", "after MULTI",
+		"before EXEC", "before SET", "after SET", "before GET", "after GET", "after EXEC",
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %q, got %q", expected, events)
+	}
+}
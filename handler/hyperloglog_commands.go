@@ -0,0 +1,177 @@
+// Package handler는 Redis의 HyperLogLog 명령어들을 구현합니다.
+// HyperLogLog는 String 값(blob)으로 저장되며, 실제 자료구조 로직은 hll 패키지가 담당합니다.
+package handler
+
+import (
+	"bytes"
+
+	"github.com/codecrafters-io/redis-starter-go/hll"
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// invalidHLLError는 키의 값이 HyperLogLog 형식이 아닐 때 반환하는 에러입니다.
+var invalidHLLError = &WrongTypeError{Message: "Key is not a valid HyperLogLog string value."}
+
+// PFAddHandler는 PFADD 명령어를 처리하는 핸들러입니다.
+//
+// Redis PFADD 명령어 사양:
+//   - PFADD key [element ...]
+//   - 키가 없으면 빈 HyperLogLog를 생성
+//   - 내부 레지스터가 하나라도 변경되었거나 키가 새로 생성되면 1, 아니면 0 반환
+//
+// 예시:
+//
+//	PFADD hll a b c d → :1\r\n
+//	PFADD hll a → :0\r\n
+//
+// 시간 복잡도: O(1) (원소 하나당)
+type PFAddHandler struct{}
+
+// Execute는 PFADD 명령어를 실행합니다.
+func (h *PFAddHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "pfadd"}
+	}
+
+	key := args[0]
+	blob, exists, err := loadHLL(store, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// 새로 생성한 키는 원소가 없어도 변경으로 취급
+	updated := !exists
+	for _, element := range args[1:] {
+		if hll.Add(blob, element) {
+			updated = true
+		}
+	}
+
+	if !updated {
+		return protocol.Integer(0), nil
+	}
+
+	// 값을 고쳐 쓰는 것이므로 키의 TTL은 유지
+	store.SETKEEPTTL(key, blob)
+	return protocol.Integer(1), nil
+}
+
+// PFCountHandler는 PFCOUNT 명령어를 처리하는 핸들러입니다.
+//
+// Redis PFCOUNT 명령어 사양:
+//   - PFCOUNT key → 해당 HyperLogLog의 추정 cardinality
+//   - PFCOUNT key1 key2 ... → 모든 HyperLogLog 합집합의 추정 cardinality
+//   - 존재하지 않는 키는 빈 HyperLogLog로 취급
+//
+// 단일 키의 경우 계산한 값을 blob 헤더에 캐시하여 다음 호출을 O(1)로 만듭니다.
+//
+// 시간 복잡도: O(1) (캐시 적중 시), O(N) (N은 키 개수, 다중 키)
+type PFCountHandler struct{}
+
+// Execute는 PFCOUNT 명령어를 실행합니다.
+func (h *PFCountHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "pfcount"}
+	}
+
+	// 단일 키: 캐시된 값을 사용하고, 새로 계산했으면 캐시를 저장
+	if len(args) == 1 {
+		blob, exists, err := loadHLL(store, args[0])
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return protocol.Integer(0), nil
+		}
+
+		// 캐시 갱신은 값을 바꾸지 않으므로 변경 알림 없이 TTL을 유지한 채 저장
+		count, updated := hll.Count(blob)
+		if updated {
+			store.ReplaceString(args[0], blob)
+		}
+		return protocol.Integer(count), nil
+	}
+
+	// 다중 키: 임시로 병합한 결과의 cardinality 계산
+	blobs := make([][]byte, 0, len(args))
+	for _, key := range args {
+		blob, exists, err := loadHLL(store, key)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			blobs = append(blobs, blob)
+		}
+	}
+
+	return protocol.Integer(hll.CountUnion(blobs...)), nil
+}
+
+// PFMergeHandler는 PFMERGE 명령어를 처리하는 핸들러입니다.
+//
+// Redis PFMERGE 명령어 사양:
+//   - PFMERGE destkey [sourcekey ...]
+//   - destkey가 이미 존재하면 그 값도 병합 대상에 포함
+//   - 결과를 destkey에 저장하고 OK 반환
+//
+// 예시:
+//
+//	PFADD hll1 foo bar zap a
+//	PFADD hll2 a b c foo
+//	PFMERGE hll3 hll1 hll2 → +OK\r\n
+//	PFCOUNT hll3 → :6\r\n
+//
+// 시간 복잡도: O(N) (N은 소스 키 개수)
+type PFMergeHandler struct{}
+
+// Execute는 PFMERGE 명령어를 실행합니다.
+func (h *PFMergeHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "pfmerge"}
+	}
+
+	destKey := args[0]
+	dest, _, err := loadHLL(store, destKey)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range args[1:] {
+		src, exists, err := loadHLL(store, key)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			hll.Merge(dest, src)
+		}
+	}
+
+	// destkey가 이미 있었다면 그 TTL은 유지
+	store.SETKEEPTTL(destKey, dest)
+	return okReply, nil
+}
+
+// loadHLL은 키에 저장된 HyperLogLog blob을 읽어옵니다.
+//
+// 반환값:
+//   - []byte: 수정 가능한 blob 복사본 (키가 없으면 새로 생성한 빈 blob)
+//   - bool: 키가 존재했는지 여부
+//   - error: String이 아닌 키이거나 값이 HyperLogLog 형식이 아니면 WRONGTYPE 에러
+func loadHLL(store *store.Store, key string) ([]byte, bool, error) {
+	if err := checkStringKeyType(store, key); err != nil {
+		return nil, true, err
+	}
+
+	value := store.GET(key)
+	if value == nil {
+		return hll.New(), false, nil
+	}
+
+	// 저장소의 값은 읽기 전용이므로 고치기 전에 복사
+	blob := bytes.Clone(value)
+	if !hll.IsValid(blob) {
+		return nil, true, invalidHLLError
+	}
+	return blob, true, nil
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestPFAddHandler는 PFADD 명령어 핸들러를 테스트합니다.
+func TestPFAddHandler(t *testing.T) {
+	handler := &PFAddHandler{}
+	dataStore := store.NewStore()
+
+	// 테스트 케이스 1: 새 키에 원소 추가
+	result, err := handler.Execute([]string{"hll", "a", "b", "c"}, dataStore)
+	if err != nil {
+		t.Fatalf("PFADD failed: %v", err)
+	}
+	if result != protocol.Integer(1) {
+		t.Errorf("Expected 1, got %v", result)
+	}
+
+	// 테스트 케이스 2: 이미 있는 원소 추가 → 변경 없음
+	result, err = handler.Execute([]string{"hll", "a"}, dataStore)
+	if err != nil {
+		t.Fatalf("PFADD duplicate failed: %v", err)
+	}
+	if result != protocol.Integer(0) {
+		t.Errorf("Expected 0, got %v", result)
+	}
+
+	// 테스트 케이스 3: 원소 없이 새 키 생성
+	result, err = handler.Execute([]string{"empty"}, dataStore)
+	if err != nil {
+		t.Fatalf("PFADD without elements failed: %v", err)
+	}
+	if result != protocol.Integer(1) {
+		t.Errorf("Expected 1 for key creation, got %v", result)
+	}
+
+	// 테스트 케이스 4: HyperLogLog가 아닌 값 (에러 케이스)
+	dataStore.SET("plain", []byte("hello"), nil)
+	_, err = handler.Execute([]string{"plain", "a"}, dataStore)
+	if _, ok := err.(*WrongTypeError); !ok {
+		t.Errorf("Expected WrongTypeError, got %v", err)
+	}
+
+	// 테스트 케이스 5: 인자 부족 (에러 케이스)
+	if _, err := handler.Execute([]string{}, dataStore); err == nil {
+		t.Error("Expected error for no args")
+	}
+}
+
+// TestPFCountHandler는 PFCOUNT 명령어 핸들러를 테스트합니다.
+func TestPFCountHandler(t *testing.T) {
+	pfadd := &PFAddHandler{}
+	handler := &PFCountHandler{}
+	dataStore := store.NewStore()
+
+	pfadd.Execute([]string{"hll1", "foo", "bar", "zap", "a"}, dataStore)
+	pfadd.Execute([]string{"hll2", "a", "b", "c", "foo"}, dataStore)
+
+	// 테스트 케이스 1: 단일 키
+	result, err := handler.Execute([]string{"hll1"}, dataStore)
+	if err != nil {
+		t.Fatalf("PFCOUNT failed: %v", err)
+	}
+	if result != protocol.Integer(4) {
+		t.Errorf("Expected 4, got %v", result)
+	}
+
+	// 테스트 케이스 2: 여러 키의 합집합
+	result, err = handler.Execute([]string{"hll1", "hll2", "nokey"}, dataStore)
+	if err != nil {
+		t.Fatalf("PFCOUNT with multiple keys failed: %v", err)
+	}
+	if result != protocol.Integer(6) {
+		t.Errorf("Expected 6, got %v", result)
+	}
+
+	// 테스트 케이스 3: 존재하지 않는 키
+	result, err = handler.Execute([]string{"nokey"}, dataStore)
+	if err != nil {
+		t.Fatalf("PFCOUNT for non-existent key failed: %v", err)
+	}
+	if result != protocol.Integer(0) {
+		t.Errorf("Expected 0, got %v", result)
+	}
+
+	// 테스트 케이스 4: HyperLogLog가 아닌 값 (에러 케이스)
+	dataStore.SET("plain", []byte("hello"), nil)
+	if _, err := handler.Execute([]string{"hll1", "plain"}, dataStore); err == nil {
+		t.Error("Expected error for non-HLL value")
+	}
+}
+
+// TestPFMergeHandler는 PFMERGE 명령어 핸들러를 테스트합니다.
+func TestPFMergeHandler(t *testing.T) {
+	pfadd := &PFAddHandler{}
+	pfcount := &PFCountHandler{}
+	handler := &PFMergeHandler{}
+	dataStore := store.NewStore()
+
+	pfadd.Execute([]string{"hll1", "foo", "bar", "zap", "a"}, dataStore)
+	pfadd.Execute([]string{"hll2", "a", "b", "c", "foo"}, dataStore)
+	pfadd.Execute([]string{"hll3", "extra"}, dataStore)
+
+	// 테스트 케이스 1: 새 destkey로 병합
+	result, err := handler.Execute([]string{"merged", "hll1", "hll2"}, dataStore)
+	if err != nil {
+		t.Fatalf("PFMERGE failed: %v", err)
+	}
+	if !isStatus(result, "OK") {
+		t.Errorf("Expected OK, got %v", result)
+	}
+
+	count, _ := pfcount.Execute([]string{"merged"}, dataStore)
+	if count != protocol.Integer(6) {
+		t.Errorf("Expected merged count 6, got %v", count)
+	}
+
+	// 테스트 케이스 2: 기존 destkey의 값도 병합에 포함
+	if _, err := handler.Execute([]string{"merged", "hll3"}, dataStore); err != nil {
+		t.Fatalf("PFMERGE into existing key failed: %v", err)
+	}
+	count, _ = pfcount.Execute([]string{"merged"}, dataStore)
+	if count != protocol.Integer(7) {
+		t.Errorf("Expected merged count 7, got %v", count)
+	}
+
+	// 테스트 케이스 3: 인자 부족 (에러 케이스)
+	if _, err := handler.Execute([]string{}, dataStore); err == nil {
+		t.Error("Expected error for no args")
+	}
+}
+
+// TestHLLWrongType은 String이 아닌 키에 대한 HyperLogLog 명령어가 WRONGTYPE을 반환하는지 테스트합니다.
+func TestHLLWrongType(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.Execute("RPUSH", []string{"list", "a"})
+	registry.Execute("PFADD", []string{"hll", "x"})
+
+	tests := []struct {
+		cmd  string
+		args []string
+	}{
+		{"PFADD", []string{"list", "z"}},
+		{"PFCOUNT", []string{"list"}},
+		{"PFCOUNT", []string{"hll", "list"}},
+		{"PFMERGE", []string{"list", "hll"}},
+		{"PFMERGE", []string{"dest", "hll", "list"}},
+	}
+	for _, tt := range tests {
+		_, err := registry.Execute(tt.cmd, tt.args)
+		if _, ok := err.(*WrongTypeError); !ok {
+			t.Errorf("%s %v: expected WrongTypeError, got %v", tt.cmd, tt.args, err)
+		}
+	}
+
+	// 리스트는 그대로이고 String 값이 따로 생기지 않음
+	if result, _ := registry.Execute("LRANGE", []string{"list", "0", "-1"}); !reflect.DeepEqual(result, protocol.BulkStrings{"a"}) {
+		t.Errorf("Expected list [a], got %v", result)
+	}
+	if value := registry.store.GET("list"); value != nil {
+		t.Errorf("Expected no string value for the list key, got %q", value)
+	}
+	if keyType := registry.store.TYPE("dest"); keyType != "none" {
+		t.Errorf("Expected failed PFMERGE not to create dest, got a %s key", keyType)
+	}
+}
+
+// TestHLLKeepTTL은 PFADD, PFCOUNT, PFMERGE가 키의 TTL을 유지하고,
+// PFCOUNT의 캐시 갱신은 키 변경으로 세지 않는지 테스트합니다.
+func TestHLLKeepTTL(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.Execute("PFADD", []string{"src", "a", "b", "c"})
+	blob := registry.store.GET("src")
+	ttl := 60000
+	registry.store.SET("hll", blob, &ttl)
+	entry, _ := registry.store.Lookup("hll")
+	expireAt := entry.ExpireAt
+
+	keepsTTL := func(step string) {
+		t.Helper()
+		if entry, exists := registry.store.Lookup("hll"); !exists || !entry.ExpireAt.Equal(expireAt) {
+			t.Errorf("%s: expected expiry %v to be kept, got %v", step, expireAt, entry.ExpireAt)
+		}
+	}
+
+	// 테스트 케이스 1: PFADD
+	if result, _ := registry.Execute("PFADD", []string{"hll", "zz"}); result != protocol.Integer(1) {
+		t.Fatalf("Expected PFADD to update the key, got %v", result)
+	}
+	keepsTTL("PFADD")
+
+	// 테스트 케이스 2: PFCOUNT의 캐시 갱신은 TTL을 유지하고 변경 횟수에 포함되지 않음
+	dirty := registry.persistence.dirty
+	if result, _ := registry.Execute("PFCOUNT", []string{"hll"}); result != protocol.Integer(4) {
+		t.Errorf("Expected count 4, got %v", result)
+	}
+	keepsTTL("PFCOUNT")
+	if registry.persistence.dirty != dirty {
+		t.Errorf("Expected PFCOUNT not to count as a change, dirty went from %d to %d", dirty, registry.persistence.dirty)
+	}
+
+	// 테스트 케이스 3: PFMERGE의 destkey
+	registry.Execute("PFADD", []string{"other", "d"})
+	if result, err := registry.Execute("PFMERGE", []string{"hll", "other"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	keepsTTL("PFMERGE")
+
+	// 테스트 케이스 4: 만료 시각이 지나면 키가 사라짐
+	short := 50
+	registry.store.SET("short", blob, &short)
+	registry.Execute("PFADD", []string{"short", "zz"})
+	time.Sleep(100 * time.Millisecond)
+	if result, _ := registry.Execute("PFCOUNT", []string{"short"}); result != protocol.Integer(0) {
+		t.Errorf("Expected the key to expire after PFADD, got count %v", result)
+	}
+}
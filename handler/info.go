@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// infoSection은 INFO 응답의 섹션 하나입니다.
+type infoSection struct {
+	name   string                               // 섹션 이름 (소문자, INFO 인자로 사용)
+	title  string                               // 응답에 표시되는 제목 (# Persistence)
+	fields func(r *CommandRegistry) [][2]string // 필드 이름과 값 목록
+	// extra이면 INFO, INFO default에는 포함하지 않고 이름이나 all, everything으로 요청해야 보고합니다 (Redis와 동일).
+	extra bool
+}
+
+// infoSections는 INFO가 보고하는 섹션들입니다 (응답 순서와 동일).
+var infoSections = []infoSection{
+	{name: "server", title: "Server", fields: serverInfo},
+	{name: "clients", title: "Clients", fields: clientsInfo},
+	{name: "memory", title: "Memory", fields: memoryInfo},
+	{name: "persistence", title: "Persistence", fields: persistenceInfo},
+	{name: "stats", title: "Stats", fields: statsInfo},
+	{name: "replication", title: "Replication", fields: replicationInfo},
+	{name: "cluster", title: "Cluster", fields: clusterInfo},
+	{name: "commandstats", title: "Commandstats", fields: commandStatsInfo, extra: true},
+	{name: "latencystats", title: "Latencystats", fields: latencyStatsInfo, extra: true},
+}
+
+// InfoHandler는 INFO 명령어를 처리하는 핸들러입니다.
+//
+// Redis INFO 명령어 사양:
+//   - INFO [section [section ...]]
+//   - 서버 상태와 통계를 "# 섹션" 제목과 "필드:값" 줄로 이루어진 텍스트로 반환
+//   - 섹션을 지정하지 않거나 default이면 commandstats, latencystats를 뺀 섹션들
+//   - all, everything이면 모든 섹션
+//   - 알 수 없는 섹션은 무시
+//
+// 예시:
+//
+//	클라이언트: INFO persistence
+//	서버: $...\r\n# Persistence\r\nloading:0\r\nrdb_changes_since_last_save:0\r\n...
+type InfoHandler struct {
+	registry *CommandRegistry
+}
+
+// Execute는 INFO 명령어를 실행합니다.
+func (h *InfoHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	requested := make(map[string]bool, len(args))
+	all, defaults := false, len(args) == 0
+	for _, arg := range args {
+		switch name := strings.ToLower(arg); name {
+		case "all", "everything":
+			all = true
+		case "default":
+			defaults = true
+		default:
+			requested[name] = true
+		}
+	}
+
+	var sb strings.Builder
+	for _, section := range infoSections {
+		if !all && !requested[section.name] && !(defaults && !section.extra) {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString("# " + section.title + "\r\n")
+		for _, field := range section.fields(h.registry) {
+			sb.WriteString(field[0] + ":" + field[1] + "\r\n")
+		}
+	}
+	return protocol.BulkString(sb.String()), nil
+}
+
+// serverInfo는 INFO server 섹션의 필드들을 반환합니다.
+func serverInfo(r *CommandRegistry) [][2]string {
+	mode := "standalone"
+	r.cluster.mu.Lock()
+	if r.cluster.enabled {
+		mode = "cluster"
+	}
+	r.cluster.mu.Unlock()
+	r.replication.mu.Lock()
+	port := r.replication.listeningPort
+	r.replication.mu.Unlock()
+	r.configMu.Lock()
+	configFile := r.configFile
+	r.configMu.Unlock()
+
+	uptime := time.Since(r.started)
+	return [][2]string{
+		{"redis_version", serverVersion},
+		{"redis_mode", mode},
+		{"process_id", strconv.Itoa(os.Getpid())},
+		{"run_id", r.runID},
+		{"tcp_port", strconv.Itoa(port)},
+		{"uptime_in_seconds", strconv.FormatInt(int64(uptime/time.Second), 10)},
+		{"uptime_in_days", strconv.FormatInt(int64(uptime/(24*time.Hour)), 10)},
+		{"config_file", configFile},
+	}
+}
+
+// clientsInfo는 INFO clients 섹션의 필드들을 반환합니다.
+// blocked_clients는 BLPOP처럼 대기할 수 있는 명령어를 실행 중인 연결 수입니다.
+func clientsInfo(r *CommandRegistry) [][2]string {
+	return [][2]string{
+		{"connected_clients", strconv.Itoa(r.networkClientCount())},
+		{"blocked_clients", strconv.FormatInt(atomic.LoadInt64(&r.stats.blockedClients), 10)},
+		{"maxclients", strconv.FormatInt(r.maxClients.Load(), 10)},
+	}
+}
+
+// memoryInfo는 INFO memory 섹션의 필드들을 반환합니다.
+// used_memory는 Go 런타임의 힙 크기이고 used_memory_rss는 런타임이 운영체제에서 받은 메모리입니다.
+// used_memory_dataset은 maxmemory와 비교하는 데이터셋의 크기입니다 (저장소가 쓰기마다 갱신).
+func memoryInfo(r *CommandRegistry) [][2]string {
+	used, ms := r.memory.usedMemory()
+	return [][2]string{
+		{"used_memory", strconv.FormatInt(used, 10)},
+		{"used_memory_human", humanBytes(used)},
+		{"used_memory_rss", strconv.FormatUint(ms.Sys, 10)},
+		{"used_memory_peak", strconv.FormatInt(atomic.LoadInt64(&r.memory.peak), 10)},
+		{"used_memory_startup", strconv.FormatInt(atomic.LoadInt64(&r.memory.startup), 10)},
+		{"used_memory_dataset", strconv.FormatInt(r.store.UsedMemory(), 10)},
+		{"maxmemory", strconv.FormatInt(r.MaxMemory(), 10)},
+		{"maxmemory_policy", r.MaxMemoryPolicy()},
+	}
+}
+
+// persistenceInfo는 INFO persistence 섹션의 필드들을 반환합니다.
+func persistenceInfo(r *CommandRegistry) [][2]string {
+	p := r.persistence
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	aofEnabled := p.aof != nil
+	aofRewriting := aofEnabled && p.aof.Rewriting()
+
+	return [][2]string{
+		{"loading", "0"},
+		{"rdb_changes_since_last_save", strconv.FormatInt(atomic.LoadInt64(&p.dirty), 10)},
+		{"rdb_bgsave_in_progress", boolInfo(p.bgsaveInProgress)},
+		{"rdb_last_save_time", strconv.FormatInt(p.lastSave.Unix(), 10)},
+		{"rdb_last_bgsave_status", statusInfo(p.lastBgsaveErr)},
+		{"aof_enabled", boolInfo(aofEnabled)},
+		{"aof_rewrite_in_progress", boolInfo(aofRewriting)},
+		{"aof_last_bgrewrite_status", statusInfo(p.lastRewriteErr)},
+		{"aof_last_write_status", statusInfo(p.lastAOFWriteErr)},
+	}
+}
+
+// stats는 INFO stats 섹션이 보고하는 누적 통계입니다.
+// 모든 필드는 원자적으로 읽고 쓰며, blockedClients 외에는 CONFIG RESETSTAT으로 0으로 되돌립니다.
+type stats struct {
+	connectionsReceived int64 // 생성한 클라이언트 수, maxclients로 거부한 연결 제외 (total_connections_received)
+	rejectedConnections int64 // maxclients를 넘어 거부한 연결 수 (rejected_connections)
+	commandsProcessed   int64 // 실행한 명령어 수, 트랜잭션과 스크립트 안의 명령어 포함 (total_commands_processed)
+	expiredKeys         int64 // 만료되어 삭제된 키 수 (expired_keys)
+	evictedKeys         int64 // maxmemory 때문에 지운 키 수 (evicted_keys)
+	syncFull            int64 // 레플리카와의 전체 동기화 횟수 (sync_full)
+	keyspaceHits        int64 // 읽기 명령어가 찾은 키 수 (keyspace_hits)
+	keyspaceMisses      int64 // 읽기 명령어가 찾지 못한 키 수 (keyspace_misses)
+	blockedClients      int64 // 대기할 수 있는 명령어를 실행 중인 연결 수, 누적이 아닌 현재 값 (blocked_clients)
+
+	// opsPerSec은 최근의 초당 명령어 수입니다 (instantaneous_ops_per_sec, StartStatsCollector가 갱신).
+	opsPerSec instantaneousMetric
+}
+
+// keyExpired는 키가 만료되어 삭제되었을 때 호출됩니다 (store.OnKeyExpired로 등록됨).
+func (s *stats) keyExpired(key string) {
+	atomic.AddInt64(&s.expiredKeys, 1)
+}
+
+// reset은 모든 통계를 0으로 되돌립니다 (CONFIG RESETSTAT).
+func (s *stats) reset() {
+	atomic.StoreInt64(&s.connectionsReceived, 0)
+	atomic.StoreInt64(&s.commandsProcessed, 0)
+	atomic.StoreInt64(&s.rejectedConnections, 0)
+	atomic.StoreInt64(&s.expiredKeys, 0)
+	atomic.StoreInt64(&s.evictedKeys, 0)
+	atomic.StoreInt64(&s.syncFull, 0)
+	atomic.StoreInt64(&s.keyspaceHits, 0)
+	atomic.StoreInt64(&s.keyspaceMisses, 0)
+	s.opsPerSec.reset()
+}
+
+// statsInfo는 INFO stats 섹션의 필드들을 반환합니다.
+func statsInfo(r *CommandRegistry) [][2]string {
+	s := &r.stats
+	return [][2]string{
+		{"total_connections_received", strconv.FormatInt(atomic.LoadInt64(&s.connectionsReceived), 10)},
+		{"total_commands_processed", strconv.FormatInt(atomic.LoadInt64(&s.commandsProcessed), 10)},
+		{"instantaneous_ops_per_sec", strconv.FormatInt(s.opsPerSec.perSecond(), 10)},
+		{"rejected_connections", strconv.FormatInt(atomic.LoadInt64(&s.rejectedConnections), 10)},
+		{"expired_keys", strconv.FormatInt(atomic.LoadInt64(&s.expiredKeys), 10)},
+		{"evicted_keys", strconv.FormatInt(atomic.LoadInt64(&s.evictedKeys), 10)},
+		{"keyspace_hits", strconv.FormatInt(atomic.LoadInt64(&s.keyspaceHits), 10)},
+		{"keyspace_misses", strconv.FormatInt(atomic.LoadInt64(&s.keyspaceMisses), 10)},
+		{"sync_full", strconv.FormatInt(atomic.LoadInt64(&s.syncFull), 10)},
+	}
+}
+
+// replicationInfo는 INFO replication 섹션의 필드들을 반환합니다.
+// 레플리카이면 마스터 정보와 연결 상태를, 마스터이면 연결된 레플리카들(slaveN)을 보고합니다.
+func replicationInfo(r *CommandRegistry) [][2]string {
+	p := r.replication
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var fields [][2]string
+	if p.masterHost == "" {
+		fields = append(fields, [2]string{"role", "master"})
+	} else {
+		linkStatus := "down"
+		if p.masterLinkUp {
+			linkStatus = "up"
+		}
+		fields = append(fields,
+			[2]string{"role", "slave"},
+			[2]string{"master_host", p.masterHost},
+			[2]string{"master_port", strconv.Itoa(p.masterPort)},
+			[2]string{"master_link_status", linkStatus},
+			[2]string{"master_sync_in_progress", boolInfo(!p.masterLinkUp)},
+			[2]string{"slave_repl_offset", strconv.FormatInt(p.offset, 10)},
+		)
+	}
+
+	fields = append(fields, [2]string{"connected_slaves", strconv.Itoa(len(p.replicas))})
+	now := time.Now()
+	for i, rep := range p.replicas {
+		fields = append(fields, [2]string{"slave" + strconv.Itoa(i), rep.info(now)})
+	}
+
+	return append(fields,
+		[2]string{"master_failover_state", p.failoverStateLocked()},
+		[2]string{"master_replid", p.replID},
+		[2]string{"master_repl_offset", strconv.FormatInt(p.offset, 10)},
+	)
+}
+
+// boolInfo는 INFO 필드의 불리언 값을 "1"/"0"으로 변환합니다.
+func boolInfo(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// statusInfo는 마지막 작업의 결과를 INFO 필드 값("ok"/"err")으로 변환합니다.
+func statusInfo(err error) string {
+	if err != nil {
+		return "err"
+	}
+	return "ok"
+}
+
+// humanBytes는 바이트 수를 Redis의 *_human 필드 형식으로 바꿉니다 (512B, 1.50K, 2.00M, 1.00G).
+func humanBytes(n int64) string {
+	units := []string{"K", "M", "G", "T", "P"}
+	if n < 1024 {
+		return strconv.FormatInt(n, 10) + "B"
+	}
+	value := float64(n)
+	unit := ""
+	for _, u := range units {
+		if value < 1024 {
+			break
+		}
+		value /= 1024
+		unit = u
+	}
+	return strconv.FormatFloat(value, 'f', 2, 64) + unit
+}
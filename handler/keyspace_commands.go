@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/rdb"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// DelHandler는 DEL 명령어를 처리하는 핸들러입니다.
+//
+// Redis DEL 명령어 사양:
+//   - DEL key [key ...]
+//   - 타입에 관계없이 키들을 삭제하고, 실제로 삭제한 키 개수를 반환
+//   - 레플리카와 AOF는 만료되거나 MIGRATE로 옮겨진 키도 DEL로 전달받음
+//
+// 예시:
+//
+//	클라이언트: DEL a b
+//	서버: :1\r\n
+type DelHandler struct{}
+
+// Execute는 DEL 명령어를 실행합니다.
+func (h *DelHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "del"}
+	}
+	return protocol.Integer(store.DEL(args...)), nil
+}
+
+// DumpHandler는 DUMP 명령어를 처리하는 핸들러입니다.
+//
+// Redis DUMP 명령어 사양:
+//   - DUMP key
+//   - 키의 값을 RESTORE로 되살릴 수 있는 RDB 형식 페이로드로 직렬화 (만료 시간은 포함하지 않음)
+//   - 키가 없으면 nil
+//
+// 예시:
+//
+//	클라이언트: DUMP mykey
+//	서버: $13\r\n\x00\xc0\n\x0b\x00...\r\n
+type DumpHandler struct{}
+
+// Execute는 DUMP 명령어를 실행합니다.
+func (h *DumpHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "dump"}
+	}
+	entry, exists := store.Lookup(args[0])
+	if !exists {
+		return nil, nil
+	}
+	payload, err := rdb.Dump(entry.Value)
+	if err != nil {
+		return nil, &InvalidArgumentError{Message: err.Error()}
+	}
+	return protocol.BulkString(string(payload)), nil
+}
+
+// RestoreHandler는 RESTORE 명령어를 처리하는 핸들러입니다.
+//
+// Redis RESTORE 명령어 사양:
+//   - RESTORE key ttl serialized-value [REPLACE] [ABSTTL]
+//   - DUMP 페이로드로 키를 만듦
+//   - ttl: 밀리초 단위 만료 시간 (0이면 만료 없음), ABSTTL이면 Unix 시각(밀리초)
+//   - 키가 이미 있으면 REPLACE가 없는 한 BUSYKEY 에러
+//   - 만료 시간은 문자열에만 적용됨 (저장소가 다른 타입의 만료를 지원하지 않음)
+//
+// 예시:
+//
+//	클라이언트: RESTORE mykey 0 "\x00\xc0\n\x0b\x00..."
+//	서버: +OK\r\n
+type RestoreHandler struct{}
+
+// Execute는 RESTORE 명령어를 실행합니다.
+func (h *RestoreHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 3 {
+		return nil, &WrongNumberOfArgumentsError{Command: "restore"}
+	}
+	key, payload := args[0], args[2]
+
+	var replace, absTTL bool
+	for _, opt := range args[3:] {
+		switch strings.ToUpper(opt) {
+		case "REPLACE":
+			replace = true
+		case "ABSTTL":
+			absTTL = true
+		default:
+			return nil, &InvalidArgumentError{Message: "syntax error"}
+		}
+	}
+
+	ttl, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return nil, &InvalidArgumentError{Message: "value is not an integer or out of range"}
+	}
+	if ttl < 0 {
+		return nil, &InvalidArgumentError{Message: "Invalid TTL value, must be >= 0"}
+	}
+	var expireAt time.Time
+	switch {
+	case ttl == 0:
+	case absTTL:
+		expireAt = time.UnixMilli(ttl)
+	default:
+		expireAt = time.Now().Add(time.Duration(ttl) * time.Millisecond)
+	}
+
+	if _, exists := store.Lookup(key); exists && !replace {
+		return nil, &BusyKeyError{}
+	}
+	if err := rdb.Restore(store, key, []byte(payload), expireAt); err != nil {
+		return nil, &InvalidArgumentError{Message: "DUMP payload version or checksum are wrong"}
+	}
+	return okReply, nil
+}
+
+// ObjectHandler는 OBJECT 명령어를 처리하는 핸들러입니다.
+//
+// Redis OBJECT 명령어 사양 (지원하는 서브커맨드):
+//   - OBJECT ENCODING key: 값의 내부 인코딩 이름 (키가 없으면 nil)
+//   - OBJECT FREQ key: 키의 LFU 빈도 카운터 (키가 없으면 nil, maxmemory-policy가 LFU 정책일 때만)
+//   - OBJECT IDLETIME key: 키를 마지막으로 사용한 뒤 지난 초 (키가 없으면 nil, maxmemory-policy가 LFU 정책이 아닐 때만)
+//   - OBJECT HELP: 서브커맨드들의 사용법
+//
+// OBJECT로 키를 조회해도 키를 사용한 것으로 기록하지 않습니다.
+//
+// 예시:
+//
+//	클라이언트: OBJECT FREQ mykey
+//	서버: :5\r\n
+type ObjectHandler struct {
+	registry *CommandRegistry
+}
+
+// Execute는 OBJECT 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.BulkString: ENCODING의 결과
+//   - protocol.Integer: FREQ, IDLETIME의 결과
+//   - nil: 키가 없는 경우
+//   - error: 서브커맨드가 없거나 알 수 없는 경우, FREQ에서 LFU 정책이 아니거나 IDLETIME에서 LFU 정책인 경우
+func (h *ObjectHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.subcommands().dispatch(nil, args, store)
+}
+
+// subcommands는 OBJECT의 서브커맨드 목록입니다.
+func (h *ObjectHandler) subcommands() *subcommandTable {
+	return &subcommandTable{command: "OBJECT", subs: []subcommand{
+		{"ENCODING", 3, []string{"ENCODING <key>", "Return the kind of internal representation used in order to store the value",
+			"associated with a <key>."}, h.encoding},
+		{"FREQ", 3, []string{"FREQ <key>", "Return the access frequency index of the <key>. The returned integer is",
+			"proportional to the logarithm of the recent access frequency of the key."}, h.freq},
+		{"IDLETIME", 3, []string{"IDLETIME <key>", "Return the idle time of the <key>, that is the approximated number of",
+			"seconds elapsed since the last access to the key."}, h.idleTime},
+	}}
+}
+
+// encoding은 OBJECT ENCODING을 실행합니다. 키가 없으면 nil입니다.
+func (h *ObjectHandler) encoding(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	entry, exists := store.Lookup(args[1])
+	if !exists {
+		return nil, nil
+	}
+	return protocol.BulkString(objectEncoding(entry.Value)), nil
+}
+
+// freq는 OBJECT FREQ를 실행합니다. 키가 없으면 nil입니다.
+func (h *ObjectHandler) freq(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if !h.registry.tracksFrequency() {
+		return nil, &InvalidArgumentError{Message: "An LFU maxmemory policy is not selected, access frequency not tracked. " +
+			"Please note that when switching between policies at runtime LRU and LFU data will take some time to adjust."}
+	}
+	access, exists := store.Access(args[1])
+	if !exists {
+		return nil, nil
+	}
+	return protocol.Integer(access.Freq), nil
+}
+
+// idleTime은 OBJECT IDLETIME을 실행합니다. 키가 없으면 nil입니다.
+func (h *ObjectHandler) idleTime(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if h.registry.tracksFrequency() {
+		return nil, &InvalidArgumentError{Message: "An LFU maxmemory policy is selected, idle time not tracked. " +
+			"Please note that when switching between policies at runtime LRU and LFU data will take some time to adjust."}
+	}
+	access, exists := store.Access(args[1])
+	if !exists {
+		return nil, nil
+	}
+	return protocol.Integer(access.Idle / time.Second), nil
+}
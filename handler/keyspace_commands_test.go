@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestDel은 타입에 관계없이 키를 삭제하는지 테스트합니다.
+func TestDel(t *testing.T) {
+	s := store.NewStore()
+	s.SET("str", []byte("v"), nil)
+	s.RPUSH("list", "a")
+	s.ZADD("zset", 1, "m")
+
+	result, err := (&DelHandler{}).Execute([]string{"str", "list", "zset", "missing"}, s)
+	if err != nil || result != protocol.Integer(3) {
+		t.Errorf("Expected 3, got %v, %v", result, err)
+	}
+	if keys := s.Keys(); len(keys) != 0 {
+		t.Errorf("Expected empty keyspace, got %v", keys)
+	}
+}
+
+// TestDumpRestore는 DUMP 페이로드로 키를 되살리는지 테스트합니다.
+func TestDumpRestore(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	recorded := recordPropagation(registry)
+	registry.ExecuteForClient(client, "RPUSH", []string{"list", "a", "b"})
+	registry.ExecuteForClient(client, "SET", []string{"str", "value"})
+
+	// 테스트 케이스 1: 없는 키는 nil
+	if result, err := registry.ExecuteForClient(client, "DUMP", []string{"missing"}); err != nil || result != nil {
+		t.Errorf("Expected nil, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 2: 다른 키로 복원
+	payload, _ := registry.ExecuteForClient(client, "DUMP", []string{"list"})
+	if result, err := registry.ExecuteForClient(client, "RESTORE", []string{"copy", "0", string(payload.(protocol.BulkString))}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	if result, _ := registry.ExecuteForClient(client, "LRANGE", []string{"copy", "0", "-1"}); !reflect.DeepEqual(result, protocol.BulkStrings{"a", "b"}) {
+		t.Errorf("Expected [a b], got %v", result)
+	}
+
+	// 테스트 케이스 3: 이미 있는 키는 REPLACE가 있어야 덮어씀
+	if _, err := registry.ExecuteForClient(client, "RESTORE", []string{"str", "0", string(payload.(protocol.BulkString))}); err == nil || !strings.HasPrefix(err.Error(), "-BUSYKEY") {
+		t.Errorf("Expected BUSYKEY, got %v", err)
+	}
+	if _, err := registry.ExecuteForClient(client, "RESTORE", []string{"str", "0", string(payload.(protocol.BulkString)), "REPLACE"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if result, _ := registry.ExecuteForClient(client, "LLEN", []string{"str"}); result != protocol.Integer(2) {
+		t.Errorf("Expected replaced list of length 2, got %v", result)
+	}
+
+	// 테스트 케이스 4: 잘못된 페이로드와 TTL
+	if _, err := registry.ExecuteForClient(client, "RESTORE", []string{"bad", "0", "garbage"}); err == nil || !strings.Contains(err.Error(), "checksum are wrong") {
+		t.Errorf("Expected payload error, got %v", err)
+	}
+	if _, err := registry.ExecuteForClient(client, "RESTORE", []string{"bad", "-1", string(payload.(protocol.BulkString))}); err == nil {
+		t.Error("Expected error for negative TTL")
+	}
+
+	// 테스트 케이스 5: 상대 만료 시간은 ABSTTL로 바꿔 전달
+	strPayload, _ := registry.ExecuteForClient(client, "DUMP", []string{"copy"})
+	*recorded = nil
+	before := time.Now().UnixMilli()
+	registry.ExecuteForClient(client, "RESTORE", []string{"ttl", "60000", string(strPayload.(protocol.BulkString))})
+	command := (*recorded)[0][0]
+	expireAt, _ := strconv.ParseInt(command[2], 10, 64)
+	if command[0] != "RESTORE" || command[len(command)-1] != "ABSTTL" || expireAt < before+60000 {
+		t.Errorf("Expected RESTORE with absolute TTL, got %q", command)
+	}
+}
+
+// TestMigrate는 MIGRATE가 키를 다른 서버로 옮기는지 테스트합니다.
+func TestMigrate(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	recorded := recordPropagation(registry)
+	target := NewCommandRegistry(store.NewStore())
+	targetClient, _ := newTestClient(target)
+	port := strconv.Itoa(serveRegistry(t, target))
+
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	registry.ExecuteForClient(client, "SET", []string{"b", "2", "PX", "60000"})
+	registry.ExecuteForClient(client, "RPUSH", []string{"c", "x", "y"})
+	*recorded = nil
+
+	// 테스트 케이스 1: 키 하나를 옮기면 이 서버에서 삭제하고 DEL을 전달
+	if result, err := registry.ExecuteForClient(client, "MIGRATE", []string{"127.0.0.1", port, "a", "0", "1000"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	if result, _ := target.ExecuteForClient(targetClient, "GET", []string{"a"}); !isBulk(result, "1") {
+		t.Errorf("Expected '1' on target, got %v", result)
+	}
+	if result, _ := registry.ExecuteForClient(client, "GET", []string{"a"}); result != nil {
+		t.Errorf("Expected key to be deleted, got %v", result)
+	}
+	if expected := [][][]string{{{"DEL", "a"}}}; !reflect.DeepEqual(*recorded, expected) {
+		t.Errorf("Expected %q, got %q", expected, *recorded)
+	}
+
+	// 테스트 케이스 2: KEYS와 COPY, 만료 시간 유지
+	if result, err := registry.ExecuteForClient(client, "MIGRATE", []string{"127.0.0.1", port, "", "0", "1000", "COPY", "KEYS", "b", "c", "missing"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	if result, _ := target.ExecuteForClient(targetClient, "LRANGE", []string{"c", "0", "-1"}); !reflect.DeepEqual(result, protocol.BulkStrings{"x", "y"}) {
+		t.Errorf("Expected [x y] on target, got %v", result)
+	}
+	if entry, _ := target.store.Lookup("b"); entry.ExpireAt.IsZero() {
+		t.Error("Expected TTL to be migrated")
+	}
+	if result, _ := registry.ExecuteForClient(client, "LLEN", []string{"c"}); result != protocol.Integer(2) {
+		t.Errorf("Expected key to be kept with COPY, got %v", result)
+	}
+
+	// 테스트 케이스 3: 대상에 같은 키가 있으면 REPLACE가 없는 한 실패하고 키를 남김
+	_, err := registry.ExecuteForClient(client, "MIGRATE", []string{"127.0.0.1", port, "c", "0", "1000"})
+	if err == nil || !strings.Contains(err.Error(), "Target instance replied with error: BUSYKEY") {
+		t.Errorf("Expected BUSYKEY from target, got %v", err)
+	}
+	if result, _ := registry.ExecuteForClient(client, "LLEN", []string{"c"}); result != protocol.Integer(2) {
+		t.Errorf("Expected key to be kept after a failed migration, got %v", result)
+	}
+	if result, err := registry.ExecuteForClient(client, "MIGRATE", []string{"127.0.0.1", port, "c", "0", "1000", "REPLACE"}); err != nil || !isStatus(result, "OK") {
+		t.Errorf("Expected OK with REPLACE, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 4: 옮길 키가 없는 경우, 잘못된 인자, 연결 실패
+	if result, _ := registry.ExecuteForClient(client, "MIGRATE", []string{"127.0.0.1", port, "missing", "0", "1000"}); !reflect.DeepEqual(result, protocol.SimpleString("NOKEY")) {
+		t.Errorf("Expected NOKEY, got %v", result)
+	}
+	if _, err := registry.ExecuteForClient(client, "MIGRATE", []string{"127.0.0.1", port, "b", "0", "1000", "KEYS", "b"}); err == nil {
+		t.Error("Expected error for KEYS with a non-empty key argument")
+	}
+	if _, err := registry.ExecuteForClient(client, "MIGRATE", []string{"127.0.0.1", "1", "b", "0", "1000"}); err == nil || !strings.HasPrefix(err.Error(), "-IOERR") {
+		t.Errorf("Expected IOERR, got %v", err)
+	}
+}
@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// latencyHistoryLen은 이벤트마다 기억하는 지연 기록의 수입니다 (Redis의 LATENCY_TS_LEN과 동일).
+const latencyHistoryLen = 160
+
+// 지연을 기록하는 이벤트 이름 (Redis와 동일)
+const (
+	latencyEventCommand     = "command"      // 명령어 실행 (대기하는 명령어의 대기 시간은 제외)
+	latencyEventExpireCycle = "expire-cycle" // 만료된 키를 주기적으로 지우는 작업 한 번
+	latencyEventFork        = "fork"         // BGSAVE, BGREWRITEAOF, 전체 동기화의 스냅샷 생성
+)
+
+// latencySample은 지연 기록 하나입니다.
+type latencySample struct {
+	time    int64 // 기록한 시각 (Unix 시간, 초)
+	latency int64 // 지연 시간 (밀리초)
+}
+
+// latencyEvent는 이벤트 하나의 지연 기록입니다.
+// 기록은 최근 latencyHistoryLen개만 순환 버퍼에 남고, 같은 초의 기록은 가장 큰 값 하나로 합칩니다.
+type latencyEvent struct {
+	samples [latencyHistoryLen]latencySample
+	next    int   // 다음 기록을 쓸 위치
+	max     int64 // 지금까지 가장 큰 지연 시간 (LATENCY LATEST의 최대값)
+}
+
+// history는 남아 있는 기록을 오래된 것부터 반환합니다.
+func (e *latencyEvent) history() []latencySample {
+	result := make([]latencySample, 0, latencyHistoryLen)
+	for i := 0; i < latencyHistoryLen; i++ {
+		if s := e.samples[(e.next+i)%latencyHistoryLen]; s.time != 0 {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// latencyMonitor는 latency-monitor-threshold 이상 걸린 작업을 이벤트별로 기록합니다.
+// 기준값이 0이면 아무것도 기록하지 않습니다 (기본값).
+type latencyMonitor struct {
+	threshold atomic.Int64 // 밀리초
+
+	mu     sync.Mutex
+	events map[string]*latencyEvent
+}
+
+// newLatencyMonitor는 비어 있고 꺼진 latencyMonitor를 만듭니다.
+func newLatencyMonitor() *latencyMonitor {
+	return &latencyMonitor{events: make(map[string]*latencyEvent)}
+}
+
+// SetLatencyMonitorThreshold는 지연을 기록할 기준 시간(밀리초)을 설정합니다 (0이면 끔).
+func (r *CommandRegistry) SetLatencyMonitorThreshold(ms int) {
+	r.latency.threshold.Store(int64(ms))
+}
+
+// addSample은 event가 duration만큼 걸렸음을 알립니다. 기준 시간 이상이면 기록합니다.
+func (m *latencyMonitor) addSample(event string, duration time.Duration) {
+	threshold := m.threshold.Load()
+	latency := duration.Milliseconds()
+	if threshold == 0 || latency < threshold {
+		return
+	}
+	now := time.Now().Unix()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.events[event]
+	if !ok {
+		e = &latencyEvent{}
+		m.events[event] = e
+	}
+	if latency > e.max {
+		e.max = latency
+	}
+	last := &e.samples[(e.next+latencyHistoryLen-1)%latencyHistoryLen]
+	if last.time == now {
+		if latency > last.latency {
+			last.latency = latency
+		}
+		return
+	}
+	e.samples[e.next] = latencySample{time: now, latency: latency}
+	e.next = (e.next + 1) % latencyHistoryLen
+}
+
+// beginSnapshot은 store.BeginSnapshot에 걸린 시간을 fork 이벤트로 기록합니다.
+// Redis에서 fork가 하는 일(저장할 시점의 데이터셋 고정)을 이 서버에서는 스냅샷 생성이 합니다.
+func (m *latencyMonitor) beginSnapshot(s *store.Store) *store.SnapshotView {
+	start := time.Now()
+	view := s.BeginSnapshot()
+	m.addSample(latencyEventFork, time.Since(start))
+	return view
+}
+
+// LatencyHandler는 LATENCY 명령어를 처리하는 핸들러입니다.
+//
+// Redis LATENCY 명령어 사양:
+//   - LATENCY LATEST: 이벤트마다 [이름, 마지막 기록 시각, 마지막 지연, 최대 지연] (밀리초)
+//   - LATENCY HISTORY event: 이벤트의 기록들 [시각, 지연] (오래된 것부터, 최대 160개)
+//   - LATENCY RESET [event ...]: 이벤트들의 기록을 지우고 지운 이벤트 수를 반환 (생략하면 모두)
+//   - LATENCY DOCTOR: 기록을 분석한 사람이 읽을 수 있는 보고서
+//   - LATENCY HELP: 서브커맨드들의 사용법
+//
+// 기록하는 이벤트: command (명령어 실행), expire-cycle (만료된 키 정리), fork (저장용 스냅샷 생성)
+//
+// 예시:
+//
+//	클라이언트: LATENCY LATEST
+//	서버: *1\r\n*4\r\n$7\r\ncommand\r\n:1700000000\r\n:250\r\n:250\r\n
+type LatencyHandler struct {
+	latency *latencyMonitor
+}
+
+// Execute는 LATENCY 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.Array: LATEST, HISTORY의 결과
+//   - protocol.Integer: RESET으로 지운 이벤트 수
+//   - protocol.BulkString: DOCTOR의 보고서
+//   - error: 서브커맨드가 없거나 알 수 없는 경우, 인자 개수가 잘못된 경우
+func (h *LatencyHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.subcommands().dispatch(nil, args, store)
+}
+
+// subcommands는 LATENCY의 서브커맨드 목록입니다.
+func (h *LatencyHandler) subcommands() *subcommandTable {
+	return &subcommandTable{command: "LATENCY", subs: []subcommand{
+		{"DOCTOR", 2, []string{"DOCTOR", "Return a human readable latency analysis report."}, h.doctor},
+		{"HISTORY", 3, []string{"HISTORY <event>", "Return time-latency samples for the <event> class."}, h.history},
+		{"LATEST", 2, []string{"LATEST", "Return the latest latency samples for all events."}, h.latest},
+		{"RESET", -2, []string{"RESET [<event> ...]", "Reset latency data of one or more <event> classes.",
+			"(default: reset all data for all event classes)"}, h.reset},
+	}}
+}
+
+// latest는 LATENCY LATEST를 실행합니다.
+func (h *LatencyHandler) latest(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	m := h.latency
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := protocol.Array{}
+	for _, name := range m.eventNames() {
+		e := m.events[name]
+		last := e.samples[(e.next+latencyHistoryLen-1)%latencyHistoryLen]
+		result = append(result, protocol.Array{protocol.BulkString(name), protocol.Integer(last.time), protocol.Integer(last.latency), protocol.Integer(e.max)})
+	}
+	return result, nil
+}
+
+// history는 LATENCY HISTORY를 실행합니다. 기록이 없는 이벤트는 빈 배열입니다.
+func (h *LatencyHandler) history(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	m := h.latency
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := protocol.Array{}
+	if e, ok := m.events[strings.ToLower(args[1])]; ok {
+		for _, s := range e.history() {
+			result = append(result, protocol.Array{protocol.Integer(s.time), protocol.Integer(s.latency)})
+		}
+	}
+	return result, nil
+}
+
+// reset은 LATENCY RESET을 실행하고 지운 이벤트 수를 반환합니다.
+func (h *LatencyHandler) reset(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	m := h.latency
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(args) == 1 {
+		reset := len(m.events)
+		m.events = make(map[string]*latencyEvent)
+		return protocol.Integer(reset), nil
+	}
+	reset := 0
+	for _, name := range args[1:] {
+		if _, ok := m.events[strings.ToLower(name)]; ok {
+			delete(m.events, strings.ToLower(name))
+			reset++
+		}
+	}
+	return protocol.Integer(reset), nil
+}
+
+// doctor는 LATENCY DOCTOR를 실행합니다.
+func (h *LatencyHandler) doctor(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return protocol.BulkString(h.latency.doctor()), nil
+}
+
+// eventNames는 기록이 있는 이벤트 이름들을 이름 순으로 반환합니다. m.mu를 잡고 호출해야 합니다.
+func (m *latencyMonitor) eventNames() []string {
+	names := make([]string, 0, len(m.events))
+	for name := range m.events {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// doctor는 LATENCY DOCTOR 보고서를 만듭니다.
+// 이벤트마다 지연 횟수, 평균, 평균 편차, 기록 사이의 평균 간격, 최대 지연을 보고합니다.
+func (m *latencyMonitor) doctor() string {
+	if m.threshold.Load() == 0 {
+		return "I'm sorry, Dave, I can't do that. Latency monitoring is disabled in this Redis instance. " +
+			"You may set latency-monitor-threshold <milliseconds> in order to enable it. " +
+			"If we weren't in a deep space mission I'd suggest to take a look at https://redis.io/topics/latency-monitor.\n"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.events) == 0 {
+		return "Dave, no latency spike was observed during the lifetime of this Redis instance, not in the slightest bit. " +
+			"I honestly think you ought to sleep tonight.\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Dave, I have observed latency spikes in this Redis instance. You don't mind talking about it, do you Dave?\n\n")
+	for i, name := range m.eventNames() {
+		e := m.events[name]
+		history := e.history()
+
+		var sum int64
+		for _, s := range history {
+			sum += s.latency
+		}
+		avg := sum / int64(len(history))
+		var deviation int64
+		for _, s := range history {
+			if d := s.latency - avg; d > 0 {
+				deviation += d
+			} else {
+				deviation -= d
+			}
+		}
+		deviation /= int64(len(history))
+		var period float64
+		if len(history) > 1 {
+			period = float64(history[len(history)-1].time-history[0].time) / float64(len(history)-1)
+		}
+
+		fmt.Fprintf(&sb, "%d. %s: %d latency spikes (average %dms, mean deviation %dms, period %.2f sec). Worst all time event %dms.\n",
+			i+1, name, len(history), avg, deviation, period, e.max)
+	}
+	sb.WriteString("\nI have a few advices for you:\n\n")
+	sb.WriteString("- Avoid O(N) commands such as KEYS or LRANGE key 0 -1 on big values: they block every other client while running.\n")
+	sb.WriteString("- Large datasets make snapshots for BGSAVE and replication (fork) slower.\n")
+	return sb.String()
+}
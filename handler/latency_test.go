@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestLatencyMonitor는 기준 시간 이상 걸린 명령어가 기록되고 조회, 초기화되는지 테스트합니다.
+func TestLatencyMonitor(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: 기준 시간이 0이면 기록하지 않음
+	registry.ExecuteForClient(client, "DEBUG", []string{"SLEEP", "0.02"})
+	if result, _ := registry.Execute("LATENCY", []string{"LATEST"}); len(result.(protocol.Array)) != 0 {
+		t.Errorf("Expected no events while disabled, got %v", result)
+	}
+	if result, _ := registry.Execute("LATENCY", []string{"DOCTOR"}); !strings.Contains(string(result.(protocol.BulkString)), "disabled") {
+		t.Errorf("Expected disabled report, got %v", result)
+	}
+
+	// 테스트 케이스 2: 기준 시간 이상 걸린 명령어만 기록
+	registry.SetLatencyMonitorThreshold(10)
+	registry.ExecuteForClient(client, "PING", nil)
+	registry.ExecuteForClient(client, "DEBUG", []string{"SLEEP", "0.02"})
+
+	result, err := registry.Execute("LATENCY", []string{"LATEST"})
+	if err != nil {
+		t.Fatalf("LATENCY LATEST: %v", err)
+	}
+	latest := result.(protocol.Array)
+	if len(latest) != 1 {
+		t.Fatalf("Expected one event, got %v", latest)
+	}
+	event := latest[0].(protocol.Array)
+	if event[0] != protocol.BulkString("command") || event[2].(protocol.Integer) < 20 || event[3].(protocol.Integer) < 20 {
+		t.Errorf("Expected command event of at least 20ms, got %v", event)
+	}
+	if ts := int64(event[1].(protocol.Integer)); time.Now().Unix()-ts > 5 {
+		t.Errorf("Expected recent timestamp, got %d", ts)
+	}
+
+	history, _ := registry.Execute("LATENCY", []string{"HISTORY", "command"})
+	if len(history.(protocol.Array)) != 1 {
+		t.Errorf("Expected one history sample, got %v", history)
+	}
+	if result, _ := registry.Execute("LATENCY", []string{"DOCTOR"}); !strings.Contains(string(result.(protocol.BulkString)), "1. command: 1 latency spikes") {
+		t.Errorf("Expected doctor report for command, got %v", result)
+	}
+
+	// 테스트 케이스 3: RESET은 지운 이벤트 수를 반환
+	if result, _ := registry.Execute("LATENCY", []string{"RESET", "nosuch"}); result != protocol.Integer(0) {
+		t.Errorf("LATENCY RESET nosuch: expected 0, got %v", result)
+	}
+	if result, _ := registry.Execute("LATENCY", []string{"RESET"}); result != protocol.Integer(1) {
+		t.Errorf("LATENCY RESET: expected 1, got %v", result)
+	}
+	if result, _ := registry.Execute("LATENCY", []string{"HISTORY", "command"}); len(result.(protocol.Array)) != 0 {
+		t.Errorf("Expected empty history after reset, got %v", result)
+	}
+}
+
+// TestLatencySamplesMerge는 같은 초의 기록이 가장 큰 값 하나로 합쳐지는지 테스트합니다.
+func TestLatencySamplesMerge(t *testing.T) {
+	m := newLatencyMonitor()
+	m.threshold.Store(1)
+	m.addSample("fork", 5*time.Millisecond)
+	m.addSample("fork", 9*time.Millisecond)
+	m.addSample("fork", 3*time.Millisecond)
+
+	e := m.events["fork"]
+	history := e.history()
+	if len(history) > 2 || e.max != 9 {
+		t.Errorf("Expected merged samples with max 9ms, got %v max %d", history, e.max)
+	}
+	if last := history[len(history)-1]; last.latency < 3 {
+		t.Errorf("Unexpected last sample %v", last)
+	}
+}
@@ -5,19 +5,14 @@ package handler
 import (
 	"strconv"
 
+	"github.com/codecrafters-io/redis-starter-go/protocol"
 	"github.com/codecrafters-io/redis-starter-go/store"
 )
 
-// NullArray는 RESP null array (*-1\r\n)를 나타내는 특별한 타입입니다.
-type NullArray struct{}
-
-// Redis BLPOP timeout시 null array 반환을 위한 싱글톤 인스턴스
-var nullArray = &NullArray{}
-
 // RPushHandler는 RPUSH 명령어를 처리하는 핸들러입니다.
 type RPushHandler struct{}
 
-func (h *RPushHandler) Execute(args []string, store *store.Store) (interface{}, error) {
+func (h *RPushHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
 	// 최소 인자 개수 검증 (key + 최소 1개 값)
 	if len(args) < 2 {
 		return nil, &WrongNumberOfArgumentsError{Command: "rpush"}
@@ -32,13 +27,13 @@ func (h *RPushHandler) Execute(args []string, store *store.Store) (interface{},
 
 	// 새로운 리스트 길이를 Integer로 반환
 	// Redis RPUSH는 항상 정수를 반환함
-	return newLength, nil
+	return protocol.Integer(newLength), nil
 }
 
 // LRangeHandler는 LRANGE 명령어를 처리하는 핸들러입니다.
 type LRangeHandler struct{}
 
-func (h *LRangeHandler) Execute(args []string, store *store.Store) (interface{}, error) {
+func (h *LRangeHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
 	// 정확한 인자 개수 검증 (key, start, stop)
 	if len(args) != 3 {
 		return nil, &WrongNumberOfArgumentsError{Command: "lrange"}
@@ -68,13 +63,13 @@ func (h *LRangeHandler) Execute(args []string, store *store.Store) (interface{},
 
 	// 결과 배열 반환
 	// []string 타입은 main.go의 writeResponse에서 Array로 변환됨
-	return elements, nil
+	return protocol.BulkStrings(elements), nil
 }
 
 // LPushHandler는 LPUSH 명령어를 처리하는 핸들러입니다.
 type LPushHandler struct{}
 
-func (h *LPushHandler) Execute(args []string, store *store.Store) (interface{}, error) {
+func (h *LPushHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
 	// 최소 인자 개수 검증 (key + 최소 1개 값)
 	// Redis와 동일한 에러 메시지 형식 준수
 	if len(args) < 2 {
@@ -92,13 +87,13 @@ func (h *LPushHandler) Execute(args []string, store *store.Store) (interface{},
 
 	// 새로운 리스트 길이를 Integer로 반환
 	// Redis LPUSH는 항상 정수를 반환함 (RESP Integer 타입)
-	return newLength, nil
+	return protocol.Integer(newLength), nil
 }
 
 // LLenHandler는 LLEN 명령어를 처리하는 핸들러입니다.
 type LLenHandler struct{}
 
-func (h *LLenHandler) Execute(args []string, store *store.Store) (interface{}, error) {
+func (h *LLenHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
 	// 정확한 인자 개수 검증 (key 하나만 필요)
 	if len(args) != 1 {
 		return nil, &WrongNumberOfArgumentsError{Command: "llen"}
@@ -112,7 +107,7 @@ func (h *LLenHandler) Execute(args []string, store *store.Store) (interface{}, e
 
 	// 길이를 Integer로 반환
 	// Redis LLEN은 항상 정수를 반환함 (RESP Integer 타입)
-	return length, nil
+	return protocol.Integer(length), nil
 }
 
 // LPopHandler는 LPOP 명령어를 처리하는 핸들러입니다.
@@ -120,7 +115,7 @@ type LPopHandler struct{}
 
 // Execute는 LPOP 명령어를 실행합니다.
 // Redis 6.2+ 구문: LPOP key [count]
-func (h *LPopHandler) Execute(args []string, store *store.Store) (interface{}, error) {
+func (h *LPopHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
 	// 인자 개수 검증 (key 또는 key + count)
 	if len(args) < 1 || len(args) > 2 {
 		return nil, &WrongNumberOfArgumentsError{Command: "lpop"}
@@ -150,14 +145,14 @@ func (h *LPopHandler) Execute(args []string, store *store.Store) (interface{}, e
 			if ptr == nil {
 				return nil, nil
 			}
-			return *ptr, nil
+			return protocol.BulkString(*ptr), nil
 		} else if result == nil {
 			return nil, nil
 		}
 	}
 
-	// count 지정 모드: []string 그대로 반환
-	return result, nil
+	// count 지정 모드: []string을 Bulk String 배열로 반환
+	return protocol.BulkStrings(result.([]string)), nil
 }
 
 // BLPopHandler는 BLPOP 명령어를 처리하는 핸들러입니다.
@@ -165,10 +160,62 @@ type BLPopHandler struct{}
 
 // Execute는 BLPOP 명령어를 실행합니다.
 // Redis 구문: BLPOP key [key ...] timeout
-func (h *BLPopHandler) Execute(args []string, store *store.Store) (interface{}, error) {
+func (h *BLPopHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	keys, timeoutFloat, err := parseBLPopArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store의 blocking BLPOP 메소드 호출
+	result := store.BLPOPBlocking(keys, timeoutFloat, nil)
+
+	// 결과가 있으면 [key, value] 배열로 반환
+	if result != nil {
+		return protocol.BulkStrings{result.Key, result.Value}, nil
+	}
+
+	// 타임아웃이 발생하여 null array 반환
+	return protocol.NullArray{}, nil
+}
+
+// ExecuteWithClient는 연결에서 받은 BLPOP 명령어를 실행합니다.
+// 대기하는 동안 연결이 끊어지면 대기를 취소하여, 끊어진 클라이언트가 값을 가져가지 않도록 합니다.
+func (h *BLPopHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	keys, timeoutFloat, err := parseBLPopArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	disconnected, stop := client.watchDisconnect()
+	result := store.BLPOPBlocking(keys, timeoutFloat, disconnected)
+	stop()
+
+	if result != nil {
+		return protocol.BulkStrings{result.Key, result.Value}, nil
+	}
+	return protocol.NullArray{}, nil
+}
+
+// ExecuteNonBlocking은 대기하지 않고 BLPOP 명령어를 실행합니다.
+// 트랜잭션(EXEC) 안에서는 다른 클라이언트가 값을 넣을 수 없으므로,
+// Redis와 동일하게 timeout이 즉시 만료된 것처럼 동작합니다.
+func (h *BLPopHandler) ExecuteNonBlocking(args []string, store *store.Store) (protocol.Reply, error) {
+	keys, _, err := parseBLPopArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if result := store.BLPOP(keys); result != nil {
+		return protocol.BulkStrings{result.Key, result.Value}, nil
+	}
+	return protocol.NullArray{}, nil
+}
+
+// parseBLPopArgs는 BLPOP 인자를 키 목록과 timeout(초)으로 분리합니다.
+func parseBLPopArgs(args []string) ([]string, float64, error) {
 	// 인자 개수 검증 (최소 2개: key + timeout)
 	if len(args) < 2 {
-		return nil, &WrongNumberOfArgumentsError{Command: "blpop"}
+		return nil, 0, &WrongNumberOfArgumentsError{Command: "blpop"}
 	}
 
 	// 마지막 인자는 timeout
@@ -178,28 +225,19 @@ func (h *BLPopHandler) Execute(args []string, store *store.Store) (interface{},
 	// timeout 파싱 (float으로)
 	timeoutFloat, err := strconv.ParseFloat(timeoutStr, 64)
 	if err != nil {
-		return nil, &InvalidArgumentError{
+		return nil, 0, &InvalidArgumentError{
 			Message: "timeout is not a float or out of range",
 		}
 	}
 
 	// timeout이 음수이면 에러
 	if timeoutFloat < 0 {
-		return nil, &InvalidArgumentError{
+		return nil, 0, &InvalidArgumentError{
 			Message: "timeout is negative",
 		}
 	}
 
-	// Store의 blocking BLPOP 메소드 호출
-	result := store.BLPOPBlocking(keys, timeoutFloat)
-
-	// 결과가 있으면 [key, value] 배열로 반환
-	if result != nil {
-		return []string{result.Key, result.Value}, nil
-	}
-
-	// 타임아웃이 발생하여 null array 반환
-	return nullArray, nil
+	return keys, timeoutFloat, nil
 }
 
 // TODO: 향후 구현할 List 명령어들
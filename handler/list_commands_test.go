@@ -3,6 +3,7 @@ package handler
 import (
 	"testing"
 
+	"github.com/codecrafters-io/redis-starter-go/protocol"
 	"github.com/codecrafters-io/redis-starter-go/store"
 )
 
@@ -16,7 +17,7 @@ func TestRPushHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("RPUSH failed: %v", err)
 	}
-	if result != 1 {
+	if result != protocol.Integer(1) {
 		t.Errorf("Expected length 1, got %v", result)
 	}
 
@@ -25,7 +26,7 @@ func TestRPushHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("RPUSH with multiple values failed: %v", err)
 	}
-	if result != 3 {
+	if result != protocol.Integer(3) {
 		t.Errorf("Expected length 3, got %v", result)
 	}
 
@@ -52,7 +53,7 @@ func TestLPushHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LPUSH on new list failed: %v", err)
 	}
-	if result != 1 {
+	if result != protocol.Integer(1) {
 		t.Errorf("Expected length 1, got %v", result)
 	}
 
@@ -68,7 +69,7 @@ func TestLPushHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LPUSH to existing list failed: %v", err)
 	}
-	if result != 2 {
+	if result != protocol.Integer(2) {
 		t.Errorf("Expected length 2, got %v", result)
 	}
 
@@ -84,7 +85,7 @@ func TestLPushHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LPUSH with multiple values failed: %v", err)
 	}
-	if result != 3 {
+	if result != protocol.Integer(3) {
 		t.Errorf("Expected length 3, got %v", result)
 	}
 
@@ -148,7 +149,7 @@ func TestLRangeHandler(t *testing.T) {
 	}
 
 	expected := []string{"first", "second", "third"}
-	if !equalStringSlices(result.([]string), expected) {
+	if !equalStringSlices(result.(protocol.BulkStrings), expected) {
 		t.Errorf("Expected %v, got %v", expected, result)
 	}
 
@@ -159,7 +160,7 @@ func TestLRangeHandler(t *testing.T) {
 	}
 
 	expected = []string{"third", "fourth", "fifth"}
-	if !equalStringSlices(result.([]string), expected) {
+	if !equalStringSlices(result.(protocol.BulkStrings), expected) {
 		t.Errorf("Expected %v, got %v", expected, result)
 	}
 
@@ -170,7 +171,7 @@ func TestLRangeHandler(t *testing.T) {
 	}
 
 	expected = []string{"first", "second", "third", "fourth", "fifth"}
-	if !equalStringSlices(result.([]string), expected) {
+	if !equalStringSlices(result.(protocol.BulkStrings), expected) {
 		t.Errorf("Expected %v, got %v", expected, result)
 	}
 
@@ -180,7 +181,7 @@ func TestLRangeHandler(t *testing.T) {
 		t.Fatalf("LRANGE 10 20 failed: %v", err)
 	}
 
-	if len(result.([]string)) != 0 {
+	if len(result.(protocol.BulkStrings)) != 0 {
 		t.Errorf("Expected empty slice, got %v", result)
 	}
 
@@ -190,7 +191,7 @@ func TestLRangeHandler(t *testing.T) {
 		t.Fatalf("LRANGE on non-existent key failed: %v", err)
 	}
 
-	if len(result.([]string)) != 0 {
+	if len(result.(protocol.BulkStrings)) != 0 {
 		t.Errorf("Expected empty slice for non-existent key, got %v", result)
 	}
 
@@ -221,7 +222,7 @@ func TestLRangeHandler(t *testing.T) {
 		t.Fatalf("LRANGE 3 1 failed: %v", err)
 	}
 
-	if len(result.([]string)) != 0 {
+	if len(result.(protocol.BulkStrings)) != 0 {
 		t.Errorf("Expected empty slice for reversed range, got %v", result)
 	}
 
@@ -232,7 +233,7 @@ func TestLRangeHandler(t *testing.T) {
 	}
 
 	expected = []string{"third"}
-	if !equalStringSlices(result.([]string), expected) {
+	if !equalStringSlices(result.(protocol.BulkStrings), expected) {
 		t.Errorf("Expected %v, got %v", expected, result)
 	}
 }
@@ -247,7 +248,7 @@ func TestLLenHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LLEN on non-existent key should not fail: %v", err)
 	}
-	if result != 0 {
+	if result != protocol.Integer(0) {
 		t.Errorf("Expected 0 for non-existent key, got %v", result)
 	}
 
@@ -257,7 +258,7 @@ func TestLLenHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LLEN on single element list failed: %v", err)
 	}
-	if result != 1 {
+	if result != protocol.Integer(1) {
 		t.Errorf("Expected 1 for single element list, got %v", result)
 	}
 
@@ -267,32 +268,32 @@ func TestLLenHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LLEN on multi element list failed: %v", err)
 	}
-	if result != 5 {
+	if result != protocol.Integer(5) {
 		t.Errorf("Expected 5 for multi element list, got %v", result)
 	}
 
 	// 테스트 케이스 4: 동적 리스트 변화
 	dynamicKey := "dynamic"
 	result, _ = handler.Execute([]string{dynamicKey}, dataStore)
-	if result != 0 {
+	if result != protocol.Integer(0) {
 		t.Errorf("Initial state should be 0, got %v", result)
 	}
 
 	dataStore.RPUSH(dynamicKey, "item1")
 	result, _ = handler.Execute([]string{dynamicKey}, dataStore)
-	if result != 1 {
+	if result != protocol.Integer(1) {
 		t.Errorf("After 1 RPUSH should be 1, got %v", result)
 	}
 
 	dataStore.RPUSH(dynamicKey, "item2", "item3")
 	result, _ = handler.Execute([]string{dynamicKey}, dataStore)
-	if result != 3 {
+	if result != protocol.Integer(3) {
 		t.Errorf("After adding 2 more should be 3, got %v", result)
 	}
 
 	dataStore.LPUSH(dynamicKey, "front1", "front2")
 	result, _ = handler.Execute([]string{dynamicKey}, dataStore)
-	if result != 5 {
+	if result != protocol.Integer(5) {
 		t.Errorf("After LPUSH 2 more should be 5, got %v", result)
 	}
 
@@ -322,7 +323,7 @@ func TestLLenHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LLEN on large list failed: %v", err)
 	}
-	if result != expectedSize {
+	if result != protocol.Integer(expectedSize) {
 		t.Errorf("Expected %d for large list, got %v", expectedSize, result)
 	}
 }
\ No newline at end of file
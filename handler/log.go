@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// loglevel의 값들 (Redis와 동일, 아래로 갈수록 적게 기록)
+const (
+	LogLevelDebug   = "debug"   // 개발과 테스트용 (연결과 끊김 등 모든 이벤트)
+	LogLevelVerbose = "verbose" // 자주 일어나지만 보통은 필요 없는 이벤트 (잘못된 요청으로 끊은 연결 등)
+	LogLevelNotice  = "notice"  // 운영 환경에 적당한 양 (기본값, 시작과 종료, 설정 변경)
+	LogLevelWarning = "warning" // 중요하거나 문제가 있는 이벤트만
+	LogLevelNothing = "nothing" // 아무것도 기록하지 않음
+)
+
+// LevelVerbose는 slog에 없는 Redis의 verbose 수준입니다 (slog.LevelDebug와 LevelInfo 사이).
+const LevelVerbose = slog.LevelInfo - 2
+
+// levelNothing은 어떤 기록보다도 높은 수준입니다 (loglevel nothing).
+const levelNothing = slog.Level(1 << 10)
+
+// logLevels는 loglevel의 값과 slog 수준의 대응입니다 (notice는 slog.LevelInfo, warning은 slog.LevelWarn).
+var logLevels = []struct {
+	name  string
+	level slog.Level
+}{
+	{LogLevelDebug, slog.LevelDebug},
+	{LogLevelVerbose, LevelVerbose},
+	{LogLevelNotice, slog.LevelInfo},
+	{LogLevelWarning, slog.LevelWarn},
+	{LogLevelNothing, levelNothing},
+}
+
+// ParseLogLevel은 loglevel 값을 확인하고 소문자로 정규화합니다.
+//
+// 에러 케이스:
+//   - 알 수 없는 수준인 경우
+func ParseLogLevel(s string) (string, error) {
+	name := strings.ToLower(s)
+	for _, l := range logLevels {
+		if l.name == name {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("invalid loglevel %q", s)
+}
+
+// NewLogger는 w에 한 줄에 하나씩 key=value 형식으로 기록하는 로거를 만듭니다 (slog.TextHandler).
+// level보다 낮은 수준의 기록은 버리며, 수준은 Redis의 이름(debug, verbose, notice, warning)으로 출력합니다.
+func NewLogger(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.LevelKey {
+				a.Value = slog.StringValue(logLevelName(a.Value.Any().(slog.Level)))
+			}
+			return a
+		},
+	}))
+}
+
+// logLevelName은 slog 수준에 해당하는 loglevel의 이름입니다 (사이 값은 바로 아래 수준의 이름).
+func logLevelName(level slog.Level) string {
+	name := LogLevelDebug
+	for _, l := range logLevels {
+		if level >= l.level {
+			name = l.name
+		}
+	}
+	return name
+}
+
+// SetLogOutput은 서버의 로그를 w에 기록하도록 설정합니다 (logfile, 기본은 표준 출력).
+// 기록하는 수준은 SetLogLevel로 바꿀 수 있습니다.
+func (r *CommandRegistry) SetLogOutput(w io.Writer) {
+	r.logger.Store(NewLogger(w, &r.logLevel))
+}
+
+// SetLogFile은 로그를 기록하는 파일 경로를 설정합니다 (CONFIG GET logfile, 표준 출력이면 빈 문자열).
+// 출력 자체는 SetLogOutput으로 바꿉니다.
+func (r *CommandRegistry) SetLogFile(path string) {
+	r.logMu.Lock()
+	defer r.logMu.Unlock()
+	r.logFile = path
+}
+
+// Logger는 서버의 로거를 반환합니다. 연결마다 With로 클라이언트 ID와 주소를 붙여 사용합니다.
+func (r *CommandRegistry) Logger() *slog.Logger {
+	return r.logger.Load()
+}
+
+// SetLogLevel은 로그에 기록할 최소 수준을 설정합니다 (loglevel, 기본 notice).
+// 이미 만든 로거(With로 만든 연결별 로거 포함)에도 바로 적용됩니다.
+//
+// 에러 케이스:
+//   - 알 수 없는 수준인 경우
+func (r *CommandRegistry) SetLogLevel(level string) error {
+	name, err := ParseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	for _, l := range logLevels {
+		if l.name == name {
+			r.logLevel.Set(l.level)
+		}
+	}
+	return nil
+}
+
+// LogLevel은 현재 loglevel을 반환합니다.
+func (r *CommandRegistry) LogLevel() string {
+	return logLevelName(r.logLevel.Level())
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"testing"
 
+	"github.com/codecrafters-io/redis-starter-go/protocol"
 	"github.com/codecrafters-io/redis-starter-go/store"
 )
 
@@ -29,7 +30,7 @@ func TestLPopHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LPOP on single element list failed: %v", err)
 	}
-	if result != "only_one" {
+	if result != protocol.BulkString("only_one") {
 		t.Errorf("Expected 'only_one', got %v", result)
 	}
 
@@ -45,7 +46,7 @@ func TestLPopHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LPOP on multi element list failed: %v", err)
 	}
-	if result != "first" {
+	if result != protocol.BulkString("first") {
 		t.Errorf("Expected 'first', got %v", result)
 	}
 
@@ -66,7 +67,7 @@ func TestLPopHandler(t *testing.T) {
 	}
 
 	// 결과는 []string이어야 함
-	resultArray, ok := result.([]string)
+	resultArray, ok := result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -90,7 +91,7 @@ func TestLPopHandler(t *testing.T) {
 		t.Fatalf("LPOP with count > length failed: %v", err)
 	}
 
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -112,7 +113,7 @@ func TestLPopHandler(t *testing.T) {
 		t.Fatalf("LPOP with count on non-existent key should not fail: %v", err)
 	}
 
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -128,7 +129,7 @@ func TestLPopHandler(t *testing.T) {
 		t.Fatalf("LPOP with count=0 failed: %v", err)
 	}
 
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -149,7 +150,7 @@ func TestLPopHandler(t *testing.T) {
 		t.Fatalf("LPOP with negative count failed: %v", err)
 	}
 
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
@@ -165,7 +166,7 @@ func TestLPopHandler(t *testing.T) {
 		t.Fatalf("LPOP with count after LPUSH failed: %v", err)
 	}
 
-	resultArray, ok = result.([]string)
+	resultArray, ok = result.(protocol.BulkStrings)
 	if !ok {
 		t.Fatalf("Expected []string result, got %T", result)
 	}
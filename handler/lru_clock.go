@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"time"
+)
+
+// lruClockInterval은 저장소의 LRU 시계를 현재 시각으로 맞추는 주기입니다 (Redis의 serverCron, hz 10과 동일).
+// 키의 마지막 사용 시각(OBJECT IDLETIME, LRU 정책)은 이 정밀도로 기록됩니다.
+const lruClockInterval = 100 * time.Millisecond
+
+// lruClockMax는 DEBUG OBJECT가 보고하는 lru 값의 최댓값입니다 (Redis의 LRU_CLOCK_MAX, 24비트).
+const lruClockMax = 1<<24 - 1
+
+// StartLRUClock은 저장소의 LRU 시계를 주기적으로 맞추는 고루틴을 시작합니다.
+// 키를 사용할 때마다 현재 시각을 읽는 대신 이 시계를 기록하므로, 명령어 실행 경로에는 원자적 읽기 하나만 더해집니다.
+// 여러 번 호출해도 고루틴은 하나만 시작합니다.
+func (r *CommandRegistry) StartLRUClock() {
+	r.lruClockOnce.Do(func() { go r.lruClockCron() })
+}
+
+// lruClockCron은 서버가 종료될 때까지 lruClockInterval마다 LRU 시계를 맞춥니다.
+func (r *CommandRegistry) lruClockCron() {
+	ticker := time.NewTicker(lruClockInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.store.SetClock(now)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// maxmemory-policy의 값들
+const (
+	// MaxMemoryPolicyNoEviction은 키를 지우지 않고, 메모리를 늘릴 수 있는 명령어를 거부하는 정책입니다 (기본값).
+	MaxMemoryPolicyNoEviction = "noeviction"
+	// MaxMemoryPolicyAllKeysLRU는 모든 키 중 가장 오래 사용하지 않은 키부터 지우는 정책입니다.
+	MaxMemoryPolicyAllKeysLRU = "allkeys-lru"
+	// MaxMemoryPolicyVolatileLRU는 만료 시간이 있는 키 중 가장 오래 사용하지 않은 키부터 지우는 정책입니다.
+	MaxMemoryPolicyVolatileLRU = "volatile-lru"
+	// MaxMemoryPolicyAllKeysLFU는 모든 키 중 가장 적게 사용한 키부터 지우는 정책입니다.
+	MaxMemoryPolicyAllKeysLFU = "allkeys-lfu"
+	// MaxMemoryPolicyVolatileLFU는 만료 시간이 있는 키 중 가장 적게 사용한 키부터 지우는 정책입니다.
+	MaxMemoryPolicyVolatileLFU = "volatile-lfu"
+	// MaxMemoryPolicyVolatileTTL은 만료 시간이 있는 키 중 가장 먼저 만료될 키부터 지우는 정책입니다.
+	MaxMemoryPolicyVolatileTTL = "volatile-ttl"
+	// MaxMemoryPolicyAllKeysRandom은 모든 키 중 임의의 키를 지우는 정책입니다.
+	MaxMemoryPolicyAllKeysRandom = "allkeys-random"
+	// MaxMemoryPolicyVolatileRandom은 만료 시간이 있는 키 중 임의의 키를 지우는 정책입니다.
+	MaxMemoryPolicyVolatileRandom = "volatile-random"
+)
+
+// DefaultMaxMemorySamples는 maxmemory-samples의 기본값입니다 (Redis와 동일).
+const DefaultMaxMemorySamples = 5
+
+// evictionPolicy는 maxmemory를 넘었을 때 지울 키를 고르는 방법입니다.
+type evictionPolicy struct {
+	name     string
+	volatile bool // 만료 시간이 있는 키 중에서만 고름
+	// score는 후보 키의 점수입니다. 샘플 중 점수가 가장 큰 키를 지웁니다. nil이면 키를 지우지 않습니다.
+	score func(c store.EvictionCandidate, now time.Time) int64
+}
+
+// evictionPolicies는 maxmemory-policy로 고를 수 있는 정책들입니다 (첫 번째가 기본값).
+var evictionPolicies = []evictionPolicy{
+	{name: MaxMemoryPolicyNoEviction},
+	{name: MaxMemoryPolicyAllKeysLRU, score: idleScore},
+	{name: MaxMemoryPolicyVolatileLRU, volatile: true, score: idleScore},
+	{name: MaxMemoryPolicyAllKeysLFU, score: lfuScore},
+	{name: MaxMemoryPolicyVolatileLFU, volatile: true, score: lfuScore},
+	{name: MaxMemoryPolicyVolatileTTL, volatile: true, score: ttlScore},
+	{name: MaxMemoryPolicyAllKeysRandom, score: randomScore},
+	{name: MaxMemoryPolicyVolatileRandom, volatile: true, score: randomScore},
+}
+
+// idleScore는 LRU 시계로 잰 키를 사용하지 않은 시간입니다 (LRU, 오래 사용하지 않은 키부터 지움).
+func idleScore(c store.EvictionCandidate, now time.Time) int64 {
+	return int64(c.Idle)
+}
+
+// lfuScore는 LFU 카운터가 작을수록 큰 점수입니다 (LFU, 적게 사용한 키부터 지움).
+// 카운터는 사용하지 않은 시간만큼 줄어들므로, 예전에 많이 사용했어도 최근에 사용하지 않은 키는 지워질 수 있습니다.
+func lfuScore(c store.EvictionCandidate, now time.Time) int64 {
+	return int64(255 - c.Freq)
+}
+
+// ttlScore는 남은 만료 시간이 짧을수록 큰 점수입니다 (volatile-ttl, 곧 만료될 키부터 지움).
+func ttlScore(c store.EvictionCandidate, now time.Time) int64 {
+	return -int64(c.ExpireAt.Sub(now))
+}
+
+// randomScore는 임의의 점수입니다 (*-random, 샘플 중 아무 키나 지움).
+func randomScore(c store.EvictionCandidate, now time.Time) int64 {
+	return rand.Int64()
+}
+
+// ParseMaxMemoryPolicy는 maxmemory-policy 값을 확인하고 소문자로 정규화합니다.
+//
+// 에러 케이스:
+//   - 알 수 없는 정책인 경우
+func ParseMaxMemoryPolicy(s string) (string, error) {
+	name := strings.ToLower(s)
+	for _, p := range evictionPolicies {
+		if p.name == name {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("invalid maxmemory-policy %q", s)
+}
+
+// SetMaxMemory는 데이터셋이 사용할 수 있는 메모리의 한도를 바이트로 설정합니다 (maxmemory, 0이면 제한 없음).
+// 데이터셋의 크기는 저장소가 쓰기와 삭제마다 갱신하는 모든 키의 MEMORY USAGE 합입니다 (Store.UsedMemory).
+//
+// 데이터셋이 한도를 넘으면 명령어를 실행하기 전에 maxmemory-policy에 따라 키를 지워 한도 아래로 내립니다.
+// 지울 키가 없으면(noeviction 등) 메모리를 늘릴 수 있는 명령어(denyoom 플래그, SET, RPUSH 등)는
+// OOM 에러로 거부하고, 읽기와 삭제 명령어(GET, DEL, LPOP 등)는 그대로 실행합니다.
+func (r *CommandRegistry) SetMaxMemory(n int64) {
+	r.maxMemory.Store(n)
+}
+
+// MaxMemory는 데이터셋이 사용할 수 있는 메모리의 한도를 반환합니다 (0이면 제한 없음).
+func (r *CommandRegistry) MaxMemory() int64 {
+	return r.maxMemory.Load()
+}
+
+// SetMaxMemoryPolicy는 maxmemory를 넘었을 때 지울 키를 고르는 정책을 설정합니다 (maxmemory-policy).
+//
+// 에러 케이스:
+//   - 알 수 없는 정책인 경우
+func (r *CommandRegistry) SetMaxMemoryPolicy(policy string) error {
+	name, err := ParseMaxMemoryPolicy(policy)
+	if err != nil {
+		return err
+	}
+	for i, p := range evictionPolicies {
+		if p.name == name {
+			r.maxMemoryPolicy.Store(int32(i))
+		}
+	}
+	return nil
+}
+
+// MaxMemoryPolicy는 현재 maxmemory-policy를 반환합니다.
+func (r *CommandRegistry) MaxMemoryPolicy() string {
+	return r.evictionPolicy().name
+}
+
+// tracksFrequency는 현재 maxmemory-policy가 LFU 정책인지 확인합니다 (OBJECT FREQ, IDLETIME).
+func (r *CommandRegistry) tracksFrequency() bool {
+	policy := r.MaxMemoryPolicy()
+	return policy == MaxMemoryPolicyAllKeysLFU || policy == MaxMemoryPolicyVolatileLFU
+}
+
+// evictionPolicy는 현재 maxmemory-policy의 정책입니다.
+func (r *CommandRegistry) evictionPolicy() evictionPolicy {
+	return evictionPolicies[r.maxMemoryPolicy.Load()]
+}
+
+// SetMaxMemorySamples는 지울 키를 고를 때 한 번에 확인하는 키 수를 설정합니다 (maxmemory-samples).
+// 클수록 정확한 LRU에 가까워지지만 느려집니다. 1보다 작으면 1로 취급합니다.
+func (r *CommandRegistry) SetMaxMemorySamples(n int) {
+	r.maxMemorySamples.Store(int64(max(n, 1)))
+}
+
+// MaxMemorySamples는 지울 키를 고를 때 한 번에 확인하는 키 수를 반환합니다.
+func (r *CommandRegistry) MaxMemorySamples() int {
+	return int(r.maxMemorySamples.Load())
+}
+
+// SetLFULogFactor는 LFU 카운터가 늘어나는 속도를 설정합니다 (lfu-log-factor, 기본 10).
+// 클수록 많이 사용한 키끼리 더 잘 구분하며, 0이면 사용할 때마다 카운터가 1 늘어납니다.
+func (r *CommandRegistry) SetLFULogFactor(factor int) {
+	r.store.SetLFULogFactor(factor)
+}
+
+// SetLFUDecayTime은 사용하지 않는 키의 LFU 카운터를 1 줄이는 주기를 설정합니다 (lfu-decay-time, 기본 1분, 0이면 줄이지 않음).
+func (r *CommandRegistry) SetLFUDecayTime(period time.Duration) {
+	r.store.SetLFUDecayTime(period)
+}
+
+// rejectsOOM은 데이터셋이 maxmemory를 넘은 상태에서 client가 메모리를 늘릴 수 있는 명령어를 실행하려는지 확인합니다.
+// 한도를 넘었으면 먼저 maxmemory-policy에 따라 키를 지워 보고, 한도 아래로 내리지 못했을 때만 거부합니다.
+// 마스터의 복제 스트림은 마스터의 데이터셋을 그대로 따라야 하므로 제외합니다.
+// 실행 잠금을 잡지 않은 상태에서 호출해야 합니다 (키를 지우는 동안 공유 잠금을 잡음).
+func (r *CommandRegistry) rejectsOOM(client *Client, cmdUpper string) bool {
+	if client != nil && client.master || !r.overMaxMemory() {
+		return false
+	}
+	if r.performEvictions() {
+		return false
+	}
+	return r.commandHas(cmdUpper, cmdDenyOOM)
+}
+
+// overMaxMemory는 maxmemory가 설정되어 있고 데이터셋이 그보다 큰지 확인합니다.
+// 힙 전체가 아닌 데이터셋만 세므로, 연결 버퍼나 런타임의 메모리는 한도에 포함되지 않습니다.
+func (r *CommandRegistry) overMaxMemory() bool {
+	limit := r.maxMemory.Load()
+	return limit > 0 && r.store.UsedMemory() > limit
+}
+
+// performEvictions는 데이터셋이 limit 아래로 내려갈 때까지 maxmemory-policy에 따라 키를 지우고,
+// 한도 아래로 내렸는지 반환합니다 (Redis의 performEvictions).
+//
+// 매번 maxmemory-samples개의 키를 임의로 골라 그중 점수가 가장 큰 키(LRU이면 가장 오래 사용하지 않은 키)를 지웁니다.
+// 지운 키는 만료된 키와 같이 레플리카와 AOF에 DEL로 전달하고 evicted_keys로 셉니다.
+func (r *CommandRegistry) performEvictions() bool {
+	policy := r.evictionPolicy()
+	if policy.score == nil {
+		return false
+	}
+
+	// 여러 연결이 동시에 한도를 넘은 것을 보고 필요 이상으로 지우지 않도록 한 번에 하나만 지움
+	// (트랜잭션이나 스크립트가 실행 중이면 끝난 뒤에 지움)
+	r.evictMu.Lock()
+	defer r.evictMu.Unlock()
+	r.execMu.RLock()
+	defer r.execMu.RUnlock()
+
+	samples := int(r.maxMemorySamples.Load())
+	for r.overMaxMemory() {
+		candidates := r.store.EvictionSample(samples, policy.volatile)
+		if len(candidates) == 0 {
+			return false
+		}
+		now := time.Now()
+		best := candidates[0]
+		for _, c := range candidates[1:] {
+			if policy.score(c, now) > policy.score(best, now) {
+				best = c
+			}
+		}
+		r.evictKey(best.Key)
+	}
+	return true
+}
+
+// evictKey는 maxmemory 때문에 키 하나를 지웁니다.
+func (r *CommandRegistry) evictKey(key string) {
+	if r.store.DEL(key) == 0 {
+		return
+	}
+	atomic.AddInt64(&r.stats.evictedKeys, 1)
+	r.propagateCommand([]string{"DEL", key})
+}
@@ -0,0 +1,338 @@
+package handler
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestMaxMemoryNoEviction은 데이터셋이 maxmemory를 넘으면 메모리를 늘릴 수 있는 명령어만 거부하는지 테스트합니다.
+func TestMaxMemoryNoEviction(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	for _, key := range []string{"a", "b"} {
+		if _, err := registry.ExecuteForClient(client, "RPUSH", []string{key, "x", "y", "z"}); err != nil {
+			t.Fatalf("RPUSH failed: %v", err)
+		}
+	}
+
+	// 테스트 케이스 1: 한도 안이면 쓰기 명령어 실행
+	registry.SetMaxMemory(1 << 20)
+	if _, err := registry.ExecuteForClient(client, "SET", []string{"c", "1"}); err != nil {
+		t.Fatalf("Expected SET within maxmemory to succeed, got %v", err)
+	}
+
+	// 테스트 케이스 2: 한도를 넘으면 메모리를 늘릴 수 있는 명령어는 OOM
+	registry.SetMaxMemory(1)
+	for _, cmd := range [][]string{{"SET", "d", "1"}, {"RPUSH", "a", "w"}, {"ZADD", "z", "1", "m"}} {
+		if _, err := registry.ExecuteForClient(client, cmd[0], cmd[1:]); err == nil || err.Error() != "-OOM command not allowed when used memory > 'maxmemory'." {
+			t.Errorf("Expected OOM error for %s, got %v", cmd[0], err)
+		}
+	}
+
+	// 테스트 케이스 3: 읽기와 삭제 명령어는 실행
+	if result, err := registry.ExecuteForClient(client, "LLEN", []string{"a"}); err != nil || result != protocol.Integer(3) {
+		t.Errorf("Expected LLEN 3, got %v, %v", result, err)
+	}
+	if _, err := registry.ExecuteForClient(client, "LPOP", []string{"a"}); err != nil {
+		t.Errorf("Expected LPOP to succeed, got %v", err)
+	}
+	if result, err := registry.ExecuteForClient(client, "DEL", []string{"b"}); err != nil || result != protocol.Integer(1) {
+		t.Errorf("Expected DEL 1, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 4: 트랜잭션 안의 명령어는 대기열에 넣지 않고 EXEC를 실패시킴
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	if _, err := registry.ExecuteForClient(client, "SET", []string{"d", "1"}); err == nil {
+		t.Error("Expected OOM error inside MULTI")
+	}
+	if _, err := registry.ExecuteForClient(client, "EXEC", []string{}); err == nil {
+		t.Error("Expected EXECABORT")
+	}
+
+	// 테스트 케이스 5: 스크립트 안의 명령어도 거부
+	if _, err := registry.ExecuteForClient(client, "EVAL", []string{"return redis.call('SET', 'd', '1')", "0"}); err == nil || !strings.Contains(err.Error(), "OOM") {
+		t.Errorf("Expected OOM error from script, got %v", err)
+	}
+
+	// 테스트 케이스 6: 마스터의 복제 스트림은 거부하지 않음
+	master, _ := newTestClient(registry)
+	master.master = true
+	if _, err := registry.ExecuteForClient(master, "SET", []string{"d", "1"}); err != nil {
+		t.Errorf("Expected SET from master to succeed, got %v", err)
+	}
+
+	// 테스트 케이스 7: 한도를 없애면 다시 실행
+	registry.SetMaxMemory(0)
+	if _, err := registry.ExecuteForClient(client, "SET", []string{"e", "1"}); err != nil {
+		t.Errorf("Expected SET without maxmemory to succeed, got %v", err)
+	}
+}
+
+// TestMaxMemoryInfo는 INFO memory가 데이터셋 크기와 한도를 보고하는지 테스트합니다.
+func TestMaxMemoryInfo(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.Execute("SET", []string{"key", "value"})
+	registry.SetMaxMemory(100 << 20)
+
+	result, err := registry.Execute("INFO", []string{"memory"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectedDataset, _ := registry.store.MemoryUsage("key")
+	for _, line := range []string{"# Memory\r\n", "maxmemory:104857600\r\n", "maxmemory_policy:noeviction\r\n",
+		"used_memory_dataset:" + strconv.FormatInt(expectedDataset, 10) + "\r\n"} {
+		if !strings.Contains(string(result.(protocol.BulkString)), line) {
+			t.Errorf("Expected %q in INFO output, got %q", line, result)
+		}
+	}
+}
+
+// TestMaxMemoryLRU는 LRU 정책이 한도 아래로 내려갈 때까지 오래 사용하지 않은 키부터 지우는지 테스트합니다.
+func TestMaxMemoryLRU(t *testing.T) {
+	// 테스트 케이스 1: allkeys-lru는 모든 키 중 가장 오래 사용하지 않은 키부터 지우고 DEL로 전달
+	t.Run("AllKeys", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		var propagated [][]string
+		registry.OnPropagate(func(commands [][]string) { propagated = append(propagated, commands...) })
+		client, _ := newTestClient(registry)
+		// LRU 시계를 1초씩 움직이며 k0~k9를 차례로 만들고 k0을 마지막으로 사용
+		start := time.Now()
+		for i := 0; i < 10; i++ {
+			registry.store.SetClock(start.Add(time.Duration(i) * time.Second))
+			registry.ExecuteForClient(client, "SET", []string{"k" + strconv.Itoa(i), "value"})
+		}
+		registry.store.SetClock(start.Add(10 * time.Second))
+		registry.ExecuteForClient(client, "GET", []string{"k0"})
+		propagated = nil
+
+		keySize, _ := registry.store.MemoryUsage("k0")
+		if err := registry.SetMaxMemoryPolicy("allkeys-lru"); err != nil {
+			t.Fatal(err)
+		}
+		registry.SetMaxMemorySamples(10)
+		registry.SetMaxMemory(7 * keySize)
+
+		if _, err := registry.ExecuteForClient(client, "SET", []string{"k9", "value"}); err != nil {
+			t.Fatalf("Expected SET to succeed after eviction, got %v", err)
+		}
+		for i := 0; i < 10; i++ {
+			key := "k" + strconv.Itoa(i)
+			evicted := i >= 1 && i <= 3
+			if exists := registry.store.GET(key) != nil; exists == evicted {
+				t.Errorf("Expected %s evicted=%v", key, evicted)
+			}
+		}
+		expected := [][]string{{"DEL", "k1"}, {"DEL", "k2"}, {"DEL", "k3"}}
+		if !reflect.DeepEqual(propagated[:3], expected) {
+			t.Errorf("Expected %v to be propagated, got %v", expected, propagated)
+		}
+		if result, _ := registry.Execute("INFO", []string{"stats"}); !strings.Contains(string(result.(protocol.BulkString)), "evicted_keys:3\r\n") {
+			t.Errorf("Expected evicted_keys:3, got %q", result)
+		}
+	})
+
+	// 테스트 케이스 2: volatile-lru는 만료 시간이 있는 키만 지우고, 지울 키가 없으면 OOM
+	t.Run("Volatile", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		client, _ := newTestClient(registry)
+		registry.ExecuteForClient(client, "SET", []string{"persistent", "value"})
+		registry.ExecuteForClient(client, "SET", []string{"volatile", "value", "PX", "100000"})
+
+		registry.SetMaxMemoryPolicy("volatile-lru")
+		registry.SetMaxMemory(1)
+		if _, err := registry.ExecuteForClient(client, "SET", []string{"new", "value"}); err == nil || !strings.Contains(err.Error(), "OOM") {
+			t.Errorf("Expected OOM error once no volatile keys are left, got %v", err)
+		}
+		if registry.store.GET("volatile") != nil {
+			t.Error("Expected the volatile key to be evicted")
+		}
+		if registry.store.GET("persistent") == nil {
+			t.Error("Expected the persistent key to be kept")
+		}
+	})
+
+	// 테스트 케이스 3: 알 수 없는 정책은 에러
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		if err := registry.SetMaxMemoryPolicy("lru"); err == nil {
+			t.Error("Expected an error for an unknown policy")
+		}
+		if policy := registry.MaxMemoryPolicy(); policy != MaxMemoryPolicyNoEviction {
+			t.Errorf("Expected the policy to stay noeviction, got %q", policy)
+		}
+	})
+}
+
+// TestMaxMemoryLFU는 LFU 정책이 적게 사용한 키부터 지우는지 테스트합니다.
+func TestMaxMemoryLFU(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetLFULogFactor(0) // 사용할 때마다 카운터가 1 늘어나도록
+	client, _ := newTestClient(registry)
+	for i := 0; i < 10; i++ {
+		registry.ExecuteForClient(client, "SET", []string{"k" + strconv.Itoa(i), "value"})
+	}
+	// k0~k6은 자주 사용한 키
+	for i := 0; i < 7; i++ {
+		for j := 0; j < 3; j++ {
+			registry.ExecuteForClient(client, "GET", []string{"k" + strconv.Itoa(i)})
+		}
+	}
+
+	keySize, _ := registry.store.MemoryUsage("k0")
+	if err := registry.SetMaxMemoryPolicy("allkeys-lfu"); err != nil {
+		t.Fatal(err)
+	}
+	registry.SetMaxMemorySamples(10)
+	registry.SetMaxMemory(7 * keySize)
+
+	if _, err := registry.ExecuteForClient(client, "GET", []string{"k0"}); err != nil {
+		t.Fatalf("Expected GET to succeed, got %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		key := "k" + strconv.Itoa(i)
+		evicted := i >= 7
+		if exists := registry.store.GET(key) != nil; exists == evicted {
+			t.Errorf("Expected %s evicted=%v", key, evicted)
+		}
+	}
+}
+
+// TestObjectCommand는 OBJECT ENCODING과 FREQ를 테스트합니다.
+func TestObjectCommand(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	registry.ExecuteForClient(client, "SET", []string{"counter", "123"})
+
+	// 테스트 케이스 1: ENCODING
+	if result, err := registry.ExecuteForClient(client, "OBJECT", []string{"ENCODING", "counter"}); err != nil || result != protocol.BulkString("int") {
+		t.Errorf("Expected int encoding, got %v, %v", result, err)
+	}
+	if result, err := registry.ExecuteForClient(client, "OBJECT", []string{"ENCODING", "missing"}); err != nil || result != nil {
+		t.Errorf("Expected nil for a missing key, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 2: LFU 정책이 아니면 FREQ는 에러
+	if _, err := registry.ExecuteForClient(client, "OBJECT", []string{"FREQ", "counter"}); err == nil || !strings.Contains(err.Error(), "LFU maxmemory policy is not selected") {
+		t.Errorf("Expected an LFU policy error, got %v", err)
+	}
+
+	// 테스트 케이스 3: 초기값 5에서 사용할 때마다 늘어남 (lfu-log-factor 0)
+	registry.SetMaxMemoryPolicy("allkeys-lfu")
+	registry.SetLFULogFactor(0)
+	for i := 0; i < 3; i++ {
+		registry.ExecuteForClient(client, "GET", []string{"counter"})
+	}
+	if result, err := registry.ExecuteForClient(client, "OBJECT", []string{"FREQ", "counter"}); err != nil || result != protocol.Integer(8) {
+		t.Errorf("Expected FREQ 8, got %v, %v", result, err)
+	}
+	// OBJECT 자체는 키를 사용한 것으로 기록하지 않음
+	if result, _ := registry.ExecuteForClient(client, "OBJECT", []string{"FREQ", "counter"}); result != protocol.Integer(8) {
+		t.Errorf("Expected FREQ to stay 8, got %v", result)
+	}
+	if result, err := registry.ExecuteForClient(client, "OBJECT", []string{"FREQ", "missing"}); err != nil || result != nil {
+		t.Errorf("Expected nil for a missing key, got %v, %v", result, err)
+	}
+}
+
+// TestMaxMemoryVolatileTTL은 volatile-ttl 정책이 가장 먼저 만료될 키부터 지우는지 테스트합니다.
+func TestMaxMemoryVolatileTTL(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	registry.ExecuteForClient(client, "SET", []string{"persistent", "value"})
+	for i := 1; i <= 4; i++ {
+		registry.ExecuteForClient(client, "SET", []string{"t" + strconv.Itoa(i), "value", "PX", strconv.Itoa(i * 100000)})
+	}
+
+	keySize, _ := registry.store.MemoryUsage("t1")
+	registry.SetMaxMemoryPolicy("volatile-ttl")
+	registry.SetMaxMemorySamples(10)
+	registry.SetMaxMemory(registry.store.UsedMemory() - 2*keySize)
+	if _, err := registry.ExecuteForClient(client, "GET", []string{"persistent"}); err != nil {
+		t.Fatalf("Expected GET to succeed, got %v", err)
+	}
+	for key, kept := range map[string]bool{"persistent": true, "t1": false, "t2": false, "t3": true, "t4": true} {
+		if exists := registry.store.GET(key) != nil; exists != kept {
+			t.Errorf("Expected %s kept=%v", key, kept)
+		}
+	}
+}
+
+// TestMaxMemoryRandom은 random 정책이 정책의 범위 안에서 한도 아래로 내려갈 때까지 키를 지우는지 테스트합니다.
+func TestMaxMemoryRandom(t *testing.T) {
+	// 테스트 케이스 1: allkeys-random은 종류와 관계없이 지움
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	for i := 0; i < 10; i++ {
+		registry.ExecuteForClient(client, "SET", []string{"k" + strconv.Itoa(i), "value"})
+	}
+	keySize, _ := registry.store.MemoryUsage("k0")
+	registry.SetMaxMemoryPolicy("allkeys-random")
+	registry.SetMaxMemory(5 * keySize)
+	registry.ExecuteForClient(client, "SET", []string{"k0", "value"})
+	if n := len(registry.store.Snapshot()); n != 5 && n != 6 {
+		t.Errorf("Expected 5 keys left (6 if k0 was evicted and set again), got %d", n)
+	}
+
+	// 테스트 케이스 2: volatile-random은 만료 시간이 있는 키만 지움
+	registry = NewCommandRegistry(store.NewStore())
+	client, _ = newTestClient(registry)
+	registry.ExecuteForClient(client, "SET", []string{"persistent", "value"})
+	registry.ExecuteForClient(client, "SET", []string{"volatile", "value", "PX", "100000"})
+	registry.SetMaxMemoryPolicy("volatile-random")
+	registry.SetMaxMemory(1)
+	if _, err := registry.ExecuteForClient(client, "SET", []string{"new", "value"}); err == nil || !strings.Contains(err.Error(), "OOM") {
+		t.Errorf("Expected OOM error once no volatile keys are left, got %v", err)
+	}
+	if registry.store.GET("volatile") != nil || registry.store.GET("persistent") == nil {
+		t.Error("Expected only the volatile key to be evicted")
+	}
+}
+
+// TestObjectIdleTime은 OBJECT IDLETIME이 LRU 시계로 잰 사용하지 않은 시간을 보고하고,
+// CLIENT NO-TOUCH를 켠 연결의 읽기는 기록하지 않는지 테스트합니다.
+func TestObjectIdleTime(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	start := time.Now()
+	registry.store.SetClock(start)
+	registry.ExecuteForClient(client, "SET", []string{"key", "value"})
+
+	// 테스트 케이스 1: 만든 뒤 시계가 움직인 만큼 (OBJECT 자체는 키를 사용하지 않음)
+	registry.store.SetClock(start.Add(90 * time.Second))
+	if result, err := registry.ExecuteForClient(client, "OBJECT", []string{"IDLETIME", "key"}); err != nil || result != protocol.Integer(90) {
+		t.Errorf("Expected IDLETIME 90, got %v, %v", result, err)
+	}
+	if result, err := registry.ExecuteForClient(client, "OBJECT", []string{"IDLETIME", "missing"}); err != nil || result != nil {
+		t.Errorf("Expected nil for a missing key, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 2: NO-TOUCH 연결의 읽기는 기록하지 않고, 다른 연결의 읽기는 기록
+	registry.ExecuteForClient(client, "CLIENT", []string{"NO-TOUCH", "ON"})
+	registry.ExecuteForClient(client, "GET", []string{"key"})
+	if result, _ := registry.ExecuteForClient(client, "OBJECT", []string{"IDLETIME", "key"}); result != protocol.Integer(90) {
+		t.Errorf("Expected IDLETIME to stay 90 after a NO-TOUCH read, got %v", result)
+	}
+	other, _ := newTestClient(registry)
+	registry.ExecuteForClient(other, "GET", []string{"key"})
+	if result, _ := registry.ExecuteForClient(client, "OBJECT", []string{"IDLETIME", "key"}); result != protocol.Integer(0) {
+		t.Errorf("Expected IDLETIME 0 after a read, got %v", result)
+	}
+
+	// 테스트 케이스 3: DEBUG OBJECT도 같은 값을 보고
+	registry.store.SetClock(start.Add(100 * time.Second))
+	if result, err := registry.ExecuteForClient(client, "DEBUG", []string{"OBJECT", "key"}); err != nil || !strings.HasSuffix(string(result.(protocol.SimpleString)), " lru_seconds_idle:10") {
+		t.Errorf("Expected lru_seconds_idle:10, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 4: LFU 정책에서는 에러
+	registry.SetMaxMemoryPolicy(MaxMemoryPolicyAllKeysLFU)
+	if _, err := registry.ExecuteForClient(client, "OBJECT", []string{"IDLETIME", "key"}); err == nil || !strings.Contains(err.Error(), "idle time not tracked") {
+		t.Errorf("Expected an LFU policy error, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// memoryDoctorMinUsage보다 메모리를 적게 쓰면 MEMORY DOCTOR는 진단하지 않습니다 (Redis와 동일하게 5MB).
+const memoryDoctorMinUsage = 5 << 20
+
+// memoryStats는 MEMORY STATS가 보고하는 메모리 사용량의 기준값들입니다.
+type memoryStats struct {
+	startup int64 // 레지스트리를 만들 때 할당된 힙 크기 (startup.allocated)
+	peak    int64 // 지금까지 관찰한 가장 큰 힙 크기 (peak.allocated)
+}
+
+// usedMemory는 현재 할당된 힙 크기를 반환하고, 최대값을 갱신합니다.
+func (m *memoryStats) usedMemory() (used int64, ms runtime.MemStats) {
+	runtime.ReadMemStats(&ms)
+	used = int64(ms.HeapAlloc)
+	for {
+		peak := atomic.LoadInt64(&m.peak)
+		if used <= peak || atomic.CompareAndSwapInt64(&m.peak, peak, used) {
+			return used, ms
+		}
+	}
+}
+
+// MemoryHandler는 MEMORY 명령어를 처리하는 핸들러입니다.
+//
+// Redis MEMORY 명령어 사양:
+//   - MEMORY USAGE key [SAMPLES count]: 키와 값이 차지하는 메모리의 추정치 (바이트, 키가 없으면 nil)
+//     저장소가 쓰기마다 키의 크기를 갱신하므로 모든 요소를 센 값이며, SAMPLES는 Redis와의 호환을 위해 확인만 함
+//   - MEMORY STATS: 서버 전체의 메모리 사용량 보고 (이름과 값을 번갈아 나열)
+//   - MEMORY DOCTOR: 메모리 사용에 대한 진단 메시지
+//   - MEMORY PURGE: 사용하지 않는 메모리를 운영체제에 돌려줌
+//   - MEMORY HELP: 서브커맨드들의 사용법
+//
+// 저장소는 값을 Go의 자료구조로 보관하므로, 키별 추정치는 같은 값을 Redis가 저장할 때의 크기입니다 (Store.MemoryUsage).
+// 서버 전체의 값은 Go 런타임의 힙 통계(runtime.MemStats)에서 가져옵니다.
+//
+// 예시:
+//
+//	클라이언트: MEMORY USAGE mykey
+//	서버: :56\r\n
+type MemoryHandler struct {
+	registry *CommandRegistry
+}
+
+// Execute는 MEMORY 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.Integer: USAGE의 결과
+//   - nil: USAGE의 키가 없는 경우
+//   - protocol.Map: STATS의 결과
+//   - protocol.BulkString: DOCTOR의 결과
+//   - protocol.SimpleString: PURGE 성공 시 OK
+//   - error: 서브커맨드가 없거나 알 수 없는 경우, 인자가 잘못된 경우
+func (h *MemoryHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.subcommands().dispatch(nil, args, store)
+}
+
+// subcommands는 MEMORY의 서브커맨드 목록입니다.
+func (h *MemoryHandler) subcommands() *subcommandTable {
+	return &subcommandTable{command: "MEMORY", subs: []subcommand{
+		{"DOCTOR", 2, []string{"DOCTOR", "Return memory problems reports."}, h.doctor},
+		{"PURGE", 2, []string{"PURGE", "Attempt to purge dirty pages for reclamation by the allocator."}, h.purge},
+		{"STATS", 2, []string{"STATS", "Return information about the memory usage of the server."}, h.stats},
+		{"USAGE", -3, []string{"USAGE <key> [SAMPLES <count>]",
+			"Return memory in bytes used by <key> and its value. Nested values are",
+			"sampled up to <count> times (default: 5, 0 means sample all)."}, h.usage},
+	}}
+}
+
+// usage는 MEMORY USAGE를 실행합니다. 키가 없으면 nil입니다.
+func (h *MemoryHandler) usage(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 2 && len(args) != 4 {
+		return nil, &WrongNumberOfArgumentsError{Command: "memory|usage"}
+	}
+	if len(args) == 4 {
+		if !strings.EqualFold(args[2], "SAMPLES") {
+			return nil, &InvalidArgumentError{Message: "syntax error"}
+		}
+		if n, err := strconv.Atoi(args[3]); err != nil || n < 0 {
+			return nil, &InvalidArgumentError{Message: "value is out of range, must be positive"}
+		}
+	}
+	usage, exists := store.MemoryUsage(args[1])
+	if !exists {
+		return nil, nil
+	}
+	return protocol.Integer(usage), nil
+}
+
+// stats는 MEMORY STATS를 실행합니다.
+func (h *MemoryHandler) stats(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return h.registry.memoryStatsReply(store), nil
+}
+
+// doctor는 MEMORY DOCTOR를 실행합니다.
+func (h *MemoryHandler) doctor(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if used, _ := h.registry.memory.usedMemory(); used < memoryDoctorMinUsage {
+		return protocol.BulkString("Hi Sam, this instance is empty or is using very little memory, my issues detector can't be used in these conditions. " +
+			"Please, leave for your mission on Earth and fill it with some data. The new Sam and I will be back to our programming as soon as I finished rebooting."), nil
+	}
+	return protocol.BulkString("Hi Sam, I can't find any memory issue in your instance. I can only account for what occurs on this base."), nil
+}
+
+// purge는 MEMORY PURGE를 실행합니다. 사용하지 않는 메모리를 운영체제에 돌려줍니다.
+func (h *MemoryHandler) purge(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	debug.FreeOSMemory()
+	return okReply, nil
+}
+
+// memoryStatsReply는 MEMORY STATS의 응답을 만듭니다.
+// 데이터셋 크기는 모든 키의 MEMORY USAGE 추정치의 합이고 (Store.UsedMemory),
+// 나머지(overhead.total)는 힙 크기에서 데이터셋 크기를 뺀 값입니다.
+func (r *CommandRegistry) memoryStatsReply(s *store.Store) protocol.Map {
+	used, ms := r.memory.usedMemory()
+	startup := atomic.LoadInt64(&r.memory.startup)
+	peak := atomic.LoadInt64(&r.memory.peak)
+
+	keys := len(s.Keys())
+	dataset := int(s.UsedMemory())
+	overhead := int(used) - dataset
+	if overhead < 0 {
+		overhead = 0
+	}
+
+	var normalClients, replicaClients int
+	for _, client := range r.connectedClients() {
+		if client.snapshot().kind == "replica" {
+			replicaClients++
+		} else {
+			normalClients++
+		}
+	}
+
+	bytesPerKey := 0
+	if keys > 0 {
+		bytesPerKey = (int(used) - int(startup)) / keys
+	}
+
+	return protocol.Map{
+		protocol.BulkString("peak.allocated"), protocol.Integer(peak),
+		protocol.BulkString("total.allocated"), protocol.Integer(used),
+		protocol.BulkString("startup.allocated"), protocol.Integer(startup),
+		protocol.BulkString("clients.slaves"), protocol.Integer(replicaClients),
+		protocol.BulkString("clients.normal"), protocol.Integer(normalClients),
+		protocol.BulkString("overhead.total"), protocol.Integer(overhead),
+		protocol.BulkString("keys.count"), protocol.Integer(keys),
+		protocol.BulkString("keys.bytes-per-key"), protocol.Integer(bytesPerKey),
+		protocol.BulkString("dataset.bytes"), protocol.Integer(dataset),
+		protocol.BulkString("dataset.percentage"), percentage(dataset, int(used)),
+		protocol.BulkString("peak.percentage"), percentage(int(used), int(peak)),
+		protocol.BulkString("allocator.allocated"), protocol.Integer(ms.HeapAlloc),
+		protocol.BulkString("allocator.active"), protocol.Integer(ms.HeapInuse),
+		protocol.BulkString("allocator.resident"), protocol.Integer(ms.Sys - ms.HeapReleased),
+		protocol.BulkString("allocator.fragmentation.ratio"), ratio(int(ms.HeapInuse), int(ms.HeapAlloc)),
+		protocol.BulkString("gc.count"), protocol.Integer(ms.NumGC),
+	}
+}
+
+// percentage는 part가 total의 몇 퍼센트인지 반환합니다.
+func percentage(part, total int) protocol.Double {
+	if total == 0 {
+		return 0
+	}
+	return protocol.Double(float64(part) * 100 / float64(total))
+}
+
+// ratio는 a/b를 반환합니다.
+func ratio(a, b int) protocol.Double {
+	if b == 0 {
+		return 0
+	}
+	return protocol.Double(float64(a) / float64(b))
+}
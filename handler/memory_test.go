@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestMemoryUsage는 MEMORY USAGE가 값의 크기에 따라 추정치를 반환하는지 테스트합니다.
+func TestMemoryUsage(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.Execute("SET", []string{"small", "hello"})
+	registry.Execute("SET", []string{"big", strings.Repeat("x", 1000)})
+
+	small, err := registry.Execute("MEMORY", []string{"USAGE", "small"})
+	if err != nil {
+		t.Fatalf("MEMORY USAGE small: %v", err)
+	}
+	big, _ := registry.Execute("MEMORY", []string{"USAGE", "big"})
+	if small.(protocol.Integer) <= 5 || big.(protocol.Integer) < 1000 || big.(protocol.Integer) <= small.(protocol.Integer) {
+		t.Errorf("Expected usage to grow with value size, got small=%v big=%v", small, big)
+	}
+
+	// 테스트 케이스 1: 없는 키는 nil
+	if result, err := registry.Execute("MEMORY", []string{"USAGE", "nosuch"}); err != nil || result != nil {
+		t.Errorf("Expected nil for missing key, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 2: 요소 크기가 같은 리스트는 SAMPLES와 관계없이 같은 추정치
+	args := []string{"list"}
+	for i := 0; i < 100; i++ {
+		args = append(args, "element")
+	}
+	registry.Execute("RPUSH", args)
+	sampled, _ := registry.Execute("MEMORY", []string{"USAGE", "list", "SAMPLES", "5"})
+	full, _ := registry.Execute("MEMORY", []string{"USAGE", "list", "SAMPLES", "0"})
+	if sampled != full || full.(protocol.Integer) < protocol.Integer(100*len("element")) {
+		t.Errorf("Expected equal estimates for uniform list, got sampled=%v full=%v", sampled, full)
+	}
+
+	// 테스트 케이스 3: 잘못된 SAMPLES
+	if _, err := registry.Execute("MEMORY", []string{"USAGE", "list", "SAMPLES", "-1"}); err == nil {
+		t.Error("Expected error for negative SAMPLES")
+	}
+	if _, err := registry.Execute("MEMORY", []string{"USAGE", "list", "COUNT", "1"}); err == nil || !strings.Contains(err.Error(), "syntax error") {
+		t.Errorf("Expected syntax error, got %v", err)
+	}
+}
+
+// TestMemoryStats는 MEMORY STATS가 키 수와 데이터셋 크기를 보고하는지 테스트합니다.
+func TestMemoryStats(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.Execute("SET", []string{"a", "1"})
+	registry.Execute("SET", []string{"b", "hello"})
+
+	result, err := registry.Execute("MEMORY", []string{"STATS"})
+	if err != nil {
+		t.Fatalf("MEMORY STATS: %v", err)
+	}
+	reply := result.(protocol.Map)
+	fields := make(map[protocol.Reply]protocol.Reply, len(reply)/2)
+	for i := 0; i+1 < len(reply); i += 2 {
+		fields[reply[i]] = reply[i+1]
+	}
+
+	if fields[protocol.BulkString("keys.count")] != protocol.Integer(2) {
+		t.Errorf("Expected keys.count 2, got %v", fields[protocol.BulkString("keys.count")])
+	}
+	a, _ := registry.Execute("MEMORY", []string{"USAGE", "a", "SAMPLES", "0"})
+	b, _ := registry.Execute("MEMORY", []string{"USAGE", "b", "SAMPLES", "0"})
+	if fields[protocol.BulkString("dataset.bytes")] != a.(protocol.Integer)+b.(protocol.Integer) {
+		t.Errorf("Expected dataset.bytes %d, got %v", a.(protocol.Integer)+b.(protocol.Integer), fields[protocol.BulkString("dataset.bytes")])
+	}
+	if total, peak := fields[protocol.BulkString("total.allocated")].(protocol.Integer), fields[protocol.BulkString("peak.allocated")].(protocol.Integer); total <= 0 || peak < total {
+		t.Errorf("Expected 0 < total.allocated <= peak.allocated, got %d, %d", total, peak)
+	}
+	if percentage, ok := fields[protocol.BulkString("dataset.percentage")].(protocol.Double); !ok || percentage <= 0 || percentage > 100 {
+		t.Errorf("Expected dataset.percentage between 0 and 100, got %v", fields[protocol.BulkString("dataset.percentage")])
+	}
+
+	// 테스트 케이스 1: 작은 인스턴스의 DOCTOR
+	if result, err := registry.Execute("MEMORY", []string{"DOCTOR"}); err != nil || !strings.HasPrefix(string(result.(protocol.BulkString)), "Hi Sam") {
+		t.Errorf("MEMORY DOCTOR: got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 2: 알 수 없는 서브커맨드
+	if _, err := registry.Execute("MEMORY", []string{"NOSUCH"}); err == nil || !strings.Contains(err.Error(), "unknown subcommand") {
+		t.Errorf("Expected unknown subcommand error, got %v", err)
+	}
+}
+
+// TestMemoryAccounting은 저장소가 쓰기와 삭제마다 갱신한 키의 크기가 같은 값을 새로 만든 키의 크기와 같은지 테스트합니다.
+func TestMemoryAccounting(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	usage := func(key string) protocol.Reply {
+		result, _ := registry.Execute("MEMORY", []string{"USAGE", key})
+		return result
+	}
+
+	// 테스트 케이스 1: 앞뒤로 넣고 뺀 리스트 (quicklist로 바뀌었다가 listpack으로 돌아옴)
+	big := strings.Repeat("x", 5000)
+	registry.Execute("RPUSH", []string{"list", "a", big, "b"})
+	registry.Execute("LPUSH", []string{"list", big, "c"})
+	registry.Execute("LPOP", []string{"list", "3"})
+	registry.Execute("RPUSH", []string{"copy", big, "b"})
+	if usage("list") != usage("copy") {
+		t.Errorf("Expected list usage %v to equal fresh list usage %v", usage("list"), usage("copy"))
+	}
+
+	// 테스트 케이스 2: 점수를 바꾼 정렬된 집합
+	registry.Execute("ZADD", []string{"zset", "1", "m"})
+	registry.Execute("ZADD", []string{"zset", "1.5", "m"})
+	registry.Execute("ZADD", []string{"zcpy", "1.5", "m"})
+	if usage("zset") == nil || usage("zset") != usage("zcpy") {
+		t.Errorf("Expected zset usage %v to equal fresh zset usage %v", usage("zset"), usage("zcpy"))
+	}
+
+	// 테스트 케이스 3: 데이터셋 크기는 키들의 크기 합이고, 지운 키는 빠짐
+	registry.Execute("SET", []string{"string", "value"})
+	registry.Execute("DEL", []string{"copy", "zcpy"})
+	expected := int64(usage("list").(protocol.Integer) + usage("zset").(protocol.Integer) + usage("string").(protocol.Integer))
+	if used := registry.store.UsedMemory(); used != expected {
+		t.Errorf("Expected dataset of %d bytes, got %d", expected, used)
+	}
+	registry.Execute("DEL", []string{"list", "zset", "string"})
+	if used := registry.store.UsedMemory(); used != 0 {
+		t.Errorf("Expected an empty dataset after deleting every key, got %d bytes", used)
+	}
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/rdb"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// migrateDefaultTimeout은 MIGRATE의 timeout이 0 이하일 때 사용하는 시간 제한입니다.
+const migrateDefaultTimeout = time.Second
+
+// MigrateHandler는 MIGRATE 명령어를 처리하는 핸들러입니다.
+//
+// Redis MIGRATE 명령어 사양:
+//   - MIGRATE host port key|"" destination-db timeout [COPY] [REPLACE] [AUTH password] [AUTH2 username password] [KEYS key ...]
+//   - 키들을 DUMP해 대상 서버에 일반 클라이언트 연결로 RESTORE하고, 성공한 키는 이 서버에서 삭제
+//   - KEYS: 여러 키를 옮김 (key 인자는 빈 문자열이어야 함)
+//   - COPY: 이 서버의 키를 삭제하지 않음
+//   - REPLACE: 대상 서버에 같은 키가 있으면 덮어씀 (없으면 BUSYKEY 에러)
+//   - timeout: 대상 서버와 주고받는 데 기다리는 최대 시간 (밀리초)
+//   - 옮길 키가 하나도 없으면 NOKEY
+//   - 클러스터 모드이면 RESTORE 앞에 ASKING을 보내 가져오는 중인 슬롯에도 넣을 수 있게 함
+//   - 레플리카와 AOF에는 삭제한 키의 DEL을 전달
+//
+// 예시:
+//
+//	클라이언트: MIGRATE 127.0.0.1 7001 "" 0 5000 KEYS a b
+//	서버: +OK\r\n
+type MigrateHandler struct {
+	registry *CommandRegistry
+}
+
+// migrateOptions는 MIGRATE 명령어의 인자입니다.
+type migrateOptions struct {
+	addr    string
+	db      int
+	timeout time.Duration
+	copy    bool
+	replace bool
+	auth    []string // AUTH 명령어 인자 (없으면 nil)
+	keys    []string
+}
+
+// parseMigrateOptions는 MIGRATE 인자를 파싱합니다.
+func parseMigrateOptions(args []string) (*migrateOptions, error) {
+	if len(args) < 5 {
+		return nil, &WrongNumberOfArgumentsError{Command: "migrate"}
+	}
+	db, err := strconv.Atoi(args[3])
+	if err != nil {
+		return nil, &InvalidArgumentError{Message: "value is not an integer or out of range"}
+	}
+	ms, err := strconv.ParseInt(args[4], 10, 64)
+	if err != nil {
+		return nil, &InvalidArgumentError{Message: "value is not an integer or out of range"}
+	}
+	opts := &migrateOptions{
+		addr:    net.JoinHostPort(args[0], args[1]),
+		db:      db,
+		timeout: time.Duration(ms) * time.Millisecond,
+	}
+	if opts.timeout <= 0 {
+		opts.timeout = migrateDefaultTimeout
+	}
+
+	for i := 5; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "COPY":
+			opts.copy = true
+		case "REPLACE":
+			opts.replace = true
+		case "AUTH":
+			if i+1 >= len(args) {
+				return nil, &InvalidArgumentError{Message: "syntax error"}
+			}
+			opts.auth = []string{"AUTH", args[i+1]}
+			i++
+		case "AUTH2":
+			if i+2 >= len(args) {
+				return nil, &InvalidArgumentError{Message: "syntax error"}
+			}
+			opts.auth = []string{"AUTH", args[i+1], args[i+2]}
+			i += 2
+		case "KEYS":
+			if args[2] != "" {
+				return nil, &InvalidArgumentError{Message: "When using MIGRATE KEYS option, the key argument must be set to the empty string"}
+			}
+			opts.keys = args[i+1:]
+			i = len(args)
+		default:
+			return nil, &InvalidArgumentError{Message: "syntax error"}
+		}
+	}
+	if opts.keys == nil {
+		opts.keys = []string{args[2]}
+	}
+	return opts, nil
+}
+
+// migrateKeys는 MIGRATE가 옮길 키들을 반환합니다 (클러스터 모드의 슬롯 확인용).
+// 인자가 잘못되었으면 nil을 반환합니다.
+func migrateKeys(args []string) []string {
+	opts, err := parseMigrateOptions(args)
+	if err != nil {
+		return nil
+	}
+	return opts.keys
+}
+
+// Execute는 MIGRATE 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.SimpleString: 성공 시 OK, 옮길 키가 없으면 NOKEY
+//   - error: 인자가 잘못된 경우, 대상 서버와 통신하지 못한 경우(IOERR), 대상 서버가 에러로 응답한 경우
+func (h *MigrateHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	opts, err := parseMigrateOptions(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// 키마다 보낼 RESTORE 명령어 (남은 만료 시간을 상대 시간으로 전달)
+	var keys []string
+	var restores [][]string
+	now := time.Now()
+	for _, key := range opts.keys {
+		entry, exists := store.Lookup(key)
+		if !exists {
+			continue
+		}
+		payload, err := rdb.Dump(entry.Value)
+		if err != nil {
+			return nil, &InvalidArgumentError{Message: err.Error()}
+		}
+		ttl := int64(0)
+		if !entry.ExpireAt.IsZero() {
+			ttl = max(entry.ExpireAt.Sub(now).Milliseconds(), 1)
+		}
+		restore := []string{"RESTORE", key, strconv.FormatInt(ttl, 10), string(payload)}
+		if opts.replace {
+			restore = append(restore, "REPLACE")
+		}
+		keys = append(keys, key)
+		restores = append(restores, restore)
+	}
+	if len(keys) == 0 {
+		return protocol.SimpleString("NOKEY"), nil
+	}
+
+	conn, err := net.DialTimeout("tcp", opts.addr, opts.timeout)
+	if err != nil {
+		return nil, &IOError{Message: "error or timeout connecting to the client"}
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(opts.timeout))
+
+	// 모든 명령어를 한 번에 보낸 뒤 응답을 순서대로 읽음
+	h.registry.cluster.mu.Lock()
+	asking := h.registry.cluster.enabled
+	h.registry.cluster.mu.Unlock()
+	var commands [][]string
+	if opts.auth != nil {
+		commands = append(commands, opts.auth)
+	}
+	if opts.db != 0 {
+		commands = append(commands, []string{"SELECT", strconv.Itoa(opts.db)})
+	}
+	setup := len(commands)
+	for _, restore := range restores {
+		if asking {
+			commands = append(commands, []string{"ASKING"})
+		}
+		commands = append(commands, restore)
+	}
+
+	writer := protocol.NewWriter(conn)
+	for _, command := range commands {
+		if err := writer.WriteArray(command); err != nil {
+			return nil, &IOError{Message: "error or timeout writing to target instance"}
+		}
+	}
+
+	parser := protocol.NewParser(bufio.NewReader(conn))
+	var migrated []string
+	var replyErr error
+	for i, command := range commands {
+		reply, err := parser.Parse()
+		if err != nil {
+			replyErr = &IOError{Message: "error or timeout reading to target instance"}
+			break
+		}
+		// 에러 응답은 -ERR ... 형식
+		if errReply, ok := reply.(*protocol.ErrorReply); ok {
+			if replyErr == nil {
+				replyErr = &InvalidArgumentError{Message: "Target instance replied with error: " + errReply.Message}
+			}
+			// AUTH나 SELECT가 실패하면 어떤 키도 옮겨지지 않음
+			if i < setup {
+				break
+			}
+			continue
+		}
+		if command[0] == "RESTORE" {
+			migrated = append(migrated, command[1])
+		}
+	}
+
+	if !opts.copy && len(migrated) > 0 {
+		store.DEL(migrated...)
+		h.registry.propagateCommand(append([]string{"DEL"}, migrated...))
+	}
+	if replyErr != nil {
+		return nil, replyErr
+	}
+	return okReply, nil
+}
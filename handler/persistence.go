@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/aof"
+	"github.com/codecrafters-io/redis-starter-go/rdb"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// persistence는 RDB 스냅샷 저장(SAVE, BGSAVE)과 AOF(BGREWRITEAOF)의 설정과 상태입니다.
+//
+// 저장할 키 공간의 시점 고정 뷰(store.BeginSnapshot)는 실행 잠금을 배타적으로 잡은 명령어 안에서 만들어지고,
+// BGSAVE와 BGREWRITEAOF는 잠금을 놓은 뒤 백그라운드 고루틴에서 그 뷰를 파일로 씁니다.
+type persistence struct {
+	mu sync.Mutex
+
+	dir        string // RDB 파일을 저장할 디렉터리 (--dir)
+	dbfilename string // RDB 파일 이름 (--dbfilename)
+
+	bgsaveInProgress bool      // 백그라운드 저장이 진행 중인지 여부
+	lastSave         time.Time // 마지막으로 저장에 성공한 시각 (rdb_last_save_time)
+	lastBgsaveErr    error     // 마지막 백그라운드 저장의 실패 원인 (성공했으면 nil)
+	lastBgsaveTry    time.Time // 마지막으로 백그라운드 저장을 시작한 시각
+
+	// dirty는 마지막 저장 이후 변경된 키의 수입니다 (rdb_changes_since_last_save).
+	// 키 변경 알림에서 원자적으로 증가시킵니다.
+	dirty int64
+
+	saveRules []SaveRule // 자동 BGSAVE 조건 (save <seconds> <changes>)
+	cronOnce  sync.Once  // 자동 저장 검사 고루틴을 한 번만 시작
+
+	aof             *aof.Writer // AOF 기록기 (appendonly no이면 nil)
+	lastAOFWriteErr error       // 마지막 AOF 기록의 실패 원인 (성공했으면 nil)
+	lastRewriteErr  error       // 마지막 AOF 재작성의 실패 원인 (성공했으면 nil)
+}
+
+// newPersistence는 기본 설정(./dump.rdb)의 persistence를 생성합니다.
+// 마지막 저장 시각은 Redis와 같이 서버 시작 시각으로 초기화합니다.
+func newPersistence() *persistence {
+	return &persistence{
+		dir:        ".",
+		dbfilename: "dump.rdb",
+		lastSave:   time.Now(),
+	}
+}
+
+// path는 RDB 파일 경로(dir/dbfilename)를 반환합니다.
+func (p *persistence) path() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return filepath.Join(p.dir, p.dbfilename)
+}
+
+// save는 키 목록을 RDB 파일로 저장하고 마지막 저장 시각을 갱신합니다 (SAVE).
+//
+// 에러 케이스:
+//   - 백그라운드 저장이 진행 중인 경우
+//   - 파일 쓰기에 실패한 경우
+func (p *persistence) save(entries []store.Entry) error {
+	p.mu.Lock()
+	inProgress := p.bgsaveInProgress
+	p.mu.Unlock()
+	if inProgress {
+		return &InvalidArgumentError{Message: "Background save already in progress"}
+	}
+
+	dirty := atomic.LoadInt64(&p.dirty)
+	if err := rdb.SaveFile(p.path(), entries); err != nil {
+		return &InvalidArgumentError{Message: fmt.Sprintf("Error saving DB on disk: %v", err)}
+	}
+
+	p.mu.Lock()
+	p.lastSave = time.Now()
+	p.mu.Unlock()
+	atomic.AddInt64(&p.dirty, -dirty)
+	return nil
+}
+
+// bgsave는 백그라운드 고루틴에서 스냅샷을 RDB 파일로 저장합니다 (BGSAVE).
+//
+// 에러 케이스:
+//   - 백그라운드 저장이 이미 진행 중인 경우
+func (p *persistence) bgsave(view *store.SnapshotView) error {
+	p.mu.Lock()
+	if p.bgsaveInProgress {
+		p.mu.Unlock()
+		return &InvalidArgumentError{Message: "Background save already in progress"}
+	}
+	p.bgsaveInProgress = true
+	p.lastBgsaveTry = time.Now()
+	path := filepath.Join(p.dir, p.dbfilename)
+	p.mu.Unlock()
+
+	// 저장하는 동안의 변경은 저장된 파일에 없으므로, 시작 시점까지의 변경만 차감
+	dirty := atomic.LoadInt64(&p.dirty)
+
+	go func() {
+		err := rdb.SaveFile(path, view.Entries())
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.bgsaveInProgress = false
+		p.lastBgsaveErr = err
+		if err == nil {
+			p.lastSave = time.Now()
+			atomic.AddInt64(&p.dirty, -dirty)
+		}
+	}()
+	return nil
+}
+
+// keyModified는 키가 변경될 때마다 호출되어 변경 횟수를 셉니다 (store.OnKeyModified로 등록됨).
+func (p *persistence) keyModified(key string) {
+	atomic.AddInt64(&p.dirty, 1)
+}
+
+// bgrewriteaof는 백그라운드에서 AOF를 재작성합니다 (BGREWRITEAOF).
+// view는 호출한 순간의 데이터셋이어야 하며, 이후의 쓰기 명령어는 AOF 기록기가 따로 모아 둡니다.
+//
+// 에러 케이스:
+//   - AOF가 꺼져 있는 경우
+//   - 재작성이 이미 진행 중인 경우
+func (p *persistence) bgrewriteaof(view *store.SnapshotView) error {
+	p.mu.Lock()
+	writer := p.aof
+	p.mu.Unlock()
+	if writer == nil {
+		return &InvalidArgumentError{Message: "Background append only file rewriting is not possible when appendonly is disabled"}
+	}
+
+	done, err := writer.Rewrite(view.Entries)
+	if err != nil {
+		return &InvalidArgumentError{Message: "Background append only file rewriting already in progress"}
+	}
+
+	go func() {
+		err := <-done
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.lastRewriteErr = err
+	}()
+	return nil
+}
+
+// appendAOF는 전달받은 쓰기 명령어들을 AOF에 기록합니다 (OnPropagate로 등록됨).
+func (p *persistence) appendAOF(commands [][]string) {
+	p.mu.Lock()
+	writer := p.aof
+	p.mu.Unlock()
+	if writer == nil {
+		return
+	}
+
+	err := writer.Append(commands...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastAOFWriteErr = err
+}
+
+// saving은 백그라운드 저장이 진행 중인지 확인합니다.
+func (p *persistence) saving() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.bgsaveInProgress
+}
+
+// SetRDBFile은 SAVE/BGSAVE가 RDB 파일을 저장할 경로를 설정합니다.
+// 서버 시작 시 --dir, --dbfilename 설정으로 호출합니다.
+//
+// 매개변수:
+//   - dir: RDB 파일을 저장할 디렉터리
+//   - dbfilename: RDB 파일 이름
+func (r *CommandRegistry) SetRDBFile(dir, dbfilename string) {
+	r.persistence.mu.Lock()
+	defer r.persistence.mu.Unlock()
+	r.persistence.dir = dir
+	r.persistence.dbfilename = dbfilename
+}
+
+// SetAOF는 쓰기 명령어를 기록할 AOF를 설정합니다 (appendonly yes).
+// 이후 실행되는 쓰기 명령어가 AOF에 기록되며, BGREWRITEAOF로 재작성할 수 있습니다.
+// AOF를 다시 실행해 데이터셋을 복원한 뒤, 연결을 받기 전에 호출해야 합니다.
+//
+// 매개변수:
+//   - w: aof.Open으로 연 AOF 기록기
+func (r *CommandRegistry) SetAOF(w *aof.Writer) {
+	r.persistence.mu.Lock()
+	first := r.persistence.aof == nil
+	r.persistence.aof = w
+	r.persistence.mu.Unlock()
+
+	if first {
+		r.OnPropagate(r.persistence.appendAOF)
+	}
+}
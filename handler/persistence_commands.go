@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// SaveHandler는 SAVE 명령어를 처리하는 핸들러입니다.
+//
+// Redis SAVE 명령어 사양:
+//   - SAVE
+//   - 키 공간 전체를 RDB 파일(dir/dbfilename)로 저장하고, 끝날 때까지 다른 명령어를 막음
+//   - 백그라운드 저장이 진행 중이면 에러
+//
+// 예시:
+//
+//	클라이언트: SAVE
+//	서버: +OK\r\n
+type SaveHandler struct {
+	persistence *persistence
+}
+
+// Execute는 SAVE 명령어를 실행합니다.
+// 실행 잠금을 배타적으로 잡고 실행되므로 저장하는 동안 키 공간이 변경되지 않습니다.
+func (h *SaveHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 0 {
+		return nil, &WrongNumberOfArgumentsError{Command: "save"}
+	}
+	if err := h.persistence.save(store.Snapshot()); err != nil {
+		return nil, err
+	}
+	return okReply, nil
+}
+
+// BgSaveHandler는 BGSAVE 명령어를 처리하는 핸들러입니다.
+//
+// Redis BGSAVE 명령어 사양:
+//   - BGSAVE [SCHEDULE]
+//   - 현재 키 공간의 시점 고정 뷰를 만든 뒤 백그라운드에서 RDB 파일로 저장하고 즉시 응답
+//   - 저장하는 동안 들어오는 명령어는 뷰에 영향을 주지 않음 (copy-on-write)
+//   - 백그라운드 저장이 이미 진행 중이면 에러
+//
+// 예시:
+//
+//	클라이언트: BGSAVE
+//	서버: +Background saving started\r\n
+type BgSaveHandler struct {
+	persistence *persistence
+	latency     *latencyMonitor
+}
+
+// Execute는 BGSAVE 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.SimpleString: "Background saving started"
+//   - error: 알 수 없는 옵션, 백그라운드 저장이 이미 진행 중인 경우
+func (h *BgSaveHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) > 1 || (len(args) == 1 && !strings.EqualFold(args[0], "SCHEDULE")) {
+		return nil, &InvalidArgumentError{Message: "syntax error"}
+	}
+	if err := h.persistence.bgsave(h.latency.beginSnapshot(store)); err != nil {
+		return nil, err
+	}
+	return protocol.SimpleString("Background saving started"), nil
+}
+
+// BgRewriteAofHandler는 BGREWRITEAOF 명령어를 처리하는 핸들러입니다.
+//
+// Redis BGREWRITEAOF 명령어 사양:
+//   - BGREWRITEAOF
+//   - 현재 데이터셋을 최소한의 명령어로 기록한 새 AOF를 백그라운드에서 만들고 기존 파일과 교체
+//   - 재작성하는 동안의 쓰기 명령어는 기존 AOF에 계속 기록되고, 새 AOF 끝에도 덧붙여짐
+//   - AOF가 꺼져 있거나 재작성이 이미 진행 중이면 에러
+//
+// 예시:
+//
+//	클라이언트: BGREWRITEAOF
+//	서버: +Background append only file rewriting started\r\n
+type BgRewriteAofHandler struct {
+	persistence *persistence
+	latency     *latencyMonitor
+}
+
+// Execute는 BGREWRITEAOF 명령어를 실행합니다.
+// 실행 잠금을 배타적으로 잡고 실행되므로 스냅샷과 명령어 모으기 시작 사이에 끼어드는 쓰기가 없습니다.
+func (h *BgRewriteAofHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 0 {
+		return nil, &WrongNumberOfArgumentsError{Command: "bgrewriteaof"}
+	}
+	if err := h.persistence.bgrewriteaof(h.latency.beginSnapshot(store)); err != nil {
+		return nil, err
+	}
+	return protocol.SimpleString("Background append only file rewriting started"), nil
+}
+
+// LastSaveHandler는 LASTSAVE 명령어를 처리하는 핸들러입니다.
+//
+// Redis LASTSAVE 명령어 사양:
+//   - LASTSAVE
+//   - 마지막으로 저장에 성공한 시각을 Unix 시간(초)으로 반환
+//   - 저장한 적이 없으면 서버 시작 시각
+//
+// 예시:
+//
+//	클라이언트: LASTSAVE
+//	서버: :1700000000\r\n
+type LastSaveHandler struct {
+	persistence *persistence
+}
+
+// Execute는 LASTSAVE 명령어를 실행합니다.
+func (h *LastSaveHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 0 {
+		return nil, &WrongNumberOfArgumentsError{Command: "lastsave"}
+	}
+	h.persistence.mu.Lock()
+	defer h.persistence.mu.Unlock()
+	return protocol.Integer(h.persistence.lastSave.Unix()), nil
+}
@@ -0,0 +1,364 @@
+package handler
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/aof"
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/rdb"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// waitForBgsave는 백그라운드 저장이 끝날 때까지 기다립니다.
+func waitForBgsave(t *testing.T, registry *CommandRegistry) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for registry.persistence.saving() {
+		if time.Now().After(deadline) {
+			t.Fatal("Background save did not finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestSaveHandler는 SAVE로 저장한 RDB 파일을 다시 불러올 수 있는지 테스트합니다.
+func TestSaveHandler(t *testing.T) {
+	dir := t.TempDir()
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetRDBFile(dir, "test.rdb")
+	client, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(client, "SET", []string{"foo", "bar"})
+	registry.ExecuteForClient(client, "RPUSH", []string{"list", "a", "b"})
+	before := registry.persistence.lastSave
+
+	// 테스트 케이스 1: SAVE는 OK를 반환하고 파일을 만듦
+	result, err := registry.ExecuteForClient(client, "SAVE", []string{})
+	if err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	if !registry.persistence.lastSave.After(before) {
+		t.Error("Expected last save time to be updated")
+	}
+
+	loaded := store.NewStore()
+	if err := rdb.LoadFile(filepath.Join(dir, "test.rdb"), loaded); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if value := loaded.GET("foo"); value == nil || string(value) != "bar" {
+		t.Errorf("Expected 'bar', got %v", value)
+	}
+	if list := loaded.LRANGE("list", 0, -1); len(list) != 2 || list[0] != "a" || list[1] != "b" {
+		t.Errorf("Expected [a b], got %v", list)
+	}
+
+	// 테스트 케이스 2: 인자 개수 오류
+	if _, err := registry.ExecuteForClient(client, "SAVE", []string{"extra"}); err == nil {
+		t.Error("Expected error for SAVE with arguments")
+	}
+
+	// 테스트 케이스 3: 백그라운드 저장 중에는 거부
+	registry.persistence.bgsaveInProgress = true
+	_, err = registry.ExecuteForClient(client, "SAVE", []string{})
+	if err == nil || err.Error() != "-ERR Background save already in progress" {
+		t.Errorf("Expected background save error, got %v", err)
+	}
+	registry.persistence.bgsaveInProgress = false
+
+	// 테스트 케이스 4: 저장할 수 없는 경로
+	registry.SetRDBFile(filepath.Join(dir, "missing"), "test.rdb")
+	if _, err := registry.ExecuteForClient(client, "SAVE", []string{}); err == nil {
+		t.Error("Expected error for unwritable directory")
+	}
+}
+
+// TestBgSaveHandler는 BGSAVE의 백그라운드 저장과 동시 저장 거부를 테스트합니다.
+func TestBgSaveHandler(t *testing.T) {
+	dir := t.TempDir()
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetRDBFile(dir, "bg.rdb")
+	client, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(client, "SET", []string{"foo", "bar"})
+
+	// 테스트 케이스 1: 즉시 상태 응답을 반환하고 백그라운드에서 저장
+	result, err := registry.ExecuteForClient(client, "BGSAVE", []string{})
+	if err != nil {
+		t.Fatalf("BGSAVE failed: %v", err)
+	}
+	if !isStatus(result, "Background saving started") {
+		t.Errorf("Expected 'Background saving started', got %v", result)
+	}
+
+	// 저장 시작 후의 변경은 파일에 포함되지 않음
+	registry.ExecuteForClient(client, "SET", []string{"later", "value"})
+	waitForBgsave(t, registry)
+
+	loaded := store.NewStore()
+	if err := rdb.LoadFile(filepath.Join(dir, "bg.rdb"), loaded); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if value := loaded.GET("foo"); value == nil || string(value) != "bar" {
+		t.Errorf("Expected 'bar', got %v", value)
+	}
+	if value := loaded.GET("later"); value != nil {
+		t.Errorf("Expected key written after BGSAVE to be absent, got %q", value)
+	}
+
+	// 테스트 케이스 2: 진행 중이면 거부
+	registry.persistence.mu.Lock()
+	registry.persistence.bgsaveInProgress = true
+	registry.persistence.mu.Unlock()
+	_, err = registry.ExecuteForClient(client, "BGSAVE", []string{})
+	if err == nil || err.Error() != "-ERR Background save already in progress" {
+		t.Errorf("Expected background save error, got %v", err)
+	}
+	registry.persistence.mu.Lock()
+	registry.persistence.bgsaveInProgress = false
+	registry.persistence.mu.Unlock()
+
+	// 테스트 케이스 3: SCHEDULE 옵션과 잘못된 옵션
+	if _, err := registry.ExecuteForClient(client, "BGSAVE", []string{"SCHEDULE"}); err != nil {
+		t.Errorf("Expected BGSAVE SCHEDULE to succeed, got %v", err)
+	}
+	waitForBgsave(t, registry)
+	if _, err := registry.ExecuteForClient(client, "BGSAVE", []string{"NOW"}); err == nil {
+		t.Error("Expected syntax error for unknown option")
+	}
+}
+
+// TestBgRewriteAofHandler는 BGREWRITEAOF가 데이터셋을 최소한의 명령어로 재작성하는지 테스트합니다.
+func TestBgRewriteAofHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: AOF가 꺼져 있으면 에러
+	if _, err := registry.ExecuteForClient(client, "BGREWRITEAOF", []string{}); err == nil {
+		t.Error("Expected error when AOF is disabled")
+	}
+
+	path := filepath.Join(t.TempDir(), "appendonly.aof")
+	writer, err := aof.Open(path, aof.FsyncAlways)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+	registry.SetAOF(writer)
+
+	registry.ExecuteForClient(client, "SET", []string{"key", "v1"})
+	registry.ExecuteForClient(client, "SET", []string{"key", "v2"})
+	registry.ExecuteForClient(client, "RPUSH", []string{"list", "a"})
+	registry.ExecuteForClient(client, "LPOP", []string{"list"})
+	registry.ExecuteForClient(client, "ZADD", []string{"zset", "1", "one"})
+
+	// 테스트 케이스 2: 재작성 후에는 최종 상태만 남음
+	result, err := registry.ExecuteForClient(client, "BGREWRITEAOF", []string{})
+	if err != nil {
+		t.Fatalf("BGREWRITEAOF failed: %v", err)
+	}
+	if !isStatus(result, "Background append only file rewriting started") {
+		t.Errorf("Unexpected reply %v", result)
+	}
+	registry.ExecuteForClient(client, "SET", []string{"after", "rewrite"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for writer.Rewriting() {
+		if time.Now().After(deadline) {
+			t.Fatal("AOF rewrite did not finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	var replayed [][]string
+	aof.ReplayFile(path, store.NewStore(), func(args []string) error {
+		replayed = append(replayed, args)
+		return nil
+	})
+	expected := [][]string{
+		{"SET", "key", "v2"},
+		{"ZADD", "zset", "1", "one"},
+		{"SET", "after", "rewrite"},
+	}
+	if !reflect.DeepEqual(replayed, expected) {
+		t.Errorf("Expected %q, got %q", expected, replayed)
+	}
+
+	// 테스트 케이스 3: 재작성한 AOF를 다시 실행하면 같은 데이터셋
+	replica := NewCommandRegistry(store.NewStore())
+	replicaClient, _ := newTestClient(replica)
+	for _, args := range replayed {
+		if _, err := replica.ExecuteForClient(replicaClient, args[0], args[1:]); err != nil {
+			t.Fatalf("Replaying %q failed: %v", args, err)
+		}
+	}
+	if !reflect.DeepEqual(replica.store.Snapshot(), registry.store.Snapshot()) {
+		t.Error("Replayed dataset differs from the original")
+	}
+}
+
+// TestLastSaveHandler는 LASTSAVE가 마지막 저장 시각을 반환하는지 테스트합니다.
+func TestLastSaveHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetRDBFile(t.TempDir(), "test.rdb")
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: 저장한 적이 없으면 서버 시작 시각
+	result, err := registry.ExecuteForClient(client, "LASTSAVE", []string{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != protocol.Integer(registry.persistence.lastSave.Unix()) {
+		t.Errorf("Expected %d, got %v", registry.persistence.lastSave.Unix(), result)
+	}
+
+	// 테스트 케이스 2: SAVE 이후에는 저장한 시각
+	registry.persistence.lastSave = time.Unix(1000, 0)
+	registry.ExecuteForClient(client, "SAVE", []string{})
+	result, _ = registry.ExecuteForClient(client, "LASTSAVE", []string{})
+	if seconds, ok := result.(protocol.Integer); !ok || seconds <= 1000 {
+		t.Errorf("Expected updated save time, got %v", result)
+	}
+
+	// 테스트 케이스 3: 인자 개수 오류
+	if _, err := registry.ExecuteForClient(client, "LASTSAVE", []string{"extra"}); err == nil {
+		t.Error("Expected error for LASTSAVE with arguments")
+	}
+}
+
+// TestParseSaveRules는 save 설정 파싱을 테스트합니다.
+func TestParseSaveRules(t *testing.T) {
+	// 테스트 케이스 1: 초와 변경 횟수 쌍
+	rules, err := ParseSaveRules("3600 1 300 100")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []SaveRule{{Seconds: 3600, Changes: 1}, {Seconds: 300, Changes: 100}}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("Expected %v, got %v", expected, rules)
+	}
+
+	// 테스트 케이스 2: 빈 설정은 자동 저장 끄기
+	if rules, err := ParseSaveRules(""); err != nil || len(rules) != 0 {
+		t.Errorf("Expected empty rules, got %v, %v", rules, err)
+	}
+
+	// 테스트 케이스 3: 잘못된 설정
+	for _, spec := range []string{"3600", "0 1", "60 -1", "abc 1"} {
+		if _, err := ParseSaveRules(spec); err == nil {
+			t.Errorf("Expected error for %q", spec)
+		}
+	}
+}
+
+// TestSaveRules는 자동 저장 조건을 만족하면 BGSAVE가 시작되는지 테스트합니다.
+func TestSaveRules(t *testing.T) {
+	dir := t.TempDir()
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetRDBFile(dir, "test.rdb")
+	registry.persistence.saveRules = []SaveRule{{Seconds: 60, Changes: 2}}
+	client, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	registry.ExecuteForClient(client, "SET", []string{"b", "2"})
+	start := registry.persistence.lastSave
+
+	// 테스트 케이스 1: 시간이 충분히 지나지 않았으면 저장하지 않음
+	if registry.checkSaveRules(start.Add(30 * time.Second)) {
+		t.Error("Expected no save before the rule's seconds elapsed")
+	}
+
+	// 테스트 케이스 2: 시간과 변경 횟수를 모두 만족하면 BGSAVE
+	if !registry.checkSaveRules(start.Add(61 * time.Second)) {
+		t.Fatal("Expected save to be triggered")
+	}
+	waitForBgsave(t, registry)
+	if dirty := registry.persistence.dirty; dirty != 0 {
+		t.Errorf("Expected changes to be reset, got %d", dirty)
+	}
+	loaded := store.NewStore()
+	if err := rdb.LoadFile(filepath.Join(dir, "test.rdb"), loaded); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if value := loaded.GET("b"); value == nil || string(value) != "2" {
+		t.Errorf("Expected '2', got %v", value)
+	}
+
+	// 테스트 케이스 3: 변경 횟수가 부족하면 저장하지 않음
+	registry.ExecuteForClient(client, "SET", []string{"c", "3"})
+	if registry.checkSaveRules(time.Now().Add(time.Hour)) {
+		t.Error("Expected no save with too few changes")
+	}
+}
+
+// TestInfoHandler는 INFO persistence 섹션을 테스트합니다.
+func TestInfoHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	registry.ExecuteForClient(client, "RPUSH", []string{"list", "x"})
+
+	// 테스트 케이스 1: 지정한 섹션
+	result, err := registry.ExecuteForClient(client, "INFO", []string{"persistence"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	info, _ := result.(protocol.BulkString)
+	for _, line := range []string{"# Persistence\r\n", "rdb_changes_since_last_save:2\r\n", "aof_enabled:0\r\n", "rdb_last_bgsave_status:ok\r\n"} {
+		if !strings.Contains(string(info), line) {
+			t.Errorf("Expected %q in INFO output, got %q", line, info)
+		}
+	}
+
+	// 테스트 케이스 2: 인자가 없으면 모든 섹션
+	if result, _ := registry.ExecuteForClient(client, "INFO", []string{}); !strings.Contains(string(result.(protocol.BulkString)), "# Persistence") {
+		t.Errorf("Expected persistence section, got %q", result)
+	}
+
+	// 테스트 케이스 3: 알 수 없는 섹션은 무시
+	if result, _ := registry.ExecuteForClient(client, "INFO", []string{"nosuchsection"}); result != protocol.BulkString("") {
+		t.Errorf("Expected empty output, got %q", result)
+	}
+}
+
+// TestBgSaveSnapshotIsolation은 BGSAVE가 저장하는 스냅샷이 이후의 쓰기에 영향을 받지 않는지 테스트합니다.
+func TestBgSaveSnapshotIsolation(t *testing.T) {
+	s := store.NewStore()
+	registry := NewCommandRegistry(s)
+	client, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(client, "SET", []string{"str", "before"})
+	registry.ExecuteForClient(client, "RPUSH", []string{"list", "a", "b"})
+	registry.ExecuteForClient(client, "ZADD", []string{"zset", "1", "one"})
+	expected := s.Snapshot()
+
+	view := s.BeginSnapshot()
+	entries := make(chan []store.Entry)
+	go func() { entries <- view.Entries() }()
+
+	// 스냅샷을 읽는 동안 모든 타입을 변경
+	registry.ExecuteForClient(client, "SET", []string{"str", "after"})
+	registry.ExecuteForClient(client, "RPUSH", []string{"list", "c"})
+	registry.ExecuteForClient(client, "LPOP", []string{"list"})
+	registry.ExecuteForClient(client, "ZADD", []string{"zset", "2", "one", "3", "two"})
+	registry.ExecuteForClient(client, "SET", []string{"new", "key"})
+
+	if got := <-entries; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := view.Entries(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected snapshot to stay unchanged, got %v", got)
+	}
+
+	// 저장소에는 변경이 반영됨
+	if score, _ := s.ZSCORE("zset", "one"); score != 2 {
+		t.Errorf("Expected score 2, got %v", score)
+	}
+	if list := s.LRANGE("list", 0, -1); !reflect.DeepEqual(list, []string{"b", "c"}) {
+		t.Errorf("Expected [b c], got %v", list)
+	}
+}
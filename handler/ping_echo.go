@@ -3,6 +3,7 @@
 package handler
 
 import (
+	"github.com/codecrafters-io/redis-starter-go/protocol"
 	"github.com/codecrafters-io/redis-starter-go/store"
 )
 
@@ -29,7 +30,7 @@ type PingHandler struct{}
 // Execute는 PING 명령어를 실행합니다.
 //
 // PING 동작 로직:
-//  1. 인자가 없으면 → "PONG" 문자열 반환 (Simple String)
+//  1. 인자가 없으면 → PONG 상태 응답 반환 (Simple String)
 //  2. 인자가 있으면 → 첫 번째 인자를 그대로 반환 (Bulk String)
 //  3. 데이터 저장소는 사용하지 않음 (상태 없는 명령어)
 //
@@ -40,22 +41,41 @@ type PingHandler struct{}
 //   - store: 사용하지 않음 (nil이어도 무관)
 //
 // 반환값:
-//   - interface{}: "PONG" (string) 또는 에코할 메시지 (string)
+//   - protocol.Reply: "PONG" (protocol.SimpleString) 또는 에코할 메시지 (protocol.BulkString)
 //   - error: 항상 nil (PING은 실패할 수 없음)
 //
 // 성능 특성:
 //   - O(1) 시간 복잡도
 //   - 메모리 사용량 최소
 //   - I/O 없음
-func (h *PingHandler) Execute(args []string, store *store.Store) (interface{}, error) {
+func (h *PingHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
 	// 인자가 없는 경우: 기본 PONG 응답
 	if len(args) == 0 {
-		return "PONG", nil
+		return protocol.SimpleString("PONG"), nil
 	}
 
 	// 인자가 있는 경우: 첫 번째 인자를 에코
 	// Redis는 여러 인자가 있어도 첫 번째만 사용
-	return args[0], nil
+	return protocol.BulkString(args[0]), nil
+}
+
+// ExecuteWithClient는 연결 상태를 고려하여 PING 명령어를 실행합니다.
+//
+// RESP2 연결의 구독 모드(SUBSCRIBE 중)에서는 응답 형식이 달라집니다:
+//   - PING → ["pong", ""]
+//   - PING <메시지> → ["pong", <메시지>]
+//
+// 구독 모드가 아니거나 RESP3 연결이면 Execute와 동일하게 동작합니다.
+func (h *PingHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if !client.InSubscribeMode() || client.Protocol() != 2 {
+		return h.Execute(args, store)
+	}
+
+	message := ""
+	if len(args) > 0 {
+		message = args[0]
+	}
+	return protocol.Array{protocol.BulkString("pong"), protocol.BulkString(message)}, nil
 }
 
 // EchoHandler는 ECHO 명령어를 처리하는 핸들러입니다.
@@ -92,7 +112,7 @@ type EchoHandler struct{}
 //   - store: 사용하지 않음
 //
 // 반환값:
-//   - interface{}: 에코할 메시지 (string)
+//   - protocol.BulkString: 에코할 메시지
 //   - error: 인자가 없으면 에러
 //
 // 에러 케이스:
@@ -101,14 +121,14 @@ type EchoHandler struct{}
 // Redis 표준 에러 메시지 형식:
 //
 //	-ERR wrong number of arguments for 'echo' command
-func (h *EchoHandler) Execute(args []string, store *store.Store) (interface{}, error) {
+func (h *EchoHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
 	// 인자 개수 검증
 	if len(args) == 0 {
 		return nil, &WrongNumberOfArgumentsError{Command: "echo"}
 	}
 
 	// 첫 번째 인자를 그대로 반환
-	return args[0], nil
+	return protocol.BulkString(args[0]), nil
 }
 
 // WrongNumberOfArgumentsError는 명령어 인자 개수가 잘못된 경우의 에러입니다.
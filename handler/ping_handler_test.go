@@ -3,6 +3,7 @@ package handler
 import (
 	"testing"
 
+	"github.com/codecrafters-io/redis-starter-go/protocol"
 	"github.com/codecrafters-io/redis-starter-go/store"
 )
 
@@ -16,7 +17,7 @@ func TestPingHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("PING without args failed: %v", err)
 	}
-	if result != "PONG" {
+	if !isStatus(result, "PONG") {
 		t.Errorf("Expected 'PONG', got %v", result)
 	}
 
@@ -26,7 +27,7 @@ func TestPingHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("PING with message failed: %v", err)
 	}
-	if result != message {
+	if result != protocol.BulkString(message) {
 		t.Errorf("Expected %q, got %v", message, result)
 	}
 
@@ -35,7 +36,7 @@ func TestPingHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("PING with multiple args failed: %v", err)
 	}
-	if result != "first" {
+	if result != protocol.BulkString("first") {
 		t.Errorf("Expected 'first', got %v", result)
 	}
 }
\ No newline at end of file
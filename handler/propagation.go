@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+)
+
+// isWriteCommand는 명령어가 데이터를 변경하는 명령어인지 확인합니다.
+// 성공적으로 실행된 쓰기 명령어는 OnPropagate로 등록한 함수(AOF 등)에 전달됩니다.
+func (r *CommandRegistry) isWriteCommand(cmdUpper string) bool {
+	return r.commandHas(cmdUpper, cmdWrite)
+}
+
+// OnPropagate는 쓰기 명령어가 실행될 때마다 호출될 함수를 등록합니다.
+// AOF처럼 데이터 변경을 다른 곳에 그대로 재현해야 하는 기능이 사용합니다.
+//
+// fn에는 함께 재현되어야 하는 명령어들이 한 번에 전달됩니다 (각 명령어는 이름을 포함한 인자 목록).
+//   - 일반 명령어: 명령어 하나
+//   - 트랜잭션, 스크립트: 실행된 쓰기 명령어들을 MULTI ... EXEC로 감싼 목록 (하나뿐이면 그대로)
+//
+// 다시 실행해도 같은 결과가 되도록 일부 명령어는 바꿔서 전달됩니다.
+//   - SET key value PX ms → SET key value PXAT 만료시각(밀리초)
+//   - BLPOP → 실제로 꺼낸 키에 대한 LPOP
+//
+// fn은 여러 고루틴에서 동시에 호출될 수 있습니다.
+// RegisterCommand와 마찬가지로 연결을 받기 전에 호출해야 합니다.
+func (r *CommandRegistry) OnPropagate(fn func(commands [][]string)) {
+	r.propagateHooks = append(r.propagateHooks, fn)
+}
+
+// propagate는 실행에 성공한 쓰기 명령어를 등록된 함수들에 전달합니다.
+// 트랜잭션이나 스크립트 안에서 실행된 명령어는 모아 두었다가 endPropagation에서 함께 전달합니다.
+//
+// 매개변수:
+//   - cmdUpper: 대문자로 정규화된 명령어 이름
+//   - args: 명령어 인자들
+//   - result: 명령어의 응답
+//   - batched: 트랜잭션이나 스크립트 안에서 실행되었는지 여부
+func (r *CommandRegistry) propagate(cmdUpper string, args []string, result protocol.Reply, batched bool) {
+	if len(r.propagateHooks) == 0 || !r.isWriteCommand(cmdUpper) {
+		return
+	}
+	command := propagationArgs(cmdUpper, args, result)
+	if command == nil {
+		return
+	}
+
+	// 트랜잭션과 스크립트는 배타 잠금을 잡고 실행되므로 잠금 없이 모아도 안전함
+	if batched && r.propagateDepth > 0 {
+		r.propagateBatch = append(r.propagateBatch, command)
+		return
+	}
+	r.emitPropagation([][]string{command})
+}
+
+// propagateExpired는 만료되어 삭제된 키를 DEL로 전달합니다 (store.OnKeyExpired로 등록됨).
+// 레플리카와 AOF는 만료를 직접 처리하지 않고 이 DEL로 키를 삭제합니다.
+// 트랜잭션이나 스크립트 안이면 실행 중인 쓰기 명령어들과 함께 모아서 전달합니다.
+func (r *CommandRegistry) propagateExpired(key string) {
+	r.propagateCommand([]string{"DEL", key})
+}
+
+// propagateCommand는 실행한 명령어 대신 그 결과로 생긴 변경을 명령어 하나로 전달합니다
+// (만료된 키의 DEL, MIGRATE로 옮긴 키의 DEL).
+// 트랜잭션이나 스크립트 안이면 실행 중인 쓰기 명령어들과 함께 모아서 전달합니다.
+func (r *CommandRegistry) propagateCommand(command []string) {
+	if len(r.propagateHooks) == 0 {
+		return
+	}
+	if r.propagateDepth > 0 {
+		r.propagateBatch = append(r.propagateBatch, command)
+		return
+	}
+	r.emitPropagation([][]string{command})
+}
+
+// beginPropagation은 트랜잭션이나 스크립트의 쓰기 명령어를 모으기 시작합니다.
+// 트랜잭션 안에서 스크립트가 실행되는 경우처럼 중첩될 수 있으며, 가장 바깥에서 끝날 때 전달됩니다.
+func (r *CommandRegistry) beginPropagation() {
+	r.propagateDepth++
+}
+
+// endPropagation은 모은 쓰기 명령어들을 MULTI ... EXEC로 감싸 전달합니다.
+func (r *CommandRegistry) endPropagation() {
+	r.propagateDepth--
+	if r.propagateDepth > 0 {
+		return
+	}
+
+	batch := r.propagateBatch
+	r.propagateBatch = nil
+	switch len(batch) {
+	case 0:
+	case 1:
+		r.emitPropagation(batch)
+	default:
+		commands := make([][]string, 0, len(batch)+2)
+		commands = append(commands, []string{"MULTI"})
+		commands = append(commands, batch...)
+		commands = append(commands, []string{"EXEC"})
+		r.emitPropagation(commands)
+	}
+}
+
+// emitPropagation은 등록된 모든 함수에 명령어들을 전달합니다.
+func (r *CommandRegistry) emitPropagation(commands [][]string) {
+	for _, fn := range r.propagateHooks {
+		fn(commands)
+	}
+}
+
+// propagationArgs는 다시 실행해도 같은 결과가 되도록 바꾼 명령어를 반환합니다.
+// 전달할 필요가 없으면 nil을 반환합니다 (예: 대기 시간이 끝난 BLPOP).
+func propagationArgs(cmdUpper string, args []string, result protocol.Reply) []string {
+	switch cmdUpper {
+	case "SET":
+		// 상대 만료 시간은 다시 실행하는 시점에 따라 달라지므로 절대 시각으로 변환
+		if len(args) >= 4 && strings.EqualFold(args[2], "PX") {
+			if ms, err := strconv.Atoi(args[3]); err == nil {
+				expireAt := time.Now().Add(time.Duration(ms) * time.Millisecond).UnixMilli()
+				return []string{"SET", args[0], args[1], "PXAT", strconv.FormatInt(expireAt, 10)}
+			}
+		}
+	case "RESTORE":
+		// 상대 만료 시간은 절대 시각으로 변환
+		if len(args) >= 3 && !slices.ContainsFunc(args[3:], func(opt string) bool { return strings.EqualFold(opt, "ABSTTL") }) {
+			if ms, err := strconv.ParseInt(args[1], 10, 64); err == nil && ms > 0 {
+				expireAt := time.Now().Add(time.Duration(ms) * time.Millisecond).UnixMilli()
+				command := []string{"RESTORE", args[0], strconv.FormatInt(expireAt, 10)}
+				return append(append(command, args[2:]...), "ABSTTL")
+			}
+		}
+	case "MIGRATE":
+		// 옮긴 키의 DEL은 MigrateHandler가 직접 전달
+		return nil
+	case "BLPOP":
+		popped, ok := result.(protocol.BulkStrings)
+		if !ok || len(popped) != 2 {
+			return nil
+		}
+		return []string{"LPOP", popped[0]}
+	}
+	return append([]string{cmdUpper}, args...)
+}
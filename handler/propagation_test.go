@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// recordPropagation은 레지스트리가 전달하는 명령어들을 기록하는 함수를 등록합니다.
+func recordPropagation(registry *CommandRegistry) *[][][]string {
+	var recorded [][][]string
+	registry.OnPropagate(func(commands [][]string) {
+		recorded = append(recorded, commands)
+	})
+	return &recorded
+}
+
+// TestPropagate는 쓰기 명령어만 전달되는지 테스트합니다.
+func TestPropagate(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	recorded := recordPropagation(registry)
+
+	registry.ExecuteForClient(client, "SET", []string{"key", "value"})
+	registry.ExecuteForClient(client, "GET", []string{"key"})
+	registry.ExecuteForClient(client, "RPUSH", []string{"list", "a", "b"})
+	registry.ExecuteForClient(client, "LRANGE", []string{"list", "0", "-1"})
+	registry.ExecuteForClient(client, "SET", []string{"key"})        // 인자 오류는 전달하지 않음
+	registry.ExecuteForClient(client, "PFADD", []string{"key", "x"}) // WRONGTYPE도 전달하지 않음
+
+	expected := [][][]string{
+		{{"SET", "key", "value"}},
+		{{"RPUSH", "list", "a", "b"}},
+	}
+	if !reflect.DeepEqual(*recorded, expected) {
+		t.Errorf("Expected %q, got %q", expected, *recorded)
+	}
+}
+
+// TestPropagateRewrite는 다시 실행해도 같은 결과가 되도록 명령어를 바꿔 전달하는지 테스트합니다.
+func TestPropagateRewrite(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	recorded := recordPropagation(registry)
+
+	// 테스트 케이스 1: SET PX → SET PXAT
+	before := time.Now().Add(time.Minute).UnixMilli()
+	registry.ExecuteForClient(client, "SET", []string{"key", "value", "px", "60000"})
+	after := time.Now().Add(time.Minute).UnixMilli()
+
+	command := (*recorded)[0][0]
+	if len(command) != 5 || command[0] != "SET" || command[3] != "PXAT" {
+		t.Fatalf("Expected SET key value PXAT ms, got %q", command)
+	}
+	if ms, _ := strconv.ParseInt(command[4], 10, 64); ms < before || ms > after {
+		t.Errorf("Expected expire time between %d and %d, got %d", before, after, ms)
+	}
+
+	// 전달된 명령어를 다시 실행하면 같은 값과 만료 시각
+	replica := NewCommandRegistry(store.NewStore())
+	if _, err := replica.Execute(command[0], command[1:]); err != nil {
+		t.Fatalf("Replaying %q failed: %v", command, err)
+	}
+	if value := replica.store.GET("key"); value == nil || string(value) != "value" {
+		t.Errorf("Expected 'value' after replay, got %v", value)
+	}
+
+	// 테스트 케이스 2: BLPOP → LPOP (꺼내지 못한 경우는 전달하지 않음)
+	*recorded = nil
+	registry.ExecuteForClient(client, "RPUSH", []string{"list", "a"})
+	registry.ExecuteForClient(client, "BLPOP", []string{"list", "0"})
+	registry.ExecuteForClient(client, "BLPOP", []string{"list", "0.01"})
+
+	expected := [][][]string{
+		{{"RPUSH", "list", "a"}},
+		{{"LPOP", "list"}},
+	}
+	if !reflect.DeepEqual(*recorded, expected) {
+		t.Errorf("Expected %q, got %q", expected, *recorded)
+	}
+}
+
+// TestPropagateTransaction은 트랜잭션과 스크립트의 쓰기 명령어를 MULTI ... EXEC로 묶어 전달하는지 테스트합니다.
+func TestPropagateTransaction(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	recorded := recordPropagation(registry)
+
+	// 테스트 케이스 1: 트랜잭션 안의 쓰기 명령어들
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	registry.ExecuteForClient(client, "GET", []string{"a"})
+	registry.ExecuteForClient(client, "LPUSH", []string{"list", "x"})
+	registry.ExecuteForClient(client, "EXEC", []string{})
+
+	expected := [][][]string{
+		{{"MULTI"}, {"SET", "a", "1"}, {"LPUSH", "list", "x"}, {"EXEC"}},
+	}
+	if !reflect.DeepEqual(*recorded, expected) {
+		t.Errorf("Expected %q, got %q", expected, *recorded)
+	}
+
+	// 테스트 케이스 2: 스크립트의 쓰기 명령어 (하나뿐이면 감싸지 않음)
+	*recorded = nil
+	registry.ExecuteForClient(client, "EVAL", []string{"redis.call('SET', KEYS[1], 'v'); return redis.call('GET', KEYS[1])", "1", "k"})
+	registry.ExecuteForClient(client, "EVAL", []string{"return redis.call('GET', 'k')", "0"})
+
+	expected = [][][]string{
+		{{"SET", "k", "v"}},
+	}
+	if !reflect.DeepEqual(*recorded, expected) {
+		t.Errorf("Expected %q, got %q", expected, *recorded)
+	}
+
+	// 테스트 케이스 3: 트랜잭션 안의 스크립트는 바깥 트랜잭션에 합쳐짐
+	*recorded = nil
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	registry.ExecuteForClient(client, "EVAL", []string{"redis.call('SET', 'x', '1'); redis.call('SET', 'y', '2')", "0"})
+	registry.ExecuteForClient(client, "RPUSH", []string{"list", "z"})
+	registry.ExecuteForClient(client, "EXEC", []string{})
+
+	expected = [][][]string{
+		{{"MULTI"}, {"SET", "x", "1"}, {"SET", "y", "2"}, {"RPUSH", "list", "z"}, {"EXEC"}},
+	}
+	if !reflect.DeepEqual(*recorded, expected) {
+		t.Errorf("Expected %q, got %q", expected, *recorded)
+	}
+}
+
+// TestPropagateExpired는 읽을 때 만료되어 삭제된 키가 DEL로 전달되는지 테스트합니다.
+func TestPropagateExpired(t *testing.T) {
+	s := store.NewStore()
+	registry := NewCommandRegistry(s)
+	client, _ := newTestClient(registry)
+	recorded := recordPropagation(registry)
+
+	// 테스트 케이스 1: 일반 명령어에서 만료
+	s.SETPXAT("a", []byte("1"), time.Now().Add(-time.Second))
+	if result, _ := registry.ExecuteForClient(client, "GET", []string{"a"}); result != nil {
+		t.Errorf("Expected nil, got %v", result)
+	}
+	expected := [][][]string{{{"DEL", "a"}}}
+	if !reflect.DeepEqual(*recorded, expected) {
+		t.Errorf("Expected %q, got %q", expected, *recorded)
+	}
+
+	// 테스트 케이스 2: 스크립트 안에서 만료되면 스크립트의 쓰기 명령어들과 함께 전달
+	*recorded = nil
+	s.SETPXAT("b", []byte("1"), time.Now().Add(-time.Second))
+	registry.ExecuteForClient(client, "EVAL", []string{"redis.call('GET', 'b'); redis.call('SET', 'c', '1')", "0"})
+
+	expected = [][][]string{{{"MULTI"}, {"DEL", "b"}, {"SET", "c", "1"}, {"EXEC"}}}
+	if !reflect.DeepEqual(*recorded, expected) {
+		t.Errorf("Expected %q, got %q", expected, *recorded)
+	}
+}
+
+// TestPropagateExtension은 FlagWrite로 등록한 확장 명령어가 전달되는지 테스트합니다.
+func TestPropagateExtension(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	noop := func(ctx *CommandContext, args []string) (protocol.Reply, error) {
+		return protocol.SimpleString("OK"), nil
+	}
+	registry.RegisterCommand(CommandSpec{Name: "MYWRITE", Arity: 2, Flags: []string{FlagWrite}, Func: noop})
+	registry.RegisterCommand(CommandSpec{Name: "MYREAD", Arity: 2, Flags: []string{FlagReadOnly}, Func: noop})
+	recorded := recordPropagation(registry)
+
+	registry.ExecuteForClient(client, "mywrite", []string{"a"})
+	registry.ExecuteForClient(client, "myread", []string{"a"})
+
+	expected := [][][]string{{{"MYWRITE", "a"}}}
+	if !reflect.DeepEqual(*recorded, expected) {
+		t.Errorf("Expected %q, got %q", expected, *recorded)
+	}
+}
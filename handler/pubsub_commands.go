@@ -0,0 +1,375 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/pubsub"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// SubscribeHandler는 SUBSCRIBE 명령어를 처리하는 핸들러입니다.
+//
+// Redis SUBSCRIBE 명령어 사양:
+//   - SUBSCRIBE channel [channel ...]
+//   - 채널마다 ["subscribe", <채널>, <현재 구독 수>] 응답을 하나씩 전송
+//   - 이후 해당 채널로 발행된 메시지를 ["message", <채널>, <메시지>] 형태로 수신
+//
+// 예시:
+//
+//	클라이언트: SUBSCRIBE news sports
+//	서버: *3\r\n$9\r\nsubscribe\r\n$4\r\nnews\r\n:1\r\n
+//	      *3\r\n$9\r\nsubscribe\r\n$6\r\nsports\r\n:2\r\n
+type SubscribeHandler struct {
+	broker *pubsub.Broker
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. SUBSCRIBE는 연결 상태가 필요합니다.
+func (h *SubscribeHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "SUBSCRIBE requires a client connection"}
+}
+
+// ExecuteWithClient는 SUBSCRIBE 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.Multi: 채널마다 하나씩의 subscribe 확인 응답
+//   - error: 인자가 없는 경우
+func (h *SubscribeHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "subscribe"}
+	}
+
+	var reply protocol.Multi
+	for _, channel := range args {
+		if h.broker.Subscribe(client, channel) {
+			client.channels[channel] = struct{}{}
+		}
+		reply = append(reply, subscriptionReply("subscribe", protocol.BulkString(channel), client.SubscriptionCount()))
+	}
+	return reply, nil
+}
+
+// subscriptionReply는 [<kind>, <채널>, <구독 수>] 형태의 구독 확인 응답 하나를 만듭니다.
+// 해지할 구독이 없으면 channel은 nil(Null)입니다.
+func subscriptionReply(kind string, channel protocol.Reply, count int) protocol.Push {
+	return protocol.Push{protocol.BulkString(kind), channel, protocol.Integer(count)}
+}
+
+// UnsubscribeHandler는 UNSUBSCRIBE 명령어를 처리하는 핸들러입니다.
+//
+// Redis UNSUBSCRIBE 명령어 사양:
+//   - UNSUBSCRIBE [channel [channel ...]]
+//   - 채널을 지정하지 않으면 구독 중인 모든 채널에서 해지
+//   - 채널마다 ["unsubscribe", <채널>, <남은 구독 수>] 응답을 하나씩 전송
+//   - 구독 중인 채널이 없는데 인자 없이 호출하면 ["unsubscribe", nil, 0] 응답
+//
+// 예시:
+//
+//	클라이언트: UNSUBSCRIBE news
+//	서버: *3\r\n$11\r\nunsubscribe\r\n$4\r\nnews\r\n:1\r\n
+type UnsubscribeHandler struct {
+	broker *pubsub.Broker
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. UNSUBSCRIBE는 연결 상태가 필요합니다.
+func (h *UnsubscribeHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "UNSUBSCRIBE requires a client connection"}
+}
+
+// ExecuteWithClient는 UNSUBSCRIBE 명령어를 실행합니다.
+func (h *UnsubscribeHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	channels := args
+	if len(channels) == 0 {
+		channels = subscriptionNames(client.channels)
+	}
+	if len(channels) == 0 {
+		return protocol.Multi{subscriptionReply("unsubscribe", nil, client.SubscriptionCount())}, nil
+	}
+
+	var reply protocol.Multi
+	for _, channel := range channels {
+		h.broker.Unsubscribe(client, channel)
+		delete(client.channels, channel)
+		reply = append(reply, subscriptionReply("unsubscribe", protocol.BulkString(channel), client.SubscriptionCount()))
+	}
+	return reply, nil
+}
+
+// PSubscribeHandler는 PSUBSCRIBE 명령어를 처리하는 핸들러입니다.
+//
+// Redis PSUBSCRIBE 명령어 사양:
+//   - PSUBSCRIBE pattern [pattern ...]
+//   - glob 패턴과 매칭되는 모든 채널의 메시지를 수신
+//   - 메시지는 ["pmessage", <패턴>, <채널>, <메시지>] 형태로 전달
+//
+// 예시:
+//
+//	클라이언트: PSUBSCRIBE news.*
+//	서버: *3\r\n$10\r\npsubscribe\r\n$6\r\nnews.*\r\n:1\r\n
+type PSubscribeHandler struct {
+	broker *pubsub.Broker
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. PSUBSCRIBE는 연결 상태가 필요합니다.
+func (h *PSubscribeHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "PSUBSCRIBE requires a client connection"}
+}
+
+// ExecuteWithClient는 PSUBSCRIBE 명령어를 실행합니다.
+func (h *PSubscribeHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "psubscribe"}
+	}
+
+	var reply protocol.Multi
+	for _, pattern := range args {
+		if h.broker.PSubscribe(client, pattern) {
+			client.patterns[pattern] = struct{}{}
+		}
+		reply = append(reply, subscriptionReply("psubscribe", protocol.BulkString(pattern), client.SubscriptionCount()))
+	}
+	return reply, nil
+}
+
+// PUnsubscribeHandler는 PUNSUBSCRIBE 명령어를 처리하는 핸들러입니다.
+//
+// Redis PUNSUBSCRIBE 명령어 사양:
+//   - PUNSUBSCRIBE [pattern [pattern ...]]
+//   - 패턴을 지정하지 않으면 구독 중인 모든 패턴에서 해지
+//   - 응답 형식은 UNSUBSCRIBE와 동일 ("punsubscribe")
+type PUnsubscribeHandler struct {
+	broker *pubsub.Broker
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. PUNSUBSCRIBE는 연결 상태가 필요합니다.
+func (h *PUnsubscribeHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "PUNSUBSCRIBE requires a client connection"}
+}
+
+// ExecuteWithClient는 PUNSUBSCRIBE 명령어를 실행합니다.
+func (h *PUnsubscribeHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	patterns := args
+	if len(patterns) == 0 {
+		patterns = subscriptionNames(client.patterns)
+	}
+	if len(patterns) == 0 {
+		return protocol.Multi{subscriptionReply("punsubscribe", nil, client.SubscriptionCount())}, nil
+	}
+
+	var reply protocol.Multi
+	for _, pattern := range patterns {
+		h.broker.PUnsubscribe(client, pattern)
+		delete(client.patterns, pattern)
+		reply = append(reply, subscriptionReply("punsubscribe", protocol.BulkString(pattern), client.SubscriptionCount()))
+	}
+	return reply, nil
+}
+
+// PublishHandler는 PUBLISH 명령어를 처리하는 핸들러입니다.
+//
+// Redis PUBLISH 명령어 사양:
+//   - PUBLISH channel message
+//   - 채널 구독자와 매칭되는 패턴 구독자 모두에게 메시지 전달
+//   - 메시지를 받은 구독자 수를 반환
+//
+// 예시:
+//
+//	클라이언트: PUBLISH news "hello"
+//	서버: :2\r\n
+type PublishHandler struct {
+	broker *pubsub.Broker
+}
+
+// Execute는 PUBLISH 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.Integer: 메시지를 받은 구독자 수
+//   - error: 인자 개수가 맞지 않는 경우
+func (h *PublishHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 2 {
+		return nil, &WrongNumberOfArgumentsError{Command: "publish"}
+	}
+	return protocol.Integer(h.broker.Publish(args[0], args[1])), nil
+}
+
+// PubSubHandler는 PUBSUB 명령어를 처리하는 핸들러입니다.
+//
+// Redis PUBSUB 명령어 사양:
+//   - PUBSUB CHANNELS [pattern]: 구독자가 있는 활성 채널 목록 (패턴 구독 제외)
+//   - PUBSUB NUMSUB [channel ...]: 채널별 구독자 수 ([채널, 수, 채널, 수, ...])
+//   - PUBSUB NUMPAT: 활성 패턴 구독 수
+//   - PUBSUB SHARDCHANNELS [pattern]: 활성 샤드 채널 목록
+//   - PUBSUB SHARDNUMSUB [channel ...]: 샤드 채널별 구독자 수
+//   - PUBSUB HELP: 서브커맨드들의 사용법
+//
+// 예시:
+//
+//	클라이언트: PUBSUB NUMSUB news sports
+//	서버: *4\r\n$4\r\nnews\r\n:2\r\n$6\r\nsports\r\n:0\r\n
+type PubSubHandler struct {
+	broker *pubsub.Broker
+}
+
+// Execute는 PUBSUB 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.BulkStrings: CHANNELS/SHARDCHANNELS의 채널 목록
+//   - protocol.Array: NUMSUB/SHARDNUMSUB의 채널/구독자 수 쌍
+//   - protocol.Integer: NUMPAT의 패턴 수
+//   - error: 서브커맨드가 없거나 알 수 없는 경우, 인자 개수가 맞지 않는 경우
+func (h *PubSubHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.subcommands().dispatch(nil, args, store)
+}
+
+// subcommands는 PUBSUB의 서브커맨드 목록입니다.
+func (h *PubSubHandler) subcommands() *subcommandTable {
+	return &subcommandTable{command: "PUBSUB", subs: []subcommand{
+		{"CHANNELS", -2, []string{"CHANNELS [<pattern>]",
+			"Return the currently active channels matching a <pattern> (default: '*')."}, h.channels},
+		{"NUMPAT", 2, []string{"NUMPAT", "Return number of subscriptions to patterns."}, h.numPat},
+		{"NUMSUB", -2, []string{"NUMSUB [<channel> ...]",
+			"Return the number of subscribers for the specified channels, excluding",
+			"pattern subscriptions(default: no channels)."}, h.numSub},
+		{"SHARDCHANNELS", -2, []string{"SHARDCHANNELS [<pattern>]",
+			"Return the currently active shard level channels matching a <pattern> (default: '*')."}, h.channels},
+		{"SHARDNUMSUB", -2, []string{"SHARDNUMSUB [<shardchannel> ...]",
+			"Return the number of subscribers for the specified shard level channel(s)"}, h.numSub},
+	}}
+}
+
+// channels는 PUBSUB CHANNELS와 SHARDCHANNELS를 실행합니다.
+func (h *PubSubHandler) channels(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) > 2 {
+		return nil, &WrongNumberOfArgumentsError{Command: "pubsub|" + strings.ToLower(args[0])}
+	}
+	pattern := ""
+	if len(args) == 2 {
+		pattern = args[1]
+	}
+	if strings.EqualFold(args[0], "SHARDCHANNELS") {
+		return protocol.BulkStrings(h.broker.ShardChannels(pattern)), nil
+	}
+	return protocol.BulkStrings(h.broker.Channels(pattern)), nil
+}
+
+// numSub는 PUBSUB NUMSUB와 SHARDNUMSUB를 실행합니다. 채널과 구독자 수를 번갈아 나열합니다.
+func (h *PubSubHandler) numSub(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	numSub := h.broker.NumSub
+	if strings.EqualFold(args[0], "SHARDNUMSUB") {
+		numSub = h.broker.ShardNumSub
+	}
+	result := make(protocol.Array, 0, (len(args)-1)*2)
+	for _, channel := range args[1:] {
+		result = append(result, protocol.BulkString(channel), protocol.Integer(numSub(channel)))
+	}
+	return result, nil
+}
+
+// numPat은 PUBSUB NUMPAT을 실행합니다.
+func (h *PubSubHandler) numPat(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	return protocol.Integer(h.broker.NumPat()), nil
+}
+
+// SSubscribeHandler는 SSUBSCRIBE 명령어를 처리하는 핸들러입니다.
+//
+// Redis SSUBSCRIBE 명령어 사양 (Redis 7.0+):
+//   - SSUBSCRIBE shardchannel [shardchannel ...]
+//   - 샤드 채널은 일반 채널과 별개의 네임스페이스를 사용
+//   - 클러스터 모드에서는 채널 이름의 슬롯을 담당하는 노드에서만 전파됨
+//   - 응답의 구독 수는 샤드 채널 구독 수만 셈
+//
+// 예시:
+//
+//	클라이언트: SSUBSCRIBE orders
+//	서버: *3\r\n$10\r\nssubscribe\r\n$6\r\norders\r\n:1\r\n
+type SSubscribeHandler struct {
+	broker *pubsub.Broker
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. SSUBSCRIBE는 연결 상태가 필요합니다.
+func (h *SSubscribeHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "SSUBSCRIBE requires a client connection"}
+}
+
+// ExecuteWithClient는 SSUBSCRIBE 명령어를 실행합니다.
+func (h *SSubscribeHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: "ssubscribe"}
+	}
+
+	var reply protocol.Multi
+	for _, channel := range args {
+		if h.broker.SSubscribe(client, channel) {
+			client.shardChannels[channel] = struct{}{}
+		}
+		reply = append(reply, subscriptionReply("ssubscribe", protocol.BulkString(channel), client.ShardSubscriptionCount()))
+	}
+	return reply, nil
+}
+
+// SUnsubscribeHandler는 SUNSUBSCRIBE 명령어를 처리하는 핸들러입니다.
+//
+// Redis SUNSUBSCRIBE 명령어 사양 (Redis 7.0+):
+//   - SUNSUBSCRIBE [shardchannel [shardchannel ...]]
+//   - 채널을 지정하지 않으면 구독 중인 모든 샤드 채널에서 해지
+//   - 응답 형식은 UNSUBSCRIBE와 동일 ("sunsubscribe")
+type SUnsubscribeHandler struct {
+	broker *pubsub.Broker
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. SUNSUBSCRIBE는 연결 상태가 필요합니다.
+func (h *SUnsubscribeHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "SUNSUBSCRIBE requires a client connection"}
+}
+
+// ExecuteWithClient는 SUNSUBSCRIBE 명령어를 실행합니다.
+func (h *SUnsubscribeHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	channels := args
+	if len(channels) == 0 {
+		channels = subscriptionNames(client.shardChannels)
+	}
+	if len(channels) == 0 {
+		return protocol.Multi{subscriptionReply("sunsubscribe", nil, client.ShardSubscriptionCount())}, nil
+	}
+
+	var reply protocol.Multi
+	for _, channel := range channels {
+		h.broker.SUnsubscribe(client, channel)
+		delete(client.shardChannels, channel)
+		reply = append(reply, subscriptionReply("sunsubscribe", protocol.BulkString(channel), client.ShardSubscriptionCount()))
+	}
+	return reply, nil
+}
+
+// SPublishHandler는 SPUBLISH 명령어를 처리하는 핸들러입니다.
+//
+// Redis SPUBLISH 명령어 사양 (Redis 7.0+):
+//   - SPUBLISH shardchannel message
+//   - 샤드 채널 구독자에게만 전달 (패턴 구독자에게는 전달되지 않음)
+//   - 메시지를 받은 구독자 수를 반환
+//
+// 예시:
+//
+//	클라이언트: SPUBLISH orders "new"
+//	서버: :1\r\n
+type SPublishHandler struct {
+	broker *pubsub.Broker
+}
+
+// Execute는 SPUBLISH 명령어를 실행합니다.
+func (h *SPublishHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 2 {
+		return nil, &WrongNumberOfArgumentsError{Command: "spublish"}
+	}
+	return protocol.Integer(h.broker.SPublish(args[0], args[1])), nil
+}
+
+// subscriptionNames는 구독 집합의 이름 목록을 반환합니다.
+// 반복 중에 집합을 수정할 수 있도록 복사본을 만듭니다.
+func subscriptionNames(set map[string]struct{}) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,326 @@
+package handler
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// newTestClient는 응답을 버퍼에 기록하는 테스트용 클라이언트를 생성합니다.
+func newTestClient(registry *CommandRegistry) (*Client, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	return registry.NewClient(protocol.NewWriter(buf)), buf
+}
+
+// TestSubscribeHandler는 SUBSCRIBE/UNSUBSCRIBE 명령어를 테스트합니다.
+func TestSubscribeHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: 여러 채널 구독 → 채널마다 확인 응답
+	result, err := registry.ExecuteForClient(client, "SUBSCRIBE", []string{"news", "sports"})
+	if err != nil {
+		t.Fatalf("SUBSCRIBE failed: %v", err)
+	}
+	expected := protocol.Multi{
+		protocol.Push{protocol.BulkString("subscribe"), protocol.BulkString("news"), protocol.Integer(1)},
+		protocol.Push{protocol.BulkString("subscribe"), protocol.BulkString("sports"), protocol.Integer(2)},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// 테스트 케이스 2: 이미 구독 중인 채널은 구독 수가 늘지 않음
+	result, _ = registry.ExecuteForClient(client, "SUBSCRIBE", []string{"news"})
+	expected = protocol.Multi{protocol.Push{protocol.BulkString("subscribe"), protocol.BulkString("news"), protocol.Integer(2)}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// 테스트 케이스 3: 특정 채널 구독 해지
+	result, err = registry.ExecuteForClient(client, "UNSUBSCRIBE", []string{"news"})
+	if err != nil {
+		t.Fatalf("UNSUBSCRIBE failed: %v", err)
+	}
+	expected = protocol.Multi{protocol.Push{protocol.BulkString("unsubscribe"), protocol.BulkString("news"), protocol.Integer(1)}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// 테스트 케이스 4: 인자 없이 해지 → 남은 모든 채널 해지
+	result, _ = registry.ExecuteForClient(client, "UNSUBSCRIBE", []string{})
+	expected = protocol.Multi{protocol.Push{protocol.BulkString("unsubscribe"), protocol.BulkString("sports"), protocol.Integer(0)}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// 테스트 케이스 5: 구독이 없을 때 인자 없이 해지 → nil 채널
+	result, _ = registry.ExecuteForClient(client, "UNSUBSCRIBE", []string{})
+	expected = protocol.Multi{protocol.Push{protocol.BulkString("unsubscribe"), nil, protocol.Integer(0)}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// 테스트 케이스 6: 인자 없는 SUBSCRIBE (에러 케이스)
+	if _, err := registry.ExecuteForClient(client, "SUBSCRIBE", []string{}); err == nil {
+		t.Error("Expected error for SUBSCRIBE without channels")
+	}
+
+	// 테스트 케이스 7: 연결 정보 없이 실행 (에러 케이스)
+	if _, err := registry.Execute("SUBSCRIBE", []string{"news"}); err == nil {
+		t.Error("Expected error for SUBSCRIBE without client")
+	}
+}
+
+// TestPSubscribeHandler는 PSUBSCRIBE/PUNSUBSCRIBE 명령어를 테스트합니다.
+func TestPSubscribeHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: 채널 구독과 패턴 구독은 구독 수를 공유
+	registry.ExecuteForClient(client, "SUBSCRIBE", []string{"news"})
+	result, err := registry.ExecuteForClient(client, "PSUBSCRIBE", []string{"news.*"})
+	if err != nil {
+		t.Fatalf("PSUBSCRIBE failed: %v", err)
+	}
+	expected := protocol.Multi{protocol.Push{protocol.BulkString("psubscribe"), protocol.BulkString("news.*"), protocol.Integer(2)}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// 테스트 케이스 2: 패턴 구독 해지
+	result, _ = registry.ExecuteForClient(client, "PUNSUBSCRIBE", []string{})
+	expected = protocol.Multi{protocol.Push{protocol.BulkString("punsubscribe"), protocol.BulkString("news.*"), protocol.Integer(1)}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+// TestPublishHandler는 PUBLISH 명령어와 메시지 전달을 테스트합니다.
+func TestPublishHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	subscriber, buf := newTestClient(registry)
+	publisher, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(subscriber, "SUBSCRIBE", []string{"news"})
+	registry.ExecuteForClient(subscriber, "PSUBSCRIBE", []string{"n*"})
+
+	// 테스트 케이스 1: 채널 구독 + 패턴 구독 → 2번 전달
+	result, err := registry.ExecuteForClient(publisher, "PUBLISH", []string{"news", "hello"})
+	if err != nil {
+		t.Fatalf("PUBLISH failed: %v", err)
+	}
+	if result != protocol.Integer(2) {
+		t.Errorf("Expected 2, got %v", result)
+	}
+
+	// 구독자 연결에 RESP 배열로 메시지가 기록되었는지 확인
+	want := "*3\r\n$7\r\nmessage\r\n$4\r\nnews\r\n$5\r\nhello\r\n" +
+		"*4\r\n$8\r\npmessage\r\n$2\r\nn*\r\n$4\r\nnews\r\n$5\r\nhello\r\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	// 테스트 케이스 2: 구독자가 없는 채널
+	result, _ = registry.ExecuteForClient(publisher, "PUBLISH", []string{"sports", "goal"})
+	if result != protocol.Integer(0) {
+		t.Errorf("Expected 0, got %v", result)
+	}
+
+	// 테스트 케이스 3: 연결 종료 후에는 메시지를 받지 않음
+	registry.CloseClient(subscriber)
+	result, _ = registry.ExecuteForClient(publisher, "PUBLISH", []string{"news", "bye"})
+	if result != protocol.Integer(0) {
+		t.Errorf("Expected 0 after close, got %v", result)
+	}
+
+	// 테스트 케이스 4: 인자 개수 오류 (에러 케이스)
+	if _, err := registry.Execute("PUBLISH", []string{"news"}); err == nil {
+		t.Error("Expected error for PUBLISH with one arg")
+	}
+}
+
+// TestPubSubHandler는 PUBSUB CHANNELS/NUMSUB/NUMPAT 명령어를 테스트합니다.
+func TestPubSubHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	first, _ := newTestClient(registry)
+	second, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(first, "SUBSCRIBE", []string{"news.tech", "weather"})
+	registry.ExecuteForClient(second, "SUBSCRIBE", []string{"news.tech"})
+	registry.ExecuteForClient(first, "PSUBSCRIBE", []string{"news.*"})
+	registry.ExecuteForClient(second, "PSUBSCRIBE", []string{"news.*", "*"})
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected protocol.Reply
+	}{
+		// 테스트 케이스 1: 모든 활성 채널
+		{"channels", []string{"CHANNELS"}, protocol.BulkStrings{"news.tech", "weather"}},
+		// 테스트 케이스 2: 패턴과 매칭되는 채널만
+		{"channels with pattern", []string{"channels", "news.*"}, protocol.BulkStrings{"news.tech"}},
+		// 테스트 케이스 3: 채널별 구독자 수 (구독자 없는 채널은 0)
+		{"numsub", []string{"NUMSUB", "news.tech", "nobody"}, protocol.Array{
+			protocol.BulkString("news.tech"), protocol.Integer(2), protocol.BulkString("nobody"), protocol.Integer(0),
+		}},
+		// 테스트 케이스 4: 채널 없이 NUMSUB → 빈 배열
+		{"numsub empty", []string{"NUMSUB"}, protocol.Array{}},
+		// 테스트 케이스 5: 고유 패턴 수
+		{"numpat", []string{"NUMPAT"}, protocol.Integer(2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := registry.Execute("PUBSUB", tt.args)
+			if err != nil {
+				t.Fatalf("PUBSUB %v failed: %v", tt.args, err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// 테스트 케이스 6: 알 수 없는 서브커맨드 (에러 케이스)
+	if _, err := registry.Execute("PUBSUB", []string{"UNKNOWN"}); err == nil {
+		t.Error("Expected error for unknown subcommand")
+	}
+
+	// 테스트 케이스 7: 서브커맨드 없음 (에러 케이스)
+	if _, err := registry.Execute("PUBSUB", []string{}); err == nil {
+		t.Error("Expected error for missing subcommand")
+	}
+}
+
+// TestSubscribeModeRestrictions는 구독 모드에서의 명령어 제한을 테스트합니다.
+func TestSubscribeModeRestrictions(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: 구독 전에는 모든 명령어 실행 가능
+	if _, err := registry.ExecuteForClient(client, "SET", []string{"key", "value"}); err != nil {
+		t.Fatalf("SET before subscribe failed: %v", err)
+	}
+
+	registry.ExecuteForClient(client, "SUBSCRIBE", []string{"news"})
+
+	// 테스트 케이스 2: 구독 모드에서 일반 명령어는 에러
+	_, err := registry.ExecuteForClient(client, "get", []string{"key"})
+	if err == nil {
+		t.Fatal("Expected error for GET in subscribe mode")
+	}
+	want := "-ERR Can't execute 'get': only (P|S)SUBSCRIBE / (P|S)UNSUBSCRIBE / PING / QUIT / RESET are allowed in this context"
+	if err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+
+	// 테스트 케이스 3: 구독 모드의 PING은 배열로 응답
+	result, err := registry.ExecuteForClient(client, "PING", []string{})
+	if err != nil {
+		t.Fatalf("PING in subscribe mode failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, protocol.Array{protocol.BulkString("pong"), protocol.BulkString("")}) {
+		t.Errorf("Expected [pong ], got %v", result)
+	}
+	result, _ = registry.ExecuteForClient(client, "PING", []string{"hi"})
+	if !reflect.DeepEqual(result, protocol.Array{protocol.BulkString("pong"), protocol.BulkString("hi")}) {
+		t.Errorf("Expected [pong hi], got %v", result)
+	}
+
+	// 테스트 케이스 4: 모든 구독을 해지하면 일반 모드로 복귀
+	registry.ExecuteForClient(client, "UNSUBSCRIBE", []string{})
+	result, err = registry.ExecuteForClient(client, "GET", []string{"key"})
+	if err != nil {
+		t.Fatalf("GET after unsubscribe failed: %v", err)
+	}
+	if !isBulk(result, "value") {
+		t.Errorf("Expected 'value', got %v", result)
+	}
+}
+
+// TestShardPubSubHandlers는 SSUBSCRIBE/SUNSUBSCRIBE/SPUBLISH 명령어를 테스트합니다.
+func TestShardPubSubHandlers(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	subscriber, buf := newTestClient(registry)
+
+	// 테스트 케이스 1: 샤드 채널 구독 수는 일반 구독과 별도로 셈
+	registry.ExecuteForClient(subscriber, "SUBSCRIBE", []string{"orders"})
+	result, err := registry.ExecuteForClient(subscriber, "SSUBSCRIBE", []string{"orders"})
+	if err != nil {
+		t.Fatalf("SSUBSCRIBE failed: %v", err)
+	}
+	expected := protocol.Multi{protocol.Push{protocol.BulkString("ssubscribe"), protocol.BulkString("orders"), protocol.Integer(1)}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// 테스트 케이스 2: SPUBLISH → smessage로 전달
+	buf.Reset()
+	result, err = registry.Execute("SPUBLISH", []string{"orders", "o1"})
+	if err != nil {
+		t.Fatalf("SPUBLISH failed: %v", err)
+	}
+	if result != protocol.Integer(1) {
+		t.Errorf("Expected 1, got %v", result)
+	}
+	want := "*3\r\n$8\r\nsmessage\r\n$6\r\norders\r\n$2\r\no1\r\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	// 테스트 케이스 3: PUBSUB SHARDCHANNELS / SHARDNUMSUB
+	result, _ = registry.Execute("PUBSUB", []string{"SHARDCHANNELS"})
+	if !reflect.DeepEqual(result, protocol.BulkStrings{"orders"}) {
+		t.Errorf("Expected [orders], got %v", result)
+	}
+	result, _ = registry.Execute("PUBSUB", []string{"SHARDNUMSUB", "orders"})
+	if !reflect.DeepEqual(result, protocol.Array{protocol.BulkString("orders"), protocol.Integer(1)}) {
+		t.Errorf("Expected [orders 1], got %v", result)
+	}
+
+	// 테스트 케이스 4: 샤드 채널만 해지해도 일반 구독은 유지 (구독 모드 유지)
+	result, _ = registry.ExecuteForClient(subscriber, "SUNSUBSCRIBE", []string{})
+	expected = protocol.Multi{protocol.Push{protocol.BulkString("sunsubscribe"), protocol.BulkString("orders"), protocol.Integer(0)}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if !subscriber.InSubscribeMode() {
+		t.Error("Expected client to stay in subscribe mode")
+	}
+
+	// 테스트 케이스 5: 인자 개수 오류 (에러 케이스)
+	if _, err := registry.Execute("SPUBLISH", []string{"orders"}); err == nil {
+		t.Error("Expected error for SPUBLISH with one arg")
+	}
+}
+
+// TestRESP3PushDelivery는 RESP3 연결에서 발행 메시지가 Push로 전달되는지 테스트합니다.
+func TestRESP3PushDelivery(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	subscriber, buf := newTestClient(registry)
+	subscriber.SetProtocol(3)
+
+	registry.ExecuteForClient(subscriber, "SUBSCRIBE", []string{"news"})
+
+	// 테스트 케이스 1: 발행 메시지는 '>' Push 타입으로 작성
+	registry.Execute("PUBLISH", []string{"news", "hello"})
+	want := ">3\r\n$7\r\nmessage\r\n$4\r\nnews\r\n$5\r\nhello\r\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	// 테스트 케이스 2: RESP3에서는 구독 중에도 일반 명령어 실행 가능
+	if _, err := registry.ExecuteForClient(subscriber, "SET", []string{"key", "value"}); err != nil {
+		t.Errorf("SET in RESP3 subscribe mode failed: %v", err)
+	}
+
+	// 테스트 케이스 3: RESP3에서는 PING이 일반 응답
+	result, _ := registry.ExecuteForClient(subscriber, "PING", []string{})
+	if !isStatus(result, "PONG") {
+		t.Errorf("Expected 'PONG', got %v", result)
+	}
+}
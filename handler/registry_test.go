@@ -25,7 +25,7 @@ func TestCommandRegistry(t *testing.T) {
 	if err != nil {
 		t.Fatalf("PING execution failed: %v", err)
 	}
-	if result != "PONG" {
+	if !isStatus(result, "PONG") {
 		t.Errorf("Expected 'PONG', got %v", result)
 	}
 
@@ -34,7 +34,7 @@ func TestCommandRegistry(t *testing.T) {
 	if err != nil {
 		t.Fatalf("ping (lowercase) execution failed: %v", err)
 	}
-	if result != "PONG" {
+	if !isStatus(result, "PONG") {
 		t.Errorf("Expected 'PONG', got %v", result)
 	}
 
@@ -43,7 +43,7 @@ func TestCommandRegistry(t *testing.T) {
 	if err != nil {
 		t.Fatalf("PiNg (mixed case) execution failed: %v", err)
 	}
-	if result != "PONG" {
+	if !isStatus(result, "PONG") {
 		t.Errorf("Expected 'PONG', got %v", result)
 	}
 
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+)
+
+// ParseRenameCommand는 "CONFIG MYCONFIG"나 `FLUSHALL ""`처럼 명령어 이름과 새 이름을 나열한
+// rename-command 설정을 파싱합니다. 새 이름이 빈 문자열이면 명령어를 끄는 설정입니다.
+//
+// 에러 케이스:
+//   - 값이 두 개가 아니거나 따옴표가 맞지 않는 경우
+func ParseRenameCommand(spec string) (name, newName string, err error) {
+	fields, err := protocol.SplitArgs(spec)
+	if err != nil || len(fields) != 2 || fields[0] == "" {
+		return "", "", fmt.Errorf("invalid rename-command %q: expected <command> <new-name>", spec)
+	}
+	return fields[0], fields[1], nil
+}
+
+// RenameCommand는 명령어를 새 이름으로만 실행할 수 있게 바꿉니다 (rename-command).
+// newName이 빈 문자열이면 명령어를 끕니다. 이전 이름으로 실행하면 알 수 없는 명령어 에러입니다.
+//
+// 이름은 클라이언트가 보내는 명령어에만 적용됩니다 (연결, Execute, 스크립트의 redis.call).
+// AOF와 레플리카에는 원래 이름으로 전달되며, 마스터의 복제 스트림은 원래 이름으로 실행되므로
+// AOF를 다시 실행한 뒤에 호출해야 합니다.
+//
+// 동시성: 레지스트리는 명령어 목록을 잠금 없이 읽으므로, 연결을 받기 전에 호출해야 합니다.
+//
+// 에러 케이스:
+//   - 실행할 수 있는 명령어가 아닌 경우 (이미 이름을 바꾸었거나 끈 명령어의 이전 이름 포함)
+//   - 새 이름으로 이미 다른 명령어를 실행할 수 있는 경우
+func (r *CommandRegistry) RenameCommand(name, newName string) error {
+	nameUpper, newUpper := strings.ToUpper(name), strings.ToUpper(newName)
+	original, ok := r.resolveCommand(nameUpper)
+	if _, exists := r.handlers[original]; !ok || !exists {
+		return fmt.Errorf("no such command %q", name)
+	}
+	if newUpper != "" && newUpper != nameUpper && r.callable(newUpper) {
+		return fmt.Errorf("command %q already exists", newName)
+	}
+
+	if r.renames == nil {
+		r.renames = make(map[string]string)
+	}
+	// 지금까지의 이름은 더 이상 실행할 수 없음 (원래 이름이면 끈 것으로 표시, 바꾼 이름이면 지움)
+	if nameUpper == original {
+		r.renames[nameUpper] = ""
+	} else {
+		delete(r.renames, nameUpper)
+	}
+	switch newUpper {
+	case "":
+	case original:
+		delete(r.renames, newUpper)
+	default:
+		r.renames[newUpper] = original
+	}
+	return nil
+}
+
+// resolveCommand는 클라이언트가 보낸 명령어 이름을 핸들러가 등록된 원래 이름으로 바꿉니다.
+// 이름을 바꾸었거나 끈 명령어의 이전 이름이면 false를 반환합니다.
+// 바꾸지 않은 이름은 등록 여부와 관계없이 그대로 반환합니다.
+func (r *CommandRegistry) resolveCommand(cmdUpper string) (string, bool) {
+	if original, ok := r.renames[cmdUpper]; ok {
+		return original, original != ""
+	}
+	return cmdUpper, true
+}
+
+// callable은 클라이언트가 이 이름으로 명령어를 실행할 수 있는지 확인합니다.
+func (r *CommandRegistry) callable(cmdUpper string) bool {
+	original, ok := r.resolveCommand(cmdUpper)
+	if !ok {
+		return false
+	}
+	_, exists := r.handlers[original]
+	return exists
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestRenameCommand는 rename-command로 명령어 이름을 바꾸거나 끄는 것을 테스트합니다.
+func TestRenameCommand(t *testing.T) {
+	// 테스트 케이스 1: 바꾼 이름으로만 실행되고, 원래 이름은 알 수 없는 명령어
+	t.Run("Rename", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		client, _ := newTestClient(registry)
+		if err := registry.RenameCommand("set", "MYSET"); err != nil {
+			t.Fatalf("RenameCommand failed: %v", err)
+		}
+
+		if _, err := registry.ExecuteForClient(client, "myset", []string{"key", "value"}); err != nil {
+			t.Errorf("Expected the renamed command to run, got %v", err)
+		}
+		_, err := registry.ExecuteForClient(client, "SET", []string{"key", "other"})
+		if _, ok := err.(*UnknownCommandError); !ok {
+			t.Errorf("Expected unknown command error for the original name, got %v", err)
+		}
+		if result, _ := registry.Execute("GET", []string{"key"}); !isBulk(result, "value") {
+			t.Errorf("Expected value, got %v", result)
+		}
+	})
+
+	// 테스트 케이스 2: 빈 이름이면 명령어를 끔 (연결, Execute, 스크립트 모두)
+	t.Run("Disable", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		client, _ := newTestClient(registry)
+		if err := registry.RenameCommand("DEL", ""); err != nil {
+			t.Fatalf("RenameCommand failed: %v", err)
+		}
+
+		_, err := registry.ExecuteForClient(client, "DEL", []string{"key"})
+		if err == nil || err.Error() != "-ERR unknown command 'DEL'" {
+			t.Errorf("Expected unknown command error, got %v", err)
+		}
+		if _, err := registry.Execute("del", []string{"key"}); err == nil {
+			t.Error("Expected Execute to reject the disabled command")
+		}
+		if _, err := registry.ExecuteForClient(client, "EVAL", []string{"return redis.call('DEL', 'key')", "0"}); err == nil {
+			t.Error("Expected scripts to reject the disabled command")
+		}
+	})
+
+	// 테스트 케이스 3: MULTI 중에 바꾼 이름으로 넣은 명령어도 EXEC에서 실행
+	t.Run("Transaction", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		client, _ := newTestClient(registry)
+		registry.RenameCommand("SET", "MYSET")
+
+		registry.ExecuteForClient(client, "MULTI", nil)
+		if result, err := registry.ExecuteForClient(client, "MYSET", []string{"key", "value"}); err != nil || result != queuedReply {
+			t.Fatalf("Expected QUEUED, got %v, %v", result, err)
+		}
+		if _, err := registry.ExecuteForClient(client, "EXEC", nil); err != nil {
+			t.Fatalf("EXEC failed: %v", err)
+		}
+		if result, _ := registry.Execute("GET", []string{"key"}); !isBulk(result, "value") {
+			t.Errorf("Expected value, got %v", result)
+		}
+	})
+
+	// 테스트 케이스 4: 잘못된 이름 바꾸기는 에러
+	t.Run("Errors", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		if err := registry.RenameCommand("NOSUCH", "OTHER"); err == nil {
+			t.Error("Expected error for an unknown command")
+		}
+		if err := registry.RenameCommand("SET", "GET"); err == nil {
+			t.Error("Expected error when the new name already exists")
+		}
+		registry.RenameCommand("SET", "MYSET")
+		if err := registry.RenameCommand("SET", "OTHER"); err == nil {
+			t.Error("Expected error for a name that was renamed away")
+		}
+		if err := registry.RenameCommand("MYSET", "SET"); err != nil {
+			t.Errorf("Expected to rename back to the original name, got %v", err)
+		}
+		if _, err := registry.Execute("SET", []string{"key", "value"}); err != nil {
+			t.Errorf("Expected the original name to work again, got %v", err)
+		}
+	})
+}
+
+// TestParseRenameCommand는 rename-command 설정의 파싱을 테스트합니다.
+func TestParseRenameCommand(t *testing.T) {
+	tests := []struct {
+		spec    string
+		name    string
+		newName string
+		wantErr bool
+	}{
+		{"CONFIG MYCONFIG", "CONFIG", "MYCONFIG", false},
+		{`FLUSHALL ""`, "FLUSHALL", "", false},
+		{"CONFIG", "", "", true},
+		{"A B C", "", "", true},
+		{`CONFIG "unterminated`, "", "", true},
+	}
+	for _, tt := range tests {
+		name, newName, err := ParseRenameCommand(tt.spec)
+		if (err != nil) != tt.wantErr || name != tt.name || newName != tt.newName {
+			t.Errorf("ParseRenameCommand(%q) = %q, %q, %v", tt.spec, name, newName, err)
+		}
+	}
+}
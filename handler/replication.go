@@ -0,0 +1,566 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/rdb"
+)
+
+// replConnectTimeout은 마스터에 연결할 때 기다리는 최대 시간입니다.
+const replConnectTimeout = 5 * time.Second
+
+// replAckPeriod는 레플리카가 처리한 오프셋을 마스터에 알리는(REPLCONF ACK) 주기입니다.
+const replAckPeriod = time.Second
+
+// replEOFMarkLen은 디스크 없는 동기화에서 RDB 페이로드의 끝을 나타내는 표식의 길이입니다.
+const replEOFMarkLen = 40
+
+// replRetryDelay는 마스터와의 연결이 실패하거나 끊어진 뒤 다시 연결하기까지 기다리는 시간입니다.
+const replRetryDelay = time.Second
+
+// errLinkCancelled는 REPLICAOF로 마스터가 바뀌거나 승격되어 마스터와의 연결이 취소된 경우의 에러입니다.
+var errLinkCancelled = errors.New("replication link cancelled")
+
+// replication은 복제 설정과 상태입니다.
+//
+// 마스터는 PSYNC를 보낸 연결을 레플리카로 등록하고 RDB 스냅샷을 보냅니다.
+//
+// 레플리카 모드(--replicaof, REPLICAOF)에서는 백그라운드 고루틴이 마스터에 연결해 핸드셰이크를 하고,
+// 전체 동기화로 받은 RDB를 불러온 뒤 마스터가 보내는 쓰기 명령어를 계속 실행합니다.
+// 레플리카도 PSYNC를 받을 수 있으며, 마스터의 복제 스트림을 하위 레플리카들에 그대로 전달합니다
+// (복제 ID와 오프셋을 이어받으므로 하위 레플리카의 오프셋도 마스터와 일치).
+type replication struct {
+	mu sync.Mutex
+
+	// ctx는 서버가 종료될 때 취소되어 GETACK 고루틴을 멈춥니다.
+	ctx context.Context
+
+	listeningPort int // 이 서버가 연결을 받는 포트 (REPLCONF listening-port로 마스터에 알림)
+
+	// replID와 offset은 이 서버의 복제 ID와 복제 스트림 위치입니다 (master_replid, master_repl_offset).
+	// 레플리카는 동기화한 마스터의 값을 이어받습니다.
+	replID string
+	offset int64
+
+	// replicas는 PSYNC로 연결된 레플리카들입니다.
+	// getAckOffset은 마지막으로 REPLCONF GETACK를 보낸 직후의 오프셋이고,
+	// getAckOnce는 GETACK를 보내는 고루틴을 한 번만 시작합니다.
+	replicas     []*replicaConn
+	getAckOffset int64
+	getAckOnce   sync.Once
+
+	// masterHost가 빈 문자열이면 마스터, 아니면 masterHost:masterPort의 레플리카
+	// link는 현재 마스터와의 복제 연결이며, 마스터이면 nil입니다.
+	masterHost   string
+	masterPort   int
+	masterLinkUp bool // 동기화를 마치고 명령어 스트림을 받는 중인지 여부 (master_link_status)
+	link         *masterLink
+
+	// readOnly이면 레플리카는 마스터가 보낸 것 외의 쓰기 명령어를 거부합니다 (replica-read-only).
+	readOnly bool
+
+	// disklessSync이면 전체 동기화의 RDB를 임시 파일 없이 레플리카 연결에 바로 씁니다 (repl-diskless-sync).
+	disklessSync bool
+
+	// failover는 진행 중인 FAILOVER이며, 없으면 nil입니다.
+	failover *failover
+}
+
+// masterLink는 마스터와의 복제 연결 하나입니다.
+// 마스터가 바뀌거나 승격되면 취소되며, 취소된 연결은 데이터셋과 복제 상태를 더 이상 바꾸지 않습니다.
+type masterLink struct {
+	host     string
+	port     int
+	failover bool          // FAILOVER로 강등되어 대상 레플리카에 PSYNC FAILOVER를 보내는지 여부
+	done     chan struct{} // 취소되면 닫힘
+	conn     net.Conn      // 현재 마스터 연결 (replication.mu로 보호, 취소 시 닫음)
+}
+
+// cancelled는 연결이 취소되었는지 확인합니다.
+func (l *masterLink) cancelled() bool {
+	select {
+	case <-l.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// newReplication은 새 복제 ID를 가진 마스터로 시작하는 replication을 생성합니다.
+func newReplication(ctx context.Context) *replication {
+	return &replication{ctx: ctx, listeningPort: 6379, replID: newReplID(), readOnly: true, disklessSync: true}
+}
+
+// newReplID는 40자리 16진수의 임의 복제 ID를 만듭니다.
+func newReplID() string {
+	buf := make([]byte, 20)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// SetListeningPort는 이 서버가 연결을 받는 포트를 설정합니다 (--port).
+// 레플리카로 동작할 때 마스터에 이 포트를 알리고, 클러스터 모드에서는 이 노드의 주소로 사용합니다.
+func (r *CommandRegistry) SetListeningPort(port int) {
+	r.replication.mu.Lock()
+	defer r.replication.mu.Unlock()
+	r.replication.listeningPort = port
+
+	r.cluster.mu.Lock()
+	defer r.cluster.mu.Unlock()
+	r.cluster.myself.port = port
+}
+
+// SetReplicaReadOnly는 레플리카일 때 일반 클라이언트의 쓰기 명령어를 거부할지 설정합니다 (--replica-read-only).
+// 기본값은 true입니다.
+func (r *CommandRegistry) SetReplicaReadOnly(readOnly bool) {
+	r.replication.mu.Lock()
+	defer r.replication.mu.Unlock()
+	r.replication.readOnly = readOnly
+}
+
+// SetReplDisklessSync는 전체 동기화의 RDB를 임시 파일 없이 보낼지 설정합니다 (--repl-diskless-sync).
+// 기본값은 true입니다.
+func (r *CommandRegistry) SetReplDisklessSync(diskless bool) {
+	r.replication.mu.Lock()
+	defer r.replication.mu.Unlock()
+	r.replication.disklessSync = diskless
+}
+
+// rejectsWrite는 읽기 전용 레플리카에서 client가 쓰기 명령어를 실행하려는지 확인합니다.
+// 마스터의 복제 스트림을 실행하는 연결은 제외합니다.
+func (r *CommandRegistry) rejectsWrite(client *Client, cmdUpper string) bool {
+	if client != nil && client.master || !r.isWriteCommand(cmdUpper) {
+		return false
+	}
+	r.replication.mu.Lock()
+	defer r.replication.mu.Unlock()
+	return r.replication.masterHost != "" && r.replication.readOnly
+}
+
+// ReplicaOf는 host:port의 레플리카로 동작하기 시작합니다 (--replicaof, REPLICAOF).
+// 백그라운드에서 마스터에 연결해 전체 동기화를 받고, 연결이 끊어지면 다시 연결합니다.
+// 데이터셋은 마스터에서 받은 RDB로 교체됩니다.
+// 만료된 키는 읽을 때 없는 것으로 보고하지만, 마스터가 DEL을 보낼 때까지 삭제하지 않습니다.
+// 이미 다른 마스터의 레플리카이면 기존 연결을 끊고 새 마스터와 동기화합니다.
+//
+// 매개변수:
+//   - host: 마스터 주소
+//   - port: 마스터 포트
+func (r *CommandRegistry) ReplicaOf(host string, port int) {
+	r.replicaOf(host, port, false)
+}
+
+// replicaOf는 ReplicaOf의 구현입니다.
+// failover이면 PSYNC FAILOVER로 새 마스터에 승격을 요청합니다 (FAILOVER).
+func (r *CommandRegistry) replicaOf(host string, port int, failover bool) {
+	p := r.replication
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cancelLinkLocked()
+	link := &masterLink{host: host, port: port, failover: failover, done: make(chan struct{})}
+	p.link = link
+	p.masterHost = host
+	p.masterPort = port
+	p.masterLinkUp = false
+	// 만료된 키는 마스터가 보내는 DEL로만 삭제
+	r.store.SetKeepExpired(true)
+	if failover && p.failover != nil {
+		p.failover.link = link
+	}
+
+	go r.replicationLoop(link)
+}
+
+// ReplicaOfNoOne은 마스터와의 복제를 끊고 마스터로 승격합니다 (REPLICAOF NO ONE).
+// 데이터셋과 복제 오프셋은 그대로 두고, 새 복제 ID로 자신의 복제 스트림을 시작합니다.
+// 이후로는 만료된 키를 직접 삭제하고 DEL을 전달합니다.
+// 이미 마스터이면 아무 일도 하지 않습니다.
+func (r *CommandRegistry) ReplicaOfNoOne() {
+	p := r.replication
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.masterHost == "" {
+		return
+	}
+
+	r.becomeMasterLocked()
+	p.replID = newReplID()
+}
+
+// becomeMasterLocked는 마스터와의 연결을 끊고 마스터로 동작하기 시작합니다. p.mu를 잡고 호출합니다.
+// 복제 ID는 바꾸지 않습니다 (승격이 실패한 FAILOVER를 되돌릴 때는 원래 복제 ID를 유지).
+func (r *CommandRegistry) becomeMasterLocked() {
+	p := r.replication
+	p.cancelLinkLocked()
+	p.masterHost = ""
+	p.masterPort = 0
+	p.masterLinkUp = false
+	p.getAckOffset = p.offset
+	r.store.SetKeepExpired(false)
+}
+
+// cancelLinkLocked는 현재 마스터와의 연결을 취소합니다. p.mu를 잡고 호출합니다.
+func (p *replication) cancelLinkLocked() {
+	if p.link == nil {
+		return
+	}
+	close(p.link.done)
+	if p.link.conn != nil {
+		p.link.conn.Close()
+	}
+	p.link = nil
+}
+
+// replicationLoop는 연결이 취소될 때까지 마스터와의 동기화를 반복합니다.
+// 연결이 끊어지면 replRetryDelay 뒤에 다시 연결합니다.
+func (r *CommandRegistry) replicationLoop(link *masterLink) {
+	for {
+		err := r.syncWithMaster(link)
+		if link.cancelled() {
+			return
+		}
+		// FAILOVER 대상이 승격을 거부했거나 연결할 수 없으면 다시 마스터로 돌아감
+		if link.failover && r.abortFailover(link) {
+			r.Logger().Warn("Failover failed", "host", link.host, "port", link.port, "err", err)
+			return
+		}
+
+		r.replication.mu.Lock()
+		r.replication.masterLinkUp = false
+		r.replication.mu.Unlock()
+
+		r.Logger().Warn("Replication with master failed", "host", link.host, "port", link.port, "err", err)
+		select {
+		case <-link.done:
+			return
+		case <-time.After(replRetryDelay):
+		}
+	}
+}
+
+// syncWithMaster는 마스터에 연결해 핸드셰이크와 전체 동기화를 한 뒤,
+// 연결이 끊어질 때까지 마스터가 보내는 명령어를 실행합니다.
+//
+// 핸드셰이크 순서:
+//  1. PING → +PONG
+//  2. REPLCONF listening-port <port> → +OK
+//  3. REPLCONF capa eof capa psync2 → +OK
+//  4. PSYNC ? -1 → +FULLRESYNC <replid> <offset>, 이어서 $<길이>\r\n<RDB>
+//     (FAILOVER로 강등된 경우 PSYNC <replid> <offset> FAILOVER로 새 마스터의 승격을 요청)
+func (r *CommandRegistry) syncWithMaster(link *masterLink) error {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(link.host, strconv.Itoa(link.port)), replConnectTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// 취소되면 연결을 닫아 대기 중인 읽기를 끝냄
+	r.replication.mu.Lock()
+	if r.replication.link != link {
+		r.replication.mu.Unlock()
+		return errLinkCancelled
+	}
+	link.conn = conn
+	r.replication.mu.Unlock()
+
+	reader := bufio.NewReader(conn)
+	writer := protocol.NewWriter(conn)
+	send := func(args ...string) (string, error) {
+		if err := writer.WriteArray(args); err != nil {
+			return "", err
+		}
+		return readStatusReply(reader)
+	}
+
+	r.replication.mu.Lock()
+	listeningPort := r.replication.listeningPort
+	r.replication.mu.Unlock()
+
+	if _, err := send("PING"); err != nil {
+		return fmt.Errorf("PING: %w", err)
+	}
+	if _, err := send("REPLCONF", "listening-port", strconv.Itoa(listeningPort)); err != nil {
+		return fmt.Errorf("REPLCONF listening-port: %w", err)
+	}
+	if _, err := send("REPLCONF", "capa", "eof", "capa", "psync2"); err != nil {
+		return fmt.Errorf("REPLCONF capa: %w", err)
+	}
+	psync := []string{"PSYNC", "?", "-1"}
+	if link.failover {
+		r.replication.mu.Lock()
+		psync = []string{"PSYNC", r.replication.replID, strconv.FormatInt(r.replication.offset, 10), "FAILOVER"}
+		r.replication.mu.Unlock()
+	}
+	reply, err := send(psync...)
+	if err != nil {
+		return fmt.Errorf("PSYNC: %w", err)
+	}
+	fields := strings.Fields(reply)
+	if len(fields) != 3 || fields[0] != "FULLRESYNC" {
+		return fmt.Errorf("PSYNC: unexpected reply %q", reply)
+	}
+	if link.failover {
+		// 새 마스터가 승격했으므로 FAILOVER를 마치고 쓰기를 재개
+		r.replication.endFailover()
+	}
+	offset, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("PSYNC: invalid offset %q", fields[2])
+	}
+
+	payload, err := readRDBPayload(reader)
+	if err != nil {
+		return fmt.Errorf("reading RDB: %w", err)
+	}
+	if err := r.loadMasterDataset(link, payload, fields[1], offset); err != nil {
+		return err
+	}
+
+	// GETACK에 대한 응답과 주기적인 ACK가 섞이지 않도록 쓰기를 직렬화
+	var ackMu sync.Mutex
+	sendAck := func(offset int64) error {
+		ackMu.Lock()
+		defer ackMu.Unlock()
+		return writer.WriteArray([]string{"REPLCONF", "ACK", strconv.FormatInt(offset, 10)})
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go r.ackLoop(stop, sendAck)
+
+	return r.applyMasterStream(link, reader, sendAck)
+}
+
+// ackLoop는 stop이 닫힐 때까지 replAckPeriod마다 처리한 오프셋을 마스터에 알립니다.
+// GETACK에 대한 응답은 GETACK 자체를 포함하지 않으므로, 마스터는 이 ACK로 레플리카가 따라잡았는지 확인합니다 (FAILOVER).
+func (r *CommandRegistry) ackLoop(stop <-chan struct{}, sendAck func(offset int64) error) {
+	ticker := time.NewTicker(replAckPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			r.replication.mu.Lock()
+			offset := r.replication.offset
+			r.replication.mu.Unlock()
+			if sendAck(offset) != nil {
+				return
+			}
+		}
+	}
+}
+
+// readStatusReply는 핸드셰이크 응답 하나를 읽습니다.
+// 에러 응답(-ERR ...)이면 그 응답(*protocol.ErrorReply)을 에러로 반환하고,
+// 문자열이나 정수이면 그 내용을 반환합니다.
+func readStatusReply(reader *bufio.Reader) (string, error) {
+	reply, err := protocol.NewParser(reader).Parse()
+	if err != nil {
+		return "", err
+	}
+	switch v := reply.(type) {
+	case *protocol.ErrorReply:
+		return "", v
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	}
+	return "", fmt.Errorf("unexpected reply %v", reply)
+}
+
+// readRDBPayload는 전체 동기화의 RDB 페이로드를 읽습니다.
+//   - $<길이>\r\n<내용>: 일반 벌크 문자열과 달리 내용 뒤에 \r\n이 붙지 않음
+//   - $EOF:<표식>\r\n<내용><표식>: 디스크 없는 동기화 (표식이 나올 때까지 읽음)
+//
+// 마스터가 스냅샷을 준비하는 동안 보내는 빈 줄(연결 유지용)은 건너뜁니다.
+func readRDBPayload(reader *bufio.Reader) ([]byte, error) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			continue
+		}
+		if line[0] != '$' {
+			return nil, fmt.Errorf("unexpected reply %q", line)
+		}
+		if mark, ok := strings.CutPrefix(line, "$EOF:"); ok {
+			return readUntilMark(reader, mark)
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("invalid payload length %q", line)
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return nil, err
+		}
+		return payload, nil
+	}
+}
+
+// readUntilMark는 mark가 나올 때까지 읽고, mark를 뗀 내용을 반환합니다.
+func readUntilMark(reader *bufio.Reader, mark string) ([]byte, error) {
+	if len(mark) != replEOFMarkLen {
+		return nil, fmt.Errorf("invalid EOF mark %q", mark)
+	}
+	last := mark[len(mark)-1]
+	var payload []byte
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, b)
+		if b == last && bytes.HasSuffix(payload, []byte(mark)) {
+			return payload[:len(payload)-len(mark)], nil
+		}
+	}
+}
+
+// loadMasterDataset은 기존 데이터셋을 지우고 마스터에서 받은 RDB를 불러온 뒤,
+// 마스터의 복제 ID와 오프셋을 이어받습니다.
+// 다른 명령어가 중간 상태를 보지 않도록 실행 잠금을 배타적으로 잡으며,
+// 그 사이 연결이 취소되었으면 데이터셋을 건드리지 않습니다.
+func (r *CommandRegistry) loadMasterDataset(link *masterLink, payload []byte, replID string, offset int64) error {
+	r.execMu.Lock()
+	defer r.execMu.Unlock()
+
+	p := r.replication
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.link != link {
+		return errLinkCancelled
+	}
+
+	r.store.FlushAll()
+	if err := rdb.Load(bytes.NewReader(payload), r.store); err != nil {
+		return fmt.Errorf("loading RDB from master: %w", err)
+	}
+	p.replID = replID
+	p.offset = offset
+	p.masterLinkUp = true
+
+	// 데이터셋이 바뀌었으므로 하위 레플리카들은 다시 전체 동기화를 받아야 함
+	p.disconnectReplicasLocked()
+	return nil
+}
+
+// applyMasterStream은 마스터가 보내는 명령어를 연결이 끊어질 때까지 실행합니다.
+// 마스터에는 응답을 보내지 않으며, 처리한 명령어의 크기만큼 복제 오프셋을 늘립니다.
+// 예외로 REPLCONF GETACK에는 그 앞까지 처리한 오프셋을 REPLCONF ACK <offset>으로 알립니다.
+func (r *CommandRegistry) applyMasterStream(link *masterLink, reader *bufio.Reader, sendAck func(offset int64) error) error {
+	// MULTI/EXEC를 그대로 재현할 수 있도록 응답을 버리는 가상의 연결로 실행
+	// CLIENT LIST에 마스터의 주소가 보이고, CLIENT KILL TYPE master로 연결을 끊을 수 있도록 마스터 연결을 연결해 둠
+	client := r.NewClient(protocol.NewWriter(io.Discard))
+	client.master = true
+	client.authenticated = true
+	client.SetConn(link.conn)
+	client.commandFinished()
+	defer r.CloseClient(client)
+
+	parser := protocol.NewParser(reader)
+	for {
+		value, err := parser.Parse()
+		if err != nil {
+			return err
+		}
+		args, ok := commandArgs(value)
+		if !ok {
+			return fmt.Errorf("invalid command from master: %v", value)
+		}
+
+		ackOffset, err := r.applyMasterCommand(link, client, args)
+		if err != nil {
+			return err
+		}
+		if isGetAck(args) {
+			if err := sendAck(ackOffset); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// applyMasterCommand는 마스터가 보낸 명령어 하나를 실행하고 하위 레플리카들에 그대로 전달합니다.
+// 하위 레플리카의 전체 동기화(PSYNC)가 실행과 전달 사이에 끼어들어 같은 명령어를 두 번 받지 않도록
+// 실행 잠금을 배타적으로 잡은 채 실행하고 전달합니다. REPLCONF GETACK는 실행하지 않습니다.
+//
+// 반환값:
+//   - int64: 명령어를 처리하기 전의 복제 오프셋 (GETACK에 대한 ACK 오프셋)
+//   - error: 마스터와의 연결이 취소된 경우
+func (r *CommandRegistry) applyMasterCommand(link *masterLink, client *Client, args []string) (int64, error) {
+	r.execMu.Lock()
+	defer r.execMu.Unlock()
+	if link.cancelled() {
+		return 0, errLinkCancelled
+	}
+
+	if !isGetAck(args) {
+		r.executeForClient(client, args[0], args[1:], true)
+	}
+	return r.replication.relay(link, args)
+}
+
+// relay는 마스터에서 받은 명령어를 복제 스트림에 추가합니다.
+// 복제 오프셋을 늘리고 하위 레플리카들의 출력 버퍼에 넣습니다.
+//
+// 반환값:
+//   - int64: 추가하기 전의 복제 오프셋
+//   - error: 마스터와의 연결이 취소된 경우
+func (p *replication) relay(link *masterLink, args []string) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.link != link {
+		return 0, errLinkCancelled
+	}
+	offset := p.offset
+	p.feedLocked([][]string{args})
+	return offset, nil
+}
+
+// isGetAck는 마스터가 보낸 REPLCONF GETACK인지 확인합니다.
+func isGetAck(args []string) bool {
+	return len(args) >= 2 && strings.EqualFold(args[0], "REPLCONF") && strings.EqualFold(args[1], "GETACK")
+}
+
+// commandArgs는 파싱한 RESP 값을 명령어 인자 목록으로 변환합니다.
+// 비어 있지 않은 벌크 문자열 배열이 아니면 false를 반환합니다.
+func commandArgs(value interface{}) ([]string, bool) {
+	arr, ok := value.([]interface{})
+	if !ok || len(arr) == 0 {
+		return nil, false
+	}
+	args := make([]string, len(arr))
+	for i, elem := range arr {
+		s, ok := elem.(string)
+		if !ok {
+			return nil, false
+		}
+		args[i] = s
+	}
+	return args, true
+}
+
+// commandSize는 명령어를 RESP 배열로 인코딩했을 때의 바이트 수입니다 (복제 오프셋 계산용).
+func commandSize(args []string) int64 {
+	return int64(protocol.CommandSize(args))
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// ReplConfHandler는 REPLCONF 명령어를 처리하는 핸들러입니다.
+//
+// Redis REPLCONF 명령어 사양 (레플리카가 마스터에 보냄):
+//   - REPLCONF listening-port <port>: 레플리카가 연결을 받는 포트 (핸드셰이크)
+//   - REPLCONF capa <capability> [capa <capability> ...]: 레플리카가 지원하는 기능 (eof, psync2)
+//   - REPLCONF ACK <offset> [FACK <offset>]: GETACK에 대한 처리 완료 오프셋 (응답 없음)
+//
+// 예시:
+//
+//	레플리카: REPLCONF listening-port 6380
+//	마스터: +OK\r\n
+type ReplConfHandler struct{}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. REPLCONF는 연결 상태가 필요합니다.
+func (h *ReplConfHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "REPLCONF requires a client connection"}
+}
+
+// ExecuteWithClient는 REPLCONF 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.SimpleString: OK
+//   - error: 인자가 옵션-값 쌍이 아니거나, 포트가 숫자가 아니거나, 알 수 없는 옵션인 경우
+func (h *ReplConfHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args)%2 != 0 {
+		return nil, &InvalidArgumentError{Message: "syntax error"}
+	}
+
+	for i := 0; i < len(args); i += 2 {
+		switch strings.ToLower(args[i]) {
+		case "listening-port":
+			port, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				return nil, &InvalidArgumentError{Message: "value is not an integer or out of range"}
+			}
+			client.replListeningPort = port
+		case "capa":
+			// eof: 디스크 없는 동기화에서 RDB를 크기 없이 EOF 표식 형식으로 받을 수 있음
+			if strings.EqualFold(args[i+1], "eof") {
+				client.replCapaEOF = true
+			}
+		case "ack":
+			offset, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil {
+				return nil, &InvalidArgumentError{Message: "value is not an integer or out of range"}
+			}
+			// 레플리카 연결에서 온 ACK만 기록 (응답은 보내지 않음)
+			if client.replica != nil {
+				client.replica.ack(offset)
+			}
+		case "fack":
+			// AOF 동기화 오프셋은 사용하지 않음
+		default:
+			return nil, &InvalidArgumentError{Message: "Unrecognized REPLCONF option: " + args[i]}
+		}
+	}
+	return okReply, nil
+}
+
+// ReplicaOfHandler는 REPLICAOF(SLAVEOF) 명령어를 처리하는 핸들러입니다.
+//
+// Redis REPLICAOF 명령어 사양:
+//   - REPLICAOF <host> <port>: host:port의 레플리카가 됨 (기존 데이터셋은 마스터의 것으로 교체)
+//   - REPLICAOF NO ONE: 복제를 끊고 마스터로 승격 (데이터셋은 유지)
+//   - 동기화는 백그라운드에서 진행되므로 바로 OK를 반환
+//   - 이미 같은 마스터의 레플리카이면 "OK Already connected to specified master"
+//
+// 예시:
+//
+//	클라이언트: REPLICAOF 127.0.0.1 6379
+//	서버: +OK\r\n
+type ReplicaOfHandler struct {
+	registry *CommandRegistry
+}
+
+// Execute는 REPLICAOF 명령어를 실행합니다.
+func (h *ReplicaOfHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 2 {
+		return nil, &WrongNumberOfArgumentsError{Command: "replicaof"}
+	}
+
+	if strings.EqualFold(args[0], "no") && strings.EqualFold(args[1], "one") {
+		h.registry.ReplicaOfNoOne()
+		return okReply, nil
+	}
+
+	port, err := strconv.Atoi(args[1])
+	if err != nil || port < 0 || port > 65535 {
+		return nil, &InvalidArgumentError{Message: "Invalid master port"}
+	}
+
+	p := h.registry.replication
+	p.mu.Lock()
+	connected := p.masterHost == args[0] && p.masterPort == port
+	failingOver := p.failover != nil
+	p.mu.Unlock()
+	if failingOver {
+		return nil, &InvalidArgumentError{Message: "REPLICAOF not allowed while failing over."}
+	}
+	if connected {
+		return protocol.SimpleString("OK Already connected to specified master"), nil
+	}
+
+	h.registry.ReplicaOf(args[0], port)
+	return okReply, nil
+}
+
+// PsyncHandler는 PSYNC 명령어를 처리하는 핸들러입니다.
+//
+// Redis PSYNC 명령어 사양:
+//   - PSYNC <replid> <offset> [FAILOVER]
+//   - 부분 동기화는 지원하지 않으므로 항상 전체 동기화로 응답
+//   - FAILOVER: FAILOVER 중인 마스터가 보냄, 이 레플리카가 마스터로 승격한 뒤 전체 동기화로 응답
+//   - +FULLRESYNC <replid> <offset> 뒤에 RDB 스냅샷($<길이>\r\n<RDB>)을 보냄
+//   - 이후 연결은 레플리카로 등록되어 명령어 응답 대신 복제 스트림을 받음
+//
+// 예시:
+//
+//	레플리카: PSYNC ? -1
+//	마스터: +FULLRESYNC 8371b4fb1155b71f4a04d3e1bc3e18c4a990aeeb 0\r\n$88\r\nREDIS0011...
+type PsyncHandler struct {
+	registry *CommandRegistry
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. PSYNC는 연결 상태가 필요합니다.
+func (h *PsyncHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "PSYNC requires a client connection"}
+}
+
+// ExecuteWithClient는 PSYNC 명령어를 실행합니다.
+// 응답(+FULLRESYNC와 RDB)은 직접 작성하므로 빈 protocol.Multi를 반환합니다.
+func (h *PsyncHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, &WrongNumberOfArgumentsError{Command: "psync"}
+	}
+	if len(args) == 3 && !strings.EqualFold(args[2], "FAILOVER") {
+		return nil, &InvalidArgumentError{Message: "syntax error"}
+	}
+	if client.IsReplica() {
+		return nil, &InvalidArgumentError{Message: "Replica already connected"}
+	}
+
+	if len(args) == 3 {
+		h.registry.replication.mu.Lock()
+		isReplica := h.registry.replication.masterHost != ""
+		h.registry.replication.mu.Unlock()
+		if !isReplica {
+			return nil, &InvalidArgumentError{Message: "PSYNC FAILOVER can't be sent to a master."}
+		}
+		// 이전 마스터가 강등되어 보낸 요청이므로 마스터로 승격
+		h.registry.ReplicaOfNoOne()
+	}
+
+	h.registry.startFullSync(client)
+	return protocol.Multi{}, nil
+}
@@ -0,0 +1,314 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/rdb"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// replGetAckPeriod는 마스터가 레플리카들에 처리한 오프셋을 묻는(REPLCONF GETACK) 주기입니다.
+const replGetAckPeriod = time.Second
+
+// replicaConn은 마스터에 연결된 레플리카 하나의 상태입니다.
+//
+// 복제 스트림은 레플리카마다 출력 버퍼(queue)에 쌓였다가 전용 고루틴이 연결에 씁니다.
+// 느린 레플리카가 명령어를 실행하는 고루틴을 막지 않으며, RDB를 보내는 동안 쌓인 명령어는
+// 전송이 끝난 뒤 순서대로 이어서 보냅니다.
+type replicaConn struct {
+	client        *Client
+	ip            string // 레플리카 연결의 원격 IP
+	listeningPort int    // REPLCONF listening-port로 받은 포트
+	capaEOF       bool   // EOF 표식 형식의 RDB 페이로드를 받을 수 있는지 여부
+
+	mu     sync.Mutex
+	online bool          // RDB 전송을 마치고 명령어 스트림을 받는 중인지 여부
+	closed bool          // 등록이 해제되었는지 여부
+	queue  [][]string    // 아직 보내지 않은 복제 스트림 (출력 버퍼)
+	wake   chan struct{} // queue에 보낼 명령어가 생겼음을 쓰기 고루틴에 알림
+
+	ackOffset int64     // 레플리카가 REPLCONF ACK로 알린 처리 완료 오프셋
+	ackTime   time.Time // 마지막으로 ACK를 받은 시각 (INFO의 lag)
+}
+
+// addReplica는 PSYNC를 보낸 연결을 레플리카로 등록합니다.
+// 이후 이 연결에는 명령어 응답을 보내지 않고, 쓰기 명령어가 출력 버퍼에 쌓이기 시작합니다.
+func (p *replication) addReplica(client *Client) *replicaConn {
+	ip := client.addr
+	if host, _, err := net.SplitHostPort(client.addr); err == nil {
+		ip = host
+	}
+	rep := &replicaConn{
+		client:        client,
+		ip:            ip,
+		listeningPort: client.replListeningPort,
+		capaEOF:       client.replCapaEOF,
+		wake:          make(chan struct{}, 1),
+	}
+	client.replica = rep
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.replicas = append(p.replicas, rep)
+	p.getAckOnce.Do(func() { go p.getAckLoop() })
+	return rep
+}
+
+// removeReplica는 레플리카 등록을 해제합니다. 레플리카가 아니면 아무 일도 하지 않습니다.
+func (p *replication) removeReplica(client *Client) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, rep := range p.replicas {
+		if rep.client == client {
+			p.replicas = append(p.replicas[:i], p.replicas[i+1:]...)
+			rep.close()
+			return
+		}
+	}
+}
+
+// feed는 쓰기 명령어들을 복제 스트림에 추가합니다 (OnPropagate로 등록됨).
+// 복제 오프셋을 늘리고 모든 레플리카의 출력 버퍼에 넣습니다.
+// 레플리카는 마스터에서 받은 스트림을 그대로 전달하므로(relay) 아무 일도 하지 않습니다.
+func (p *replication) feed(commands [][]string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.masterHost != "" {
+		return
+	}
+	p.feedLocked(commands)
+}
+
+// disconnectReplicasLocked는 모든 레플리카의 등록을 해제하고 연결을 끊습니다. p.mu를 잡고 호출합니다.
+// 끊어진 레플리카는 다시 연결해 전체 동기화를 받습니다.
+func (p *replication) disconnectReplicasLocked() {
+	for _, rep := range p.replicas {
+		rep.close()
+		rep.client.disconnect()
+	}
+	p.replicas = nil
+}
+
+// feedLocked는 feed와 같으며 p.mu를 잡고 호출합니다.
+func (p *replication) feedLocked(commands [][]string) {
+	for _, args := range commands {
+		p.offset += commandSize(args)
+	}
+	for _, rep := range p.replicas {
+		rep.enqueue(commands)
+	}
+}
+
+// getAckLoop는 서버가 종료될 때까지 replGetAckPeriod마다 레플리카들에 처리한 오프셋을 묻습니다.
+func (p *replication) getAckLoop() {
+	ticker := time.NewTicker(replGetAckPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.sendGetAck()
+		}
+	}
+}
+
+// sendGetAck는 마지막으로 물어본 뒤 복제 스트림이 늘어났으면 REPLCONF GETACK *를 보냅니다.
+// GETACK도 복제 스트림의 일부이므로 오프셋이 늘어나며, 쓰기가 없으면 다시 묻지 않습니다.
+//
+// 반환값:
+//   - bool: GETACK를 보냈으면 true
+func (p *replication) sendGetAck() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.masterHost != "" || len(p.replicas) == 0 || p.offset == p.getAckOffset {
+		return false
+	}
+	p.feedLocked([][]string{{"REPLCONF", "GETACK", "*"}})
+	p.getAckOffset = p.offset
+	return true
+}
+
+// info는 INFO replication의 slaveN 필드 값을 반환합니다.
+// 예: ip=127.0.0.1,port=6380,state=online,offset=1234,lag=0
+func (rep *replicaConn) info(now time.Time) string {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+
+	state := "wait_bgsave"
+	if rep.online {
+		state = "online"
+	}
+	lag := int64(0)
+	if !rep.ackTime.IsZero() {
+		lag = int64(now.Sub(rep.ackTime) / time.Second)
+	}
+	return fmt.Sprintf("ip=%s,port=%d,state=%s,offset=%d,lag=%d", rep.ip, rep.listeningPort, state, rep.ackOffset, lag)
+}
+
+// ack는 레플리카가 REPLCONF ACK로 알린 처리 완료 오프셋을 기록합니다.
+func (rep *replicaConn) ack(offset int64) {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	rep.ackOffset = offset
+	rep.ackTime = time.Now()
+}
+
+// enqueue는 명령어들을 출력 버퍼에 넣고, 온라인이면 쓰기 고루틴을 깨웁니다.
+func (rep *replicaConn) enqueue(commands [][]string) {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	if rep.closed {
+		return
+	}
+	rep.queue = append(rep.queue, commands...)
+	if rep.online {
+		rep.notify()
+	}
+}
+
+// notify는 쓰기 고루틴을 깨웁니다. 이미 깨울 예정이면 아무 일도 하지 않습니다. rep.mu를 잡고 호출합니다.
+func (rep *replicaConn) notify() {
+	select {
+	case rep.wake <- struct{}{}:
+	default:
+	}
+}
+
+// goOnline은 RDB 전송을 마친 레플리카에 복제 스트림을 보내기 시작합니다.
+func (rep *replicaConn) goOnline() {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	if rep.closed {
+		return
+	}
+	rep.online = true
+	rep.notify()
+	go rep.writeLoop()
+}
+
+// writeLoop는 등록이 해제될 때까지 출력 버퍼의 명령어들을 레플리카 연결에 씁니다.
+func (rep *replicaConn) writeLoop() {
+	for range rep.wake {
+		rep.mu.Lock()
+		queue := rep.queue
+		rep.queue = nil
+		rep.mu.Unlock()
+
+		if len(queue) == 0 {
+			continue
+		}
+		rep.client.WithWriter(func(w *protocol.Writer) {
+			for _, args := range queue {
+				w.WriteArray(args)
+			}
+		})
+	}
+}
+
+// close는 출력 버퍼를 비우고 쓰기 고루틴을 끝냅니다.
+func (rep *replicaConn) close() {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	if rep.closed {
+		return
+	}
+	rep.closed = true
+	rep.queue = nil
+	close(rep.wake)
+}
+
+// startFullSync는 레플리카에 +FULLRESYNC 응답을 보내고, 백그라운드에서 스냅샷을 RDB로 보냅니다.
+// PSYNC는 실행 잠금을 배타적으로 잡고 실행되므로, 스냅샷과 응답의 오프셋이 일치합니다.
+func (r *CommandRegistry) startFullSync(client *Client) {
+	rep := r.replication.addReplica(client)
+	atomic.AddInt64(&r.stats.syncFull, 1)
+
+	r.replication.mu.Lock()
+	reply := "FULLRESYNC " + r.replication.replID + " " + strconv.FormatInt(r.replication.offset, 10)
+	diskless := r.replication.disklessSync
+	r.replication.mu.Unlock()
+	client.WithWriter(func(w *protocol.Writer) {
+		w.WriteSimpleString(reply)
+	})
+
+	view := r.latency.beginSnapshot(r.store)
+	dir := filepath.Dir(r.persistence.path())
+	go func() {
+		var err error
+		if diskless {
+			err = sendRDBDiskless(rep, view)
+		} else {
+			err = sendRDB(rep, view, dir)
+		}
+		if err != nil {
+			r.Logger().Warn("Full sync with replica failed", "client", client.ID, "err", err)
+			r.replication.removeReplica(client)
+			return
+		}
+		rep.goOnline()
+	}()
+}
+
+// sendRDBDiskless는 임시 파일 없이 스냅샷을 레플리카에 보냅니다 (디스크 없는 동기화).
+// 레플리카가 EOF 표식 형식을 지원하면 RDB를 연결에 바로 쓰고,
+// 그렇지 않으면 크기를 알아야 하므로 메모리에 직렬화한 뒤 보냅니다.
+func sendRDBDiskless(rep *replicaConn, view *store.SnapshotView) error {
+	var err error
+	if rep.capaEOF {
+		mark := newReplID() // 40자리 임의 문자열
+		rep.client.WithWriter(func(w *protocol.Writer) {
+			err = w.WriteRDBStream(mark, func(out io.Writer) error {
+				return rdb.Write(out, view.Entries())
+			})
+		})
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := rdb.Write(&buf, view.Entries()); err != nil {
+		return err
+	}
+	rep.client.WithWriter(func(w *protocol.Writer) {
+		err = w.WriteRDBPayload(&buf, int64(buf.Len()))
+	})
+	return err
+}
+
+// sendRDB는 스냅샷을 dir의 임시 RDB 파일로 저장한 뒤 레플리카에 보냅니다 (디스크 기반 동기화).
+func sendRDB(rep *replicaConn, view *store.SnapshotView, dir string) error {
+	f, err := os.CreateTemp(dir, "temp-repl-*.rdb")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := rdb.Write(f, view.Entries()); err != nil {
+		return err
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	rep.client.WithWriter(func(w *protocol.Writer) {
+		err = w.WriteRDBPayload(bufio.NewReader(f), size)
+	})
+	return err
+}
@@ -0,0 +1,706 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/rdb"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// waitFor는 cond가 true가 될 때까지 기다립니다.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestReplicaOf는 레플리카가 핸드셰이크, 전체 동기화, 명령어 스트림 적용을 하는지 테스트합니다.
+func TestReplicaOf(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	// 마스터의 데이터셋
+	masterStore := store.NewStore()
+	masterStore.SET("foo", []byte("from-rdb"), nil)
+	var snapshot bytes.Buffer
+	rdb.Write(&snapshot, masterStore.Snapshot())
+
+	stream := [][]string{{"SET", "bar", "1"}, {"RPUSH", "list", "a", "b"}}
+	getAck := []string{"REPLCONF", "GETACK", "*"}
+	handshake := make(chan [][]string, 1)
+	ack := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		parser := protocol.NewParser(bufio.NewReader(conn))
+
+		var received [][]string
+		for _, reply := range []string{"+PONG\r\n", "+OK\r\n", "+OK\r\n", "+FULLRESYNC 8371b4fb1155b71f4a04d3e1bc3e18c4a990aeeb 100\r\n"} {
+			value, err := parser.Parse()
+			if err != nil {
+				return
+			}
+			args, _ := commandArgs(value)
+			received = append(received, args)
+			conn.Write([]byte(reply))
+		}
+		handshake <- received
+
+		conn.Write([]byte("\n$" + strconv.Itoa(snapshot.Len()) + "\r\n"))
+		conn.Write(snapshot.Bytes())
+		w := protocol.NewWriter(conn)
+		for _, args := range stream {
+			w.WriteArray(args)
+		}
+		w.WriteArray(getAck)
+		value, err := parser.Parse()
+		if err != nil {
+			return
+		}
+		args, _ := commandArgs(value)
+		ack <- args
+		time.Sleep(time.Second) // 레플리카가 적용할 때까지 연결 유지
+	}()
+
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	registry.ExecuteForClient(client, "SET", []string{"stale", "value"})
+	registry.SetListeningPort(6380)
+
+	port := ln.Addr().(*net.TCPAddr).Port
+	registry.ReplicaOf("127.0.0.1", port)
+
+	// 테스트 케이스 1: 핸드셰이크 순서
+	expected := [][]string{
+		{"PING"},
+		{"REPLCONF", "listening-port", "6380"},
+		{"REPLCONF", "capa", "eof", "capa", "psync2"},
+		{"PSYNC", "?", "-1"},
+	}
+	select {
+	case received := <-handshake:
+		if !reflect.DeepEqual(received, expected) {
+			t.Errorf("Expected handshake %q, got %q", expected, received)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for handshake")
+	}
+
+	// 테스트 케이스 2: RDB로 데이터셋을 교체한 뒤 명령어 스트림 적용
+	waitFor(t, "replicated list", func() bool {
+		result, _ := registry.ExecuteForClient(client, "LLEN", []string{"list"})
+		return result == protocol.Integer(2)
+	})
+	if result, _ := registry.ExecuteForClient(client, "GET", []string{"foo"}); !isBulk(result, "from-rdb") {
+		t.Errorf("Expected 'from-rdb', got %v", result)
+	}
+	if result, _ := registry.ExecuteForClient(client, "GET", []string{"bar"}); !isBulk(result, "1") {
+		t.Errorf("Expected '1', got %v", result)
+	}
+	if result, _ := registry.ExecuteForClient(client, "GET", []string{"stale"}); result != nil {
+		t.Errorf("Expected old dataset to be discarded, got %v", result)
+	}
+
+	// 테스트 케이스 3: GETACK에는 GETACK 앞까지 처리한 오프셋으로 응답
+	streamOffset := 100 + commandSize(stream[0]) + commandSize(stream[1])
+	select {
+	case args := <-ack:
+		if want := []string{"REPLCONF", "ACK", strconv.FormatInt(streamOffset, 10)}; !reflect.DeepEqual(args, want) {
+			t.Errorf("Expected %q, got %q", want, args)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for REPLCONF ACK")
+	}
+
+	// 테스트 케이스 4: 복제 ID는 마스터의 것, 오프셋은 FULLRESYNC 오프셋 + 처리한 명령어 크기 (GETACK 포함)
+	waitFor(t, "offset after GETACK", func() bool {
+		registry.replication.mu.Lock()
+		defer registry.replication.mu.Unlock()
+		return registry.replication.offset == streamOffset+commandSize(getAck)
+	})
+	registry.replication.mu.Lock()
+	offset := registry.replication.offset
+	linkUp := registry.replication.masterLinkUp
+	replID := registry.replication.replID
+	registry.replication.mu.Unlock()
+	if want := streamOffset + commandSize(getAck); offset != want {
+		t.Errorf("Expected offset %d, got %d", want, offset)
+	}
+	if replID != "8371b4fb1155b71f4a04d3e1bc3e18c4a990aeeb" {
+		t.Errorf("Expected master's replication ID, got %q", replID)
+	}
+	if !linkUp {
+		t.Error("Expected master link to be up")
+	}
+}
+
+// TestCommandSize는 명령어의 RESP 인코딩 크기 계산을 테스트합니다.
+func TestCommandSize(t *testing.T) {
+	var buf bytes.Buffer
+	args := []string{"SET", "key", "a value longer than ten bytes"}
+	protocol.NewWriter(&buf).WriteArray(args)
+	if size := commandSize(args); size != int64(buf.Len()) {
+		t.Errorf("Expected %d, got %d", buf.Len(), size)
+	}
+}
+
+// TestReadStatusReply는 핸드셰이크 응답의 상태, 에러, 정수 응답을 읽는지 테스트합니다.
+func TestReadStatusReply(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("+PONG\r\n-NOAUTH Authentication required.\r\n:5\r\n"))
+
+	if reply, err := readStatusReply(reader); err != nil || reply != "PONG" {
+		t.Errorf("Expected PONG, got %q, %v", reply, err)
+	}
+	_, err := readStatusReply(reader)
+	if replyErr, ok := err.(*protocol.ErrorReply); !ok || replyErr.Code() != "NOAUTH" {
+		t.Errorf("Expected NOAUTH error reply, got %v", err)
+	}
+	if reply, err := readStatusReply(reader); err != nil || reply != "5" {
+		t.Errorf("Expected 5, got %q, %v", reply, err)
+	}
+}
+
+// TestPsyncHandler는 마스터가 PSYNC에 전체 동기화로 응답하고 연결을 레플리카로 등록하는지 테스트합니다.
+func TestPsyncHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetRDBFile(t.TempDir(), "dump.rdb")
+	client, buf := newTestClient(registry)
+	registry.ExecuteForClient(client, "SET", []string{"foo", "bar"})
+
+	// 테스트 케이스 1: REPLCONF 핸드셰이크
+	for _, args := range [][]string{{"listening-port", "6380"}, {"capa", "eof", "capa", "psync2"}} {
+		if result, err := registry.ExecuteForClient(client, "REPLCONF", args); err != nil || !isStatus(result, "OK") {
+			t.Fatalf("REPLCONF %v: expected OK, got %v, %v", args, result, err)
+		}
+	}
+	if client.replListeningPort != 6380 {
+		t.Errorf("Expected listening port 6380, got %d", client.replListeningPort)
+	}
+	if _, err := registry.ExecuteForClient(client, "REPLCONF", []string{"unknown", "1"}); err == nil {
+		t.Error("Expected error for unknown REPLCONF option")
+	}
+
+	// 테스트 케이스 2: PSYNC → +FULLRESYNC와 RDB 페이로드, 이후 레플리카로 등록
+	result, err := registry.ExecuteForClient(client, "PSYNC", []string{"?", "-1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if reply, ok := result.(protocol.Multi); !ok || len(reply) != 0 {
+		t.Errorf("Expected empty reply, got %v", result)
+	}
+	if !client.IsReplica() {
+		t.Fatal("Expected connection to be registered as a replica")
+	}
+	rep := client.replica
+	waitFor(t, "full sync", func() bool {
+		rep.mu.Lock()
+		defer rep.mu.Unlock()
+		return rep.online
+	})
+
+	reader := bufio.NewReader(bytes.NewReader(buf.Bytes()))
+	reply, err := readStatusReply(reader)
+	wantReply := "FULLRESYNC " + registry.replication.replID + " " + strconv.FormatInt(commandSize([]string{"SET", "foo", "bar"}), 10)
+	if err != nil || reply != wantReply {
+		t.Errorf("Expected FULLRESYNC reply, got %q, %v", reply, err)
+	}
+	payload, err := readRDBPayload(reader)
+	if err != nil {
+		t.Fatalf("Reading RDB payload failed: %v", err)
+	}
+	replicaStore := store.NewStore()
+	if err := rdb.Load(bytes.NewReader(payload), replicaStore); err != nil {
+		t.Fatalf("Loading RDB payload failed: %v", err)
+	}
+	if value := replicaStore.GET("foo"); value == nil || string(value) != "bar" {
+		t.Errorf("Expected 'bar', got %v", value)
+	}
+	if reader.Buffered() != 0 {
+		t.Errorf("Expected nothing after the RDB payload, got %d bytes", reader.Buffered())
+	}
+
+	// 테스트 케이스 3: 연결이 끊어지면 등록 해제
+	registry.CloseClient(client)
+	if n := len(registry.replication.replicas); n != 0 {
+		t.Errorf("Expected no replicas, got %d", n)
+	}
+}
+
+// readReplicaStream은 레플리카 연결에 쓰인 +FULLRESYNC 응답과 RDB 뒤의 복제 스트림을 읽습니다.
+func readReplicaStream(replica *Client, buf *bytes.Buffer) (string, [][]string) {
+	var reply string
+	var stream [][]string
+	replica.WithWriter(func(*protocol.Writer) {
+		reader := bufio.NewReader(bytes.NewReader(buf.Bytes()))
+		var err error
+		if reply, err = readStatusReply(reader); err != nil {
+			return
+		}
+		if _, err := readRDBPayload(reader); err != nil {
+			return
+		}
+		parser := protocol.NewParser(reader)
+		for {
+			value, err := parser.Parse()
+			if err != nil {
+				return
+			}
+			args, _ := commandArgs(value)
+			stream = append(stream, args)
+		}
+	})
+	return reply, stream
+}
+
+// TestReplicationStream은 쓰기 명령어가 RDB 뒤에 이어서 레플리카로 전달되는지 테스트합니다.
+func TestReplicationStream(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetRDBFile(t.TempDir(), "dump.rdb")
+	replica, buf := newTestClient(registry)
+	client, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(client, "SET", []string{"before", "sync"})
+	registry.ExecuteForClient(replica, "PSYNC", []string{"?", "-1"})
+	startOffset := registry.replication.offset
+
+	// RDB를 보내는 동안 실행된 명령어도 출력 버퍼에 쌓였다가 전달됨
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	registry.ExecuteForClient(client, "GET", []string{"a"}) // 읽기 명령어는 전달하지 않음
+	registry.ExecuteForClient(client, "RPUSH", []string{"list", "x", "y"})
+
+	expected := [][]string{{"SET", "a", "1"}, {"RPUSH", "list", "x", "y"}}
+	var stream [][]string
+	waitFor(t, "replication stream", func() bool {
+		_, stream = readReplicaStream(replica, buf)
+		return len(stream) == len(expected)
+	})
+
+	// 테스트 케이스 1: 순서대로 전달
+	if !reflect.DeepEqual(stream, expected) {
+		t.Errorf("Expected %q, got %q", expected, stream)
+	}
+
+	// 테스트 케이스 2: 오프셋은 전달한 명령어 크기만큼 증가
+	registry.replication.mu.Lock()
+	offset := registry.replication.offset
+	registry.replication.mu.Unlock()
+	if want := startOffset + commandSize(expected[0]) + commandSize(expected[1]); offset != want {
+		t.Errorf("Expected offset %d, got %d", want, offset)
+	}
+}
+
+// TestReplConfAck는 마스터가 GETACK를 보내고 레플리카의 ACK 오프셋을 기록하는지 테스트합니다.
+func TestReplConfAck(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetRDBFile(t.TempDir(), "dump.rdb")
+	replica, _ := newTestClient(registry)
+	client, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(replica, "PSYNC", []string{"?", "-1"})
+	rep := replica.replica
+
+	// 테스트 케이스 1: 쓰기가 없으면 GETACK를 보내지 않음
+	if registry.replication.sendGetAck() {
+		t.Error("Expected no GETACK without writes")
+	}
+
+	// 테스트 케이스 2: 쓰기 뒤에는 GETACK를 한 번만 보내고, GETACK만큼 오프셋 증가
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	before := registry.replication.offset
+	if !registry.replication.sendGetAck() {
+		t.Fatal("Expected GETACK after a write")
+	}
+	if registry.replication.sendGetAck() {
+		t.Error("Expected GETACK not to be repeated")
+	}
+	if want := before + commandSize([]string{"REPLCONF", "GETACK", "*"}); registry.replication.offset != want {
+		t.Errorf("Expected offset %d, got %d", want, registry.replication.offset)
+	}
+
+	// 테스트 케이스 3: 레플리카의 ACK 오프셋 기록
+	if _, err := registry.ExecuteForClient(replica, "REPLCONF", []string{"ACK", strconv.FormatInt(before, 10)}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rep.mu.Lock()
+	ackOffset, ackTime := rep.ackOffset, rep.ackTime
+	rep.mu.Unlock()
+	if ackOffset != before || ackTime.IsZero() {
+		t.Errorf("Expected ack offset %d, got %d (at %v)", before, ackOffset, ackTime)
+	}
+
+	// 테스트 케이스 4: 숫자가 아닌 오프셋
+	if _, err := registry.ExecuteForClient(replica, "REPLCONF", []string{"ACK", "abc"}); err == nil {
+		t.Error("Expected error for invalid offset")
+	}
+}
+
+// TestInfoReplication은 INFO replication 섹션이 역할과 레플리카 목록을 보고하는지 테스트합니다.
+func TestInfoReplication(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetRDBFile(t.TempDir(), "dump.rdb")
+	replica, _ := newTestClient(registry)
+	replica.SetAddr("10.0.0.2:51234")
+	client, _ := newTestClient(registry)
+
+	info := func() string {
+		result, _ := registry.ExecuteForClient(client, "INFO", []string{"replication"})
+		s, _ := result.(protocol.BulkString)
+		return string(s)
+	}
+
+	// 테스트 케이스 1: 레플리카가 없는 마스터
+	for _, line := range []string{"# Replication\r\n", "role:master\r\n", "connected_slaves:0\r\n", "master_replid:" + registry.replication.replID + "\r\n", "master_repl_offset:0\r\n"} {
+		if s := info(); !strings.Contains(s, line) {
+			t.Errorf("Expected %q in INFO output, got %q", line, s)
+		}
+	}
+
+	// 테스트 케이스 2: 연결된 레플리카의 주소, 상태, ACK 오프셋
+	registry.ExecuteForClient(replica, "REPLCONF", []string{"listening-port", "6380"})
+	registry.ExecuteForClient(replica, "PSYNC", []string{"?", "-1"})
+	waitFor(t, "full sync", func() bool {
+		return strings.Contains(info(), "state=online")
+	})
+	registry.ExecuteForClient(replica, "REPLCONF", []string{"ACK", "0"})
+	if s, want := info(), "slave0:ip=10.0.0.2,port=6380,state=online,offset=0,lag=0\r\n"; !strings.Contains(s, want) || !strings.Contains(s, "connected_slaves:1\r\n") {
+		t.Errorf("Expected %q in INFO output, got %q", want, s)
+	}
+
+	// 테스트 케이스 3: 레플리카는 마스터 정보와 연결 상태를 보고
+	other := NewCommandRegistry(store.NewStore())
+	other.replication.masterHost = "127.0.0.1"
+	other.replication.masterPort = 6379
+	otherClient, _ := newTestClient(other)
+	result, _ := other.ExecuteForClient(otherClient, "INFO", []string{"replication"})
+	for _, line := range []string{"role:slave\r\n", "master_host:127.0.0.1\r\n", "master_port:6379\r\n", "master_link_status:down\r\n"} {
+		if !strings.Contains(string(result.(protocol.BulkString)), line) {
+			t.Errorf("Expected %q in INFO output, got %q", line, result)
+		}
+	}
+}
+
+// TestReplicaReadOnly는 읽기 전용 레플리카가 일반 클라이언트의 쓰기 명령어만 거부하는지 테스트합니다.
+func TestReplicaReadOnly(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.replication.masterHost = "127.0.0.1"
+	registry.replication.masterPort = 6379
+	client, _ := newTestClient(registry)
+	master, _ := newTestClient(registry)
+	master.master = true
+
+	// 테스트 케이스 1: 일반 클라이언트의 쓰기 명령어는 거부
+	if _, err := registry.ExecuteForClient(client, "SET", []string{"a", "1"}); err == nil || err.Error() != "-READONLY You can't write against a read only replica." {
+		t.Errorf("Expected READONLY error, got %v", err)
+	}
+
+	// 테스트 케이스 2: 마스터 연결의 쓰기 명령어와 읽기 명령어는 실행
+	if _, err := registry.ExecuteForClient(master, "SET", []string{"a", "1"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result, err := registry.ExecuteForClient(client, "GET", []string{"a"}); err != nil || !isBulk(result, "1") {
+		t.Errorf("Expected '1', got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 3: 트랜잭션 안의 쓰기 명령어는 대기열에 넣지 않고 EXEC를 실패시킴
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	if _, err := registry.ExecuteForClient(client, "RPUSH", []string{"list", "x"}); err == nil {
+		t.Error("Expected READONLY error inside MULTI")
+	}
+	if _, err := registry.ExecuteForClient(client, "EXEC", []string{}); err == nil {
+		t.Error("Expected EXECABORT")
+	}
+
+	// 테스트 케이스 4: 스크립트 안의 쓰기 명령어도 거부
+	if _, err := registry.ExecuteForClient(client, "EVAL", []string{"return redis.call('SET', 'b', '1')", "0"}); err == nil || !strings.Contains(err.Error(), "READONLY") {
+		t.Errorf("Expected READONLY error from script, got %v", err)
+	}
+
+	// 테스트 케이스 5: replica-read-only no이면 쓰기 허용
+	registry.SetReplicaReadOnly(false)
+	if _, err := registry.ExecuteForClient(client, "SET", []string{"a", "2"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+// startFakeMaster는 핸드셰이크에 응답하고 key=value만 있는 데이터셋을 보내는 가짜 마스터를 시작합니다.
+// 반환값은 마스터의 포트와, 동기화를 마친 레플리카에 명령어를 보내는 채널입니다.
+func startFakeMaster(t *testing.T, key, value string) (int, chan<- []string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := make(chan []string)
+	t.Cleanup(func() {
+		ln.Close()
+		close(stream)
+	})
+
+	dataset := store.NewStore()
+	dataset.SET(key, []byte(value), nil)
+	var snapshot bytes.Buffer
+	rdb.Write(&snapshot, dataset.Snapshot())
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				parser := protocol.NewParser(bufio.NewReader(conn))
+				for _, reply := range []string{"+PONG\r\n", "+OK\r\n", "+OK\r\n", "+FULLRESYNC 8371b4fb1155b71f4a04d3e1bc3e18c4a990aeeb 0\r\n"} {
+					if _, err := parser.Parse(); err != nil {
+						return
+					}
+					conn.Write([]byte(reply))
+				}
+				conn.Write([]byte("$" + strconv.Itoa(snapshot.Len()) + "\r\n"))
+				conn.Write(snapshot.Bytes())
+				w := protocol.NewWriter(conn)
+				for args := range stream {
+					if w.WriteArray(args) != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port, stream
+}
+
+// TestReplicaOfHandler는 REPLICAOF로 마스터를 바꾸고 NO ONE으로 승격하는지 테스트합니다.
+func TestReplicaOfHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	registry.ExecuteForClient(client, "SET", []string{"local", "1"})
+
+	get := func(key string) protocol.Reply {
+		result, _ := registry.ExecuteForClient(client, "GET", []string{key})
+		return result
+	}
+
+	// 테스트 케이스 1: 마스터의 데이터셋으로 교체
+	first, _ := startFakeMaster(t, "first", "1")
+	if result, err := registry.ExecuteForClient(client, "REPLICAOF", []string{"127.0.0.1", strconv.Itoa(first)}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	waitFor(t, "first master's dataset", func() bool { return isBulk(get("first"), "1") })
+	if get("local") != nil {
+		t.Error("Expected old dataset to be discarded")
+	}
+
+	// 테스트 케이스 2: 같은 마스터를 다시 지정
+	result, _ := registry.ExecuteForClient(client, "SLAVEOF", []string{"127.0.0.1", strconv.Itoa(first)})
+	if !isStatus(result, "OK Already connected to specified master") {
+		t.Errorf("Expected already connected reply, got %v", result)
+	}
+
+	// 테스트 케이스 3: 다른 마스터로 변경
+	second, _ := startFakeMaster(t, "second", "2")
+	registry.ExecuteForClient(client, "REPLICAOF", []string{"127.0.0.1", strconv.Itoa(second)})
+	waitFor(t, "second master's dataset", func() bool { return isBulk(get("second"), "2") })
+	if get("first") != nil {
+		t.Error("Expected first master's dataset to be discarded")
+	}
+
+	// 테스트 케이스 4: NO ONE → 데이터셋을 유지한 채 새 복제 ID의 마스터로 승격
+	registry.replication.mu.Lock()
+	oldID := registry.replication.replID
+	registry.replication.mu.Unlock()
+	if result, err := registry.ExecuteForClient(client, "REPLICAOF", []string{"no", "one"}); err != nil || !isStatus(result, "OK") {
+		t.Fatalf("Expected OK, got %v, %v", result, err)
+	}
+	if !isBulk(get("second"), "2") {
+		t.Error("Expected dataset to be kept after promotion")
+	}
+	if _, err := registry.ExecuteForClient(client, "SET", []string{"a", "1"}); err != nil {
+		t.Errorf("Expected writes to be accepted after promotion, got %v", err)
+	}
+	registry.replication.mu.Lock()
+	newID, masterHost := registry.replication.replID, registry.replication.masterHost
+	registry.replication.mu.Unlock()
+	if masterHost != "" || newID == oldID {
+		t.Errorf("Expected promotion with a new replication ID, got master %q, ID %q", masterHost, newID)
+	}
+
+	// 테스트 케이스 5: 잘못된 포트
+	if _, err := registry.ExecuteForClient(client, "REPLICAOF", []string{"127.0.0.1", "abc"}); err == nil {
+		t.Error("Expected error for invalid port")
+	}
+}
+
+// TestReplicaExpiration은 레플리카가 만료된 키를 없는 것으로 보고하되 마스터의 DEL을 기다리는지 테스트합니다.
+func TestReplicaExpiration(t *testing.T) {
+	s := store.NewStore()
+	registry := NewCommandRegistry(s)
+	client, _ := newTestClient(registry)
+	recorded := recordPropagation(registry)
+	expired := 0
+	s.OnKeyExpired(func(string) { expired++ })
+
+	port, _ := startFakeMaster(t, "foo", "bar")
+	registry.ReplicaOf("127.0.0.1", port)
+	waitFor(t, "master's dataset", func() bool {
+		result, _ := registry.ExecuteForClient(client, "GET", []string{"foo"})
+		return isBulk(result, "bar")
+	})
+
+	// 테스트 케이스 1: 만료된 키는 없는 것으로 보고하지만 삭제하지 않음
+	s.SETPXAT("key", []byte("value"), time.Now().Add(-time.Second))
+	if result, _ := registry.ExecuteForClient(client, "GET", []string{"key"}); result != nil {
+		t.Errorf("Expected nil, got %v", result)
+	}
+	if expired != 0 || len(*recorded) != 0 {
+		t.Errorf("Expected no local expiration, got %d expirations, %q propagated", expired, *recorded)
+	}
+
+	// 테스트 케이스 2: 승격한 뒤에는 직접 삭제하고 DEL을 전달
+	registry.ExecuteForClient(client, "REPLICAOF", []string{"NO", "ONE"})
+	registry.ExecuteForClient(client, "GET", []string{"key"})
+	expected := [][][]string{{{"DEL", "key"}}}
+	if expired != 1 || !reflect.DeepEqual(*recorded, expected) {
+		t.Errorf("Expected %q after promotion, got %d expirations, %q", expected, expired, *recorded)
+	}
+}
+
+// TestChainedReplication은 레플리카가 마스터의 복제 스트림을 하위 레플리카에 그대로 전달하는지 테스트합니다.
+func TestChainedReplication(t *testing.T) {
+	port, masterStream := startFakeMaster(t, "foo", "bar")
+	registry := NewCommandRegistry(store.NewStore())
+	registry.SetRDBFile(t.TempDir(), "dump.rdb")
+	client, _ := newTestClient(registry)
+	registry.ReplicaOf("127.0.0.1", port)
+	waitFor(t, "master's dataset", func() bool {
+		result, _ := registry.ExecuteForClient(client, "GET", []string{"foo"})
+		return isBulk(result, "bar")
+	})
+
+	// 테스트 케이스 1: 하위 레플리카는 마스터의 복제 ID와 오프셋으로 전체 동기화
+	sub, buf := newTestClient(registry)
+	registry.ExecuteForClient(sub, "PSYNC", []string{"?", "-1"})
+	waitFor(t, "full sync", func() bool {
+		sub.replica.mu.Lock()
+		defer sub.replica.mu.Unlock()
+		return sub.replica.online
+	})
+	if reply, _ := readReplicaStream(sub, buf); reply != "FULLRESYNC 8371b4fb1155b71f4a04d3e1bc3e18c4a990aeeb 0" {
+		t.Errorf("Expected master's replication ID and offset, got %q", reply)
+	}
+
+	// 테스트 케이스 2: 마스터의 명령어를 실행하고 순서대로 전달 (GETACK 포함)
+	expected := [][]string{{"SET", "a", "1"}, {"REPLCONF", "GETACK", "*"}, {"RPUSH", "list", "x"}}
+	for _, args := range expected {
+		masterStream <- args
+	}
+	var stream [][]string
+	waitFor(t, "relayed stream", func() bool {
+		_, stream = readReplicaStream(sub, buf)
+		return len(stream) == len(expected)
+	})
+	if !reflect.DeepEqual(stream, expected) {
+		t.Errorf("Expected %q, got %q", expected, stream)
+	}
+	if result, _ := registry.ExecuteForClient(client, "LLEN", []string{"list"}); result != protocol.Integer(1) {
+		t.Errorf("Expected relayed commands to be applied, got LLEN %v", result)
+	}
+	registry.replication.mu.Lock()
+	offset := registry.replication.offset
+	registry.replication.mu.Unlock()
+	if want := commandSize(expected[0]) + commandSize(expected[1]) + commandSize(expected[2]); offset != want {
+		t.Errorf("Expected offset %d, got %d", want, offset)
+	}
+
+	// 테스트 케이스 3: 새 마스터와 전체 동기화하면 하위 레플리카의 등록을 해제
+	other, _ := startFakeMaster(t, "other", "1")
+	registry.ReplicaOf("127.0.0.1", other)
+	waitFor(t, "sub-replica disconnect", func() bool {
+		registry.replication.mu.Lock()
+		defer registry.replication.mu.Unlock()
+		return len(registry.replication.replicas) == 0
+	})
+	registry.ReplicaOfNoOne()
+}
+
+// TestDisklessSync는 전체 동기화 방식마다 같은 RDB를 레플리카에 보내는지 테스트합니다.
+func TestDisklessSync(t *testing.T) {
+	tests := []struct {
+		name     string
+		diskless bool
+		capa     []string
+		header   string // 페이로드 헤더의 접두사
+	}{
+		{name: "diskless with EOF mark", diskless: true, capa: []string{"capa", "eof", "capa", "psync2"}, header: "$EOF:"},
+		{name: "diskless without EOF capability", diskless: true, capa: []string{"capa", "psync2"}, header: "$"},
+		{name: "disk-backed", diskless: false, capa: []string{"capa", "eof"}, header: "$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			registry := NewCommandRegistry(store.NewStore())
+			registry.SetRDBFile(dir, "dump.rdb")
+			registry.SetReplDisklessSync(tt.diskless)
+			client, buf := newTestClient(registry)
+			registry.ExecuteForClient(client, "RPUSH", []string{"list", "a", "b"})
+
+			registry.ExecuteForClient(client, "REPLCONF", tt.capa)
+			registry.ExecuteForClient(client, "PSYNC", []string{"?", "-1"})
+			waitFor(t, "full sync", func() bool {
+				client.replica.mu.Lock()
+				defer client.replica.mu.Unlock()
+				return client.replica.online
+			})
+
+			var output []byte
+			client.WithWriter(func(*protocol.Writer) {
+				output = bytes.Clone(buf.Bytes())
+			})
+			reader := bufio.NewReader(bytes.NewReader(output))
+			readStatusReply(reader)
+			if header, _ := reader.Peek(len(tt.header) + 1); !bytes.HasPrefix(header, []byte(tt.header)) || (tt.header == "$" && header[1] == 'E') {
+				t.Errorf("Expected payload header %q, got %q", tt.header, header)
+			}
+			payload, err := readRDBPayload(reader)
+			if err != nil {
+				t.Fatalf("Reading RDB payload failed: %v", err)
+			}
+			if reader.Buffered() != 0 {
+				t.Errorf("Expected nothing after the RDB payload, got %d bytes", reader.Buffered())
+			}
+			replicaStore := store.NewStore()
+			if err := rdb.Load(bytes.NewReader(payload), replicaStore); err != nil {
+				t.Fatalf("Loading RDB payload failed: %v", err)
+			}
+			if n := replicaStore.LLEN("list"); n != 2 {
+				t.Errorf("Expected list of 2, got %d", n)
+			}
+
+			// 임시 파일을 남기지 않음
+			if files, _ := os.ReadDir(dir); len(files) != 0 {
+				t.Errorf("Expected no files in %s, got %d", dir, len(files))
+			}
+		})
+	}
+}
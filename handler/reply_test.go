@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestErrorReply는 핸들러의 에러가 protocol.NewError로 올바른 에러 응답이 되는지 테스트합니다.
+func TestErrorReply(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		// 테스트 케이스 1: 에러 코드를 포함한 핸들러 에러
+		{"wrong type", &WrongTypeError{}, "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		{"unknown command", &UnknownCommandError{Command: "FOO"}, "-ERR unknown command 'FOO'\r\n"},
+		{"moved", &MovedError{Slot: 12182, Addr: "127.0.0.1:7001"}, "-MOVED 12182 127.0.0.1:7001\r\n"},
+		// 테스트 케이스 2: 스크립트가 반환한 에러
+		{"script error", &ScriptError{Message: "MYERR custom"}, "-MYERR custom\r\n"},
+		// 테스트 케이스 3: 다른 서버에서 받은 에러 응답은 그대로
+		{"error reply", &protocol.ErrorReply{Message: "ERR bad"}, "-ERR bad\r\n"},
+		// 테스트 케이스 4: 에러 코드가 없는 일반 에러는 ERR 코드
+		{"plain error", errors.New("boom"), "-ERR boom\r\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		protocol.NewWriter(&buf).WriteReply(protocol.NewError(tt.err))
+		if buf.String() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, buf.String())
+		}
+	}
+}
+
+// TestReplyTypes는 핸들러가 상태 응답과 사용자 데이터를 서로 다른 응답 타입으로 반환하는지 테스트합니다.
+func TestReplyTypes(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+
+	// 테스트 케이스 1: SET의 OK는 Simple String
+	result, err := registry.Execute("SET", []string{"key", "OK"})
+	if err != nil || result != okReply {
+		t.Fatalf("Expected +OK status, got %#v (err %v)", result, err)
+	}
+
+	// 테스트 케이스 2: 값이 "OK"여도 GET의 결과는 Bulk String
+	result, err = registry.Execute("GET", []string{"key"})
+	if err != nil || !isBulk(result, "OK") {
+		t.Fatalf("Expected bulk string OK, got %#v (err %v)", result, err)
+	}
+
+	// 테스트 케이스 3: EXEC 결과 안의 상태 응답, 데이터, 에러가 각자의 형식으로 작성됨
+	var buf bytes.Buffer
+	writer := protocol.NewWriter(&buf)
+	writer.WriteReply(protocol.Array{okReply, protocol.BulkString("OK"), protocol.NewError(&WrongTypeError{})})
+	expected := "*3\r\n+OK\r\n$2\r\nOK\r\n-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+// isStatus는 결과가 message를 담은 상태 응답(Simple String)인지 확인합니다.
+func isStatus(result protocol.Reply, message string) bool {
+	return result == protocol.SimpleString(message)
+}
+
+// isBulk는 결과가 value를 담은 Bulk String인지 확인합니다 (GET 등은 저장소의 값을 BulkBytes로 반환).
+func isBulk(result protocol.Reply, value string) bool {
+	switch v := result.(type) {
+	case protocol.BulkString:
+		return string(v) == value
+	case protocol.BulkBytes:
+		return string(v) == value
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// saveCronInterval은 자동 저장 조건을 검사하는 주기입니다 (Redis의 serverCron, hz 10과 동일).
+const saveCronInterval = 100 * time.Millisecond
+
+// bgsaveRetryDelay는 자동 BGSAVE가 실패한 뒤 다시 시도하기까지 기다리는 시간입니다
+// (Redis의 CONFIG_BGSAVE_RETRY_DELAY와 동일).
+const bgsaveRetryDelay = 5 * time.Second
+
+// SaveRule은 자동 BGSAVE 조건 하나입니다 (redis.conf의 save <seconds> <changes>).
+// 마지막 저장 후 Seconds초 이상 지났고 Changes개 이상의 키가 변경되었으면 BGSAVE를 시작합니다.
+type SaveRule struct {
+	Seconds int
+	Changes int
+}
+
+// ParseSaveRules는 "3600 1 300 100"처럼 초와 변경 횟수를 번갈아 나열한 설정을 파싱합니다.
+// 빈 문자열은 자동 저장을 끄는 설정으로, 빈 목록을 반환합니다.
+//
+// 에러 케이스:
+//   - 값의 개수가 홀수인 경우
+//   - 초가 1 미만이거나 변경 횟수가 음수, 또는 숫자가 아닌 경우
+func ParseSaveRules(spec string) ([]SaveRule, error) {
+	fields := strings.Fields(spec)
+	if len(fields)%2 != 0 {
+		return nil, fmt.Errorf("invalid save parameters %q: expected <seconds> <changes> pairs", spec)
+	}
+
+	rules := make([]SaveRule, 0, len(fields)/2)
+	for i := 0; i < len(fields); i += 2 {
+		seconds, err1 := strconv.Atoi(fields[i])
+		changes, err2 := strconv.Atoi(fields[i+1])
+		if err1 != nil || err2 != nil || seconds < 1 || changes < 0 {
+			return nil, fmt.Errorf("invalid save parameters %q", fields[i]+" "+fields[i+1])
+		}
+		rules = append(rules, SaveRule{Seconds: seconds, Changes: changes})
+	}
+	return rules, nil
+}
+
+// SetSaveRules는 자동 BGSAVE 조건을 설정합니다.
+// 조건이 하나라도 있으면 주기적으로 조건을 검사하는 고루틴이 시작됩니다.
+//
+// 매개변수:
+//   - rules: 자동 저장 조건 (ParseSaveRules의 결과, 비어 있으면 자동 저장 안 함)
+func (r *CommandRegistry) SetSaveRules(rules []SaveRule) {
+	r.persistence.mu.Lock()
+	r.persistence.saveRules = append([]SaveRule(nil), rules...)
+	r.persistence.mu.Unlock()
+
+	if len(rules) > 0 {
+		r.persistence.cronOnce.Do(func() { go r.saveCron() })
+	}
+}
+
+// DatasetLoaded는 서버 시작 시 RDB나 AOF로 데이터셋을 불러온 뒤 호출합니다.
+// 불러오면서 생긴 변경은 다시 저장할 필요가 없으므로 변경 횟수를 0으로 되돌립니다.
+func (r *CommandRegistry) DatasetLoaded() {
+	atomic.StoreInt64(&r.persistence.dirty, 0)
+}
+
+// saveCron은 서버가 종료될 때까지 saveCronInterval마다 자동 저장 조건을 검사합니다.
+func (r *CommandRegistry) saveCron() {
+	ticker := time.NewTicker(saveCronInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.checkSaveRules(now)
+		}
+	}
+}
+
+// checkSaveRules는 만족하는 자동 저장 조건이 있으면 BGSAVE를 시작합니다.
+// 직전 백그라운드 저장이 실패했다면 bgsaveRetryDelay가 지날 때까지 다시 시도하지 않습니다.
+// BGSAVE 명령어와 같이 실행 잠금을 배타적으로 잡고 스냅샷을 만듭니다.
+//
+// 반환값:
+//   - bool: BGSAVE를 시작했으면 true
+func (r *CommandRegistry) checkSaveRules(now time.Time) bool {
+	p := r.persistence
+	p.mu.Lock()
+	if p.bgsaveInProgress || (p.lastBgsaveErr != nil && now.Sub(p.lastBgsaveTry) < bgsaveRetryDelay) {
+		p.mu.Unlock()
+		return false
+	}
+	elapsed := now.Sub(p.lastSave)
+	dirty := atomic.LoadInt64(&p.dirty)
+	triggered := false
+	for _, rule := range p.saveRules {
+		if dirty >= int64(rule.Changes) && elapsed >= time.Duration(rule.Seconds)*time.Second {
+			triggered = true
+			break
+		}
+	}
+	p.mu.Unlock()
+
+	if !triggered {
+		return false
+	}
+
+	r.execMu.Lock()
+	defer r.execMu.Unlock()
+	return p.bgsave(r.latency.beginSnapshot(r.store)) == nil
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// newBit은 스크립트에서 사용할 LuaBitOp 호환 bit 라이브러리를 만듭니다.
+// 모든 연산은 32비트 부호 있는 정수로 수행됩니다.
+func newBit(L *lua.LState) *lua.LTable {
+	funcs := map[string]lua.LGFunction{
+		"tobit": func(L *lua.LState) int {
+			L.Push(lua.LNumber(checkBit(L, 1)))
+			return 1
+		},
+		"bnot": func(L *lua.LState) int {
+			L.Push(lua.LNumber(^checkBit(L, 1)))
+			return 1
+		},
+		"bswap": func(L *lua.LState) int {
+			u := uint32(checkBit(L, 1))
+			L.Push(lua.LNumber(int32(u>>24 | (u>>8)&0xff00 | (u<<8)&0xff0000 | u<<24)))
+			return 1
+		},
+		"tohex": bitTohex,
+	}
+	fold := func(name string, op func(a, b int32) int32) {
+		funcs[name] = func(L *lua.LState) int {
+			result := checkBit(L, 1)
+			for i := 2; i <= L.GetTop(); i++ {
+				result = op(result, checkBit(L, i))
+			}
+			L.Push(lua.LNumber(result))
+			return 1
+		}
+	}
+	fold("band", func(a, b int32) int32 { return a & b })
+	fold("bor", func(a, b int32) int32 { return a | b })
+	fold("bxor", func(a, b int32) int32 { return a ^ b })
+
+	shift := func(name string, op func(x int32, n uint) int32) {
+		funcs[name] = func(L *lua.LState) int {
+			x := checkBit(L, 1)
+			n := checkBit(L, 2)
+			L.Push(lua.LNumber(op(x, uint(n&31))))
+			return 1
+		}
+	}
+	shift("lshift", func(x int32, n uint) int32 { return int32(uint32(x) << n) })
+	shift("rshift", func(x int32, n uint) int32 { return int32(uint32(x) >> n) })
+	shift("arshift", func(x int32, n uint) int32 { return x >> n })
+	shift("rol", func(x int32, n uint) int32 { return int32(uint32(x)<<n | uint32(x)>>(32-n)) })
+	shift("ror", func(x int32, n uint) int32 { return int32(uint32(x)>>n | uint32(x)<<(32-n)) })
+
+	lib := L.NewTable()
+	L.SetFuncs(lib, funcs)
+	return lib
+}
+
+// checkBit는 i번째 인자를 32비트 정수로 정규화합니다 (LuaBitOp의 barg와 같은 방식).
+func checkBit(L *lua.LState, i int) int32 {
+	n := float64(L.CheckNumber(i))
+	// 2^52 + 2^51을 더해 가수부의 하위 32비트를 얻는 것과 같은 결과 (반올림 후 모듈로 2^32)
+	return int32(uint32(int64(math.RoundToEven(n))))
+}
+
+// bitTohex는 bit.tohex(x [, n])입니다. n이 음수면 대문자를 사용합니다.
+func bitTohex(L *lua.LState) int {
+	x := checkBit(L, 1)
+	n := 8
+	if L.GetTop() >= 2 && L.Get(2) != lua.LNil {
+		n = int(checkBit(L, 2))
+	}
+	upper := n < 0
+	if upper {
+		n = -n
+	}
+	if n > 8 {
+		n = 8
+	}
+	s := fmt.Sprintf("%08x", uint32(x))[8-n:]
+	if upper {
+		s = strings.ToUpper(s)
+	}
+	L.Push(lua.LString(s))
+	return 1
+}
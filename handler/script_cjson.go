@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// maxJSONDepth는 cjson.encode/decode가 허용하는 최대 중첩 깊이입니다 (lua-cjson의 기본값).
+const maxJSONDepth = 1000
+
+// newCJSON은 스크립트에서 사용할 lua-cjson 호환 cjson 라이브러리(encode, decode, null)를 만듭니다.
+// cjson.null은 JSON의 null을 테이블 안에 담기 위한 값으로, LState마다 하나씩 만듭니다.
+func newCJSON(L *lua.LState) *lua.LTable {
+	null := L.NewUserData()
+	lib := L.NewTable()
+	L.SetFuncs(lib, map[string]lua.LGFunction{
+		"encode": func(L *lua.LState) int {
+			if L.GetTop() != 1 {
+				L.RaiseError("bad argument #1 to 'encode' (expected 1 argument)")
+			}
+			var sb strings.Builder
+			if err := encodeJSON(&sb, L.Get(1), null, 0); err != nil {
+				L.RaiseError("%s", err.Error())
+			}
+			L.Push(lua.LString(sb.String()))
+			return 1
+		},
+		"decode": func(L *lua.LState) int {
+			if L.GetTop() != 1 {
+				L.RaiseError("bad argument #1 to 'decode' (expected 1 argument)")
+			}
+			v, err := decodeJSON(L, L.CheckString(1), null)
+			if err != nil {
+				L.RaiseError("%s", err.Error())
+			}
+			L.Push(v)
+			return 1
+		},
+	})
+	lib.RawSetString("null", null)
+	return lib
+}
+
+// encodeJSON은 Lua 값을 JSON으로 씁니다.
+//
+// 변환 규칙 (lua-cjson과 동일):
+//   - 키가 1..n 정수뿐인 테이블: 배열 (빈 테이블은 객체 {})
+//   - 그 외 테이블: 객체 (키는 문자열 또는 숫자, 넣은 순서대로)
+//   - nil, cjson.null: null
+//   - 숫자: 유효 숫자 14자리 (%.14g)
+func encodeJSON(sb *strings.Builder, v lua.LValue, null *lua.LUserData, depth int) error {
+	switch v := v.(type) {
+	case *lua.LNilType:
+		sb.WriteString("null")
+	case *lua.LUserData:
+		if v != null {
+			return errors.New("Cannot serialise userdata: type not supported")
+		}
+		sb.WriteString("null")
+	case lua.LBool:
+		sb.WriteString(strconv.FormatBool(bool(v)))
+	case lua.LNumber:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return errors.New("Cannot serialise number: must not be NaN or Inf")
+		}
+		sb.WriteString(formatJSONNumber(v))
+	case lua.LString:
+		writeJSONString(sb, string(v))
+	case *lua.LTable:
+		depth++
+		if depth > maxJSONDepth {
+			return errors.New("Cannot serialise, excessive nesting (" + strconv.Itoa(depth) + ")")
+		}
+		n, err := jsonArrayLength(v)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			sb.WriteByte('[')
+			for i := 1; i <= n; i++ {
+				if i > 1 {
+					sb.WriteByte(',')
+				}
+				if err := encodeJSON(sb, v.RawGetInt(i), null, depth); err != nil {
+					return err
+				}
+			}
+			sb.WriteByte(']')
+			return nil
+		}
+		return encodeJSONObject(sb, v, null, depth)
+	default:
+		return errors.New("Cannot serialise " + v.Type().String() + ": type not supported")
+	}
+	return nil
+}
+
+// formatJSONNumber는 숫자를 lua-cjson과 같은 표기(%.14g)로 변환합니다.
+func formatJSONNumber(n lua.LNumber) string {
+	return strconv.FormatFloat(float64(n), 'g', 14, 64)
+}
+
+// jsonArrayLength는 테이블이 배열이면 길이(가장 큰 키)를, 객체이면 0을 반환합니다.
+// 원소보다 빈 자리가 훨씬 많은 배열은 에러입니다.
+func jsonArrayLength(t *lua.LTable) (int, error) {
+	max, items := 0, 0
+	isArray := true
+	t.ForEach(func(key, value lua.LValue) {
+		n, ok := key.(lua.LNumber)
+		if !ok || n < 1 || n != lua.LNumber(int(n)) {
+			isArray = false
+			return
+		}
+		if int(n) > max {
+			max = int(n)
+		}
+		items++
+	})
+	if !isArray {
+		return 0, nil
+	}
+	if max > 10 && max > items*2 {
+		return 0, errors.New("Cannot serialise table: excessively sparse array")
+	}
+	return max, nil
+}
+
+// encodeJSONObject는 테이블을 JSON 객체로 씁니다. 키는 테이블에 넣은 순서대로 씁니다 (pairs와 같은 순서).
+func encodeJSONObject(sb *strings.Builder, t *lua.LTable, null *lua.LUserData, depth int) error {
+	sb.WriteByte('{')
+	first := true
+	for key, value := t.Next(lua.LNil); key != lua.LNil; key, value = t.Next(key) {
+		var name string
+		switch k := key.(type) {
+		case lua.LString:
+			name = string(k)
+		case lua.LNumber:
+			name = formatJSONNumber(k)
+		default:
+			return errors.New("Cannot serialise " + key.Type().String() + ": table key must be a number or string")
+		}
+		if !first {
+			sb.WriteByte(',')
+		}
+		first = false
+		writeJSONString(sb, name)
+		sb.WriteByte(':')
+		if err := encodeJSON(sb, value, null, depth); err != nil {
+			return err
+		}
+	}
+	sb.WriteByte('}')
+	return nil
+}
+
+// writeJSONString은 문자열을 JSON 문자열 리터럴로 씁니다 (lua-cjson처럼 '/'도 이스케이프).
+func writeJSONString(sb *strings.Builder, s string) {
+	const hex = "0123456789abcdef"
+	sb.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '/':
+			sb.WriteString(`\/`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if c < 0x20 || c == 0x7f {
+				sb.WriteString(`\u00`)
+				sb.WriteByte(hex[c>>4])
+				sb.WriteByte(hex[c&0xf])
+			} else {
+				sb.WriteByte(c)
+			}
+		}
+	}
+	sb.WriteByte('"')
+}
+
+// decodeJSON은 JSON 문자열을 Lua 값으로 변환합니다.
+// 객체는 키가 나온 순서대로 테이블에 넣고, null은 cjson.null이 됩니다.
+// 값 하나 뒤에 다른 내용이 있으면 에러입니다.
+func decodeJSON(L *lua.LState, s string, null *lua.LUserData) (lua.LValue, error) {
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+	v, err := decodeJSONValue(L, d, null, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, errors.New("Expected the end but found invalid token at character " + strconv.FormatInt(d.InputOffset()+1, 10))
+	}
+	return v, nil
+}
+
+// decodeJSONValue는 d에서 JSON 값 하나를 읽어 Lua 값으로 변환합니다.
+func decodeJSONValue(L *lua.LState, d *json.Decoder, null *lua.LUserData, depth int) (lua.LValue, error) {
+	token, err := d.Token()
+	if err != nil {
+		return nil, jsonDecodeError(d, err)
+	}
+	switch token := token.(type) {
+	case json.Delim:
+		depth++
+		if depth > maxJSONDepth {
+			return nil, errors.New("Found too many nested data structures (" + strconv.Itoa(depth) + ") at character " + strconv.FormatInt(d.InputOffset(), 10))
+		}
+		t := L.NewTable()
+		for d.More() {
+			var key lua.LValue
+			if token == '{' {
+				name, err := d.Token()
+				if err != nil {
+					return nil, jsonDecodeError(d, err)
+				}
+				key = lua.LString(name.(string))
+			}
+			value, err := decodeJSONValue(L, d, null, depth)
+			if err != nil {
+				return nil, err
+			}
+			if key == nil {
+				t.Append(value)
+			} else {
+				t.RawSet(key, value)
+			}
+		}
+		if _, err := d.Token(); err != nil {
+			return nil, jsonDecodeError(d, err)
+		}
+		return t, nil
+	case json.Number:
+		n, err := token.Float64()
+		if err != nil {
+			return nil, jsonDecodeError(d, err)
+		}
+		return lua.LNumber(n), nil
+	case string:
+		return lua.LString(token), nil
+	case bool:
+		return lua.LBool(token), nil
+	case nil:
+		return null, nil
+	}
+	return nil, jsonDecodeError(d, nil)
+}
+
+// jsonDecodeError는 lua-cjson과 같은 형식의 디코딩 에러를 만듭니다.
+//
+// 예시:
+//
+//	Expected value but found invalid token at character 1
+func jsonDecodeError(d *json.Decoder, err error) error {
+	found := "invalid token"
+	if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+		found = "T_END"
+	}
+	return errors.New("Expected value but found " + found + " at character " + strconv.FormatInt(d.InputOffset()+1, 10))
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// EvalHandler는 EVAL 명령어를 처리하는 핸들러입니다.
+//
+// Redis EVAL 명령어 사양:
+//   - EVAL script numkeys [key ...] [arg ...]
+//   - Lua 스크립트를 실행하고, 스크립트의 반환값을 응답으로 변환해 반환
+//   - 키 이름은 KEYS 테이블로, 나머지 인자는 ARGV 테이블로 전달
+//   - 스크립트 안에서 redis.call로 명령어를 실행할 수 있음
+//   - 실행 중에는 다른 클라이언트의 명령어가 끼어들지 않음 (원자적 실행)
+//   - 실행한 스크립트는 SHA1 다이제스트로 캐시되어 EVALSHA로 다시 실행 가능
+//
+// 예시:
+//
+//	클라이언트: EVAL "return redis.call('SET', KEYS[1], ARGV[1])" 1 key value
+//	서버: +OK\r\n
+type EvalHandler struct {
+	scripts *scriptEngine
+}
+
+// Execute는 연결 정보 없이 EVAL 명령어를 실행합니다.
+func (h *EvalHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.ExecuteWithClient(nil, args, store)
+}
+
+// ExecuteWithClient는 EVAL 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.Reply: 스크립트의 반환값을 변환한 응답
+//   - error: 인자 오류, 컴파일 오류, 스크립트 실행 중 에러
+func (h *EvalHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 2 {
+		return nil, &WrongNumberOfArgumentsError{Command: "eval"}
+	}
+	keys, argv, err := parseScriptKeys(args[1], args[2:])
+	if err != nil {
+		return nil, err
+	}
+
+	sha, fn, err := h.scripts.load(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return h.scripts.run(client, sha, fn, keys, argv)
+}
+
+// EvalShaHandler는 EVALSHA 명령어를 처리하는 핸들러입니다.
+//
+// Redis EVALSHA 명령어 사양:
+//   - EVALSHA sha1 numkeys [key ...] [arg ...]
+//   - EVAL로 캐시된 스크립트를 SHA1 다이제스트로 찾아 실행 (대소문자 구분 없음)
+//   - 캐시에 없으면 NOSCRIPT 에러
+//
+// 예시:
+//
+//	클라이언트: EVALSHA 1b936e3fe509bcbc9cd0664897bbe8fd0cac101b 0
+//	서버: $5\r\nhello\r\n
+type EvalShaHandler struct {
+	scripts *scriptEngine
+}
+
+// Execute는 연결 정보 없이 EVALSHA 명령어를 실행합니다.
+func (h *EvalShaHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.ExecuteWithClient(nil, args, store)
+}
+
+// ExecuteWithClient는 EVALSHA 명령어를 실행합니다.
+func (h *EvalShaHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 2 {
+		return nil, &WrongNumberOfArgumentsError{Command: "evalsha"}
+	}
+	keys, argv, err := parseScriptKeys(args[1], args[2:])
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := h.scripts.lookup(args[0])
+	if !ok {
+		return nil, &NoScriptError{}
+	}
+	return h.scripts.run(client, strings.ToLower(args[0]), fn, keys, argv)
+}
+
+// ScriptHandler는 SCRIPT 명령어를 처리하는 핸들러입니다.
+//
+// Redis SCRIPT 명령어 사양 (지원하는 서브커맨드):
+//   - SCRIPT LOAD script: 스크립트를 실행하지 않고 캐시에 넣은 뒤 SHA1 다이제스트 반환
+//   - SCRIPT EXISTS sha1 [sha1 ...]: 각 스크립트가 캐시에 있는지 (1/0) 배열로 반환
+//   - SCRIPT FLUSH [ASYNC|SYNC]: 스크립트 캐시 비우기
+//   - SCRIPT KILL: 데이터를 변경하지 않은 채 오래 실행 중인 스크립트 중단
+//   - SCRIPT HELP: 서브커맨드들의 사용법
+//
+// 예시:
+//
+//	클라이언트: SCRIPT LOAD "return 'hello'"
+//	서버: $40\r\n1b936e3fe509bcbc9cd0664897bbe8fd0cac101b\r\n
+type ScriptHandler struct {
+	scripts *scriptEngine
+}
+
+// Execute는 SCRIPT 명령어를 실행합니다.
+//
+// 반환값:
+//   - protocol.BulkString: LOAD의 SHA1 다이제스트
+//   - protocol.SimpleString: FLUSH/KILL 성공 시 OK
+//   - protocol.Array: EXISTS의 결과 (각 다이제스트마다 1 또는 0)
+//   - error: 서브커맨드가 없거나 알 수 없는 경우, 컴파일 오류, 중단할 스크립트가 없는 경우 등
+func (h *ScriptHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return h.subcommands().dispatch(nil, args, store)
+}
+
+// subcommands는 SCRIPT의 서브커맨드 목록입니다.
+func (h *ScriptHandler) subcommands() *subcommandTable {
+	return &subcommandTable{command: "SCRIPT", subs: []subcommand{
+		{"EXISTS", -3, []string{"EXISTS <sha1> [<sha1> ...]",
+			"Return information about the existence of the scripts in the script cache."}, h.exists},
+		{"FLUSH", -2, []string{"FLUSH [ASYNC|SYNC]", "Flush the Lua scripts cache. Very dangerous on replicas."}, h.flush},
+		{"KILL", 2, []string{"KILL", "Kill the currently executing Lua script."}, h.kill},
+		{"LOAD", 3, []string{"LOAD <script>", "Load a script into the scripts cache without executing it."}, h.load},
+	}}
+}
+
+// load는 SCRIPT LOAD를 실행하고 스크립트의 SHA1 다이제스트를 반환합니다.
+func (h *ScriptHandler) load(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	sha, _, err := h.scripts.load(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return protocol.BulkString(sha), nil
+}
+
+// exists는 SCRIPT EXISTS를 실행합니다. 각 다이제스트마다 캐시에 있으면 1, 없으면 0입니다.
+func (h *ScriptHandler) exists(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	results := make(protocol.Array, 0, len(args)-1)
+	for _, sha := range args[1:] {
+		if _, ok := h.scripts.lookup(sha); ok {
+			results = append(results, protocol.Integer(1))
+		} else {
+			results = append(results, protocol.Integer(0))
+		}
+	}
+	return results, nil
+}
+
+// flush는 SCRIPT FLUSH를 실행합니다.
+func (h *ScriptHandler) flush(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) > 2 {
+		return nil, &WrongNumberOfArgumentsError{Command: "script|flush"}
+	}
+	if len(args) == 2 && !strings.EqualFold(args[1], "ASYNC") && !strings.EqualFold(args[1], "SYNC") {
+		return nil, &InvalidArgumentError{Message: "SCRIPT FLUSH only support SYNC|ASYNC option"}
+	}
+	h.scripts.flush()
+	return okReply, nil
+}
+
+// kill은 SCRIPT KILL을 실행합니다.
+func (h *ScriptHandler) kill(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if err := h.scripts.kill(); err != nil {
+		return nil, err
+	}
+	return okReply, nil
+}
+
+// isScriptKill은 명령어가 SCRIPT KILL 또는 FUNCTION KILL인지 확인합니다.
+// 두 명령어는 실행 중인 스크립트를 멈춰야 하므로 BUSY 상태에서도 거부되지 않고,
+// 스크립트가 잡고 있는 실행 잠금을 기다리지 않고 실행됩니다.
+func isScriptKill(cmdUpper string, args []string) bool {
+	return (cmdUpper == "SCRIPT" || cmdUpper == "FUNCTION") && len(args) == 1 && strings.EqualFold(args[0], "KILL")
+}
+
+// parseScriptKeys는 numkeys 인자에 따라 나머지 인자를 KEYS와 ARGV로 나눕니다.
+func parseScriptKeys(numkeys string, rest []string) ([]string, []string, error) {
+	n, err := strconv.Atoi(numkeys)
+	if err != nil {
+		return nil, nil, &InvalidArgumentError{Message: "value is not an integer or out of range"}
+	}
+	if n < 0 {
+		return nil, nil, &InvalidArgumentError{Message: "Number of keys can't be negative"}
+	}
+	if n > len(rest) {
+		return nil, nil, &InvalidArgumentError{Message: "Number of keys can't be greater than number of args"}
+	}
+	return rest[:n], rest[n:], nil
+}
@@ -0,0 +1,385 @@
+package handler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestEvalHandler는 EVAL 명령어의 실행과 반환값 변환을 테스트합니다.
+func TestEvalHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	tests := []struct {
+		args     []string
+		expected protocol.Reply
+	}{
+		// 테스트 케이스 1: Lua 값 → Redis 응답 변환
+		{[]string{"return 42", "0"}, protocol.Integer(42)},
+		{[]string{"return 3.99", "0"}, protocol.Integer(3)},
+		{[]string{"return 'hello'", "0"}, protocol.BulkString("hello")},
+		{[]string{"return true", "0"}, protocol.Integer(1)},
+		{[]string{"return false", "0"}, nil},
+		{[]string{"return nil", "0"}, nil},
+		{[]string{"return {1, 'two', {3}, nil, 5}", "0"}, protocol.Array{protocol.Integer(1), protocol.BulkString("two"), protocol.Array{protocol.Integer(3)}}},
+		{[]string{"return redis.status_reply('FINE')", "0"}, protocol.SimpleString("FINE")},
+
+		// 테스트 케이스 2: KEYS와 ARGV
+		{[]string{"return {KEYS[1], KEYS[2], ARGV[1], #ARGV}", "2", "k1", "k2", "a1", "a2"}, protocol.Array{protocol.BulkString("k1"), protocol.BulkString("k2"), protocol.BulkString("a1"), protocol.Integer(2)}},
+
+		// 테스트 케이스 3: redis.call로 명령어 실행
+		{[]string{"return redis.call('SET', KEYS[1], ARGV[1])", "1", "key", "value"}, protocol.SimpleString("OK")},
+		{[]string{"return redis.call('get', KEYS[1])", "1", "key"}, protocol.BulkString("value")},
+		{[]string{"return redis.call('GET', 'missing')", "0"}, nil},
+		{[]string{"redis.call('RPUSH', 'list', 1, 2, 3); return redis.call('LRANGE', 'list', 0, -1)", "0"}, protocol.Array{protocol.BulkString("1"), protocol.BulkString("2"), protocol.BulkString("3")}},
+
+		// 테스트 케이스 4: redis.pcall은 에러를 테이블로 반환
+		{[]string{"local r = redis.pcall('PFADD', 'key', 'x'); return r.err", "0"}, protocol.BulkString("WRONGTYPE Key is not a valid HyperLogLog string value.")},
+
+		// 테스트 케이스 5: cjson과 redis.sha1hex
+		{[]string{"return cjson.encode(cjson.decode(ARGV[1]))", "0", `{"a":[1,2]}`}, protocol.BulkString(`{"a":[1,2]}`)},
+		{[]string{"return redis.sha1hex('')", "0"}, protocol.BulkString("da39a3ee5e6b4b0d3255bfef95601890afd80709")},
+	}
+
+	for _, tt := range tests {
+		result, err := registry.ExecuteForClient(client, "EVAL", tt.args)
+		if err != nil {
+			t.Errorf("EVAL %q failed: %v", tt.args[0], err)
+			continue
+		}
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("EVAL %q: expected %v, got %v", tt.args[0], tt.expected, result)
+		}
+	}
+}
+
+// TestEvalErrors는 EVAL의 에러 처리를 테스트합니다.
+func TestEvalErrors(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	registry.ExecuteForClient(client, "SET", []string{"key", "value"})
+
+	tests := []struct {
+		args     []string
+		expected string // 에러 메시지의 시작 부분
+	}{
+		// 테스트 케이스 1: numkeys 오류
+		{[]string{"return 1", "x"}, "-ERR value is not an integer or out of range"},
+		{[]string{"return 1", "-1"}, "-ERR Number of keys can't be negative"},
+		{[]string{"return 1", "2", "k1"}, "-ERR Number of keys can't be greater than number of args"},
+
+		// 테스트 케이스 2: 컴파일 오류
+		{[]string{"return +", "0"}, "-ERR Error compiling script (new function): user_script:1:"},
+
+		// 테스트 케이스 3: 실행 중 에러 (error, redis.call 실패)
+		{[]string{"error('boom')", "0"}, "-ERR user_script:1: boom script: "},
+		{[]string{"return redis.call('PFADD', 'key', 'x')", "0"}, "-WRONGTYPE Key is not a valid HyperLogLog string value. script: "},
+		{[]string{"return redis.call('NOSUCH')", "0"}, "-ERR Unknown Redis command called from script script: "},
+		{[]string{"return redis.call('GET')", "0"}, "-ERR Wrong number of args calling Redis command from script script: "},
+		{[]string{"return redis.call('MULTI')", "0"}, "-ERR This Redis command is not allowed from script script: "},
+		{[]string{"return redis.call('SET', 'k', {})", "0"}, "-ERR Lua redis lib command arguments must be strings or integers script: "},
+
+		// 테스트 케이스 4: 반환한 에러 테이블은 그대로 에러 응답
+		{[]string{"return redis.error_reply('MY custom error')", "0"}, "-MY custom error"},
+	}
+
+	for _, tt := range tests {
+		_, err := registry.ExecuteForClient(client, "EVAL", tt.args)
+		if err == nil {
+			t.Errorf("EVAL %q: expected error", tt.args[0])
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), tt.expected) {
+			t.Errorf("EVAL %q: expected error starting with %q, got %q", tt.args[0], tt.expected, err.Error())
+		}
+	}
+
+	// 테스트 케이스 5: 인자 개수 오류
+	if _, err := registry.ExecuteForClient(client, "EVAL", []string{"return 1"}); err == nil {
+		t.Error("Expected error for EVAL without numkeys")
+	}
+}
+
+// TestScriptSandbox는 스크립트에서 파일과 프로세스에 접근하는 라이브러리를 쓸 수 없는지 테스트합니다.
+func TestScriptSandbox(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	for _, name := range []string{"os", "io", "debug", "package", "require", "dofile", "loadfile", "module", "print"} {
+		result, err := registry.ExecuteForClient(client, "EVAL", []string{"return type(" + name + ")", "0"})
+		if err != nil || result != protocol.BulkString("nil") {
+			t.Errorf("Expected %s to be nil, got %v, %v", name, result, err)
+		}
+	}
+	for _, name := range []string{"string", "table", "math", "cjson", "bit", "redis"} {
+		result, err := registry.ExecuteForClient(client, "EVAL", []string{"return type(" + name + ")", "0"})
+		if err != nil || result != protocol.BulkString("table") {
+			t.Errorf("Expected %s to be a table, got %v, %v", name, result, err)
+		}
+	}
+}
+
+// TestScriptLibraries는 cjson과 bit 라이브러리를 테스트합니다.
+func TestScriptLibraries(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	tests := []struct {
+		script   string
+		expected protocol.Reply
+	}{
+		// 테스트 케이스 1: cjson.encode
+		{"return cjson.encode({1, 2, 'three'})", protocol.BulkString(`[1,2,"three"]`)},
+		{"local t = {}; t.name = 'redis'; t.version = 7; return cjson.encode(t)", protocol.BulkString(`{"name":"redis","version":7}`)},
+		{"return cjson.encode({})", protocol.BulkString(`{}`)},
+		{`return cjson.encode('quote" slash/ \n')`, protocol.BulkString(`"quote\" slash\/ \n"`)},
+		{"return cjson.encode({[1] = 'a', [3] = 'c'})", protocol.BulkString(`["a",null,"c"]`)},
+		{"return cjson.encode(0.1)", protocol.BulkString(`0.1`)},
+
+		// 테스트 케이스 2: cjson.decode (null은 cjson.null, 객체 키는 나온 순서대로)
+		{`return cjson.encode(cjson.decode('{"list":[1,"two",null]}'))`, protocol.BulkString(`{"list":[1,"two",null]}`)},
+		{`return cjson.decode('[null]')[1] == cjson.null`, protocol.Integer(1)},
+		{`local keys = ''; for k in pairs(cjson.decode('{"z":1,"a":2,"m":3}')) do keys = keys .. k end; return keys`, protocol.BulkString("zam")},
+
+		// 테스트 케이스 3: bit
+		{"return {bit.band(12, 10), bit.bor(12, 10), bit.bxor(12, 10), bit.lshift(1, 4), bit.tohex(255)}", protocol.Array{protocol.Integer(8), protocol.Integer(14), protocol.Integer(6), protocol.Integer(16), protocol.BulkString("000000ff")}},
+		{"return {bit.bnot(0), bit.rshift(-1, 28), bit.arshift(-16, 2), bit.tohex(255, -2)}", protocol.Array{protocol.Integer(-1), protocol.Integer(15), protocol.Integer(-4), protocol.BulkString("FF")}},
+	}
+	for _, tt := range tests {
+		result, err := registry.ExecuteForClient(client, "EVAL", []string{tt.script, "0"})
+		if err != nil {
+			t.Errorf("EVAL %q failed: %v", tt.script, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("EVAL %q: expected %v, got %v", tt.script, tt.expected, result)
+		}
+	}
+
+	// 테스트 케이스 4: 잘못된 값은 에러
+	for _, script := range []string{
+		"return cjson.encode({[1] = 1, [100] = 2})",
+		"return cjson.encode(0/0)",
+		"return cjson.encode(function() end)",
+	} {
+		if _, err := registry.ExecuteForClient(client, "EVAL", []string{script, "0"}); err == nil || !strings.Contains(err.Error(), "Cannot serialise") {
+			t.Errorf("EVAL %q: expected serialise error, got %v", script, err)
+		}
+	}
+	for _, input := range []string{`{`, `[1,]`, `{"a" 1}`, `tru`, `"unterminated`, `1 2`, ``} {
+		if _, err := registry.ExecuteForClient(client, "EVAL", []string{"return cjson.decode(ARGV[1])", "0", input}); err == nil || !strings.Contains(err.Error(), "Expected") {
+			t.Errorf("cjson.decode(%q): expected error, got %v", input, err)
+		}
+	}
+}
+
+// TestEvalShaHandler는 EVALSHA와 스크립트 캐시를 테스트합니다.
+func TestEvalShaHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	script := "return ARGV[1] .. '!'"
+	sha := sha1hex(script)
+
+	// 테스트 케이스 1: EVAL 전에는 캐시에 없음 (에러 케이스)
+	_, err := registry.ExecuteForClient(client, "EVALSHA", []string{sha, "0", "hi"})
+	if _, ok := err.(*NoScriptError); !ok {
+		t.Errorf("Expected NoScriptError, got %v", err)
+	}
+
+	// 테스트 케이스 2: EVAL로 캐시한 뒤 EVALSHA로 실행 (대소문자 구분 없음)
+	if _, err := registry.ExecuteForClient(client, "EVAL", []string{script, "0", "x"}); err != nil {
+		t.Fatalf("EVAL failed: %v", err)
+	}
+	for _, s := range []string{sha, strings.ToUpper(sha)} {
+		result, err := registry.ExecuteForClient(client, "EVALSHA", []string{s, "0", "hi"})
+		if err != nil {
+			t.Fatalf("EVALSHA failed: %v", err)
+		}
+		if result != protocol.BulkString("hi!") {
+			t.Errorf("Expected 'hi!', got %v", result)
+		}
+	}
+
+	// 테스트 케이스 3: 전역 변수는 스크립트 실행 사이에 유지됨
+	registry.ExecuteForClient(client, "EVAL", []string{"counter = (counter or 0) + 1; return counter", "0"})
+	result, _ := registry.ExecuteForClient(client, "EVAL", []string{"counter = (counter or 0) + 1; return counter", "0"})
+	if result != protocol.Integer(2) {
+		t.Errorf("Expected 2, got %v", result)
+	}
+}
+
+// TestEvalInTransaction은 MULTI 안에서 EVAL이 대기열에 쌓였다가 실행되는지 테스트합니다.
+func TestEvalInTransaction(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	result, _ := registry.ExecuteForClient(client, "EVAL", []string{"return redis.call('SET', KEYS[1], 'v')", "1", "k"})
+	if !isStatus(result, "QUEUED") {
+		t.Errorf("Expected 'QUEUED', got %v", result)
+	}
+	registry.ExecuteForClient(client, "GET", []string{"k"})
+
+	result, err := registry.ExecuteForClient(client, "EXEC", []string{})
+	if err != nil {
+		t.Fatalf("EXEC failed: %v", err)
+	}
+	expected := protocol.Array{protocol.SimpleString("OK"), protocol.BulkBytes("v")}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+// TestScriptHandler는 SCRIPT LOAD/EXISTS/FLUSH를 테스트합니다.
+func TestScriptHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: LOAD → SHA1 다이제스트, 실행하지 않고 캐시에만 넣음
+	result, err := registry.ExecuteForClient(client, "SCRIPT", []string{"LOAD", "return 'hello'"})
+	if err != nil {
+		t.Fatalf("SCRIPT LOAD failed: %v", err)
+	}
+	if result != protocol.BulkString("1b936e3fe509bcbc9cd0664897bbe8fd0cac101b") {
+		t.Errorf("Expected sha1 of script, got %v", result)
+	}
+	result, _ = registry.ExecuteForClient(client, "EVALSHA", []string{"1b936e3fe509bcbc9cd0664897bbe8fd0cac101b", "0"})
+	if result != protocol.BulkString("hello") {
+		t.Errorf("Expected 'hello', got %v", result)
+	}
+
+	// 테스트 케이스 2: EXISTS → 각 다이제스트마다 1 또는 0
+	result, _ = registry.ExecuteForClient(client, "SCRIPT", []string{"EXISTS", "1B936E3FE509BCBC9CD0664897BBE8FD0CAC101B", "ffffffffffffffffffffffffffffffffffffffff"})
+	if !reflect.DeepEqual(result, protocol.Array{protocol.Integer(1), protocol.Integer(0)}) {
+		t.Errorf("Expected [1 0], got %v", result)
+	}
+
+	// 테스트 케이스 3: FLUSH → 캐시와 전역 변수 초기화
+	registry.ExecuteForClient(client, "EVAL", []string{"leftover = 1", "0"})
+	if result, _ := registry.ExecuteForClient(client, "SCRIPT", []string{"FLUSH", "ASYNC"}); !isStatus(result, "OK") {
+		t.Errorf("Expected 'OK', got %v", result)
+	}
+	result, _ = registry.ExecuteForClient(client, "SCRIPT", []string{"EXISTS", "1b936e3fe509bcbc9cd0664897bbe8fd0cac101b"})
+	if !reflect.DeepEqual(result, protocol.Array{protocol.Integer(0)}) {
+		t.Errorf("Expected [0] after FLUSH, got %v", result)
+	}
+	result, _ = registry.ExecuteForClient(client, "EVAL", []string{"return leftover == nil", "0"})
+	if result != protocol.Integer(1) {
+		t.Errorf("Expected globals to be reset after FLUSH, got %v", result)
+	}
+
+	// 테스트 케이스 4: 잘못된 사용 (에러 케이스)
+	errorCases := [][]string{
+		{"LOAD", "return +"},
+		{"LOAD"},
+		{"EXISTS"},
+		{"FLUSH", "NOW"},
+		{"NOSUCH"},
+	}
+	for _, args := range errorCases {
+		if _, err := registry.ExecuteForClient(client, "SCRIPT", args); err == nil {
+			t.Errorf("Expected error for SCRIPT %v", args)
+		}
+	}
+
+	// 테스트 케이스 5: 스크립트에서 SCRIPT 호출 불가
+	if _, err := registry.ExecuteForClient(client, "EVAL", []string{"return redis.call('SCRIPT', 'FLUSH')", "0"}); err == nil {
+		t.Error("Expected error for SCRIPT called from script")
+	}
+}
+
+// waitForBusy는 스크립트가 BUSY 상태가 될 때까지 기다립니다.
+func waitForBusy(t *testing.T, registry *CommandRegistry) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !registry.scripts.busy() {
+		if time.Now().After(deadline) {
+			t.Fatal("Script did not become busy")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestScriptKill은 오래 실행되는 스크립트의 BUSY 응답과 SCRIPT KILL을 테스트합니다.
+func TestScriptKill(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.scripts.timeLimit = 10 * time.Millisecond
+	runner, _ := newTestClient(registry)
+	other, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: 실행 중인 스크립트가 없으면 NOTBUSY
+	_, err := registry.ExecuteForClient(other, "SCRIPT", []string{"KILL"})
+	if _, ok := err.(*NotBusyError); !ok {
+		t.Errorf("Expected NotBusyError, got %v", err)
+	}
+
+	// 테스트 케이스 2: 시간 제한을 넘긴 스크립트 실행 중에는 다른 명령어가 BUSY
+	done := make(chan error, 1)
+	go func() {
+		_, err := registry.ExecuteForClient(runner, "EVAL", []string{"while true do end", "0"})
+		done <- err
+	}()
+	waitForBusy(t, registry)
+
+	_, err = registry.ExecuteForClient(other, "GET", []string{"key"})
+	if _, ok := err.(*BusyError); !ok {
+		t.Errorf("Expected BusyError, got %v", err)
+	}
+
+	// 테스트 케이스 3: SCRIPT KILL → 스크립트는 에러로 종료
+	result, err := registry.ExecuteForClient(other, "SCRIPT", []string{"KILL"})
+	if err != nil || !isStatus(result, "OK") {
+		t.Fatalf("SCRIPT KILL failed: %v, %v", result, err)
+	}
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "Script killed by user with SCRIPT KILL") {
+			t.Errorf("Expected killed script error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Script was not killed")
+	}
+
+	// 종료 후에는 다시 명령어 실행 가능
+	if _, err := registry.ExecuteForClient(other, "GET", []string{"key"}); err != nil {
+		t.Errorf("Expected GET to succeed after kill, got %v", err)
+	}
+}
+
+// TestScriptKillAfterWrite는 데이터를 변경한 스크립트는 중단할 수 없는지 테스트합니다.
+func TestScriptKillAfterWrite(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	registry.scripts.timeLimit = 10 * time.Millisecond
+	runner, _ := newTestClient(registry)
+	other, _ := newTestClient(registry)
+
+	// 쓰기 후 시간 제한보다 충분히 오래 반복한 뒤 스스로 종료하는 스크립트
+	done := make(chan error, 1)
+	go func() {
+		_, err := registry.ExecuteForClient(runner, "EVAL", []string{
+			"redis.call('SET', 'written', '1'); for i = 1, 3000000 do end; return 'finished'", "0",
+		})
+		done <- err
+	}()
+	waitForBusy(t, registry)
+
+	_, err := registry.ExecuteForClient(other, "SCRIPT", []string{"KILL"})
+	if _, ok := err.(*UnkillableError); !ok {
+		t.Errorf("Expected UnkillableError, got %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected script to finish, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Script did not finish")
+	}
+}
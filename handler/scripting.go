@@ -0,0 +1,564 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+// defaultScriptTimeLimit는 스크립트가 BUSY 상태가 되기까지의 기본 실행 시간입니다
+// (Redis의 busy-reply-threshold 기본값).
+const defaultScriptTimeLimit = 5 * time.Second
+
+// errScriptKilled는 SCRIPT KILL로 중단된 스크립트의 에러 메시지입니다.
+var errScriptKilled = errors.New("Script killed by user with SCRIPT KILL...")
+
+// sandboxLibs는 스크립트에서 사용할 수 있는 표준 라이브러리입니다 (Redis와 동일하게 파일, OS, 모듈 로딩 제외).
+var sandboxLibs = []struct {
+	name string
+	open lua.LGFunction
+}{
+	{lua.BaseLibName, lua.OpenBase},
+	{lua.TabLibName, lua.OpenTable},
+	{lua.StringLibName, lua.OpenString},
+	{lua.MathLibName, lua.OpenMath},
+}
+
+// sandboxRemoved는 기본 라이브러리에서 지우는 전역 함수입니다.
+// 파일을 읽거나(dofile, loadfile) 모듈을 불러오거나(require, module) 서버의 표준 출력에 쓰는(print) 함수입니다.
+var sandboxRemoved = []string{"dofile", "loadfile", "require", "module", "print", "_printregs"}
+
+// scriptEngine은 EVAL/EVALSHA가 공유하는 Lua 실행 환경입니다 (gopher-lua).
+//
+// 모든 스크립트는 하나의 lua.LState에서 실행되고, 컴파일된 스크립트는
+// SHA1 다이제스트를 키로 캐시되어 EVALSHA로 다시 실행할 수 있습니다.
+//
+// 스크립트 안의 redis.call은 레지스트리의 실행 잠금을 다시 잡지 않고 명령어를 실행합니다.
+// EVAL/EVALSHA는 배타 잠금을 잡고 실행되므로 스크립트 전체가 원자적으로 실행됩니다.
+//
+// 스크립트가 timeLimit보다 오래 실행되면 BUSY 상태가 되어 다른 클라이언트의 명령어는
+// 대기하지 않고 BUSY 에러를 받습니다. 아직 데이터를 변경하지 않은 스크립트는 SCRIPT KILL로 중단할 수 있습니다.
+type scriptEngine struct {
+	registry *CommandRegistry
+
+	// mu는 lua.LState와 스크립트 캐시를 보호합니다.
+	// LState는 동시에 하나의 스크립트만 실행할 수 있습니다.
+	mu      sync.Mutex
+	L       *lua.LState
+	scripts map[string]*lua.LFunction // SHA1 (소문자 16진수) → 컴파일된 스크립트
+
+	// functionL은 FUNCTION LOAD로 등록한 함수들이 실행되는 별도의 lua.LState이고,
+	// libraries와 functions는 등록된 라이브러리와 함수입니다 (mu로 보호).
+	functionL *lua.LState
+	libraries map[string]*functionLibrary // 라이브러리 이름 → 라이브러리
+	functions map[string]*luaFunction     // 함수 이름 → 함수
+
+	// loading은 FUNCTION LOAD로 라이브러리 코드를 실행하는 동안 함수를 모으는 라이브러리입니다.
+	// nil이 아닐 때만 redis.register_function을 호출할 수 있습니다.
+	loading *functionLibrary
+
+	// caller는 현재 실행 중인 스크립트를 호출한 연결입니다 (연결 없이 실행되면 nil).
+	caller *Client
+
+	// timeLimit은 스크립트가 BUSY 상태가 되기까지의 실행 시간입니다.
+	timeLimit time.Duration
+
+	// stateMu는 실행 중인 스크립트의 상태를 보호합니다.
+	// 스크립트가 실행되는 동안(mu를 잡고 있는 동안)에도 BUSY 확인과 SCRIPT KILL이 가능하도록 mu와 분리되어 있습니다.
+	stateMu sync.Mutex
+	running bool      // 스크립트 실행 중 여부
+	started time.Time // 실행 시작 시각
+	wrote   bool      // 실행 중 데이터를 변경했는지 (변경한 스크립트는 중단할 수 없음)
+	killed  bool      // SCRIPT KILL 요청 여부
+
+	// cancel은 실행 중인 스크립트의 컨텍스트를 취소합니다 (SCRIPT KILL).
+	// LState는 명령어마다 컨텍스트를 확인하므로 pcall 안에서 반복하는 스크립트도 중단됩니다.
+	cancel context.CancelFunc
+}
+
+// newScriptEngine은 redis 라이브러리가 등록된 새 Lua 실행 환경을 생성합니다.
+// 스크립트(EVAL)와 함수(FCALL)는 서로 다른 lua.LState에서 실행됩니다.
+//
+// 스크립트에서 사용할 수 있는 redis 라이브러리:
+//   - redis.call(cmd, ...): 명령어 실행, 실패하면 스크립트 에러 발생
+//   - redis.pcall(cmd, ...): 명령어 실행, 실패하면 {err = "..."} 테이블 반환
+//   - redis.error_reply(msg), redis.status_reply(msg): 에러/상태 응답 테이블 생성
+//   - redis.sha1hex(s): 문자열의 SHA1 다이제스트
+//   - redis.log(level, msg): 로그 (무시됨)
+//
+// 그 외에 cjson 라이브러리(encode, decode, null)와 bit 라이브러리를 사용할 수 있습니다.
+func newScriptEngine(registry *CommandRegistry) *scriptEngine {
+	e := &scriptEngine{
+		registry:  registry,
+		scripts:   make(map[string]*lua.LFunction),
+		timeLimit: defaultScriptTimeLimit,
+	}
+	e.L = e.newState()
+	e.resetFunctions()
+	registry.store.OnKeyModified(e.keyModified)
+	return e
+}
+
+// newState는 샌드박스 표준 라이브러리와 redis, cjson, bit 라이브러리가 설정된 새 lua.LState를 생성합니다.
+func (e *scriptEngine) newState() *lua.LState {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	for _, lib := range sandboxLibs {
+		L.Push(L.NewFunction(lib.open))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	for _, name := range sandboxRemoved {
+		L.SetGlobal(name, lua.LNil)
+	}
+
+	redis := L.NewTable()
+	L.SetFuncs(redis, map[string]lua.LGFunction{
+		"call": func(L *lua.LState) int {
+			return e.call(L, true)
+		},
+		"pcall": func(L *lua.LState) int {
+			return e.call(L, false)
+		},
+		"error_reply": func(L *lua.LState) int {
+			return replyTable(L, "err", "error_reply")
+		},
+		"status_reply": func(L *lua.LState) int {
+			return replyTable(L, "ok", "status_reply")
+		},
+		"sha1hex": func(L *lua.LState) int {
+			if L.GetTop() != 1 {
+				L.RaiseError("wrong number of arguments")
+			}
+			L.Push(lua.LString(sha1hex(L.ToString(1))))
+			return 1
+		},
+		"log": func(L *lua.LState) int {
+			if L.GetTop() < 2 {
+				L.RaiseError("redis.log() requires two arguments or more.")
+			}
+			return 0
+		},
+	})
+	for i, level := range []string{"LOG_DEBUG", "LOG_VERBOSE", "LOG_NOTICE", "LOG_WARNING"} {
+		redis.RawSetString(level, lua.LNumber(i))
+	}
+	L.SetGlobal("redis", redis)
+	L.SetGlobal("cjson", newCJSON(L))
+	L.SetGlobal("bit", newBit(L))
+
+	return L
+}
+
+// sha1hex는 문자열의 SHA1 다이제스트를 소문자 16진수로 반환합니다.
+func sha1hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// replyTable은 redis.error_reply/status_reply가 반환하는 {field = msg} 테이블을 만듭니다.
+func replyTable(L *lua.LState, field string, name string) int {
+	if L.GetTop() != 1 {
+		L.RaiseError("wrong number of arguments")
+	}
+	msg, ok := L.Get(1).(lua.LString)
+	if !ok {
+		L.RaiseError("bad argument #1 to '%s' (string expected, got %s)", name, L.Get(1).Type())
+	}
+	t := L.NewTable()
+	t.RawSetString(field, msg)
+	L.Push(t)
+	return 1
+}
+
+// compile은 소스 코드를 chunk 이름으로 컴파일합니다.
+// 문법 오류는 Lua와 같은 "<청크>:<줄>: <설명> near '<토큰>'" 형식의 메시지로 반환합니다.
+func compile(L *lua.LState, src, chunk string) (*lua.LFunction, error) {
+	fn, err := L.Load(strings.NewReader(src), chunk)
+	if err == nil {
+		return fn, nil
+	}
+	var apiErr *lua.ApiError
+	var parseErr *parse.Error
+	if errors.As(err, &apiErr) && errors.As(apiErr.Cause, &parseErr) {
+		where := parseErr.Pos.Source
+		if parseErr.Pos.Line > 0 {
+			where += ":" + strconv.Itoa(parseErr.Pos.Line)
+		}
+		return nil, errors.New(where + ": " + parseErr.Message + " near '" + parseErr.Token + "'")
+	}
+	return nil, errors.New(luaErrorMessage(err))
+}
+
+// luaErrorMessage는 Lua 실행 에러의 메시지를 반환합니다 (gopher-lua가 덧붙이는 스택 트레이스 제외).
+func luaErrorMessage(err error) string {
+	var apiErr *lua.ApiError
+	if errors.As(err, &apiErr) && apiErr.Object != nil {
+		return apiErr.Object.String()
+	}
+	return err.Error()
+}
+
+// load는 스크립트를 컴파일해 캐시에 넣고 SHA1 다이제스트를 반환합니다.
+// 이미 캐시된 스크립트는 다시 컴파일하지 않습니다.
+func (e *scriptEngine) load(body string) (string, *lua.LFunction, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sha := sha1hex(body)
+	if fn, ok := e.scripts[sha]; ok {
+		return sha, fn, nil
+	}
+
+	fn, err := compile(e.L, body, "user_script")
+	if err != nil {
+		return "", nil, &InvalidArgumentError{Message: "Error compiling script (new function): " + err.Error()}
+	}
+	e.scripts[sha] = fn
+	return sha, fn, nil
+}
+
+// lookup은 SHA1 다이제스트로 캐시된 스크립트를 찾습니다.
+func (e *scriptEngine) lookup(sha string) (*lua.LFunction, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	fn, ok := e.scripts[strings.ToLower(sha)]
+	return fn, ok
+}
+
+// run은 KEYS와 ARGV 전역 변수를 설정하고 스크립트를 실행합니다.
+// 스크립트의 반환값은 Redis 응답으로 변환되어 반환됩니다.
+//
+// 매개변수:
+//   - client: 스크립트를 실행한 연결 (연결 없이 실행되면 nil)
+//   - sha: 스크립트의 SHA1 다이제스트 (에러 메시지에 사용)
+func (e *scriptEngine) run(client *Client, sha string, fn *lua.LFunction, keys, argv []string) (protocol.Reply, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.L.SetGlobal("KEYS", stringArray(e.L, keys))
+	e.L.SetGlobal("ARGV", stringArray(e.L, argv))
+
+	return e.invoke(client, sha, e.L, fn)
+}
+
+// invoke는 스크립트 실행 상태(호출한 연결, BUSY/SCRIPT KILL을 위한 실행 상태)를 기록하고
+// L에서 fn을 실행한 뒤 첫 번째 반환값을 Redis 응답으로 변환합니다. 호출하는 쪽에서 mu를 잡고 있어야 합니다.
+//
+// 매개변수:
+//   - name: 에러 메시지에 덧붙일 스크립트 이름 (EVAL은 SHA1, FCALL은 함수 이름)
+func (e *scriptEngine) invoke(client *Client, name string, L *lua.LState, fn *lua.LFunction, args ...lua.LValue) (protocol.Reply, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	L.SetContext(ctx)
+	e.caller = client
+	e.begin(cancel)
+	e.registry.beginPropagation()
+	defer func() {
+		e.registry.endPropagation()
+		e.caller = nil
+		e.end()
+		L.RemoveContext()
+		cancel()
+	}()
+
+	if err := L.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, args...); err != nil {
+		if e.wasKilled() {
+			return nil, &ScriptError{Message: "ERR " + errScriptKilled.Error() + " script: " + name}
+		}
+		return nil, scriptRunError(err, name)
+	}
+	result := L.Get(-1)
+	L.Pop(1)
+
+	reply := luaToReply(result)
+	if errReply, ok := reply.(*protocol.ErrorReply); ok {
+		return nil, &ScriptError{Message: errReply.Message}
+	}
+	return reply, nil
+}
+
+// flush는 스크립트 캐시를 비우고 Lua 실행 환경을 새로 만듭니다 (전역 변수도 초기화됨).
+func (e *scriptEngine) flush() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.L.Close()
+	e.scripts = make(map[string]*lua.LFunction)
+	e.L = e.newState()
+}
+
+// begin은 스크립트 실행 시작을 기록합니다. cancel은 SCRIPT KILL이 스크립트를 중단할 때 호출합니다.
+func (e *scriptEngine) begin(cancel context.CancelFunc) {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	e.running = true
+	e.started = time.Now()
+	e.wrote = false
+	e.killed = false
+	e.cancel = cancel
+}
+
+// end는 스크립트 실행 종료를 기록합니다.
+func (e *scriptEngine) end() {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	e.running = false
+	e.cancel = nil
+}
+
+// busy는 스크립트가 시간 제한을 넘겨 실행 중인지 확인합니다.
+func (e *scriptEngine) busy() bool {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	return e.running && time.Since(e.started) >= e.timeLimit
+}
+
+// kill은 실행 중인 스크립트에 중단을 요청합니다 (SCRIPT KILL).
+// 스크립트의 컨텍스트를 취소하므로 스크립트는 다음 Lua 명령어를 실행할 때 에러로 중단됩니다.
+//
+// 반환값:
+//   - error: 실행 중인 스크립트가 없으면 NotBusyError, 이미 데이터를 변경했으면 UnkillableError
+func (e *scriptEngine) kill() error {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	if !e.running {
+		return &NotBusyError{}
+	}
+	if e.wrote {
+		return &UnkillableError{}
+	}
+	e.killed = true
+	e.cancel()
+	return nil
+}
+
+// wasKilled는 실행 중인 스크립트가 SCRIPT KILL로 중단되었는지 확인합니다.
+func (e *scriptEngine) wasKilled() bool {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	return e.killed
+}
+
+// keyModified는 저장소의 키 변경 알림을 받아, 실행 중인 스크립트가 데이터를 변경했음을 기록합니다.
+func (e *scriptEngine) keyModified(key string) {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	if e.running {
+		e.wrote = true
+	}
+}
+
+// scriptRunError는 스크립트 실행 중 발생한 Lua 에러를 Redis 에러로 변환합니다.
+//
+// redis.call이 발생시킨 {err = "..."} 테이블은 원래 에러 코드를 유지하고,
+// 그 외의 에러는 ERR 에러가 됩니다. 두 경우 모두 스크립트 이름(SHA1 또는 함수 이름)이 덧붙습니다.
+func scriptRunError(err error, name string) error {
+	var apiErr *lua.ApiError
+	if errors.As(err, &apiErr) {
+		if t, ok := apiErr.Object.(*lua.LTable); ok {
+			if msg, ok := t.RawGetString("err").(lua.LString); ok {
+				return &ScriptError{Message: string(msg) + " script: " + name}
+			}
+		}
+	}
+	return &ScriptError{Message: "ERR " + luaErrorMessage(err) + " script: " + name}
+}
+
+// stringArray는 문자열 목록을 Lua 배열 테이블로 변환합니다.
+func stringArray(L *lua.LState, values []string) *lua.LTable {
+	t := L.CreateTable(len(values), 0)
+	for _, v := range values {
+		t.Append(lua.LString(v))
+	}
+	return t
+}
+
+// call은 redis.call/redis.pcall의 구현입니다.
+//
+// 명령어가 실패하면 raise가 true(redis.call)일 때는 {err = "..."} 테이블로 스크립트 에러를 발생시키고,
+// false(redis.pcall)일 때는 같은 테이블을 반환값으로 돌려줍니다.
+func (e *scriptEngine) call(L *lua.LState, raise bool) int {
+	if e.loading != nil {
+		L.RaiseError("redis.call and redis.pcall are not allowed while loading a library")
+	}
+	args := make([]lua.LValue, L.GetTop())
+	for i := range args {
+		args[i] = L.Get(i + 1)
+	}
+	result, err := e.execute(args)
+	if err != nil {
+		t := L.NewTable()
+		t.RawSetString("err", lua.LString(strings.TrimPrefix(err.Error(), "-")))
+		if raise {
+			L.Error(t, 0)
+		}
+		L.Push(t)
+		return 1
+	}
+	L.Push(replyToLua(L, result))
+	return 1
+}
+
+// execute는 스크립트에서 요청한 명령어를 검사하고 실행합니다.
+// 클러스터 모드에서는 일반 명령어와 같이 키가 이 노드가 담당하는 한 슬롯에 있어야 합니다 (clusterRedirect).
+func (e *scriptEngine) execute(args []lua.LValue) (protocol.Reply, error) {
+	if len(args) == 0 {
+		return nil, &InvalidArgumentError{Message: "Please specify at least one argument for this redis lib call"}
+	}
+
+	strArgs := make([]string, len(args))
+	for i, arg := range args {
+		switch arg.(type) {
+		case lua.LString, lua.LNumber:
+			strArgs[i] = arg.String()
+		default:
+			return nil, &InvalidArgumentError{Message: "Lua redis lib command arguments must be strings or integers"}
+		}
+	}
+
+	cmdUpper, callable := e.registry.resolveCommand(strings.ToUpper(strArgs[0]))
+	handler, exists := e.registry.handlers[cmdUpper]
+	if !exists || !callable {
+		return nil, &InvalidArgumentError{Message: "Unknown Redis command called from script"}
+	}
+	if !e.registry.checkArity(cmdUpper, len(strArgs)-1) {
+		return nil, &InvalidArgumentError{Message: "Wrong number of args calling Redis command from script"}
+	}
+	if !e.registry.allowedInScript(cmdUpper) {
+		return nil, &InvalidArgumentError{Message: "This Redis command is not allowed from script"}
+	}
+	if e.registry.rejectsWrite(e.caller, cmdUpper) {
+		return nil, &ReadOnlyError{}
+	}
+	// 스크립트 안에서는 키를 지우지 않고, 이미 데이터를 변경한 스크립트는 원자성을 위해 끝까지 실행
+	if !e.wrote && (e.caller == nil || !e.caller.master) && e.registry.overMaxMemory() && e.registry.commandHas(cmdUpper, cmdDenyOOM) {
+		return nil, &OOMError{}
+	}
+	if err := e.registry.clusterRedirect(e.caller, cmdUpper, strArgs[1:]); err != nil {
+		return nil, err
+	}
+
+	// EVAL/EVALSHA가 이미 배타 잠금을 잡고 있으므로 잠금 없이 실행
+	return e.registry.dispatch(e.caller, cmdUpper, handler, strArgs[1:], true)
+}
+
+// replyToLua는 명령어 응답을 Lua 값으로 변환합니다 (Redis의 변환 규칙과 동일).
+//   - 상태 응답 (OK 등) → {ok = "..."}
+//   - 에러 → {err = "..."}
+//   - 정수 → 숫자, 문자열 → 문자열
+//   - 배열 → 배열 테이블 (재귀적으로 변환)
+//   - Map, Set → RESP2와 같은 배열 테이블 (Map은 키와 값을 번갈아 나열)
+//   - Double → RESP2와 같은 문자열, Boolean → RESP2와 같은 숫자 (1, 0)
+//   - Null, Null Array → false
+func replyToLua(L *lua.LState, reply protocol.Reply) lua.LValue {
+	switch v := reply.(type) {
+	case nil, protocol.Null, protocol.NullArray:
+		return lua.LFalse
+	case protocol.BulkString:
+		return lua.LString(v)
+	case protocol.BulkBytes:
+		return lua.LString(v)
+	case protocol.SimpleString:
+		t := L.NewTable()
+		t.RawSetString("ok", lua.LString(v))
+		return t
+	case protocol.Integer:
+		return lua.LNumber(v)
+	case protocol.BulkStrings:
+		t := L.CreateTable(len(v), 0)
+		for _, element := range v {
+			t.Append(lua.LString(element))
+		}
+		return t
+	case protocol.Array:
+		return repliesToLua(L, v)
+	case protocol.Map:
+		return repliesToLua(L, v)
+	case protocol.Set:
+		return repliesToLua(L, v)
+	case protocol.Double:
+		return lua.LString(protocol.FormatDouble(float64(v)))
+	case protocol.Boolean:
+		if v {
+			return lua.LNumber(1)
+		}
+		return lua.LNumber(0)
+	case *protocol.ErrorReply:
+		t := L.NewTable()
+		t.RawSetString("err", lua.LString(v.Message))
+		return t
+	}
+	return lua.LFalse
+}
+
+// repliesToLua는 응답 목록을 배열 테이블로 변환합니다.
+func repliesToLua(L *lua.LState, replies []protocol.Reply) *lua.LTable {
+	t := L.CreateTable(len(replies), 0)
+	for _, element := range replies {
+		t.Append(replyToLua(L, element))
+	}
+	return t
+}
+
+// luaToReply는 스크립트의 반환값을 명령어 응답으로 변환합니다 (Redis의 변환 규칙과 동일).
+//   - 숫자 → 정수 (소수점 이하 버림)
+//   - 문자열 → Bulk String
+//   - true → 1, false/nil → nil (Null)
+//   - {ok = "..."} → 상태 응답, {err = "..."} → 에러 응답
+//   - 배열 테이블 → 배열 (첫 번째 nil 앞까지)
+func luaToReply(v lua.LValue) protocol.Reply {
+	switch v := v.(type) {
+	case lua.LNumber:
+		return protocol.Integer(v)
+	case lua.LString:
+		return protocol.BulkString(v)
+	case lua.LBool:
+		if v {
+			return protocol.Integer(1)
+		}
+		return nil
+	case *lua.LTable:
+		if msg, ok := v.RawGetString("ok").(lua.LString); ok {
+			return protocol.SimpleString(msg)
+		}
+		if msg, ok := v.RawGetString("err").(lua.LString); ok {
+			return &protocol.ErrorReply{Message: string(msg)}
+		}
+		elements := make(protocol.Array, 0, v.Len())
+		for i := 1; ; i++ {
+			element := v.RawGetInt(i)
+			if element == lua.LNil {
+				break
+			}
+			elements = append(elements, luaToReply(element))
+		}
+		return elements
+	}
+	return nil
+}
+
+// ScriptError는 스크립트가 반환하거나 발생시킨 에러입니다.
+// Message는 에러 코드를 포함한 전체 메시지입니다 (예: "ERR boom", "WRONGTYPE ...").
+type ScriptError struct {
+	Message string
+}
+
+// Error는 error 인터페이스를 구현합니다.
+func (e *ScriptError) Error() string {
+	return "-" + e.Message
+}
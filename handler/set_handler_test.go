@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"bufio"
+	"bytes"
+	"strings"
 	"testing"
+	"unsafe"
 
+	"github.com/codecrafters-io/redis-starter-go/protocol"
 	"github.com/codecrafters-io/redis-starter-go/store"
 )
 
@@ -16,14 +21,14 @@ func TestSetHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("SET failed: %v", err)
 	}
-	if result != "OK" {
+	if !isStatus(result, "OK") {
 		t.Errorf("Expected 'OK', got %v", result)
 	}
 
 	// 저장이 되었는지 확인
 	value := dataStore.GET("mykey")
-	if value == nil || *value != "myvalue" {
-		t.Errorf("Value not stored correctly, got %v", value)
+	if value == nil || string(value) != "myvalue" {
+		t.Errorf("Value not stored correctly, got %q", value)
 	}
 
 	// 테스트 케이스 2: TTL이 있는 SET
@@ -31,7 +36,7 @@ func TestSetHandler(t *testing.T) {
 	if err != nil {
 		t.Fatalf("SET with TTL failed: %v", err)
 	}
-	if result != "OK" {
+	if !isStatus(result, "OK") {
 		t.Errorf("Expected 'OK', got %v", result)
 	}
 
@@ -52,4 +57,34 @@ func TestSetHandler(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for unknown option")
 	}
-}
\ No newline at end of file
+}
+
+// TestSetLargeValueNoCopy는 큰 값이 파서의 버퍼에서 저장소까지 복사되지 않고 전달되는지 테스트합니다.
+func TestSetLargeValueNoCopy(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	value := strings.Repeat("x", 1<<20)
+	request := protocol.EncodeCommand("SET", "big", value)
+	parsed, err := protocol.NewParser(bufio.NewReader(bytes.NewReader(request))).ParseRequest()
+	if err != nil {
+		t.Fatalf("ParseRequest failed: %v", err)
+	}
+	arg := parsed.([]interface{})[2].(string)
+
+	// 테스트 케이스 1: 저장소의 값이 파서가 읽은 인자의 버퍼를 그대로 사용
+	if _, err := registry.Execute("SET", []string{"big", arg}); err != nil {
+		t.Fatalf("SET failed: %v", err)
+	}
+	stored := registry.store.GET("big")
+	if len(stored) != len(value) || unsafe.SliceData(stored) != unsafe.StringData(arg) {
+		t.Error("Expected the stored value to share the parsed argument's buffer")
+	}
+
+	// 테스트 케이스 2: APPEND는 새 배열에 덧붙이므로 인자 문자열은 바뀌지 않음
+	registry.Execute("APPEND", []string{"big", "y"})
+	if arg != value {
+		t.Error("Expected APPEND to leave the parsed argument unchanged")
+	}
+	if appended := registry.store.GET("big"); len(appended) != len(value)+1 || appended[len(value)] != 'y' {
+		t.Errorf("Expected value to end with the appended byte, got length %d", len(appended))
+	}
+}
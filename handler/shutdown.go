@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"time"
+)
+
+// shutdownPollInterval은 종료 중에 실행 중인 명령어가 끝났는지 확인하는 주기입니다.
+const shutdownPollInterval = 10 * time.Millisecond
+
+// Shutdown은 서버를 종료하기 전에 호출합니다 (SIGTERM, SIGINT).
+// 새 연결을 받지 않도록 리스너를 닫은 뒤에 호출해야 합니다.
+//
+// 종료 과정:
+//  1. 백그라운드 고루틴(만료된 키 정리, 자동 저장, GETACK)과 마스터와의 복제 연결을 멈춤
+//  2. 명령어를 실행하지 않는 연결은 바로 끊고, 실행 중인 명령어(BLPOP처럼 대기 중인 명령어 포함)는
+//     ctx가 끝날 때까지 기다린 뒤 응답을 보낸 연결부터 끊음
+//  3. ctx가 끝나면 남아 있는 연결을 모두 끊음
+//  4. save이면 데이터셋을 RDB 파일로 저장
+//
+// 매개변수:
+//   - ctx: 실행 중인 명령어를 기다리는 시간 제한 (shutdown-timeout)
+//   - save: 종료 전에 RDB 파일로 저장할지 여부
+//
+// 에러 케이스:
+//   - RDB 파일 저장에 실패한 경우
+func (r *CommandRegistry) Shutdown(ctx context.Context, save bool) error {
+	r.shuttingDown.Store(true)
+	r.cancel()
+
+	p := r.replication
+	p.mu.Lock()
+	p.cancelLinkLocked()
+	p.mu.Unlock()
+
+	r.drainClients(ctx)
+
+	if !save {
+		return nil
+	}
+	r.execMu.Lock()
+	entries := r.store.Snapshot()
+	r.execMu.Unlock()
+	return r.persistence.save(entries)
+}
+
+// ShuttingDown은 Shutdown이 호출되었는지 확인합니다.
+// 연결 고루틴은 명령어의 응답을 보낸 뒤 이 값이 true이면 연결을 종료합니다.
+func (r *CommandRegistry) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+// drainClients는 명령어를 실행하지 않는 연결을 끊으면서 모든 연결이 종료될 때까지 기다립니다.
+// ctx가 끝나면 실행 중인 명령어가 남아 있어도 모든 연결을 끊고 바로 반환합니다.
+// 가상의 연결(conn이 없는 연결)은 끊을 수 없으므로 기다리지 않습니다.
+func (r *CommandRegistry) drainClients(ctx context.Context) {
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+
+	for {
+		remaining := 0
+		for _, client := range r.connectedClients() {
+			if !client.hasConn() {
+				continue
+			}
+			remaining++
+			if !client.snapshot().executing {
+				client.disconnect()
+			}
+		}
+		if remaining == 0 {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, client := range r.connectedClients() {
+				client.disconnect()
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
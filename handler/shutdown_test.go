@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// closeOnDisconnect는 연결 고루틴처럼 연결이 끊어질 때까지 읽은 뒤 CloseClient로 정리합니다.
+func closeOnDisconnect(registry *CommandRegistry, client *Client, reader *bufio.Reader) {
+	go func() {
+		for {
+			if _, err := reader.ReadByte(); err != nil {
+				registry.CloseClient(client)
+				return
+			}
+		}
+	}()
+}
+
+// TestShutdown은 종료 시 연결 정리와 저장을 테스트합니다.
+func TestShutdown(t *testing.T) {
+	// 테스트 케이스 1: 명령어를 실행하지 않는 연결은 바로 끊김
+	t.Run("IdleClientsDisconnected", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		client, reader, _ := newPipeClient(t, registry)
+		closeOnDisconnect(registry, client, reader)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		start := time.Now()
+		if err := registry.Shutdown(ctx, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("Expected idle clients to be disconnected immediately, took %v", elapsed)
+		}
+		if !registry.ShuttingDown() {
+			t.Error("Expected ShuttingDown to be true")
+		}
+		if registry.ctx.Err() == nil {
+			t.Error("Expected the background context to be cancelled")
+		}
+	})
+
+	// 테스트 케이스 2: 대기 중인 BLPOP은 값을 받아 끝날 때까지 기다림
+	t.Run("BlockedClientFinishes", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		client, reader, _ := newPipeClient(t, registry)
+
+		popped := make(chan protocol.Reply, 1)
+		go func() {
+			result, _ := registry.ExecuteForClient(client, "BLPOP", []string{"queue", "0"})
+			popped <- result
+			closeOnDisconnect(registry, client, reader)
+		}()
+		waitFor(t, "BLPOP to block", func() bool {
+			return registry.store.Blocking().Blocked("queue", store.BlockOnList) == 1
+		})
+
+		shutdown := make(chan error, 1)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			shutdown <- registry.Shutdown(ctx, false)
+		}()
+		waitFor(t, "shutdown to start", registry.ShuttingDown)
+
+		select {
+		case <-shutdown:
+			t.Fatal("Shutdown returned while a command was still blocked")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		registry.store.RPUSH("queue", "value")
+		result := <-popped
+		if arr, ok := result.(protocol.BulkStrings); !ok || !equalStringSlices(arr, []string{"queue", "value"}) {
+			t.Errorf("Expected [queue value], got %v", result)
+		}
+		select {
+		case err := <-shutdown:
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Shutdown did not return after the blocked command finished")
+		}
+	})
+
+	// 테스트 케이스 3: 시간 제한이 지나면 대기 중인 연결도 끊김
+	t.Run("TimeoutDisconnectsBlocked", func(t *testing.T) {
+		registry := NewCommandRegistry(store.NewStore())
+		client, _, _ := newPipeClient(t, registry)
+
+		popped := make(chan protocol.Reply, 1)
+		go func() {
+			result, _ := registry.ExecuteForClient(client, "BLPOP", []string{"queue", "0"})
+			popped <- result
+		}()
+		waitFor(t, "BLPOP to block", func() bool {
+			return registry.store.Blocking().Blocked("queue", store.BlockOnList) == 1
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if err := registry.Shutdown(ctx, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		select {
+		case result := <-popped:
+			if result != (protocol.NullArray{}) {
+				t.Errorf("Expected null array after disconnect, got %v", result)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("BLPOP was not cancelled after the shutdown timeout")
+		}
+	})
+
+	// 테스트 케이스 4: save이면 데이터셋을 RDB 파일로 저장
+	t.Run("SaveWritesRDB", func(t *testing.T) {
+		dir := t.TempDir()
+		registry := NewCommandRegistry(store.NewStore())
+		registry.SetRDBFile(dir, "dump.rdb")
+		registry.Execute("SET", []string{"key", "value"})
+
+		if err := registry.Shutdown(context.Background(), true); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "dump.rdb")); err != nil {
+			t.Errorf("Expected the RDB file to be written: %v", err)
+		}
+	})
+}
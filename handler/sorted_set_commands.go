@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// ZAddHandler는 ZADD 명령어를 처리하는 핸들러입니다.
+//
+// Redis ZADD 명령어 사양 (옵션 없는 기본 형식):
+//   - ZADD key score member [score member ...]
+//   - 멤버를 추가하거나 기존 멤버의 점수를 갱신
+//   - 새로 추가된 멤버 수를 반환 (점수만 갱신된 멤버는 제외)
+//
+// AOF 재작성이 정렬된 집합을 명령어로 기록할 때도 사용합니다.
+//
+// 예시:
+//
+//	클라이언트: ZADD myzset 1 one 2 two
+//	서버: :2\r\n
+type ZAddHandler struct{}
+
+// Execute는 ZADD 명령어를 실행합니다.
+//
+// 에러 케이스:
+//   - 점수와 멤버가 짝이 맞지 않는 경우: syntax error
+//   - 점수가 숫자가 아니거나 NaN인 경우
+//   - 키가 다른 타입인 경우: WRONGTYPE
+func (h *ZAddHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 3 {
+		return nil, &WrongNumberOfArgumentsError{Command: "zadd"}
+	}
+	key := args[0]
+	pairs := args[1:]
+	if len(pairs)%2 != 0 {
+		return nil, &InvalidArgumentError{Message: "syntax error"}
+	}
+
+	// 모든 점수를 먼저 검증 (일부만 추가되는 일이 없도록)
+	scores := make([]float64, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		score, err := strconv.ParseFloat(pairs[i], 64)
+		if err != nil || math.IsNaN(score) {
+			return nil, &InvalidArgumentError{Message: "value is not a valid float"}
+		}
+		scores = append(scores, score)
+	}
+
+	if keyType := store.TYPE(key); keyType != "none" && keyType != "zset" {
+		return nil, &WrongTypeError{}
+	}
+
+	added := 0
+	for i, score := range scores {
+		if store.ZADD(key, score, pairs[i*2+1]) {
+			added++
+		}
+	}
+	return protocol.Integer(added), nil
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestZAddHandler는 ZADD 명령어를 테스트합니다.
+func TestZAddHandler(t *testing.T) {
+	dataStore := store.NewStore()
+	handler := &ZAddHandler{}
+
+	// 테스트 케이스 1: 새 멤버 추가
+	result, err := handler.Execute([]string{"zset", "1", "one", "2", "two"}, dataStore)
+	if err != nil || result != protocol.Integer(2) {
+		t.Errorf("Expected 2, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 2: 기존 멤버의 점수 갱신은 개수에 포함되지 않음
+	result, _ = handler.Execute([]string{"zset", "3", "one", "-inf", "three"}, dataStore)
+	if result != protocol.Integer(1) {
+		t.Errorf("Expected 1, got %v", result)
+	}
+	if score, _ := dataStore.ZSCORE("zset", "one"); score != 3 {
+		t.Errorf("Expected score 3, got %v", score)
+	}
+
+	// 테스트 케이스 3: 잘못된 점수는 아무것도 추가하지 않음
+	_, err = handler.Execute([]string{"zset", "1", "four", "abc", "five"}, dataStore)
+	if err == nil || err.Error() != "-ERR value is not a valid float" {
+		t.Errorf("Expected float error, got %v", err)
+	}
+	if dataStore.ZCARD("zset") != 3 {
+		t.Errorf("Expected 3 members, got %d", dataStore.ZCARD("zset"))
+	}
+
+	// 테스트 케이스 4: 짝이 맞지 않는 인자, 다른 타입의 키
+	if _, err := handler.Execute([]string{"zset", "1", "one", "2"}, dataStore); err == nil {
+		t.Error("Expected syntax error")
+	}
+	dataStore.SET("str", []byte("value"), nil)
+	if _, err := handler.Execute([]string{"str", "1", "one"}, dataStore); err == nil {
+		t.Error("Expected WRONGTYPE error")
+	}
+}
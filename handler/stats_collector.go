@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// statsCollectorInterval은 순간 통계(instantaneous_ops_per_sec)의 표본을 모으는 주기입니다 (Redis의 serverCron, hz 10과 동일).
+const statsCollectorInterval = 100 * time.Millisecond
+
+// instantaneousSamples는 순간 통계의 평균을 낼 표본 수입니다 (Redis의 STATS_METRIC_SAMPLES와 동일, 약 1.6초).
+const instantaneousSamples = 16
+
+// instantaneousMetric은 누적 카운터의 최근 초당 증가량입니다 (Redis의 trackInstantaneousMetric).
+// 주기마다 직전 표본 이후의 증가량을 초당 값으로 바꿔 순환 버퍼에 넣고, 버퍼의 평균을 보고합니다.
+type instantaneousMetric struct {
+	mu        sync.Mutex
+	lastTime  time.Time
+	lastValue int64
+	samples   [instantaneousSamples]float64
+	next      int
+}
+
+// track은 now 시점의 누적 값 value를 표본으로 기록합니다.
+// CONFIG RESETSTAT으로 카운터가 줄었으면 0부터 다시 셉니다.
+func (m *instantaneousMetric) track(now time.Time, value int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.lastTime.IsZero() {
+		if elapsed := now.Sub(m.lastTime); elapsed > 0 {
+			delta := value - m.lastValue
+			if delta < 0 {
+				delta = value
+			}
+			m.samples[m.next] = float64(delta) / elapsed.Seconds()
+			m.next = (m.next + 1) % instantaneousSamples
+		}
+	}
+	m.lastTime, m.lastValue = now, value
+}
+
+// perSecond는 최근 표본들의 평균입니다 (초당 값, 소수점 이하 버림).
+func (m *instantaneousMetric) perSecond() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var sum float64
+	for _, sample := range m.samples {
+		sum += sample
+	}
+	return int64(sum / instantaneousSamples)
+}
+
+// reset은 모은 표본을 지웁니다 (CONFIG RESETSTAT).
+func (m *instantaneousMetric) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.samples = [instantaneousSamples]float64{}
+	m.lastTime = time.Time{}
+}
+
+// StartStatsCollector는 INFO의 순간 통계(instantaneous_ops_per_sec)를 위해 주기적으로 표본을 모으는 고루틴을 시작합니다.
+// 명령어 실행 경로는 원자적 카운터만 늘리고, 비율 계산은 이 고루틴이 합니다.
+// 여러 번 호출해도 고루틴은 하나만 시작합니다.
+func (r *CommandRegistry) StartStatsCollector() {
+	r.statsCollectorOnce.Do(func() { go r.statsCollectorCron() })
+}
+
+// statsCollectorCron은 서버가 종료될 때까지 statsCollectorInterval마다 표본을 모읍니다.
+func (r *CommandRegistry) statsCollectorCron() {
+	ticker := time.NewTicker(statsCollectorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.stats.opsPerSec.track(now, atomic.LoadInt64(&r.stats.commandsProcessed))
+		}
+	}
+}
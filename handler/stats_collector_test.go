@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestInstantaneousMetric는 누적 카운터의 초당 증가량 계산을 테스트합니다.
+func TestInstantaneousMetric(t *testing.T) {
+	var m instantaneousMetric
+	now := time.Unix(1000, 0)
+
+	// 테스트 케이스 1: 100ms마다 100씩 늘면 초당 1000 (버퍼가 찰 때까지는 빈 표본이 평균을 낮춤)
+	for i := 0; i <= instantaneousSamples; i++ {
+		m.track(now.Add(time.Duration(i)*statsCollectorInterval), int64(i*100))
+	}
+	if rate := m.perSecond(); rate != 1000 {
+		t.Errorf("Expected 1000 ops/sec, got %d", rate)
+	}
+
+	// 테스트 케이스 2: 카운터가 줄면(CONFIG RESETSTAT) 0부터 다시 셈
+	m.track(now.Add(time.Duration(instantaneousSamples+1)*statsCollectorInterval), 50)
+	if rate := m.perSecond(); rate >= 1000 || rate <= 0 {
+		t.Errorf("Expected the rate to drop after the counter reset, got %d", rate)
+	}
+
+	// 테스트 케이스 3: reset하면 0
+	m.reset()
+	if rate := m.perSecond(); rate != 0 {
+		t.Errorf("Expected 0 after reset, got %d", rate)
+	}
+}
+
+// TestInfoStatsCollector는 INFO server, clients, stats 섹션의 실제 값을 테스트합니다.
+func TestInfoStatsCollector(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	registry.SetListeningPort(7000)
+
+	// 테스트 케이스 1: 서버 정보
+	info, _ := registry.ExecuteForClient(client, "INFO", []string{"server"})
+	for _, line := range []string{"# Server\r\n", "redis_mode:standalone\r\n", "process_id:" + strconv.Itoa(os.Getpid()) + "\r\n",
+		"run_id:" + registry.runID + "\r\n", "tcp_port:7000\r\n", "uptime_in_seconds:0\r\n", "uptime_in_days:0\r\n"} {
+		if !strings.Contains(string(info.(protocol.BulkString)), line) {
+			t.Errorf("Expected %q in %q", line, info)
+		}
+	}
+
+	// 테스트 케이스 2: 읽기 명령어가 찾은 키와 찾지 못한 키 (쓰기 명령어의 키는 세지 않음)
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	registry.ExecuteForClient(client, "GET", []string{"a"})
+	registry.ExecuteForClient(client, "GET", []string{"missing"})
+	registry.ExecuteForClient(client, "LRANGE", []string{"missing", "0", "-1"})
+	info, _ = registry.ExecuteForClient(client, "INFO", []string{"stats"})
+	for _, line := range []string{"keyspace_hits:1\r\n", "keyspace_misses:2\r\n", "instantaneous_ops_per_sec:0\r\n"} {
+		if !strings.Contains(string(info.(protocol.BulkString)), line) {
+			t.Errorf("Expected %q in %q", line, info)
+		}
+	}
+
+	// 테스트 케이스 3: NO-TOUCH 연결의 읽기도 셈
+	registry.ExecuteForClient(client, "CLIENT", []string{"NO-TOUCH", "ON"})
+	registry.ExecuteForClient(client, "GET", []string{"a"})
+	info, _ = registry.ExecuteForClient(client, "INFO", []string{"stats"})
+	if !strings.Contains(string(info.(protocol.BulkString)), "keyspace_hits:2\r\n") {
+		t.Errorf("Expected keyspace_hits:2, got %q", info)
+	}
+
+	// 테스트 케이스 4: BLPOP으로 대기하는 동안 blocked_clients
+	blocked, _ := newTestClient(registry)
+	done := make(chan struct{})
+	go func() {
+		registry.ExecuteForClient(blocked, "BLPOP", []string{"queue", "0"})
+		close(done)
+	}()
+	waitFor(t, "client to block", func() bool {
+		info, _ := registry.ExecuteForClient(client, "INFO", []string{"clients"})
+		return strings.Contains(string(info.(protocol.BulkString)), "blocked_clients:1\r\n")
+	})
+	registry.ExecuteForClient(client, "RPUSH", []string{"queue", "x"})
+	<-done
+	info, _ = registry.ExecuteForClient(client, "INFO", []string{"clients"})
+	if !strings.Contains(string(info.(protocol.BulkString)), "blocked_clients:0\r\n") {
+		t.Errorf("Expected blocked_clients:0 after BLPOP returned, got %q", info)
+	}
+}
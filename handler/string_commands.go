@@ -5,7 +5,10 @@ package handler
 import (
 	"strconv"
 	"strings"
+	"time"
+	"unsafe"
 
+	"github.com/codecrafters-io/redis-starter-go/protocol"
 	"github.com/codecrafters-io/redis-starter-go/store"
 )
 
@@ -19,6 +22,7 @@ import (
 // Redis SET 명령어 사양:
 //   - SET key value → OK
 //   - SET key value PX milliseconds → OK (만료 시간 설정)
+//   - SET key value PXAT unix-time-milliseconds → OK (만료 시각 설정)
 //   - SET key value EX seconds → OK (현재 미구현)
 //
 // 예시:
@@ -37,11 +41,12 @@ type SetHandler struct{}
 //  2. 기본 SET: key, value 저장
 //  3. 옵션 처리: PX (밀리초 TTL) 지원
 //  4. 저장소에 값 저장
-//  5. "OK" 응답 반환
+//  5. OK 상태 응답 반환
 //
 // 지원하는 인자 패턴:
 //   - [key, value]: 기본 SET
 //   - [key, value, "PX", milliseconds]: TTL과 함께 SET
+//   - [key, value, "PXAT", unix-time-milliseconds]: 만료 시각과 함께 SET (AOF 재실행에 사용)
 //
 // 매개변수:
 //   - args: 명령어 인자들
@@ -52,21 +57,21 @@ type SetHandler struct{}
 //   - store: 데이터 저장소
 //
 // 반환값:
-//   - interface{}: "OK" 문자열
+//   - protocol.SimpleString: OK 상태 응답
 //   - error: 인자가 부족하거나 잘못된 경우
 //
 // 에러 케이스:
 //   - 인자가 2개 미만
 //   - TTL 값이 숫자가 아님
 //   - 알 수 없는 옵션
-func (h *SetHandler) Execute(args []string, store *store.Store) (interface{}, error) {
+func (h *SetHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
 	// 최소 인자 개수 검증 (key, value)
 	if len(args) < 2 {
 		return nil, &WrongNumberOfArgumentsError{Command: "set"}
 	}
 
 	key := args[0]
-	value := args[1]
+	value := argBytes(args[1])
 
 	// TTL 옵션 처리
 	var ttlMs *int
@@ -86,6 +91,17 @@ func (h *SetHandler) Execute(args []string, store *store.Store) (interface{}, er
 			}
 			ttlMs = &ms
 
+		case "PXAT":
+			// 밀리초 단위 Unix 시각으로 만료 시각 지정
+			ms, err := strconv.ParseInt(args[3], 10, 64)
+			if err != nil {
+				return nil, &InvalidArgumentError{
+					Message: "value is not an integer or out of range",
+				}
+			}
+			store.SETPXAT(key, value, time.UnixMilli(ms))
+			return okReply, nil
+
 		default:
 			// 지원하지 않는 옵션
 			return nil, &InvalidArgumentError{
@@ -103,8 +119,8 @@ func (h *SetHandler) Execute(args []string, store *store.Store) (interface{}, er
 	// TTL이 있으면 만료 시간과 함께, 없으면 영구 저장
 	store.SET(key, value, ttlMs)
 
-	// SET 명령어는 항상 "OK" 반환
-	return "OK", nil
+	// SET 명령어는 항상 OK 반환
+	return okReply, nil
 }
 
 // GetHandler는 GET 명령어를 처리하는 핸들러입니다.
@@ -141,7 +157,7 @@ type GetHandler struct{}
 //   - store: 데이터 저장소
 //
 // 반환값:
-//   - interface{}: 저장된 값 (string) 또는 nil
+//   - protocol.BulkBytes: 저장된 값 (키가 없으면 nil)
 //   - error: 인자가 잘못된 경우
 //
 // 에러 케이스:
@@ -149,8 +165,8 @@ type GetHandler struct{}
 //
 // 특별한 반환값:
 //   - nil: 키가 존재하지 않거나 만료됨 → Null Bulk String ($-1\r\n)
-//   - string: 실제 저장된 값 → Bulk String ($<len>\r\n<value>\r\n)
-func (h *GetHandler) Execute(args []string, store *store.Store) (interface{}, error) {
+//   - protocol.BulkBytes: 실제 저장된 값 → Bulk String ($<len>\r\n<value>\r\n)
+func (h *GetHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
 	// 정확한 인자 개수 검증
 	if len(args) != 1 {
 		return nil, &WrongNumberOfArgumentsError{Command: "get"}
@@ -162,13 +178,73 @@ func (h *GetHandler) Execute(args []string, store *store.Store) (interface{}, er
 	// store.GET은 만료 확인과 자동 삭제를 수행
 	value := store.GET(key)
 
-	// 포인터가 nil이면 키가 없거나 만료됨
+	// 슬라이스가 nil이면 키가 없거나 만료됨 (빈 문자열은 nil이 아닌 빈 슬라이스)
 	if value == nil {
 		return nil, nil // nil 반환 → Null Bulk String
 	}
 
-	// 실제 값 반환 → Bulk String
-	return *value, nil
+	// 실제 값 반환 → Bulk String (저장소의 값을 복사하지 않고 작성)
+	return protocol.BulkBytes(value), nil
+}
+
+// AppendHandler는 APPEND 명령어를 처리하는 핸들러입니다.
+//
+// APPEND 명령어의 역할:
+//   - 키의 문자열 값 끝에 value를 덧붙임
+//   - 키가 없으면 빈 문자열에 덧붙이는 것과 같음 (SET과 동일)
+//   - 키의 TTL은 유지
+//
+// Redis APPEND 명령어 사양:
+//   - APPEND key value → 덧붙인 뒤 값의 길이
+//
+// 예시:
+//
+//	SET mykey "Hello" → +OK\r\n
+//	APPEND mykey " World" → :11\r\n
+//	GET mykey → $11\r\nHello World\r\n
+//
+// 시간 복잡도: 분할 상환 O(1) (값 뒤의 남는 용량에 덧붙임)
+type AppendHandler struct{}
+
+// Execute는 APPEND 명령어를 실행합니다.
+//
+// 매개변수:
+//   - args: 명령어 인자들
+//   - args[0]: 키 이름
+//   - args[1]: 덧붙일 값
+//   - store: 데이터 저장소
+//
+// 반환값:
+//   - protocol.Integer: 덧붙인 뒤 값의 길이
+//   - error: 인자가 잘못되었거나 String이 아닌 키인 경우
+func (h *AppendHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 2 {
+		return nil, &WrongNumberOfArgumentsError{Command: "append"}
+	}
+	if err := checkStringKeyType(store, args[0]); err != nil {
+		return nil, err
+	}
+
+	return protocol.Integer(store.APPEND(args[0], argBytes(args[1]))), nil
+}
+
+// argBytes는 명령어 인자를 복사하지 않고 저장소에 넣을 []byte로 봅니다.
+// 큰 값(readBigBulk로 읽은 100MB 인자 등)을 SET할 때 같은 크기의 사본을 하나 더 만들지 않기 위함입니다.
+//
+// 인자 문자열의 바이트는 바뀌지 않으므로 안전합니다.
+//   - 파서가 인자마다 새 버퍼를 할당하고, 이후 그 버퍼를 재사용하지 않음
+//   - 저장소는 값의 길이 안쪽을 바꾸지 않고, 용량이 길이와 같으므로 APPEND는 새 배열에 덧붙임
+func argBytes(arg string) []byte {
+	return unsafe.Slice(unsafe.StringData(arg), len(arg))
+}
+
+// checkStringKeyType은 키가 존재한다면 String 타입인지 확인합니다.
+// store.GET은 List나 Sorted Set 키를 없는 키로 보므로, 값을 String으로 다루는 명령어는 읽기 전에 확인해야 합니다.
+func checkStringKeyType(store *store.Store, key string) error {
+	if keyType := store.TYPE(key); keyType != "none" && keyType != "string" {
+		return &WrongTypeError{}
+	}
+	return nil
 }
 
 // InvalidArgumentError는 명령어 인자가 잘못된 경우의 에러입니다.
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// subcommandFunc는 서브커맨드 하나의 구현입니다.
+//
+// 매개변수:
+//   - client: 명령어를 보낸 연결 (연결 없이 실행된 경우 nil)
+//   - args: 컨테이너 명령어의 인자들 (args[0]이 서브커맨드 이름)
+//   - store: 데이터 저장소
+type subcommandFunc func(client *Client, args []string, store *store.Store) (protocol.Reply, error)
+
+// subcommand는 컨테이너 명령어(CONFIG, CLIENT 등)의 서브커맨드 하나입니다.
+type subcommand struct {
+	// name은 대문자 서브커맨드 이름입니다.
+	name string
+
+	// arity는 컨테이너 명령어와 서브커맨드 이름을 포함한 인자 개수 규칙입니다 (Redis의 서브커맨드 arity와 동일).
+	// 예: CONFIG GET parameter [parameter ...]는 -3
+	arity int
+
+	// help는 HELP가 보여주는 사용법입니다. 첫 줄은 사용법이고, 나머지 줄은 들여쓰기해 설명으로 보여줍니다.
+	help []string
+
+	// run은 서브커맨드의 구현입니다. 인자 개수는 arity로 확인한 뒤 호출됩니다.
+	run subcommandFunc
+}
+
+// subcommandTable은 컨테이너 명령어의 서브커맨드 목록입니다.
+//
+// 서브커맨드 이름을 대소문자 구분 없이 찾아 arity를 확인한 뒤 실행하고,
+// HELP는 목록의 순서대로 사용법을 보여줍니다.
+//
+// 사용 예:
+//
+//	func (h *ConfigHandler) subcommands() *subcommandTable {
+//	    return &subcommandTable{command: "CONFIG", subs: []subcommand{
+//	        {"GET", -3, []string{"GET <pattern>", "..."}, h.configGet},
+//	    }}
+//	}
+type subcommandTable struct {
+	command string       // 대문자 컨테이너 명령어 이름
+	subs    []subcommand // HELP에 보여줄 순서
+}
+
+// containerCommandHandler는 서브커맨드로 이루어진 명령어의 핸들러입니다.
+// COMMAND INFO가 서브커맨드 정보를 보고하는 데 사용합니다.
+type containerCommandHandler interface {
+	CommandHandler
+	subcommands() *subcommandTable
+}
+
+// lookup은 이름으로 서브커맨드를 찾습니다 (대소문자 구분 없음). 없으면 nil을 반환합니다.
+func (t *subcommandTable) lookup(name string) *subcommand {
+	for i := range t.subs {
+		if strings.EqualFold(t.subs[i].name, name) {
+			return &t.subs[i]
+		}
+	}
+	return nil
+}
+
+// dispatch는 args[0]의 서브커맨드를 찾아 실행합니다.
+//
+// 에러 케이스:
+//   - 서브커맨드가 없는 경우: wrong number of arguments for '<command>'
+//   - 알 수 없는 서브커맨드: unknown subcommand '<name>'. Try <COMMAND> HELP.
+//   - 인자 개수가 arity에 맞지 않는 경우: wrong number of arguments for '<command>|<subcommand>'
+func (t *subcommandTable) dispatch(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) < 1 {
+		return nil, &WrongNumberOfArgumentsError{Command: strings.ToLower(t.command)}
+	}
+
+	if strings.EqualFold(args[0], "HELP") && t.lookup("HELP") == nil {
+		if len(args) != 1 {
+			return nil, &WrongNumberOfArgumentsError{Command: strings.ToLower(t.command) + "|help"}
+		}
+		return t.helpReply(), nil
+	}
+
+	sub := t.lookup(args[0])
+	if sub == nil {
+		return nil, &InvalidArgumentError{Message: "unknown subcommand '" + args[0] + "'. Try " + t.command + " HELP."}
+	}
+	if !arityMatches(sub.arity, len(args)) {
+		return nil, &WrongNumberOfArgumentsError{Command: t.fullName(sub)}
+	}
+	return sub.run(client, args, store)
+}
+
+// fullName은 서브커맨드의 Redis 형식 이름을 반환합니다 (예: "config|get").
+func (t *subcommandTable) fullName(sub *subcommand) string {
+	return strings.ToLower(t.command + "|" + sub.name)
+}
+
+// helpReply는 HELP의 응답을 만듭니다 (Redis와 같은 형식의 상태 응답 배열).
+//
+//	CONFIG <subcommand> [<arg> [value] [opt] ...]. Subcommands are:
+//	GET <pattern>
+//	    Return parameters matching the glob-like <pattern> and their values.
+//	...
+//	HELP
+//	    Print this help.
+func (t *subcommandTable) helpReply() protocol.Array {
+	lines := protocol.Array{
+		protocol.SimpleString(t.command + " <subcommand> [<arg> [value] [opt] ...]. Subcommands are:"),
+	}
+	add := func(help []string) {
+		for i, line := range help {
+			if i > 0 {
+				line = "    " + line
+			}
+			lines = append(lines, protocol.SimpleString(line))
+		}
+	}
+	for _, sub := range t.subs {
+		add(sub.help)
+	}
+	add([]string{"HELP", "Print this help."})
+	return lines
+}
+
+// infos는 COMMAND INFO가 보고하는 서브커맨드 정보들입니다.
+// 각 서브커맨드는 컨테이너 명령어의 플래그를 물려받고, 키 위치는 없습니다.
+func (t *subcommandTable) infos(flags protocol.Set) protocol.Array {
+	result := make(protocol.Array, 0, len(t.subs))
+	for i := range t.subs {
+		sub := &t.subs[i]
+		result = append(result, protocol.Array{
+			protocol.BulkString(t.fullName(sub)), protocol.Integer(sub.arity), flags,
+			protocol.Integer(0), protocol.Integer(0), protocol.Integer(0),
+			protocol.Array{}, protocol.Array{}, protocol.Array{}, protocol.Array{},
+		})
+	}
+	return result
+}
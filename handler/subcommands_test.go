@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestSubcommandDispatch는 컨테이너 명령어의 서브커맨드 검색, arity 확인, HELP를 테스트합니다.
+func TestSubcommandDispatch(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+
+	// 테스트 케이스 1: 서브커맨드 이름은 대소문자를 구분하지 않음
+	if result, err := registry.Execute("CONFIG", []string{"get", "dbfilename"}); err != nil || len(result.(protocol.Map)) != 2 {
+		t.Errorf("Expected dbfilename pair, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 2: 서브커맨드의 arity가 맞지 않으면 command|subcommand 이름으로 에러
+	tests := []struct {
+		cmd      string
+		args     []string
+		expected string
+	}{
+		{"CONFIG", []string{"GET"}, "-ERR wrong number of arguments for 'config|get' command"},
+		{"CONFIG", []string{"RESETSTAT", "extra"}, "-ERR wrong number of arguments for 'config|resetstat' command"},
+		{"MEMORY", []string{"USAGE", "a", "b"}, "-ERR wrong number of arguments for 'memory|usage' command"},
+		{"LATENCY", []string{"HISTORY"}, "-ERR wrong number of arguments for 'latency|history' command"},
+		{"CONFIG", []string{"NOSUCH"}, "-ERR unknown subcommand 'NOSUCH'. Try CONFIG HELP."},
+		{"SCRIPT", []string{"HELP", "extra"}, "-ERR wrong number of arguments for 'script|help' command"},
+	}
+	for _, tt := range tests {
+		_, err := registry.Execute(tt.cmd, tt.args)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("%s %v: expected %q, got %v", tt.cmd, tt.args, tt.expected, err)
+		}
+	}
+
+	// 테스트 케이스 3: HELP는 등록된 서브커맨드의 사용법과 HELP 자신을 나열
+	result, err := registry.Execute("config", []string{"help"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := result.(protocol.Array)
+	if first := string(lines[0].(protocol.SimpleString)); first != "CONFIG <subcommand> [<arg> [value] [opt] ...]. Subcommands are:" {
+		t.Errorf("Unexpected HELP header %q", first)
+	}
+	if second := string(lines[1].(protocol.SimpleString)); second != "GET <pattern>" {
+		t.Errorf("Expected GET usage, got %q", second)
+	}
+	if last := string(lines[len(lines)-1].(protocol.SimpleString)); last != "    Print this help." {
+		t.Errorf("Expected HELP description last, got %q", last)
+	}
+
+	// 테스트 케이스 4: COMMAND INFO가 서브커맨드들의 이름과 arity를 보고
+	info, _ := registry.Execute("COMMAND", []string{"INFO", "config"})
+	subcommands := info.(protocol.Array)[0].(protocol.Array)[9].(protocol.Array)
+	if len(subcommands) != 5 {
+		t.Fatalf("Expected 5 CONFIG subcommands, got %d", len(subcommands))
+	}
+	if get := subcommands[0].(protocol.Array); get[0] != protocol.BulkString("config|get") || get[1] != protocol.Integer(-3) {
+		t.Errorf("Expected config|get with arity -3, got %v", get)
+	}
+}
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/pubsub"
+)
+
+// invalidationChannel은 RESP2 클라이언트가 무효화 메시지를 받기 위해 구독하는 채널입니다.
+// RESP2에는 Push 타입이 없으므로, REDIRECT 대상 연결이 이 채널을 구독해야 메시지를 받습니다.
+const invalidationChannel = "__redis__:invalidate"
+
+// trackingOptions는 CLIENT TRACKING ON으로 설정한 클라이언트의 추적 옵션입니다.
+type trackingOptions struct {
+	// redirect는 무효화 메시지를 대신 받을 클라이언트 ID입니다 (0이면 자기 자신).
+	redirect int64
+	// bcast는 읽은 키와 관계없이 접두사와 매칭되는 모든 키 변경을 알리는 모드입니다.
+	bcast bool
+	// prefixes는 BCAST 모드에서 알림을 받을 키 접두사입니다 (비어 있으면 모든 키).
+	prefixes []string
+}
+
+// keyRange는 명령어 인자 중 키의 위치를 나타냅니다 (명령어 이름 제외, 0부터 시작).
+type keyRange struct {
+	first int // 첫 번째 키의 위치
+	last  int // 마지막 키의 위치 (음수이면 끝에서부터: -1은 마지막 인자, -2는 그 앞 인자)
+	step  int // 키 사이의 간격
+}
+
+// keys는 args에서 키 인자들을 골라 반환합니다. 인자가 모자라면 있는 것까지만 반환합니다.
+func (k keyRange) keys(args []string) []string {
+	last := k.last
+	if last < 0 {
+		last += len(args)
+	}
+	if last >= len(args) {
+		last = len(args) - 1
+	}
+	var keys []string
+	for i := k.first; i <= last; i += k.step {
+		keys = append(keys, args[i])
+	}
+	return keys
+}
+
+// trackedReadCommands는 키를 읽는 명령어와 그 키의 위치입니다.
+// 추적 중인 클라이언트가 이 명령어들을 실행하면 읽은 키를 기억합니다.
+var trackedReadCommands = map[string]keyRange{
+	"GET":       {0, 0, 1},
+	"LRANGE":    {0, 0, 1},
+	"LLEN":      {0, 0, 1},
+	"BITCOUNT":  {0, 0, 1},
+	"BITPOS":    {0, 0, 1},
+	"PFCOUNT":   {0, -1, 1},
+	"GEOPOS":    {0, 0, 1},
+	"GEODIST":   {0, 0, 1},
+	"GEOHASH":   {0, 0, 1},
+	"GEOSEARCH": {0, 0, 1},
+}
+
+// trackingTable은 클라이언트 측 캐싱(CLIENT TRACKING)을 위한 키 추적 테이블입니다.
+//
+// 동작 방식:
+//   - 기본 모드: 클라이언트가 읽은 키를 기억했다가, 키가 변경되면 한 번 알리고 잊음
+//   - BCAST 모드: 읽기와 관계없이 접두사와 매칭되는 키가 변경될 때마다 알림
+//
+// 키 변경은 store.OnKeyModified 훅을 통해 전달됩니다.
+type trackingTable struct {
+	mu sync.Mutex
+
+	// clients는 추적 중인 클라이언트와 그 옵션입니다.
+	clients map[*Client]trackingOptions
+	// keys는 키 → 그 키를 읽은 기본 모드 클라이언트 집합입니다.
+	keys map[string]map[*Client]struct{}
+	// prefixes는 접두사 → BCAST 모드 클라이언트 집합입니다.
+	prefixes map[string]map[*Client]struct{}
+
+	// broker는 RESP2 REDIRECT 대상이 무효화 채널을 구독 중인지 확인할 때 사용합니다.
+	broker *pubsub.Broker
+	// lookup은 REDIRECT 대상 클라이언트를 ID로 찾습니다.
+	lookup func(id int64) *Client
+}
+
+// newTrackingTable은 빈 추적 테이블을 생성합니다.
+func newTrackingTable(broker *pubsub.Broker, lookup func(id int64) *Client) *trackingTable {
+	return &trackingTable{
+		clients:  make(map[*Client]trackingOptions),
+		keys:     make(map[string]map[*Client]struct{}),
+		prefixes: make(map[string]map[*Client]struct{}),
+		broker:   broker,
+		lookup:   lookup,
+	}
+}
+
+// enable은 클라이언트의 추적을 켭니다. 이미 켜져 있으면 옵션을 새로 설정합니다.
+func (t *trackingTable) enable(client *Client, opts trackingOptions) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.removeLocked(client)
+	t.clients[client] = opts
+	if !opts.bcast {
+		return
+	}
+
+	prefixes := opts.prefixes
+	if len(prefixes) == 0 {
+		prefixes = []string{""}
+	}
+	for _, prefix := range prefixes {
+		if t.prefixes[prefix] == nil {
+			t.prefixes[prefix] = make(map[*Client]struct{})
+		}
+		t.prefixes[prefix][client] = struct{}{}
+	}
+}
+
+// disable은 클라이언트의 추적을 끄고 기억하던 키를 모두 잊습니다.
+func (t *trackingTable) disable(client *Client) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.removeLocked(client)
+}
+
+// options는 클라이언트의 추적 옵션과 추적 중인지 여부를 반환합니다.
+func (t *trackingTable) options(client *Client) (trackingOptions, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	opts, enabled := t.clients[client]
+	return opts, enabled
+}
+
+// trackRead는 추적 중인 기본 모드 클라이언트가 읽기 명령어를 실행했을 때 읽은 키를 기억합니다.
+func (t *trackingTable) trackRead(client *Client, cmd string, args []string) {
+	keys, ok := trackedReadCommands[cmd]
+	if !ok || len(args) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	opts, enabled := t.clients[client]
+	if !enabled || opts.bcast {
+		return
+	}
+
+	for _, key := range keys.keys(args) {
+		if t.keys[key] == nil {
+			t.keys[key] = make(map[*Client]struct{})
+		}
+		t.keys[key][client] = struct{}{}
+	}
+}
+
+// invalidate는 키가 변경되었을 때 관심 있는 클라이언트들에게 무효화 메시지를 보냅니다.
+// store.OnKeyModified에 등록되어 호출됩니다.
+func (t *trackingTable) invalidate(key string) {
+	t.mu.Lock()
+	targets := make(map[*Client]struct{})
+	for client := range t.keys[key] {
+		if target := t.targetLocked(client); target != nil {
+			targets[target] = struct{}{}
+		}
+	}
+	// 기본 모드는 한 번 알린 키를 잊음 (다시 읽으면 다시 기억)
+	delete(t.keys, key)
+
+	for prefix, clients := range t.prefixes {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		for client := range clients {
+			if target := t.targetLocked(client); target != nil {
+				targets[target] = struct{}{}
+			}
+		}
+	}
+	t.mu.Unlock()
+
+	for target := range targets {
+		target.sendInvalidation([]string{key}, t.broker.IsSubscribed(target, invalidationChannel))
+	}
+}
+
+// targetLocked는 클라이언트의 무효화 메시지를 받을 연결을 반환합니다.
+// REDIRECT 대상이 이미 종료되었으면 nil을 반환합니다.
+func (t *trackingTable) targetLocked(client *Client) *Client {
+	opts := t.clients[client]
+	if opts.redirect == 0 {
+		return client
+	}
+	return t.lookup(opts.redirect)
+}
+
+// removeLocked는 테이블에서 클라이언트와 관련된 모든 항목을 제거합니다.
+func (t *trackingTable) removeLocked(client *Client) {
+	if _, enabled := t.clients[client]; !enabled {
+		return
+	}
+	delete(t.clients, client)
+	removeFromIndex(t.keys, client)
+	removeFromIndex(t.prefixes, client)
+}
+
+// removeFromIndex는 이름 → 클라이언트 집합 맵에서 클라이언트를 제거하고 빈 항목을 정리합니다.
+func removeFromIndex(index map[string]map[*Client]struct{}, client *Client) {
+	for name, clients := range index {
+		delete(clients, client)
+		if len(clients) == 0 {
+			delete(index, name)
+		}
+	}
+}
+
+// sendInvalidation은 무효화 메시지를 연결에 작성합니다.
+//
+// 전송 형식:
+//   - RESP3: Push ["invalidate", [키...]]
+//   - RESP2: __redis__:invalidate 채널을 구독 중이면 ["message", "__redis__:invalidate", [키...]]
+//
+// RESP2 연결이 무효화 채널을 구독하지 않았다면 메시지를 받을 방법이 없으므로 보내지 않습니다.
+func (c *Client) sendInvalidation(keys []string, subscribed bool) {
+	c.WithWriter(func(w *protocol.Writer) {
+		if w.Protocol() >= 3 {
+			w.WriteReply(protocol.Push{protocol.BulkString("invalidate"), protocol.BulkStrings(keys)})
+			return
+		}
+		if !subscribed {
+			return
+		}
+		w.WriteReply(protocol.Push{protocol.BulkString("message"), protocol.BulkString(invalidationChannel), protocol.BulkStrings(keys)})
+	})
+}
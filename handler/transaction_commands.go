@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// queuedCommand는 MULTI 이후 EXEC까지 대기열에 쌓인 명령어입니다.
+type queuedCommand struct {
+	name string   // 대문자로 정규화된 명령어 이름
+	args []string // 명령어 인자들
+}
+
+// transactionCommands는 MULTI 중에도 대기열에 넣지 않고 즉시 실행하는 명령어 목록입니다.
+var transactionCommands = map[string]bool{
+	"MULTI":   true,
+	"EXEC":    true,
+	"DISCARD": true,
+	"QUIT":    true,
+	"RESET":   true,
+}
+
+// exclusiveCommands는 실행하는 동안 다른 클라이언트의 명령어가 끼어들면 안 되는 명령어 목록입니다.
+// 레지스트리의 실행 잠금을 배타적으로 잡고 실행됩니다.
+var exclusiveCommands = map[string]bool{
+	"EXEC":         true,
+	"EVAL":         true,
+	"EVALSHA":      true,
+	"FCALL":        true,
+	"FCALL_RO":     true,
+	"SAVE":         true,
+	"BGSAVE":       true,
+	"BGREWRITEAOF": true,
+	"PSYNC":        true,
+	"DEBUG":        true,
+}
+
+// blockingCommandHandler는 클라이언트를 대기시킬 수 있는 명령어(BLPOP 등) 핸들러입니다.
+//
+// 대기하는 명령어는 레지스트리의 실행 잠금을 잡은 채로 대기하면 안 되므로 잠금 없이 실행되고,
+// 트랜잭션이나 스크립트 안에서는 ExecuteNonBlocking으로 대기 없이 실행됩니다.
+type blockingCommandHandler interface {
+	CommandHandler
+
+	// ExecuteNonBlocking은 대기하지 않고 즉시 결과를 반환하도록 명령어를 실행합니다.
+	ExecuteNonBlocking(args []string, store *store.Store) (protocol.Reply, error)
+}
+
+// MultiHandler는 MULTI 명령어를 처리하는 핸들러입니다.
+//
+// Redis MULTI 명령어 사양:
+//   - MULTI → +OK, 이후 명령어들은 실행되지 않고 대기열에 쌓임 (+QUEUED 응답)
+//   - EXEC로 대기열의 명령어를 한 번에 실행하거나 DISCARD로 취소
+//   - MULTI 안에서 다시 MULTI를 호출하면 에러
+//
+// 예시:
+//
+//	클라이언트: MULTI
+//	서버: +OK\r\n
+//	클라이언트: SET key value
+//	서버: +QUEUED\r\n
+type MultiHandler struct{}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. MULTI는 연결 상태가 필요합니다.
+func (h *MultiHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "MULTI requires a client connection"}
+}
+
+// ExecuteWithClient는 MULTI 명령어를 실행합니다.
+func (h *MultiHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 0 {
+		return nil, &WrongNumberOfArgumentsError{Command: "multi"}
+	}
+	if client.inMulti {
+		return nil, &InvalidArgumentError{Message: "MULTI calls can not be nested"}
+	}
+
+	client.resetTransaction()
+	client.inMulti = true
+	return okReply, nil
+}
+
+// ExecHandler는 EXEC 명령어를 처리하는 핸들러입니다.
+//
+// Redis EXEC 명령어 사양:
+//   - 대기열의 명령어들을 순서대로 실행하고, 각 명령어의 응답을 배열로 반환
+//   - 실행 중에는 다른 클라이언트의 명령어가 끼어들지 않음 (원자적 실행)
+//   - MULTI 없이 호출하면 에러
+//
+// 예시:
+//
+//	클라이언트: MULTI / SET a 1 / GET a / EXEC
+//	서버: *2\r\n+OK\r\n$1\r\n1\r\n
+type ExecHandler struct {
+	registry *CommandRegistry
+}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. EXEC는 연결 상태가 필요합니다.
+func (h *ExecHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "EXEC requires a client connection"}
+}
+
+// ExecuteWithClient는 EXEC 명령어를 실행합니다.
+//
+// 에러 처리 (Redis와 동일):
+//   - 대기열에 넣는 중 오류가 있었으면 아무것도 실행하지 않고 EXECABORT
+//   - 실행 중 오류(타입 오류 등)는 해당 명령어의 응답으로만 기록되고 나머지는 계속 실행
+//
+// 반환값:
+//   - protocol.Array: 대기열 명령어들의 응답 (실패한 명령어는 해당 위치에 에러 응답)
+//   - error: MULTI 없이 호출한 경우, 트랜잭션이 실패로 표시된 경우
+func (h *ExecHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 0 {
+		return nil, &WrongNumberOfArgumentsError{Command: "exec"}
+	}
+	if !client.inMulti {
+		return nil, &InvalidArgumentError{Message: "EXEC without MULTI"}
+	}
+
+	queued, dirty := client.queued, client.multiDirty
+	client.resetTransaction()
+	if dirty {
+		return nil, &ExecAbortError{}
+	}
+
+	return h.registry.execTransaction(client, queued), nil
+}
+
+// DiscardHandler는 DISCARD 명령어를 처리하는 핸들러입니다.
+//
+// Redis DISCARD 명령어 사양:
+//   - 대기열의 명령어들을 실행하지 않고 버린 뒤 트랜잭션 종료
+//   - MULTI 없이 호출하면 에러
+type DiscardHandler struct{}
+
+// Execute는 연결 정보 없이 호출된 경우입니다. DISCARD는 연결 상태가 필요합니다.
+func (h *DiscardHandler) Execute(args []string, store *store.Store) (protocol.Reply, error) {
+	return nil, &InvalidArgumentError{Message: "DISCARD requires a client connection"}
+}
+
+// ExecuteWithClient는 DISCARD 명령어를 실행합니다.
+func (h *DiscardHandler) ExecuteWithClient(client *Client, args []string, store *store.Store) (protocol.Reply, error) {
+	if len(args) != 0 {
+		return nil, &WrongNumberOfArgumentsError{Command: "discard"}
+	}
+	if !client.inMulti {
+		return nil, &InvalidArgumentError{Message: "DISCARD without MULTI"}
+	}
+
+	client.resetTransaction()
+	return okReply, nil
+}
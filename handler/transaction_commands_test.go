@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestMultiExecHandler는 MULTI/EXEC 트랜잭션을 테스트합니다.
+func TestMultiExecHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: MULTI 이후 명령어는 대기열에 쌓임
+	result, err := registry.ExecuteForClient(client, "MULTI", []string{})
+	if err != nil {
+		t.Fatalf("MULTI failed: %v", err)
+	}
+	if !isStatus(result, "OK") {
+		t.Errorf("Expected 'OK', got %v", result)
+	}
+
+	for _, cmd := range [][]string{{"SET", "a", "1"}, {"RPUSH", "list", "x", "y"}, {"GET", "a"}} {
+		result, err = registry.ExecuteForClient(client, cmd[0], cmd[1:])
+		if err != nil {
+			t.Fatalf("%s in MULTI failed: %v", cmd[0], err)
+		}
+		if !isStatus(result, "QUEUED") {
+			t.Errorf("Expected 'QUEUED' for %s, got %v", cmd[0], result)
+		}
+	}
+
+	// 대기열의 명령어는 EXEC 전까지 실행되지 않음
+	if value, _ := registry.Execute("GET", []string{"a"}); value != nil {
+		t.Errorf("Expected nil before EXEC, got %v", value)
+	}
+
+	// 테스트 케이스 2: EXEC → 각 명령어의 응답 배열
+	result, err = registry.ExecuteForClient(client, "EXEC", []string{})
+	if err != nil {
+		t.Fatalf("EXEC failed: %v", err)
+	}
+	expected := protocol.Array{protocol.SimpleString("OK"), protocol.Integer(2), protocol.BulkBytes("1")}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// 테스트 케이스 3: EXEC 이후에는 바로 실행
+	result, _ = registry.ExecuteForClient(client, "GET", []string{"a"})
+	if !isBulk(result, "1") {
+		t.Errorf("Expected '1', got %v", result)
+	}
+
+	// 테스트 케이스 4: MULTI 없이 EXEC (에러 케이스)
+	if _, err := registry.ExecuteForClient(client, "EXEC", []string{}); err == nil {
+		t.Error("Expected error for EXEC without MULTI")
+	}
+
+	// 테스트 케이스 5: 중첩된 MULTI (에러 케이스)
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	if _, err := registry.ExecuteForClient(client, "MULTI", []string{}); err == nil {
+		t.Error("Expected error for nested MULTI")
+	}
+}
+
+// TestExecRuntimeErrors는 EXEC 안에서 실패한 명령어가 나머지 실행을 막지 않는지 테스트합니다.
+func TestExecRuntimeErrors(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	registry.ExecuteForClient(client, "GEOADD", []string{"a", "13.36", "38.11", "palermo"})
+	registry.ExecuteForClient(client, "BLPOP", []string{"empty", "0"})
+	registry.ExecuteForClient(client, "GET", []string{"a"})
+
+	result, err := registry.ExecuteForClient(client, "EXEC", []string{})
+	if err != nil {
+		t.Fatalf("EXEC failed: %v", err)
+	}
+	results := result.(protocol.Array)
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %v", results)
+	}
+
+	// 테스트 케이스 1: 타입 오류는 해당 위치에 에러로 기록
+	if reply, ok := results[1].(*protocol.ErrorReply); !ok || !strings.HasPrefix(reply.Message, "WRONGTYPE ") {
+		t.Errorf("Expected WrongTypeError, got %v", results[1])
+	}
+
+	// 테스트 케이스 2: 트랜잭션 안의 BLPOP은 대기하지 않음
+	if results[2] != (protocol.NullArray{}) {
+		t.Errorf("Expected null array for BLPOP, got %v", results[2])
+	}
+
+	// 테스트 케이스 3: 에러 이후의 명령어도 실행됨
+	if !isBulk(results[3], "1") {
+		t.Errorf("Expected '1', got %v", results[3])
+	}
+}
+
+// TestDiscardHandler는 DISCARD 명령어를 테스트합니다.
+func TestDiscardHandler(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	// 테스트 케이스 1: DISCARD → 대기열의 명령어는 실행되지 않음
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	result, err := registry.ExecuteForClient(client, "DISCARD", []string{})
+	if err != nil {
+		t.Fatalf("DISCARD failed: %v", err)
+	}
+	if !isStatus(result, "OK") {
+		t.Errorf("Expected 'OK', got %v", result)
+	}
+	if value, _ := registry.Execute("GET", []string{"a"}); value != nil {
+		t.Errorf("Expected nil after DISCARD, got %v", value)
+	}
+
+	// 테스트 케이스 2: MULTI 없이 DISCARD (에러 케이스)
+	if _, err := registry.ExecuteForClient(client, "DISCARD", []string{}); err == nil {
+		t.Error("Expected error for DISCARD without MULTI")
+	}
+}
+
+// TestExecIsAtomic은 EXEC 실행 중에 다른 클라이언트의 명령어가 끼어들지 않는지 테스트합니다.
+func TestExecIsAtomic(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+	other, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	for i := 0; i < 100; i++ {
+		registry.ExecuteForClient(client, "RPUSH", []string{"list", "tx"})
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			registry.ExecuteForClient(other, "RPUSH", []string{"list", "other"})
+		}
+	}()
+	registry.ExecuteForClient(client, "EXEC", []string{})
+	wg.Wait()
+
+	// 트랜잭션의 100개 요소는 연속으로 들어가 있어야 함
+	values, _ := registry.Execute("LRANGE", []string{"list", "0", "-1"})
+	list := values.(protocol.BulkStrings)
+	start := -1
+	for i, v := range list {
+		if v == "tx" {
+			start = i
+			break
+		}
+	}
+	for i := start; i < start+100; i++ {
+		if list[i] != "tx" {
+			t.Fatalf("Transaction was interleaved at index %d: %v", i, list)
+		}
+	}
+}
+
+// TestExecAbort는 대기열에 넣는 중 오류가 있으면 EXEC가 EXECABORT로 실패하는지 테스트합니다.
+func TestExecAbort(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  []string
+	}{
+		// 테스트 케이스 1: 알 수 없는 명령어
+		{"unknown command", []string{"NOSUCHCOMMAND", "a"}},
+		// 테스트 케이스 2: 인자 개수가 부족한 명령어
+		{"too few args", []string{"SET", "a"}},
+		// 테스트 케이스 3: 인자 개수가 정확해야 하는 명령어
+		{"wrong exact arity", []string{"GET", "a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewCommandRegistry(store.NewStore())
+			client, _ := newTestClient(registry)
+
+			registry.ExecuteForClient(client, "MULTI", []string{})
+			registry.ExecuteForClient(client, "SET", []string{"ok", "1"})
+
+			// 대기열에 넣을 때 바로 에러 반환
+			if _, err := registry.ExecuteForClient(client, tt.cmd[0], tt.cmd[1:]); err == nil {
+				t.Fatalf("Expected queue-time error for %v", tt.cmd)
+			}
+
+			// 에러 이후의 명령어는 계속 대기열에 들어감
+			result, _ := registry.ExecuteForClient(client, "SET", []string{"later", "2"})
+			if !isStatus(result, "QUEUED") {
+				t.Errorf("Expected 'QUEUED', got %v", result)
+			}
+
+			// EXEC는 EXECABORT로 실패하고 아무것도 실행하지 않음
+			_, err := registry.ExecuteForClient(client, "EXEC", []string{})
+			if _, ok := err.(*ExecAbortError); !ok {
+				t.Errorf("Expected ExecAbortError, got %v", err)
+			}
+			if value, _ := registry.Execute("GET", []string{"ok"}); value != nil {
+				t.Errorf("Expected nothing executed, got %v", value)
+			}
+
+			// 트랜잭션은 종료되어 이후 명령어는 바로 실행
+			result, _ = registry.ExecuteForClient(client, "SET", []string{"after", "3"})
+			if !isStatus(result, "OK") {
+				t.Errorf("Expected 'OK' after EXECABORT, got %v", result)
+			}
+		})
+	}
+}
+
+// TestDiscardClearsAbort는 DISCARD 후 새 트랜잭션은 이전 오류의 영향을 받지 않는지 테스트합니다.
+func TestDiscardClearsAbort(t *testing.T) {
+	registry := NewCommandRegistry(store.NewStore())
+	client, _ := newTestClient(registry)
+
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	registry.ExecuteForClient(client, "GET", []string{})
+	registry.ExecuteForClient(client, "DISCARD", []string{})
+
+	registry.ExecuteForClient(client, "MULTI", []string{})
+	registry.ExecuteForClient(client, "SET", []string{"a", "1"})
+	result, err := registry.ExecuteForClient(client, "EXEC", []string{})
+	if err != nil {
+		t.Fatalf("EXEC failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, protocol.Array{protocol.SimpleString("OK")}) {
+		t.Errorf("Expected [OK], got %v", result)
+	}
+}
@@ -0,0 +1,270 @@
+// Package hll은 Redis 호환 HyperLogLog 자료구조를 구현합니다.
+//
+// HyperLogLog는 고정된 메모리(약 12KB)로 집합의 고유 원소 개수(cardinality)를
+// 추정하는 확률적 자료구조이며, 표준 오차는 약 0.81%입니다.
+//
+// Redis와 마찬가지로 HyperLogLog는 일반 String 값(blob)으로 저장되므로
+// GET/SET으로 그대로 읽고 쓸 수 있습니다. 이 패키지는 Redis의 dense 인코딩만 사용합니다.
+//
+// Blob 레이아웃 (총 12304 바이트):
+//
+//	+------+---+-----+----------+------------------------------+
+//	| HYLL | E | N/U | Cardin.  | 16384개의 6비트 레지스터       |
+//	+------+---+-----+----------+------------------------------+
+//	 4바이트 1   3     8바이트(LE)  12288바이트
+//
+// 캐시된 cardinality의 마지막 바이트 최상위 비트가 1이면 캐시가 무효임을 의미합니다.
+package hll
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+const (
+	// precision은 레지스터 인덱스에 사용하는 해시 비트 수입니다.
+	precision = 14
+	// registers는 레지스터 개수입니다 (2^14 = 16384).
+	registers = 1 << precision
+	// registerBits는 레지스터 하나의 비트 폭입니다.
+	registerBits = 6
+	// registerMax는 레지스터가 가질 수 있는 최대값입니다.
+	registerMax = 1<<registerBits - 1
+	// hashBits는 run length 계산에 사용하는 해시 비트 수입니다 (64 - 14).
+	hashBits = 64 - precision
+
+	// headerSize는 "HYLL" 매직, 인코딩, 예약 바이트, 캐시 영역의 크기입니다.
+	headerSize = 16
+	// DenseSize는 dense 인코딩 HyperLogLog blob의 전체 크기입니다.
+	DenseSize = headerSize + (registers*registerBits+7)/8
+
+	// encodingDense는 dense 인코딩을 나타내는 헤더 값입니다.
+	encodingDense = 0
+
+	// alphaInf는 개선된 추정식에서 사용하는 상수 (1 / (2 ln 2))입니다.
+	alphaInf = 0.721347520444481703680
+
+	// hashSeed는 Redis와 동일한 MurmurHash64A 시드입니다.
+	hashSeed = 0xadc83b19
+)
+
+// New는 모든 레지스터가 0인 빈 HyperLogLog blob을 생성합니다.
+//
+// 반환값:
+//   - []byte: DenseSize 길이의 blob (캐시된 cardinality는 0으로 유효한 상태)
+func New() []byte {
+	blob := make([]byte, DenseSize)
+	copy(blob, "HYLL")
+	blob[4] = encodingDense
+	return blob
+}
+
+// IsValid는 blob이 이 패키지가 다룰 수 있는 HyperLogLog 값인지 확인합니다.
+// 매직 문자열, 인코딩, 길이를 모두 검사합니다.
+func IsValid(blob []byte) bool {
+	return len(blob) == DenseSize &&
+		string(blob[:4]) == "HYLL" &&
+		blob[4] == encodingDense
+}
+
+// Add는 원소를 HyperLogLog에 추가합니다.
+//
+// 동작 과정:
+//  1. 원소를 64비트 해시로 변환
+//  2. 하위 14비트로 레지스터 선택
+//  3. 나머지 비트에서 처음 1이 나오는 위치(run length) 계산
+//  4. 레지스터 값보다 크면 갱신하고 캐시 무효화
+//
+// 반환값:
+//   - bool: 레지스터가 변경되었으면 true (추정값이 바뀌었을 수 있음)
+func Add(blob []byte, element string) bool {
+	index, count := patternLen(element)
+	regs := blob[headerSize:]
+
+	if getRegister(regs, index) >= count {
+		return false
+	}
+
+	setRegister(regs, index, count)
+	invalidateCache(blob)
+	return true
+}
+
+// Count는 HyperLogLog의 추정 cardinality를 반환합니다.
+// 캐시가 유효하면 캐시 값을 그대로 사용하고, 아니면 다시 계산한 뒤 캐시에 기록합니다.
+//
+// 반환값:
+//   - uint64: 추정 고유 원소 개수
+//   - bool: 캐시를 새로 계산해서 blob이 변경되었으면 true
+func Count(blob []byte) (uint64, bool) {
+	if blob[15]&0x80 == 0 {
+		return binary.LittleEndian.Uint64(blob[8:16]), false
+	}
+
+	card := estimate(blob[headerSize:])
+	binary.LittleEndian.PutUint64(blob[8:16], card)
+	return card, true
+}
+
+// Merge는 src의 레지스터들을 dst에 병합합니다.
+// 각 레지스터의 최대값을 취하므로 결과는 두 집합의 합집합을 추정합니다.
+func Merge(dst, src []byte) {
+	dstRegisters := dst[headerSize:]
+	srcRegisters := src[headerSize:]
+
+	for i := 0; i < registers; i++ {
+		if v := getRegister(srcRegisters, i); v > getRegister(dstRegisters, i) {
+			setRegister(dstRegisters, i, v)
+		}
+	}
+	invalidateCache(dst)
+}
+
+// CountUnion은 여러 HyperLogLog의 합집합 cardinality를 추정합니다.
+// 원본 blob들은 변경하지 않습니다 (PFCOUNT key1 key2 ... 용).
+func CountUnion(blobs ...[]byte) uint64 {
+	merged := New()
+	for _, blob := range blobs {
+		Merge(merged, blob)
+	}
+	return estimate(merged[headerSize:])
+}
+
+// invalidateCache는 캐시된 cardinality를 무효로 표시합니다.
+func invalidateCache(blob []byte) {
+	blob[15] |= 0x80
+}
+
+// getRegister는 6비트로 압축된 레지스터 배열에서 index번째 값을 읽습니다.
+// 레지스터는 바이트 경계를 넘을 수 있으므로 두 바이트를 조합합니다.
+func getRegister(regs []byte, index int) uint8 {
+	bytePos := index * registerBits / 8
+	fb := uint(index * registerBits & 7)
+
+	b0 := uint(regs[bytePos])
+	var b1 uint
+	if bytePos+1 < len(regs) {
+		b1 = uint(regs[bytePos+1])
+	}
+
+	return uint8(((b0 >> fb) | (b1 << (8 - fb))) & registerMax)
+}
+
+// setRegister는 6비트로 압축된 레지스터 배열의 index번째 값을 설정합니다.
+func setRegister(regs []byte, index int, value uint8) {
+	bytePos := index * registerBits / 8
+	fb := uint(index * registerBits & 7)
+	v := uint(value)
+
+	regs[bytePos] &^= byte(registerMax << fb)
+	regs[bytePos] |= byte(v << fb)
+
+	if bytePos+1 < len(regs) {
+		regs[bytePos+1] &^= byte(registerMax >> (8 - fb))
+		regs[bytePos+1] |= byte(v >> (8 - fb))
+	}
+}
+
+// patternLen은 원소의 레지스터 인덱스와 run length(처음 1이 나올 때까지의 비트 수 + 1)를 계산합니다.
+func patternLen(element string) (int, uint8) {
+	hash := murmurHash64A([]byte(element), hashSeed)
+
+	index := int(hash & (registers - 1))
+	hash >>= precision
+	// 해시가 모두 0이어도 run length가 hashBits+1을 넘지 않도록 경계 비트 설정
+	hash |= 1 << hashBits
+
+	count := uint8(1)
+	for hash&1 == 0 {
+		count++
+		hash >>= 1
+	}
+	return index, count
+}
+
+// estimate는 Otmar Ertl의 개선된 추정식으로 cardinality를 계산합니다.
+// 작은 값과 큰 값 모두에서 별도의 보정(bias correction) 없이 정확한 결과를 냅니다.
+func estimate(regs []byte) uint64 {
+	var histogram [64]int
+	for i := 0; i < registers; i++ {
+		histogram[getRegister(regs, i)]++
+	}
+
+	m := float64(registers)
+	z := m * tau((m-float64(histogram[hashBits+1]))/m)
+	for j := hashBits; j >= 1; j-- {
+		z += float64(histogram[j])
+		z *= 0.5
+	}
+	z += m * sigma(float64(histogram[0])/m)
+
+	return uint64(math.Round(alphaInf * m * m / z))
+}
+
+// sigma는 추정식의 작은 cardinality 보정 항입니다.
+func sigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	y := 1.0
+	z := x
+	for {
+		x *= x
+		zPrime := z
+		z += x * y
+		y += y
+		if zPrime == z {
+			return z
+		}
+	}
+}
+
+// tau는 추정식의 큰 cardinality 보정 항입니다.
+func tau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	y := 1.0
+	z := 1 - x
+	for {
+		x = math.Sqrt(x)
+		zPrime := z
+		y *= 0.5
+		z -= math.Pow(1-x, 2) * y
+		if zPrime == z {
+			return z / 3
+		}
+	}
+}
+
+// murmurHash64A는 Redis가 HyperLogLog에 사용하는 MurmurHash2 64비트 변형입니다.
+// 같은 해시 함수를 사용해야 Redis와 동일한 레지스터 분포를 얻을 수 있습니다.
+func murmurHash64A(key []byte, seed uint64) uint64 {
+	const m = 0xc6a4a7935bd1e995
+	const r = 47
+
+	h := seed ^ (uint64(len(key)) * m)
+
+	for len(key) >= 8 {
+		k := binary.LittleEndian.Uint64(key)
+		k *= m
+		k ^= k >> r
+		k *= m
+
+		h ^= k
+		h *= m
+		key = key[8:]
+	}
+
+	if len(key) > 0 {
+		for i := len(key) - 1; i >= 0; i-- {
+			h ^= uint64(key[i]) << (8 * uint(i))
+		}
+		h *= m
+	}
+
+	h ^= h >> r
+	h *= m
+	h ^= h >> r
+	return h
+}
@@ -0,0 +1,100 @@
+package hll
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// TestNewIsValid는 새로 생성된 blob의 형식을 테스트합니다.
+func TestNewIsValid(t *testing.T) {
+	blob := New()
+	if len(blob) != DenseSize {
+		t.Fatalf("Expected size %d, got %d", DenseSize, len(blob))
+	}
+	if !IsValid(blob) {
+		t.Error("New blob should be valid")
+	}
+	if IsValid([]byte("not a hll")) {
+		t.Error("Plain string should not be valid")
+	}
+
+	count, _ := Count(blob)
+	if count != 0 {
+		t.Errorf("Expected empty count 0, got %d", count)
+	}
+}
+
+// TestRegisterRoundTrip은 6비트 레지스터 읽기/쓰기가 인접 레지스터를 훼손하지 않는지 테스트합니다.
+func TestRegisterRoundTrip(t *testing.T) {
+	regs := New()[headerSize:]
+
+	for i := 0; i < registers; i++ {
+		setRegister(regs, i, uint8(i%registerMax)+1)
+	}
+	for i := 0; i < registers; i++ {
+		if got := getRegister(regs, i); got != uint8(i%registerMax)+1 {
+			t.Fatalf("Register %d: expected %d, got %d", i, i%registerMax+1, got)
+		}
+	}
+}
+
+// TestAddAndCount는 추정값이 표준 오차 범위 안에 있는지 테스트합니다.
+func TestAddAndCount(t *testing.T) {
+	for _, n := range []int{1, 10, 1000, 100000} {
+		blob := New()
+		for i := 0; i < n; i++ {
+			Add(blob, "element:"+strconv.Itoa(i))
+		}
+
+		count, updated := Count(blob)
+		if !updated {
+			t.Errorf("n=%d: expected cache to be recomputed", n)
+		}
+
+		// 표준 오차 0.81%의 약 3배까지 허용
+		if diff := math.Abs(float64(count)-float64(n)) / float64(n); diff > 0.025 {
+			t.Errorf("n=%d: estimate %d off by %.2f%%", n, count, diff*100)
+		}
+
+		// 두 번째 호출은 캐시를 사용해야 함
+		cached, updated := Count(blob)
+		if updated || cached != count {
+			t.Errorf("n=%d: expected cached count %d, got %d (updated=%v)", n, count, cached, updated)
+		}
+	}
+}
+
+// TestAddDuplicate는 같은 원소를 다시 추가하면 변경이 없는지 테스트합니다.
+func TestAddDuplicate(t *testing.T) {
+	blob := New()
+	if !Add(blob, "a") {
+		t.Error("First add should change registers")
+	}
+	if Add(blob, "a") {
+		t.Error("Duplicate add should not change registers")
+	}
+}
+
+// TestMerge는 병합 결과가 합집합을 추정하는지 테스트합니다.
+func TestMerge(t *testing.T) {
+	a, b := New(), New()
+	for i := 0; i < 5000; i++ {
+		Add(a, "a:"+strconv.Itoa(i))
+		Add(b, "b:"+strconv.Itoa(i))
+		// 공통 원소
+		Add(a, "common:"+strconv.Itoa(i))
+		Add(b, "common:"+strconv.Itoa(i))
+	}
+
+	union := CountUnion(a, b)
+	if diff := math.Abs(float64(union)-15000) / 15000; diff > 0.025 {
+		t.Errorf("Union estimate %d off by %.2f%%", union, diff*100)
+	}
+
+	Merge(a, b)
+	merged, _ := Count(a)
+	if merged != union {
+		t.Errorf("Expected merged count %d to equal union count %d", merged, union)
+	}
+}
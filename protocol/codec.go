@@ -0,0 +1,69 @@
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strconv"
+)
+
+// AppendCommand는 명령어 하나를 RESP 배열(Bulk String 요소들)로 인코딩해 buf 뒤에 붙입니다.
+// 클라이언트가 서버에 명령어를 보내는 형식이며, AOF와 복제 스트림도 같은 형식을 사용합니다.
+//
+// 예시: ["SET", "k", "v"] → *3\r\n$3\r\nSET\r\n$1\r\nk\r\n$1\r\nv\r\n
+func AppendCommand(buf []byte, args []string) []byte {
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	return buf
+}
+
+// EncodeCommand는 명령어 하나를 RESP 배열로 인코딩합니다 (AppendCommand 참고).
+func EncodeCommand(args ...string) []byte {
+	return AppendCommand(nil, args)
+}
+
+// CommandSize는 명령어를 EncodeCommand로 인코딩했을 때의 바이트 수입니다.
+// 인코딩하지 않고 계산하므로 복제 오프셋처럼 크기만 필요한 경우에 사용합니다.
+func CommandSize(args []string) int {
+	size := 1 + len(strconv.Itoa(len(args))) + 2
+	for _, arg := range args {
+		size += 1 + len(strconv.Itoa(len(arg))) + 2 + len(arg) + 2
+	}
+	return size
+}
+
+// Marshal은 응답을 RESP2 형식으로 인코딩합니다.
+//
+// 예시: Array{BulkString("a"), Integer(1), Null{}} → *3\r\n$1\r\na\r\n:1\r\n$-1\r\n
+func Marshal(r Reply) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).WriteReply(r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal은 RESP 값 하나를 디코딩합니다.
+// 반환하는 값 타입은 Parser.Parse와 같습니다 (string, int64, []interface{}, *ErrorReply 등).
+//
+// data가 값 하나로 끝나지 않으면(뒤에 데이터가 남거나 중간에 끊기면) 에러를 반환합니다.
+func Unmarshal(data []byte) (interface{}, error) {
+	source := bytes.NewReader(data)
+	reader := bufio.NewReader(source)
+	value, err := NewParser(reader).Parse()
+	if err != nil {
+		return nil, err
+	}
+	if reader.Buffered() > 0 || source.Len() > 0 {
+		return nil, errors.New("trailing data after RESP value")
+	}
+	return value, nil
+}
@@ -0,0 +1,227 @@
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ProtocolError는 클라이언트 요청의 형식이 잘못된 경우의 에러입니다.
+//
+// 서버는 이 에러를 응답으로 보낸 뒤:
+//   - Fatal이면 연결을 종료합니다. 길이가 잘못되는 등 요청의 경계를 알 수 없어 다음 요청을 찾을 수 없는 경우입니다.
+//   - 아니면 다음 요청을 계속 읽습니다. 잘못된 요청 한 줄을 모두 읽어서 다음 요청의 시작 위치가 확실한 경우입니다
+//     (따옴표가 맞지 않는 인라인 명령어 등).
+type ProtocolError struct {
+	Message string // 구체적인 에러 메시지
+	Fatal   bool   // 연결을 종료해야 하는지 여부
+}
+
+// Error는 error 인터페이스를 구현합니다.
+//
+// Redis 에러 메시지 형식:
+//
+//	-ERR Protocol error: <메시지>
+func (e *ProtocolError) Error() string {
+	return "-ERR Protocol error: " + e.Message
+}
+
+// errUnbalancedQuotes는 인라인 명령어의 따옴표가 닫히지 않은 경우의 에러입니다.
+var errUnbalancedQuotes = &ProtocolError{Message: "unbalanced quotes in request"}
+
+// ParseRequest는 클라이언트가 보낸 요청 하나를 파싱합니다.
+//
+// 요청 형식:
+//   - RESP 배열 ('*'로 시작): 클라이언트 라이브러리가 보내는 일반적인 형식
+//   - 인라인 명령어 (그 외): netcat, telnet으로 직접 입력한 한 줄 (예: SET foo "hello world"\r\n)
+//
+// 인라인 명령어는 공백으로 인자를 나누며, 따옴표 규칙은 SplitArgs와 같습니다.
+// 빈 줄은 인자가 없는 빈 배열로 반환합니다.
+//
+// 반환값:
+//   - interface{}: 파싱된 요청 (인라인 명령어는 문자열들의 []interface{})
+//   - error: 연결 에러나 파싱 에러, 따옴표가 맞지 않으면 *ProtocolError
+func (p *Parser) ParseRequest() (interface{}, error) {
+	typeByte, err := p.reader.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+	if typeByte[0] == '*' {
+		return p.readRequestArray()
+	}
+
+	line, err := p.readLine()
+	if errors.Is(err, errLineTooLong) {
+		return nil, &ProtocolError{Message: "too big inline request", Fatal: true}
+	}
+	if err != nil {
+		return nil, err
+	}
+	args, err := SplitArgs(line)
+	if err != nil {
+		return nil, err
+	}
+	request := make([]interface{}, len(args))
+	for i, arg := range args {
+		request[i] = arg
+	}
+	return request, nil
+}
+
+// readRequestArray는 RESP 배열 형식의 요청을 파싱합니다.
+// 형식: *<인자 개수>\r\n$<길이>\r\n<인자>\r\n...
+//
+// 응답과 달리 요청의 요소는 모두 Bulk String이어야 합니다.
+// 다른 타입이면 요청의 경계를 믿을 수 없으므로 연결을 종료하는 ProtocolError를 반환합니다 (Redis와 동일).
+// 인자가 없는 배열(*0, *-1)은 빈 배열로 반환합니다.
+func (p *Parser) readRequestArray() ([]interface{}, error) {
+	if _, err := p.reader.ReadByte(); err != nil {
+		return nil, err
+	}
+	count, err := p.readCount(MaxMultibulkLen)
+	if err != nil {
+		return nil, err
+	}
+
+	request := make([]interface{}, 0, max(min(count, preallocLimit), 0))
+	for i := int64(0); i < count; i++ {
+		typeByte, err := p.reader.Peek(1)
+		if err != nil {
+			return nil, err
+		}
+		if typeByte[0] != '$' {
+			return nil, &ProtocolError{Message: fmt.Sprintf("expected '$', got '%c'", typeByte[0]), Fatal: true}
+		}
+		p.reader.ReadByte()
+		arg, err := p.readBulkString()
+		if err != nil {
+			return nil, err
+		}
+		if arg == nil {
+			return nil, &ProtocolError{Message: "invalid bulk length", Fatal: true}
+		}
+		request = append(request, arg)
+	}
+	return request, nil
+}
+
+// SplitArgs는 인라인 명령어 한 줄을 인자들로 나눕니다 (Redis의 sdssplitargs와 같은 규칙).
+//
+// 규칙:
+//   - 공백(스페이스, 탭, 줄바꿈)으로 인자를 구분
+//   - "..." 안에서는 공백이 인자에 포함되고 \n, \r, \t, \b, \a, \\, \", \xHH 이스케이프를 해석
+//   - '...' 안에서는 공백이 인자에 포함되고 \' 만 이스케이프로 해석
+//   - 닫는 따옴표 뒤에는 공백이나 줄 끝이 와야 함
+//
+// 예시:
+//
+//	SplitArgs(`SET key "hello world"`) → ["SET", "key", "hello world"]
+//	SplitArgs(`SET key 'it\'s'`) → ["SET", "key", "it's"]
+//
+// 따옴표가 맞지 않으면 *ProtocolError를 반환합니다.
+func SplitArgs(line string) ([]string, error) {
+	args := []string{}
+	i := 0
+	for {
+		for i < len(line) && isSpace(line[i]) {
+			i++
+		}
+		if i == len(line) {
+			return args, nil
+		}
+
+		var current strings.Builder
+		inDouble, inSingle := false, false
+	token:
+		for {
+			switch {
+			case inDouble:
+				switch {
+				case i == len(line):
+					return nil, errUnbalancedQuotes
+				case line[i] == '\\' && i+3 < len(line) && line[i+1] == 'x' && isHexDigit(line[i+2]) && isHexDigit(line[i+3]):
+					b, _ := strconv.ParseUint(line[i+2:i+4], 16, 8)
+					current.WriteByte(byte(b))
+					i += 3
+				case line[i] == '\\' && i+1 < len(line):
+					i++
+					current.WriteByte(unescape(line[i]))
+				case line[i] == '"':
+					// 닫는 따옴표 바로 뒤에는 공백이나 줄 끝이 와야 함
+					if i+1 < len(line) && !isSpace(line[i+1]) {
+						return nil, errUnbalancedQuotes
+					}
+					i++
+					break token
+				default:
+					current.WriteByte(line[i])
+				}
+			case inSingle:
+				switch {
+				case i == len(line):
+					return nil, errUnbalancedQuotes
+				case line[i] == '\\' && i+1 < len(line) && line[i+1] == '\'':
+					i++
+					current.WriteByte('\'')
+				case line[i] == '\'':
+					if i+1 < len(line) && !isSpace(line[i+1]) {
+						return nil, errUnbalancedQuotes
+					}
+					i++
+					break token
+				default:
+					current.WriteByte(line[i])
+				}
+			default:
+				if i == len(line) || isSpace(line[i]) {
+					break token
+				}
+				switch line[i] {
+				case '"':
+					inDouble = true
+				case '\'':
+					inSingle = true
+				default:
+					current.WriteByte(line[i])
+				}
+			}
+			i++
+		}
+		args = append(args, current.String())
+	}
+}
+
+// unescape는 큰따옴표 안의 \ 뒤에 오는 문자를 실제 문자로 바꿉니다.
+// 알 수 없는 이스케이프는 문자 그대로 사용합니다 (\" → ", \\ → \).
+func unescape(c byte) byte {
+	switch c {
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'b':
+		return '\b'
+	case 'a':
+		return '\a'
+	}
+	return c
+}
+
+// isSpace는 인라인 명령어의 인자를 구분하는 공백 문자인지 확인합니다.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// isHexDigit는 16진수 숫자인지 확인합니다.
+func isHexDigit(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+// IsProtocolError는 err가 요청 형식 에러(*ProtocolError)인지 확인합니다.
+func IsProtocolError(err error) bool {
+	var protocolErr *ProtocolError
+	return errors.As(err, &protocolErr)
+}
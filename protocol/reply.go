@@ -0,0 +1,215 @@
+package protocol
+
+import (
+	"errors"
+	"strings"
+)
+
+// Reply는 RESP 형식으로 작성되는 응답입니다.
+//
+// 명령어의 결과는 항상 이 패키지의 구체적인 응답 타입으로 표현하며, Writer는 응답 트리를 따라
+// 각 타입의 WriteRESP를 호출해 작성합니다. 같은 응답이라도 RESP 버전에 따라 다르게 작성되는
+// 타입(Map, Set, Push, Double, Null 등)은 WriteRESP가 Writer의 협상된 버전을 보고 형식을 고릅니다.
+//
+// 응답 타입과 RESP 형식:
+//   - SimpleString: +OK\r\n
+//   - BulkString: $5\r\nhello\r\n
+//   - BulkBytes: BulkString과 같음 ([]byte 값)
+//   - BulkStrings: Bulk String만 담은 Array
+//   - Integer: :42\r\n
+//   - Double: ,1.5\r\n (RESP2에서는 Bulk String)
+//   - Boolean: #t\r\n (RESP2에서는 Integer 1/0)
+//   - Null: _\r\n (RESP2에서는 Null Bulk String $-1\r\n)
+//   - NullArray: _\r\n (RESP2에서는 *-1\r\n)
+//   - Array, Map, Set, Push: 요소를 재귀적으로 작성하는 집합 타입
+//   - *ErrorReply: -ERR message\r\n
+//   - Multi: 최상위 응답 여러 개 (SUBSCRIBE a b 등)
+type Reply interface {
+	// WriteRESP는 값을 w에 RESP 형식으로 작성합니다.
+	WriteRESP(w *Writer) error
+}
+
+// SimpleString은 Simple String(+<문자열>\r\n)으로 작성되는 상태 응답입니다 (OK, PONG, QUEUED 등).
+// 줄바꿈을 포함할 수 없으므로 사용자 데이터는 BulkString으로 보내야 합니다.
+type SimpleString string
+
+// BulkString은 Bulk String($<길이>\r\n<데이터>\r\n)으로 작성되는 바이너리 안전한 문자열입니다.
+type BulkString string
+
+// BulkBytes는 []byte 값을 복사하지 않고 Bulk String으로 작성하는 응답입니다 (GET 등 저장소의 문자열 값).
+// nil도 빈 문자열로 작성하므로, 값이 없음은 Null로 나타내야 합니다.
+type BulkBytes []byte
+
+// BulkStrings는 Bulk String만 담은 Array입니다 (LRANGE, KEYS 등).
+// 요소마다 Reply로 감싸지 않으므로 []string 결과를 복사하지 않고 그대로 보낼 수 있습니다.
+type BulkStrings []string
+
+// Integer는 Integer(:<정수>\r\n)로 작성되는 응답입니다.
+type Integer int64
+
+// Double은 RESP3 Double(,<수>\r\n)로 작성되는 응답입니다.
+// RESP2 연결에서는 같은 표기의 Bulk String으로 작성됩니다 (FormatDouble).
+type Double float64
+
+// Boolean은 RESP3 Boolean(#t\r\n, #f\r\n)으로 작성되는 응답입니다.
+// RESP2 연결에서는 Integer 1 또는 0으로 작성됩니다.
+type Boolean bool
+
+// Null은 값이 없음을 나타내는 응답입니다 (GET의 없는 키 등).
+// RESP2 연결에서는 Null Bulk String($-1\r\n)으로 작성됩니다.
+type Null struct{}
+
+// NullArray는 배열이 없음을 나타내는 응답입니다 (BLPOP 시간 초과 등).
+// RESP2 연결에서는 Null Array(*-1\r\n)로 작성됩니다.
+type NullArray struct{}
+
+// Array는 요소 타입이 섞이거나 중첩된 Array입니다 (XRANGE, GEOPOS 등).
+// 요소가 nil이면 Null로 작성합니다.
+type Array []Reply
+
+// Map은 RESP3 Map(%<쌍 개수>\r\n)으로 작성되는 응답입니다.
+// 키와 값을 번갈아 나열한 목록이며, RESP2 연결에서는 같은 요소를 가진 일반 Array로 작성됩니다.
+type Map []Reply
+
+// Set은 RESP3 Set(~<요소 개수>\r\n)으로 작성되는 응답입니다 (COMMAND INFO의 플래그 등).
+// RESP2 연결에서는 같은 요소를 가진 일반 Array로 작성됩니다.
+type Set []Reply
+
+// Push는 RESP3 Push(><요소 개수>\r\n)로 작성되는 응답입니다 (Pub/Sub 메시지, 캐시 무효화 등).
+// RESP2 연결에서는 같은 요소를 가진 일반 Array로 작성됩니다.
+type Push []Reply
+
+// Multi는 하나의 명령어가 여러 개의 최상위 응답을 보내야 할 때 사용합니다.
+//
+// 예시: SUBSCRIBE a b → 채널마다 하나씩 두 개의 응답
+//
+//	*3\r\n$9\r\nsubscribe\r\n$1\r\na\r\n:1\r\n
+//	*3\r\n$9\r\nsubscribe\r\n$1\r\nb\r\n:2\r\n
+type Multi []Reply
+
+// WriteReply는 응답 하나를 작성합니다. nil은 Null로 작성합니다.
+func (w *Writer) WriteReply(r Reply) error {
+	if r == nil {
+		return w.WriteNull()
+	}
+	return r.WriteRESP(w)
+}
+
+// writeReplies는 header로 개수를 작성한 뒤 각 요소를 재귀적으로 작성합니다.
+func (w *Writer) writeReplies(header func(n int) error, elements []Reply) error {
+	if err := header(len(elements)); err != nil {
+		return err
+	}
+	for _, element := range elements {
+		if err := w.WriteReply(element); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (s SimpleString) WriteRESP(w *Writer) error {
+	return w.WriteSimpleString(string(s))
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (s BulkString) WriteRESP(w *Writer) error {
+	str := string(s)
+	return w.WriteBulkString(&str)
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (b BulkBytes) WriteRESP(w *Writer) error {
+	return w.WriteBulkBytes(b)
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (a BulkStrings) WriteRESP(w *Writer) error {
+	return w.WriteArray(a)
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (n Integer) WriteRESP(w *Writer) error {
+	return w.writeInt64(int64(n))
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (d Double) WriteRESP(w *Writer) error {
+	return w.WriteDouble(float64(d))
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (b Boolean) WriteRESP(w *Writer) error {
+	return w.WriteBoolean(bool(b))
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (Null) WriteRESP(w *Writer) error {
+	return w.WriteNull()
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (NullArray) WriteRESP(w *Writer) error {
+	return w.WriteNullArray()
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (a Array) WriteRESP(w *Writer) error {
+	return w.writeReplies(w.WriteArrayHeader, a)
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (m Map) WriteRESP(w *Writer) error {
+	if err := w.WriteMapHeader(len(m) / 2); err != nil {
+		return err
+	}
+	for _, element := range m {
+		if err := w.WriteReply(element); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (s Set) WriteRESP(w *Writer) error {
+	return w.writeReplies(w.WriteSetHeader, s)
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (p Push) WriteRESP(w *Writer) error {
+	return w.writeReplies(w.WritePushHeader, p)
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다. 응답들을 차례로 작성합니다.
+func (m Multi) WriteRESP(w *Writer) error {
+	for _, reply := range m {
+		if err := w.WriteReply(reply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteRESP는 Reply 인터페이스를 구현합니다.
+func (e *ErrorReply) WriteRESP(w *Writer) error {
+	return w.WriteError("-" + e.Message)
+}
+
+// NewError는 명령어 실행 에러를 Error 응답으로 바꿉니다.
+// 에러 응답(*ErrorReply)은 그대로 반환하고, 그 외의 에러는 Error()가 "-ERR ...", "-NOAUTH ..."처럼
+// 에러 코드를 포함하면 그대로, 포함하지 않으면 ERR 코드를 붙여 사용합니다 (Writer.WriteError와 같은 규칙).
+//
+// EXEC 결과 중 실패한 명령어처럼 배열 안에 에러를 담거나, 연결에 에러를 보낼 때 사용합니다.
+func NewError(err error) *ErrorReply {
+	var reply *ErrorReply
+	if errors.As(err, &reply) {
+		return reply
+	}
+	message := err.Error()
+	if strings.HasPrefix(message, "-") {
+		return &ErrorReply{Message: message[1:]}
+	}
+	return &ErrorReply{Message: "ERR " + message}
+}
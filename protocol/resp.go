@@ -4,17 +4,62 @@ package protocol
 
 import (
 	"bufio"   // 버퍼링된 I/O를 제공하여 효율적인 읽기/쓰기를 지원
+	"errors"  // 줄 길이 초과 에러 판별
 	"fmt"     // 포맷팅된 I/O 함수들 (에러 메시지 생성 등)
 	"io"      // 기본 I/O 인터페이스와 함수들
+	"math"    // 배열 요소 개수 상한
 	"strconv" // 문자열과 다른 타입 간의 변환 (문자열을 숫자로 변환 등)
+	"strings" // 에러 코드 추출
+	"sync"    // Bulk String 읽기 버퍼 풀
+	"unsafe"  // 큰 Bulk String을 복사 없이 문자열로 변환
 )
 
+// 프로토콜 제한값 (Redis와 동일)
+// 상대가 보낸 길이를 그대로 믿고 메모리를 할당하지 않도록 파싱 전에 검사합니다.
+const (
+	// DefaultMaxBulkLen은 Bulk String 하나의 기본 최대 길이입니다 (proto-max-bulk-len, 512MB).
+	DefaultMaxBulkLen = 512 * 1024 * 1024
+
+	// MaxMultibulkLen은 배열 하나의 최대 요소 개수입니다.
+	MaxMultibulkLen = math.MaxInt32
+
+	// MaxInlineLen은 한 줄(인라인 명령어, 길이 헤더 등)의 최대 길이입니다 (64KB).
+	MaxInlineLen = 64 * 1024
+
+	// preallocLimit은 길이만 보고 미리 할당하는 배열의 최대 크기입니다.
+	// 이보다 큰 배열은 실제로 요소가 도착하는 만큼만 메모리를 늘립니다.
+	// bulkPreallocLimit보다 큰 Bulk String은 bulkChunkSize부터 시작해 데이터가 도착하는 만큼 늘려 읽습니다 (readBigBulk).
+	preallocLimit     = 1024
+	bulkPreallocLimit = 32 * 1024
+	bulkChunkSize     = 64 * 1024
+)
+
+// errLineTooLong은 한 줄이 MaxInlineLen을 넘은 경우의 내부 에러입니다.
+// 호출하는 쪽에서 상황에 맞는 ProtocolError로 바꿉니다.
+var errLineTooLong = errors.New("line too long")
+
+// bulkBufferPool은 bulkPreallocLimit 이하의 Bulk String을 읽을 임시 버퍼(*[]byte)의 풀입니다.
+// 읽은 데이터는 문자열로 복사해 반환하므로, 버퍼는 바로 풀에 돌려놓고 다음 인자를 읽을 때 다시 씁니다.
+// 파이프라이닝으로 인자가 많이 들어와도 인자마다 버퍼를 할당하지 않습니다.
+var bulkBufferPool = sync.Pool{New: func() any { return new([]byte) }}
+
 // Parser는 RESP 프로토콜 형식의 데이터를 파싱하는 구조체입니다.
 // Redis 클라이언트로부터 받은 명령어를 해석할 때 사용됩니다.
 type Parser struct {
 	// reader는 네트워크 연결에서 데이터를 버퍼링하여 읽습니다.
 	// 버퍼링을 통해 시스템 콜 횟수를 줄여 성능을 향상시킵니다.
 	reader *bufio.Reader
+
+	// maxBulkLen은 Bulk String 하나의 최대 길이를 반환합니다 (SetMaxBulkLen, SetMaxBulkLenFunc).
+	// 요청마다 다시 읽으므로 CONFIG SET proto-max-bulk-len이 이미 연결된 클라이언트에도 적용됩니다.
+	maxBulkLen func() int64
+
+	// attributes는 마지막으로 건너뛴 Attribute의 키와 값입니다 (LastAttributes).
+	attributes []interface{}
+
+	// line은 reader의 버퍼보다 긴 줄을 이어 붙이는 버퍼입니다 (readLineBytes).
+	// 다음 줄을 읽을 때 다시 씁니다.
+	line []byte
 }
 
 // NewParser는 새로운 Parser 인스턴스를 생성합니다.
@@ -24,7 +69,22 @@ type Parser struct {
 // 반환값:
 //   - 생성된 Parser 포인터
 func NewParser(reader *bufio.Reader) *Parser {
-	return &Parser{reader: reader}
+	p := &Parser{reader: reader}
+	p.SetMaxBulkLen(DefaultMaxBulkLen)
+	return p
+}
+
+// SetMaxBulkLen은 Bulk String 하나의 최대 길이를 고정된 값으로 설정합니다 (proto-max-bulk-len).
+// 이보다 긴 Bulk String은 "invalid bulk length" ProtocolError가 됩니다.
+func (p *Parser) SetMaxBulkLen(n int64) {
+	p.maxBulkLen = func() int64 { return n }
+}
+
+// SetMaxBulkLenFunc는 Bulk String 하나의 최대 길이를 읽을 함수를 설정합니다.
+// fn은 Bulk String의 길이 헤더를 읽을 때마다 호출되므로, 설정이 바뀌면 다음 요청부터 적용됩니다.
+// 여러 연결의 고루틴에서 동시에 호출되므로 동시성에 안전해야 합니다 (atomic 값 읽기 등).
+func (p *Parser) SetMaxBulkLenFunc(fn func() int64) {
+	p.maxBulkLen = fn
 }
 
 // Parse는 RESP 프로토콜 데이터를 파싱하는 메인 함수입니다.
@@ -35,11 +95,13 @@ func NewParser(reader *bufio.Reader) *Parser {
 //   - '$': Bulk String (길이가 명시된 문자열, 예: $5\r\nhello\r\n)
 //   - '*': Array (배열, 예: *2\r\n$4\r\nPING\r\n$4\r\ntest\r\n)
 //   - ':': Integer (정수, 예: :1000\r\n)
-//   - '-': Error (에러, 예: -ERR unknown command\r\n) - 현재 미구현
+//   - '-': Error (에러 응답, 예: -ERR unknown command\r\n)
+//   - '>': Push (RESP3 서버가 먼저 보내는 메시지, 예: >3\r\n$7\r\nmessage\r\n...)
+//   - '|': Attribute (RESP3 응답 앞의 메타데이터, 건너뛰고 뒤따르는 응답을 반환)
 //
 // 반환값:
-//   - interface{}: 파싱된 데이터 (string, []interface{}, int64 등)
-//   - error: 파싱 중 발생한 에러
+//   - interface{}: 파싱된 데이터 (string, []interface{}, int64, *ErrorReply, *PushReply 등)
+//   - error: 파싱 중 발생한 에러 (데이터가 에러 응답인 경우는 에러가 아니라 *ErrorReply 값)
 func (p *Parser) Parse() (interface{}, error) {
 	// 첫 번째 바이트를 읽어서 데이터 타입을 판별합니다
 	typeByte, err := p.reader.ReadByte()
@@ -62,6 +124,22 @@ func (p *Parser) Parse() (interface{}, error) {
 	case ':':
 		// Integer: 부호있는 64비트 정수
 		return p.readInteger()
+	case '-':
+		// Error: 상대가 보낸 에러 응답 (파싱 에러와 구분되도록 값으로 반환)
+		return p.readError()
+	case '>':
+		// Push: 배열과 같은 형식이지만 명령어 응답과 구분되도록 별도 타입으로 반환
+		elements, err := p.readArray()
+		if err != nil {
+			return nil, err
+		}
+		return &PushReply{Elements: elements}, nil
+	case '|':
+		// Attribute: 키와 값 쌍을 읽어 보관하고, 뒤따르는 실제 응답을 반환
+		if err := p.readAttribute(); err != nil {
+			return nil, err
+		}
+		return p.Parse()
 	default:
 		// 알 수 없는 타입은 에러 반환
 		return nil, fmt.Errorf("unknown RESP type: %c", typeByte)
@@ -98,15 +176,19 @@ func (p *Parser) readSimpleString() (string, error) {
 //   - null 값 표현 가능 ($-1)
 func (p *Parser) readBulkString() (interface{}, error) {
 	// 첫 줄에서 문자열 길이를 읽습니다
-	line, err := p.readLine()
+	line, err := p.readLineBytes()
+	if errors.Is(err, errLineTooLong) {
+		return nil, &ProtocolError{Message: "too big bulk count string", Fatal: true}
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	// 문자열을 정수로 변환 (10진수, 64비트)
-	length, err := strconv.ParseInt(line, 10, 64)
-	if err != nil {
-		return nil, err
+	// 숫자가 아니거나 proto-max-bulk-len을 넘으면 할당하기 전에 거부
+	length, err := strconv.ParseInt(string(line), 10, 64)
+	if err != nil || length < -1 || length > p.maxBulkLen() {
+		return nil, &ProtocolError{Message: "invalid bulk length", Fatal: true}
 	}
 
 	// -1은 null bulk string을 의미 (Redis의 nil 값)
@@ -114,18 +196,81 @@ func (p *Parser) readBulkString() (interface{}, error) {
 		return nil, nil
 	}
 
-	// 지정된 길이 + 2바이트(\r\n) 만큼의 버퍼 생성
-	buf := make([]byte, length+2)
-	// 정확히 필요한 바이트 수만큼 읽기 (부분 읽기 방지)
-	_, err = io.ReadFull(p.reader, buf)
-	if err != nil {
-		return nil, err
+	// 지정된 길이 + 2바이트(\r\n) 만큼 읽기
+	if length+2 <= bulkPreallocLimit {
+		return p.readSmallBulk(int(length))
+	}
+	return p.readBigBulk(length)
+}
+
+// readSmallBulk는 bulkPreallocLimit 이하인 Bulk String의 데이터를 풀의 버퍼로 읽어 문자열로 복사합니다.
+func (p *Parser) readSmallBulk(length int) (string, error) {
+	pooled := bulkBufferPool.Get().(*[]byte)
+	defer bulkBufferPool.Put(pooled)
+	if cap(*pooled) < length+2 {
+		*pooled = make([]byte, length+2)
 	}
+	buf := (*pooled)[:length+2]
 
+	// 정확히 필요한 바이트 수만큼 읽기 (부분 읽기 방지)
+	if _, err := io.ReadFull(p.reader, buf); err != nil {
+		return "", err
+	}
+	if err := checkBulkTerminator(buf[length:]); err != nil {
+		return "", err
+	}
 	// \r\n을 제외한 실제 데이터만 반환
 	return string(buf[:length]), nil
 }
 
+// readBigBulk는 bulkPreallocLimit보다 큰 Bulk String의 데이터를 읽습니다.
+//
+// 길이 헤더만 믿고 한 번에 할당하면 길이만 보내고 멈춘 연결 하나가 proto-max-bulk-len만큼의 메모리를 잡으므로:
+//  1. bulkChunkSize 크기의 버퍼로 시작해
+//  2. 버퍼가 데이터로 가득 찰 때마다 두 배로 늘리고 (최대 길이+2까지)
+//  3. 다 읽은 버퍼는 복사 없이 문자열로 사용 (이후 버퍼를 수정하지 않으므로 안전)
+//
+// 할당한 메모리는 실제로 도착한 데이터의 두 배를 넘지 않고,
+// 반환된 문자열은 그대로 명령어 인자가 되며, SET과 APPEND는 이 버퍼를 복사하지 않고 저장소의 []byte 값으로 넣습니다.
+// 따라서 큰 값도 연결에서 저장소까지 버퍼 하나로 전달됩니다.
+func (p *Parser) readBigBulk(length int64) (string, error) {
+	total := int(length + 2)
+	buf := make([]byte, 0, min(total, bulkChunkSize))
+	for len(buf) < total {
+		if len(buf) == cap(buf) {
+			// 남은 데이터가 한 조각보다 적으면 마지막에 한 번 더 늘리지 않도록 전체 크기로 할당
+			size := 2 * cap(buf)
+			if size > total-bulkChunkSize {
+				size = total
+			}
+			grown := make([]byte, len(buf), size)
+			copy(grown, buf)
+			buf = grown
+		}
+		n, err := io.ReadFull(p.reader, buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return "", err
+		}
+	}
+	if err := checkBulkTerminator(buf[length:]); err != nil {
+		return "", err
+	}
+	return unsafe.String(&buf[0], length), nil
+}
+
+// checkBulkTerminator는 Bulk String 데이터 뒤의 두 바이트가 \r\n인지 확인합니다.
+// 길이 헤더와 실제 데이터가 맞지 않으면 이후의 요청을 해석할 수 없으므로 연결을 종료해야 합니다.
+func checkBulkTerminator(tail []byte) error {
+	if tail[0] != '\r' || tail[1] != '\n' {
+		return &ProtocolError{Message: "invalid bulk format", Fatal: true}
+	}
+	return nil
+}
+
 // readArray는 Array 타입을 파싱합니다.
 // 형식: *<요소개수>\r\n<요소1><요소2>...
 // 예시: *2\r\n$4\r\nPING\r\n$4\r\ntest\r\n → ["PING", "test"]
@@ -140,13 +285,7 @@ func (p *Parser) readBulkString() (interface{}, error) {
 //   - SET key value: *3\r\n$3\r\nSET\r\n$3\r\nkey\r\n$5\r\nvalue\r\n
 func (p *Parser) readArray() ([]interface{}, error) {
 	// 첫 줄에서 배열 요소 개수를 읽습니다
-	line, err := p.readLine()
-	if err != nil {
-		return nil, err
-	}
-
-	// 문자열을 정수로 변환
-	count, err := strconv.ParseInt(line, 10, 64)
+	count, err := p.readCount(MaxMultibulkLen)
 	if err != nil {
 		return nil, err
 	}
@@ -156,8 +295,9 @@ func (p *Parser) readArray() ([]interface{}, error) {
 		return nil, nil
 	}
 
-	// 지정된 개수만큼의 슬라이스 생성
-	result := make([]interface{}, count)
+	// 요소를 담을 슬라이스 생성
+	// 미리 할당하는 크기는 제한하고, 그보다 많은 요소는 실제로 도착하는 대로 늘림
+	result := make([]interface{}, 0, min(count, preallocLimit))
 
 	// 각 요소를 재귀적으로 파싱
 	// 배열의 요소는 어떤 RESP 타입이든 가능 (문자열, 정수, 다른 배열 등)
@@ -166,7 +306,7 @@ func (p *Parser) readArray() ([]interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
-		result[i] = value
+		result = append(result, value)
 	}
 
 	return result, nil
@@ -194,11 +334,106 @@ func (p *Parser) readInteger() (int64, error) {
 	return strconv.ParseInt(line, 10, 64)
 }
 
+// ErrorReply는 파싱한 Error 타입 데이터입니다.
+// 연결이나 형식 문제로 생기는 파싱 에러와 달리, 상대가 정상적으로 보낸 에러 응답입니다.
+// error 인터페이스를 구현하므로 응답을 그대로 에러로 반환할 수도 있습니다.
+type ErrorReply struct {
+	// Message는 - 뒤의 내용입니다 (예: "ERR unknown command 'FOO'").
+	// 첫 단어는 에러 코드입니다 (ERR, WRONGTYPE, NOAUTH 등).
+	Message string
+}
+
+// Error는 error 인터페이스를 구현합니다. 에러 응답의 내용을 그대로 반환합니다.
+func (e *ErrorReply) Error() string {
+	return e.Message
+}
+
+// Code는 에러 코드(내용의 첫 단어)를 반환합니다 (예: "ERR", "WRONGTYPE").
+func (e *ErrorReply) Code() string {
+	if i := strings.IndexByte(e.Message, ' '); i >= 0 {
+		return e.Message[:i]
+	}
+	return e.Message
+}
+
+// readError는 Error 타입을 파싱합니다.
+// 형식: -<에러 코드> <메시지>\r\n
+// 예시: -ERR unknown command 'FOO'\r\n → &ErrorReply{Message: "ERR unknown command 'FOO'"}
+//
+// Error 특징:
+//   - Simple String과 같이 한 줄이며 줄바꿈을 포함할 수 없음
+//   - 레플리카의 핸드셰이크나 MIGRATE처럼 이 서버가 다른 서버의 응답을 읽을 때 사용
+func (p *Parser) readError() (*ErrorReply, error) {
+	line, err := p.readLine()
+	if err != nil {
+		return nil, err
+	}
+	return &ErrorReply{Message: line}, nil
+}
+
+// PushReply는 파싱한 Push 타입 데이터입니다 (RESP3).
+// Pub/Sub 메시지나 캐시 무효화처럼 명령어 응답이 아닌, 상대가 먼저 보낸 메시지입니다.
+type PushReply struct {
+	// Elements는 Push의 요소들입니다 (예: ["message", "채널", "내용"]).
+	Elements []interface{}
+}
+
+// readCount는 배열 같은 집합 타입의 요소 개수 줄을 읽습니다.
+// 개수는 -1(null) 이상 limit 이하여야 하며, 그 외에는 뒤따르는 요소의 경계를 알 수 없으므로
+// 연결을 종료해야 하는 ProtocolError를 반환합니다.
+func (p *Parser) readCount(limit int64) (int64, error) {
+	line, err := p.readLineBytes()
+	if errors.Is(err, errLineTooLong) {
+		return 0, &ProtocolError{Message: "too big mbulk count string", Fatal: true}
+	}
+	if err != nil {
+		return 0, err
+	}
+	count, err := strconv.ParseInt(string(line), 10, 64)
+	if err != nil || count < -1 || count > limit {
+		return 0, &ProtocolError{Message: "invalid multibulk length", Fatal: true}
+	}
+	return count, nil
+}
+
+// readAttribute는 Attribute 타입을 파싱합니다.
+// 형식: |<쌍 개수>\r\n<키1><값1>...
+// 예시: |1\r\n+ttl\r\n:3600\r\n 뒤에 실제 응답이 옴
+//
+// Attribute는 클라이언트가 몰라도 되는 보조 정보이므로, 읽은 내용은 LastAttributes로만 제공하고
+// Parse는 뒤따르는 응답을 반환합니다 (레플리카가 마스터의 응답을 읽을 때도 그대로 건너뜀).
+func (p *Parser) readAttribute() error {
+	count, err := p.readCount(MaxMultibulkLen / 2)
+	if err != nil {
+		return err
+	}
+	if count < 0 {
+		return &ProtocolError{Message: "invalid multibulk length", Fatal: true}
+	}
+
+	pairs := make([]interface{}, 0, min(count*2, preallocLimit))
+	for i := int64(0); i < count*2; i++ {
+		value, err := p.Parse()
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, value)
+	}
+	p.attributes = pairs
+	return nil
+}
+
+// LastAttributes는 마지막으로 읽은 Attribute의 키와 값을 번갈아 나열한 목록을 반환합니다.
+// 아직 Attribute를 읽지 않았다면 nil입니다.
+func (p *Parser) LastAttributes() []interface{} {
+	return p.attributes
+}
+
 // readLine은 \r\n으로 끝나는 한 줄을 읽는 헬퍼 함수입니다.
 // RESP 프로토콜에서 모든 데이터는 \r\n(CRLF)로 구분됩니다.
 //
 // 동작 과정:
-//  1. '\n' 문자까지 읽기
+//  1. '\n' 문자까지 읽기 (MaxInlineLen을 넘으면 errLineTooLong)
 //  2. 끝에서 \r\n 제거
 //  3. 순수한 데이터만 반환
 //
@@ -207,12 +442,41 @@ func (p *Parser) readInteger() (int64, error) {
 //   - "42\r\n" → "42"
 //   - "\r\n" → ""
 func (p *Parser) readLine() (string, error) {
-	// '\n' 문자를 만날 때까지 읽습니다
-	// ReadString은 구분자를 포함하여 반환합니다
-	line, err := p.reader.ReadString('\n')
+	line, err := p.readLineBytes()
 	if err != nil {
 		return "", err
 	}
+	return string(line), nil
+}
+
+// readLineBytes는 readLine과 같지만 줄을 문자열로 복사하지 않고 바이트로 반환합니다.
+// 반환한 바이트는 reader의 버퍼나 p.line을 가리키므로 다음에 읽기 전까지만 유효합니다
+// (길이 헤더처럼 바로 숫자로 바꾸는 줄에 사용).
+func (p *Parser) readLineBytes() ([]byte, error) {
+	// '\n' 문자를 만날 때까지 읽습니다
+	// 대부분의 줄은 reader의 버퍼 안에 있으므로 복사하지 않고 그대로 사용
+	line, err := p.reader.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		// 버퍼보다 긴 줄: 버퍼 크기만큼씩 p.line에 이어 붙이면서 MaxInlineLen을 넘으면 더 읽지 않고 에러 반환
+		if len(line) > MaxInlineLen+2 {
+			return nil, errLineTooLong
+		}
+		p.line = append(p.line[:0], line...)
+		for err == bufio.ErrBufferFull {
+			line, err = p.reader.ReadSlice('\n')
+			if len(p.line)+len(line) > MaxInlineLen+2 {
+				return nil, errLineTooLong
+			}
+			p.line = append(p.line, line...)
+		}
+		line = p.line
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(line) > MaxInlineLen+2 {
+		return nil, errLineTooLong
+	}
 
 	// Windows 스타일 줄바꿈(\r\n) 처리
 	// 끝에서 두 번째 문자가 \r인지 확인
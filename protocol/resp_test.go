@@ -5,6 +5,12 @@ package protocol
 import (
 	"bufio"   // 테스트 입력을 위한 버퍼링된 리더 생성
 	"bytes"   // 테스트 출력을 위한 버퍼 생성
+	"errors"  // ProtocolError 확인
+	"fmt"     // 하위 테스트 이름 생성
+	"io"      // 출력을 버리는 Writer
+	"math"    // 무한대 값 생성
+	"runtime" // 메모리 할당량 측정
+	"strconv" // 길이 헤더 생성
 	"strings" // 문자열을 Reader로 변환
 	"testing" // Go의 표준 테스트 패키지
 )
@@ -174,6 +180,299 @@ func TestParseInteger(t *testing.T) {
 	}
 }
 
+// TestParseError는 Error 타입의 파싱을 테스트합니다.
+// 에러 응답은 파싱 에러가 아니라 *ErrorReply 값으로 반환되어야 합니다.
+func TestParseError(t *testing.T) {
+	input := "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n*2\r\n-ERR bad\r\n:1\r\n"
+	parser := NewParser(bufio.NewReader(strings.NewReader(input)))
+
+	// 테스트 케이스 1: 최상위 에러 응답
+	result, err := parser.Parse()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reply, ok := result.(*ErrorReply)
+	if !ok {
+		t.Fatalf("expected *ErrorReply, got %T", result)
+	}
+	if reply.Message != "WRONGTYPE Operation against a key holding the wrong kind of value" || reply.Code() != "WRONGTYPE" {
+		t.Errorf("unexpected error reply %q (code %q)", reply.Message, reply.Code())
+	}
+
+	// 테스트 케이스 2: 배열 안의 에러 응답 (EXEC 결과 등)
+	result, err = parser.Parse()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	array := result.([]interface{})
+	if inner, ok := array[0].(*ErrorReply); !ok || inner.Error() != "ERR bad" || array[1] != int64(1) {
+		t.Errorf("expected [ERR bad, 1], got %v", array)
+	}
+}
+
+// TestParsePushAndAttribute는 RESP3 Push와 Attribute 타입의 파싱을 테스트합니다.
+func TestParsePushAndAttribute(t *testing.T) {
+	input := ">3\r\n$7\r\nmessage\r\n$2\r\nch\r\n$2\r\nhi\r\n" +
+		"|1\r\n+ttl\r\n:3600\r\n$5\r\nvalue\r\n" +
+		"*2\r\n|1\r\n+key-popularity\r\n:1\r\n:1\r\n:2\r\n"
+	parser := NewParser(bufio.NewReader(strings.NewReader(input)))
+
+	// 테스트 케이스 1: Push는 *PushReply로 반환
+	result, err := parser.Parse()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	push, ok := result.(*PushReply)
+	if !ok || fmt.Sprint(push.Elements) != "[message ch hi]" {
+		t.Errorf("expected push [message ch hi], got %#v", result)
+	}
+
+	// 테스트 케이스 2: Attribute는 건너뛰고 뒤따르는 응답을 반환
+	result, err = parser.Parse()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != "value" {
+		t.Errorf("expected value after attribute, got %v", result)
+	}
+	if attrs := parser.LastAttributes(); fmt.Sprint(attrs) != "[ttl 3600]" {
+		t.Errorf("expected attributes [ttl 3600], got %v", attrs)
+	}
+
+	// 테스트 케이스 3: 배열 요소 앞의 Attribute도 건너뜀
+	result, err = parser.Parse()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(result) != "[1 2]" {
+		t.Errorf("expected [1 2], got %v", result)
+	}
+}
+
+// TestParseLimits는 길이 제한을 넘는 요청이 메모리를 할당하기 전에 ProtocolError로 거부되는지 테스트합니다.
+func TestParseLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		// 테스트 케이스 1: proto-max-bulk-len을 넘는 Bulk String
+		{"bulk too long", "*1\r\n$1025\r\n", "-ERR Protocol error: invalid bulk length"},
+		// 테스트 케이스 2: 숫자가 아닌 길이
+		{"bulk not a number", "*1\r\n$abc\r\n", "-ERR Protocol error: invalid bulk length"},
+		// 테스트 케이스 3: 최대 요소 개수를 넘는 배열
+		{"multibulk too long", "*4294967296\r\n", "-ERR Protocol error: invalid multibulk length"},
+		// 테스트 케이스 4: 음수 배열 길이
+		{"multibulk negative", "*-5\r\n", "-ERR Protocol error: invalid multibulk length"},
+		// 테스트 케이스 5: 64KB를 넘는 인라인 명령어
+		{"inline too long", "SET k " + strings.Repeat("v", MaxInlineLen) + "\r\n", "-ERR Protocol error: too big inline request"},
+		// 테스트 케이스 6: 64KB를 넘는 길이 헤더
+		{"count string too long", "*" + strings.Repeat("1", MaxInlineLen+1) + "\r\n", "-ERR Protocol error: too big mbulk count string"},
+	}
+	for _, tt := range tests {
+		parser := NewParser(bufio.NewReader(strings.NewReader(tt.input)))
+		parser.SetMaxBulkLen(1024)
+		_, err := parser.ParseRequest()
+		var protocolErr *ProtocolError
+		if !errors.As(err, &protocolErr) || err.Error() != tt.expected {
+			t.Errorf("%s: expected %q, got %v", tt.name, tt.expected, err)
+		} else if !protocolErr.Fatal {
+			// 요청의 경계를 알 수 없으므로 연결을 종료해야 함
+			t.Errorf("%s: expected fatal protocol error", tt.name)
+		}
+	}
+
+	// 테스트 케이스 7: 미리 할당하는 크기보다 큰 배열과 Bulk String도 정상적으로 읽음
+	value := strings.Repeat("x", 100*1024)
+	input := fmt.Sprintf("*2000\r\n%s$%d\r\n%s\r\n", strings.Repeat("$1\r\na\r\n", 1999), len(value), value)
+	result, err := NewParser(bufio.NewReader(strings.NewReader(input))).ParseRequest()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	array := result.([]interface{})
+	if len(array) != 2000 || array[0] != "a" || array[1999] != value {
+		t.Errorf("large request parsed incorrectly (%d elements)", len(array))
+	}
+
+	// 테스트 케이스 8: 데이터가 길이보다 짧게 끝나면 에러
+	_, err = NewParser(bufio.NewReader(strings.NewReader("$40000\r\nshort"))).Parse()
+	if err == nil {
+		t.Error("expected error for truncated bulk string")
+	}
+}
+
+// TestParseBigBulk는 큰 Bulk String을 값 크기만큼만 할당하여 읽는지 테스트합니다.
+func TestParseBigBulk(t *testing.T) {
+	const size = 8 * 1024 * 1024
+	input := "$" + strconv.Itoa(size) + "\r\n" + strings.Repeat("x", size) + "\r\n"
+	parser := NewParser(bufio.NewReader(strings.NewReader(input)))
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	result, err := parser.Parse()
+	runtime.ReadMemStats(&after)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 테스트 케이스 1: 값이 정확히 읽힘
+	value, ok := result.(string)
+	if !ok || len(value) != size || value[0] != 'x' || value[size-1] != 'x' {
+		t.Fatalf("big bulk string parsed incorrectly")
+	}
+
+	// 테스트 케이스 2: 버퍼를 두 배씩 늘려 읽으므로 할당량이 값 크기의 2.5배 미만
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > size*5/2 {
+		t.Errorf("expected at most %d bytes allocated, got %d", size*5/2, allocated)
+	}
+
+	// 테스트 케이스 3: 길이 헤더만 크고 데이터가 조금만 오면 도착한 만큼만 할당
+	input = "$" + strconv.Itoa(DefaultMaxBulkLen) + "\r\n" + strings.Repeat("x", 1000)
+	runtime.ReadMemStats(&before)
+	_, err = NewParser(bufio.NewReader(strings.NewReader(input))).Parse()
+	runtime.ReadMemStats(&after)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected unexpected EOF, got %v", err)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1024*1024 {
+		t.Errorf("expected a small allocation for a truncated bulk string, got %d bytes", allocated)
+	}
+}
+
+// TestParseBulkTerminator는 Bulk String 데이터 뒤에 \r\n이 없으면 연결을 종료하는 에러가 되는지 테스트합니다.
+func TestParseBulkTerminator(t *testing.T) {
+	big := strings.Repeat("x", bulkPreallocLimit*3)
+	for _, input := range []string{
+		"$3\r\nfooXY",
+		"$3\r\nfoo\n\r",
+		"$" + strconv.Itoa(len(big)) + "\r\n" + big + "ab",
+		"*2\r\n$3\r\nGET\r\n$1\r\nkey\r\n",
+	} {
+		_, err := NewParser(bufio.NewReader(strings.NewReader(input))).Parse()
+		var protocolErr *ProtocolError
+		if !errors.As(err, &protocolErr) || !protocolErr.Fatal || err.Error() != "-ERR Protocol error: invalid bulk format" {
+			t.Errorf("%.20q: expected fatal invalid bulk format error, got %v", input, err)
+		}
+	}
+}
+
+// TestParseRequest는 RESP 배열과 인라인 명령어 요청의 파싱을 테스트합니다.
+func TestParseRequest(t *testing.T) {
+	input := "PING\r\n*2\r\n$3\r\nGET\r\n$3\r\nfoo\r\n\r\n  SET foo \"hello world\"\n"
+	parser := NewParser(bufio.NewReader(strings.NewReader(input)))
+
+	expected := [][]interface{}{
+		{"PING"},                      // 테스트 케이스 1: 인라인 명령어
+		{"GET", "foo"},                // 테스트 케이스 2: RESP 배열
+		{},                            // 테스트 케이스 3: 빈 줄
+		{"SET", "foo", "hello world"}, // 테스트 케이스 4: 따옴표와 \n 줄 끝
+	}
+	for i, want := range expected {
+		result, err := parser.ParseRequest()
+		if err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i+1, err)
+		}
+		if fmt.Sprint(result) != fmt.Sprint(want) || len(result.([]interface{})) != len(want) {
+			t.Errorf("request %d: expected %v, got %v", i+1, want, result)
+		}
+	}
+
+	// 테스트 케이스 5: 따옴표가 맞지 않으면 ProtocolError, 줄을 모두 읽었으므로 다음 요청을 계속 읽을 수 있음
+	parser = NewParser(bufio.NewReader(strings.NewReader("SET foo \"bar\r\nPING\r\n")))
+	_, err := parser.ParseRequest()
+	var protocolErr *ProtocolError
+	if !errors.As(err, &protocolErr) || protocolErr.Fatal || err.Error() != "-ERR Protocol error: unbalanced quotes in request" {
+		t.Errorf("expected recoverable unbalanced quotes error, got %v", err)
+	}
+	if result, err := parser.ParseRequest(); err != nil || fmt.Sprint(result) != "[PING]" {
+		t.Errorf("expected [PING] after recoverable error, got %v, %v", result, err)
+	}
+
+	// 테스트 케이스 6: 요청 배열의 요소가 Bulk String이 아니면 연결을 종료해야 하는 ProtocolError
+	parser = NewParser(bufio.NewReader(strings.NewReader("*2\r\n$3\r\nGET\r\n:5\r\n")))
+	_, err = parser.ParseRequest()
+	if !errors.As(err, &protocolErr) || !protocolErr.Fatal || err.Error() != "-ERR Protocol error: expected '$', got ':'" {
+		t.Errorf("expected fatal expected '$' error, got %v", err)
+	}
+
+	// 테스트 케이스 7: 인자가 없는 배열은 빈 요청
+	parser = NewParser(bufio.NewReader(strings.NewReader("*0\r\n*-1\r\n")))
+	for i := 0; i < 2; i++ {
+		if result, err := parser.ParseRequest(); err != nil || len(result.([]interface{})) != 0 {
+			t.Errorf("expected empty request, got %v, %v", result, err)
+		}
+	}
+}
+
+// TestSplitArgs는 인라인 명령어의 인자 분리와 따옴표 규칙을 테스트합니다.
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected []string
+	}{
+		{`SET key value`, []string{"SET", "key", "value"}},
+		{"  GET\tkey  ", []string{"GET", "key"}},
+		{`SET key "hello world"`, []string{"SET", "key", "hello world"}},
+		{`SET key "a\nb\x41\"\\"`, []string{"SET", "key", "a\nbA\"\\"}},
+		{`SET key 'it\'s \n'`, []string{"SET", "key", `it's \n`}},
+		{`SET key ""`, []string{"SET", "key", ""}},
+		{``, []string{}},
+	}
+	for _, tt := range tests {
+		args, err := SplitArgs(tt.line)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.line, err)
+			continue
+		}
+		if fmt.Sprintf("%q", args) != fmt.Sprintf("%q", tt.expected) {
+			t.Errorf("%q: expected %q, got %q", tt.line, tt.expected, args)
+		}
+	}
+
+	// 따옴표가 닫히지 않거나 닫는 따옴표 뒤에 공백이 없으면 에러
+	for _, line := range []string{`SET key "value`, `SET key 'value`, `SET key "a"b`, `SET key 'a'b`} {
+		if _, err := SplitArgs(line); !IsProtocolError(err) {
+			t.Errorf("%q: expected protocol error, got %v", line, err)
+		}
+	}
+}
+
+// TestCodec은 Marshal/Unmarshal과 EncodeCommand의 인코딩과 디코딩을 테스트합니다.
+func TestCodec(t *testing.T) {
+	// 테스트 케이스 1: EncodeCommand와 CommandSize
+	encoded := EncodeCommand("SET", "key", "a\r\nb")
+	if string(encoded) != "*3\r\n$3\r\nSET\r\n$3\r\nkey\r\n$4\r\na\r\nb\r\n" {
+		t.Errorf("unexpected encoding %q", encoded)
+	}
+	if size := CommandSize([]string{"SET", "key", "a\r\nb"}); size != len(encoded) {
+		t.Errorf("expected size %d, got %d", len(encoded), size)
+	}
+
+	// 테스트 케이스 2: Marshal한 값을 Unmarshal하면 같은 값
+	data, err := Marshal(Array{BulkString("a"), Integer(1), Null{}, BulkStrings{"x"}})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != "*4\r\n$1\r\na\r\n:1\r\n$-1\r\n*1\r\n$1\r\nx\r\n" {
+		t.Errorf("unexpected encoding %q", data)
+	}
+	value, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if fmt.Sprint(value) != "[a 1 <nil> [x]]" {
+		t.Errorf("expected [a 1 <nil> [x]], got %v", value)
+	}
+
+	// 테스트 케이스 3: 끊긴 데이터와 남는 데이터는 에러
+	if _, err := Unmarshal(data[:len(data)-3]); err == nil {
+		t.Error("expected error for truncated data")
+	}
+	if _, err := Unmarshal(append(data, ':', '1', '\r', '\n')); err == nil {
+		t.Error("expected error for trailing data")
+	}
+}
+
 // TestWriteSimpleString은 Simple String 작성 기능을 테스트합니다.
 // 테스트 케이스: "OK" → "+OK\r\n"
 //
@@ -203,6 +502,144 @@ func TestWriteSimpleString(t *testing.T) {
 	}
 }
 
+// TestWriteError는 Error 응답 작성 기능을 테스트합니다.
+// 에러 코드가 있는 메시지는 그대로, 없는 메시지는 ERR 코드를 붙여 작성해야 합니다.
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected string
+	}{
+		// 테스트 케이스 1: 핸들러 에러 (코드 포함)
+		{"-WRONGTYPE Operation against a key holding the wrong kind of value", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		// 테스트 케이스 2: 코드가 없는 메시지
+		{"invalid request format", "-ERR invalid request format\r\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		writer := NewWriter(&buf)
+		if err := writer.WriteError(tt.message); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, buf.String())
+		}
+	}
+}
+
+// TestWriteReply는 정수, 문자열, null, 중첩 배열, 맵이 섞인 응답 트리의 작성을 테스트합니다.
+func TestWriteReply(t *testing.T) {
+	// XRANGE 형식: [[ID, [필드, 값...]], ...]와 LMPOP 형식: [키, [요소...]], null 섞인 배열
+	value := Array{
+		Array{BulkString("1-0"), BulkStrings{"field", "value"}},
+		Array{BulkString("list"), Array{Integer(7), Null{}, SimpleString("OK"), nil}},
+		Map{BulkString("a"), Boolean(true), BulkString("b"), Integer(2)},
+	}
+	tests := []struct {
+		version  int
+		expected string
+	}{
+		// 테스트 케이스 1: RESP2 (맵은 평탄화, bool은 정수, nil 요소는 Null)
+		{2, "*3\r\n*2\r\n$3\r\n1-0\r\n*2\r\n$5\r\nfield\r\n$5\r\nvalue\r\n" +
+			"*2\r\n$4\r\nlist\r\n*4\r\n:7\r\n$-1\r\n+OK\r\n$-1\r\n" +
+			"*4\r\n$1\r\na\r\n:1\r\n$1\r\nb\r\n:2\r\n"},
+		// 테스트 케이스 2: RESP3 (null과 bool은 전용 타입)
+		{3, "*3\r\n*2\r\n$3\r\n1-0\r\n*2\r\n$5\r\nfield\r\n$5\r\nvalue\r\n" +
+			"*2\r\n$4\r\nlist\r\n*4\r\n:7\r\n_\r\n+OK\r\n_\r\n" +
+			"%2\r\n$1\r\na\r\n#t\r\n$1\r\nb\r\n:2\r\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		writer := NewWriter(&buf)
+		writer.SetProtocol(tt.version)
+		if err := writer.WriteReply(value); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("RESP%d: expected %q, got %q", tt.version, tt.expected, buf.String())
+		}
+	}
+
+	// 테스트 케이스 3: 에러 응답과 여러 개의 최상위 응답
+	var buf bytes.Buffer
+	writer := NewWriter(&buf)
+	reply := Multi{
+		Array{Integer(1), NewError(errors.New("-WRONGTYPE wrong kind")), NewError(errors.New("oops"))},
+		&ErrorReply{Message: "NOAUTH Authentication required."},
+	}
+	if err := writer.WriteReply(reply); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "*3\r\n:1\r\n-WRONGTYPE wrong kind\r\n-ERR oops\r\n-NOAUTH Authentication required.\r\n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+// TestFormatDouble은 점수 등의 부동소수점 수가 Redis와 같은 표기로 변환되는지 테스트합니다.
+func TestFormatDouble(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{1.5, "1.5"},
+		{0.1, "0.1"},
+		{-3, "-3"},
+		{1000000, "1000000"}, // Go의 %g와 달리 지수 표기를 쓰지 않음
+		{123456789012345678, "1.2345678901234568e+17"}, // 17자리를 넘으면 지수 표기
+		{1e21, "1e+21"},
+		{0.0001, "0.0001"},
+		{0.00001, "1e-05"},
+		{1.0 / 3, "0.3333333333333333"},
+		{math.Inf(1), "inf"},
+		{math.Inf(-1), "-inf"},
+	}
+	for _, tt := range tests {
+		if got := FormatDouble(tt.value); got != tt.expected {
+			t.Errorf("FormatDouble(%v): expected %q, got %q", tt.value, tt.expected, got)
+		}
+	}
+}
+
+// TestWriteAttribute는 Attribute가 RESP3에서만 작성되는지 테스트합니다.
+func TestWriteAttribute(t *testing.T) {
+	for version, expected := range map[int]string{2: "", 3: "|1\r\n$3\r\nttl\r\n:3600\r\n"} {
+		var buf bytes.Buffer
+		writer := NewWriter(&buf)
+		writer.SetProtocol(version)
+		if err := writer.WriteAttribute(BulkString("ttl"), Integer(3600)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.String() != expected {
+			t.Errorf("RESP%d: expected %q, got %q", version, expected, buf.String())
+		}
+	}
+}
+
+// TestBufferedWriter는 버퍼링된 Writer가 Flush할 때만 응답을 보내는지 테스트합니다.
+func TestBufferedWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewBufferedWriter(&buf)
+
+	// 테스트 케이스 1: Flush 전에는 아무것도 보내지 않음
+	writer.WriteSimpleString("OK")
+	writer.WriteInteger(1)
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing before Flush, got %q", buf.String())
+	}
+
+	// 테스트 케이스 2: Flush하면 모인 응답을 한 번에 보냄
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "+OK\r\n:1\r\n" {
+		t.Errorf("expected %q, got %q", "+OK\r\n:1\r\n", buf.String())
+	}
+
+	// 테스트 케이스 3: 버퍼링하지 않는 Writer의 Flush는 아무것도 하지 않음
+	if err := NewWriter(&buf).Flush(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 // TestWriteBulkString은 Bulk String 작성 기능의 다양한 케이스를 테스트합니다.
 //
 // 테스트하는 케이스:
@@ -314,6 +751,169 @@ func TestWriteArray(t *testing.T) {
 	}
 }
 
+// TestSharedReplies는 미리 인코딩한 응답이 포맷한 응답과 같은 바이트이고 할당 없이 작성되는지 테스트합니다.
+func TestSharedReplies(t *testing.T) {
+	// 테스트 케이스 1: 공유 범위의 경계와 범위 밖의 정수
+	for _, n := range []int{0, 1, 9999, 10000, 10001, -1, math.MaxInt64} {
+		var buf bytes.Buffer
+		writer := NewWriter(&buf)
+		writer.WriteInteger(n)
+		writer.WriteReply(Integer(n))
+		expected := strings.Repeat(":"+strconv.Itoa(n)+"\r\n", 2)
+		if buf.String() != expected {
+			t.Errorf("%d: expected %q, got %q", n, expected, buf.String())
+		}
+	}
+
+	// 테스트 케이스 2: 상태 응답과 null
+	var buf bytes.Buffer
+	writer := NewWriter(&buf)
+	writer.WriteSimpleString("OK")
+	writer.WriteOK()
+	writer.WriteSimpleString("PONG")
+	writer.WritePONG()
+	writer.WriteNull()
+	writer.WriteNullArray()
+	writer.SetProtocol(3)
+	writer.WriteNull()
+	writer.WriteNullArray()
+	if expected := "+OK\r\n+OK\r\n+PONG\r\n+PONG\r\n$-1\r\n*-1\r\n_\r\n_\r\n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	// 테스트 케이스 3: 공유 응답은 할당 없이 작성
+	discard := NewWriter(io.Discard)
+	allocs := testing.AllocsPerRun(100, func() {
+		discard.WriteSimpleString("OK")
+		discard.WritePONG()
+		discard.WriteInteger(42)
+		discard.WriteBulkString(nil)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations for shared replies, got %v", allocs)
+	}
+}
+
+// TestWriteArrayHeader는 배열 헤더만 작성하는 기능을 테스트합니다.
+// 헤더 뒤에 서로 다른 타입의 요소를 이어서 작성하여 혼합 배열을 만들 수 있는지 확인합니다.
+func TestWriteArrayHeader(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewWriter(&buf)
+
+	// 정수와 null이 섞인 배열 작성
+	writer.WriteArrayHeader(2)
+	writer.WriteInteger(1)
+	writer.WriteBulkString(nil)
+
+	expected := "*2\r\n:1\r\n$-1\r\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+// TestWritePushHeader는 RESP 버전에 따른 Push 헤더 작성을 테스트합니다.
+func TestWritePushHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol int
+		expected string
+	}{
+		// 테스트 케이스 1: 기본값(RESP2)에서는 일반 배열로 작성
+		{"RESP2 fallback", 2, "*3\r\n"},
+		// 테스트 케이스 2: RESP3에서는 Push 타입으로 작성
+		{"RESP3 push", 3, ">3\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := NewWriter(&buf)
+			writer.SetProtocol(tt.protocol)
+
+			writer.WritePushHeader(3)
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+// TestWriteMapHeader는 RESP 버전에 따른 Map 헤더 작성을 테스트합니다.
+func TestWriteMapHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol int
+		expected string
+	}{
+		// 테스트 케이스 1: RESP2에서는 키와 값을 번갈아 나열한 배열로 작성
+		{"RESP2 fallback", 2, "*4\r\n"},
+		// 테스트 케이스 2: RESP3에서는 Map 타입으로 작성
+		{"RESP3 map", 3, "%2\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := NewWriter(&buf)
+			writer.SetProtocol(tt.protocol)
+
+			writer.WriteMapHeader(2)
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+// TestWriteRESP3Types는 RESP3 전용 타입과 RESP2 대체 형식 작성을 테스트합니다.
+func TestWriteRESP3Types(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(w *Writer) error
+		resp2 string
+		resp3 string
+	}{
+		// 테스트 케이스 1: null 값은 RESP3에서 종류를 구분하지 않음
+		{"null", func(w *Writer) error { return w.WriteNull() }, "$-1\r\n", "_\r\n"},
+		{"null bulk string", func(w *Writer) error { return w.WriteBulkString(nil) }, "$-1\r\n", "_\r\n"},
+		{"null array", func(w *Writer) error { return w.WriteNullArray() }, "*-1\r\n", "_\r\n"},
+		// 테스트 케이스 2: Set은 RESP2에서 일반 배열
+		{"set", func(w *Writer) error { return w.WriteSetHeader(2) }, "*2\r\n", "~2\r\n"},
+		// 테스트 케이스 3: Double은 RESP2에서 같은 표기의 Bulk String
+		{"double", func(w *Writer) error { return w.WriteDouble(1.5) }, "$3\r\n1.5\r\n", ",1.5\r\n"},
+		{"double integer", func(w *Writer) error { return w.WriteDouble(10) }, "$2\r\n10\r\n", ",10\r\n"},
+		{"double inf", func(w *Writer) error { return w.WriteDouble(math.Inf(-1)) }, "$4\r\n-inf\r\n", ",-inf\r\n"},
+		// 테스트 케이스 4: Boolean은 RESP2에서 1/0 Integer
+		{"true", func(w *Writer) error { return w.WriteBoolean(true) }, ":1\r\n", "#t\r\n"},
+		{"false", func(w *Writer) error { return w.WriteBoolean(false) }, ":0\r\n", "#f\r\n"},
+		// 테스트 케이스 5: Big Number는 RESP2에서 Bulk String
+		{"big number", func(w *Writer) error { return w.WriteBigNumber("12345678901234567890") }, "$20\r\n12345678901234567890\r\n", "(12345678901234567890\r\n"},
+		// 테스트 케이스 6: Verbatim String은 형식을 빼고 Bulk String
+		{"verbatim", func(w *Writer) error { return w.WriteVerbatimString("txt", "a\r\nb") }, "$4\r\na\r\nb\r\n", "=8\r\ntxt:a\r\nb\r\n"},
+	}
+
+	for _, tt := range tests {
+		for _, version := range []int{2, 3} {
+			t.Run(fmt.Sprintf("%s RESP%d", tt.name, version), func(t *testing.T) {
+				var buf bytes.Buffer
+				writer := NewWriter(&buf)
+				writer.SetProtocol(version)
+
+				if err := tt.write(writer); err != nil {
+					t.Fatalf("write failed: %v", err)
+				}
+				expected := tt.resp2
+				if version == 3 {
+					expected = tt.resp3
+				}
+				if buf.String() != expected {
+					t.Errorf("expected %q, got %q", expected, buf.String())
+				}
+			})
+		}
+	}
+}
+
 // stringPtr는 문자열의 포인터를 반환하는 헬퍼 함수입니다.
 // 테스트에서 문자열 포인터가 필요할 때 사용합니다.
 //
@@ -326,3 +926,81 @@ func TestWriteArray(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+// TestBufferPools는 풀의 버퍼를 다시 써도 앞서 반환한 값이 바뀌지 않고, 버퍼보다 긴 값도 그대로 읽고 쓰는지 테스트합니다.
+func TestBufferPools(t *testing.T) {
+	long := strings.Repeat("x", maxScratchSize+1)
+	inline := "ECHO " + strings.Repeat("y", 10000)
+
+	// 테스트 케이스 1: 파싱한 인자는 다음 인자를 읽은 뒤에도 그대로 (reader 버퍼보다 긴 인라인 명령어 포함)
+	input := string(EncodeCommand("SET", "a", "first")) + string(EncodeCommand("SET", "b", "second")) + inline + "\r\n"
+	parser := NewParser(bufio.NewReader(strings.NewReader(input)))
+	var requests []interface{}
+	for i := 0; i < 3; i++ {
+		request, err := parser.ParseRequest()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		requests = append(requests, request)
+	}
+	if fmt.Sprint(requests[:2]) != "[[SET a first] [SET b second]]" {
+		t.Errorf("expected both requests intact, got %v", requests[:2])
+	}
+	if args := requests[2].([]interface{}); len(args) != 2 || args[1] != strings.Repeat("y", 10000) {
+		t.Errorf("expected the long inline argument, got %d arguments", len(args))
+	}
+
+	// 테스트 케이스 2: 풀에 돌려놓지 않는 큰 Bulk String은 헤더와 데이터를 따로 작성
+	var buf bytes.Buffer
+	writer := NewWriter(&buf)
+	writer.WriteBulkString(&long)
+	writer.WriteBulkString(stringPtr("short"))
+	if expected := "$" + strconv.Itoa(len(long)) + "\r\n" + long + "\r\n$5\r\nshort\r\n"; buf.String() != expected {
+		t.Errorf("expected the long value followed by short, got %d bytes", buf.Len())
+	}
+}
+
+// pipelinedRequests는 파이프라이닝한 SET 요청 100개입니다 (값은 64바이트).
+var pipelinedRequests = func() []byte {
+	var buf []byte
+	value := strings.Repeat("v", 64)
+	for i := 0; i < 100; i++ {
+		buf = AppendCommand(buf, []string{"SET", "key:" + strconv.Itoa(i), value})
+	}
+	return buf
+}()
+
+// BenchmarkParseRequest는 파이프라이닝한 요청을 연속으로 파싱하는 성능을 측정합니다 (한 번에 요청 100개).
+func BenchmarkParseRequest(b *testing.B) {
+	source := bytes.NewReader(pipelinedRequests)
+	reader := bufio.NewReader(source)
+	parser := NewParser(reader)
+	b.SetBytes(int64(len(pipelinedRequests)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		source.Reset(pipelinedRequests)
+		reader.Reset(source)
+		for j := 0; j < 100; j++ {
+			if _, err := parser.ParseRequest(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkWriteReplies는 자주 쓰는 응답들을 버퍼링하는 Writer에 작성하는 성능을 측정합니다.
+func BenchmarkWriteReplies(b *testing.B) {
+	writer := NewBufferedWriter(io.Discard)
+	value := strings.Repeat("v", 64)
+	elements := []string{"a", "bb", value}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		writer.WriteBulkString(&value)
+		writer.WriteInteger(123456)
+		writer.WriteReply(Integer(-7))
+		writer.WriteSimpleString("QUEUED")
+		writer.WriteArray(elements)
+		writer.WriteError("-ERR unknown command")
+	}
+	writer.Flush()
+}
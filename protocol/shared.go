@@ -0,0 +1,42 @@
+package protocol
+
+import "strconv"
+
+// 자주 쓰는 응답을 미리 인코딩한 바이트입니다 (Redis의 shared.ok, shared.pong, shared.null 등).
+// SET의 +OK, GET의 $-1처럼 거의 모든 요청이 작성하는 응답은 매번 포맷하지 않고 이 바이트를 그대로 씁니다.
+// 모든 Writer가 공유하므로 수정하면 안 됩니다.
+var (
+	sharedOK        = []byte("+OK\r\n")
+	sharedPONG      = []byte("+PONG\r\n")
+	sharedNullBulk  = []byte("$-1\r\n")
+	sharedNull      = []byte("_\r\n")
+	sharedNullArray = []byte("*-1\r\n")
+	sharedCRLF      = []byte("\r\n")
+)
+
+// sharedIntegerCount는 미리 인코딩해 두는 Integer 응답의 개수입니다 (:0부터 :10000까지).
+// LLEN, INCR, DEL처럼 작은 수를 반환하는 명령어가 많으므로 Redis의 OBJ_SHARED_INTEGERS와 같은 범위를 사용합니다.
+const sharedIntegerCount = 10001
+
+// sharedIntegers는 :0\r\n부터 :10000\r\n까지 미리 인코딩한 Integer 응답입니다.
+// 모두 하나의 배열을 나눠 쓰며, 각 조각의 용량을 길이로 제한해 append가 이웃 조각을 덮어쓰지 않게 합니다.
+var sharedIntegers = func() [][]byte {
+	buf := make([]byte, 0, sharedIntegerCount*len(":10000\r\n"))
+	integers := make([][]byte, sharedIntegerCount)
+	for i := range integers {
+		start := len(buf)
+		buf = append(buf, ':')
+		buf = strconv.AppendInt(buf, int64(i), 10)
+		buf = append(buf, '\r', '\n')
+		integers[i] = buf[start:len(buf):len(buf)]
+	}
+	return integers
+}()
+
+// sharedInteger는 n을 미리 인코딩한 Integer 응답을 반환합니다. 범위 밖이면 nil을 반환합니다.
+func sharedInteger(n int64) []byte {
+	if n < 0 || n >= sharedIntegerCount {
+		return nil
+	}
+	return sharedIntegers[n]
+}
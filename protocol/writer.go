@@ -2,16 +2,41 @@
 package protocol
 
 import (
-	"fmt" // 포맷팅된 문자열 생성을 위해 사용 (Sprintf 등)
-	"io"  // Writer 인터페이스를 위해 사용
+	"bufio"   // 여러 응답을 모아서 한 번에 보내기 위한 버퍼
+	"io"      // Writer 인터페이스를 위해 사용
+	"math"    // Double의 무한대, NaN 판별
+	"strconv" // Double을 문자열로 변환
+	"strings" // 에러 코드 접두사 확인
+	"sync"    // 응답 인코딩 버퍼 풀
 )
 
+// scratchPool은 응답 하나를 인코딩할 임시 버퍼(*[]byte)의 풀입니다.
+// 응답을 버퍼에 인코딩해 한 번에 작성한 뒤 버퍼를 풀에 돌려놓으므로,
+// 파이프라이닝으로 응답을 많이 작성해도 응답마다 문자열이나 바이트 슬라이스를 할당하지 않습니다.
+var scratchPool = sync.Pool{New: func() any {
+	buf := make([]byte, 0, 512)
+	return &buf
+}}
+
+// maxScratchSize는 풀에 돌려놓는 버퍼의 최대 크기입니다.
+// 큰 값을 작성하느라 커진 버퍼를 계속 붙잡고 있지 않도록 이보다 커진 버퍼는 버립니다.
+// 이보다 큰 Bulk String은 버퍼에 복사하지 않고 헤더와 데이터를 따로 작성합니다.
+const maxScratchSize = 64 * 1024
+
 // Writer는 RESP 프로토콜 형식으로 데이터를 작성하는 구조체입니다.
 // Redis 클라이언트에게 응답을 보낼 때 사용됩니다.
 type Writer struct {
 	// writer는 실제 데이터를 쓰는 인터페이스
 	// 주로 net.Conn(네트워크 연결)이나 bytes.Buffer(테스트용)가 사용됨
 	writer io.Writer
+
+	// buffered는 NewBufferedWriter로 만든 경우의 버퍼입니다 (없으면 nil).
+	// 작성한 응답은 Flush를 호출할 때 연결로 보내집니다.
+	buffered *bufio.Writer
+
+	// protocol은 클라이언트와 협상된 RESP 버전입니다 (2 또는 3).
+	// RESP3 전용 타입은 RESP2 연결에서 대응되는 RESP2 타입으로 작성됩니다.
+	protocol int
 }
 
 // NewWriter는 새로운 Writer 인스턴스를 생성합니다.
@@ -21,7 +46,39 @@ type Writer struct {
 // 반환값:
 //   - 생성된 Writer 포인터
 func NewWriter(w io.Writer) *Writer {
-	return &Writer{writer: w}
+	return &Writer{writer: w, protocol: 2}
+}
+
+// NewBufferedWriter는 작성한 응답을 버퍼에 모았다가 Flush할 때 보내는 Writer를 생성합니다.
+//
+// 파이프라이닝: 클라이언트가 여러 명령어를 한 번에 보내면 응답 조각마다 시스템 콜을 하지 않고
+// 모든 응답을 모아서 한 번에 보낼 수 있습니다.
+//
+// 매개변수:
+//   - w: 데이터를 쓸 io.Writer (주로 TCP 연결)
+func NewBufferedWriter(w io.Writer) *Writer {
+	buffered := bufio.NewWriter(w)
+	return &Writer{writer: buffered, buffered: buffered, protocol: 2}
+}
+
+// Flush는 버퍼에 모인 응답을 연결로 보냅니다.
+// NewWriter로 만든 Writer는 작성할 때 바로 보내므로 아무것도 하지 않습니다.
+func (w *Writer) Flush() error {
+	if w.buffered == nil {
+		return nil
+	}
+	return w.buffered.Flush()
+}
+
+// SetProtocol은 이후 응답에 사용할 RESP 버전을 설정합니다.
+// 새 연결은 RESP2로 시작하며, HELLO 3으로 RESP3를 협상한 경우 3으로 바뀝니다.
+func (w *Writer) SetProtocol(version int) {
+	w.protocol = version
+}
+
+// Protocol은 현재 사용 중인 RESP 버전을 반환합니다.
+func (w *Writer) Protocol() int {
+	return w.protocol
 }
 
 // WriteSimpleString은 Simple String 형식으로 문자열을 작성합니다.
@@ -35,10 +92,41 @@ func NewWriter(w io.Writer) *Writer {
 // 주의사항:
 //   - 문자열에 \r이나 \n이 포함되면 안 됨 (단순 문자열만 가능)
 //   - 바이너리 안전하지 않음
+//
+// OK와 PONG은 미리 인코딩한 바이트를 그대로 씁니다.
 func (w *Writer) WriteSimpleString(s string) error {
+	switch s {
+	case "OK":
+		return w.writeShared(sharedOK)
+	case "PONG":
+		return w.writeShared(sharedPONG)
+	}
+
 	// + 시작 문자와 \r\n 종료 문자를 추가하여 작성
-	_, err := w.writer.Write([]byte(fmt.Sprintf("+%s\r\n", s)))
-	return err
+	return w.writeScratch(func(buf []byte) []byte {
+		return appendLine(buf, '+', s)
+	})
+}
+
+// WriteError는 Error 형식으로 에러 응답을 작성합니다.
+// 형식: -<에러 코드> <메시지>\r\n
+// 예시:
+//   - "-WRONGTYPE Operation against a key holding the wrong kind of value" → 그대로 작성
+//   - "invalid request format" → "-ERR invalid request format\r\n"
+//
+// 핸들러의 에러 타입은 Error()가 "-ERR ...", "-NOAUTH ..."처럼 에러 코드를 포함하므로 그대로 씁니다.
+// '-'로 시작하지 않는 메시지는 에러 코드가 없는 것으로 보고 ERR 코드를 붙입니다 (Redis의 addReplyError와 동일).
+//
+// 주의사항:
+//   - 메시지에 \r이나 \n이 포함되면 안 됨 (Simple String과 같은 제약)
+func (w *Writer) WriteError(message string) error {
+	return w.writeScratch(func(buf []byte) []byte {
+		if !strings.HasPrefix(message, "-") {
+			buf = append(buf, "-ERR "...)
+		}
+		buf = append(buf, message...)
+		return append(buf, '\r', '\n')
+	})
 }
 
 // WriteBulkString은 Bulk String 형식으로 문자열을 작성합니다.
@@ -57,17 +145,49 @@ func (w *Writer) WriteSimpleString(s string) error {
 //   - s: 문자열 포인터 (nil일 수 있음)
 //     nil은 Redis의 null 값을 표현 (예: 키가 없을 때)
 func (w *Writer) WriteBulkString(s *string) error {
-	// nil 처리: Redis의 null bulk string
+	// nil 처리: Redis의 null bulk string (RESP3에서는 Null 타입)
 	if s == nil {
-		// $-1\r\n은 null을 나타내는 특별한 형식
-		_, err := w.writer.Write([]byte("$-1\r\n"))
-		return err
+		return w.WriteNull()
 	}
 
 	// 정상 문자열: 길이를 먼저 보내고 데이터를 보냄
 	// 길이는 바이트 수 기준 (UTF-8 문자열의 경우 len()이 바이트 수 반환)
-	_, err := w.writer.Write([]byte(fmt.Sprintf("$%d\r\n%s\r\n", len(*s), *s)))
-	return err
+	if len(*s) > maxScratchSize {
+		// 큰 값은 버퍼에 복사하지 않고 그대로 작성
+		if err := w.writeHeader('$', len(*s)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w.writer, *s); err != nil {
+			return err
+		}
+		return w.writeShared(sharedCRLF)
+	}
+	return w.writeScratch(func(buf []byte) []byte {
+		buf = appendHeader(buf, '$', int64(len(*s)))
+		buf = append(buf, *s...)
+		return append(buf, '\r', '\n')
+	})
+}
+
+// WriteBulkBytes는 바이트열을 Bulk String 형식으로 작성합니다 (WriteBulkString 참고).
+// 저장소의 문자열 값처럼 []byte로 보관된 값을 string으로 복사하지 않고 보낼 때 사용합니다.
+// nil은 빈 문자열로 작성하므로, null은 WriteNull로 따로 보내야 합니다.
+func (w *Writer) WriteBulkBytes(b []byte) error {
+	if len(b) > maxScratchSize {
+		// 큰 값은 버퍼에 복사하지 않고 그대로 작성
+		if err := w.writeHeader('$', len(b)); err != nil {
+			return err
+		}
+		if _, err := w.writer.Write(b); err != nil {
+			return err
+		}
+		return w.writeShared(sharedCRLF)
+	}
+	return w.writeScratch(func(buf []byte) []byte {
+		buf = appendHeader(buf, '$', int64(len(b)))
+		buf = append(buf, b...)
+		return append(buf, '\r', '\n')
+	})
 }
 
 // WriteInteger는 Integer 형식으로 정수를 작성합니다.
@@ -83,14 +203,66 @@ func (w *Writer) WriteBulkString(s *string) error {
 //   - INCR의 반환값 (증가된 값)
 //   - 성공/실패 코드 (1/0/-1 등)
 //
+// 0부터 10000까지는 미리 인코딩한 바이트를 그대로 씁니다 (sharedIntegers).
+//
 // 매개변수:
 //   - n: 작성할 정수값
 func (w *Writer) WriteInteger(n int) error {
+	return w.writeInt64(int64(n))
+}
+
+// writeInt64는 int64 값을 Integer 형식으로 작성합니다 (WriteInteger, Integer).
+func (w *Writer) writeInt64(n int64) error {
+	if shared := sharedInteger(n); shared != nil {
+		return w.writeShared(shared)
+	}
+
 	// : 시작 문자와 \r\n 종료 문자를 추가하여 작성
-	_, err := w.writer.Write([]byte(fmt.Sprintf(":%d\r\n", n)))
+	return w.writeScratch(func(buf []byte) []byte {
+		return appendHeader(buf, ':', n)
+	})
+}
+
+// writeShared는 미리 인코딩한 응답(shared.go)을 그대로 작성합니다.
+func (w *Writer) writeShared(reply []byte) error {
+	_, err := w.writer.Write(reply)
+	return err
+}
+
+// writeScratch는 encode가 풀의 버퍼(scratchPool)에 인코딩한 응답을 한 번에 작성합니다.
+// encode는 받은 빈 버퍼 뒤에 응답을 붙여 반환해야 합니다.
+func (w *Writer) writeScratch(encode func(buf []byte) []byte) error {
+	pooled := scratchPool.Get().(*[]byte)
+	buf := encode((*pooled)[:0])
+	_, err := w.writer.Write(buf)
+	if cap(buf) <= maxScratchSize {
+		*pooled = buf
+		scratchPool.Put(pooled)
+	}
 	return err
 }
 
+// writeHeader는 <prefix><n>\r\n 형식의 헤더를 작성합니다 (배열 크기, Bulk String 길이 등).
+func (w *Writer) writeHeader(prefix byte, n int) error {
+	return w.writeScratch(func(buf []byte) []byte {
+		return appendHeader(buf, prefix, int64(n))
+	})
+}
+
+// appendHeader는 <prefix><n>\r\n을 buf 뒤에 붙입니다.
+func appendHeader(buf []byte, prefix byte, n int64) []byte {
+	buf = append(buf, prefix)
+	buf = strconv.AppendInt(buf, n, 10)
+	return append(buf, '\r', '\n')
+}
+
+// appendLine은 <prefix><s>\r\n을 buf 뒤에 붙입니다.
+func appendLine(buf []byte, prefix byte, s string) []byte {
+	buf = append(buf, prefix)
+	buf = append(buf, s...)
+	return append(buf, '\r', '\n')
+}
+
 // WriteArray는 Array 형식으로 문자열 배열을 작성합니다.
 // 형식: *<요소개수>\r\n<요소1><요소2>...
 // 예시: ["PING", "test"] → "*2\r\n$4\r\nPING\r\n$4\r\ntest\r\n"
@@ -108,8 +280,7 @@ func (w *Writer) WriteInteger(n int) error {
 //   - arr: 작성할 문자열 배열
 func (w *Writer) WriteArray(arr []string) error {
 	// 먼저 배열 크기를 명시 (*<개수>\r\n)
-	_, err := w.writer.Write([]byte(fmt.Sprintf("*%d\r\n", len(arr))))
-	if err != nil {
+	if err := w.writeHeader('*', len(arr)); err != nil {
 		return err
 	}
 
@@ -123,15 +294,261 @@ func (w *Writer) WriteArray(arr []string) error {
 	return nil
 }
 
-func (w *Writer) WriteNullArray() error {
-	_, err := w.writer.Write([]byte(fmt.Sprintf("*-1\r\n")))
-	if err != nil {
-		return err
+// WriteArrayHeader는 배열의 헤더(*<요소개수>\r\n)만 작성합니다.
+// 요소들은 호출자가 이어서 직접 작성해야 합니다.
+//
+// 사용 예:
+//   - 정수, 문자열, null이 섞인 배열 (GEOPOS 등)
+//   - 중첩 배열 (GEOSEARCH WITHCOORD 등)
+//
+// 매개변수:
+//   - n: 뒤따를 요소 개수
+func (w *Writer) WriteArrayHeader(n int) error {
+	return w.writeHeader('*', n)
+}
+
+// WritePushHeader는 Push 메시지의 헤더를 작성합니다.
+// 형식:
+//   - RESP3: ><요소개수>\r\n
+//   - RESP2: *<요소개수>\r\n (Push 타입이 없으므로 일반 배열로 대체)
+//
+// Push는 명령어 응답이 아닌 서버가 먼저 보내는 메시지(out-of-band)입니다.
+// RESP3 클라이언트는 Push를 일반 응답과 구분할 수 있으므로,
+// Pub/Sub 구독 중에도 같은 연결로 일반 명령어를 계속 보낼 수 있습니다.
+//
+// 사용 예:
+//   - Pub/Sub 메시지 (message, pmessage, smessage)
+//   - 클라이언트 캐시 무효화 메시지 (invalidate)
+//
+// 매개변수:
+//   - n: 뒤따를 요소 개수
+func (w *Writer) WritePushHeader(n int) error {
+	prefix := byte('*')
+	if w.protocol >= 3 {
+		prefix = '>'
 	}
+	return w.writeHeader(prefix, n)
+}
 
+// WriteAttribute는 뒤따르는 응답에 붙는 메타데이터(Attribute)를 작성합니다.
+// 형식:
+//   - RESP3: |<쌍 개수>\r\n<키1><값1>...
+//   - RESP2: 아무것도 작성하지 않음 (Attribute 타입이 없으며, 클라이언트는 응답만 읽음)
+//
+// Attribute는 응답 바로 앞에 오며, 클라이언트가 모르면 무시해도 되는 보조 정보입니다.
+//
+// 매개변수:
+//   - pairs: 키와 값을 번갈아 나열한 목록
+func (w *Writer) WriteAttribute(pairs ...Reply) error {
+	if w.protocol < 3 {
+		return nil
+	}
+	if err := w.writeHeader('|', len(pairs)/2); err != nil {
+		return err
+	}
+	for _, element := range pairs {
+		if err := w.WriteReply(element); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// WriteMapHeader는 Map의 헤더를 작성합니다.
+// 형식:
+//   - RESP3: %<쌍 개수>\r\n
+//   - RESP2: *<쌍 개수 × 2>\r\n (Map 타입이 없으므로 키와 값을 번갈아 나열한 배열로 대체)
+//
+// 헤더 뒤에는 키와 값을 번갈아 2n개 작성해야 합니다.
+//
+// 사용 예:
+//   - HELLO의 서버 정보
+//
+// 매개변수:
+//   - n: 뒤따를 키-값 쌍의 개수
+func (w *Writer) WriteMapHeader(n int) error {
+	if w.protocol >= 3 {
+		return w.writeHeader('%', n)
+	}
+	return w.writeHeader('*', 2*n)
+}
+
+// WriteRDBPayload는 복제 전체 동기화(PSYNC)의 RDB 페이로드를 작성합니다.
+// 형식: $<길이>\r\n<RDB 내용> (벌크 문자열과 달리 끝에 \r\n이 붙지 않음)
+//
+// 매개변수:
+//   - r: RDB 내용
+//   - size: RDB 내용의 바이트 수
+func (w *Writer) WriteRDBPayload(r io.Reader, size int64) error {
+	if err := w.writeScratch(func(buf []byte) []byte { return appendHeader(buf, '$', size) }); err != nil {
+		return err
+	}
+	_, err := io.CopyN(w.writer, r, size)
+	return err
+}
+
+// WriteRDBStream은 크기를 미리 알 수 없는 RDB 페이로드를 EOF 표식 형식으로 작성합니다 (디스크 없는 동기화).
+// 형식: $EOF:<표식>\r\n<RDB 내용><표식> (표식은 40바이트 임의 문자열)
+// 레플리카가 REPLCONF capa eof로 이 형식을 지원한다고 알린 경우에만 사용합니다.
+//
+// 매개변수:
+//   - mark: 내용의 끝을 나타내는 40바이트 표식
+//   - write: RDB 내용을 주어진 io.Writer에 직접 작성하는 함수
+func (w *Writer) WriteRDBStream(mark string, write func(io.Writer) error) error {
+	if _, err := w.writer.Write([]byte("$EOF:" + mark + "\r\n")); err != nil {
+		return err
+	}
+	if err := write(w.writer); err != nil {
+		return err
+	}
+	_, err := w.writer.Write([]byte(mark))
+	return err
+}
+
+// WriteNullArray는 null 배열을 작성합니다 (BLPOP 시간 초과 등).
+// 형식:
+//   - RESP3: _\r\n (null의 종류를 구분하지 않음)
+//   - RESP2: *-1\r\n
+func (w *Writer) WriteNullArray() error {
+	if w.protocol >= 3 {
+		return w.WriteNull()
+	}
+	return w.writeShared(sharedNullArray)
+}
+
+// WriteNull은 null 값을 작성합니다.
+// 형식:
+//   - RESP3: _\r\n
+//   - RESP2: $-1\r\n (null bulk string)
+func (w *Writer) WriteNull() error {
+	if w.protocol >= 3 {
+		return w.writeShared(sharedNull)
+	}
+	return w.writeShared(sharedNullBulk)
+}
+
+// WriteSetHeader는 Set의 헤더를 작성합니다.
+// 형식:
+//   - RESP3: ~<요소개수>\r\n
+//   - RESP2: *<요소개수>\r\n (Set 타입이 없으므로 일반 배열로 대체)
+//
+// 사용 예:
+//   - COMMAND INFO의 플래그 목록처럼 순서와 중복이 의미 없는 집합
+//
+// 매개변수:
+//   - n: 뒤따를 요소 개수
+func (w *Writer) WriteSetHeader(n int) error {
+	prefix := byte('*')
+	if w.protocol >= 3 {
+		prefix = '~'
+	}
+	return w.writeHeader(prefix, n)
+}
+
+// WriteDouble은 부동소수점 수를 작성합니다.
+// 형식:
+//   - RESP3: ,<수>\r\n (예: ,1.5\r\n, ,inf\r\n)
+//   - RESP2: 같은 표기의 Bulk String (예: $3\r\n1.5\r\n)
+//
+// 수는 다시 읽었을 때 같은 값이 되는 가장 짧은 표기(유효 숫자 최대 17자리, FormatDouble)이며,
+// 무한대와 NaN은 inf, -inf, nan으로 작성합니다.
+//
+// 매개변수:
+//   - v: 작성할 수
+func (w *Writer) WriteDouble(v float64) error {
+	s := FormatDouble(v)
+	if w.protocol >= 3 {
+		return w.writeScratch(func(buf []byte) []byte {
+			return appendLine(buf, ',', s)
+		})
+	}
+	return w.WriteBulkString(&s)
+}
+
+// FormatDouble은 WriteDouble이 사용하는 표기로 수를 변환합니다 (Redis의 ZSCORE 등과 같은 표기).
+//
+// 규칙 (C의 %.17g와 같은 자리 기준):
+//   - 유효 숫자는 최대 17자리이며, 다시 읽었을 때 같은 값이 되는 가장 짧은 자릿수를 사용
+//   - 지수가 -4 미만이거나 17 이상이면 지수 표기 (예: 1e+21, 1e-05)
+//   - 그 외에는 일반 소수 표기 (예: 1000000, 0.0001, 3.14)
+//
+// Go의 'g' 형식은 1e+06부터 지수 표기를 사용하므로 직접 자리를 판단합니다.
+func FormatDouble(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "inf"
+	case math.IsInf(v, -1):
+		return "-inf"
+	case math.IsNaN(v):
+		return "nan"
+	}
+
+	// 가장 짧은 지수 표기에서 지수를 읽음 (예: "1.5e+06" → 6)
+	scientific := strconv.FormatFloat(v, 'e', -1, 64)
+	exp, _ := strconv.Atoi(scientific[strings.IndexByte(scientific, 'e')+1:])
+	if exp < -4 || exp >= 17 {
+		return scientific
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// WriteBoolean은 참/거짓 값을 작성합니다.
+// 형식:
+//   - RESP3: #t\r\n 또는 #f\r\n
+//   - RESP2: :1\r\n 또는 :0\r\n (Integer로 대체)
+func (w *Writer) WriteBoolean(b bool) error {
+	if w.protocol >= 3 {
+		value := "f"
+		if b {
+			value = "t"
+		}
+		return w.writeScratch(func(buf []byte) []byte {
+			return appendLine(buf, '#', value)
+		})
+	}
+	if b {
+		return w.WriteInteger(1)
+	}
+	return w.WriteInteger(0)
+}
+
+// WriteBigNumber는 64비트 정수 범위를 넘는 정수를 작성합니다.
+// 형식:
+//   - RESP3: (<정수>\r\n
+//   - RESP2: 같은 숫자의 Bulk String
+//
+// 매개변수:
+//   - n: 10진수로 표기한 정수 (부호 포함 가능)
+func (w *Writer) WriteBigNumber(n string) error {
+	if w.protocol >= 3 {
+		return w.writeScratch(func(buf []byte) []byte {
+			return appendLine(buf, '(', n)
+		})
+	}
+	return w.WriteBulkString(&n)
+}
+
+// WriteVerbatimString은 형식 정보가 붙은 문자열을 작성합니다.
+// 형식:
+//   - RESP3: =<길이>\r\n<형식>:<문자열>\r\n (형식은 txt 또는 mkd 세 글자, 길이는 "형식:"을 포함)
+//   - RESP2: 문자열만 담은 Bulk String
+//
+// 클라이언트가 줄바꿈을 그대로 보여 줘야 하는 사람이 읽는 텍스트(INFO, CLIENT LIST 등)에 사용합니다.
+//
+// 매개변수:
+//   - format: 세 글자 형식 (txt: 일반 텍스트, mkd: 마크다운)
+//   - s: 문자열
+func (w *Writer) WriteVerbatimString(format, s string) error {
+	if w.protocol >= 3 {
+		return w.writeScratch(func(buf []byte) []byte {
+			buf = appendHeader(buf, '=', int64(len(format)+1+len(s)))
+			buf = append(buf, format...)
+			return appendLine(buf, ':', s)
+		})
+	}
+	return w.WriteBulkString(&s)
+}
+
 // WriteOK는 표준 OK 응답을 작성하는 헬퍼 함수입니다.
 // 출력: +OK\r\n
 //
@@ -141,7 +558,7 @@ func (w *Writer) WriteNullArray() error {
 //   - FLUSHDB: 데이터베이스 초기화 성공 시
 //   - 기타 성공적으로 수행된 명령어들
 func (w *Writer) WriteOK() error {
-	return w.WriteSimpleString("OK")
+	return w.writeShared(sharedOK)
 }
 
 // WritePONG은 PING 명령에 대한 표준 응답을 작성하는 헬퍼 함수입니다.
@@ -156,5 +573,5 @@ func (w *Writer) WriteOK() error {
 //   - PING (no args) → PONG
 //   - PING "hello" → "hello" (인자가 있으면 그대로 반환)
 func (w *Writer) WritePONG() error {
-	return w.WriteSimpleString("PONG")
+	return w.writeShared(sharedPONG)
 }
@@ -0,0 +1,282 @@
+// Package pubsub은 Redis의 Publish/Subscribe 메시징 시스템을 구현합니다.
+//
+// 구조:
+//   - Broker: 채널/패턴/샤드 채널 → 구독자 매핑을 관리하고 PUBLISH 메시지를 팬아웃
+//   - Subscriber: 메시지를 받을 수 있는 대상 (주로 클라이언트 연결)
+//
+// Broker는 연결이나 RESP 형식을 알지 못합니다.
+// 메시지를 어떤 형식으로 전송할지는 Subscriber 구현이 결정합니다.
+package pubsub
+
+import (
+	"sort"
+	"sync"
+)
+
+// Message는 구독자에게 전달되는 발행 메시지입니다.
+type Message struct {
+	// Pattern은 패턴 구독(PSUBSCRIBE)으로 전달된 경우 매칭된 패턴입니다.
+	// 채널 구독으로 전달된 경우 빈 문자열입니다.
+	Pattern string
+	// Channel은 메시지가 발행된 채널입니다.
+	Channel string
+	// Payload는 발행된 메시지 본문입니다.
+	Payload string
+	// Shard는 샤드 채널(SPUBLISH)로 발행된 메시지인지 여부입니다.
+	Shard bool
+}
+
+// Subscriber는 발행된 메시지를 받을 수 있는 구독자입니다.
+//
+// Deliver는 PUBLISH를 실행한 고루틴에서 호출되므로,
+// 구현체는 다른 고루틴과 동시에 호출되어도 안전해야 합니다.
+type Subscriber interface {
+	Deliver(msg Message)
+}
+
+// Broker는 채널과 패턴 구독을 관리하고 메시지를 구독자들에게 전달합니다.
+// 모든 메서드는 여러 고루틴에서 동시에 호출해도 안전합니다.
+type Broker struct {
+	mu sync.RWMutex
+
+	// channels는 채널 이름 → 구독자 집합입니다.
+	channels map[string]map[Subscriber]struct{}
+	// patterns는 glob 패턴 → 구독자 집합입니다.
+	patterns map[string]map[Subscriber]struct{}
+	// shardChannels는 샤드 채널 이름 → 구독자 집합입니다.
+	// 일반 채널과 이름이 같아도 별개의 네임스페이스로 취급합니다.
+	shardChannels map[string]map[Subscriber]struct{}
+}
+
+// NewBroker는 새로운 Broker 인스턴스를 생성합니다.
+func NewBroker() *Broker {
+	return &Broker{
+		channels:      make(map[string]map[Subscriber]struct{}),
+		patterns:      make(map[string]map[Subscriber]struct{}),
+		shardChannels: make(map[string]map[Subscriber]struct{}),
+	}
+}
+
+// Subscribe는 구독자를 채널에 등록합니다.
+//
+// 반환값:
+//   - bool: 새로 구독했으면 true, 이미 구독 중이었으면 false
+func (b *Broker) Subscribe(sub Subscriber, channel string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return add(b.channels, channel, sub)
+}
+
+// Unsubscribe는 구독자를 채널에서 제거합니다.
+//
+// 반환값:
+//   - bool: 구독 중이었다가 제거되었으면 true
+func (b *Broker) Unsubscribe(sub Subscriber, channel string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return remove(b.channels, channel, sub)
+}
+
+// PSubscribe는 구독자를 glob 패턴에 등록합니다.
+//
+// 반환값:
+//   - bool: 새로 구독했으면 true, 이미 구독 중이었으면 false
+func (b *Broker) PSubscribe(sub Subscriber, pattern string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return add(b.patterns, pattern, sub)
+}
+
+// PUnsubscribe는 구독자를 glob 패턴에서 제거합니다.
+//
+// 반환값:
+//   - bool: 구독 중이었다가 제거되었으면 true
+func (b *Broker) PUnsubscribe(sub Subscriber, pattern string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return remove(b.patterns, pattern, sub)
+}
+
+// SSubscribe는 구독자를 샤드 채널에 등록합니다.
+//
+// 반환값:
+//   - bool: 새로 구독했으면 true, 이미 구독 중이었으면 false
+func (b *Broker) SSubscribe(sub Subscriber, channel string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return add(b.shardChannels, channel, sub)
+}
+
+// SUnsubscribe는 구독자를 샤드 채널에서 제거합니다.
+//
+// 반환값:
+//   - bool: 구독 중이었다가 제거되었으면 true
+func (b *Broker) SUnsubscribe(sub Subscriber, channel string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return remove(b.shardChannels, channel, sub)
+}
+
+// UnsubscribeAll은 구독자의 모든 채널/패턴/샤드 채널 구독을 제거합니다.
+// 클라이언트 연결이 종료될 때 호출됩니다.
+func (b *Broker) UnsubscribeAll(sub Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for channel := range b.channels {
+		remove(b.channels, channel, sub)
+	}
+	for pattern := range b.patterns {
+		remove(b.patterns, pattern, sub)
+	}
+	for channel := range b.shardChannels {
+		remove(b.shardChannels, channel, sub)
+	}
+}
+
+// Publish는 채널에 메시지를 발행합니다.
+//
+// 전달 대상:
+//   - 채널을 직접 구독한 모든 구독자
+//   - 채널 이름과 매칭되는 패턴을 구독한 모든 구독자 (패턴마다 한 번씩)
+//
+// 구독자 목록은 잠금 안에서 복사하고, 실제 전달은 잠금 밖에서 수행합니다.
+// 느린 구독자가 다른 구독/해지 작업을 막지 않도록 하기 위함입니다.
+//
+// 반환값:
+//   - int: 메시지를 받은 구독자 수 (패턴 매칭 포함)
+func (b *Broker) Publish(channel, payload string) int {
+	type delivery struct {
+		sub Subscriber
+		msg Message
+	}
+
+	b.mu.RLock()
+	deliveries := make([]delivery, 0, len(b.channels[channel]))
+	for sub := range b.channels[channel] {
+		deliveries = append(deliveries, delivery{sub, Message{Channel: channel, Payload: payload}})
+	}
+	for pattern, subs := range b.patterns {
+		if !Match(pattern, channel) {
+			continue
+		}
+		for sub := range subs {
+			deliveries = append(deliveries, delivery{sub, Message{Pattern: pattern, Channel: channel, Payload: payload}})
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, d := range deliveries {
+		d.sub.Deliver(d.msg)
+	}
+	return len(deliveries)
+}
+
+// SPublish는 샤드 채널에 메시지를 발행합니다.
+// 샤드 채널 메시지는 패턴 구독자에게 전달되지 않습니다.
+//
+// 반환값:
+//   - int: 메시지를 받은 구독자 수
+func (b *Broker) SPublish(channel, payload string) int {
+	b.mu.RLock()
+	subs := make([]Subscriber, 0, len(b.shardChannels[channel]))
+	for sub := range b.shardChannels[channel] {
+		subs = append(subs, sub)
+	}
+	b.mu.RUnlock()
+
+	msg := Message{Channel: channel, Payload: payload, Shard: true}
+	for _, sub := range subs {
+		sub.Deliver(msg)
+	}
+	return len(subs)
+}
+
+// Channels는 구독자가 한 명 이상 있는 활성 채널 목록을 반환합니다.
+// pattern이 비어 있지 않으면 패턴과 매칭되는 채널만 반환합니다.
+// 결과는 이름 순으로 정렬됩니다.
+func (b *Broker) Channels(pattern string) []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return activeNames(b.channels, pattern)
+}
+
+// IsSubscribed는 구독자가 채널을 직접 구독 중인지 확인합니다.
+func (b *Broker) IsSubscribed(sub Subscriber, channel string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, subscribed := b.channels[channel][sub]
+	return subscribed
+}
+
+// NumSub는 채널을 직접 구독한 구독자 수를 반환합니다 (패턴 구독 제외).
+func (b *Broker) NumSub(channel string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.channels[channel])
+}
+
+// ShardChannels는 구독자가 한 명 이상 있는 활성 샤드 채널 목록을 반환합니다.
+// pattern의 의미는 Channels와 동일합니다.
+func (b *Broker) ShardChannels(pattern string) []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return activeNames(b.shardChannels, pattern)
+}
+
+// ShardNumSub는 샤드 채널을 구독한 구독자 수를 반환합니다.
+func (b *Broker) ShardNumSub(channel string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.shardChannels[channel])
+}
+
+// NumPat는 활성 패턴(구독자가 한 명 이상 있는 고유 패턴)의 개수를 반환합니다.
+func (b *Broker) NumPat() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.patterns)
+}
+
+// activeNames는 구독 맵의 이름 중 pattern과 매칭되는 것을 정렬하여 반환합니다.
+// pattern이 비어 있으면 모든 이름을 반환합니다.
+func activeNames(index map[string]map[Subscriber]struct{}, pattern string) []string {
+	names := make([]string, 0, len(index))
+	for name := range index {
+		if pattern == "" || Match(pattern, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// add는 구독 맵에 구독자를 추가합니다.
+func add(index map[string]map[Subscriber]struct{}, name string, sub Subscriber) bool {
+	subs, exists := index[name]
+	if !exists {
+		subs = make(map[Subscriber]struct{})
+		index[name] = subs
+	}
+	if _, already := subs[sub]; already {
+		return false
+	}
+	subs[sub] = struct{}{}
+	return true
+}
+
+// remove는 구독 맵에서 구독자를 제거하고, 빈 항목은 정리합니다.
+func remove(index map[string]map[Subscriber]struct{}, name string, sub Subscriber) bool {
+	subs, exists := index[name]
+	if !exists {
+		return false
+	}
+	if _, subscribed := subs[sub]; !subscribed {
+		return false
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(index, name)
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+package pubsub
+
+import (
+	"sync"
+	"testing"
+)
+
+// recorder는 받은 메시지를 기록하는 테스트용 Subscriber입니다.
+type recorder struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func (r *recorder) Deliver(msg Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, msg)
+}
+
+// TestBrokerPublish는 채널/패턴 구독자에게 메시지가 전달되는지 테스트합니다.
+func TestBrokerPublish(t *testing.T) {
+	broker := NewBroker()
+	channelSub := &recorder{}
+	patternSub := &recorder{}
+
+	// 테스트 케이스 1: 구독 등록
+	if !broker.Subscribe(channelSub, "news.tech") {
+		t.Error("First subscribe should return true")
+	}
+	if broker.Subscribe(channelSub, "news.tech") {
+		t.Error("Duplicate subscribe should return false")
+	}
+	broker.PSubscribe(patternSub, "news.*")
+
+	// 테스트 케이스 2: 채널 + 패턴 구독자 모두에게 전달
+	receivers := broker.Publish("news.tech", "hello")
+	if receivers != 2 {
+		t.Errorf("Expected 2 receivers, got %d", receivers)
+	}
+	if len(channelSub.messages) != 1 || channelSub.messages[0] != (Message{Channel: "news.tech", Payload: "hello"}) {
+		t.Errorf("Unexpected channel messages %v", channelSub.messages)
+	}
+	expected := Message{Pattern: "news.*", Channel: "news.tech", Payload: "hello"}
+	if len(patternSub.messages) != 1 || patternSub.messages[0] != expected {
+		t.Errorf("Unexpected pattern messages %v", patternSub.messages)
+	}
+
+	// 테스트 케이스 3: 패턴만 매칭되는 채널
+	if receivers := broker.Publish("news.sports", "goal"); receivers != 1 {
+		t.Errorf("Expected 1 receiver, got %d", receivers)
+	}
+
+	// 테스트 케이스 4: 구독자가 없는 채널
+	if receivers := broker.Publish("weather", "sunny"); receivers != 0 {
+		t.Errorf("Expected 0 receivers, got %d", receivers)
+	}
+
+	// 테스트 케이스 5: 구독 해지 후에는 전달되지 않음
+	if !broker.Unsubscribe(channelSub, "news.tech") {
+		t.Error("Unsubscribe should return true")
+	}
+	if broker.Unsubscribe(channelSub, "news.tech") {
+		t.Error("Second unsubscribe should return false")
+	}
+	broker.UnsubscribeAll(patternSub)
+	if receivers := broker.Publish("news.tech", "bye"); receivers != 0 {
+		t.Errorf("Expected 0 receivers after unsubscribe, got %d", receivers)
+	}
+}
+
+// TestBrokerIntrospection은 활성 채널/구독자 수 조회를 테스트합니다.
+func TestBrokerIntrospection(t *testing.T) {
+	broker := NewBroker()
+	a, b := &recorder{}, &recorder{}
+
+	broker.Subscribe(a, "news.tech")
+	broker.Subscribe(b, "news.tech")
+	broker.Subscribe(a, "weather")
+	broker.PSubscribe(a, "news.*")
+	broker.PSubscribe(b, "news.*")
+	broker.PSubscribe(b, "*")
+
+	channels := broker.Channels("")
+	if len(channels) != 2 || channels[0] != "news.tech" || channels[1] != "weather" {
+		t.Errorf("Expected [news.tech weather], got %v", channels)
+	}
+	if channels := broker.Channels("news.*"); len(channels) != 1 {
+		t.Errorf("Expected 1 matching channel, got %v", channels)
+	}
+	if n := broker.NumSub("news.tech"); n != 2 {
+		t.Errorf("Expected 2 subscribers, got %d", n)
+	}
+	if n := broker.NumSub("nobody"); n != 0 {
+		t.Errorf("Expected 0 subscribers, got %d", n)
+	}
+	if n := broker.NumPat(); n != 2 {
+		t.Errorf("Expected 2 patterns, got %d", n)
+	}
+}
+
+// TestBrokerShardChannels는 샤드 채널이 일반 채널과 분리되어 동작하는지 테스트합니다.
+func TestBrokerShardChannels(t *testing.T) {
+	broker := NewBroker()
+	shardSub := &recorder{}
+	channelSub := &recorder{}
+	patternSub := &recorder{}
+
+	broker.SSubscribe(shardSub, "orders")
+	broker.Subscribe(channelSub, "orders")
+	broker.PSubscribe(patternSub, "*")
+
+	// 테스트 케이스 1: SPUBLISH는 샤드 채널 구독자에게만 전달
+	if n := broker.SPublish("orders", "o1"); n != 1 {
+		t.Errorf("Expected 1 receiver, got %d", n)
+	}
+	if len(shardSub.messages) != 1 || !shardSub.messages[0].Shard {
+		t.Errorf("Expected one shard message, got %+v", shardSub.messages)
+	}
+	if len(channelSub.messages) != 0 || len(patternSub.messages) != 0 {
+		t.Error("Shard message should not reach channel or pattern subscribers")
+	}
+
+	// 테스트 케이스 2: PUBLISH는 샤드 채널 구독자에게 전달되지 않음
+	if n := broker.Publish("orders", "o2"); n != 2 {
+		t.Errorf("Expected 2 receivers, got %d", n)
+	}
+	if len(shardSub.messages) != 1 {
+		t.Error("Regular message should not reach shard subscribers")
+	}
+
+	// 테스트 케이스 3: 조회와 해지
+	if channels := broker.ShardChannels(""); len(channels) != 1 || channels[0] != "orders" {
+		t.Errorf("Expected [orders], got %v", channels)
+	}
+	if n := broker.ShardNumSub("orders"); n != 1 {
+		t.Errorf("Expected 1 shard subscriber, got %d", n)
+	}
+	if !broker.SUnsubscribe(shardSub, "orders") {
+		t.Error("SUnsubscribe should return true")
+	}
+	if n := broker.ShardNumSub("orders"); n != 0 {
+		t.Errorf("Expected 0 shard subscribers, got %d", n)
+	}
+}
@@ -0,0 +1,116 @@
+package pubsub
+
+// Match는 Redis의 glob 스타일 패턴 매칭을 구현합니다 (stringmatchlen과 동일한 규칙).
+//
+// 지원하는 패턴:
+//   - *: 임의 길이의 문자열 (빈 문자열 포함)
+//   - ?: 임의의 한 글자
+//   - [abc]: 괄호 안의 한 글자
+//   - [^abc]: 괄호 안에 없는 한 글자
+//   - [a-z]: 범위 안의 한 글자
+//   - \x: 특수 문자 x를 문자 그대로 매칭
+//
+// 예시:
+//
+//	Match("news.*", "news.tech") → true
+//	Match("h?llo", "hello") → true
+//	Match("h[^e]llo", "hello") → false
+func Match(pattern, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			// 연속된 *는 하나로 취급
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(s); i++ {
+				if Match(pattern[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+
+		case '?':
+			if len(s) == 0 {
+				return false
+			}
+			s = s[1:]
+
+		case '[':
+			if len(s) == 0 {
+				return false
+			}
+			matched, rest := matchClass(pattern[1:], s[0])
+			if !matched {
+				return false
+			}
+			pattern = rest
+			s = s[1:]
+			continue
+
+		case '\\':
+			if len(pattern) >= 2 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+
+		default:
+			if len(s) == 0 || pattern[0] != s[0] {
+				return false
+			}
+			s = s[1:]
+		}
+		pattern = pattern[1:]
+	}
+	return len(s) == 0
+}
+
+// matchClass는 문자 클래스([...])를 해석하여 c가 매칭되는지 확인합니다.
+// pattern은 '[' 다음부터 시작하며, 닫는 ']' 다음의 나머지 패턴을 함께 반환합니다.
+func matchClass(pattern string, c byte) (bool, string) {
+	not := false
+	if len(pattern) > 0 && pattern[0] == '^' {
+		not = true
+		pattern = pattern[1:]
+	}
+
+	matched := false
+	for len(pattern) > 0 && pattern[0] != ']' {
+		switch {
+		case pattern[0] == '\\' && len(pattern) >= 2:
+			if pattern[1] == c {
+				matched = true
+			}
+			pattern = pattern[2:]
+
+		case len(pattern) >= 3 && pattern[1] == '-':
+			start, end := pattern[0], pattern[2]
+			if start > end {
+				start, end = end, start
+			}
+			if c >= start && c <= end {
+				matched = true
+			}
+			pattern = pattern[3:]
+
+		default:
+			if pattern[0] == c {
+				matched = true
+			}
+			pattern = pattern[1:]
+		}
+	}
+
+	// 닫는 ']' 건너뛰기 (닫히지 않은 클래스는 패턴 끝까지로 취급)
+	if len(pattern) > 0 {
+		pattern = pattern[1:]
+	}
+
+	if not {
+		matched = !matched
+	}
+	return matched, pattern
+}
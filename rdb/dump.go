@@ -0,0 +1,97 @@
+package rdb
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// dumpVersion은 DUMP 페이로드에 기록하는 RDB 형식 버전입니다 (version과 동일).
+const dumpVersion = 11
+
+// ErrInvalidDump는 DUMP 페이로드의 버전이나 체크섬이 맞지 않거나 내용이 잘못된 경우의 에러입니다.
+var ErrInvalidDump = errors.New("rdb: DUMP payload version or checksum are wrong")
+
+// Dump는 값 하나를 DUMP 명령어의 페이로드로 직렬화합니다.
+//
+// 페이로드 구조 (키와 만료 시각은 포함하지 않음):
+//
+//	+----------+-----+-------------------+-----------------+
+//	| 값 타입   | 값   | RDB 버전 (2바이트) | CRC64 (8바이트)  |
+//	+----------+-----+-------------------+-----------------+
+//
+// 버전과 체크섬은 리틀 엔디언이며, 체크섬은 값 타입부터 버전까지를 계산한 값입니다.
+func Dump(value interface{}) ([]byte, error) {
+	valueType, err := typeOf(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	e := &encoder{w: bufio.NewWriter(&buf)}
+	e.w.WriteByte(valueType)
+	e.writeValue(value)
+	var footer [2]byte
+	binary.LittleEndian.PutUint16(footer[:], dumpVersion)
+	e.w.Write(footer[:])
+	if err := e.w.Flush(); err != nil {
+		return nil, err
+	}
+
+	var sum [8]byte
+	binary.LittleEndian.PutUint64(sum[:], crc64Update(0, buf.Bytes()))
+	buf.Write(sum[:])
+	return buf.Bytes(), nil
+}
+
+// Restore는 DUMP 페이로드의 값을 key에 넣습니다 (RESTORE 명령어).
+// 키가 이미 있으면 기존 값을 지우고 바꿉니다.
+// expireAt이 zero가 아니면 만료 시각으로 설정합니다 (문자열에만 적용).
+// 페이로드가 잘못되었으면 저장소를 바꾸지 않고 ErrInvalidDump를 반환합니다.
+func Restore(s *store.Store, key string, payload []byte, expireAt time.Time) error {
+	value, err := decodeDump(payload)
+	if err != nil {
+		return err
+	}
+	s.DEL(key)
+	restore(s, key, value, expireAt)
+	return nil
+}
+
+// decodeDump는 DUMP 페이로드의 버전과 체크섬을 확인하고 값을 읽습니다.
+// 체크섬이 0인 페이로드는 검증하지 않습니다.
+func decodeDump(payload []byte) (interface{}, error) {
+	if len(payload) < 10 {
+		return nil, ErrInvalidDump
+	}
+	body, footer := payload[:len(payload)-10], payload[len(payload)-10:]
+	if binary.LittleEndian.Uint16(footer[:2]) > dumpVersion {
+		return nil, ErrInvalidDump
+	}
+	if sum := binary.LittleEndian.Uint64(footer[2:]); sum != 0 && sum != crc64Update(0, payload[:len(payload)-8]) {
+		return nil, ErrInvalidDump
+	}
+
+	d := &decoder{r: bufio.NewReader(bytes.NewReader(body))}
+	valueType, err := d.readByte()
+	if err != nil {
+		return nil, ErrInvalidDump
+	}
+	if valueType != typeString && valueType != typeList && valueType != typeZSet2 {
+		return nil, ErrInvalidDump
+	}
+	value, err := d.readValue(valueType)
+	if err != nil {
+		return nil, ErrInvalidDump
+	}
+	// 값 뒤에 남은 바이트가 있으면 잘못된 페이로드
+	if _, err := d.r.ReadByte(); err != io.EOF {
+		return nil, ErrInvalidDump
+	}
+	return value, nil
+}
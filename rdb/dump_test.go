@@ -0,0 +1,64 @@
+package rdb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// TestDumpRestore는 DUMP 페이로드의 직렬화와 복원을 테스트합니다.
+func TestDumpRestore(t *testing.T) {
+	src := store.NewStore()
+	src.SET("str", []byte("value"), nil)
+	src.RPUSH("list", "a", "b", "c")
+	src.ZADD("zset", 1.5, "one")
+	src.ZADD("zset", -2, "two")
+
+	// 테스트 케이스 1: 타입별로 같은 값으로 복원
+	dst := store.NewStore()
+	for _, key := range []string{"str", "list", "zset"} {
+		entry, _ := src.Lookup(key)
+		payload, err := Dump(entry.Value)
+		if err != nil {
+			t.Fatalf("Dump(%q) failed: %v", key, err)
+		}
+		if err := Restore(dst, key, payload, time.Time{}); err != nil {
+			t.Fatalf("Restore(%q) failed: %v", key, err)
+		}
+	}
+	if !reflect.DeepEqual(src.Snapshot(), dst.Snapshot()) {
+		t.Errorf("Expected %v, got %v", src.Snapshot(), dst.Snapshot())
+	}
+
+	// 테스트 케이스 2: Redis가 만든 페이로드 (정수 인코딩 문자열 10, RDB 버전 9)
+	redisPayload := []byte("\x00\xc0\x0a\x09\x00\xbe\x6d\x06\x89\x5a\x28\x00\x0a")
+	if err := Restore(dst, "mykey", redisPayload, time.Time{}); err != nil {
+		t.Fatalf("Restore of Redis payload failed: %v", err)
+	}
+	if value := dst.GET("mykey"); value == nil || string(value) != "10" {
+		t.Errorf("Expected '10', got %v", value)
+	}
+
+	// 테스트 케이스 3: 만료 시각
+	payload, _ := Dump([]byte("soon"))
+	Restore(dst, "ttl", payload, time.Now().Add(-time.Second))
+	if value := dst.GET("ttl"); value != nil {
+		t.Errorf("Expected expired key, got %q", value)
+	}
+
+	// 테스트 케이스 4: 손상되었거나 새 버전의 페이로드는 거부
+	corrupt := append([]byte(nil), payload...)
+	corrupt[1] ^= 0xff
+	newer := append([]byte(nil), payload...)
+	newer[len(newer)-10] = dumpVersion + 1
+	for name, p := range map[string][]byte{"corrupt": corrupt, "newer": newer, "short": payload[:5]} {
+		if err := Restore(dst, "bad", p, time.Time{}); err != ErrInvalidDump {
+			t.Errorf("%s: expected ErrInvalidDump, got %v", name, err)
+		}
+	}
+	if _, ok := dst.Lookup("bad"); ok {
+		t.Error("Expected invalid payloads to leave the store unchanged")
+	}
+}
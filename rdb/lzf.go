@@ -0,0 +1,58 @@
+package rdb
+
+import "fmt"
+
+// lzfDecompress는 LZF로 압축된 데이터를 풉니다 (Redis lzf_d.c와 동일한 형식).
+//
+// 압축 스트림은 제어 바이트로 시작하는 블록들의 연속입니다:
+//   - 제어 바이트 < 32: 리터럴, 뒤따르는 (제어+1)바이트를 그대로 복사
+//   - 그 외: 역참조, 상위 3비트는 길이(7이면 다음 바이트를 더함), 하위 5비트와 다음 바이트는 거리
+//
+// 매개변수:
+//   - in: 압축된 데이터
+//   - outLen: 압축을 푼 데이터의 길이 (RDB에 기록된 값)
+func lzfDecompress(in []byte, outLen int) ([]byte, error) {
+	out := make([]byte, 0, outLen)
+	for i := 0; i < len(in); {
+		ctrl := int(in[i])
+		i++
+
+		if ctrl < 32 {
+			// 리터럴
+			n := ctrl + 1
+			if i+n > len(in) {
+				return nil, fmt.Errorf("lzf: literal run exceeds input")
+			}
+			out = append(out, in[i:i+n]...)
+			i += n
+			continue
+		}
+
+		// 역참조
+		length := ctrl >> 5
+		if length == 7 {
+			if i >= len(in) {
+				return nil, fmt.Errorf("lzf: truncated back reference")
+			}
+			length += int(in[i])
+			i++
+		}
+		if i >= len(in) {
+			return nil, fmt.Errorf("lzf: truncated back reference")
+		}
+		ref := len(out) - ((ctrl&0x1f)<<8 | int(in[i])) - 1
+		i++
+		if ref < 0 {
+			return nil, fmt.Errorf("lzf: back reference out of range")
+		}
+		// 겹치는 복사가 가능하므로 한 바이트씩 복사
+		for j := 0; j < length+2; j++ {
+			out = append(out, out[ref+j])
+		}
+	}
+
+	if len(out) != outLen {
+		return nil, fmt.Errorf("lzf: expected %d bytes, got %d", outLen, len(out))
+	}
+	return out, nil
+}
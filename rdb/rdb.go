@@ -0,0 +1,59 @@
+// Package rdb는 Redis RDB 스냅샷 파일 형식을 읽고 씁니다.
+//
+// RDB 파일 구조:
+//
+//	+-------+------+-------+----------------------------------+-----+----------+
+//	| REDIS | 0011 | AUX.. | SELECTDB n, RESIZEDB, 키-값 쌍...  | EOF | 체크섬    |
+//	+-------+------+-------+----------------------------------+-----+----------+
+//	 매직    버전               (데이터베이스마다 반복)                  8바이트
+//
+// 체크섬은 매직부터 EOF까지를 CRC64로 계산한 값이며, 불러올 때 일치하지 않으면 거부합니다.
+// 체크섬이 0인 파일(rdbchecksum no로 저장된 파일)은 검증하지 않습니다.
+//
+// 키-값 쌍은 선택적인 만료 시각(EXPIRETIME, EXPIRETIME_MS) 뒤에
+// 값 타입 바이트, 키(문자열), 값이 이어지는 형태입니다.
+//
+// 지원 범위:
+//   - 값 타입: 문자열 (일반, 정수 인코딩, LZF 압축), 리스트, 정렬된 집합
+//   - 오피코드: AUX, SELECTDB, RESIZEDB, EXPIRETIME, EXPIRETIME_MS, EOF
+//   - 데이터베이스: 0번만 불러옴 (다른 데이터베이스의 키는 건너뜀)
+//
+// 저장할 때는 문자열을 압축하지 않고 일반 문자열 인코딩으로 기록합니다.
+package rdb
+
+// magic은 RDB 파일의 시작을 나타내는 문자열입니다.
+const magic = "REDIS"
+
+// version은 저장할 때 기록하는 RDB 형식 버전입니다 (Redis 7.x와 동일).
+const version = "0011"
+
+// checksumVersion은 EOF 뒤에 체크섬이 붙기 시작한 RDB 형식 버전입니다.
+const checksumVersion = 5
+
+// 오피코드 (키-값 쌍 사이에 나타나는 특수 바이트)
+const (
+	opModuleAux    = 0xF7 // 모듈 보조 데이터
+	opIdle         = 0xF8 // LRU 유휴 시간
+	opFreq         = 0xF9 // LFU 접근 빈도
+	opAux          = 0xFA // 보조 필드 (키-값 메타데이터)
+	opResizeDB     = 0xFB // 해시 테이블 크기 힌트
+	opExpireTimeMs = 0xFC // 만료 시각 (밀리초, 8바이트 리틀 엔디언)
+	opExpireTime   = 0xFD // 만료 시각 (초, 4바이트 리틀 엔디언)
+	opSelectDB     = 0xFE // 데이터베이스 선택
+	opEOF          = 0xFF // 파일 끝
+)
+
+// 값 타입
+const (
+	typeString = 0 // 문자열
+	typeList   = 1 // 리스트 (원소 개수, 원소 문자열들)
+	typeZSet2  = 5 // 정렬된 집합 (원소 개수, 멤버 문자열과 8바이트 리틀 엔디언 double 점수 쌍)
+)
+
+// 길이 인코딩에서 특수 인코딩(상위 2비트가 11)의 종류
+const (
+	encInt8  = 0 // 8비트 정수로 저장된 문자열
+	encInt16 = 1 // 16비트 정수로 저장된 문자열
+	encInt32 = 2 // 32비트 정수로 저장된 문자열
+	encLZF   = 3 // LZF로 압축된 문자열
+)
@@ -0,0 +1,219 @@
+package rdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// rdbString은 길이 접두사가 붙은 일반 문자열 인코딩을 만듭니다.
+func rdbString(s string) []byte {
+	return append([]byte{byte(len(s))}, s...)
+}
+
+// buildRDB는 헤더와 EOF(빈 체크섬 포함) 사이에 본문을 넣은 RDB 파일을 만듭니다.
+func buildRDB(body ...[]byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("REDIS0011")
+	buf.Write([]byte{opAux})
+	buf.Write(rdbString("redis-ver"))
+	buf.Write(rdbString("7.2.0"))
+	for _, b := range body {
+		buf.Write(b)
+	}
+	buf.WriteByte(opEOF)
+	buf.Write(make([]byte, 8))
+	return buf.Bytes()
+}
+
+// TestLoad는 문자열 키와 만료 시각을 불러오는지 테스트합니다.
+func TestLoad(t *testing.T) {
+	future := make([]byte, 8)
+	binary.LittleEndian.PutUint64(future, uint64(time.Now().Add(time.Hour).UnixMilli()))
+	past := make([]byte, 8)
+	binary.LittleEndian.PutUint64(past, uint64(time.Now().Add(-time.Hour).UnixMilli()))
+
+	data := buildRDB(
+		[]byte{opSelectDB, 0},
+		[]byte{opResizeDB, 4, 2},
+		// 만료 없는 키
+		[]byte{typeString}, rdbString("plain"), rdbString("value"),
+		// 미래에 만료되는 키
+		[]byte{opExpireTimeMs}, future, []byte{typeString}, rdbString("later"), rdbString("soon"),
+		// 이미 만료된 키
+		[]byte{opExpireTimeMs}, past, []byte{typeString}, rdbString("gone"), rdbString("old"),
+		// 정수 인코딩
+		[]byte{typeString}, rdbString("int8"), []byte{0xC0, 0xFE},
+		[]byte{typeString}, rdbString("int16"), []byte{0xC1, 0x39, 0x30},
+		[]byte{typeString}, rdbString("int32"), []byte{0xC2, 0x87, 0xD6, 0x12, 0x00},
+		// LZF 압축: "aaaaaaaaaa" = 리터럴 'a' + 길이 9인 역참조
+		[]byte{typeString}, rdbString("lzf"), []byte{0xC3, 5, 10, 0x00, 'a', 0xE0, 0x00, 0x00},
+		// 다른 데이터베이스의 키는 건너뜀
+		[]byte{opSelectDB, 1},
+		[]byte{typeString}, rdbString("other"), rdbString("db1"),
+	)
+
+	s := store.NewStore()
+	if err := Load(bytes.NewReader(data), s); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	tests := []struct {
+		key      string
+		expected *string
+	}{
+		{"plain", ptr("value")},
+		{"later", ptr("soon")},
+		{"gone", nil},
+		{"int8", ptr("-2")},
+		{"int16", ptr("12345")},
+		{"int32", ptr("1234567")},
+		{"lzf", ptr("aaaaaaaaaa")},
+		{"other", nil},
+	}
+	for _, tt := range tests {
+		got := s.GET(tt.key)
+		switch {
+		case tt.expected == nil && got != nil:
+			t.Errorf("%s: expected nil, got %q", tt.key, got)
+		case tt.expected != nil && (got == nil || string(got) != *tt.expected):
+			t.Errorf("%s: expected %q, got %q", tt.key, *tt.expected, got)
+		}
+	}
+}
+
+// TestLoadErrors는 잘못된 RDB 데이터를 거부하는지 테스트합니다.
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"wrong signature", []byte("RADIS0011\xff")},
+		{"bad version", []byte("REDISabcd\xff")},
+		{"truncated", []byte("REDIS0011\x00\x03key")},
+		{"missing EOF", []byte("REDIS0011")},
+		{"unsupported type", append([]byte("REDIS0011\x04"), rdbString("key")...)},
+	}
+
+	for _, tt := range tests {
+		if err := Load(bytes.NewReader(tt.data), store.NewStore()); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+// TestLoadFile은 파일에서 불러오기와 파일이 없는 경우를 테스트합니다.
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	// 테스트 케이스 1: 파일이 없으면 에러 없이 빈 저장소
+	s := store.NewStore()
+	if err := LoadFile(filepath.Join(dir, "missing.rdb"), s); err != nil {
+		t.Errorf("Expected nil for missing file, got %v", err)
+	}
+
+	// 테스트 케이스 2: 파일에서 키를 불러옴
+	path := filepath.Join(dir, "dump.rdb")
+	data := buildRDB([]byte{opSelectDB, 0, typeString}, rdbString("foo"), rdbString("bar"))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadFile(path, s); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if got := s.GET("foo"); got == nil || string(got) != "bar" {
+		t.Errorf("Expected 'bar', got %v", got)
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+// TestWriteRoundTrip은 저장한 RDB를 다시 불러오면 같은 키 공간이 되는지 테스트합니다.
+func TestWriteRoundTrip(t *testing.T) {
+	src := store.NewStore()
+	src.SET("str", []byte("value"), nil)
+	src.SETPXAT("ttl", []byte("soon"), time.UnixMilli(time.Now().Add(time.Hour).UnixMilli())) // RDB는 밀리초 단위
+	src.RPUSH("list", "a", "b", "c")
+	src.ZADD("zset", 1.5, "one")
+	src.ZADD("zset", -2, "two")
+	src.SET(strings.Repeat("k", 100), []byte(strings.Repeat("v", 20000)), nil) // 14비트, 32비트 길이 인코딩
+
+	var buf bytes.Buffer
+	if err := Write(&buf, src.Snapshot()); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	dst := store.NewStore()
+	if err := Load(bytes.NewReader(buf.Bytes()), dst); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !reflect.DeepEqual(src.Snapshot(), dst.Snapshot()) {
+		t.Error("Loaded keyspace differs from the saved one")
+	}
+
+	// 테스트 케이스 2: 빈 키 공간
+	buf.Reset()
+	if err := Write(&buf, nil); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := Load(bytes.NewReader(buf.Bytes()), store.NewStore()); err != nil {
+		t.Errorf("Load of empty RDB failed: %v", err)
+	}
+}
+
+// TestChecksum은 CRC64 계산과 저장된 체크섬 검증을 테스트합니다.
+func TestChecksum(t *testing.T) {
+	// 테스트 케이스 1: Redis의 CRC-64/Jones 검증 값
+	if sum := crc64Update(0, []byte("123456789")); sum != 0xe9c6d914c4b8d9ca {
+		t.Errorf("Expected e9c6d914c4b8d9ca, got %016x", sum)
+	}
+
+	src := store.NewStore()
+	src.SET("foo", []byte("bar"), nil)
+	var buf bytes.Buffer
+	if err := Write(&buf, src.Snapshot()); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data := buf.Bytes()
+
+	// 테스트 케이스 2: 기록된 체크섬은 EOF까지의 내용과 일치
+	body := data[:len(data)-8]
+	if stored := binary.LittleEndian.Uint64(data[len(data)-8:]); stored != crc64Update(0, body) {
+		t.Errorf("Expected checksum %016x, got %016x", crc64Update(0, body), stored)
+	}
+
+	// 테스트 케이스 3: 내용이 손상되면 거부
+	corrupt := bytes.Clone(data)
+	corrupt[bytes.Index(corrupt, []byte("bar"))] = 'c'
+	err := Load(bytes.NewReader(corrupt), store.NewStore())
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Expected checksum mismatch error, got %v", err)
+	}
+
+	// 테스트 케이스 4: 체크섬이 잘린 파일은 거부
+	if err := Load(bytes.NewReader(data[:len(data)-3]), store.NewStore()); err == nil {
+		t.Error("Expected error for truncated checksum")
+	}
+
+	// 테스트 케이스 5: 체크섬이 없는 버전(5 미만)은 EOF에서 끝남
+	old := append([]byte("REDIS0004"), opSelectDB, 0, typeString)
+	old = append(old, rdbString("foo")...)
+	old = append(old, rdbString("bar")...)
+	old = append(old, opEOF)
+	dst := store.NewStore()
+	if err := Load(bytes.NewReader(old), dst); err != nil {
+		t.Fatalf("Load of version 4 RDB failed: %v", err)
+	}
+	if value := dst.GET("foo"); value == nil || string(value) != "bar" {
+		t.Errorf("Expected 'bar', got %v", value)
+	}
+}
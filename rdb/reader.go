@@ -0,0 +1,380 @@
+package rdb
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// decoder는 RDB 스트림에서 기본 단위(바이트, 길이, 문자열)를 읽습니다.
+// 읽은 바이트는 모두 체크섬(crc)에 반영됩니다.
+type decoder struct {
+	r   *bufio.Reader
+	crc uint64
+}
+
+// readByte는 바이트 하나를 읽습니다.
+func (d *decoder) readByte() (byte, error) {
+	b, err := d.r.ReadByte()
+	if err == io.EOF {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if err == nil {
+		d.crc = crc64Update(d.crc, []byte{b})
+	}
+	return b, err
+}
+
+// readFull은 정확히 n바이트를 읽습니다.
+func (d *decoder) readFull(n int) ([]byte, error) {
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(d.r, buf); err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	d.crc = crc64Update(d.crc, buf)
+	return buf, nil
+}
+
+// readLength는 길이 인코딩된 값을 읽습니다.
+//
+// 첫 바이트의 상위 2비트에 따라:
+//   - 00: 하위 6비트가 길이
+//   - 01: 하위 6비트와 다음 바이트로 14비트 길이
+//   - 10: 0x80이면 다음 4바이트(빅 엔디언), 0x81이면 다음 8바이트(빅 엔디언)가 길이
+//   - 11: 특수 인코딩, special이 true이고 하위 6비트가 인코딩 종류
+func (d *decoder) readLength() (length uint64, special bool, err error) {
+	b, err := d.readByte()
+	if err != nil {
+		return 0, false, err
+	}
+
+	switch b >> 6 {
+	case 0:
+		return uint64(b & 0x3f), false, nil
+	case 1:
+		next, err := d.readByte()
+		if err != nil {
+			return 0, false, err
+		}
+		return uint64(b&0x3f)<<8 | uint64(next), false, nil
+	case 2:
+		switch b {
+		case 0x80:
+			buf, err := d.readFull(4)
+			if err != nil {
+				return 0, false, err
+			}
+			return uint64(binary.BigEndian.Uint32(buf)), false, nil
+		case 0x81:
+			buf, err := d.readFull(8)
+			if err != nil {
+				return 0, false, err
+			}
+			return binary.BigEndian.Uint64(buf), false, nil
+		}
+		return 0, false, fmt.Errorf("rdb: unknown length encoding 0x%02x", b)
+	}
+	return uint64(b & 0x3f), true, nil
+}
+
+// readPlainLength는 특수 인코딩이 아닌 길이를 읽습니다.
+func (d *decoder) readPlainLength() (int, error) {
+	length, special, err := d.readLength()
+	if err != nil {
+		return 0, err
+	}
+	if special {
+		return 0, fmt.Errorf("rdb: unexpected special encoding for length")
+	}
+	return int(length), nil
+}
+
+// readString은 키나 요소 같은 문자열을 읽습니다 (readBytes 참고).
+func (d *decoder) readString() (string, error) {
+	b, err := d.readBytes()
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// readBytes는 문자열을 바이트열로 읽습니다 (일반 문자열, 정수 인코딩, LZF 압축).
+// 반환된 슬라이스는 새로 할당된 것이므로 그대로 저장소에 넣을 수 있습니다.
+func (d *decoder) readBytes() ([]byte, error) {
+	length, special, err := d.readLength()
+	if err != nil {
+		return nil, err
+	}
+	if !special {
+		buf, err := d.readFull(int(length))
+		if err != nil {
+			return nil, err
+		}
+		return buf, nil
+	}
+
+	switch length {
+	case encInt8:
+		b, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strconv.Itoa(int(int8(b)))), nil
+	case encInt16:
+		buf, err := d.readFull(2)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strconv.Itoa(int(int16(binary.LittleEndian.Uint16(buf))))), nil
+	case encInt32:
+		buf, err := d.readFull(4)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strconv.Itoa(int(int32(binary.LittleEndian.Uint32(buf))))), nil
+	case encLZF:
+		compressedLen, err := d.readPlainLength()
+		if err != nil {
+			return nil, err
+		}
+		outLen, err := d.readPlainLength()
+		if err != nil {
+			return nil, err
+		}
+		compressed, err := d.readFull(compressedLen)
+		if err != nil {
+			return nil, err
+		}
+		out, err := lzfDecompress(compressed, outLen)
+		if err != nil {
+			return nil, fmt.Errorf("rdb: %w", err)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("rdb: unknown string encoding %d", length)
+}
+
+// readValue는 값 타입 바이트 뒤의 값을 읽습니다.
+// 문자열은 []byte, 리스트는 []string, 정렬된 집합은 []store.ScoredMember로 반환합니다.
+func (d *decoder) readValue(valueType byte) (interface{}, error) {
+	switch valueType {
+	case typeList:
+		n, err := d.readPlainLength()
+		if err != nil {
+			return nil, err
+		}
+		list := make([]string, 0, n)
+		for i := 0; i < n; i++ {
+			elem, err := d.readString()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, elem)
+		}
+		return list, nil
+
+	case typeZSet2:
+		n, err := d.readPlainLength()
+		if err != nil {
+			return nil, err
+		}
+		members := make([]store.ScoredMember, 0, n)
+		for i := 0; i < n; i++ {
+			member, err := d.readString()
+			if err != nil {
+				return nil, err
+			}
+			buf, err := d.readFull(8)
+			if err != nil {
+				return nil, err
+			}
+			score := math.Float64frombits(binary.LittleEndian.Uint64(buf))
+			members = append(members, store.ScoredMember{Member: member, Score: score})
+		}
+		return members, nil
+	}
+	return d.readBytes()
+}
+
+// restore는 읽은 값을 타입에 맞는 저장소 API로 넣습니다.
+// 만료 시각은 문자열에만 적용됩니다 (저장소가 다른 타입의 만료를 지원하지 않음).
+func restore(s *store.Store, key string, value interface{}, expireAt time.Time) {
+	switch v := value.(type) {
+	case []byte:
+		if expireAt.IsZero() {
+			s.SET(key, v, nil)
+		} else {
+			s.SETPXAT(key, v, expireAt)
+		}
+	case []string:
+		if len(v) > 0 {
+			s.RPUSH(key, v...)
+		}
+	case []store.ScoredMember:
+		for _, m := range v {
+			s.ZADD(key, m.Score, m.Member)
+		}
+	}
+}
+
+// Load는 RDB 스트림을 읽어 0번 데이터베이스의 키들을 저장소에 넣습니다.
+// 이미 만료된 키는 불러오지 않습니다.
+//
+// 에러 케이스:
+//   - 매직 문자열이나 버전이 잘못된 경우
+//   - 지원하지 않는 값 타입이나 오피코드
+//   - EOF 오피코드 전에 스트림이 끝난 경우
+//   - 체크섬이 일치하지 않는 경우
+//
+// r이 *bufio.Reader이면 체크섬까지만 읽으므로, 이어지는 내용을 같은 리더로 계속 읽을 수 있습니다.
+func Load(r io.Reader, s *store.Store) error {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	d := &decoder{r: br}
+
+	header, err := d.readFull(len(magic) + 4)
+	if err != nil {
+		return fmt.Errorf("rdb: reading header: %w", err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return errors.New("rdb: wrong signature")
+	}
+	rdbVersion, err := strconv.Atoi(string(header[len(magic):]))
+	if err != nil {
+		return fmt.Errorf("rdb: invalid version %q", header[len(magic):])
+	}
+
+	db := 0
+	var expireAt time.Time // 다음 키의 만료 시각 (없으면 zero)
+	now := time.Now()
+
+	for {
+		op, err := d.readByte()
+		if err != nil {
+			return fmt.Errorf("rdb: %w", err)
+		}
+
+		switch op {
+		case opEOF:
+			if rdbVersion < checksumVersion {
+				return nil
+			}
+			return d.verifyChecksum()
+
+		case opAux:
+			if _, err := d.readString(); err != nil {
+				return err
+			}
+			if _, err := d.readString(); err != nil {
+				return err
+			}
+
+		case opSelectDB:
+			if db, err = d.readPlainLength(); err != nil {
+				return err
+			}
+
+		case opResizeDB:
+			if _, err := d.readPlainLength(); err != nil {
+				return err
+			}
+			if _, err := d.readPlainLength(); err != nil {
+				return err
+			}
+
+		case opExpireTimeMs:
+			buf, err := d.readFull(8)
+			if err != nil {
+				return err
+			}
+			expireAt = time.UnixMilli(int64(binary.LittleEndian.Uint64(buf)))
+
+		case opExpireTime:
+			buf, err := d.readFull(4)
+			if err != nil {
+				return err
+			}
+			expireAt = time.Unix(int64(binary.LittleEndian.Uint32(buf)), 0)
+
+		case opIdle:
+			if _, err := d.readPlainLength(); err != nil {
+				return err
+			}
+
+		case opFreq:
+			if _, err := d.readByte(); err != nil {
+				return err
+			}
+
+		case typeString, typeList, typeZSet2:
+			key, err := d.readString()
+			if err != nil {
+				return err
+			}
+			value, err := d.readValue(op)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case db != 0:
+				// 0번 이외의 데이터베이스는 지원하지 않음
+			case !expireAt.IsZero() && !expireAt.After(now):
+				// 이미 만료된 키
+			default:
+				restore(s, key, value, expireAt)
+			}
+			expireAt = time.Time{}
+
+		default:
+			return fmt.Errorf("rdb: unsupported value type or opcode 0x%02x", op)
+		}
+	}
+}
+
+// verifyChecksum은 EOF 뒤의 8바이트 체크섬(리틀 엔디언)을 읽어 지금까지 읽은 내용과 비교합니다.
+// 체크섬이 0이면 저장할 때 계산하지 않은 것이므로 검증하지 않습니다 (rdbchecksum no).
+func (d *decoder) verifyChecksum() error {
+	expected := d.crc
+	buf, err := d.readFull(8)
+	if err != nil {
+		return fmt.Errorf("rdb: reading checksum: %w", err)
+	}
+	stored := binary.LittleEndian.Uint64(buf)
+	if stored != 0 && stored != expected {
+		return fmt.Errorf("rdb: checksum mismatch (file is corrupt): stored %016x, computed %016x", stored, expected)
+	}
+	return nil
+}
+
+// LoadFile은 RDB 파일을 읽어 저장소에 넣습니다.
+// 파일이 없으면 빈 데이터셋으로 시작하도록 아무것도 하지 않고 nil을 반환합니다.
+//
+// 매개변수:
+//   - path: RDB 파일 경로 (dir/dbfilename)
+//   - s: 키를 넣을 저장소
+func LoadFile(path string, s *store.Store) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	return Load(f, s)
+}
@@ -0,0 +1,186 @@
+package rdb
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// encoder는 RDB 스트림에 기본 단위(길이, 문자열)를 씁니다.
+type encoder struct {
+	w *bufio.Writer
+}
+
+// writeLength는 길이를 가장 짧은 길이 인코딩으로 씁니다.
+func (e *encoder) writeLength(n uint64) {
+	switch {
+	case n < 1<<6:
+		e.w.WriteByte(byte(n))
+	case n < 1<<14:
+		e.w.WriteByte(byte(n>>8) | 0x40)
+		e.w.WriteByte(byte(n))
+	case n <= math.MaxUint32:
+		var buf [4]byte
+		binary.BigEndian.PutUint32(buf[:], uint32(n))
+		e.w.WriteByte(0x80)
+		e.w.Write(buf[:])
+	default:
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], n)
+		e.w.WriteByte(0x81)
+		e.w.Write(buf[:])
+	}
+}
+
+// writeString은 문자열을 일반 문자열 인코딩(길이 + 내용)으로 씁니다.
+func (e *encoder) writeString(s string) {
+	e.writeLength(uint64(len(s)))
+	e.w.WriteString(s)
+}
+
+// writeBytes는 문자열 값을 길이와 함께 씁니다 (writeString과 같은 형식).
+func (e *encoder) writeBytes(b []byte) {
+	e.writeLength(uint64(len(b)))
+	e.w.Write(b)
+}
+
+// writeEntry는 키 하나를 (만료 시각,) 값 타입, 키, 값 순서로 씁니다.
+func (e *encoder) writeEntry(entry store.Entry) error {
+	valueType, err := typeOf(entry.Value)
+	if err != nil {
+		return fmt.Errorf("%w for key %q", err, entry.Key)
+	}
+
+	if !entry.ExpireAt.IsZero() {
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], uint64(entry.ExpireAt.UnixMilli()))
+		e.w.WriteByte(opExpireTimeMs)
+		e.w.Write(buf[:])
+	}
+	e.w.WriteByte(valueType)
+	e.writeString(entry.Key)
+	e.writeValue(entry.Value)
+	return nil
+}
+
+// typeOf는 값에 해당하는 값 타입 바이트를 반환합니다.
+func typeOf(value interface{}) (byte, error) {
+	switch value.(type) {
+	case []byte:
+		return typeString, nil
+	case []string:
+		return typeList, nil
+	case []store.ScoredMember:
+		return typeZSet2, nil
+	}
+	return 0, fmt.Errorf("rdb: unsupported value type %T", value)
+}
+
+// writeValue는 값 타입 바이트 뒤에 오는 값을 씁니다. typeOf로 확인한 값이어야 합니다.
+func (e *encoder) writeValue(value interface{}) {
+	switch v := value.(type) {
+	case []byte:
+		e.writeBytes(v)
+	case []string:
+		e.writeLength(uint64(len(v)))
+		for _, elem := range v {
+			e.writeString(elem)
+		}
+	case []store.ScoredMember:
+		e.writeLength(uint64(len(v)))
+		for _, m := range v {
+			var buf [8]byte
+			binary.LittleEndian.PutUint64(buf[:], math.Float64bits(m.Score))
+			e.writeString(m.Member)
+			e.w.Write(buf[:])
+		}
+	}
+}
+
+// Write는 키 목록을 0번 데이터베이스로 하는 RDB 스트림을 씁니다.
+//
+// 매개변수:
+//   - w: RDB를 쓸 대상
+//   - entries: 저장할 키들 (store.Store.Snapshot의 결과)
+func Write(w io.Writer, entries []store.Entry) error {
+	crc := &crc64Writer{}
+	e := &encoder{w: bufio.NewWriter(io.MultiWriter(w, crc))}
+
+	e.w.WriteString(magic + version)
+	for _, aux := range [][2]string{
+		{"redis-ver", "7.2.0"},
+		{"redis-bits", "64"},
+		{"ctime", strconv.FormatInt(time.Now().Unix(), 10)},
+	} {
+		e.w.WriteByte(opAux)
+		e.writeString(aux[0])
+		e.writeString(aux[1])
+	}
+
+	if len(entries) > 0 {
+		expires := 0
+		for _, entry := range entries {
+			if !entry.ExpireAt.IsZero() {
+				expires++
+			}
+		}
+		e.w.WriteByte(opSelectDB)
+		e.writeLength(0)
+		e.w.WriteByte(opResizeDB)
+		e.writeLength(uint64(len(entries)))
+		e.writeLength(uint64(expires))
+
+		for _, entry := range entries {
+			if err := e.writeEntry(entry); err != nil {
+				return err
+			}
+		}
+	}
+
+	e.w.WriteByte(opEOF)
+	if err := e.w.Flush(); err != nil {
+		return err
+	}
+
+	// 체크섬 자신은 체크섬에 포함되지 않으므로 대상에 직접 씀
+	var sum [8]byte
+	binary.LittleEndian.PutUint64(sum[:], crc.sum)
+	_, err := w.Write(sum[:])
+	return err
+}
+
+// SaveFile은 키 목록을 RDB 파일로 저장합니다.
+// 같은 디렉터리의 임시 파일에 먼저 쓴 뒤 이름을 바꾸므로,
+// 저장 도중 실패하더라도 기존 파일은 손상되지 않습니다.
+//
+// 매개변수:
+//   - path: RDB 파일 경로 (dir/dbfilename)
+//   - entries: 저장할 키들
+func SaveFile(path string, entries []store.Entry) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "temp-*.rdb")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // 이름을 바꾼 뒤에는 아무 일도 하지 않음
+
+	if err := Write(tmp, entries); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
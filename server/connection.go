@@ -0,0 +1,176 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+
+	"github.com/codecrafters-io/redis-starter-go/handler"
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+)
+
+// handleConnection은 클라이언트 연결을 처리하는 핵심 함수입니다.
+// 각 클라이언트 연결마다 별도의 고루틴에서 실행되어 동시성을 지원합니다.
+//
+// 연결 처리 과정:
+//  1. RESP 프로토콜 파서와 라이터 초기화
+//  2. 클라이언트 명령어 수신 대기
+//  3. 명령어 파싱 및 핸들러로 위임
+//  4. 결과를 RESP 형식으로 응답
+//  5. 에러 발생 시 연결 종료
+//
+// 연결과 끊김은 클라이언트 ID와 주소를 붙여 debug 수준으로 기록하므로, 기본 설정(notice)에서는 조용합니다.
+// 클라이언트가 연결을 닫거나 서버가 끊은 경우(CLIENT KILL, timeout) 외의 읽기 에러만 경고로 기록합니다.
+//
+// 매개변수:
+//   - conn: 클라이언트와의 네트워크 연결
+//   - registry: 명령어 핸들러 레지스트리
+func handleConnection(conn net.Conn, registry *handler.CommandRegistry) {
+	// 연결 종료 보장 (defer로 확실히 정리)
+	defer conn.Close()
+
+	// 응답은 버퍼에 모았다가 보냄 (파이프라이닝된 명령어들의 응답을 한 번에 전송)
+	writer := protocol.NewBufferedWriter(conn)
+
+	// 연결 상태(Pub/Sub 구독 등)를 보관할 클라이언트 생성
+	// 연결이 끊어지면 남아 있는 구독을 모두 정리
+	client := registry.NewClient(writer)
+	client.SetConn(conn)
+	defer registry.CloseClient(client)
+	log := registry.Logger().With("client", client.ID, "addr", conn.RemoteAddr().String())
+
+	// 연결 수가 maxclients를 넘으면 에러 응답을 보내고 연결 종료
+	if err := registry.AdmitClient(client); err != nil {
+		log.Log(context.Background(), handler.LevelVerbose, "Client rejected", "err", err)
+		client.WithWriter(func(writer *protocol.Writer) {
+			writer.WriteReply(protocol.NewError(err))
+		})
+		return
+	}
+	log.Debug("Client connected")
+
+	// RESP 프로토콜 처리를 위한 파서 초기화
+	// 버퍼의 요청을 모두 처리하고 연결에서 더 읽기 전에 모인 응답을 보냄
+	reader := bufio.NewReader(&flushingReader{conn: conn, client: client})
+	client.SetRequestReader(reader)
+	parser := protocol.NewParser(reader)
+	parser.SetMaxBulkLenFunc(registry.ProtoMaxBulkLen)
+
+	// 클라이언트 명령어 처리 루프
+	// 연결이 끊어질 때까지 계속 명령어를 수신하고 처리
+	for {
+		// RESP 배열 또는 인라인 명령어(netcat, telnet 입력) 파싱
+		value, err := parser.ParseRequest()
+		if err != nil {
+			// 잘못된 형식의 요청: 에러 응답을 보낸 뒤
+			//   - 따옴표가 맞지 않는 인라인 명령어처럼 요청 한 줄을 모두 읽은 경우: 다음 요청을 계속 처리
+			//   - 길이가 잘못되어 다음 요청의 시작을 알 수 없는 경우: 연결 종료
+			var protocolErr *protocol.ProtocolError
+			if errors.As(err, &protocolErr) {
+				client.WithWriter(func(writer *protocol.Writer) {
+					writer.WriteReply(protocol.NewError(err))
+				})
+				if !protocolErr.Fatal {
+					continue
+				}
+				log.Log(context.Background(), handler.LevelVerbose, "Protocol error, closing connection", "err", err)
+				return
+			}
+			// 연결 끊김은 흔한 일이므로 debug 수준으로, 그 밖의 읽기 에러는 경고로 기록
+			if isDisconnect(err) {
+				log.Debug("Client disconnected")
+			} else {
+				log.Warn("Connection error", "err", err)
+			}
+			return
+		}
+
+		// 빈 줄(인라인)이나 빈 배열은 응답 없이 무시 (Redis와 동일)
+		if arr, ok := value.([]interface{}); ok && len(arr) == 0 {
+			continue
+		}
+
+		// 파싱된 데이터가 배열이고 비어있지 않은지 확인
+		// Redis 명령어는 항상 배열 형태로 전송됨
+		// 예: ["SET", "key", "value"] 또는 ["GET", "key"]
+		if arr, ok := value.([]interface{}); ok && len(arr) > 0 {
+			// 첫 번째 요소가 명령어 이름
+			if cmdName, ok := arr[0].(string); ok {
+				// 명령어 인자들 추출 (명령어 이름 제외)
+				args := make([]string, 0, len(arr)-1)
+				for i := 1; i < len(arr); i++ {
+					if arg, ok := arr[i].(string); ok {
+						args = append(args, arg)
+					}
+				}
+
+				// 핸들러 레지스트리를 통해 명령어 실행
+				// 각 명령어별 비즈니스 로직은 개별 핸들러에서 처리
+				result, err := registry.ExecuteForClient(client, cmdName, args)
+
+				// 레플리카 연결(PSYNC 이후)에는 응답을 보내지 않음 (복제 스트림과 섞이지 않도록)
+				if client.IsReplica() {
+					continue
+				}
+
+				// 응답은 클라이언트의 쓰기 잠금 안에서 버퍼에 작성 (다음 요청을 읽기 전에 전송)
+				// (다른 연결의 PUBLISH가 같은 연결에 메시지를 쓰는 것과 섞이지 않도록)
+				client.WithBufferedWriter(func(writer *protocol.Writer) {
+					if err != nil {
+						// 명령어 실행 중 에러 발생
+						// Redis 표준 에러 응답 형식(-<코드> <메시지>)으로 전송
+						writer.WriteReply(protocol.NewError(err))
+					} else {
+						// 명령어 실행 성공: 응답 타입이 연결의 RESP 버전에 맞게 직접 작성
+						writer.WriteReply(result)
+					}
+				})
+
+				// QUIT 또는 서버 종료 중: 응답을 보낸 뒤 연결 종료
+				if client.ShouldClose() || registry.ShuttingDown() {
+					client.Flush()
+					log.Debug("Client disconnected")
+					return
+				}
+			} else {
+				// 명령어 이름이 문자열이 아닌 경우 (프로토콜 오류)
+				client.WithBufferedWriter(func(writer *protocol.Writer) {
+					writer.WriteError("-ERR invalid command format")
+				})
+			}
+		} else {
+			// 배열이 아닌 경우 (프로토콜 오류)
+			client.WithBufferedWriter(func(writer *protocol.Writer) {
+				writer.WriteError("-ERR invalid request format")
+			})
+		}
+	}
+}
+
+// isDisconnect는 연결에서 읽은 에러가 클라이언트나 서버가 연결을 닫아서 생긴 것인지 확인합니다
+// (EOF, 요청 중간의 EOF, 닫힌 연결, 상대가 재설정한 연결).
+func isDisconnect(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// flushingReader는 연결에서 읽기 전에 클라이언트의 응답 버퍼를 보내는 io.Reader입니다.
+//
+// bufio.Reader는 버퍼의 데이터를 모두 쓴 뒤에만 연결에서 읽으므로,
+// 파이프라이닝된 명령어들의 응답은 모아서 한 번에 보내고
+// 클라이언트가 응답을 기다리며 더 보내지 않을 때는 응답이 늦어지지 않습니다.
+type flushingReader struct {
+	conn   net.Conn
+	client *handler.Client
+}
+
+// Read는 응답 버퍼를 보낸 뒤 연결에서 읽습니다.
+func (r *flushingReader) Read(p []byte) (int, error) {
+	if err := r.client.Flush(); err != nil {
+		return 0, err
+	}
+	return r.conn.Read(p)
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// DefaultBind는 Config.Bind의 기본값입니다 (Redis와 동일한 "* -::*").
+// 모든 IPv4 주소에서 연결을 받고, IPv6를 사용할 수 있으면 모든 IPv6 주소에서도 받습니다.
+var DefaultBind = []string{"*", "-::*"}
+
+// listenAll은 bind의 주소마다 port에서 연결을 받을 리스너를 엽니다.
+// port가 0이면 첫 번째 리스너가 고른 포트를 나머지 주소에서도 사용합니다.
+//
+// "-"를 앞에 붙인 주소와 시스템에 없는 주소(EADDRNOTAVAIL, EAFNOSUPPORT)는
+// 열지 못해도 logger에 경고만 기록하고 건너뜁니다.
+//
+// 에러 케이스:
+//   - 그 밖의 이유로 주소를 열지 못한 경우 (이미 사용 중인 포트 등, 먼저 연 리스너는 닫음)
+//   - 연결을 받을 수 있는 주소가 하나도 없는 경우
+func listenAll(bind []string, port int, logger *slog.Logger) ([]net.Listener, error) {
+	if len(bind) == 0 {
+		bind = DefaultBind
+	}
+
+	var listeners []net.Listener
+	for _, addr := range bind {
+		optional := strings.HasPrefix(addr, "-")
+		addr = strings.TrimPrefix(addr, "-")
+		l, err := listen(addr, port)
+		if err != nil {
+			if optional || isUnavailableAddr(err) {
+				logger.Warn("Could not listen on address", "addr", addr, "err", err)
+				continue
+			}
+			for _, l := range listeners {
+				l.Close()
+			}
+			return nil, fmt.Errorf("listen on %s: %w", addr, err)
+		}
+		if port == 0 {
+			port = l.Addr().(*net.TCPAddr).Port
+		}
+		listeners = append(listeners, l)
+	}
+	if len(listeners) == 0 {
+		return nil, fmt.Errorf("no address to listen on (bind %s)", strings.Join(bind, " "))
+	}
+	return listeners, nil
+}
+
+// listen은 bind 주소 하나의 port에서 연결을 받을 리스너를 엽니다.
+// IP 주소는 주소 체계에 맞는 소켓(tcp4, tcp6)으로 열어, 같은 포트의 IPv4와 IPv6 리스너가 겹치지 않게 합니다.
+// IPv6 주소는 대괄호로 감싸도 됩니다 ("[::1]").
+func listen(addr string, port int) (net.Listener, error) {
+	host, network := strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]"), "tcp"
+	switch {
+	case host == "*":
+		host, network = "0.0.0.0", "tcp4"
+	case host == "::*":
+		host, network = "::", "tcp6"
+	default:
+		if ip := net.ParseIP(host); ip != nil && ip.To4() != nil {
+			network = "tcp4"
+		} else if ip != nil {
+			network = "tcp6"
+		}
+	}
+	return net.Listen(network, net.JoinHostPort(host, strconv.Itoa(port)))
+}
+
+// isUnavailableAddr는 시스템에 없는 주소나 주소 체계여서 열지 못한 에러인지 확인합니다 (IPv6가 꺼진 시스템 등).
+func isUnavailableAddr(err error) bool {
+	return errors.Is(err, syscall.EADDRNOTAVAIL) || errors.Is(err, syscall.EAFNOSUPPORT)
+}
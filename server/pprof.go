@@ -0,0 +1,51 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"strconv"
+)
+
+// pprofServer는 실행 중인 서버의 프로파일(CPU, 힙, 고루틴 등)을 HTTP로 제공하는 관리용 엔드포인트입니다 (--pprof-port).
+// 프로파일에는 키와 값이 드러날 수 있으므로 루프백 주소에서만 연결을 받습니다.
+type pprofServer struct {
+	listener net.Listener
+	http     *http.Server
+}
+
+// listenPprof는 127.0.0.1의 port에서 pprof 엔드포인트의 연결을 받을 준비를 합니다.
+// 요청은 serve를 호출해야 처리하기 시작합니다.
+//
+// 에러 케이스:
+//   - 포트에서 연결을 받을 수 없는 경우 (이미 사용 중인 포트 등)
+func listenPprof(port int) (*pprofServer, error) {
+	l, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return nil, err
+	}
+
+	// net/http/pprof가 등록하는 http.DefaultServeMux 대신 pprof 경로만 있는 전용 mux 사용
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return &pprofServer{listener: l, http: &http.Server{Handler: mux}}, nil
+}
+
+// serve는 close가 호출될 때까지 요청을 처리합니다.
+func (p *pprofServer) serve() error {
+	if err := p.http.Serve(p.listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// close는 리스너와 처리 중인 요청(진행 중인 CPU 프로파일 등)의 연결을 닫습니다.
+func (p *pprofServer) close() {
+	p.http.Close()
+	p.listener.Close()
+}
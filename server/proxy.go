@@ -0,0 +1,173 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// proxyHeaderTimeout은 PROXY 헤더를 기다리는 최대 시간입니다.
+// 헤더를 보내지 않는 연결이 고루틴을 계속 붙잡지 않게 합니다.
+const proxyHeaderTimeout = 5 * time.Second
+
+// proxyV1MaxLen은 PROXY v1 헤더 한 줄의 최대 길이입니다 (CRLF 포함, HAProxy 명세).
+const proxyV1MaxLen = 107
+
+// proxyV2Signature는 PROXY v2 헤더의 시작을 나타내는 12바이트입니다.
+var proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// proxyConn은 PROXY 헤더가 알려준 원래 클라이언트 주소를 보고하는 연결입니다.
+// 헤더를 읽으면서 버퍼에 미리 읽은 요청은 Read가 먼저 돌려줍니다.
+type proxyConn struct {
+	net.Conn
+	reader *bufio.Reader
+	remote net.Addr
+	local  net.Addr
+}
+
+// Read는 헤더 뒤에 버퍼에 남은 내용을 먼저 읽고, 그 뒤에는 연결에서 읽습니다.
+func (c *proxyConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
+
+// RemoteAddr는 로드 밸런서가 아닌 원래 클라이언트의 주소를 반환합니다.
+func (c *proxyConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+// LocalAddr는 원래 클라이언트가 연결한 주소를 반환합니다.
+func (c *proxyConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+// acceptProxy는 연결 앞의 PROXY 헤더(v1 또는 v2)를 읽고, 헤더의 주소를 보고하는 연결을 반환합니다.
+// LOCAL 명령(v2)이나 UNKNOWN(v1)처럼 주소가 없는 헤더면 원래 연결의 주소를 그대로 보고합니다.
+//
+// 에러 케이스:
+//   - proxyHeaderTimeout 안에 헤더를 받지 못한 경우
+//   - 헤더가 없거나 형식이 잘못된 경우 (PROXY 프로토콜을 켜면 헤더는 반드시 있어야 함)
+func acceptProxy(conn net.Conn) (net.Conn, error) {
+	conn.SetReadDeadline(time.Now().Add(proxyHeaderTimeout))
+	defer conn.SetReadDeadline(time.Time{})
+
+	reader := bufio.NewReader(conn)
+	remote, local, err := readProxyHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+	if remote == nil {
+		remote, local = conn.RemoteAddr(), conn.LocalAddr()
+	}
+	return &proxyConn{Conn: conn, reader: reader, remote: remote, local: local}, nil
+}
+
+// readProxyHeader는 PROXY 헤더를 읽고 원래 클라이언트의 주소(remote)와 연결한 주소(local)를 반환합니다.
+// 주소가 없는 헤더면 nil 주소를 반환합니다.
+func readProxyHeader(r *bufio.Reader) (remote, local net.Addr, err error) {
+	prefix, err := r.Peek(len(proxyV2Signature))
+	if err != nil {
+		return nil, nil, fmt.Errorf("read PROXY header: %w", err)
+	}
+	switch {
+	case bytes.Equal(prefix, proxyV2Signature):
+		return readProxyV2(r)
+	case bytes.HasPrefix(prefix, []byte("PROXY ")):
+		return readProxyV1(r)
+	}
+	return nil, nil, errors.New("missing PROXY header")
+}
+
+// readProxyV1은 사람이 읽을 수 있는 형식의 PROXY v1 헤더를 읽습니다.
+//
+// 형식: "PROXY TCP4 <원래 ip> <연결한 ip> <원래 포트> <연결한 포트>\r\n" (TCP6도 같음), 또는 "PROXY UNKNOWN ...\r\n"
+func readProxyV1(r *bufio.Reader) (remote, local net.Addr, err error) {
+	var line []byte
+	for !bytes.HasSuffix(line, []byte("\r\n")) {
+		if len(line) >= proxyV1MaxLen {
+			return nil, nil, errors.New("PROXY v1 header too long")
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, nil, fmt.Errorf("read PROXY header: %w", err)
+		}
+		line = append(line, b)
+	}
+
+	fields := strings.Split(strings.TrimSuffix(string(line), "\r\n"), " ")
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, nil, fmt.Errorf("invalid PROXY v1 header %q", line)
+	}
+	src, srcErr := parseProxyV1Addr(fields[2], fields[4], fields[1] == "TCP6")
+	dst, dstErr := parseProxyV1Addr(fields[3], fields[5], fields[1] == "TCP6")
+	if srcErr != nil || dstErr != nil {
+		return nil, nil, fmt.Errorf("invalid PROXY v1 header %q", line)
+	}
+	return src, dst, nil
+}
+
+// parseProxyV1Addr는 PROXY v1 헤더의 주소와 포트를 TCP 주소로 바꿉니다.
+func parseProxyV1Addr(host, port string, v6 bool) (*net.TCPAddr, error) {
+	ip := net.ParseIP(host)
+	if ip == nil || (ip.To4() == nil) != v6 {
+		return nil, fmt.Errorf("invalid address %q", host)
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q", port)
+	}
+	return &net.TCPAddr{IP: ip, Port: int(p)}, nil
+}
+
+// readProxyV2는 이진 형식의 PROXY v2 헤더를 읽습니다.
+//
+// 형식: 12바이트 서명, 버전과 명령(0x20 LOCAL, 0x21 PROXY), 주소 체계와 프로토콜, 주소 길이(2바이트),
+// 주소(IPv4는 4+4+2+2바이트, IPv6는 16+16+2+2바이트) 뒤에 TLV 확장 (무시)
+func readProxyV2(r *bufio.Reader) (remote, local net.Addr, err error) {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, nil, fmt.Errorf("read PROXY header: %w", err)
+	}
+	if header[12]>>4 != 2 {
+		return nil, nil, fmt.Errorf("unsupported PROXY version %d", header[12]>>4)
+	}
+	command, family := header[12]&0x0f, header[13]
+	body := make([]byte, binary.BigEndian.Uint16(header[14:16]))
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, nil, fmt.Errorf("read PROXY header: %w", err)
+	}
+
+	switch {
+	case command == 0x0:
+		// LOCAL: 로드 밸런서 자신의 연결 (상태 확인 등)
+		return nil, nil, nil
+	case command != 0x1:
+		return nil, nil, fmt.Errorf("unsupported PROXY command %#x", command)
+	}
+
+	var ipLen int
+	switch family {
+	case 0x11: // TCP over IPv4
+		ipLen = net.IPv4len
+	case 0x21: // TCP over IPv6
+		ipLen = net.IPv6len
+	default:
+		// UDP나 유닉스 소켓처럼 TCP 주소가 아니면 원래 연결의 주소를 사용
+		return nil, nil, nil
+	}
+	if len(body) < 2*ipLen+4 {
+		return nil, nil, errors.New("PROXY v2 address block too short")
+	}
+	src := &net.TCPAddr{IP: net.IP(body[:ipLen]), Port: int(binary.BigEndian.Uint16(body[2*ipLen:]))}
+	dst := &net.TCPAddr{IP: net.IP(body[ipLen : 2*ipLen]), Port: int(binary.BigEndian.Uint16(body[2*ipLen+2:]))}
+	return src, dst, nil
+}
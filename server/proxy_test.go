@@ -0,0 +1,134 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// proxyV2Header는 TCP over IPv4 PROXY v2 헤더를 만듭니다.
+func proxyV2Header(command byte, src, dst string, srcPort, dstPort uint16) []byte {
+	header := append([]byte(nil), proxyV2Signature...)
+	header = append(header, 0x20|command, 0x11, 0, 12)
+	header = append(header, net.ParseIP(src).To4()...)
+	header = append(header, net.ParseIP(dst).To4()...)
+	header = binary.BigEndian.AppendUint16(header, srcPort)
+	return binary.BigEndian.AppendUint16(header, dstPort)
+}
+
+// TestReadProxyHeader는 PROXY v1, v2 헤더의 파싱을 테스트합니다.
+func TestReadProxyHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		remote  string
+		local   string
+		wantErr bool
+	}{
+		{"V1TCP4", "PROXY TCP4 203.0.113.7 10.0.0.1 51000 6379\r\n", "203.0.113.7:51000", "10.0.0.1:6379", false},
+		{"V1TCP6", "PROXY TCP6 2001:db8::7 2001:db8::1 51000 6379\r\n", "[2001:db8::7]:51000", "[2001:db8::1]:6379", false},
+		{"V1Unknown", "PROXY UNKNOWN\r\n", "", "", false},
+		{"V2Proxy", string(proxyV2Header(0x1, "203.0.113.7", "10.0.0.1", 51000, 6379)), "203.0.113.7:51000", "10.0.0.1:6379", false},
+		{"V2Local", string(proxyV2Header(0x0, "0.0.0.0", "0.0.0.0", 0, 0)), "", "", false},
+		{"Missing", "*1\r\n$4\r\nPING\r\n", "", "", true},
+		{"V1WrongFamily", "PROXY TCP4 2001:db8::7 10.0.0.1 51000 6379\r\n", "", "", true},
+		{"V1BadPort", "PROXY TCP4 203.0.113.7 10.0.0.1 70000 6379\r\n", "", "", true},
+		{"V1TooLong", "PROXY TCP4 " + strings.Repeat("1", 120) + "\r\n", "", "", true},
+		{"V2Truncated", string(proxyV2Header(0x1, "203.0.113.7", "10.0.0.1", 51000, 6379)[:20]), "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote, local, err := readProxyHeader(bufio.NewReader(strings.NewReader(tt.input)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := addrString(remote); got != tt.remote {
+				t.Errorf("Expected remote %q, got %q", tt.remote, got)
+			}
+			if got := addrString(local); got != tt.local {
+				t.Errorf("Expected local %q, got %q", tt.local, got)
+			}
+		})
+	}
+}
+
+// addrString은 주소를 문자열로 바꿉니다 (nil이면 빈 문자열).
+func addrString(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	return addr.String()
+}
+
+// TestProxyProtocol은 PROXY 헤더의 주소가 CLIENT 명령어에 보이는지 테스트합니다.
+func TestProxyProtocol(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.ProxyProtocol = true
+	srv, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Run(context.Background())
+	defer srv.Close()
+
+	// 테스트 케이스 1: 헤더 뒤에 같이 보낸 요청도 처리하고, CLIENT INFO에 원래 주소를 표시
+	conn, err := net.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(conn)
+	request := "PROXY TCP4 203.0.113.7 10.0.0.1 51000 6379\r\n*1\r\n$4\r\nPING\r\n"
+	if got := sendCommand(t, conn, reader, request); got != "+PONG\r\n" {
+		t.Fatalf("Expected +PONG, got %q", got)
+	}
+	header := sendCommand(t, conn, reader, "*2\r\n$6\r\nCLIENT\r\n$4\r\nINFO\r\n")
+	size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "$")))
+	if err != nil {
+		t.Fatalf("Expected a bulk string, got %q", header)
+	}
+	body := make([]byte, size+2)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		t.Fatal(err)
+	}
+	if info := string(body); !strings.Contains(info, "addr=203.0.113.7:51000 laddr=10.0.0.1:6379 ") {
+		t.Errorf("Expected the proxied addresses, got %q", info)
+	}
+
+	// 테스트 케이스 2: CLIENT KILL로 원래 주소의 연결을 끊을 수 있음
+	admin, err := net.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer admin.Close()
+	admin.SetDeadline(time.Now().Add(5 * time.Second))
+	request = "PROXY TCP4 203.0.113.8 10.0.0.1 52000 6379\r\n*4\r\n$6\r\nCLIENT\r\n$4\r\nKILL\r\n$4\r\nADDR\r\n$17\r\n203.0.113.7:51000\r\n"
+	if got := sendCommand(t, admin, bufio.NewReader(admin), request); got != ":1\r\n" {
+		t.Errorf("Expected one client to be killed, got %q", got)
+	}
+	if _, err := reader.ReadByte(); err == nil {
+		t.Error("Expected the killed connection to be closed")
+	}
+
+	// 테스트 케이스 3: 헤더 없이 보낸 연결은 끊음
+	plain, err := net.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer plain.Close()
+	plain.SetDeadline(time.Now().Add(5 * time.Second))
+	plain.Write([]byte("*1\r\n$4\r\nPING\r\n"))
+	if _, err := bufio.NewReader(plain).ReadByte(); err == nil {
+		t.Error("Expected the connection without a PROXY header to be closed")
+	}
+}
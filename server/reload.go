@@ -0,0 +1,187 @@
+package server
+
+import (
+	"errors"
+	"slices"
+)
+
+// reloadParam은 Reload가 비교하는 설정 항목 하나입니다.
+type reloadParam struct {
+	name    string                      // 설정 이름 (redis.conf의 지시어, 경고 메시지에 사용)
+	changed func(old, new *Config) bool // 새 설정에서 값이 바뀌었는지 여부
+	// apply는 새 값을 실행 중인 서버에 적용합니다 (s.mu를 잡은 상태에서 호출).
+	// nil이면 실행 중에 바꿀 수 없는 설정이며, 바뀌어도 경고만 기록하고 무시합니다.
+	apply func(s *Server, cfg *Config)
+	// current는 실행 중인 서버의 현재 값을 cfg에 읽어옵니다 (s.mu를 잡은 상태에서 호출).
+	// CONFIG SET처럼 Reload를 거치지 않고 바뀔 수 있는 설정에 있으며,
+	// Reload는 시작할 때의 값이 아니라 이 값과 새 설정을 비교합니다.
+	current func(s *Server, cfg *Config)
+}
+
+// reloadParams는 Reload가 다루는 설정들입니다.
+var reloadParams = []reloadParam{
+	{name: "save", changed: func(old, new *Config) bool { return !slices.Equal(old.SaveRules, new.SaveRules) },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.SaveRules = cfg.SaveRules
+			s.registry.SetSaveRules(cfg.SaveRules)
+		}},
+	{name: "maxmemory", changed: func(old, new *Config) bool { return old.MaxMemory != new.MaxMemory },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.MaxMemory = cfg.MaxMemory
+			s.registry.SetMaxMemory(cfg.MaxMemory)
+		},
+		current: func(s *Server, cfg *Config) { cfg.MaxMemory = s.registry.MaxMemory() }},
+	{name: "maxmemory-policy", changed: func(old, new *Config) bool { return old.MaxMemoryPolicy != new.MaxMemoryPolicy },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.MaxMemoryPolicy = cfg.MaxMemoryPolicy
+			s.registry.SetMaxMemoryPolicy(cfg.MaxMemoryPolicy)
+		},
+		current: func(s *Server, cfg *Config) { cfg.MaxMemoryPolicy = s.registry.MaxMemoryPolicy() }},
+	{name: "maxmemory-samples", changed: func(old, new *Config) bool { return old.MaxMemorySamples != new.MaxMemorySamples },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.MaxMemorySamples = cfg.MaxMemorySamples
+			s.registry.SetMaxMemorySamples(cfg.MaxMemorySamples)
+		},
+		current: func(s *Server, cfg *Config) { cfg.MaxMemorySamples = s.registry.MaxMemorySamples() }},
+	{name: "lfu-log-factor", changed: func(old, new *Config) bool { return old.LFULogFactor != new.LFULogFactor },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.LFULogFactor = cfg.LFULogFactor
+			s.registry.SetLFULogFactor(cfg.LFULogFactor)
+		},
+		current: func(s *Server, cfg *Config) { cfg.LFULogFactor = s.store.LFULogFactor() }},
+	{name: "lfu-decay-time", changed: func(old, new *Config) bool { return old.LFUDecayTime != new.LFUDecayTime },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.LFUDecayTime = cfg.LFUDecayTime
+			s.registry.SetLFUDecayTime(cfg.LFUDecayTime)
+		},
+		current: func(s *Server, cfg *Config) { cfg.LFUDecayTime = s.store.LFUDecayTime() }},
+	{name: "maxclients", changed: func(old, new *Config) bool { return old.MaxClients != new.MaxClients },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.MaxClients = cfg.MaxClients
+			s.registry.SetMaxClients(cfg.MaxClients)
+		},
+		current: func(s *Server, cfg *Config) { cfg.MaxClients = s.registry.MaxClients() }},
+	{name: "timeout", changed: func(old, new *Config) bool { return old.IdleTimeout != new.IdleTimeout },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.IdleTimeout = cfg.IdleTimeout
+			s.registry.SetClientTimeout(cfg.IdleTimeout)
+		}},
+	{name: "tcp-keepalive", changed: func(old, new *Config) bool { return old.TCPKeepAlive != new.TCPKeepAlive },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.TCPKeepAlive = cfg.TCPKeepAlive
+			s.registry.SetTCPKeepAlive(cfg.TCPKeepAlive)
+		},
+		current: func(s *Server, cfg *Config) { cfg.TCPKeepAlive = s.registry.TCPKeepAlive() }},
+	{name: "requirepass", changed: func(old, new *Config) bool { return old.RequirePass != new.RequirePass },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.RequirePass = cfg.RequirePass
+			s.registry.SetRequirePass(cfg.RequirePass)
+		}},
+	{name: "latency-monitor-threshold", changed: func(old, new *Config) bool { return old.LatencyMonitorThreshold != new.LatencyMonitorThreshold },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.LatencyMonitorThreshold = cfg.LatencyMonitorThreshold
+			s.registry.SetLatencyMonitorThreshold(cfg.LatencyMonitorThreshold)
+		}},
+	{name: "proto-max-bulk-len", changed: func(old, new *Config) bool { return old.ProtoMaxBulkLen != new.ProtoMaxBulkLen },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.ProtoMaxBulkLen = cfg.ProtoMaxBulkLen
+			s.registry.SetProtoMaxBulkLen(cfg.ProtoMaxBulkLen)
+		},
+		current: func(s *Server, cfg *Config) { cfg.ProtoMaxBulkLen = s.registry.ProtoMaxBulkLen() }},
+	{name: "replica-read-only", changed: func(old, new *Config) bool { return old.ReplicaReadOnly != new.ReplicaReadOnly },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.ReplicaReadOnly = cfg.ReplicaReadOnly
+			s.registry.SetReplicaReadOnly(cfg.ReplicaReadOnly)
+		}},
+	{name: "repl-diskless-sync", changed: func(old, new *Config) bool { return old.ReplDisklessSync != new.ReplDisklessSync },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.ReplDisklessSync = cfg.ReplDisklessSync
+			s.registry.SetReplDisklessSync(cfg.ReplDisklessSync)
+		}},
+	{name: "loglevel", changed: func(old, new *Config) bool { return old.LogLevel != new.LogLevel },
+		apply: func(s *Server, cfg *Config) {
+			s.cfg.LogLevel = cfg.LogLevel
+			s.registry.SetLogLevel(cfg.LogLevel)
+		},
+		current: func(s *Server, cfg *Config) { cfg.LogLevel = s.registry.LogLevel() }},
+	{name: "shutdown-timeout", changed: func(old, new *Config) bool { return old.ShutdownTimeout != new.ShutdownTimeout },
+		apply: func(s *Server, cfg *Config) { s.cfg.ShutdownTimeout = cfg.ShutdownTimeout }},
+
+	// 연결을 받는 주소, 데이터 파일, 복제와 클러스터 구성은 재시작해야 바꿀 수 있음
+	{name: "bind", changed: func(old, new *Config) bool { return !slices.Equal(old.Bind, new.Bind) }},
+	{name: "port", changed: func(old, new *Config) bool { return old.Port != new.Port }},
+	{name: "dir", changed: func(old, new *Config) bool { return old.Dir != new.Dir }},
+	{name: "dbfilename", changed: func(old, new *Config) bool { return old.DBFilename != new.DBFilename }},
+	{name: "appendonly", changed: func(old, new *Config) bool { return old.AppendOnly != new.AppendOnly }},
+	{name: "appendfilename", changed: func(old, new *Config) bool { return old.AppendFilename != new.AppendFilename }},
+	{name: "appendfsync", changed: func(old, new *Config) bool { return old.AppendFsync != new.AppendFsync }},
+	{name: "aof-use-rdb-preamble", changed: func(old, new *Config) bool { return old.AOFUseRDBPreamble != new.AOFUseRDBPreamble }},
+	{name: "replicaof", changed: func(old, new *Config) bool { return old.ReplicaOf != new.ReplicaOf }},
+	{name: "cluster-enabled", changed: func(old, new *Config) bool { return old.ClusterEnabled != new.ClusterEnabled }},
+	{name: "pprof-port", changed: func(old, new *Config) bool { return old.PprofPort != new.PprofPort }},
+	{name: "logfile", changed: func(old, new *Config) bool { return old.LogFile != new.LogFile }},
+	{name: "proxy-protocol", changed: func(old, new *Config) bool { return old.ProxyProtocol != new.ProxyProtocol }},
+	{name: "rename-command", changed: func(old, new *Config) bool { return !slices.Equal(old.RenameCommands, new.RenameCommands) }},
+}
+
+// SetConfigLoader는 Reload가 새 설정을 읽어올 함수를 설정합니다.
+// 보통 서버를 시작할 때와 같은 방식으로 설정 파일과 명령줄 인자를 다시 파싱하는 함수입니다.
+// 설정하면 CONFIG RELOAD로도 설정을 다시 불러올 수 있습니다.
+func (s *Server) SetConfigLoader(load func() (Config, error)) {
+	s.mu.Lock()
+	s.loadConfig = load
+	s.mu.Unlock()
+	s.registry.SetConfigReloader(s.Reload)
+}
+
+// Reload는 SetConfigLoader의 함수로 설정을 다시 읽어, 실행 중에 바꿀 수 있는 설정을 적용합니다
+// (SIGHUP, CONFIG RELOAD). 실행 중에 바꿀 수 없는 설정(주소, 데이터 파일, 복제 구성 등)이 바뀌었으면
+// 경고를 기록하고 이전 값을 유지합니다.
+//
+// 에러 케이스:
+//   - 설정을 읽어올 함수가 없는 경우
+//   - 설정을 읽지 못한 경우 (설정은 바뀌지 않음)
+func (s *Server) Reload() error {
+	s.mu.Lock()
+	load := s.loadConfig
+	s.mu.Unlock()
+	if load == nil {
+		return errors.New("server: no config loader")
+	}
+	cfg, err := load()
+	if err != nil {
+		return err
+	}
+	s.applyConfig(cfg)
+	return nil
+}
+
+// applyConfig는 cfg에서 바뀐 설정 중 실행 중에 바꿀 수 있는 것들을 적용하고, 적용한 설정 이름들을 반환합니다.
+func (s *Server) applyConfig(cfg Config) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// CONFIG SET 등으로 바뀐 값을 읽어와, 시작할 때의 값이 아니라 지금 적용된 값과 비교
+	for _, p := range reloadParams {
+		if p.current != nil {
+			p.current(s, &s.cfg)
+		}
+	}
+
+	var applied []string
+	for _, p := range reloadParams {
+		if !p.changed(&s.cfg, &cfg) {
+			continue
+		}
+		if p.apply == nil {
+			s.Logger().Warn("Config cannot be changed without a restart, keeping the current value", "name", p.name)
+			continue
+		}
+		p.apply(s, &cfg)
+		applied = append(applied, p.name)
+	}
+	if len(applied) > 0 {
+		s.Logger().Info("Configuration reloaded", "changed", applied)
+	}
+	return applied
+}
@@ -0,0 +1,614 @@
+// Package server는 Redis 서버 하나를 실행합니다.
+//
+// Server는 TCP 연결을 받아 각 연결을 별도의 고루틴에서 처리하고,
+// 명령어는 handler.CommandRegistry로 실행합니다.
+// app/main.go는 명령줄 설정을 Config로 옮겨 Server를 실행하는 얇은 래퍼이며,
+// 테스트나 다른 프로그램도 같은 방식으로 서버를 띄울 수 있습니다.
+//
+// 사용 예:
+//
+//	cfg := server.DefaultConfig()
+//	cfg.Bind = []string{"127.0.0.1"}
+//	cfg.Port = 0
+//	srv, err := server.New(cfg)
+//	if err != nil { ... }
+//	go srv.Run(ctx)
+//	conn, _ := net.Dial("tcp", srv.Addr().String())
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/aof"
+	"github.com/codecrafters-io/redis-starter-go/handler"
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/rdb"
+	"github.com/codecrafters-io/redis-starter-go/store"
+)
+
+// Config는 서버 설정입니다. 비어 있는 필드는 DefaultConfig의 값과 달리 Go의 제로 값으로 동작하므로,
+// 보통 DefaultConfig에서 필요한 필드만 바꿔서 사용합니다.
+type Config struct {
+	// Bind는 연결을 받을 주소들이고 Port는 모든 주소에서 같이 쓰는 포트입니다 (--bind, --port).
+	// "*"는 모든 IPv4 주소, "::*"는 모든 IPv6 주소이며, "-"를 앞에 붙인 주소는 사용할 수 없어도 무시합니다.
+	// 비어 있으면 DefaultBind를 사용합니다.
+	// Port가 0이면 임의의 빈 포트를 골라 모든 주소에서 사용합니다 (Addr로 확인).
+	Bind []string
+	Port int
+
+	// Dir와 DBFilename은 시작 시 불러오고 SAVE/BGSAVE가 저장하는 RDB 파일입니다 (--dir, --dbfilename).
+	Dir        string
+	DBFilename string
+
+	// SaveRules는 자동 BGSAVE 조건이며, 비어 있지 않으면 종료 시에도 RDB 파일로 저장합니다 (--save).
+	SaveRules []handler.SaveRule
+
+	// AppendOnly이면 RDB 대신 AOF로 데이터셋을 복원하고, 이후의 쓰기 명령어를 AOF에 기록합니다 (--appendonly).
+	AppendOnly        bool
+	AppendFilename    string // AOF 파일 이름 (--appendfilename)
+	AppendFsync       string // AOF fsync 정책: always, everysec, no (--appendfsync)
+	AOFUseRDBPreamble bool   // BGREWRITEAOF가 데이터셋을 RDB 형식으로 기록 (--aof-use-rdb-preamble)
+
+	// ReplicaOf가 "<host> <port>"이면 해당 마스터의 레플리카로 시작합니다 (--replicaof).
+	ReplicaOf        string
+	ReplicaReadOnly  bool // 마스터가 보낸 것 외의 쓰기 명령어를 거부 (--replica-read-only)
+	ReplDisklessSync bool // 전체 동기화의 RDB를 임시 파일 없이 보냄 (--repl-diskless-sync)
+
+	ClusterEnabled          bool   // 클러스터 모드로 동작 (--cluster-enabled)
+	RequirePass             string // 연결이 AUTH로 보내야 하는 비밀번호, 비어 있으면 인증 안 함 (--requirepass)
+	LatencyMonitorThreshold int    // LATENCY가 기록하는 작업의 최소 시간, 밀리초 (--latency-monitor-threshold)
+	ProtoMaxBulkLen         int64  // 요청 인자 하나의 최대 크기, 바이트 (--proto-max-bulk-len)
+	MaxClients              int    // 동시에 연결할 수 있는 클라이언트 수 (--maxclients)
+	MaxMemory               int64  // 데이터셋이 사용할 수 있는 메모리, 바이트, 0이면 제한 없음 (--maxmemory)
+	MaxMemoryPolicy         string // maxmemory를 넘었을 때 지울 키를 고르는 정책 (--maxmemory-policy)
+	MaxMemorySamples        int    // 지울 키를 고를 때 확인하는 키 수 (--maxmemory-samples)
+	LFULogFactor            int    // LFU 카운터가 늘어나는 속도, 클수록 느림 (--lfu-log-factor)
+
+	// LFUDecayTime이 지날 때마다 사용하지 않은 키의 LFU 카운터를 1 줄입니다. 0이면 줄이지 않습니다 (--lfu-decay-time, 분).
+	LFUDecayTime time.Duration
+
+	// IdleTimeout보다 오래 명령어를 보내지 않은 연결은 끊습니다. 0이면 끊지 않습니다 (--timeout).
+	// TCPKeepAlive는 받은 연결의 TCP keepalive 주기이며, 0이면 keepalive를 끕니다 (--tcp-keepalive).
+	IdleTimeout  time.Duration
+	TCPKeepAlive time.Duration
+
+	// ProxyProtocol이면 연결마다 먼저 HAProxy PROXY 헤더(v1, v2)를 읽어, 로드 밸런서가 아닌
+	// 원래 클라이언트의 주소를 CLIENT LIST와 CLIENT KILL에 사용합니다. 헤더가 없는 연결은 끊습니다 (--proxy-protocol).
+	ProxyProtocol bool
+
+	// RenameCommands는 순서대로 적용할 {명령어, 새 이름} 쌍입니다. 새 이름이 빈 문자열이면 명령어를 끕니다 (--rename-command).
+	RenameCommands [][2]string
+
+	// ConfigFile은 서버가 불러온 설정 파일 경로이며, CONFIG REWRITE가 현재 설정을 기록합니다 (비어 있으면 REWRITE는 에러).
+	ConfigFile string
+
+	// LogLevel은 로그에 기록할 최소 수준이고 (--loglevel, debug/verbose/notice/warning/nothing),
+	// LogFile은 로그를 덧붙여 기록할 파일입니다. 비어 있으면 표준 출력에 기록합니다 (--logfile).
+	LogLevel string
+	LogFile  string
+
+	// PprofPort가 0이 아니면 127.0.0.1의 이 포트에서 net/http/pprof의 프로파일을 제공합니다 (--pprof-port).
+	// 대기하는 명령어의 고루틴 누수나 메모리 할당이 많은 곳을 실행 중인 서버에서 조사할 때 사용합니다.
+	PprofPort int
+
+	// ShutdownTimeout은 종료할 때 실행 중인 명령어(대기 중인 BLPOP 등)를 기다리는 시간입니다 (--shutdown-timeout).
+	ShutdownTimeout time.Duration
+}
+
+// DefaultConfig는 Redis의 기본 설정과 같은 Config를 반환합니다.
+func DefaultConfig() Config {
+	return Config{
+		Bind:              DefaultBind,
+		Port:              6379,
+		Dir:               ".",
+		DBFilename:        "dump.rdb",
+		AppendFilename:    "appendonly.aof",
+		AppendFsync:       aof.FsyncEverySec,
+		AOFUseRDBPreamble: true,
+		ReplicaReadOnly:   true,
+		ReplDisklessSync:  true,
+		ProtoMaxBulkLen:   protocol.DefaultMaxBulkLen,
+		MaxClients:        handler.DefaultMaxClients,
+		MaxMemoryPolicy:   handler.MaxMemoryPolicyNoEviction,
+		MaxMemorySamples:  handler.DefaultMaxMemorySamples,
+		LFULogFactor:      store.DefaultLFULogFactor,
+		LFUDecayTime:      store.DefaultLFUDecayTime,
+		TCPKeepAlive:      handler.DefaultTCPKeepAlive,
+		LogLevel:          handler.LogLevelNotice,
+		ShutdownTimeout:   10 * time.Second,
+	}
+}
+
+// Server는 실행 중인 Redis 서버 하나입니다.
+type Server struct {
+	cfg       Config
+	listeners []net.Listener // Bind의 주소마다 하나 (사용할 수 없는 주소는 제외)
+	store     *store.Store
+	registry  *handler.CommandRegistry
+	aof       *aof.Writer  // AppendOnly가 아니면 nil
+	logFile   *os.File     // LogFile이 없으면 nil
+	pprof     *pprofServer // PprofPort가 0이면 nil
+
+	// ready는 Run이 연결을 받기 시작하면 닫히고 (WaitReady), quit은 Close가 호출되면 닫히며,
+	// done은 Run이 반환하면 닫힙니다.
+	// started는 Run이 호출되었는지 여부이고, loadConfig는 Reload가 새 설정을 읽어올 함수입니다.
+	// mu는 started, loadConfig와 Reload가 바꾸는 cfg를 보호합니다.
+	mu         sync.Mutex
+	started    bool
+	loadConfig func() (Config, error)
+	ready      chan struct{}
+	quit       chan struct{}
+	quitOnce   sync.Once
+	done       chan struct{}
+	closeOnce  sync.Once
+	closeErr   error
+}
+
+// New는 cfg.Bind의 주소들에서 연결을 받을 준비를 하고, RDB나 AOF에서 데이터셋을 불러온 Server를 생성합니다.
+// 연결은 Run을 호출해야 처리하기 시작합니다.
+//
+// 에러 케이스:
+//   - cfg.Bind의 주소에서 연결을 받을 수 없는 경우 ("-"를 붙였거나 시스템에 없는 주소는 제외)
+//   - 연결을 받을 수 있는 주소가 하나도 없는 경우
+//   - ReplicaOf 형식이 잘못된 경우
+//   - MaxMemoryPolicy나 LogLevel이 알 수 없는 값인 경우
+//   - LogFile을 열 수 없는 경우
+//   - PprofPort에서 연결을 받을 수 없는 경우
+//   - RDB나 AOF 파일을 불러오지 못한 경우
+//   - RenameCommands의 명령어가 없거나 새 이름이 이미 있는 경우
+func New(cfg Config) (*Server, error) {
+	var host string
+	var masterPort int
+	if cfg.ReplicaOf != "" {
+		var ok bool
+		if host, masterPort, ok = parseReplicaOf(cfg.ReplicaOf); !ok {
+			return nil, fmt.Errorf("invalid replicaof %q", cfg.ReplicaOf)
+		}
+	}
+
+	if _, err := handler.ParseMaxMemoryPolicy(cfg.MaxMemoryPolicy); err != nil {
+		return nil, err
+	}
+
+	// 데이터 저장소 생성
+	dataStore := store.NewStore()
+
+	// 명령어 핸들러 레지스트리 생성
+	// 모든 Redis 명령어들이 여기에 등록됩니다
+	registry := handler.NewCommandRegistry(dataStore)
+	if err := registry.SetLogLevel(cfg.LogLevel); err != nil {
+		return nil, err
+	}
+	var logFile *os.File
+	if cfg.LogFile != "" {
+		f, err := os.OpenFile(cfg.LogFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("open logfile: %w", err)
+		}
+		logFile = f
+		registry.SetLogOutput(f)
+		registry.SetLogFile(cfg.LogFile)
+	}
+
+	listeners, err := listenAll(cfg.Bind, cfg.Port, registry.Logger())
+	if err != nil {
+		if logFile != nil {
+			logFile.Close()
+		}
+		return nil, err
+	}
+
+	registry.SetRDBFile(cfg.Dir, cfg.DBFilename)
+	registry.SetListeningPort(listeners[0].Addr().(*net.TCPAddr).Port)
+	registry.SetReplicaReadOnly(cfg.ReplicaReadOnly)
+	registry.SetReplDisklessSync(cfg.ReplDisklessSync)
+	registry.SetClusterEnabled(cfg.ClusterEnabled)
+	registry.SetLatencyMonitorThreshold(cfg.LatencyMonitorThreshold)
+	registry.SetProtoMaxBulkLen(cfg.ProtoMaxBulkLen)
+	registry.SetMaxClients(cfg.MaxClients)
+	registry.SetMaxMemoryPolicy(cfg.MaxMemoryPolicy)
+	registry.SetMaxMemorySamples(cfg.MaxMemorySamples)
+	registry.SetLFULogFactor(cfg.LFULogFactor)
+	registry.SetLFUDecayTime(cfg.LFUDecayTime)
+	registry.SetMaxMemory(cfg.MaxMemory)
+	registry.StartLRUClock()
+	registry.StartStatsCollector()
+	registry.SetConfigFile(cfg.ConfigFile)
+	registry.SetClientTimeout(cfg.IdleTimeout)
+	registry.SetTCPKeepAlive(cfg.TCPKeepAlive)
+
+	s := &Server{
+		cfg:       cfg,
+		listeners: listeners,
+		store:     dataStore,
+		registry:  registry,
+		logFile:   logFile,
+		ready:     make(chan struct{}),
+		quit:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	if cfg.PprofPort != 0 {
+		p, err := listenPprof(cfg.PprofPort)
+		if err != nil {
+			s.closeListeners()
+			s.closeLog()
+			return nil, fmt.Errorf("pprof: %w", err)
+		}
+		s.pprof = p
+	}
+
+	if cfg.AppendOnly {
+		// AOF의 명령어를 다시 실행해 데이터셋을 복원한 뒤, 이후의 쓰기 명령어를 기록
+		aofPath := filepath.Join(cfg.Dir, cfg.AppendFilename)
+		if err := replayAOF(registry, dataStore, aofPath); err != nil {
+			s.closeListeners()
+			s.closeLog()
+			return nil, fmt.Errorf("load AOF %s: %w", aofPath, err)
+		}
+		aofWriter, err := aof.Open(aofPath, cfg.AppendFsync)
+		if err != nil {
+			s.closeListeners()
+			s.closeLog()
+			return nil, fmt.Errorf("open AOF %s: %w", aofPath, err)
+		}
+		aofWriter.SetRDBPreamble(cfg.AOFUseRDBPreamble)
+		registry.SetAOF(aofWriter)
+		s.aof = aofWriter
+	} else {
+		// 기존 RDB 파일이 있으면 저장소로 불러옴 (파일이 없으면 빈 데이터셋으로 시작)
+		rdbPath := filepath.Join(cfg.Dir, cfg.DBFilename)
+		if err := rdb.LoadFile(rdbPath, dataStore); err != nil {
+			s.closeListeners()
+			s.closeLog()
+			return nil, fmt.Errorf("load RDB file %s: %w", rdbPath, err)
+		}
+	}
+
+	// 데이터셋을 불러온 뒤 자동 저장과 만료된 키 정리 시작
+	registry.DatasetLoaded()
+	registry.SetSaveRules(cfg.SaveRules)
+	registry.StartActiveExpire()
+
+	// 비밀번호는 AOF를 다시 실행한 뒤에 설정 (재실행용 가상 연결은 인증 없이 명령어를 실행해야 함)
+	registry.SetRequirePass(cfg.RequirePass)
+
+	// 명령어 이름도 AOF를 다시 실행한 뒤에 바꿈 (AOF에는 원래 이름으로 기록되어 있음)
+	for _, rename := range cfg.RenameCommands {
+		if err := registry.RenameCommand(rename[0], rename[1]); err != nil {
+			s.closeResources()
+			return nil, fmt.Errorf("rename-command: %w", err)
+		}
+	}
+
+	// 레플리카이면 마스터와 동기화 시작 (데이터셋은 마스터에서 받은 RDB로 교체됨)
+	if cfg.ReplicaOf != "" {
+		registry.ReplicaOf(host, masterPort)
+	}
+	return s, nil
+}
+
+// closeResources는 New가 실패했을 때 그때까지 연 리스너, AOF와 로그 파일을 닫고 백그라운드 고루틴을 멈춥니다.
+func (s *Server) closeResources() {
+	s.closeListeners()
+	s.registry.Shutdown(context.Background(), false)
+	if s.aof != nil {
+		s.aof.Close()
+	}
+	s.closeLog()
+}
+
+// closeLog는 LogFile을 닫습니다. 이후의 로그는 버립니다.
+func (s *Server) closeLog() {
+	if s.logFile != nil {
+		s.registry.SetLogOutput(io.Discard)
+		s.logFile.Close()
+	}
+}
+
+// Addr는 서버가 연결을 받는 첫 번째 주소를 반환합니다 (Config.Port가 0이면 실제로 배정된 포트).
+func (s *Server) Addr() net.Addr {
+	return s.listeners[0].Addr()
+}
+
+// Addrs는 서버가 연결을 받는 모든 주소를 Bind의 순서대로 반환합니다 (사용할 수 없어 무시한 주소는 제외).
+func (s *Server) Addrs() []net.Addr {
+	addrs := make([]net.Addr, len(s.listeners))
+	for i, l := range s.listeners {
+		addrs[i] = l.Addr()
+	}
+	return addrs
+}
+
+// PprofAddr는 pprof 엔드포인트의 주소를 반환합니다 (PprofPort가 0이면 nil).
+func (s *Server) PprofAddr() net.Addr {
+	if s.pprof == nil {
+		return nil
+	}
+	return s.pprof.listener.Addr()
+}
+
+// closeListeners는 모든 리스너와 pprof 엔드포인트를 닫아 새 연결을 받지 않습니다.
+func (s *Server) closeListeners() {
+	for _, l := range s.listeners {
+		l.Close()
+	}
+	if s.pprof != nil {
+		s.pprof.close()
+	}
+}
+
+// Logger는 서버의 로거를 반환합니다 (LogLevel, LogFile 설정을 따름).
+func (s *Server) Logger() *slog.Logger {
+	return s.registry.Logger()
+}
+
+// Registry는 서버의 명령어 레지스트리를 반환합니다.
+// 연결 없이 명령어를 실행하거나 설정을 바꿀 때 사용합니다.
+func (s *Server) Registry() *handler.CommandRegistry {
+	return s.registry
+}
+
+// Run은 ctx가 끝나거나 Close가 호출될 때까지 연결을 받아 처리합니다.
+// 멈추면 새 연결을 받지 않고, 실행 중인 명령어를 ShutdownTimeout만큼 기다린 뒤 연결을 끊고,
+// 자동 저장 조건이 있으면 RDB 파일로 저장한 뒤 반환합니다.
+//
+// 에러 케이스:
+//   - 종료 요청 없이 리스너가 닫힌 경우 (그 밖의 Accept 에러는 기다렸다가 다시 시도, 나머지 리스너도 멈춤)
+//   - 종료 시 RDB 파일 저장이나 AOF 닫기에 실패한 경우
+func (s *Server) Run(ctx context.Context) error {
+	s.mu.Lock()
+	if s.started {
+		s.mu.Unlock()
+		return errors.New("server: Run called more than once")
+	}
+	s.started = true
+	s.mu.Unlock()
+	defer close(s.done)
+
+	// ctx가 끝나거나 Close가 호출되면 리스너들을 닫아 새 연결을 받지 않음
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-s.quit:
+		}
+		s.closeListeners()
+	}()
+
+	if s.pprof != nil {
+		go func() {
+			if err := s.pprof.serve(); err != nil {
+				s.Logger().Warn("pprof endpoint stopped", "err", err)
+			}
+		}()
+	}
+
+	// 리스너마다 수락 루프를 실행하고, 모두 멈출 때까지 기다림
+	// 하나가 예기치 않게 멈추면 서버를 멈춰 나머지 수락 루프도 멈춤
+	close(s.ready)
+	errs := make(chan error, len(s.listeners))
+	for _, l := range s.listeners {
+		go func() { errs <- s.acceptLoop(ctx, l) }()
+	}
+	var acceptErr error
+	for range s.listeners {
+		if err := <-errs; err != nil && acceptErr == nil {
+			acceptErr = err
+			s.stop()
+		}
+	}
+
+	s.stop()
+	if err := s.shutdown(); err != nil {
+		return err
+	}
+	return acceptErr
+}
+
+// acceptLoop는 l에서 연결을 받아 각 연결을 별도의 고루틴에서 처리합니다.
+// 서버가 멈추면 nil을, 종료 요청 없이 리스너가 닫히면 에러를 반환합니다.
+func (s *Server) acceptLoop(ctx context.Context, l net.Listener) error {
+	var delay time.Duration
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil || s.closing() {
+				return nil
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return err
+			}
+			// 파일 디스크립터 부족(EMFILE)이나 연결 중단(ECONNABORTED) 같은 에러는
+			// 서버를 멈추지 않고 잠깐 기다렸다가 다시 시도
+			delay = nextAcceptDelay(delay)
+			s.Logger().Warn("Accept failed, retrying", "err", err, "delay", delay)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+			case <-s.quit:
+			}
+			continue
+		}
+		delay = 0
+
+		// 응답 없이 사라진 상대를 찾아낼 수 있도록 TCP keepalive 설정
+		setKeepAlive(conn, s.registry.TCPKeepAlive())
+
+		// 각 연결을 별도의 고루틴에서 처리
+		// 동시에 여러 클라이언트 연결을 처리할 수 있음
+		go s.ServeConn(conn)
+	}
+}
+
+// WaitReady는 Run이 연결을 받기 시작할 때까지 기다립니다.
+// 리스너는 New에서 이미 열려 있으므로 연결은 그 전에도 할 수 있지만, 처리는 Run이 시작된 뒤에 됩니다.
+//
+// 에러 케이스:
+//   - ctx가 먼저 끝난 경우 (ctx.Err())
+//   - Run이 시작되기 전에 Close가 호출된 경우
+func (s *Server) WaitReady(ctx context.Context) error {
+	select {
+	case <-s.ready:
+		return nil
+	case <-s.quit:
+		select {
+		case <-s.ready:
+			return nil
+		default:
+			return errors.New("server: closed before Run started")
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ServeConn은 리스너를 거치지 않고 받은 연결 하나를 연결이 끊어질 때까지 처리합니다.
+// net.Pipe처럼 네트워크 없이 만든 연결로 서버를 사용할 때 씁니다 (servertest.Pipe).
+// 반환하면 conn은 닫혀 있습니다.
+//
+// ProxyProtocol이 켜져 있으면 먼저 PROXY 헤더를 읽으며, 헤더가 잘못되었으면 연결을 닫습니다.
+func (s *Server) ServeConn(conn net.Conn) {
+	if s.cfg.ProxyProtocol {
+		proxied, err := acceptProxy(conn)
+		if err != nil {
+			s.Logger().Log(context.Background(), handler.LevelVerbose, "Invalid PROXY header", "addr", conn.RemoteAddr().String(), "err", err)
+			conn.Close()
+			return
+		}
+		conn = proxied
+	}
+	handleConnection(conn, s.registry)
+}
+
+// Close는 서버를 멈춥니다. Run이 실행 중이면 Run이 종료 과정을 마치고 반환할 때까지 기다립니다.
+// 여러 번 호출해도 안전하며, 종료 과정의 에러를 반환합니다.
+func (s *Server) Close() error {
+	s.stop()
+	s.closeListeners()
+
+	s.mu.Lock()
+	started := s.started
+	s.mu.Unlock()
+	if started {
+		<-s.done
+	}
+	return s.shutdown()
+}
+
+// stop은 Close가 호출된 것으로 표시합니다 (Run의 수락 루프가 멈춤).
+func (s *Server) stop() {
+	s.quitOnce.Do(func() { close(s.quit) })
+}
+
+// closing은 Close가 호출되었는지 확인합니다.
+func (s *Server) closing() bool {
+	select {
+	case <-s.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+// shutdown은 연결을 정리하고 데이터셋을 저장한 뒤 AOF와 로그 파일을 닫습니다. 한 번만 실행되며, 이후에는 같은 에러를 반환합니다.
+func (s *Server) shutdown() error {
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		timeout, save := s.cfg.ShutdownTimeout, len(s.cfg.SaveRules) > 0
+		s.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		err := s.registry.Shutdown(ctx, save)
+		if s.aof != nil {
+			// 남은 내용을 디스크에 쓰고 닫음
+			err = errors.Join(err, s.aof.Close())
+		}
+		if err != nil {
+			s.Logger().Warn("Error during shutdown", "err", err)
+		}
+		s.Logger().Info("Redis is now ready to exit, bye bye...")
+		s.closeLog()
+		s.closeErr = err
+	})
+	return s.closeErr
+}
+
+// 연결을 받는 데 실패했을 때 다시 시도하기 전에 기다리는 시간의 범위입니다.
+// 실패가 이어질 때마다 두 배씩 늘리고, 연결을 받으면 처음으로 돌아갑니다 (net/http와 같은 방식).
+const (
+	minAcceptDelay = 5 * time.Millisecond
+	maxAcceptDelay = time.Second
+)
+
+// nextAcceptDelay는 직전에 기다린 시간 다음으로 기다릴 시간을 반환합니다.
+func nextAcceptDelay(delay time.Duration) time.Duration {
+	if delay == 0 {
+		return minAcceptDelay
+	}
+	return min(2*delay, maxAcceptDelay)
+}
+
+// setKeepAlive는 TCP 연결에 keepalive(SO_KEEPALIVE)를 설정합니다. period가 0이면 끕니다.
+func setKeepAlive(conn net.Conn, period time.Duration) {
+	tcpConn, ok := conn.(*net.TCPConn)
+	if !ok {
+		return
+	}
+	if period <= 0 {
+		tcpConn.SetKeepAlive(false)
+		return
+	}
+	tcpConn.SetKeepAlive(true)
+	tcpConn.SetKeepAlivePeriod(period)
+}
+
+// parseReplicaOf는 --replicaof 설정("<host> <port>")을 주소와 포트로 나눕니다.
+func parseReplicaOf(value string) (string, int, bool) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return "", 0, false
+	}
+	port, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return "", 0, false
+	}
+	return fields[0], port, true
+}
+
+// replayAOF는 AOF의 명령어들을 레지스트리에서 다시 실행합니다.
+// 파일이 없으면 빈 데이터셋으로 시작하고, 마지막 명령어가 잘린 경우에는
+// 그 앞까지만 복원하고 잘린 부분을 파일에서 잘라냅니다 (Redis의 aof-load-truncated yes와 동일).
+// RDB 프리앰블로 시작하는 AOF는 프리앰블을 저장소에 직접 불러온 뒤 명령어를 실행합니다.
+//
+// 매개변수:
+//   - registry: 명령어를 실행할 레지스트리 (OnPropagate 등록 전이어야 함)
+//   - dataStore: 레지스트리의 저장소 (RDB 프리앰블을 불러올 곳)
+//   - path: AOF 파일 경로
+func replayAOF(registry *handler.CommandRegistry, dataStore *store.Store, path string) error {
+	// MULTI/EXEC를 그대로 재현할 수 있도록 응답을 버리는 가상의 연결로 실행
+	client := registry.NewClient(protocol.NewWriter(io.Discard))
+	defer registry.CloseClient(client)
+
+	err := aof.ReplayFile(path, dataStore, func(args []string) error {
+		_, err := registry.ExecuteForClient(client, args[0], args[1:])
+		return err
+	})
+	var truncated *aof.TruncatedError
+	if errors.As(err, &truncated) {
+		registry.Logger().Warn("AOF is truncated, loaded commands up to the last complete one", "offset", truncated.Offset)
+		return os.Truncate(path, truncated.Offset)
+	}
+	return err
+}
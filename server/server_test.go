@@ -0,0 +1,530 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/handler"
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+)
+
+// newTestConfig는 임시 디렉터리와 임의의 포트를 쓰는 설정을 반환합니다.
+func newTestConfig(t *testing.T) Config {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.Bind = []string{"127.0.0.1"}
+	cfg.Port = 0
+	cfg.Dir = t.TempDir()
+	cfg.ShutdownTimeout = time.Second
+	return cfg
+}
+
+// sendCommand는 RESP 배열로 명령어를 보내고 응답 한 줄을 읽습니다.
+func sendCommand(t *testing.T, conn net.Conn, reader *bufio.Reader, request string) string {
+	t.Helper()
+	if _, err := conn.Write([]byte(request)); err != nil {
+		t.Fatal(err)
+	}
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	return line
+}
+
+// flakyListener는 처음 failures번의 Accept를 일시적인 에러로 실패시키는 리스너입니다.
+type flakyListener struct {
+	net.Listener
+	failures int
+}
+
+func (l *flakyListener) Accept() (net.Conn, error) {
+	if l.failures > 0 {
+		l.failures--
+		return nil, syscall.EMFILE
+	}
+	return l.Listener.Accept()
+}
+
+// TestServer는 Server의 시작과 종료를 테스트합니다.
+func TestServer(t *testing.T) {
+	// 테스트 케이스 1: 임의의 포트에서 연결을 받아 명령어를 실행하고, ctx가 끝나면 Run이 반환
+	t.Run("RunUntilContextDone", func(t *testing.T) {
+		srv, err := New(newTestConfig(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- srv.Run(ctx) }()
+
+		conn, err := net.Dial("tcp", srv.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		if got := sendCommand(t, conn, reader, "*1\r\n$4\r\nPING\r\n"); got != "+PONG\r\n" {
+			t.Errorf("Expected +PONG, got %q", got)
+		}
+
+		cancel()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Run did not return after the context was cancelled")
+		}
+		if _, err := net.Dial("tcp", srv.Addr().String()); err == nil {
+			t.Error("Expected new connections to be refused after shutdown")
+		}
+	})
+
+	// 테스트 케이스 2: Close는 Run이 반환할 때까지 기다리며, 여러 번 호출해도 안전
+	t.Run("Close", func(t *testing.T) {
+		srv, err := New(newTestConfig(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		done := make(chan error, 1)
+		go func() { done <- srv.Run(context.Background()) }()
+
+		// 연결이 처리되면 Run이 시작된 것
+		conn, err := net.Dial("tcp", srv.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		if got := sendCommand(t, conn, bufio.NewReader(conn), "*1\r\n$4\r\nPING\r\n"); got != "+PONG\r\n" {
+			t.Fatalf("Expected +PONG, got %q", got)
+		}
+
+		if err := srv.Close(); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Unexpected error from Run: %v", err)
+			}
+		default:
+			t.Error("Expected Run to have returned when Close returned")
+		}
+		if err := srv.Close(); err != nil {
+			t.Errorf("Unexpected error from second Close: %v", err)
+		}
+	})
+
+	// 테스트 케이스 3: 저장 조건이 있으면 종료 시 RDB 파일로 저장하고, 다음 시작 시 불러옴
+	t.Run("SaveOnShutdownAndReload", func(t *testing.T) {
+		cfg := newTestConfig(t)
+		cfg.SaveRules = []handler.SaveRule{{Seconds: 3600, Changes: 1}}
+		srv, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := srv.Registry().Execute("SET", []string{"key", "value"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := srv.Close(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(cfg.Dir, cfg.DBFilename)); err != nil {
+			t.Fatalf("Expected the RDB file to be written: %v", err)
+		}
+
+		srv, err = New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer srv.Close()
+		result, err := srv.Registry().Execute("GET", []string{"key"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value, ok := result.(protocol.BulkBytes); !ok || string(value) != "value" {
+			t.Errorf("Expected value to be reloaded, got %v", result)
+		}
+	})
+
+	// 테스트 케이스 4: maxclients를 넘는 연결은 에러 응답을 받고 닫히며, INFO에 거부한 연결 수가 보고됨
+	t.Run("MaxClients", func(t *testing.T) {
+		cfg := newTestConfig(t)
+		cfg.MaxClients = 1
+		srv, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		go srv.Run(context.Background())
+		defer srv.Close()
+
+		first, err := net.Dial("tcp", srv.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer first.Close()
+		firstReader := bufio.NewReader(first)
+		if got := sendCommand(t, first, firstReader, "*1\r\n$4\r\nPING\r\n"); got != "+PONG\r\n" {
+			t.Fatalf("Expected +PONG, got %q", got)
+		}
+
+		second, err := net.Dial("tcp", srv.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer second.Close()
+		second.SetDeadline(time.Now().Add(5 * time.Second))
+		secondReader := bufio.NewReader(second)
+		if line, _ := secondReader.ReadString('\n'); line != "-ERR max number of clients reached\r\n" {
+			t.Errorf("Expected max clients error, got %q", line)
+		}
+		if _, err := secondReader.ReadByte(); err == nil {
+			t.Error("Expected the rejected connection to be closed")
+		}
+
+		info, err := srv.Registry().Execute("INFO", []string{"clients", "stats"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, field := range []string{"connected_clients:1\r\n", "maxclients:1\r\n", "rejected_connections:1\r\n", "total_connections_received:1\r\n"} {
+			if !strings.Contains(string(info.(protocol.BulkString)), field) {
+				t.Errorf("Expected INFO to contain %q, got %q", field, info)
+			}
+		}
+	})
+
+	// 테스트 케이스 5: Accept가 일시적으로 실패해도 서버는 멈추지 않고 다시 연결을 받음
+	t.Run("AcceptRetry", func(t *testing.T) {
+		srv, err := New(newTestConfig(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv.listeners[0] = &flakyListener{Listener: srv.listeners[0], failures: 3}
+		done := make(chan error, 1)
+		go func() { done <- srv.Run(context.Background()) }()
+
+		conn, err := net.Dial("tcp", srv.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if got := sendCommand(t, conn, bufio.NewReader(conn), "*1\r\n$4\r\nPING\r\n"); got != "+PONG\r\n" {
+			t.Errorf("Expected +PONG, got %q", got)
+		}
+
+		if err := srv.Close(); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if err := <-done; err != nil {
+			t.Errorf("Unexpected error from Run: %v", err)
+		}
+	})
+
+	// 테스트 케이스 6: 잘못된 설정은 New에서 에러
+	t.Run("InvalidReplicaOf", func(t *testing.T) {
+		cfg := newTestConfig(t)
+		cfg.ReplicaOf = "localhost"
+		if _, err := New(cfg); err == nil {
+			t.Error("Expected an error for an invalid replicaof")
+		}
+	})
+}
+
+// TestReload는 실행 중에 설정을 다시 불러오는 것을 테스트합니다.
+func TestReload(t *testing.T) {
+	cfg := newTestConfig(t)
+	srv, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Run(context.Background())
+	defer srv.Close()
+
+	// 테스트 케이스 1: 설정을 읽어올 함수가 없으면 에러
+	if err := srv.Reload(); err == nil {
+		t.Error("Expected an error without a config loader")
+	}
+
+	// 테스트 케이스 2: 바꿀 수 있는 설정은 적용하고, 주소와 데이터 파일은 유지
+	updated := cfg
+	updated.MaxMemory = 100 << 20
+	updated.SaveRules = []handler.SaveRule{{Seconds: 60, Changes: 10}}
+	updated.MaxClients = 5
+	updated.LogLevel = handler.LogLevelWarning
+	updated.Port = 1
+	updated.DBFilename = "other.rdb"
+	updated.ProtoMaxBulkLen = 1024
+	srv.SetConfigLoader(func() (Config, error) { return updated, nil })
+
+	// 다시 불러오기 전에 연결한 클라이언트
+	conn, err := net.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(conn)
+	if got := sendCommand(t, conn, reader, "*1\r\n$4\r\nPING\r\n"); got != "+PONG\r\n" {
+		t.Fatalf("Expected +PONG, got %q", got)
+	}
+
+	if _, err := srv.Registry().Execute("CONFIG", []string{"RELOAD"}); err != nil {
+		t.Fatalf("CONFIG RELOAD failed: %v", err)
+	}
+	result, err := srv.Registry().Execute("CONFIG", []string{"GET", "*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(map[string]protocol.Reply)
+	pairs := result.(protocol.Map)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		values[string(pairs[i].(protocol.BulkString))] = pairs[i+1]
+	}
+	expected := map[string]string{"maxmemory": "104857600", "save": "60 10", "maxclients": "5", "loglevel": "warning", "dbfilename": "dump.rdb"}
+	for name, value := range expected {
+		if values[name] != protocol.BulkString(value) {
+			t.Errorf("Expected %s to be %q, got %v", name, value, values[name])
+		}
+	}
+	if srv.Addr().(*net.TCPAddr).Port == 1 {
+		t.Error("Expected the listening address to be unchanged")
+	}
+
+	// 이미 연결된 클라이언트에도 바뀐 proto-max-bulk-len이 적용됨
+	if got := sendCommand(t, conn, reader, "*3\r\n$3\r\nSET\r\n$3\r\nkey\r\n$2000\r\n"); got != "-ERR Protocol error: invalid bulk length\r\n" {
+		t.Errorf("Expected invalid bulk length, got %q", got)
+	}
+
+	// 테스트 케이스 3: 설정을 읽지 못하면 에러이고 설정은 그대로
+	srv.SetConfigLoader(func() (Config, error) { return Config{}, os.ErrNotExist })
+	if err := srv.Reload(); err == nil {
+		t.Error("Expected the loader error to be returned")
+	}
+	if maxmemory := srv.Registry().MaxMemory(); maxmemory != 100<<20 {
+		t.Errorf("Expected maxmemory to be unchanged, got %d", maxmemory)
+	}
+
+	// 테스트 케이스 4: CONFIG SET으로 바꾼 값은 설정 파일이 그대로여도 파일의 값으로 되돌림
+	for _, args := range [][]string{{"maxmemory-policy", "allkeys-lru"}, {"maxmemory", "1mb"}, {"loglevel", "debug"}} {
+		if _, err := srv.Registry().Execute("CONFIG", append([]string{"SET"}, args...)); err != nil {
+			t.Fatalf("CONFIG SET %v failed: %v", args, err)
+		}
+	}
+	srv.SetConfigLoader(func() (Config, error) { return updated, nil })
+	if err := srv.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if policy := srv.Registry().MaxMemoryPolicy(); policy != cfg.MaxMemoryPolicy {
+		t.Errorf("Expected maxmemory-policy to be %q, got %q", cfg.MaxMemoryPolicy, policy)
+	}
+	if maxmemory := srv.Registry().MaxMemory(); maxmemory != 100<<20 {
+		t.Errorf("Expected maxmemory to be reset to 100mb, got %d", maxmemory)
+	}
+	if level := srv.Registry().LogLevel(); level != "warning" {
+		t.Errorf("Expected loglevel to be reset to warning, got %q", level)
+	}
+}
+
+// TestBind는 여러 주소에서 연결을 받는 것을 테스트합니다.
+func TestBind(t *testing.T) {
+	// 테스트 케이스 1: IPv4와 IPv6 주소에서 같은 포트로 연결을 받고, CLIENT LIST에 IPv6 주소를 표시
+	t.Run("IPv4AndIPv6", func(t *testing.T) {
+		if l, err := net.Listen("tcp6", "[::1]:0"); err != nil {
+			t.Skip("IPv6 is not available:", err)
+		} else {
+			l.Close()
+		}
+		cfg := newTestConfig(t)
+		cfg.Bind = []string{"127.0.0.1", "::1"}
+		srv, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		go srv.Run(context.Background())
+		defer srv.Close()
+
+		addrs := srv.Addrs()
+		if len(addrs) != 2 || addrs[0].(*net.TCPAddr).Port != addrs[1].(*net.TCPAddr).Port {
+			t.Fatalf("Expected two addresses with the same port, got %v", addrs)
+		}
+		var conn net.Conn
+		var reader *bufio.Reader
+		for _, addr := range addrs {
+			conn, err = net.Dial("tcp", addr.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			reader = bufio.NewReader(conn)
+			if got := sendCommand(t, conn, reader, "*1\r\n$4\r\nPING\r\n"); got != "+PONG\r\n" {
+				t.Errorf("Expected +PONG on %s, got %q", addr, got)
+			}
+		}
+
+		// 마지막 연결(IPv6)의 CLIENT INFO는 대괄호로 감싼 주소를 표시
+		header := sendCommand(t, conn, reader, "*2\r\n$6\r\nCLIENT\r\n$4\r\nINFO\r\n")
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "$")))
+		if err != nil {
+			t.Fatalf("Expected a bulk string, got %q", header)
+		}
+		body := make([]byte, size)
+		if _, err := io.ReadFull(reader, body); err != nil {
+			t.Fatal(err)
+		}
+		info := string(body)
+		if !strings.Contains(info, "addr=[::1]:") || !strings.Contains(info, "laddr="+addrs[1].String()+" ") {
+			t.Errorf("Expected IPv6 addresses in CLIENT INFO, got %q", info)
+		}
+	})
+
+	// 테스트 케이스 2: "-"를 붙인 주소와 시스템에 없는 주소는 건너뜀
+	t.Run("UnavailableAddress", func(t *testing.T) {
+		cfg := newTestConfig(t)
+		cfg.Bind = []string{"198.51.100.1", "127.0.0.1", "-198.51.100.2"}
+		srv, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer srv.Close()
+		if addrs := srv.Addrs(); len(addrs) != 1 || addrs[0].(*net.TCPAddr).IP.String() != "127.0.0.1" {
+			t.Errorf("Expected only 127.0.0.1, got %v", addrs)
+		}
+	})
+
+	// 테스트 케이스 3: 사용 중인 포트나, 연결을 받을 주소가 하나도 없으면 에러
+	t.Run("Errors", func(t *testing.T) {
+		busy, err := net.Listen("tcp4", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer busy.Close()
+
+		cfg := newTestConfig(t)
+		cfg.Port = busy.Addr().(*net.TCPAddr).Port
+		if _, err := New(cfg); err == nil {
+			t.Error("Expected an error for a port in use")
+		}
+
+		cfg = newTestConfig(t)
+		cfg.Bind = []string{"-198.51.100.1"}
+		if _, err := New(cfg); err == nil {
+			t.Error("Expected an error when no address can be used")
+		}
+	})
+}
+
+// TestLogFile은 loglevel과 logfile에 따른 로그 기록을 테스트합니다.
+func TestLogFile(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.LogFile = filepath.Join(cfg.Dir, "redis.log")
+	cfg.LogLevel = handler.LogLevelDebug
+	srv, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Run(context.Background())
+
+	// 테스트 케이스 1: 연결과 끊김은 클라이언트 ID와 주소를 붙여 debug 수준으로 기록
+	conn, err := net.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := bufio.NewReader(conn)
+	sendCommand(t, conn, reader, "*1\r\n$4\r\nPING\r\n")
+	conn.Close()
+
+	// 테스트 케이스 2: loglevel을 올리면 그보다 낮은 수준의 기록은 버림
+	// (끊김이 기록될 때까지 기다린 뒤)
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if data, _ := os.ReadFile(cfg.LogFile); strings.Contains(string(data), "Client disconnected") {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	srv.Registry().SetLogLevel(handler.LogLevelWarning)
+	conn, err = net.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sendCommand(t, conn, bufio.NewReader(conn), "*1\r\n$4\r\nPING\r\n")
+	conn.Close()
+	srv.Close()
+
+	data, err := os.ReadFile(cfg.LogFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(data)
+	addr := conn.LocalAddr().String()
+	if !strings.Contains(log, "level=debug msg=\"Client connected\" client=") {
+		t.Errorf("Expected a debug record for the connection, got %q", log)
+	}
+	if !strings.Contains(log, "msg=\"Client disconnected\"") {
+		t.Errorf("Expected a debug record for the disconnect, got %q", log)
+	}
+	if strings.Contains(log, "addr="+addr) {
+		t.Errorf("Expected no records for the connection after raising loglevel, got %q", log)
+	}
+	if strings.Contains(log, "Connection error") {
+		t.Errorf("Expected the client disconnect to be quiet, got %q", log)
+	}
+}
+
+// TestPprof는 --pprof-port의 프로파일 엔드포인트를 테스트합니다.
+func TestPprof(t *testing.T) {
+	// 테스트 케이스 1: 설정하지 않으면 엔드포인트 없음
+	srv, err := New(newTestConfig(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr := srv.PprofAddr(); addr != nil {
+		t.Errorf("Expected no pprof endpoint by default, got %v", addr)
+	}
+	srv.Close()
+
+	// 테스트 케이스 2: 루프백 주소에서 고루틴 프로파일을 제공하고, 서버를 멈추면 닫힘
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := newTestConfig(t)
+	cfg.PprofPort = l.Addr().(*net.TCPAddr).Port
+	l.Close()
+	srv, err = New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Run(context.Background())
+	url := "http://" + srv.PprofAddr().String() + "/debug/pprof/goroutine?debug=1"
+	if !strings.HasPrefix(srv.PprofAddr().String(), "127.0.0.1:") {
+		t.Errorf("Expected a loopback address, got %v", srv.PprofAddr())
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "goroutine profile:") {
+		t.Errorf("Expected a goroutine profile, got %d %q", resp.StatusCode, body)
+	}
+
+	srv.Close()
+	if _, err := http.Get(url); err == nil {
+		t.Error("Expected the pprof endpoint to be closed")
+	}
+}
@@ -0,0 +1,139 @@
+// Package servertest는 테스트에서 프로세스 안에 Redis 서버를 띄우는 도구입니다 (net/http/httptest와 같은 용도).
+//
+// NewServer는 임의의 빈 포트에서 서버를 실행하고 테스트가 끝나면 종료하므로,
+// 테스트마다 서버를 띄워도 포트가 겹치거나 다른 테스트의 데이터가 섞이지 않습니다.
+// 연결은 TCP(Dial) 또는 네트워크를 거치지 않는 net.Pipe(Pipe)로 만들 수 있습니다.
+//
+// 사용 예:
+//
+//	srv := servertest.NewServer(t)
+//	client := servertest.Dial(t, srv)
+//	if _, err := client.Do("SET", "key", "value"); err != nil {
+//		t.Fatal(err)
+//	}
+package servertest
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/server"
+)
+
+// readyTimeout은 NewServer가 서버가 연결을 받기 시작할 때까지 기다리는 최대 시간입니다.
+const readyTimeout = 5 * time.Second
+
+// NewServer는 127.0.0.1의 임의의 포트에서 서버를 실행하고, 연결을 받기 시작한 뒤 반환합니다.
+// RDB와 AOF 파일은 테스트의 임시 디렉터리에 저장되며, 테스트가 끝나면 서버를 종료합니다.
+//
+// 매개변수:
+//   - t: 서버를 사용하는 테스트
+//   - configure: 서버를 만들기 전에 설정을 바꾸는 함수들 (예: 비밀번호, 저장 조건)
+func NewServer(t testing.TB, configure ...func(cfg *server.Config)) *server.Server {
+	t.Helper()
+	cfg := server.DefaultConfig()
+	cfg.Bind = []string{"127.0.0.1"}
+	cfg.Port = 0
+	cfg.Dir = t.TempDir()
+	cfg.ShutdownTimeout = time.Second
+	for _, fn := range configure {
+		fn(&cfg)
+	}
+
+	srv, err := server.New(cfg)
+	if err != nil {
+		t.Fatalf("servertest: start server: %v", err)
+	}
+	go srv.Run(context.Background())
+	t.Cleanup(func() {
+		if err := srv.Close(); err != nil {
+			t.Errorf("servertest: close server: %v", err)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), readyTimeout)
+	defer cancel()
+	if err := srv.WaitReady(ctx); err != nil {
+		t.Fatalf("servertest: wait for server: %v", err)
+	}
+	return srv
+}
+
+// Client는 서버에 명령어를 보내고 응답을 읽는 테스트용 연결입니다.
+// 한 고루틴에서만 사용해야 합니다.
+type Client struct {
+	conn   net.Conn
+	parser *protocol.Parser
+}
+
+// Dial은 서버에 TCP로 연결한 Client를 반환합니다. 테스트가 끝나면 연결을 닫습니다.
+func Dial(t testing.TB, srv *server.Server) *Client {
+	t.Helper()
+	conn, err := net.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		t.Fatalf("servertest: dial %s: %v", srv.Addr(), err)
+	}
+	return newClient(t, conn)
+}
+
+// Pipe는 네트워크를 거치지 않고 net.Pipe로 서버에 연결한 Client를 반환합니다.
+// 서버 쪽 연결은 srv.ServeConn이 처리하며, 테스트가 끝나면 연결을 닫습니다.
+func Pipe(t testing.TB, srv *server.Server) *Client {
+	t.Helper()
+	serverConn, clientConn := net.Pipe()
+	go srv.ServeConn(serverConn)
+	return newClient(t, clientConn)
+}
+
+// newClient는 conn을 쓰는 Client를 만들고, 테스트가 끝나면 연결을 닫도록 등록합니다.
+func newClient(t testing.TB, conn net.Conn) *Client {
+	t.Cleanup(func() { conn.Close() })
+	return &Client{conn: conn, parser: protocol.NewParser(bufio.NewReader(conn))}
+}
+
+// Do는 명령어를 보내고 응답을 읽습니다.
+// 에러 응답은 *protocol.ErrorReply 에러로 반환합니다.
+//
+// 반환값:
+//   - interface{}: 파싱된 응답 (protocol.Parser.Parse와 같은 타입)
+//   - error: 에러 응답 또는 연결 에러
+func (c *Client) Do(args ...string) (interface{}, error) {
+	if err := c.Send(args...); err != nil {
+		return nil, err
+	}
+	return c.Receive()
+}
+
+// Send는 명령어를 보내기만 하고 응답은 읽지 않습니다.
+// 파이프라이닝이나 BLPOP처럼 응답이 나중에 오는 명령어를 테스트할 때 Receive와 함께 사용합니다.
+func (c *Client) Send(args ...string) error {
+	_, err := c.conn.Write(protocol.EncodeCommand(args...))
+	return err
+}
+
+// Receive는 응답이나 발행 메시지 하나를 읽습니다. 에러 응답은 *protocol.ErrorReply 에러로 반환합니다.
+func (c *Client) Receive() (interface{}, error) {
+	value, err := c.parser.Parse()
+	if err != nil {
+		return nil, err
+	}
+	if reply, ok := value.(*protocol.ErrorReply); ok {
+		return nil, reply
+	}
+	return value, nil
+}
+
+// SetDeadline은 이후의 Send와 Receive에 시간 제한을 둡니다 (net.Conn.SetDeadline).
+// 응답이 오지 않아야 하는 경우를 확인할 때 사용합니다.
+func (c *Client) SetDeadline(t time.Time) error {
+	return c.conn.SetDeadline(t)
+}
+
+// Close는 연결을 닫습니다.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,93 @@
+package servertest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/protocol"
+	"github.com/codecrafters-io/redis-starter-go/server"
+)
+
+// TestClient는 TCP와 net.Pipe 연결로 명령어를 실행할 수 있는지 테스트합니다.
+func TestClient(t *testing.T) {
+	transports := map[string]func(t testing.TB, srv *server.Server) *Client{
+		"TCP":  Dial,
+		"Pipe": Pipe,
+	}
+	for name, connect := range transports {
+		t.Run(name, func(t *testing.T) {
+			srv := NewServer(t)
+			client := connect(t, srv)
+
+			if reply, err := client.Do("SET", "key", "value"); err != nil || reply != "OK" {
+				t.Fatalf("Expected OK, got %v, %v", reply, err)
+			}
+			if reply, err := client.Do("GET", "key"); err != nil || reply != "value" {
+				t.Errorf("Expected value, got %v, %v", reply, err)
+			}
+
+			_, err := client.Do("NOSUCHCOMMAND")
+			var reply *protocol.ErrorReply
+			if !errors.As(err, &reply) || reply.Code() != "ERR" {
+				t.Errorf("Expected an ERR reply, got %v", err)
+			}
+		})
+	}
+}
+
+// TestNewServer는 서버 설정과 서버 사이의 격리를 테스트합니다.
+func TestNewServer(t *testing.T) {
+	// 테스트 케이스 1: 서버마다 데이터셋이 따로 있음
+	t.Run("Isolated", func(t *testing.T) {
+		first := Dial(t, NewServer(t))
+		second := Dial(t, NewServer(t))
+
+		if _, err := first.Do("SET", "key", "value"); err != nil {
+			t.Fatal(err)
+		}
+		if reply, err := second.Do("GET", "key"); err != nil || reply != nil {
+			t.Errorf("Expected the key to be missing on the other server, got %v, %v", reply, err)
+		}
+	})
+
+	// 테스트 케이스 2: configure로 설정을 바꿀 수 있음
+	t.Run("Configure", func(t *testing.T) {
+		srv := NewServer(t, func(cfg *server.Config) { cfg.RequirePass = "secret" })
+		client := Pipe(t, srv)
+
+		_, err := client.Do("GET", "key")
+		var reply *protocol.ErrorReply
+		if !errors.As(err, &reply) || reply.Code() != "NOAUTH" {
+			t.Errorf("Expected a NOAUTH reply, got %v", err)
+		}
+		if reply, err := client.Do("AUTH", "secret"); err != nil || reply != "OK" {
+			t.Errorf("Expected OK, got %v, %v", reply, err)
+		}
+	})
+
+	// 테스트 케이스 3: Send와 Receive로 다른 연결의 명령어를 기다리는 응답을 받음
+	t.Run("BlockingAcrossClients", func(t *testing.T) {
+		srv := NewServer(t)
+		waiter := Dial(t, srv)
+		pusher := Dial(t, srv)
+
+		if err := waiter.Send("BLPOP", "queue", "0"); err != nil {
+			t.Fatal(err)
+		}
+		// BLPOP이 먼저 대기하도록 잠깐 기다린 뒤 값을 넣음 (먼저 넣어도 결과는 같음)
+		time.Sleep(20 * time.Millisecond)
+		if _, err := pusher.Do("RPUSH", "queue", "value"); err != nil {
+			t.Fatal(err)
+		}
+
+		waiter.SetDeadline(time.Now().Add(5 * time.Second))
+		reply, err := waiter.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if arr, ok := reply.([]interface{}); !ok || len(arr) != 2 || arr[0] != "queue" || arr[1] != "value" {
+			t.Errorf("Expected [queue value], got %v", reply)
+		}
+	})
+}
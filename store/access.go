@@ -0,0 +1,264 @@
+package store
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// LFU 카운터의 기본 설정 (Redis와 동일)
+const (
+	// DefaultLFULogFactor는 lfu-log-factor의 기본값입니다. 클수록 카운터가 천천히 올라갑니다.
+	DefaultLFULogFactor = 10
+	// DefaultLFUDecayTime은 lfu-decay-time의 기본값입니다. 사용하지 않은 시간이 이만큼 지날 때마다 카운터가 1 줄어듭니다.
+	DefaultLFUDecayTime = time.Minute
+)
+
+// lfuInitVal은 새 키의 LFU 카운터입니다. 만들자마자 지워지지 않도록 0보다 크게 시작합니다.
+const lfuInitVal = 5
+
+// lfuMaxVal은 LFU 카운터의 최댓값입니다 (8비트).
+const lfuMaxVal = 255
+
+// accessMeta는 키 하나의 사용 기록입니다.
+type accessMeta struct {
+	lastAccess int64 // 마지막으로 사용한 시각 (UnixNano)
+	freq       uint8 // 로그 빈도 카운터 (lastAccess 시점의 값, 읽을 때 감소를 적용)
+}
+
+// KeyAccess는 키의 사용 기록입니다 (maxmemory의 LRU, LFU 정책과 OBJECT IDLETIME, FREQ가 사용).
+type KeyAccess struct {
+	// LastAccess는 키를 마지막으로 사용한 LRU 시계의 시각입니다 (Touch, 한 번도 사용하지 않았으면 만든 시각).
+	LastAccess time.Time
+
+	// Idle은 LRU 시계로 잰 LastAccess 이후의 시간입니다 (OBJECT IDLETIME).
+	Idle time.Duration
+
+	// Freq는 키를 사용한 빈도의 로그 카운터입니다 (0~255, Redis의 LFU 카운터와 동일).
+	// 사용할 때마다 1/((Freq-5)*lfu-log-factor+1)의 확률로 1 늘고,
+	// 사용하지 않은 채 lfu-decay-time이 지날 때마다 1 줄어듭니다.
+	Freq int
+}
+
+// EvictionCandidate는 EvictionSample이 고른 키 하나입니다.
+type EvictionCandidate struct {
+	Key string
+	KeyAccess
+
+	// ExpireAt은 키가 만료되는 시각입니다. 만료 시간이 없으면 zero 값입니다.
+	ExpireAt time.Time
+}
+
+// SetLFULogFactor는 LFU 카운터가 늘어나는 속도를 설정합니다 (lfu-log-factor, 0이면 사용할 때마다 늘어남).
+func (s *Store) SetLFULogFactor(factor int) {
+	s.lfuLogFactor.Store(int64(factor))
+}
+
+// LFULogFactor는 lfu-log-factor를 반환합니다.
+func (s *Store) LFULogFactor() int {
+	return int(s.lfuLogFactor.Load())
+}
+
+// SetLFUDecayTime은 사용하지 않는 키의 LFU 카운터를 1 줄이는 주기를 설정합니다 (lfu-decay-time, 0이면 줄이지 않음).
+func (s *Store) SetLFUDecayTime(period time.Duration) {
+	s.lfuDecayTime.Store(int64(period))
+}
+
+// LFUDecayTime은 lfu-decay-time을 반환합니다.
+func (s *Store) LFUDecayTime() time.Duration {
+	return time.Duration(s.lfuDecayTime.Load())
+}
+
+// SetClock은 LRU 시계를 now로 맞춥니다.
+// 키의 사용 기록은 매번 현재 시각을 읽지 않고 이 시계를 쓰므로, 서버가 주기적으로 현재 시각으로 맞춰야 합니다
+// (맞추는 주기가 사용 기록의 정밀도). 시계를 맞추지 않으면 저장소를 만든 시각에 멈춰 있습니다.
+func (s *Store) SetClock(now time.Time) {
+	s.clock.Store(now.UnixNano())
+}
+
+// Clock은 LRU 시계의 현재 시각을 반환합니다.
+func (s *Store) Clock() time.Time {
+	return time.Unix(0, s.clock.Load())
+}
+
+// Touch는 키들을 방금 사용한 것으로 기록하고, 그중 만료되지 않은 키의 수를 반환합니다 (keyspace_hits, misses).
+// maxmemory의 LRU, LFU 정책이 사용합니다.
+// 명령어가 키를 읽거나 바꾸기 전에 다룰 키마다 호출하며, 없는 키는 무시합니다 (새 키는 만들 때 기록을 시작).
+// 마지막 사용 시각을 LRU 시계로 바꾸고, LFU 카운터는 감소를 적용한 뒤 확률적으로 1 늘립니다.
+//
+// 시간 복잡도: O(N) (N은 키의 개수)
+func (s *Store) Touch(keys ...string) int {
+	now, wall := s.clock.Load(), time.Now()
+	found := 0
+	for _, key := range keys {
+		sh := s.shardFor(key)
+		sh.mu.Lock()
+		if sh.exists(key) {
+			if sh.live(key, wall) {
+				found++
+			}
+			meta, ok := sh.accessed[key]
+			if ok {
+				meta.freq = lfuLogIncr(s.decayedFreq(meta, now), s.LFULogFactor())
+			} else {
+				meta.freq = lfuInitVal
+			}
+			meta.lastAccess = now
+			sh.accessed[key] = meta
+		}
+		sh.mu.Unlock()
+	}
+	return found
+}
+
+// Exists는 keys 중 만료되지 않은 키의 수를 반환합니다. 같은 키를 여러 번 주면 여러 번 셉니다.
+// Touch와 달리 사용 기록을 바꾸지 않습니다.
+//
+// 시간 복잡도: O(N) (N은 키의 개수)
+func (s *Store) Exists(keys ...string) int {
+	now := time.Now()
+	found := 0
+	for _, key := range keys {
+		sh := s.shardFor(key)
+		sh.mu.RLock()
+		if sh.exists(key) && sh.live(key, now) {
+			found++
+		}
+		sh.mu.RUnlock()
+	}
+	return found
+}
+
+// Access는 키의 사용 기록을 반환합니다. 기록을 바꾸지 않습니다 (OBJECT IDLETIME, FREQ).
+// 키가 없거나 만료되었으면 false를 반환합니다.
+func (s *Store) Access(key string) (KeyAccess, bool) {
+	sh := s.shardFor(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	if !sh.exists(key) {
+		return KeyAccess{}, false
+	}
+	if obj, ok := sh.expireStorage[key]; ok && obj.ExpireAt.Before(time.Now()) {
+		return KeyAccess{}, false
+	}
+	return s.keyAccess(sh, key, s.clock.Load()), true
+}
+
+// EvictionSample은 maxmemory를 넘었을 때 지울 키를 고르기 위해 키를 최대 n개 임의로 골라 반환합니다.
+// volatile이면 만료 시간이 있는 키 중에서만 고릅니다 (volatile-* 정책).
+// 임의의 샤드 하나부터 시작해 n개를 채울 때까지 다음 샤드로 넘어가며, 샤드 안에서는 맵 순회 순서(임의)로 고릅니다.
+// 고를 키가 없으면 빈 목록을 반환합니다.
+//
+// 시간 복잡도: O(n)
+func (s *Store) EvictionSample(n int, volatile bool) []EvictionCandidate {
+	now := s.clock.Load()
+	candidates := make([]EvictionCandidate, 0, n)
+	start := rand.IntN(shardCount)
+	for i := 0; i < shardCount && len(candidates) < n; i++ {
+		sh := s.shards[(start+i)%shardCount]
+		sh.mu.RLock()
+		// 타입마다 최대 n개씩 모은 뒤 섞어서, 한 타입의 키만 고르지 않게 함
+		var sample []EvictionCandidate
+		for key, obj := range sh.expireStorage {
+			if len(sample) == n {
+				break
+			}
+			sample = append(sample, EvictionCandidate{Key: key, KeyAccess: s.keyAccess(sh, key, now), ExpireAt: obj.ExpireAt})
+		}
+		if !volatile {
+			sample = sampleKeys(s, sh, sh.storage, n, now, sample)
+			sample = sampleKeys(s, sh, sh.listStorage, n, now, sample)
+			sample = sampleKeys(s, sh, sh.zsetStorage, n, now, sample)
+		}
+		sh.mu.RUnlock()
+
+		rand.Shuffle(len(sample), func(a, b int) { sample[a], sample[b] = sample[b], sample[a] })
+		if remaining := n - len(candidates); len(sample) > remaining {
+			sample = sample[:remaining]
+		}
+		candidates = append(candidates, sample...)
+	}
+	return candidates
+}
+
+// sampleKeys는 저장소 맵 하나에서 만료 시간이 없는 키를 최대 n개 골라 sample에 추가합니다.
+// sh.mu를 잡은 상태에서 호출해야 합니다.
+func sampleKeys[V any](s *Store, sh *shard, storage map[string]V, n int, now int64, sample []EvictionCandidate) []EvictionCandidate {
+	picked := 0
+	for key := range storage {
+		if picked == n {
+			break
+		}
+		sample = append(sample, EvictionCandidate{Key: key, KeyAccess: s.keyAccess(sh, key, now)})
+		picked++
+	}
+	return sample
+}
+
+// initAccess는 새로 만든 키의 사용 기록을 시작합니다 (LRU 시계의 현재 시각, LFU 카운터의 초기값).
+// 이미 있던 키의 값을 바꾸는 경우에는 기록을 유지합니다. sh.mu를 잡은 상태에서 호출해야 합니다.
+func (s *Store) initAccess(sh *shard, key string) {
+	if _, ok := sh.accessed[key]; !ok {
+		sh.accessed[key] = accessMeta{lastAccess: s.clock.Load(), freq: lfuInitVal}
+	}
+}
+
+// keyAccess는 키의 사용 기록을 LRU 시계의 now 시점으로 반환합니다 (LFU 카운터는 감소를 적용).
+// sh.mu를 잡은 상태에서 호출해야 합니다.
+func (s *Store) keyAccess(sh *shard, key string, now int64) KeyAccess {
+	meta, ok := sh.accessed[key]
+	if !ok {
+		meta = accessMeta{lastAccess: now, freq: lfuInitVal}
+	}
+	return KeyAccess{
+		LastAccess: time.Unix(0, meta.lastAccess),
+		Idle:       time.Duration(max(now-meta.lastAccess, 0)),
+		Freq:       int(s.decayedFreq(meta, now)),
+	}
+}
+
+// decayedFreq는 마지막으로 사용한 뒤 lfu-decay-time이 지난 횟수만큼 줄인 LFU 카운터입니다 (Redis의 LFUDecrAndReturn).
+func (s *Store) decayedFreq(meta accessMeta, now int64) uint8 {
+	period := s.lfuDecayTime.Load()
+	if period <= 0 {
+		return meta.freq
+	}
+	periods := (now - meta.lastAccess) / period
+	if periods >= int64(meta.freq) {
+		return 0
+	}
+	return meta.freq - uint8(periods)
+}
+
+// lfuLogIncr는 LFU 카운터를 확률적으로 1 늘립니다 (Redis의 LFULogIncr).
+// 카운터가 클수록 늘어날 확률이 작아지므로, 8비트로 수백만 번의 사용까지 구분할 수 있습니다.
+func lfuLogIncr(counter uint8, logFactor int) uint8 {
+	if counter == lfuMaxVal {
+		return counter
+	}
+	base := max(float64(counter)-lfuInitVal, 0)
+	if rand.Float64() < 1/(base*float64(logFactor)+1) {
+		counter++
+	}
+	return counter
+}
+
+// live는 있는 키가 now에 만료되지 않았는지 확인합니다. mu를 잡은 상태에서 호출해야 합니다.
+func (sh *shard) live(key string, now time.Time) bool {
+	obj, ok := sh.expireStorage[key]
+	return !ok || !obj.ExpireAt.Before(now)
+}
+
+// exists는 키가 저장소 맵 중 하나에 있는지 확인합니다 (만료된 키 포함). mu를 잡은 상태에서 호출해야 합니다.
+func (sh *shard) exists(key string) bool {
+	if _, ok := sh.storage[key]; ok {
+		return true
+	}
+	if _, ok := sh.expireStorage[key]; ok {
+		return true
+	}
+	if _, ok := sh.listStorage[key]; ok {
+		return true
+	}
+	_, ok := sh.zsetStorage[key]
+	return ok
+}
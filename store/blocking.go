@@ -0,0 +1,190 @@
+package store
+
+import (
+	"sync"
+	"time"
+)
+
+// BlockCondition은 대기하는 클라이언트가 기다리는 조건입니다.
+// 같은 키라도 조건이 다르면 따로 대기합니다 (예: 리스트 추가를 기다리는 BLPOP과 스트림 추가를 기다리는 XREAD).
+type BlockCondition uint8
+
+const (
+	BlockOnList       BlockCondition = iota // 리스트에 요소가 추가됨 (BLPOP, BRPOP, BLMOVE)
+	BlockOnZSet                             // 정렬된 집합에 멤버가 추가됨 (BZPOPMIN)
+	BlockOnStream                           // 스트림에 항목이 추가됨 (XREAD BLOCK)
+	BlockOnReplicaAck                       // 레플리카가 복제 오프셋을 확인함 (WAIT, 키는 빈 문자열)
+)
+
+// ServeFunc는 조건이 충족되었을 수 있는 키로 대기 중인 클라이언트의 연산을 실행합니다.
+//
+// 연산에 성공하면 클라이언트에게 보낼 결과와 true를 반환하고,
+// 아직 조건이 맞지 않으면(예: 다른 클라이언트가 먼저 값을 가져감) false를 반환합니다.
+// Signal을 호출한 쪽이 잡은 잠금과 BlockingManager의 잠금 안에서 호출되므로,
+// 그 잠금들을 다시 잡거나 BlockingManager의 메서드를 호출하면 안 됩니다.
+type ServeFunc func(key string) (interface{}, bool)
+
+// blockKey는 대기 큐를 구분하는 (키, 조건) 쌍입니다.
+type blockKey struct {
+	key  string
+	cond BlockCondition
+}
+
+// Waiter는 Enqueue로 등록되어 대기 중인 클라이언트 하나입니다.
+type Waiter struct {
+	manager *BlockingManager
+	keys    []string
+	cond    BlockCondition
+	serve   ServeFunc
+	result  chan interface{} // serve의 결과 (버퍼 1)
+
+	// done은 결과를 받았거나 대기가 끝나 모든 큐에서 제거되었는지 여부입니다 (BlockingManager.mu로 보호).
+	done bool
+}
+
+// BlockingManager는 키의 조건을 기다리는 클라이언트들을 관리합니다 (BLPOP 등의 대기 명령어).
+//
+// (키, 조건)마다 FIFO 큐가 있으며, Signal이 오면 가장 먼저 대기한 클라이언트부터 차례로
+// 연산(ServeFunc)을 실행합니다. 연산의 실행과 결과 전달은 잠금 안에서 이루어지므로,
+// 값은 정확히 한 클라이언트에게 전달되고, 시간 초과나 연결 끊김으로 대기를 끝낸 클라이언트는 값을 가져가지 않습니다.
+//
+// 잠금 순서: 데이터를 보호하는 잠금(저장소의 샤드 잠금 등)을 먼저 잡고 BlockingManager의 잠금을 잡습니다.
+// 값을 추가한 쪽은 그 잠금을 잡은 채로 Signal을 호출하여, 추가한 값이 다른 명령어보다 먼저
+// 가장 오래 기다린 클라이언트에게 전달되도록 합니다.
+// 대기하려는 쪽은 같은 잠금 안에서 조건을 확인하고 Enqueue하여, 그 사이의 Signal을 놓치지 않습니다.
+//
+// 사용 예:
+//
+//	// 대기하려는 쪽 (데이터 잠금 안에서 확인 후 등록, 잠금을 푼 뒤 대기)
+//	waiter := manager.Enqueue(keys, BlockOnList, func(key string) (interface{}, bool) {
+//	    // key의 리스트에서 값을 꺼내 반환 (Signal을 호출한 쪽이 데이터 잠금을 잡고 있음)
+//	})
+//	result, ok := waiter.Wait(timeout, cancel)
+//
+//	// 리스트에 값을 추가한 쪽 (데이터 잠금 안에서)
+//	manager.Signal(key, BlockOnList)
+type BlockingManager struct {
+	mu     sync.Mutex
+	queues map[blockKey][]*Waiter
+}
+
+// NewBlockingManager는 빈 BlockingManager를 생성합니다.
+func NewBlockingManager() *BlockingManager {
+	return &BlockingManager{queues: make(map[blockKey][]*Waiter)}
+}
+
+// Enqueue는 keys 중 하나의 조건이 충족될 때 serve를 실행하도록 클라이언트를 대기 큐에 등록합니다.
+// 반환한 Waiter의 Wait로 결과를 기다립니다.
+//
+// 조건을 확인한 잠금 안에서 호출해야, 확인과 등록 사이에 충족된 조건의 Signal을 놓치지 않습니다.
+//
+// 매개변수:
+//   - keys: 기다릴 키들 (BlockOnReplicaAck처럼 키가 없는 조건은 빈 문자열 하나)
+//   - cond: 기다릴 조건
+//   - serve: Signal을 받은 키로 실행할 연산
+func (m *BlockingManager) Enqueue(keys []string, cond BlockCondition, serve ServeFunc) *Waiter {
+	waiter := &Waiter{
+		manager: m,
+		keys:    keys,
+		cond:    cond,
+		serve:   serve,
+		result:  make(chan interface{}, 1),
+	}
+
+	m.mu.Lock()
+	for _, key := range keys {
+		bk := blockKey{key: key, cond: cond}
+		m.queues[bk] = append(m.queues[bk], waiter)
+	}
+	m.mu.Unlock()
+	return waiter
+}
+
+// Block은 Enqueue 후 Wait합니다. 조건을 따로 확인할 필요가 없는 경우에 사용합니다 (예: WAIT).
+func (m *BlockingManager) Block(keys []string, cond BlockCondition, timeout time.Duration, cancel <-chan struct{}, serve ServeFunc) (interface{}, bool) {
+	return m.Enqueue(keys, cond, serve).Wait(timeout, cancel)
+}
+
+// Wait는 serve가 성공할 때까지 대기합니다.
+// 시간 초과나 취소로 끝나면 모든 큐에서 제거되어 이후의 값을 가져가지 않습니다.
+//
+// 매개변수:
+//   - timeout: 최대 대기 시간 (0 이하이면 무한 대기)
+//   - cancel: 닫히면 대기를 취소 (예: 클라이언트의 연결이 끊어짐, nil이면 취소하지 않음)
+//
+// 반환값:
+//   - interface{}: serve가 반환한 결과
+//   - bool: serve가 성공했으면 true, 시간 초과나 취소로 끝났으면 false
+func (w *Waiter) Wait(timeout time.Duration, cancel <-chan struct{}) (interface{}, bool) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case result := <-w.result:
+		return result, true
+	case <-expired:
+	case <-cancel:
+	}
+
+	m := w.manager
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// 시간 초과와 동시에 결과를 받은 경우 결과를 버리지 않음
+	if w.done {
+		return <-w.result, true
+	}
+	m.removeLocked(w)
+	return nil, false
+}
+
+// Signal은 키의 조건이 충족되었을 수 있음을 알립니다 (예: 리스트에 요소가 추가됨).
+// 그 (키, 조건)을 기다리는 클라이언트들의 연산을 대기한 순서대로 실행하고, 연산이 실패하면 멈춥니다.
+// 연산에 성공한 클라이언트는 기다리던 모든 키의 큐에서 제거됩니다.
+//
+// 조건을 충족시킨 변경과 같은 잠금 안에서 호출해야 합니다 (serve가 그 잠금 안의 데이터를 다룸).
+func (m *BlockingManager) Signal(key string, cond BlockCondition) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bk := blockKey{key: key, cond: cond}
+	for len(m.queues[bk]) > 0 {
+		waiter := m.queues[bk][0]
+		result, ok := waiter.serve(key)
+		if !ok {
+			return
+		}
+		m.removeLocked(waiter)
+		waiter.result <- result
+	}
+}
+
+// Blocked는 (키, 조건)을 기다리는 클라이언트 수를 반환합니다.
+func (m *BlockingManager) Blocked(key string, cond BlockCondition) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.queues[blockKey{key: key, cond: cond}])
+}
+
+// removeLocked는 대기자를 기다리던 모든 큐에서 제거합니다. mu를 잡은 상태에서 호출해야 합니다.
+func (m *BlockingManager) removeLocked(waiter *Waiter) {
+	waiter.done = true
+	for _, key := range waiter.keys {
+		bk := blockKey{key: key, cond: waiter.cond}
+		queue := m.queues[bk]
+		for i, w := range queue {
+			if w == waiter {
+				queue = append(queue[:i], queue[i+1:]...)
+				break
+			}
+		}
+		if len(queue) == 0 {
+			delete(m.queues, bk)
+		} else {
+			m.queues[bk] = queue
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Package store는 Redis 서버의 인메모리 데이터 저장소를 제공합니다.
+//
+// 명령어 핸들러와 handler.RegisterCommand로 등록한 확장 명령어는 모두
+// 같은 *Store를 통해 데이터를 읽고 씁니다.
+//
+// 문자열 값은 []byte로, 키와 리스트, 정렬된 집합의 요소는 Go의 string으로 보관합니다.
+// 둘 다 임의의 바이트열이므로 \r\n이나 NUL 바이트를 포함한 값도
+// RESP 요청에서 응답, RDB, AOF까지 그대로 유지됩니다 (바이너리 안전).
+//
+// 문자열 값은 복사하지 않고 주고받습니다. 저장소는 SET 등에 넘긴 슬라이스를 그대로 보관하므로
+// 호출한 쪽은 넘긴 뒤 고치면 안 되고, GET이 돌려준 슬라이스는 읽기만 해야 합니다
+// (고쳐서 저장하려면 bytes.Clone으로 복사). 저장소는 값의 길이 안쪽을 바꾸지 않으므로
+// (APPEND는 길이 뒤에만 씀) 이미 읽어 간 값은 이후의 쓰기와 관계없이 그대로입니다.
+//
+// 문자열:
+//   - SET(key, value, px): 값 저장 (px가 nil이 아니면 밀리초 단위 만료 시간)
+//   - SETPXAT(key, value, expireAt): 만료 시각을 절대 시간으로 지정해 값 저장
+//   - SETKEEPTTL(key, value): 만료 시각을 유지한 채 값 저장
+//   - GET(key): 값 조회 (없거나 만료되었으면 nil, 빈 문자열은 빈 슬라이스)
+//   - APPEND(key, value): 값 끝에 덧붙이고 덧붙인 뒤 길이 반환 (만료 시각 유지)
+//   - DEL(keys...): 키 삭제, 삭제한 키 개수 반환 (모든 타입)
+//   - FlushAll(): 모든 키 삭제
+//
+// 리스트:
+//   - RPUSH, LPUSH(key, values...): 끝/앞에 추가, 추가 후 길이 반환
+//   - LRANGE(key, start, stop): 범위 조회 (음수 인덱스는 끝에서부터)
+//   - LLEN(key), LPOP(key, count)
+//   - BLPOP(keys), BLPOPBlocking(keys, timeout, cancel): 대기하는 꺼내기
+//
+// 대기:
+//   - Blocking(): 대기 명령어가 함께 쓰는 BlockingManager ((키, 조건)마다 FIFO 큐, 시간 초과, 취소)
+//
+// 정렬된 집합:
+//   - ZADD(key, score, member), ZSCORE(key, member), ZCARD(key), ZRANGE(key, start, stop)
+//
+// 키 공간:
+//   - TYPE(key): 키의 타입 이름 ("string", "list", "zset", "none")
+//   - Keys(): 만료되지 않은 모든 키 (순서 없음)
+//   - Exists(keys...): keys 중 만료되지 않은 키의 수 (사용 기록은 바꾸지 않음)
+//   - Lookup(key): 키 하나의 Entry (DUMP, MIGRATE 등에 사용)
+//   - OnKeyModified(fn): 키가 변경되거나 삭제될 때마다 호출될 함수 등록
+//   - OnKeyExpired(fn): 만료된 키가 삭제될 때마다 호출될 함수 등록
+//   - ActiveExpire(sample): 만료 시간이 있는 키를 sample개까지 확인해 만료된 키를 삭제
+//   - SetKeepExpired(keep): 만료된 키를 없는 것으로만 보고하고 삭제하지 않음 (레플리카)
+//   - Snapshot(): 키 공간 전체의 Entry 목록 (SAVE 등에 사용)
+//   - BeginSnapshot(): 값을 복사하지 않는 시점 고정 뷰, Entries()는 다른 고루틴에서 호출 가능 (BGSAVE 등)
+//
+// 메모리 한도:
+//   - SetClock(now), Clock(): 사용 기록에 쓰는 LRU 시계 (서버가 주기적으로 맞춤)
+//   - Touch(keys...): 키를 방금 사용한 것으로 기록하고 찾은 키의 수를 반환 (LRU의 마지막 사용 시각, LFU의 빈도 카운터)
+//   - Access(key): 키의 사용 기록 (OBJECT IDLETIME, FREQ)
+//   - SetLFULogFactor, SetLFUDecayTime: LFU 카운터가 늘어나는 속도와 줄어드는 주기
+//   - EvictionSample(n, volatile): maxmemory를 넘었을 때 지울 후보로 키를 n개까지 임의로 고름
+//   - UsedMemory(): 데이터셋 전체의 메모리 사용량 (쓰기와 삭제마다 바뀐 만큼 갱신, O(1))
+//   - MemoryUsage(key): 키 하나의 메모리 사용량 (MEMORY USAGE)
+//   - ObjectEncoding(value): 같은 값을 Redis가 저장할 때의 인코딩 이름 (OBJECT ENCODING)
+//
+// 동시성: 모든 메서드는 여러 고루틴에서 동시에 호출해도 안전합니다 (메서드 하나가 원자적).
+// 키 공간은 키의 해시값으로 여러 샤드에 나뉘고 샤드마다 잠금이 있으므로,
+// 서로 다른 샤드의 키를 다루는 명령어는 동시에 실행됩니다.
+// 여러 키를 다루는 메서드(DEL 등)는 샤드 번호 순서대로 잠금을 잡습니다.
+// 여러 메서드 호출을 묶어 원자적으로 실행해야 하는 경우(MULTI/EXEC, 스크립트)는
+// CommandRegistry의 실행 잠금이 다른 명령어의 실행을 막습니다.
+// OnKeyModified, OnKeyExpired는 명령어를 실행하기 전에 등록해야 합니다.
+package store
@@ -0,0 +1,220 @@
+package store
+
+import (
+	"strconv"
+	"time"
+)
+
+// 키 하나의 메모리 사용량을 추정할 때 쓰는 Redis 내부 구조체의 크기 (64비트 기준, 바이트)
+const (
+	dictEntrySize     = 24  // 키 공간 해시 테이블의 항목 (키, 값, 다음 항목 포인터)
+	redisObjectSize   = 16  // 값 객체 (타입, 인코딩, LRU, 참조 수, 포인터)
+	listpackOverhead  = 7   // listpack 헤더(6바이트)와 끝 표시(1바이트)
+	quicklistNodeSize = 32  // quicklist 노드 (노드마다 listpack 하나)
+	quicklistNodeSpan = 128 // quicklist 노드 하나에 들어가는 평균 요소 수
+	skiplistNodeSize  = 48  // 정렬된 집합의 skiplist 노드 (멤버, 점수, 뒤 포인터, 평균 레벨)
+)
+
+// 작은 값에 쓰는 압축 인코딩의 한도 (Redis 기본 설정과 동일)
+const (
+	embstrSizeLimit         = 44   // 문자열: 이 길이 이하면 embstr
+	listListpackSizeLimit   = 8192 // 리스트: 요소 길이의 합이 이 이하면 listpack (list-max-listpack-size -2)
+	zsetListpackEntries     = 128  // 정렬된 집합: 요소 수가 이 이하이고 (zset-max-listpack-entries)
+	zsetListpackMemberLimit = 64   // 멤버의 길이가 모두 이 이하면 listpack (zset-max-listpack-value)
+)
+
+// keyMemory는 키 하나의 메모리 사용량 기록입니다. 값을 바꿀 때마다 바뀐 부분만큼 갱신합니다.
+// 리스트와 정렬된 집합은 인코딩이 바뀌어도 요소를 다시 훑지 않도록 요소별 크기의 합을 따로 보관합니다.
+type keyMemory struct {
+	size int64 // 키 전체의 크기 (MEMORY USAGE)
+
+	listpack int // 요소들을 listpack 항목으로 저장할 때의 크기 합 (정렬된 집합은 멤버와 점수 두 항목)
+	skiplist int // 정렬된 집합의 멤버들을 skiplist와 해시 테이블로 저장할 때의 크기 합
+	raw      int // 리스트 요소 길이의 합 (quicklist로 바뀌는 시점 판단)
+	long     int // 정렬된 집합에서 zsetListpackMemberLimit보다 긴 멤버의 수
+}
+
+// UsedMemory는 데이터셋 전체의 메모리 사용량입니다 (바이트, 모든 키의 MemoryUsage 합).
+// 쓰기와 삭제마다 바뀐 만큼 갱신한 값이므로 키 개수와 관계없이 바로 반환합니다.
+// 아직 지우지 않은 만료된 키도 포함합니다.
+//
+// 시간 복잡도: O(1)
+func (s *Store) UsedMemory() int64 {
+	return s.used.Load()
+}
+
+// MemoryUsage는 키 하나를 Redis가 저장할 때 쓰는 메모리의 추정치입니다 (바이트, MEMORY USAGE).
+// 키 공간의 항목, 키 문자열, 값 객체, 만료 시각 항목과 ObjectEncoding에 따른 값의 크기를 더합니다.
+// 키가 없거나 만료되었으면 false를 반환합니다.
+//
+// 시간 복잡도: O(1)
+func (s *Store) MemoryUsage(key string) (int64, bool) {
+	sh := s.shardFor(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	if obj, ok := sh.expireStorage[key]; ok && obj.ExpireAt.Before(time.Now()) {
+		return 0, false
+	}
+	m, ok := sh.memory[key]
+	return m.size, ok
+}
+
+// ObjectEncoding은 Redis가 값을 저장할 때 사용하는 인코딩 이름을 반환합니다 (OBJECT ENCODING).
+// 이 저장소는 인코딩을 구분하지 않으므로, 같은 값에 대해 Redis가 고를 인코딩을 계산합니다.
+//   - 문자열: int (정수로 표현 가능), embstr (44바이트 이하), raw
+//   - 리스트: listpack (작은 리스트), quicklist
+//   - 정렬된 집합: listpack (작은 집합), skiplist
+//
+// value는 Entry.Value와 같은 타입입니다.
+func ObjectEncoding(value interface{}) string {
+	switch v := value.(type) {
+	case []byte:
+		if isIntBytes(v) {
+			return "int"
+		}
+		if len(v) <= embstrSizeLimit {
+			return "embstr"
+		}
+		return "raw"
+
+	case []string:
+		size := 0
+		for _, element := range v {
+			size += len(element)
+		}
+		if size <= listListpackSizeLimit {
+			return "listpack"
+		}
+		return "quicklist"
+
+	case []ScoredMember:
+		if len(v) > zsetListpackEntries {
+			return "skiplist"
+		}
+		for _, m := range v {
+			if len(m.Member) > zsetListpackMemberLimit {
+				return "skiplist"
+			}
+		}
+		return "listpack"
+	}
+	return "unknown"
+}
+
+// setMemory는 키의 메모리 기록을 m으로 바꾸고, 저장소 전체의 합계를 바뀐 만큼 갱신합니다.
+// mu를 잡은 상태에서 호출해야 합니다.
+func (sh *shard) setMemory(key string, m keyMemory) {
+	sh.used.Add(m.size - sh.memory[key].size)
+	sh.memory[key] = m
+}
+
+// deleteMemory는 지운 키의 메모리 기록을 지우고 합계에서 뺍니다. mu를 잡은 상태에서 호출해야 합니다.
+func (sh *shard) deleteMemory(key string) {
+	if m, ok := sh.memory[key]; ok {
+		sh.used.Add(-m.size)
+		delete(sh.memory, key)
+	}
+}
+
+// keyOverhead는 값과 관계없이 키 하나가 차지하는 크기입니다 (키 공간의 항목, 키 문자열, 값 객체, 만료 시각 항목).
+func keyOverhead(key string, volatile bool) int64 {
+	size := dictEntrySize + sdsSize(len(key)) + redisObjectSize
+	if volatile {
+		size += dictEntrySize
+	}
+	return int64(size)
+}
+
+// stringMemory는 문자열 키의 메모리 기록입니다. 정수로 표현할 수 있는 값은 값 객체 안에 저장되므로 따로 세지 않습니다.
+func stringMemory(key string, value []byte, volatile bool) keyMemory {
+	size := keyOverhead(key, volatile)
+	if !isIntBytes(value) {
+		size += int64(sdsSize(len(value)))
+	}
+	return keyMemory{size: size}
+}
+
+// addListElements는 리스트에 요소들이 추가되었거나(sign 1) 제거되었음(sign -1)을 기록하고,
+// 요소 수가 length가 된 리스트의 크기를 다시 계산합니다.
+func (m *keyMemory) addListElements(key string, elements []string, sign, length int) {
+	for _, element := range elements {
+		m.listpack += sign * listpackEntrySize(len(element))
+		m.raw += sign * len(element)
+	}
+	size := int(keyOverhead(key, false)) + listpackOverhead + m.listpack
+	if m.raw > listListpackSizeLimit {
+		size += (length/quicklistNodeSpan + 1) * (quicklistNodeSize + listpackOverhead)
+	}
+	m.size = int64(size)
+}
+
+// addMember는 정렬된 집합에 멤버가 추가되었거나 점수가 바뀌었음을 기록하고,
+// 멤버 수가 length가 된 집합의 크기를 다시 계산합니다. 점수만 바뀌었으면 existed이고 old가 이전 점수입니다.
+func (m *keyMemory) addMember(key, member string, score, old float64, existed bool, length int) {
+	if existed {
+		m.listpack -= listpackEntrySize(len(formatScore(old)))
+	} else {
+		m.listpack += listpackEntrySize(len(member))
+		m.skiplist += skiplistNodeSize + dictEntrySize + sdsSize(len(member))
+		if len(member) > zsetListpackMemberLimit {
+			m.long++
+		}
+	}
+	m.listpack += listpackEntrySize(len(formatScore(score)))
+
+	size := int(keyOverhead(key, false)) + listpackOverhead
+	if length > zsetListpackEntries || m.long > 0 {
+		size += m.skiplist
+	} else {
+		size += m.listpack
+	}
+	m.size = int64(size)
+}
+
+// formatScore는 listpack에 저장하는 점수의 문자열 표현입니다.
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'g', 17, 64)
+}
+
+// isIntString은 문자열이 Redis가 정수 인코딩으로 저장하는 값인지 확인합니다 (앞의 0이나 + 기호가 없는 64비트 정수).
+func isIntString(s string) bool {
+	n, err := strconv.ParseInt(s, 10, 64)
+	return err == nil && strconv.FormatInt(n, 10) == s
+}
+
+// maxIntLen은 64비트 정수의 십진 표현 중 가장 긴 길이입니다 (-9223372036854775808).
+const maxIntLen = 20
+
+// isIntBytes는 바이트열 값이 정수 인코딩으로 저장되는지 확인합니다.
+// 64비트 정수는 20바이트를 넘지 않으므로, 큰 값은 문자열로 바꾸지 않고 바로 제외합니다.
+func isIntBytes(b []byte) bool {
+	return len(b) <= maxIntLen && isIntString(string(b))
+}
+
+// sdsSize는 길이가 n인 문자열을 Redis의 SDS(길이 헤더가 붙은 문자열)로 저장할 때의 크기입니다.
+// 헤더는 문자열 길이에 따라 1, 3, 5, 9바이트이고 끝에 널 문자가 붙습니다.
+func sdsSize(n int) int {
+	switch {
+	case n < 1<<5:
+		return n + 2
+	case n < 1<<8:
+		return n + 4
+	case n < 1<<16:
+		return n + 6
+	default:
+		return n + 10
+	}
+}
+
+// listpackEntrySize는 길이가 n인 문자열을 listpack 항목으로 저장할 때의 크기입니다
+// (인코딩 헤더, 내용, 역방향 탐색을 위한 길이).
+func listpackEntrySize(n int) int {
+	switch {
+	case n < 64:
+		return n + 2
+	case n < 4096:
+		return n + 4
+	default:
+		return n + 10
+	}
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestUsedMemory는 쓰기, 덮어쓰기, 삭제, 만료에 따라 UsedMemory가 늘고 주는지 테스트합니다.
+func TestUsedMemory(t *testing.T) {
+	s := NewStore()
+	if used := s.UsedMemory(); used != 0 {
+		t.Fatalf("Expected 0 bytes for an empty store, got %d", used)
+	}
+
+	// 테스트 케이스 1: SET하면 키의 MEMORY USAGE만큼 늘어남
+	s.SET("key", []byte("value"), nil)
+	afterSet := s.UsedMemory()
+	if usage, ok := s.MemoryUsage("key"); !ok || afterSet != usage || afterSet <= 0 {
+		t.Fatalf("Expected used memory to equal the key's usage, got %d and %d", afterSet, usage)
+	}
+
+	// 테스트 케이스 2: 더 긴 값으로 덮어쓰면 늘고, 짧은 값으로 덮어쓰면 줄어듦
+	s.SET("key", []byte(strings.Repeat("x", 1000)), nil)
+	afterGrow := s.UsedMemory()
+	if afterGrow <= afterSet+900 {
+		t.Errorf("Expected used memory to grow by about 1000 bytes, got %d -> %d", afterSet, afterGrow)
+	}
+	s.SET("key", []byte("value"), nil)
+	if used := s.UsedMemory(); used != afterSet {
+		t.Errorf("Expected used memory to return to %d, got %d", afterSet, used)
+	}
+
+	// 테스트 케이스 3: 다른 키는 합계에 더해지고, DEL하면 그만큼 줄어듦
+	s.RPUSH("list", "a", "b", "c")
+	withList := s.UsedMemory()
+	if usage, _ := s.MemoryUsage("list"); withList != afterSet+usage {
+		t.Errorf("Expected %d, got %d", afterSet+usage, withList)
+	}
+	if deleted := s.DEL("list"); deleted != 1 {
+		t.Fatalf("Expected 1 deleted key, got %d", deleted)
+	}
+	if used := s.UsedMemory(); used != afterSet {
+		t.Errorf("Expected used memory to return to %d after DEL, got %d", afterSet, used)
+	}
+
+	// 테스트 케이스 4: 만료 시각이 있는 키는 만료 항목만큼 더 크고, 만료되어 지워지면 줄어듦
+	s.SETPXAT("key", []byte("value"), time.Now().Add(-time.Second))
+	if used := s.UsedMemory(); used <= afterSet {
+		t.Errorf("Expected the expire entry to add memory, got %d (without expire %d)", used, afterSet)
+	}
+	if value := s.GET("key"); value != nil {
+		t.Fatalf("Expected the key to be expired, got %q", value)
+	}
+	if used := s.UsedMemory(); used != 0 {
+		t.Errorf("Expected 0 bytes after the key expired, got %d", used)
+	}
+
+	// 테스트 케이스 5: 주기적인 만료 처리로 지운 키도 줄어듦
+	for _, key := range []string{"a", "b", "c"} {
+		s.SETPXAT(key, []byte("value"), time.Now().Add(-time.Second))
+	}
+	if s.UsedMemory() <= 0 {
+		t.Fatal("Expected expired keys to use memory until they are removed")
+	}
+	if _, expired := s.ActiveExpire(100); expired != 3 {
+		t.Fatalf("Expected 3 expired keys, got %d", expired)
+	}
+	if used := s.UsedMemory(); used != 0 {
+		t.Errorf("Expected 0 bytes after active expire, got %d", used)
+	}
+
+	// 테스트 케이스 6: FlushAll 후에는 0
+	s.SET("key", []byte("value"), nil)
+	s.RPUSH("list", "a")
+	s.FlushAll()
+	if used := s.UsedMemory(); used != 0 {
+		t.Errorf("Expected 0 bytes after FlushAll, got %d", used)
+	}
+}
@@ -0,0 +1,99 @@
+package store
+
+import (
+	"hash/maphash"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// shardCount는 키 공간을 나누는 샤드의 개수입니다 (2의 거듭제곱).
+// 서로 다른 샤드의 키를 다루는 명령어는 여러 코어에서 동시에 실행됩니다.
+const shardCount = 16
+
+// shard는 키 공간의 한 부분입니다. 키는 해시값으로 샤드가 정해집니다.
+//
+// 키 하나를 다루는 연산은 그 키의 샤드 잠금만 잡고,
+// 여러 키를 다루는 연산은 샤드 번호 순서대로 잠금을 잡아 교착 상태를 피합니다.
+type shard struct {
+	// mu는 아래의 저장소 맵들을 보호합니다.
+	// 변경 알림(keyModifiedHooks, keyExpiredHooks)은 잠금을 푼 뒤에 호출합니다.
+	mu sync.RWMutex
+
+	storage       map[string][]byte       // Regular key-value storage
+	expireStorage map[string]ValueWithTTL // Storage with TTL
+	listStorage   map[string][]string     // List storage
+	zsetStorage   map[string]*SortedSet   // Sorted set storage
+
+	// accessed는 키의 사용 기록입니다 (Touch, 키를 지우면 함께 지움).
+	accessed map[string]accessMeta
+
+	// memory는 키의 메모리 사용량 기록이고, used는 저장소 전체의 합계입니다 (Store.used, 모든 샤드가 공유).
+	memory map[string]keyMemory
+	used   *atomic.Int64
+}
+
+// newShard는 빈 샤드를 생성합니다. used는 저장소 전체의 메모리 사용량 합계입니다.
+func newShard(used *atomic.Int64) *shard {
+	return &shard{
+		storage:       make(map[string][]byte),
+		expireStorage: make(map[string]ValueWithTTL),
+		listStorage:   make(map[string][]string),
+		zsetStorage:   make(map[string]*SortedSet),
+		accessed:      make(map[string]accessMeta),
+		memory:        make(map[string]keyMemory),
+		used:          used,
+	}
+}
+
+// size는 샤드의 키 개수입니다 (만료된 키 포함). mu를 잡은 상태에서 호출해야 합니다.
+func (sh *shard) size() int {
+	return len(sh.storage) + len(sh.expireStorage) + len(sh.listStorage) + len(sh.zsetStorage)
+}
+
+// shardIndex는 키가 속한 샤드의 번호를 반환합니다.
+func (s *Store) shardIndex(key string) int {
+	return int(maphash.String(s.seed, key) & (shardCount - 1))
+}
+
+// shardFor는 키가 속한 샤드를 반환합니다.
+func (s *Store) shardFor(key string) *shard {
+	return s.shards[s.shardIndex(key)]
+}
+
+// lockKeys는 키들이 속한 샤드들을 샤드 번호 순서대로 쓰기 잠금하고, 잠근 샤드들을 반환합니다.
+// 같은 샤드는 한 번만 잠그며, unlockShards로 풀어야 합니다.
+func (s *Store) lockKeys(keys []string) []*shard {
+	indexes := make([]int, 0, len(keys))
+	seen := make(map[int]bool, len(keys))
+	for _, key := range keys {
+		i := s.shardIndex(key)
+		if !seen[i] {
+			seen[i] = true
+			indexes = append(indexes, i)
+		}
+	}
+	sort.Ints(indexes)
+
+	locked := make([]*shard, len(indexes))
+	for n, i := range indexes {
+		locked[n] = s.shards[i]
+		locked[n].mu.Lock()
+	}
+	return locked
+}
+
+// lockAll은 모든 샤드를 샤드 번호 순서대로 쓰기 잠금합니다 (키 공간 전체를 다루는 연산).
+func (s *Store) lockAll() []*shard {
+	for _, sh := range s.shards {
+		sh.mu.Lock()
+	}
+	return s.shards
+}
+
+// unlockShards는 lockKeys나 lockAll로 잠근 샤드들을 역순으로 풉니다.
+func unlockShards(locked []*shard) {
+	for i := len(locked) - 1; i >= 0; i-- {
+		locked[i].mu.Unlock()
+	}
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"strconv"
+	"testing"
+)
+
+// TestShardDistribution은 키가 모든 샤드에 고르게 나뉘는지 테스트합니다.
+func TestShardDistribution(t *testing.T) {
+	s := NewStore()
+	const keys = 16000
+	for i := 0; i < keys; i++ {
+		s.SET("key:"+strconv.Itoa(i), []byte("value"), nil)
+	}
+
+	// 테스트 케이스 1: 모든 샤드에 키가 있고, 어느 샤드도 평균의 1.5배를 넘지 않음
+	average := keys / shardCount
+	total := 0
+	for i, sh := range s.shards {
+		n := sh.size()
+		total += n
+		if n < average/2 || n > average*3/2 {
+			t.Errorf("Shard %d has %d keys, expected about %d", i, n, average)
+		}
+	}
+	if total != keys {
+		t.Errorf("Expected %d keys in total, got %d", keys, total)
+	}
+
+	// 테스트 케이스 2: 키는 shardIndex가 가리키는 샤드에 저장됨
+	for i, sh := range s.shards {
+		for key := range sh.storage {
+			if index := s.shardIndex(key); index != i {
+				t.Fatalf("Key %q is in shard %d, expected shard %d", key, i, index)
+			}
+		}
+	}
+
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"sort"
+	"time"
+)
+
+// Entry는 스냅샷에 담긴 키 하나입니다.
+type Entry struct {
+	Key string
+
+	// Value는 키의 값입니다. 타입에 따라 다음 중 하나입니다.
+	//   - []byte: 문자열 (읽기 전용)
+	//   - []string: 리스트 (앞에서부터 순서대로)
+	//   - []ScoredMember: 정렬된 집합 (점수 순)
+	Value interface{}
+
+	// ExpireAt은 키가 만료되는 시각입니다. 만료 시간이 없으면 zero 값입니다.
+	ExpireAt time.Time
+}
+
+// SnapshotView는 BeginSnapshot을 호출한 순간의 키 공간입니다 (fork 없는 BGSAVE용).
+//
+// 값을 복사하지 않고 참조만 모아 두며, 이후 저장소가 변경되어도 다음 이유로 내용이 바뀌지 않습니다.
+//   - 문자열: 값을 바꾸는 명령어는 새 슬라이스를 만들고, APPEND는 기존 길이 뒤에만 씀
+//   - 리스트: 앞쪽을 바꾸는 명령어(LPUSH, LPOP)는 새 슬라이스를 만들고, RPUSH는 기존 길이 뒤에만 씀
+//   - 정렬된 집합: 스냅샷 이후 처음 변경될 때 멤버 맵을 복제함 (copy-on-write)
+//
+// 따라서 Entries는 저장소 잠금 없이 다른 고루틴에서 호출할 수 있습니다.
+type SnapshotView struct {
+	taken time.Time
+	keys  []snapshotKey
+}
+
+// snapshotKey는 스냅샷에 담긴 키 하나의 값 참조입니다.
+type snapshotKey struct {
+	key      string
+	value    interface{} // string, []string, *zsetVersion 중 하나
+	expireAt time.Time
+}
+
+// zsetVersion은 스냅샷 시점의 정렬된 집합입니다.
+// sorted가 nil이면 Entries에서 scores로 정렬합니다.
+type zsetVersion struct {
+	scores map[string]float64
+	sorted []ScoredMember
+}
+
+// BeginSnapshot은 현재 키 공간의 시점 고정 뷰를 만듭니다.
+// 모든 샤드를 잠근 상태에서 만들지만, 값을 복사하지 않으므로 잠금을 잡는 시간은 키 개수에만 비례합니다.
+//
+// 시간 복잡도: O(K) (K는 키의 개수)
+func (s *Store) BeginSnapshot() *SnapshotView {
+	locked := s.lockAll()
+	defer unlockShards(locked)
+
+	// 이 시점까지 만들어진 정렬된 집합은 다음 변경 때 멤버 맵을 복제함
+	s.snapshotGen++
+
+	size := 0
+	for _, sh := range s.shards {
+		size += sh.size()
+	}
+	view := &SnapshotView{
+		taken: time.Now(),
+		keys:  make([]snapshotKey, 0, size),
+	}
+	for _, sh := range s.shards {
+		for key, value := range sh.storage {
+			view.keys = append(view.keys, snapshotKey{key: key, value: readOnly(value)})
+		}
+		for key, obj := range sh.expireStorage {
+			view.keys = append(view.keys, snapshotKey{key: key, value: readOnly(obj.Value), expireAt: obj.ExpireAt})
+		}
+		for key, list := range sh.listStorage {
+			view.keys = append(view.keys, snapshotKey{key: key, value: list[:len(list):len(list)]})
+		}
+		for key, zset := range sh.zsetStorage {
+			// 정렬 결과는 변경 시 새로 만들어지므로 그대로 공유해도 안전함
+			view.keys = append(view.keys, snapshotKey{key: key, value: &zsetVersion{scores: zset.scores, sorted: zset.sorted}})
+		}
+	}
+	return view
+}
+
+// Entries는 스냅샷의 키들을 키 이름 순의 Entry 목록으로 만듭니다.
+// 스냅샷을 만든 시각에 이미 만료된 키는 포함하지 않습니다.
+//
+// 반환한 값은 저장소와 메모리를 공유할 수 있으므로 읽기만 해야 합니다.
+//
+// 시간 복잡도: O(N log N) (정렬된 집합의 정렬 포함)
+func (v *SnapshotView) Entries() []Entry {
+	entries := make([]Entry, 0, len(v.keys))
+	for _, k := range v.keys {
+		if !k.expireAt.IsZero() && k.expireAt.Before(v.taken) {
+			continue
+		}
+		entry := Entry{Key: k.key, Value: k.value, ExpireAt: k.expireAt}
+		if z, ok := k.value.(*zsetVersion); ok {
+			if z.sorted != nil {
+				entry.Value = z.sorted
+			} else {
+				entry.Value = (&SortedSet{scores: z.scores}).ordered()
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+// Snapshot은 현재 키 공간을 키 이름 순의 Entry 목록으로 반환합니다 (SAVE 등).
+// BeginSnapshot().Entries()와 같으며, 이미 만료된 키는 포함하지 않습니다.
+//
+// 시간 복잡도: O(N log N)
+func (s *Store) Snapshot() []Entry {
+	return s.BeginSnapshot().Entries()
+}
+
+// Lookup은 키 하나를 Entry로 반환합니다 (DUMP, MIGRATE 등).
+// 키가 없거나 만료되었으면 false를 반환합니다.
+//
+// 반환한 값은 저장소와 메모리를 공유할 수 있으므로 읽기만 해야 합니다.
+//
+// 시간 복잡도: O(1) (정렬된 집합은 정렬이 필요하면 O(N log N))
+func (s *Store) Lookup(key string) (Entry, bool) {
+	sh := s.shardFor(key)
+	// 정렬된 집합의 정렬 결과를 캐시하므로 쓰기 잠금이 필요함
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	if value, exists := sh.storage[key]; exists {
+		return Entry{Key: key, Value: readOnly(value)}, true
+	}
+	if obj, exists := sh.expireStorage[key]; exists && !obj.ExpireAt.Before(time.Now()) {
+		return Entry{Key: key, Value: readOnly(obj.Value), ExpireAt: obj.ExpireAt}, true
+	}
+	if list, exists := sh.listStorage[key]; exists {
+		return Entry{Key: key, Value: list[:len(list):len(list)]}, true
+	}
+	if zset, exists := sh.zsetStorage[key]; exists {
+		return Entry{Key: key, Value: zset.ordered()}, true
+	}
+	return Entry{}, false
+}
@@ -0,0 +1,214 @@
+package store
+
+import (
+	"sort"
+	"time"
+)
+
+// ScoredMember는 Sorted Set의 멤버와 점수 쌍입니다.
+type ScoredMember struct {
+	Member string
+	Score  float64
+}
+
+// SortedSet은 Redis의 Sorted Set 자료구조입니다.
+// 각 멤버는 고유하며, 점수(score) 순서로 정렬되어 조회됩니다.
+// 점수가 같은 멤버들은 멤버 이름의 사전순으로 정렬됩니다.
+//
+// 구현 방식:
+//   - scores: 멤버 → 점수 매핑 (O(1) 조회)
+//   - sorted: 정렬된 멤버 목록 (변경 시 무효화되고 조회 시 다시 정렬)
+//   - gen: scores를 마지막으로 만든 시점의 스냅샷 세대 (copy-on-write 판단용)
+type SortedSet struct {
+	scores map[string]float64
+	sorted []ScoredMember // nil이면 다시 정렬해야 함
+	gen    uint64
+}
+
+// newSortedSet은 빈 SortedSet을 생성합니다.
+func newSortedSet(gen uint64) *SortedSet {
+	return &SortedSet{scores: make(map[string]float64), gen: gen}
+}
+
+// prepareWrite는 scores를 변경하기 전에 호출합니다.
+// 만들어진 뒤 스냅샷(BeginSnapshot)이 scores를 참조했다면 복제본으로 바꿔,
+// 스냅샷이 보는 맵이 변경되지 않도록 합니다.
+func (z *SortedSet) prepareWrite(gen uint64) {
+	if z.gen == gen {
+		return
+	}
+	scores := make(map[string]float64, len(z.scores))
+	for member, score := range z.scores {
+		scores[member] = score
+	}
+	z.scores = scores
+	z.gen = gen
+}
+
+// ordered는 점수 순으로 정렬된 멤버 목록을 반환합니다.
+// 정렬 결과는 다음 변경 전까지 캐시됩니다.
+func (z *SortedSet) ordered() []ScoredMember {
+	if z.sorted != nil {
+		return z.sorted
+	}
+
+	sorted := make([]ScoredMember, 0, len(z.scores))
+	for member, score := range z.scores {
+		sorted = append(sorted, ScoredMember{Member: member, Score: score})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Score != sorted[j].Score {
+			return sorted[i].Score < sorted[j].Score
+		}
+		return sorted[i].Member < sorted[j].Member
+	})
+
+	z.sorted = sorted
+	return sorted
+}
+
+// ZADD는 Sorted Set에 멤버를 추가하거나 기존 멤버의 점수를 갱신합니다.
+// 키가 없으면 새로운 Sorted Set을 생성합니다.
+//
+// 매개변수:
+//   - key: Sorted Set 키
+//   - score: 멤버의 점수
+//   - member: 추가할 멤버
+//
+// 반환값:
+//   - bool: 새로운 멤버가 추가되었으면 true, 기존 멤버의 점수만 갱신했으면 false
+//
+// 시간 복잡도: O(1) (정렬은 다음 조회 시 수행, 스냅샷 이후 첫 변경은 O(N))
+func (s *Store) ZADD(key string, score float64, member string) bool {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	zset, exists := sh.zsetStorage[key]
+	if !exists {
+		zset = newSortedSet(s.snapshotGen)
+		sh.zsetStorage[key] = zset
+		s.initAccess(sh, key)
+	}
+
+	old, existed := zset.scores[member]
+	if existed && old == score {
+		sh.mu.Unlock()
+		return false
+	}
+
+	zset.prepareWrite(s.snapshotGen)
+	zset.scores[member] = score
+	zset.sorted = nil
+	m := sh.memory[key]
+	m.addMember(key, member, score, old, existed, len(zset.scores))
+	sh.setMemory(key, m)
+	sh.mu.Unlock()
+
+	s.signalModifiedKey(key)
+	return !existed
+}
+
+// ZSCORE는 Sorted Set 멤버의 점수를 조회합니다.
+//
+// 반환값:
+//   - float64: 멤버의 점수
+//   - bool: 키와 멤버가 존재하면 true
+func (s *Store) ZSCORE(key, member string) (float64, bool) {
+	sh := s.shardFor(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	zset, exists := sh.zsetStorage[key]
+	if !exists {
+		return 0, false
+	}
+
+	score, exists := zset.scores[member]
+	return score, exists
+}
+
+// ZCARD는 Sorted Set의 멤버 개수를 반환합니다.
+// 키가 없으면 0을 반환합니다.
+func (s *Store) ZCARD(key string) int {
+	sh := s.shardFor(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	zset, exists := sh.zsetStorage[key]
+	if !exists {
+		return 0
+	}
+	return len(zset.scores)
+}
+
+// ZRANGE는 점수 순으로 정렬된 Sorted Set에서 지정된 인덱스 범위의 멤버들을 반환합니다.
+// 인덱스 규칙은 LRANGE와 동일합니다 (음수 인덱스 지원, 범위 자동 조정).
+//
+// 매개변수:
+//   - key: Sorted Set 키
+//   - start: 시작 인덱스 (포함)
+//   - stop: 끝 인덱스 (포함)
+//
+// 반환값:
+//   - []ScoredMember: 범위 안의 멤버와 점수 (빈 슬라이스 가능)
+//
+// 시간 복잡도: O(log(N)+M) (정렬이 캐시된 경우)
+func (s *Store) ZRANGE(key string, start, stop int) []ScoredMember {
+	sh := s.shardFor(key)
+	// 정렬 결과를 캐시하므로 쓰기 잠금이 필요함
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	zset, exists := sh.zsetStorage[key]
+	if !exists {
+		return []ScoredMember{}
+	}
+
+	sorted := zset.ordered()
+	length := len(sorted)
+
+	if start < 0 {
+		start = length + start
+	}
+	if stop < 0 {
+		stop = length + stop
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= length {
+		stop = length - 1
+	}
+	if start >= length || stop < start {
+		return []ScoredMember{}
+	}
+
+	result := make([]ScoredMember, stop-start+1)
+	copy(result, sorted[start:stop+1])
+	return result
+}
+
+// TYPE은 Redis TYPE 명령어를 구현합니다.
+// 키에 저장된 값의 타입 이름을 반환합니다.
+//
+// 반환값:
+//   - "string", "list", "zset" 중 하나
+//   - 키가 없거나 만료되었으면 "none"
+func (s *Store) TYPE(key string) string {
+	sh := s.shardFor(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	if _, exists := sh.storage[key]; exists {
+		return "string"
+	}
+	if obj, exists := sh.expireStorage[key]; exists && !obj.ExpireAt.Before(time.Now()) {
+		return "string"
+	}
+	if _, exists := sh.listStorage[key]; exists {
+		return "list"
+	}
+	if _, exists := sh.zsetStorage[key]; exists {
+		return "zset"
+	}
+	return "none"
+}
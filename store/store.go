@@ -1,95 +1,460 @@
 package store
 
 import (
-	"sync"
+	"hash/maphash"
+	"sync/atomic"
 	"time"
 )
 
 // ValueWithTTL represents a value with an expiration time
 type ValueWithTTL struct {
-	Value    string
+	Value    []byte
 	ExpireAt time.Time
 }
 
-// BlockingWaiter represents a client waiting for a blocking operation
-type BlockingWaiter struct {
-	Keys     []string           // Keys this waiter is monitoring
-	Response chan *BLPopResult  // Channel to send result
-	Timeout  time.Duration      // How long to wait
-	StartTime time.Time         // When the wait started
-}
-
 // Store manages key-value storage with optional TTL support
 type Store struct {
-	storage       map[string]string       // Regular key-value storage
-	expireStorage map[string]ValueWithTTL // Storage with TTL
-	listStorage   map[string][]string     // List storage
-	
-	// Blocking operation support
-	mu            sync.RWMutex                    // Protects all blocking operations
-	waiters       map[string][]*BlockingWaiter   // Key -> list of waiters
-	waiterCleanup chan *BlockingWaiter           // Channel for cleanup
+	// 키 공간은 shardCount개의 샤드로 나뉘며, 각 샤드가 자기 키들의 저장소 맵과 잠금을 가짐
+	shards []*shard
+	seed   maphash.Seed // 키 → 샤드 해시의 시드
+
+	// 대기 명령어(BLPOP 등)의 대기 중인 클라이언트들
+	blocking *BlockingManager
+
+	// 키 변경 알림 (클라이언트 측 캐싱의 무효화 등에 사용)
+	keyModifiedHooks []func(key string)
+
+	// 만료된 키 삭제 알림 (레플리카와 AOF에 DEL을 전달하는 데 사용)
+	keyExpiredHooks []func(key string)
+
+	// keepExpired이면 만료된 키를 읽을 때 없는 것으로 보고하지만 삭제하지는 않음 (레플리카)
+	keepExpired atomic.Bool
+
+	// 스냅샷 세대 (BeginSnapshot마다 증가, 정렬된 집합의 copy-on-write에 사용)
+	// 모든 샤드의 쓰기 잠금을 잡은 상태에서만 변경하므로 샤드 잠금 하나로 읽을 수 있음
+	snapshotGen uint64
+
+	// ActiveExpire가 다음에 확인을 시작할 샤드
+	expireCursor atomic.Uint32
+
+	// LRU 시계 (UnixNano, SetClock으로 주기적으로 맞춤). 키의 사용 기록은 매번 현재 시각을 읽지 않고 이 값을 씀
+	clock atomic.Int64
+
+	// LFU 카운터가 늘어나는 속도 (lfu-log-factor)와 줄어드는 주기 (lfu-decay-time, 나노초)
+	lfuLogFactor atomic.Int64
+	lfuDecayTime atomic.Int64
+
+	// 데이터셋 전체의 메모리 사용량 (바이트, 샤드들이 키를 바꿀 때마다 갱신)
+	used atomic.Int64
 }
 
 // NewStore creates a new Store instance
 func NewStore() *Store {
 	store := &Store{
-		storage:       make(map[string]string),
-		expireStorage: make(map[string]ValueWithTTL),
-		listStorage:   make(map[string][]string),
-		waiters:       make(map[string][]*BlockingWaiter),
-		waiterCleanup: make(chan *BlockingWaiter, 100),
-	}
-	
-	// Start cleanup goroutine for expired waiters
-	go store.cleanupWaiters()
-	
+		shards:   make([]*shard, shardCount),
+		seed:     maphash.MakeSeed(),
+		blocking: NewBlockingManager(),
+	}
+	store.clock.Store(time.Now().UnixNano())
+	for i := range store.shards {
+		store.shards[i] = newShard(&store.used)
+	}
+	store.lfuLogFactor.Store(DefaultLFULogFactor)
+	store.lfuDecayTime.Store(int64(DefaultLFUDecayTime))
+
 	return store
 }
 
 // SET implements Redis SET command
 // Supports both regular SET and SET with PX (milliseconds expiry)
-func (s *Store) SET(key, value string, px *int) { // TODO handle different time unit
+// 저장소는 value를 복사하지 않고 그대로 보관하므로, 호출한 쪽은 넘긴 뒤 value를 고치면 안 됩니다.
+func (s *Store) SET(key string, value []byte, px *int) { // TODO handle different time unit
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	var expireAt time.Time
 	if px != nil {
 		// SET with expiry
-		expireAt := time.Now().Add(time.Duration(*px) * time.Millisecond)
-		s.expireStorage[key] = ValueWithTTL{
+		expireAt = time.Now().Add(time.Duration(*px) * time.Millisecond)
+	}
+	sh.setStringLocked(key, value, expireAt)
+	s.initAccess(sh, key)
+	sh.mu.Unlock()
+
+	s.signalModifiedKey(key)
+}
+
+// SETPXAT은 만료 시각을 절대 시간으로 지정해 값을 저장합니다 (Redis의 SET key value PXAT과 동일).
+// RDB 파일처럼 만료 시각이 절대 시간으로 기록된 데이터를 불러올 때 사용합니다.
+//
+// 매개변수:
+//   - key: 저장할 키
+//   - value: 저장할 값 (복사하지 않고 보관)
+//   - expireAt: 키가 만료되는 시각
+func (s *Store) SETPXAT(key string, value []byte, expireAt time.Time) {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	sh.setStringLocked(key, value, expireAt)
+	s.initAccess(sh, key)
+	sh.mu.Unlock()
+
+	s.signalModifiedKey(key)
+}
+
+// SETKEEPTTL은 키의 만료 시각을 그대로 둔 채 문자열 값을 저장합니다 (Redis의 SET key value KEEPTTL과 동일).
+// 새 키나 만료 시각이 없는 키는 만료 없이 저장합니다.
+// 기존 값을 고쳐 쓰는 명령어(PFADD, PFMERGE 등)가 키의 TTL을 지우지 않도록 사용합니다.
+//
+// 매개변수:
+//   - key: 저장할 키
+//   - value: 저장할 값 (복사하지 않고 보관)
+func (s *Store) SETKEEPTTL(key string, value []byte) {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	sh.setKeepTTLLocked(key, value)
+	s.initAccess(sh, key)
+	sh.mu.Unlock()
+
+	s.signalModifiedKey(key)
+}
+
+// ReplaceString은 이미 있는 String 키의 값을 만료 시각을 유지한 채 바꾸며, 변경 알림을 보내지 않습니다.
+// 논리적인 값은 같고 표현만 바뀌는 경우(PFCOUNT의 캐시 갱신 등)에 사용하므로
+// 클라이언트 측 캐싱의 무효화나 저장 이후 변경 횟수에 포함되지 않습니다.
+//
+// 반환값:
+//   - bool: 값을 바꿨는지 여부 (키가 없거나 만료되었으면 false)
+func (s *Store) ReplaceString(key string, value []byte) bool {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	if _, exists := sh.storage[key]; !exists {
+		if obj, exists := sh.expireStorage[key]; !exists || obj.ExpireAt.Before(time.Now()) {
+			return false
+		}
+	}
+	sh.setKeepTTLLocked(key, value)
+	return true
+}
+
+// APPEND는 Redis APPEND 명령어를 구현합니다.
+// 문자열 값의 끝에 value를 덧붙이며, 키가 없으면 value를 값으로 새 키를 만듭니다.
+//
+// 동작 방식:
+//   - 키의 만료 시각은 유지
+//   - 값 뒤에 남는 용량에 덧붙이므로 (Redis의 SDS처럼) 여러 번 덧붙여도 매번 값 전체를 복사하지 않음
+//   - 값의 길이 안쪽은 바꾸지 않으므로 GET이나 스냅샷이 이미 가져간 값은 영향을 받지 않음
+//
+// 키가 String 타입인지는 호출한 쪽에서 먼저 확인해야 합니다 (TYPE).
+//
+// 반환값:
+//   - int: 덧붙인 뒤 값의 길이
+//
+// 시간 복잡도: 분할 상환 O(1) (value의 길이에 비례)
+func (s *Store) APPEND(key string, value []byte) int {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	var current []byte
+	if obj, exists := sh.expireStorage[key]; exists && !obj.ExpireAt.Before(time.Now()) {
+		current = obj.Value
+	} else {
+		current = sh.storage[key]
+	}
+	appended := append(current, value...)
+	sh.setKeepTTLLocked(key, appended)
+	s.initAccess(sh, key)
+	sh.mu.Unlock()
+
+	s.signalModifiedKey(key)
+	return len(appended)
+}
+
+// setKeepTTLLocked는 키의 만료 시각을 유지한 채 문자열 값을 저장합니다.
+// 이미 만료된 키는 새 키처럼 만료 없이 저장합니다. mu를 잡은 상태에서 호출해야 합니다.
+func (sh *shard) setKeepTTLLocked(key string, value []byte) {
+	var expireAt time.Time
+	if obj, exists := sh.expireStorage[key]; exists && !obj.ExpireAt.Before(time.Now()) {
+		expireAt = obj.ExpireAt
+	}
+	sh.setStringLocked(key, value, expireAt)
+}
+
+// setStringLocked는 문자열 값을 저장합니다. expireAt이 zero 값이면 만료 없이 저장합니다.
+// 빈 값도 nil이 아닌 슬라이스로 보관해 GET이 없는 키와 구분할 수 있게 합니다. mu를 잡은 상태에서 호출해야 합니다.
+func (sh *shard) setStringLocked(key string, value []byte, expireAt time.Time) {
+	if value == nil {
+		value = []byte{}
+	}
+	volatile := !expireAt.IsZero()
+	if volatile {
+		sh.expireStorage[key] = ValueWithTTL{
 			Value:    value,
 			ExpireAt: expireAt,
 		}
 		// Remove from regular storage if exists
-		delete(s.storage, key)
+		delete(sh.storage, key)
 	} else {
-		// Regular SET without expiry
-		s.storage[key] = value
+		sh.storage[key] = value
 		// Remove from expire storage if exists
-		delete(s.expireStorage, key)
+		delete(sh.expireStorage, key)
 	}
+	sh.setMemory(key, stringMemory(key, value, volatile))
 }
 
 // GET implements Redis GET command
 // Returns nil if key doesn't exist or has expired
-func (s *Store) GET(key string) *string {
+// 반환된 값은 저장소와 메모리를 공유하므로 읽기만 해야 합니다 (덧붙이면 새 배열로 복사됨).
+func (s *Store) GET(key string) []byte {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+
 	// Check expire storage first
-	if obj, exists := s.expireStorage[key]; exists {
+	if obj, exists := sh.expireStorage[key]; exists {
 		now := time.Now()
 		if obj.ExpireAt.Before(now) {
+			// 레플리카는 마스터가 보내는 DEL을 기다림
+			if s.keepExpired.Load() {
+				sh.mu.Unlock()
+				return nil
+			}
 			// Key has expired, delete it
-			delete(s.expireStorage, key)
+			delete(sh.expireStorage, key)
+			delete(sh.accessed, key)
+			sh.deleteMemory(key)
+			sh.mu.Unlock()
+			s.signalModifiedKey(key)
+			s.signalExpiredKey(key)
 			return nil
 		}
-		return &obj.Value
+		sh.mu.Unlock()
+		return readOnly(obj.Value)
 	}
 
 	// Check regular storage
-	if value, exists := s.storage[key]; exists {
-		return &value
+	value, exists := sh.storage[key]
+	sh.mu.Unlock()
+	if exists {
+		return readOnly(value)
 	}
 
 	// Key not found
 	return nil
 }
 
+// readOnly는 용량을 길이로 잘라 값을 돌려줍니다.
+// 받은 쪽이 append해도 APPEND가 쓰는 남는 용량을 건드리지 않고 새 배열로 복사됩니다.
+func readOnly(value []byte) []byte {
+	return value[:len(value):len(value)]
+}
+
+// DEL은 Redis DEL 명령어를 구현합니다.
+// 타입에 관계없이 주어진 키들을 모든 저장소에서 삭제합니다.
+// 키들이 속한 샤드를 모두 잠근 뒤 삭제하므로, 다른 명령어에게는 한 번에 삭제된 것으로 보입니다.
+//
+// 매개변수:
+//   - keys: 삭제할 키들 (가변 인자)
+//
+// 반환값:
+//   - int: 실제로 삭제된 키의 개수 (존재하지 않는 키는 제외)
+//
+// 시간 복잡도: O(N) (N은 키의 개수)
+func (s *Store) DEL(keys ...string) int {
+	locked := s.lockKeys(keys)
+	var deleted []string
+	for _, key := range keys {
+		sh := s.shardFor(key)
+		_, inStorage := sh.storage[key]
+		obj, inExpire := sh.expireStorage[key]
+		_, inList := sh.listStorage[key]
+		_, inZSet := sh.zsetStorage[key]
+
+		// 이미 만료된 키는 존재하지 않는 것으로 취급
+		if inExpire && obj.ExpireAt.Before(time.Now()) {
+			inExpire = false
+		}
+
+		if inStorage || inExpire || inList || inZSet {
+			deleted = append(deleted, key)
+		}
+
+		delete(sh.storage, key)
+		delete(sh.expireStorage, key)
+		delete(sh.listStorage, key)
+		delete(sh.zsetStorage, key)
+		delete(sh.accessed, key)
+		sh.deleteMemory(key)
+	}
+	unlockShards(locked)
+
+	for _, key := range deleted {
+		s.signalModifiedKey(key)
+	}
+	return len(deleted)
+}
+
+// FlushAll은 Redis FLUSHALL 명령어를 구현합니다.
+// 타입에 관계없이 모든 키를 삭제하고, 삭제한 키마다 변경 알림을 보냅니다.
+//
+// 시간 복잡도: O(N) (N은 키의 개수)
+func (s *Store) FlushAll() {
+	locked := s.lockAll()
+	var keys []string
+	for _, sh := range s.shards {
+		for key := range sh.storage {
+			keys = append(keys, key)
+		}
+		for key := range sh.expireStorage {
+			keys = append(keys, key)
+		}
+		for key := range sh.listStorage {
+			keys = append(keys, key)
+		}
+		for key := range sh.zsetStorage {
+			keys = append(keys, key)
+		}
+
+		sh.storage = make(map[string][]byte)
+		sh.expireStorage = make(map[string]ValueWithTTL)
+		sh.listStorage = make(map[string][]string)
+		sh.zsetStorage = make(map[string]*SortedSet)
+		sh.accessed = make(map[string]accessMeta)
+		sh.memory = make(map[string]keyMemory)
+	}
+	// 모든 샤드를 잠갔으므로 합계를 바꾸는 샤드가 없음
+	s.used.Store(0)
+	unlockShards(locked)
+
+	for _, key := range keys {
+		s.signalModifiedKey(key)
+	}
+}
+
+// Keys는 만료되지 않은 모든 키를 순서 없이 반환합니다.
+// 샤드를 하나씩 읽으므로, 동시에 실행된 다른 명령어의 변경은 일부만 보일 수 있습니다.
+//
+// 시간 복잡도: O(N) (N은 키의 개수)
+func (s *Store) Keys() []string {
+	var keys []string
+	now := time.Now()
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		for key := range sh.storage {
+			keys = append(keys, key)
+		}
+		for key, obj := range sh.expireStorage {
+			if !obj.ExpireAt.Before(now) {
+				keys = append(keys, key)
+			}
+		}
+		for key := range sh.listStorage {
+			keys = append(keys, key)
+		}
+		for key := range sh.zsetStorage {
+			keys = append(keys, key)
+		}
+		sh.mu.RUnlock()
+	}
+	return keys
+}
+
+// SetKeepExpired는 만료된 키를 읽을 때 삭제할지 설정합니다.
+// keep이면 만료된 키는 없는 것으로 보고되지만 저장소에 남아 있으며, DEL로만 삭제됩니다.
+// 레플리카는 마스터와 데이터셋이 어긋나지 않도록 마스터가 보내는 DEL을 기다립니다.
+// 다른 명령어와 동시에 호출해도 안전합니다.
+func (s *Store) SetKeepExpired(keep bool) {
+	s.keepExpired.Store(keep)
+}
+
+// ActiveExpire는 만료 시간이 있는 키를 최대 sample개 골라 그중 만료된 키를 삭제합니다.
+// 읽히지 않는 만료된 키도 메모리에서 지우기 위해 주기적으로 호출합니다 (Redis의 active expire).
+// 키는 지난번에 멈춘 샤드부터 샤드마다 맵 순회 순서(임의)로 고르며, 삭제한 키마다 변경 알림과 만료 알림을 보냅니다.
+// SetKeepExpired(true)이면 아무것도 삭제하지 않습니다.
+//
+// 반환값:
+//   - sampled: 확인한 키의 개수
+//   - expired: 삭제한 키의 개수
+//
+// 시간 복잡도: O(sample)
+func (s *Store) ActiveExpire(sample int) (sampled, expired int) {
+	if s.keepExpired.Load() {
+		return 0, 0
+	}
+	now := time.Now()
+	var keys []string
+	start := int(s.expireCursor.Load())
+	for n := 0; n < shardCount && sampled < sample; n++ {
+		i := (start + n) % shardCount
+		sh := s.shards[i]
+		first := len(keys)
+		sh.mu.Lock()
+		for key, obj := range sh.expireStorage {
+			if sampled == sample {
+				break
+			}
+			sampled++
+			if obj.ExpireAt.Before(now) {
+				keys = append(keys, key)
+			}
+		}
+		for _, key := range keys[first:] {
+			delete(sh.expireStorage, key)
+			delete(sh.accessed, key)
+			sh.deleteMemory(key)
+		}
+		sh.mu.Unlock()
+
+		// 다음 호출은 다음 샤드부터 확인해 모든 샤드를 고르게 훑음
+		s.expireCursor.Store(uint32((i + 1) % shardCount))
+	}
+
+	for _, key := range keys {
+		s.signalModifiedKey(key)
+		s.signalExpiredKey(key)
+	}
+	return sampled, len(keys)
+}
+
+// OnKeyExpired는 만료된 키가 삭제될 때마다 호출될 함수를 등록합니다.
+// 마스터는 이 알림으로 레플리카와 AOF에 DEL을 전달합니다.
+// OnKeyModified로 등록한 함수도 함께 호출됩니다.
+// 명령어를 실행하기 전(서버 시작 시)에 등록해야 합니다.
+//
+// 매개변수:
+//   - fn: 삭제된 키를 인자로 받는 함수 (명령어를 실행한 고루틴에서 호출됨)
+func (s *Store) OnKeyExpired(fn func(key string)) {
+	s.keyExpiredHooks = append(s.keyExpiredHooks, fn)
+}
+
+// signalExpiredKey는 OnKeyExpired로 등록된 함수들을 호출합니다.
+func (s *Store) signalExpiredKey(key string) {
+	for _, fn := range s.keyExpiredHooks {
+		fn(key)
+	}
+}
+
+// OnKeyModified는 키의 값이 변경되거나 삭제될 때마다 호출될 함수를 등록합니다.
+//
+// 호출 시점:
+//   - 값 쓰기 (SET, RPUSH, LPUSH, ZADD 등)
+//   - 값 제거 (DEL, LPOP 등)
+//   - 만료된 키의 삭제
+//
+// fn은 저장소 잠금을 푼 뒤에 호출되며, 여러 고루틴에서 동시에 호출될 수 있습니다.
+// 명령어를 실행하기 전(서버 시작 시)에 등록해야 합니다.
+//
+// 매개변수:
+//   - fn: 변경된 키를 인자로 받는 함수 (명령어를 실행한 고루틴에서 호출됨)
+func (s *Store) OnKeyModified(fn func(key string)) {
+	s.keyModifiedHooks = append(s.keyModifiedHooks, fn)
+}
+
+// signalModifiedKey는 등록된 모든 키 변경 알림 함수를 호출합니다.
+func (s *Store) signalModifiedKey(key string) {
+	for _, fn := range s.keyModifiedHooks {
+		fn(key)
+	}
+}
+
 // RPUSH는 Redis RPUSH 명령어를 구현합니다.
 // 리스트의 오른쪽 끝(뒤쪽)에 하나 이상의 값을 추가합니다.
 //
@@ -107,18 +472,28 @@ func (s *Store) GET(key string) *string {
 //
 // 시간 복잡도: O(N) (N은 추가할 값의 개수)
 func (s *Store) RPUSH(key string, values ...string) int {
-	list, exists := s.listStorage[key]
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	list, exists := sh.listStorage[key]
 	if !exists {
 		list = []string{}
 	}
 
 	list = append(list, values...)
-	s.listStorage[key] = list
-
-	// 새 값이 추가되었으므로 대기 중인 클라이언트들에게 알림
-	s.notifyWaiters(key)
-
-	return len(list)
+	sh.listStorage[key] = list
+	length := len(list)
+	m := sh.memory[key]
+	m.addListElements(key, values, 1, length)
+	sh.setMemory(key, m)
+	s.initAccess(sh, key)
+
+	// 새 값이 추가되었으므로 대기 중인 클라이언트들에게 먼저 전달
+	// (잠금 안에서 전달하여 다른 명령어가 가로채지 않음)
+	s.blocking.Signal(key, BlockOnList)
+	sh.mu.Unlock()
+	s.signalModifiedKey(key)
+
+	return length
 }
 
 // LRANGE는 Redis LRANGE 명령어를 구현합니다.
@@ -144,8 +519,12 @@ func (s *Store) RPUSH(key string, values ...string) int {
 //
 // 시간 복잡도: O(S+N) (S는 시작 위치까지의 오프셋, N은 반환할 요소 수)
 func (s *Store) LRANGE(key string, start, stop int) []string {
+	sh := s.shardFor(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
 	// 키가 존재하지 않으면 빈 슬라이스 반환
-	list, exists := s.listStorage[key]
+	list, exists := sh.listStorage[key]
 	if !exists {
 		return []string{}
 	}
@@ -184,7 +563,8 @@ func (s *Store) LRANGE(key string, start, stop int) []string {
 
 	// 범위에 해당하는 부분 슬라이스 반환
 	// Go 슬라이스는 [start:stop+1] 형태로 사용 (stop+1은 제외)
-	return list[start : stop+1]
+	// 용량을 길이로 제한해 호출자의 append가 저장소의 배열에 쓰지 않도록 함
+	return list[start : stop+1 : stop+1]
 }
 
 // LPUSH는 Redis LPUSH 명령어를 구현합니다.
@@ -211,8 +591,11 @@ func (s *Store) LRANGE(key string, start, stop int) []string {
 // 시간 복잡도: O(N+M) (N=기존 크기, M=추가할 요소 수)
 // 공간 복잡도: O(N+M) (새 슬라이스 할당)
 func (s *Store) LPUSH(key string, values ...string) int {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+
 	// 기존 리스트 조회 (없으면 빈 슬라이스)
-	existingList, exists := s.listStorage[key]
+	existingList, exists := sh.listStorage[key]
 	if !exists {
 		existingList = []string{}
 	}
@@ -237,10 +620,17 @@ func (s *Store) LPUSH(key string, values ...string) int {
 	newList = append(newList, existingList...)
 
 	// 저장소 업데이트
-	s.listStorage[key] = newList
-
-	// 새 값이 추가되었으므로 대기 중인 클라이언트들에게 알림
-	s.notifyWaiters(key)
+	sh.listStorage[key] = newList
+	m := sh.memory[key]
+	m.addListElements(key, values, 1, newLength)
+	sh.setMemory(key, m)
+	s.initAccess(sh, key)
+
+	// 새 값이 추가되었으므로 대기 중인 클라이언트들에게 먼저 전달
+	// (잠금 안에서 전달하여 다른 명령어가 가로채지 않음)
+	s.blocking.Signal(key, BlockOnList)
+	sh.mu.Unlock()
+	s.signalModifiedKey(key)
 
 	return newLength
 }
@@ -267,8 +657,12 @@ func (s *Store) LPUSH(key string, values ...string) int {
 // 시간 복잡도: O(1)
 // 공간 복잡도: O(1) (추가 메모리 할당 없음)
 func (s *Store) LLEN(key string) int {
+	sh := s.shardFor(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
 	// 리스트 존재 여부 확인
-	list, exists := s.listStorage[key]
+	list, exists := sh.listStorage[key]
 	if !exists {
 		// 키가 존재하지 않으면 0 반환 (Redis 표준 동작)
 		return 0
@@ -297,22 +691,36 @@ func (s *Store) LLEN(key string) int {
 // 시간 복잡도: O(N) (N=제거할 요소 개수)
 // 공간 복잡도: O(N) (새 슬라이스 할당)
 func (s *Store) LPOP(key string, count *int) interface{} {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	result, modified := sh.lpopLocked(key, count)
+	sh.mu.Unlock()
+
+	if modified {
+		s.signalModifiedKey(key)
+	}
+	return result
+}
+
+// lpopLocked는 샤드 잠금을 잡은 상태에서 LPOP을 실행합니다.
+// 리스트가 변경되었으면 modified가 true이며, 변경 알림은 호출자가 잠금을 푼 뒤 보냅니다.
+func (sh *shard) lpopLocked(key string, count *int) (result interface{}, modified bool) {
 	// 리스트 존재 여부 확인
-	list, exists := s.listStorage[key]
+	list, exists := sh.listStorage[key]
 	if !exists {
 		// 키가 존재하지 않는 경우
 		if count == nil {
-			return nil // 단일 요소 모드: nil 반환
+			return nil, false // 단일 요소 모드: nil 반환
 		}
-		return []string{} // 다중 요소 모드: 빈 배열 반환
+		return []string{}, false // 다중 요소 모드: 빈 배열 반환
 	}
 
 	// 빈 리스트인 경우
 	if len(list) == 0 {
 		if count == nil {
-			return nil // 단일 요소 모드: nil 반환
+			return nil, false // 단일 요소 모드: nil 반환
 		}
-		return []string{} // 다중 요소 모드: 빈 배열 반환
+		return []string{}, false // 다중 요소 모드: 빈 배열 반환
 	}
 
 	// count가 nil이면 단일 요소 제거 (기존 동작)
@@ -321,16 +729,21 @@ func (s *Store) LPOP(key string, count *int) interface{} {
 
 		// 리스트에 요소가 하나뿐이면 키를 완전히 삭제
 		if len(list) == 1 {
-			delete(s.listStorage, key)
-			return &firstElement
+			delete(sh.listStorage, key)
+			delete(sh.accessed, key)
+			sh.deleteMemory(key)
+			return &firstElement, true
 		}
 
 		// 첫 번째 요소를 제외한 나머지로 새 슬라이스 생성
 		newList := make([]string, len(list)-1)
 		copy(newList, list[1:])
-		s.listStorage[key] = newList
+		sh.listStorage[key] = newList
+		m := sh.memory[key]
+		m.addListElements(key, list[:1], -1, len(newList))
+		sh.setMemory(key, m)
 
-		return &firstElement
+		return &firstElement, true
 	}
 
 	// count가 지정된 경우 (다중 요소 제거)
@@ -338,7 +751,7 @@ func (s *Store) LPOP(key string, count *int) interface{} {
 
 	// count가 0 이하인 경우 빈 배열 반환
 	if actualCount <= 0 {
-		return []string{}
+		return []string{}, false
 	}
 
 	// 실제 제거할 요소 개수 결정 (리스트 길이와 count 중 작은 값)
@@ -353,16 +766,21 @@ func (s *Store) LPOP(key string, count *int) interface{} {
 
 	// 리스트에서 모든 요소를 제거하는 경우 키 삭제
 	if removeCount >= len(list) {
-		delete(s.listStorage, key)
-		return removedElements
+		delete(sh.listStorage, key)
+		delete(sh.accessed, key)
+		sh.deleteMemory(key)
+		return removedElements, true
 	}
 
 	// 일부 요소만 제거하는 경우 나머지 요소들로 새 슬라이스 생성
 	remainingElements := make([]string, len(list)-removeCount)
 	copy(remainingElements, list[removeCount:])
-	s.listStorage[key] = remainingElements
+	sh.listStorage[key] = remainingElements
+	m := sh.memory[key]
+	m.addListElements(key, removedElements, -1, len(remainingElements))
+	sh.setMemory(key, m)
 
-	return removedElements
+	return removedElements, true
 }
 
 // BLPopResult는 BLPOP 명령어의 반환 결과를 나타냅니다.
@@ -392,171 +810,80 @@ type BLPopResult struct {
 // 시간 복잡도: O(N) (N=확인할 키의 개수)
 // 공간 복잡도: O(1) (결과 구조체만 할당)
 //
-// 참고: 현재는 non-blocking 모드로 구현됨. 
-// 실제 blocking 기능은 handler 레이어에서 구현됩니다.
+// 참고: 대기하지 않는 모드입니다. 대기하는 기능은 BLPOPBlocking에서 구현됩니다.
 func (s *Store) BLPOP(keys []string) *BLPopResult {
-	// 키들을 순서대로 확인
-	for _, key := range keys {
-		// 각 키에 대해 LPOP 시도 (count = nil로 단일 요소 제거)
-		result := s.LPOP(key, nil)
-		
-		// nil이 아니면 값이 있다는 의미
-		if result != nil {
-			// LPOP은 count가 nil일 때 *string을 반환
-			if valuePtr, ok := result.(*string); ok && valuePtr != nil {
-				return &BLPopResult{
-					Key:   key,
-					Value: *valuePtr,
-				}
-			}
-		}
+	// 키들의 샤드를 모두 잠가 키들을 한 번에 확인
+	locked := s.lockKeys(keys)
+	result := s.popFirstLocked(keys)
+	unlockShards(locked)
+
+	if result != nil {
+		s.signalModifiedKey(result.Key)
 	}
-	
-	// 모든 키가 비어있거나 존재하지 않음
-	return nil
+	return result
 }
 
-// cleanupWaiters는 만료된 대기자들을 정리하는 고루틴입니다.
-func (s *Store) cleanupWaiters() {
-	for waiter := range s.waiterCleanup {
-		s.mu.Lock()
-		// Remove waiter from all keys it was monitoring
-		for _, key := range waiter.Keys {
-			waiters := s.waiters[key]
-			for i, w := range waiters {
-				if w == waiter {
-					// Remove from slice
-					s.waiters[key] = append(waiters[:i], waiters[i+1:]...)
-					break
-				}
-			}
-			// Clean up empty waiter lists
-			if len(s.waiters[key]) == 0 {
-				delete(s.waiters, key)
-			}
-		}
-		s.mu.Unlock()
-		
-		// Close the response channel to signal timeout
-		close(waiter.Response)
-	}
-}
-
-// notifyWaiters는 키에 새 값이 추가되었을 때 대기자들에게 알림을 보냅니다.
-func (s *Store) notifyWaiters(key string) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	
-	waiters := s.waiters[key]
-	if len(waiters) == 0 {
-		return
-	}
-	
-	// FIFO: 가장 먼저 대기한 waiter가 값을 받음
-	waiter := waiters[0]
-	
-	// Remove this waiter from ALL keys it was waiting for
-	for _, waitKey := range waiter.Keys {
-		keyWaiters := s.waiters[waitKey]
-		for i, w := range keyWaiters {
-			if w == waiter {
-				s.waiters[waitKey] = append(keyWaiters[:i], keyWaiters[i+1:]...)
-				break
-			}
-		}
-		// Clean up empty waiter list
-		if len(s.waiters[waitKey]) == 0 {
-			delete(s.waiters, waitKey)
-		}
-	}
-	
-	// Try to get a value respecting the waiter's original key priority
-	result := s.BLPOP(waiter.Keys)
-	if result != nil {
-		// Send the result
-		select {
-		case waiter.Response <- result:
-			// Success
-		default:
-			// Channel might be closed, ignore
-		}
-		
-		// Remove this waiter from other keys it was monitoring
-		for _, otherKey := range waiter.Keys {
-			if otherKey == key {
-				continue
-			}
-			otherWaiters := s.waiters[otherKey]
-			for i, w := range otherWaiters {
-				if w == waiter {
-					s.waiters[otherKey] = append(otherWaiters[:i], otherWaiters[i+1:]...)
-					break
-				}
-			}
-			if len(s.waiters[otherKey]) == 0 {
-				delete(s.waiters, otherKey)
-			}
+// popFirstLocked는 키들을 순서대로 확인하여 비어 있지 않은 첫 번째 리스트에서 왼쪽 끝 요소를 제거합니다.
+// 키들의 샤드 잠금을 잡은 상태에서 호출해야 하며, 변경 알림은 호출자가 잠금을 푼 뒤 보냅니다.
+func (s *Store) popFirstLocked(keys []string) *BLPopResult {
+	for _, key := range keys {
+		if value, ok := s.shardFor(key).lpopLocked(key, nil); ok {
+			return &BLPopResult{Key: key, Value: *value.(*string)}
 		}
 	}
+	// 모든 키가 비어있거나 존재하지 않음
+	return nil
 }
 
 // BLPOPBlocking은 실제 blocking 기능을 가진 BLPOP을 구현합니다.
-func (s *Store) BLPOPBlocking(keys []string, timeoutSeconds float64) *BLPopResult {
-	// 먼저 non-blocking으로 시도
-	result := s.BLPOP(keys)
-	if result != nil {
+// 모든 리스트가 비어 있으면 키들 중 하나에 값이 추가될 때까지 대기합니다.
+//
+// 전달 보장:
+//   - 추가된 값은 추가한 명령어의 샤드 잠금 안에서 가장 오래 기다린 클라이언트에게 전달되므로,
+//     다른 클라이언트의 LPOP이 가로채거나 두 클라이언트에게 전달되지 않습니다.
+//   - 값을 받은 키에서 꺼내며 (Redis와 동일), 대기한 다른 키들은 다시 확인하지 않습니다.
+//   - 키 확인과 대기 등록을 같은 잠금 안에서 하므로 그 사이에 추가된 값을 놓치지 않습니다.
+//   - 시간 초과나 취소로 끝난 클라이언트는 이후에 추가된 값을 가져가지 않습니다.
+//
+// 매개변수:
+//   - keys: 확인할 키들의 목록
+//   - timeoutSeconds: 최대 대기 시간 (초, 0이면 무한 대기)
+//   - cancel: 닫히면 대기를 취소 (예: 클라이언트의 연결이 끊어짐, nil이면 취소하지 않음)
+//
+// 반환값:
+//   - *BLPopResult: 제거된 키와 값 (시간 초과나 취소로 끝났으면 nil)
+func (s *Store) BLPOPBlocking(keys []string, timeoutSeconds float64, cancel <-chan struct{}) *BLPopResult {
+	// 먼저 non-blocking으로 시도하고, 모두 비어 있으면 잠금을 푸기 전에 대기 등록
+	locked := s.lockKeys(keys)
+	if result := s.popFirstLocked(keys); result != nil {
+		unlockShards(locked)
+		s.signalModifiedKey(result.Key)
 		return result
 	}
-	
+	waiter := s.blocking.Enqueue(keys, BlockOnList, func(key string) (interface{}, bool) {
+		// Signal을 보낸 RPUSH/LPUSH가 key의 샤드 잠금을 잡고 있음
+		if value, ok := s.shardFor(key).lpopLocked(key, nil); ok {
+			return &BLPopResult{Key: key, Value: *value.(*string)}, true
+		}
+		return nil, false
+	})
+	unlockShards(locked)
+
 	// timeout 설정 (0이면 무한 대기)
 	var timeout time.Duration
-	var useTimeout bool
 	if timeoutSeconds > 0 {
 		timeout = time.Duration(timeoutSeconds * float64(time.Second))
-		useTimeout = true
-	}
-	
-	// 대기자 생성
-	waiter := &BlockingWaiter{
-		Keys:      keys,
-		Response:  make(chan *BLPopResult, 1),
-		Timeout:   timeout,
-		StartTime: time.Now(),
-	}
-	
-	// 모든 키에 대기자 등록
-	s.mu.Lock()
-	for _, key := range keys {
-		s.waiters[key] = append(s.waiters[key], waiter)
-	}
-	s.mu.Unlock()
-	
-	// 타임아웃 고루틴 시작 (timeout > 0인 경우만)
-	if useTimeout {
-		go func() {
-			time.Sleep(timeout)
-			select {
-			case s.waiterCleanup <- waiter:
-				// Cleanup initiated
-			default:
-				// Cleanup channel full, waiter might already be processed
-			}
-		}()
-	}
-	
-	// 결과를 기다림
-	if useTimeout {
-		// 타임아웃이 있는 경우
-		select {
-		case result = <-waiter.Response:
-			return result
-		case <-time.After(timeout + 100*time.Millisecond):
-			// 추가 타임아웃으로 안전장치
-			return nil
-		}
-	} else {
-		// 무한 대기 (timeout=0)
-		result = <-waiter.Response
-		return result
 	}
+
+	value, ok := waiter.Wait(timeout, cancel)
+	if !ok {
+		return nil
+	}
+	return value.(*BLPopResult)
+}
+
+// Blocking은 대기 명령어들이 함께 사용하는 BlockingManager를 반환합니다.
+// 저장소의 리스트 쓰기(RPUSH, LPUSH)는 BlockOnList 조건으로 Signal을 보냅니다.
+func (s *Store) Blocking() *BlockingManager {
+	return s.blocking
 }